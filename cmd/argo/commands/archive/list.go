@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -18,9 +19,11 @@ import (
 
 func NewListCommand() *cobra.Command {
 	var (
-		selector  string
-		output    = common.NewPrintWorkflowOutputValue("wide")
-		chunkSize int64
+		selector         string
+		outputParameters []string
+		owner            []string
+		output           = common.NewPrintWorkflowOutputValue("wide")
+		chunkSize        int64
 	)
 	command := &cobra.Command{
 		Use:   "list",
@@ -36,6 +39,12 @@ func NewListCommand() *cobra.Command {
 
 # List archived workflows that have both labels:
   argo archive list -l key1=value1,key2=value2
+
+# List archived workflows that output the "model-version" parameter with the value "v3", if it is indexed:
+  argo archive list --output-parameter model-version=v3
+
+# List archived workflows owned by the "ml-platform" team:
+  argo archive list --owner team=ml-platform
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
@@ -47,7 +56,14 @@ func NewListCommand() *cobra.Command {
 				return err
 			}
 			namespace := client.Namespace()
-			workflows, err := listArchivedWorkflows(ctx, serviceClient, namespace, selector, chunkSize)
+			var fieldSelector []string
+			for _, p := range outputParameters {
+				fieldSelector = append(fieldSelector, "status.outputs.parameters."+p)
+			}
+			for _, o := range owner {
+				fieldSelector = append(fieldSelector, "spec.owner."+o)
+			}
+			workflows, err := listArchivedWorkflows(ctx, serviceClient, namespace, selector, strings.Join(fieldSelector, ","), chunkSize)
 			if err != nil {
 				return err
 			}
@@ -56,13 +72,16 @@ func NewListCommand() *cobra.Command {
 	}
 	command.Flags().VarP(&output, "output", "o", "Output format. "+output.Usage())
 	command.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	command.Flags().StringArrayVar(&outputParameters, "output-parameter", []string{}, "Only list workflows whose output parameter has the value, e.g. --output-parameter name=value. The parameter must be in the persistence's archiveOutputParameters.")
+	command.Flags().StringArrayVar(&owner, "owner", []string{}, "Only list workflows whose owner has the value, e.g. --owner team=ml-platform. The field is one of team, email and slackChannel.")
 	command.Flags().Int64VarP(&chunkSize, "chunk-size", "", 0, "Return large lists in chunks rather than all at once. Pass 0 to disable.")
 	return command
 }
 
-func listArchivedWorkflows(ctx context.Context, serviceClient workflowarchivepkg.ArchivedWorkflowServiceClient, namespace string, labelSelector string, chunkSize int64) (wfv1.Workflows, error) {
+func listArchivedWorkflows(ctx context.Context, serviceClient workflowarchivepkg.ArchivedWorkflowServiceClient, namespace string, labelSelector string, fieldSelector string, chunkSize int64) (wfv1.Workflows, error) {
 	listOpts := &metav1.ListOptions{
 		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
 		Limit:         chunkSize,
 	}
 	var workflows wfv1.Workflows
@@ -0,0 +1,75 @@
+package artifact
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func NewDeleteCommand() *cobra.Command {
+	var (
+		nodeID       string
+		templateName string
+		all          bool
+	)
+	command := &cobra.Command{
+		Use:   "rm WORKFLOW [ARTIFACT...]",
+		Short: "delete output artifacts of a completed workflow",
+		Long:  "Delete output artifacts of a completed workflow. The artifacts are deleted by the artifact garbage collection of the controller, so they are deleted shortly after this returns, whatever their artifact GC strategy.",
+		Example: `# Delete an output artifact of a workflow:
+
+  argo artifact rm my-wf my-artifact
+
+# Delete all the output artifacts of a node of a workflow:
+
+  argo artifact rm my-wf --all --node-id my-wf-123
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := args[1:]
+			if len(names) == 0 && !all {
+				return fmt.Errorf("specify the artifacts to delete, or --all")
+			}
+			if len(names) > 0 && all {
+				return fmt.Errorf("cannot combine artifacts with --all")
+			}
+			namespace := client.Namespace()
+			wf, results, err := searchArtifacts(cmd.Context(), namespace, args[0], wfv1.ArtifactSearchQuery{NodeId: nodeID, TemplateName: templateName})
+			if err != nil {
+				return err
+			}
+			var toDelete wfv1.ArtifactSearchResults
+			for _, result := range results {
+				if all || slices.Contains(names, result.Name) {
+					toDelete = append(toDelete, result)
+				}
+			}
+			for _, name := range names {
+				if !slices.ContainsFunc(toDelete, func(result wfv1.ArtifactSearchResult) bool { return result.Name == name }) {
+					return fmt.Errorf("workflow %s has no output artifact %s", wf.Name, name)
+				}
+			}
+			for _, result := range toDelete {
+				if result.Deleted {
+					continue
+				}
+				resp, err := artifactServerRequest(http.MethodPost, fmt.Sprintf("/artifact-deletions/%s/%s/%s/%s", namespace, wf.Name, result.NodeID, result.Name), http.StatusAccepted)
+				if err != nil {
+					return fmt.Errorf("failed to delete artifact %s of node %s: %w", result.Name, result.NodeID, err)
+				}
+				_ = resp.Body.Close()
+				fmt.Printf("Artifact '%s' of node '%s' will be deleted\n", result.Name, result.NodeID)
+			}
+			return nil
+		},
+	}
+	command.Flags().StringVar(&nodeID, "node-id", "", "Only delete the artifacts of the node")
+	command.Flags().StringVar(&templateName, "template-name", "", "Only delete the artifacts of the nodes of the template")
+	command.Flags().BoolVar(&all, "all", false, "Delete all the output artifacts, of the node or template if specified")
+	return command
+}
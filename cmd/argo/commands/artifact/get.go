@@ -0,0 +1,84 @@
+package artifact
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func NewGetCommand() *cobra.Command {
+	var (
+		nodeID    string
+		outputDir string
+	)
+	command := &cobra.Command{
+		Use:   "get WORKFLOW ARTIFACT",
+		Short: "download an output artifact of a workflow",
+		Example: `# Download an output artifact of a workflow to the current directory:
+
+  argo artifact get my-wf my-artifact
+
+# Download the output artifact of a node, if more than one node has an artifact of the name:
+
+  argo artifact get my-wf my-artifact --node-id my-wf-123 --output-dir /tmp
+`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := client.Namespace()
+			wf, results, err := searchArtifacts(cmd.Context(), namespace, args[0], wfv1.ArtifactSearchQuery{NodeId: nodeID, ArtifactName: args[1]})
+			if err != nil {
+				return err
+			}
+			switch len(results) {
+			case 0:
+				return fmt.Errorf("workflow %s has no output artifact %s", wf.Name, args[1])
+			case 1:
+			default:
+				nodeIDs := make([]string, len(results))
+				for i, result := range results {
+					nodeIDs[i] = result.NodeID
+				}
+				return fmt.Errorf("nodes %s have output artifact %s, choose one with --node-id", strings.Join(nodeIDs, ", "), args[1])
+			}
+			result := results[0]
+			if result.Deleted {
+				return fmt.Errorf("output artifact %s of node %s has been deleted", result.Name, result.NodeID)
+			}
+			key, err := result.GetKey()
+			if err != nil {
+				return fmt.Errorf("error getting key for artifact: %w", err)
+			}
+			resp, err := artifactServerRequest(http.MethodGet, fmt.Sprintf("/artifacts/%s/%s/%s/%s", namespace, wf.Name, result.NodeID, result.Name), http.StatusOK)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+				return fmt.Errorf("failed to create folder path: %w", err)
+			}
+			filePath := filepath.Join(outputDir, path.Base(key))
+			f, err := os.Create(filePath)
+			if err != nil {
+				return fmt.Errorf("creating file failed: %w", err)
+			}
+			defer f.Close()
+			if _, err := io.Copy(f, resp.Body); err != nil {
+				return fmt.Errorf("copying file contents failed: %w", err)
+			}
+			fmt.Printf("Created %q\n", filePath)
+			return nil
+		},
+	}
+	command.Flags().StringVar(&nodeID, "node-id", "", "ID of the node whose artifact to download")
+	command.Flags().StringVar(&outputDir, "output-dir", ".", "Directory to download the artifact to, as the file name of its key")
+	return command
+}
@@ -0,0 +1,69 @@
+package artifact
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func NewListCommand() *cobra.Command {
+	var (
+		query  wfv1.ArtifactSearchQuery
+		output = common.EnumFlagValue{AllowedValues: []string{"wide", "name"}}
+	)
+	command := &cobra.Command{
+		Use:   "ls WORKFLOW",
+		Short: "list the output artifacts of a workflow",
+		Example: `# List the output artifacts of a workflow:
+
+  argo artifact ls my-wf
+
+# List the output artifacts of a node of a workflow:
+
+  argo artifact ls my-wf --node-id my-wf-123
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wf, results, err := searchArtifacts(cmd.Context(), client.Namespace(), args[0], query)
+			if err != nil {
+				return err
+			}
+			switch output.String() {
+			case "", "wide":
+				printArtifactTable(wf, results)
+			case "name":
+				for _, result := range results {
+					fmt.Println(result.Name)
+				}
+			default:
+				return fmt.Errorf("Unknown output mode: %s", output.String())
+			}
+			return nil
+		},
+	}
+	command.Flags().StringVar(&query.NodeId, "node-id", "", "Only list the artifacts of the node")
+	command.Flags().StringVar(&query.TemplateName, "template-name", "", "Only list the artifacts of the nodes of the template")
+	command.Flags().VarP(&output, "output", "o", "Output format. "+output.Usage())
+	return command
+}
+
+func printArtifactTable(wf *wfv1.Workflow, results wfv1.ArtifactSearchResults) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NODE ID\tTEMPLATE\tNAME\tKEY\tDELETED")
+	for _, result := range results {
+		templateName := ""
+		if node, err := wf.Status.Nodes.Get(result.NodeID); err == nil {
+			templateName = wfutil.GetTemplateFromNode(*node)
+		}
+		key, _ := result.GetKey()
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", result.NodeID, templateName, result.Name, key, result.Deleted)
+	}
+	_ = w.Flush()
+}
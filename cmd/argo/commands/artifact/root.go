@@ -0,0 +1,19 @@
+package artifact
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewArtifactCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "artifact",
+		Short: "manage the output artifacts of workflows",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	command.AddCommand(NewListCommand())
+	command.AddCommand(NewGetCommand())
+	command.AddCommand(NewDeleteCommand())
+	return command
+}
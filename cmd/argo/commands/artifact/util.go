@@ -0,0 +1,72 @@
+package artifact
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// searchArtifacts returns the output artifacts of the pods of the workflow that match the query, ordered by node and
+// name
+func searchArtifacts(ctx context.Context, namespace, workflowName string, query wfv1.ArtifactSearchQuery) (*wfv1.Workflow, wfv1.ArtifactSearchResults, error) {
+	ctx, apiClient, err := client.NewAPIClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	wf, err := apiClient.NewWorkflowServiceClient().GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
+		Name:      workflowName,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	// artifacts are saved by pods, other nodes only refer to them
+	query.NodeTypes = map[wfv1.NodeType]bool{wfv1.NodeTypePod: true}
+	results := wf.SearchArtifacts(&query)
+	slices.SortFunc(results, func(a, b wfv1.ArtifactSearchResult) int {
+		if c := strings.Compare(a.NodeID, b.NodeID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return wf, results, nil
+}
+
+// artifactServerRequest sends a request to the artifact server of the Argo Server, e.g. for
+// /artifacts/{namespace}/{workflowName}/{nodeID}/{artifactName}, and returns the response if it has the status
+func artifactServerRequest(method, path string, status int) (*http.Response, error) {
+	request, err := http.NewRequest(method, client.ArgoServerOpts.GetURL()+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	authString, err := client.GetAuthString()
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", authString)
+	c := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: client.ArgoServerOpts.InsecureSkipVerify,
+			},
+		},
+	}
+	resp, err := c.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("request failed with: %w", err)
+	}
+	if resp.StatusCode != status {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("request failed %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return resp, nil
+}
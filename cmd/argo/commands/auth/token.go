@@ -9,10 +9,15 @@ import (
 )
 
 func NewTokenCommand() *cobra.Command {
-	return &cobra.Command{
+	command := &cobra.Command{
 		Use:   "token",
-		Short: "Print the auth token",
-		Args:  cobra.NoArgs,
+		Short: "Print the auth token, or manage API tokens",
+		Long: `Print the auth token, or manage API tokens.
+
+API tokens are short-lived tokens of a service account, for systems such as CI that cannot log in interactively, that
+can be listed, rotated and revoked. They are managed through the Argo Server if one is configured, and through the
+Kubernetes API otherwise.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			authString, err := client.GetAuthString()
 			if err != nil {
@@ -22,4 +27,9 @@ func NewTokenCommand() *cobra.Command {
 			return nil
 		},
 	}
+	command.AddCommand(NewTokenCreateCommand())
+	command.AddCommand(NewTokenListCommand())
+	command.AddCommand(NewTokenRotateCommand())
+	command.AddCommand(NewTokenRevokeCommand())
+	return command
 }
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
+)
+
+func NewTokenCreateCommand() *cobra.Command {
+	var (
+		expiration  time.Duration
+		description string
+	)
+	command := &cobra.Command{
+		Use:   "create SERVICE_ACCOUNT",
+		Short: "create an API token of a service account, and print it",
+		Example: `# Create a token of the ci service account that is valid for a week:
+
+  argo auth token create ci --expiration 168h --description "GitHub Actions"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			serviceClient, err := apiClient.NewAPITokenServiceClient()
+			if err != nil {
+				return err
+			}
+			token, err := serviceClient.CreateAPIToken(ctx, &apitokenpkg.CreateAPITokenRequest{
+				Namespace:         client.Namespace(),
+				ServiceAccount:    args[0],
+				Description:       description,
+				ExpirationSeconds: int64(expiration.Seconds()),
+			})
+			if err != nil {
+				return err
+			}
+			printToken(token)
+			return nil
+		},
+	}
+	command.Flags().DurationVar(&expiration, "expiration", 24*time.Hour, "How long the token is valid for, at least 10m")
+	command.Flags().StringVar(&description, "description", "", "What the token is for")
+	return command
+}
+
+// printToken prints the token, and where it can be revoked from to stderr, so that the output can be captured
+func printToken(token *apitokenpkg.APIToken) {
+	_, _ = fmt.Fprintf(os.Stderr, "API token %s of service account %s expires at %s\n", token.Name, token.ServiceAccount, token.ExpirationTimestamp.Format(time.RFC3339))
+	fmt.Println("Bearer " + token.Token)
+}
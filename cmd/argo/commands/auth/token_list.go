@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
+	"github.com/argoproj/argo-workflows/v3/util/humanize"
+)
+
+func NewTokenListCommand() *cobra.Command {
+	var (
+		serviceAccount string
+		output         = common.EnumFlagValue{AllowedValues: []string{"wide", "name"}}
+	)
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "list API tokens",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			serviceClient, err := apiClient.NewAPITokenServiceClient()
+			if err != nil {
+				return err
+			}
+			list, err := serviceClient.ListAPITokens(ctx, &apitokenpkg.ListAPITokensRequest{
+				Namespace:      client.Namespace(),
+				ServiceAccount: serviceAccount,
+			})
+			if err != nil {
+				return err
+			}
+			switch output.String() {
+			case "", "wide":
+				printTokenTable(list.Items)
+			case "name":
+				for _, token := range list.Items {
+					fmt.Println(token.Name)
+				}
+			default:
+				return fmt.Errorf("Unknown output mode: %s", output.String())
+			}
+			return nil
+		},
+	}
+	command.Flags().StringVar(&serviceAccount, "service-account", "", "Only list the tokens of the service account")
+	command.Flags().VarP(&output, "output", "o", "Output format. "+output.Usage())
+	return command
+}
+
+func printTokenTable(tokens []*apitokenpkg.APIToken) {
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSERVICE ACCOUNT\tCREATOR\tAGE\tEXPIRES\tDESCRIPTION")
+	for _, token := range tokens {
+		expires := "expired"
+		if token.ExpirationTimestamp.After(now) {
+			expires = humanize.RelativeDurationShort(now, token.ExpirationTimestamp.Time)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", token.Name, token.ServiceAccount, token.Creator, humanize.RelativeDurationShort(token.CreationTimestamp.Time, now), expires, token.Description)
+	}
+	_ = w.Flush()
+}
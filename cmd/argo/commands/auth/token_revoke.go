@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
+)
+
+func NewTokenRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke TOKEN...",
+		Short: "revoke API tokens",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			serviceClient, err := apiClient.NewAPITokenServiceClient()
+			if err != nil {
+				return err
+			}
+			for _, name := range args {
+				if _, err := serviceClient.RevokeAPIToken(ctx, &apitokenpkg.RevokeAPITokenRequest{Namespace: client.Namespace(), Name: name}); err != nil {
+					return err
+				}
+				fmt.Printf("API token %s revoked\n", name)
+			}
+			return nil
+		},
+	}
+}
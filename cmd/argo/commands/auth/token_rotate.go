@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
+)
+
+func NewTokenRotateCommand() *cobra.Command {
+	var expiration time.Duration
+	command := &cobra.Command{
+		Use:   "rotate TOKEN",
+		Short: "replace an API token with a new token of the same service account, and print it",
+		Long:  "Replace an API token with a new token of the same service account, and print it. The old token is revoked.",
+		Example: `# Rotate a token, and store the new token in a GitHub Actions secret:
+
+  argo auth token rotate ci-api-token-x7k2p | gh secret set ARGO_TOKEN`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			serviceClient, err := apiClient.NewAPITokenServiceClient()
+			if err != nil {
+				return err
+			}
+			token, err := serviceClient.RotateAPIToken(ctx, &apitokenpkg.RotateAPITokenRequest{
+				Namespace:         client.Namespace(),
+				Name:              args[0],
+				ExpirationSeconds: int64(expiration.Seconds()),
+			})
+			if err != nil {
+				return err
+			}
+			printToken(token)
+			return nil
+		},
+	}
+	command.Flags().DurationVar(&expiration, "expiration", 0, "How long the new token is valid for. Defaults to as long as the old token was.")
+	return command
+}
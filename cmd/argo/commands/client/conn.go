@@ -50,6 +50,8 @@ func AddAPIClientFlagsToCmd(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringSliceVarP(&ArgoServerOpts.Headers, "header", "H", []string{}, "Sets additional header to all requests made by Argo CLI. (Can be repeated multiple times to add multiple headers, also supports comma separated headers) Used only when either ARGO_HTTP1 or --argo-http1 is set to true.")
 	// "-e" for encrypted - like zip
 	cmd.PersistentFlags().BoolVarP(&ArgoServerOpts.Secure, "secure", "e", os.Getenv("ARGO_SECURE") != "false", "Whether or not the server is using TLS with the Argo Server. Defaults to the ARGO_SECURE environment variable.")
+	cmd.PersistentFlags().StringVar(&ArgoServerOpts.ClientCertificate, "argo-client-certificate", os.Getenv("ARGO_CLIENT_CERTIFICATE"), "File of the certificate to present to the Argo Server, for the client-cert auth mode. Defaults to the ARGO_CLIENT_CERTIFICATE environment variable.")
+	cmd.PersistentFlags().StringVar(&ArgoServerOpts.ClientKey, "argo-client-key", os.Getenv("ARGO_CLIENT_KEY"), "File of the key of the client certificate. Defaults to the ARGO_CLIENT_KEY environment variable.")
 	// "-k" like curl
 	cmd.PersistentFlags().BoolVarP(&ArgoServerOpts.InsecureSkipVerify, "insecure-skip-verify", "k", os.Getenv("ARGO_INSECURE_SKIP_VERIFY") == "true", "If true, the Argo Server's certificate will not be checked for validity. This will make your HTTPS connections insecure. Defaults to the ARGO_INSECURE_SKIP_VERIFY environment variable.")
 }
@@ -39,7 +39,7 @@ func testPrintNodeImpl(t *testing.T, expected string, node wfv1.NodeStatus, getA
 	w := tabwriter.NewWriter(&result, 0, 8, 1, '\t', 0)
 	filtered, _ := filterNode(node, getArgs)
 	if !filtered {
-		printNode(w, node, workflowName, "", getArgs, util.GetPodNameVersion())
+		printNode(w, node, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: workflowName}}, "", getArgs)
 	}
 	err := w.Flush()
 	require.NoError(t, err)
@@ -20,6 +20,7 @@ type CliSubmitOpts struct {
 	GetArgs       GetFlags
 	ScheduledTime string   // --scheduled-time
 	Parameters    []string // --parameter
+	AsyncDurable  bool     // --async-durable
 }
 
 func NewCliSubmitOpts() CliSubmitOpts {
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -17,6 +19,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/artifacts"
 	wfutil "github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
@@ -27,10 +30,13 @@ func NewCpCommand() *cobra.Command {
 		templateName string // --template-name
 		artifactName string // --artifact-name
 		customPath   string // --path
+		toRepository string // --to-artifact-repository-ref
+		toWorkflow   string // --to-workflow
+		key          string // --key
 	)
 	command := &cobra.Command{
-		Use:   "cp my-wf output-directory ...",
-		Short: "copy artifacts from workflow",
+		Use:   "cp my-wf [output-directory] ...",
+		Short: "copy artifacts from workflow to a local directory, an artifact repository, or another workflow",
 		Example: `# Copy a workflow's artifacts to a local output directory:
 
   argo cp my-wf output-directory
@@ -38,15 +44,29 @@ func NewCpCommand() *cobra.Command {
 # Copy artifacts from a specific node in a workflow to a local output directory:
 
   argo cp my-wf output-directory --node-id=my-wf-node-id-123
+
+# Copy a workflow's artifacts to the artifact repository of the "my-key" key of the "my-repositories" config map, without downloading them:
+
+  argo cp my-wf --to-artifact-repository-ref my-repositories/my-key --key "archive/{workflowName}/{artifactName}.tgz"
+
+# Copy an artifact to the artifact repository of another workflow:
+
+  argo cp my-wf --artifact-name my-artifact --to-workflow my-other-wf
 `,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) != 2 {
+			remote := toRepository != "" || toWorkflow != ""
+			if toRepository != "" && toWorkflow != "" {
+				return fmt.Errorf("cannot combine --to-artifact-repository-ref with --to-workflow")
+			}
+			if key != "" && !remote {
+				return fmt.Errorf("--key requires --to-artifact-repository-ref or --to-workflow")
+			}
+			if (remote && len(args) != 1) || (!remote && len(args) != 2) {
 				cmd.HelpFunc()(cmd, args)
 				return fmt.Errorf("incorrect number of arguments")
 			}
 			workflowName := args[0]
-			outputDir := args[1]
 
 			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
 			if err != nil {
@@ -81,25 +101,38 @@ func NewCpCommand() *cobra.Command {
 			}
 
 			for _, artifact := range artifactSearchResults {
-				customPath := filepath.Join(outputDir, customPath)
 				nodeInfo := workflow.Status.Nodes.Find(func(n v1alpha1.NodeStatus) bool { return n.ID == artifact.NodeID })
 				if nodeInfo == nil {
 					return fmt.Errorf("could not get node status for node ID %s", artifact.NodeID)
 				}
-				customPath = strings.Replace(customPath, "{templateName}", wfutil.GetTemplateFromNode(*nodeInfo), 1)
-				customPath = strings.Replace(customPath, "{namespace}", namespace, 1)
-				customPath = strings.Replace(customPath, "{workflowName}", workflowName, 1)
-				customPath = strings.Replace(customPath, "{nodeId}", artifact.NodeID, 1)
-				customPath = strings.Replace(customPath, "{artifactName}", artifact.Name, 1)
+				expand := func(p string) string {
+					p = strings.Replace(p, "{templateName}", wfutil.GetTemplateFromNode(*nodeInfo), 1)
+					p = strings.Replace(p, "{namespace}", namespace, 1)
+					p = strings.Replace(p, "{workflowName}", workflowName, 1)
+					p = strings.Replace(p, "{nodeId}", artifact.NodeID, 1)
+					return strings.Replace(p, "{artifactName}", artifact.Name, 1)
+				}
+				if remote {
+					req := &artifacts.ArtifactCopyRequest{Workflow: toWorkflow, Key: expand(key)}
+					if toRepository != "" {
+						req.ArtifactRepositoryRef = parseArtifactRepositoryRef(toRepository)
+					}
+					err = copyArtifact(namespace, workflowName, artifact.NodeID, artifact.Name, req, c, client.ArgoServerOpts)
+					if err != nil {
+						return fmt.Errorf("failed to copy artifact %s of node %s: %w", artifact.Name, artifact.NodeID, err)
+					}
+					continue
+				}
+				customPath := expand(filepath.Join(args[1], customPath))
 				err = os.MkdirAll(customPath, os.ModePerm)
 				if err != nil {
 					return fmt.Errorf("failed to create folder path: %w", err)
 				}
-				key, err := artifact.GetKey()
+				artifactKey, err := artifact.GetKey()
 				if err != nil {
 					return fmt.Errorf("error getting key for artifact: %w", err)
 				}
-				err = getAndStoreArtifactData(namespace, workflowName, artifact.NodeID, artifact.Name, path.Base(key), customPath, c, client.ArgoServerOpts)
+				err = getAndStoreArtifactData(namespace, workflowName, artifact.NodeID, artifact.Name, path.Base(artifactKey), customPath, c, client.ArgoServerOpts)
 				if err != nil {
 					return fmt.Errorf("failed to get and store artifact data: %w", err)
 				}
@@ -111,10 +144,57 @@ func NewCpCommand() *cobra.Command {
 	command.Flags().StringVar(&nodeID, "node-id", "", "id of node in workflow")
 	command.Flags().StringVar(&templateName, "template-name", "", "name of template in workflow")
 	command.Flags().StringVar(&artifactName, "artifact-name", "", "name of output artifact in workflow")
+	command.Flags().StringVar(&toRepository, "to-artifact-repository-ref", "", "copy the artifacts to the artifact repository of a config map and key, e.g. my-repositories/my-key, or the default key of a config map, e.g. my-repositories, rather than to a local directory")
+	command.Flags().StringVar(&toWorkflow, "to-workflow", "", "copy the artifacts to the artifact repository of another workflow of the namespace, rather than to a local directory")
+	command.Flags().StringVar(&key, "key", "", "the key to copy the artifacts to, with the same variables as --path; by default, the key of the artifact, or its file name under the name of the other workflow")
 	command.Flags().StringVar(&customPath, "path", "{namespace}/{workflowName}/{nodeId}/outputs/{artifactName}", "use variables {workflowName}, {nodeId}, {templateName}, {artifactName}, and {namespace} to create a customized path to store the artifacts; example: {workflowName}/{templateName}/{artifactName}")
 	return command
 }
 
+// parseArtifactRepositoryRef parses a config map and key, e.g. my-repositories/my-key, or a config map, e.g.
+// my-repositories, whose default key is used
+func parseArtifactRepositoryRef(s string) *v1alpha1.ArtifactRepositoryRef {
+	configMap, key, _ := strings.Cut(s, "/")
+	return &v1alpha1.ArtifactRepositoryRef{ConfigMap: configMap, Key: key}
+}
+
+// copyArtifact has the Argo Server copy the output artifact, without downloading it
+func copyArtifact(namespace, workflowName, nodeID, artifactName string, req *artifacts.ArtifactCopyRequest, c *http.Client, argoServerOpts apiclient.ArgoServerOpts) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest("POST", fmt.Sprintf("%s/artifact-copies/%s/%s/%s/%s", argoServerOpts.GetURL(), namespace, workflowName, nodeID, artifactName), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	authString, err := client.GetAuthString()
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", authString)
+	request.Header.Set("Content-Type", "application/json")
+	resp, err := c.Do(request)
+	if err != nil {
+		return fmt.Errorf("request failed with: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("request failed %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	out := &artifacts.ArtifactCopyResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	copyKey, err := out.Artifact.GetKey()
+	if err != nil {
+		return err
+	}
+	log.Printf("Copied %q to %q", artifactName, copyKey)
+	return nil
+}
+
 func getAndStoreArtifactData(namespace string, workflowName string, nodeID string, artifactName string, fileName string, customPath string, c *http.Client, argoServerOpts apiclient.ArgoServerOpts) error {
 	request, err := http.NewRequest("GET", fmt.Sprintf("%s/artifacts/%s/%s/%s/%s", argoServerOpts.GetURL(), namespace, workflowName, nodeID, artifactName), nil)
 	if err != nil {
@@ -14,7 +14,7 @@ import (
 func NewLintCommand() *cobra.Command {
 	var (
 		strict bool
-		output = common.EnumFlagValue{AllowedValues: []string{"pretty", "simple"}, Value: "pretty"}
+		output = common.EnumFlagValue{AllowedValues: []string{"pretty", "simple", "json"}, Value: "pretty"}
 	)
 
 	command := &cobra.Command{
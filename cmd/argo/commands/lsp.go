@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/lint"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/lsp"
+)
+
+func NewLSPCommand() *cobra.Command {
+	var (
+		strict  bool
+		offline bool
+	)
+
+	command := &cobra.Command{
+		Use:   "lsp [PATH...]",
+		Short: "run a language server for editors, which validates manifests and completes templates and variables",
+		Long: `Run a language server on stdin and stdout, which validates the manifests open in an editor like "argo lint",
+and completes the names of templates and the variables in scope of expressions.
+
+When offline, templates referenced by the manifests are resolved from the paths.`,
+		Example: `
+# Run the language server, resolving referenced templates from the manifests of the workspace:
+
+  argo lsp ./manifests
+
+# Run the language server, validating against the Argo Server:
+
+  argo lsp --offline=false`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client.Offline = offline
+			client.OfflineFiles = args
+			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			clients, err := lint.GetLintClients(apiClient, allKinds)
+			if err != nil {
+				return err
+			}
+			opts := &lint.LintOptions{
+				Strict:           strict,
+				DefaultNamespace: client.Namespace(),
+				ServiceClients:   clients,
+			}
+			return lsp.NewServer(lsp.Linter(opts)).Serve(ctx, os.Stdin, os.Stdout)
+		},
+	}
+
+	command.Flags().BoolVar(&strict, "strict", true, "Perform strict workflow validation")
+	command.Flags().BoolVar(&offline, "offline", true, "Validate manifests offline, resolving the templates they reference from the paths")
+
+	return command
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,6 +13,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
@@ -21,6 +23,10 @@ type retryOps struct {
 	namespace         string // --namespace
 	labelSelector     string // --selector
 	fieldSelector     string // --field-selector
+	patchImage        string // --patch-image
+	patchSourceFile   string // --patch-source-file
+	allFailed         bool   // --all-failed
+	parallelism       int32  // --parallelism
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -31,6 +37,11 @@ func (o *retryOps) hasSelector() bool {
 	return false
 }
 
+// isPatched returns true if the CLI arguments patch the script of the selected node
+func (o *retryOps) isPatched() bool {
+	return o.patchImage != "" || o.patchSourceFile != ""
+}
+
 func NewRetryCommand() *cobra.Command {
 	var (
 		cliSubmitOpts = common.NewCliSubmitOpts()
@@ -52,6 +63,10 @@ func NewRetryCommand() *cobra.Command {
 
   argo retry -l workflows.argoproj.io/test=true
 
+# Retry every failed workflow that matches a label selector, e.g. after an outage, 20 at a time:
+
+  argo retry -l workflows.argoproj.io/workflow-template=my-wftmpl --all-failed --parallelism 20
+
 # Retry multiple workflows by field selector:
 
   argo retry --field-selector metadata.namespace=argo
@@ -74,11 +89,23 @@ func NewRetryCommand() *cobra.Command {
 
 # Restart node with id 5 on successful workflow, using node-field-selector
   argo retry my-wf --restart-successful --node-field-selector id=5
+
+# Re-run the failed script node with id 5 with an edited script, to debug it:
+  argo retry my-wf --node-field-selector id=5 --patch-source-file script.py
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 && !retryOpts.hasSelector() {
 				return errors.New("requires either node field selector or workflow")
 			}
+			if retryOpts.isPatched() && (len(args) != 1 || retryOpts.hasSelector() || retryOpts.nodeFieldSelector == "") {
+				return errors.New("--patch-image and --patch-source-file require a single workflow and --node-field-selector")
+			}
+			if retryOpts.allFailed && (len(args) != 0 || retryOpts.labelSelector == "" || retryOpts.fieldSelector != "") {
+				return errors.New("--all-failed requires --selector, and cannot be combined with workflows or --field-selector")
+			}
+			if retryOpts.parallelism != 0 && !retryOpts.allFailed {
+				return errors.New("--parallelism requires --all-failed")
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -89,6 +116,13 @@ func NewRetryCommand() *cobra.Command {
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			retryOpts.namespace = client.Namespace()
 
+			if retryOpts.allFailed {
+				batchClient, err := apiClient.NewWorkflowBatchServiceClient()
+				if err != nil {
+					return err
+				}
+				return retryFailedWorkflows(ctx, batchClient, retryOpts, cliSubmitOpts)
+			}
 			return retryWorkflows(ctx, serviceClient, retryOpts, cliSubmitOpts, args)
 		},
 	}
@@ -99,7 +133,11 @@ func NewRetryCommand() *cobra.Command {
 	command.Flags().BoolVar(&cliSubmitOpts.Log, "log", false, "log the workflow until it completes")
 	command.Flags().BoolVar(&retryOpts.restartSuccessful, "restart-successful", false, "indicates to restart successful nodes matching the --node-field-selector")
 	command.Flags().StringVar(&retryOpts.nodeFieldSelector, "node-field-selector", "", "selector of nodes to reset, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
+	command.Flags().StringVar(&retryOpts.patchImage, "patch-image", "", "image to re-run the script node selected by --node-field-selector with")
+	command.Flags().StringVar(&retryOpts.patchSourceFile, "patch-source-file", "", "file of the script source to re-run the script node selected by --node-field-selector with")
 	command.Flags().StringVarP(&retryOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	command.Flags().BoolVar(&retryOpts.allFailed, "all-failed", false, "retry every failed or errored workflow that matches --selector in one request")
+	command.Flags().Int32Var(&retryOpts.parallelism, "parallelism", 0, "with --all-failed, how many workflows are retried at once, 10 by default")
 	command.Flags().StringVar(&retryOpts.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	return command
 }
@@ -110,6 +148,14 @@ func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServi
 	if err != nil {
 		return fmt.Errorf("unable to parse node field selector '%s': %s", retryOpts.nodeFieldSelector, err)
 	}
+	var patchSource string
+	if retryOpts.patchSourceFile != "" {
+		data, err := os.ReadFile(retryOpts.patchSourceFile)
+		if err != nil {
+			return fmt.Errorf("unable to read patch source file '%s': %w", retryOpts.patchSourceFile, err)
+		}
+		patchSource = string(data)
+	}
 	var wfs wfv1.Workflows
 	if retryOpts.hasSelector() {
 		wfs, err = listWorkflows(ctx, serviceClient, listFlags{
@@ -146,6 +192,8 @@ func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServi
 			RestartSuccessful: retryOpts.restartSuccessful,
 			NodeFieldSelector: selector.String(),
 			Parameters:        cliSubmitOpts.Parameters,
+			PatchImage:        retryOpts.patchImage,
+			PatchSource:       patchSource,
 		})
 		if err != nil {
 			return err
@@ -160,3 +208,39 @@ func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServi
 	}
 	return nil
 }
+
+// retryFailedWorkflows retries every failed workflow that matches the label selector, and reports the result of each
+func retryFailedWorkflows(ctx context.Context, batchClient workflowbatchpkg.WorkflowBatchServiceClient, retryOpts retryOps, cliSubmitOpts common.CliSubmitOpts) error {
+	selector, err := fields.ParseSelector(retryOpts.nodeFieldSelector)
+	if err != nil {
+		return fmt.Errorf("unable to parse node field selector '%s': %s", retryOpts.nodeFieldSelector, err)
+	}
+	resp, err := batchClient.RetryWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchRetryRequest{
+		Namespace:         retryOpts.namespace,
+		LabelSelector:     retryOpts.labelSelector,
+		Parallelism:       retryOpts.parallelism,
+		RestartSuccessful: retryOpts.restartSuccessful,
+		NodeFieldSelector: selector.String(),
+		Parameters:        cliSubmitOpts.Parameters,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to retry workflows: %v", err)
+	}
+	failed := 0
+	for i, item := range resp.Items {
+		if item.Workflow == nil || item.Error != "" {
+			failed++
+			fmt.Fprintf(os.Stderr, "[%d/%d] Failed to retry workflow %s: %s\n", i+1, len(resp.Items), item.Name, item.Error)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "[%d/%d] Retried workflow %s\n", i+1, len(resp.Items), item.Name)
+		if err = printWorkflow(item.Workflow, common.GetFlags{Output: cliSubmitOpts.Output}); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Retried %d of %d failed workflows\n", len(resp.Items)-failed, len(resp.Items))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d workflows were not retried", failed, len(resp.Items))
+	}
+	return nil
+}
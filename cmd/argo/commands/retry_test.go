@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
@@ -147,3 +150,40 @@ func Test_retryWorkflows(t *testing.T) {
 		require.Errorf(t, err, "mock error")
 	})
 }
+
+type fakeWorkflowBatchServiceClient struct {
+	workflowbatchpkg.WorkflowBatchServiceClient
+	req  *workflowbatchpkg.WorkflowBatchRetryRequest
+	resp *workflowbatchpkg.WorkflowBatchRetryResponse
+}
+
+func (c *fakeWorkflowBatchServiceClient) RetryWorkflowBatch(_ context.Context, req *workflowbatchpkg.WorkflowBatchRetryRequest, _ ...grpc.CallOption) (*workflowbatchpkg.WorkflowBatchRetryResponse, error) {
+	c.req = req
+	return c.resp, nil
+}
+
+func Test_retryFailedWorkflows(t *testing.T) {
+	retryOpts := retryOps{
+		namespace:     "argo",
+		labelSelector: "custom-label=true",
+		allFailed:     true,
+		parallelism:   20,
+	}
+	t.Run("Retried", func(t *testing.T) {
+		c := &fakeWorkflowBatchServiceClient{resp: &workflowbatchpkg.WorkflowBatchRetryResponse{Items: []*workflowbatchpkg.WorkflowBatchRetryResult{
+			{Name: "foo", Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}},
+			{Name: "bar", Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "bar"}}},
+		}}}
+		err := retryFailedWorkflows(context.Background(), c, retryOpts, common.CliSubmitOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, &workflowbatchpkg.WorkflowBatchRetryRequest{Namespace: "argo", LabelSelector: "custom-label=true", Parallelism: 20}, c.req)
+	})
+	t.Run("NotRetried", func(t *testing.T) {
+		c := &fakeWorkflowBatchServiceClient{resp: &workflowbatchpkg.WorkflowBatchRetryResponse{Items: []*workflowbatchpkg.WorkflowBatchRetryResult{
+			{Name: "foo", Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}},
+			{Name: "bar", Error: "mock error"},
+		}}}
+		err := retryFailedWorkflows(context.Background(), c, retryOpts, common.CliSubmitOpts{})
+		require.EqualError(t, err, "1 of 2 workflows were not retried")
+	})
+}
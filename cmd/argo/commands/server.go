@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
@@ -26,6 +28,7 @@ import (
 	wfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-workflows/v3/server/apiserver"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/devartifacts"
 	"github.com/argoproj/argo-workflows/v3/server/types"
 	"github.com/argoproj/argo-workflows/v3/util/cmd"
 	"github.com/argoproj/argo-workflows/v3/util/help"
@@ -56,6 +59,12 @@ func NewServerCommand() *cobra.Command {
 		kubeAPIBurst             int
 		allowedLinkProtocol      []string
 		logFormat                string // --log-format
+		dev                      bool   // --dev
+		devArtifactsDir          string
+		devArtifactsPort         int
+		devArtifactsBucket       string
+		devArtifactsAccessKey    string
+		devArtifactsSecretKey    string
 	)
 
 	command := cobra.Command{
@@ -183,6 +192,22 @@ See %s`, help.ArgoServer()),
 				return err
 			}
 
+			if dev {
+				log.Warn("You are running a development artifact store. It is not for production use.")
+				store, err := devartifacts.New(devArtifactsDir, devArtifactsAccessKey, devArtifactsSecretKey)
+				if err != nil {
+					return err
+				}
+				if err := store.CreateBucket(devArtifactsBucket); err != nil {
+					return err
+				}
+				go func() {
+					if err := store.Run(ctx, devArtifactsPort); err != nil {
+						log.WithError(err).Fatal("Dev artifact store failed")
+					}
+				}()
+			}
+
 			server.Run(ctx, port, browserOpenFunc)
 			return nil
 		},
@@ -194,7 +219,7 @@ See %s`, help.ArgoServer()),
 	command.Flags().BoolVarP(&secure, "secure", "e", true, "Whether or not we should listen on TLS.")
 	command.Flags().StringVar(&tlsCertificateSecretName, "tls-certificate-secret-name", "", "The name of a Kubernetes secret that contains the server certificates")
 	command.Flags().BoolVar(&hsts, "hsts", true, "Whether or not we should add a HTTP Secure Transport Security header. This only has effect if secure is enabled.")
-	command.Flags().StringArrayVar(&authModes, "auth-mode", []string{"client"}, "API server authentication mode. Any 1 or more length permutation of: client,server,sso")
+	command.Flags().StringArrayVar(&authModes, "auth-mode", []string{"client"}, "API server authentication mode. Any 1 or more length permutation of: client,server,sso,client-cert")
 	command.Flags().StringVar(&configMap, "configmap", common.ConfigMapName, "Name of K8s configmap to retrieve workflow controller configuration")
 	command.Flags().BoolVar(&namespaced, "namespaced", false, "run as namespaced mode")
 	command.Flags().StringVar(&managedNamespace, "managed-namespace", "", "namespace that watches, default to the installation namespace")
@@ -209,6 +234,12 @@ See %s`, help.ArgoServer()),
 	command.Flags().StringVar(&logFormat, "log-format", "text", "The formatter to use for logs. One of: text|json")
 	command.Flags().Float32Var(&kubeAPIQPS, "kube-api-qps", 20.0, "QPS to use while talking with kube-apiserver.")
 	command.Flags().IntVar(&kubeAPIBurst, "kube-api-burst", 30, "Burst to use while talking with kube-apiserver.")
+	command.Flags().BoolVar(&dev, "dev", false, "Run a built-in, S3-compatible artifact store that keeps artifacts on the local filesystem. For development and testing only.")
+	command.Flags().StringVar(&devArtifactsDir, "dev-artifacts-dir", filepath.Join(os.TempDir(), "argo-artifacts"), "Directory that the development artifact store keeps artifacts in. Only used with --dev.")
+	command.Flags().IntVar(&devArtifactsPort, "dev-artifacts-port", 9000, "Port that the development artifact store listens on. Only used with --dev.")
+	command.Flags().StringVar(&devArtifactsBucket, "dev-artifacts-bucket", "my-bucket", "Bucket that the development artifact store creates on start. Only used with --dev.")
+	command.Flags().StringVar(&devArtifactsAccessKey, "dev-artifacts-access-key", "admin", "Access key of the development artifact store. Only used with --dev.")
+	command.Flags().StringVar(&devArtifactsSecretKey, "dev-artifacts-secret-key", "password", "Secret key of the development artifact store. Only used with --dev.")
 
 	// set-up env vars for the CLI such that ARGO_* env vars can be used instead of flags
 	viper.AutomaticEnv()
@@ -21,6 +21,7 @@ type stopOps struct {
 	labelSelector     string // --selector
 	fieldSelector     string // --field-selector
 	dryRun            bool   // --dry-run
+	drain             bool   // --drain
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -53,11 +54,18 @@ func NewStopCommand() *cobra.Command {
 # Stop multiple workflows by field selector
 
   argo stop --field-selector metadata.namespace=argo
+
+# Drain a workflow, letting its running steps and exit handlers finish but not starting any more steps:
+
+  argo stop my-wf --drain
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 && !stopArgs.hasSelector() {
 				return errors.New("requires either selector or workflow")
 			}
+			if stopArgs.drain && stopArgs.nodeFieldSelector != "" {
+				return errors.New("--drain cannot be used with --node-field-selector")
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -76,6 +84,7 @@ func NewStopCommand() *cobra.Command {
 	command.Flags().StringVarP(&stopArgs.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&stopArgs.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	command.Flags().BoolVar(&stopArgs.dryRun, "dry-run", false, "If true, only print the workflows that would be stopped, without stopping them.")
+	command.Flags().BoolVar(&stopArgs.drain, "drain", false, "If true, let the running nodes finish instead of stopping them, but do not start any more nodes except exit handlers.")
 	return command
 }
 
@@ -123,6 +132,7 @@ func stopWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 			Namespace:         wf.Namespace,
 			NodeFieldSelector: selector.String(),
 			Message:           stopArgs.message,
+			Drain:             stopArgs.drain,
 		})
 		if err != nil {
 			return err
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	common "github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	argoJson "github.com/argoproj/argo-workflows/v3/util/json"
 	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
@@ -27,6 +29,8 @@ func NewSubmitCommand() *cobra.Command {
 		cliSubmitOpts  = common.NewCliSubmitOpts()
 		priority       int32
 		from           string
+		batch          bool
+		allOrNothing   bool
 	)
 	command := &cobra.Command{
 		Use:   "submit [FILE... | --from `kind/name]",
@@ -54,11 +58,31 @@ func NewSubmitCommand() *cobra.Command {
 # Submit multiple workflows from stdin:
 
   cat my-wf.yaml | argo submit -
+
+# Submit a workflow that the Argo Server queues if the Kubernetes API is unavailable, and creates once it is available:
+
+  argo submit --async-durable my-wf.yaml
+
+# Submit many workflows from a file in one request, creating none of them unless all of them can be created:
+
+  argo submit --batch --all-or-nothing many-wfs.yaml
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if from != "" && len(args) != 0 {
 				return errors.New("cannot combine --from with file arguments")
 			}
+			if from != "" && cliSubmitOpts.AsyncDurable {
+				return errors.New("cannot combine --from with --async-durable")
+			}
+			if batch && from != "" {
+				return errors.New("cannot combine --batch with --from")
+			}
+			if batch && cliSubmitOpts.AsyncDurable {
+				return errors.New("cannot combine --batch with --async-durable")
+			}
+			if allOrNothing && !batch {
+				return errors.New("--all-or-nothing requires --batch")
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -84,6 +108,12 @@ func NewSubmitCommand() *cobra.Command {
 			namespace := client.Namespace()
 			if from != "" {
 				return submitWorkflowFromResource(ctx, serviceClient, namespace, from, &submitOpts, &cliSubmitOpts)
+			} else if batch {
+				batchClient, err := apiClient.NewWorkflowBatchServiceClient()
+				if err != nil {
+					return err
+				}
+				return submitWorkflowBatchFromFile(ctx, serviceClient, batchClient, namespace, args, allOrNothing, &submitOpts, &cliSubmitOpts)
 			} else {
 				return submitWorkflowsFromFile(ctx, serviceClient, namespace, args, &submitOpts, &cliSubmitOpts)
 			}
@@ -99,6 +129,9 @@ func NewSubmitCommand() *cobra.Command {
 	command.Flags().StringVar(&from, "from", "", "Submit from an existing `kind/name` E.g., --from=cronwf/hello-world-cwf")
 	command.Flags().StringVar(&cliSubmitOpts.GetArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error). Should only be used with --watch.")
 	command.Flags().StringVar(&cliSubmitOpts.GetArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
+	command.Flags().BoolVar(&cliSubmitOpts.AsyncDurable, "async-durable", false, "have the Argo Server queue the workflow if the Kubernetes API is unavailable, and create it once it is available. Requires the submission queue of the Argo Server to be configured")
+	command.Flags().BoolVar(&batch, "batch", false, "submit the workflows in one request, which validates and creates each of them, rather than one request per workflow")
+	command.Flags().BoolVar(&allOrNothing, "all-or-nothing", false, "with --batch, create none of the workflows unless all of them can be created")
 	command.Flags().StringVar(&cliSubmitOpts.ScheduledTime, "scheduled-time", "", "Override the workflow's scheduledTime parameter (useful for backfilling). The time must be RFC3339")
 
 	// Only complete files with appropriate extension.
@@ -110,18 +143,100 @@ func NewSubmitCommand() *cobra.Command {
 }
 
 func submitWorkflowsFromFile(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, filePaths []string, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) error {
-	fileContents, err := util.ReadManifest(filePaths...)
+	workflows, err := readWorkflows(filePaths, cliOpts)
 	if err != nil {
 		return err
 	}
+	return submitWorkflows(ctx, serviceClient, namespace, workflows, submitOpts, cliOpts)
+}
+
+func readWorkflows(filePaths []string, cliOpts *common.CliSubmitOpts) ([]wfv1.Workflow, error) {
+	fileContents, err := util.ReadManifest(filePaths...)
+	if err != nil {
+		return nil, err
+	}
 
 	var workflows []wfv1.Workflow
 	for _, body := range fileContents {
 		wfs := unmarshalWorkflows(body, cliOpts.Strict)
 		workflows = append(workflows, wfs...)
 	}
+	return workflows, nil
+}
 
-	return submitWorkflows(ctx, serviceClient, namespace, workflows, submitOpts, cliOpts)
+func submitWorkflowBatchFromFile(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, batchClient workflowbatchpkg.WorkflowBatchServiceClient, namespace string, filePaths []string, allOrNothing bool, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) error {
+	workflows, err := readWorkflows(filePaths, cliOpts)
+	if err != nil {
+		return err
+	}
+	if err := validateOptions(workflows, submitOpts, cliOpts); err != nil {
+		return err
+	}
+	if len(workflows) == 0 {
+		return errors.New("No Workflow found in given files")
+	}
+	batch := make([]*wfv1.Workflow, len(workflows))
+	for i := range workflows {
+		if err := applySubmitOpts(&workflows[i], namespace, submitOpts, cliOpts); err != nil {
+			return err
+		}
+		batch[i] = &workflows[i]
+	}
+	resp, err := batchClient.SubmitWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchSubmitRequest{
+		Namespace:    namespace,
+		Workflows:    batch,
+		AllOrNothing: allOrNothing,
+		DryRun:       submitOpts.DryRun,
+		ServerDryRun: submitOpts.ServerDryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to submit workflows: %v", err)
+	}
+
+	var workflowNames []string
+	failed := 0
+	for i, item := range resp.Items {
+		if item.Workflow == nil || item.Error != "" {
+			failed++
+			fmt.Fprintf(os.Stderr, "Failed to submit workflow %d (%s): %s\n", i, workflowName(&workflows[i]), item.Error)
+			continue
+		}
+		if err = printWorkflow(item.Workflow, common.GetFlags{Output: cliOpts.Output, Status: cliOpts.GetArgs.Status}); err != nil {
+			return err
+		}
+		workflowNames = append(workflowNames, item.Workflow.Name)
+	}
+	if err := common.WaitWatchOrLog(ctx, serviceClient, namespace, workflowNames, *cliOpts); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d workflows were not submitted", failed, len(resp.Items))
+	}
+	return nil
+}
+
+// workflowName returns the name of a workflow that may not have been created, for messages about it
+func workflowName(wf *wfv1.Workflow) string {
+	if wf.Name != "" {
+		return wf.Name
+	}
+	return wf.GenerateName
+}
+
+// applySubmitOpts applies the options of the command to a workflow that is submitted from a file
+func applySubmitOpts(wf *wfv1.Workflow, namespace string, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) error {
+	if wf.Namespace == "" {
+		// This is here to avoid passing an empty namespace when using --server-dry-run
+		wf.Namespace = namespace
+	}
+	err := util.ApplySubmitOpts(wf, submitOpts)
+	if err != nil {
+		return err
+	}
+	if cliOpts.Priority != nil {
+		wf.Spec.Priority = cliOpts.Priority
+	}
+	return nil
 }
 
 func validateOptions(workflows []wfv1.Workflow, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) error {
@@ -163,6 +278,15 @@ func validateOptions(workflows []wfv1.Workflow, submitOpts *wfv1.SubmitOpts, cli
 			return errors.New("--server-dry-run should have an output option")
 		}
 	}
+
+	if cliOpts.AsyncDurable {
+		if cliOpts.Wait || cliOpts.Watch || cliOpts.Log {
+			return errors.New("--async-durable cannot be combined with --wait, --watch or --log")
+		}
+		if submitOpts.DryRun || submitOpts.ServerDryRun {
+			return errors.New("--async-durable cannot be combined with --dry-run or --server-dry-run")
+		}
+	}
 	return nil
 }
 
@@ -216,17 +340,10 @@ func submitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServ
 	var workflowNames []string
 
 	for _, wf := range workflows {
-		if wf.Namespace == "" {
-			// This is here to avoid passing an empty namespace when using --server-dry-run
-			wf.Namespace = namespace
-		}
-		err := util.ApplySubmitOpts(&wf, submitOpts)
+		err := applySubmitOpts(&wf, namespace, submitOpts, cliOpts)
 		if err != nil {
 			return err
 		}
-		if cliOpts.Priority != nil {
-			wf.Spec.Priority = cliOpts.Priority
-		}
 		options := &metav1.CreateOptions{}
 		if submitOpts.DryRun {
 			options.DryRun = []string{"All"}
@@ -236,6 +353,7 @@ func submitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServ
 			Workflow:      &wf,
 			ServerDryRun:  submitOpts.ServerDryRun,
 			CreateOptions: options,
+			Durable:       cliOpts.AsyncDurable,
 		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit workflow: %v", err)
@@ -244,6 +362,10 @@ func submitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServ
 		if err = printWorkflow(created, common.GetFlags{Output: cliOpts.Output, Status: cliOpts.GetArgs.Status}); err != nil {
 			return err
 		}
+		if created.UID == "" && cliOpts.AsyncDurable {
+			id := created.Labels[wfcommon.LabelKeySubmissionID]
+			fmt.Fprintf(os.Stderr, "The Kubernetes API is unavailable, so the workflow was queued with the tracking ID %s. Once it is created, it can be listed with:\n\n  argo list -l %s=%s\n", id, wfcommon.LabelKeySubmissionID, id)
+		}
 		workflowNames = append(workflowNames, created.Name)
 	}
 
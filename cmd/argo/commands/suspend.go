@@ -10,15 +10,20 @@ import (
 )
 
 func NewSuspendCommand() *cobra.Command {
+	var after string
 	command := &cobra.Command{
-		Use:   "suspend WORKFLOW1 WORKFLOW2...",
-		Short: "suspend zero or more workflows (opposite of resume)",
+		Use:     "suspend WORKFLOW1 WORKFLOW2...",
+		Aliases: []string{"pause"},
+		Short:   "suspend zero or more workflows (opposite of resume)",
 		Example: `# Suspend a workflow:
 
   argo suspend my-wf
 
 # Suspend the latest workflow:
   argo suspend @latest
+
+# Suspend a workflow once its "train" step has completed:
+  argo suspend my-wf --after train
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
@@ -31,14 +36,20 @@ func NewSuspendCommand() *cobra.Command {
 				_, err := serviceClient.SuspendWorkflow(ctx, &workflowpkg.WorkflowSuspendRequest{
 					Name:      wfName,
 					Namespace: namespace,
+					After:     after,
 				})
 				if err != nil {
 					return fmt.Errorf("Failed to suspended %s: %+v", wfName, err)
 				}
-				fmt.Printf("workflow %s suspended\n", wfName)
+				if after != "" {
+					fmt.Printf("workflow %s will be suspended after %s\n", wfName, after)
+				} else {
+					fmt.Printf("workflow %s suspended\n", wfName)
+				}
 			}
 			return nil
 		},
 	}
+	command.Flags().StringVar(&after, "after", "", "Suspend the workflow once the node, by name, display name or ID, has completed, rather than immediately. The node must have started, or be a step or task of the workflow")
 	return command
 }
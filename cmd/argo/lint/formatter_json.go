@@ -0,0 +1,70 @@
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
+)
+
+// formatterJSON formats each linting error as a line of JSON, with the path of the field, the offending value, and a
+// suggestion of what was probably meant if these are known, so that the results can be read by tools such as IDEs
+type formatterJSON struct{}
+
+type jsonLintError struct {
+	File       string `json:"file"`
+	Message    string `json:"message"`
+	Path       string `json:"path,omitempty"`
+	Value      string `json:"value,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func (f formatterJSON) Format(l *LintResult) string {
+	if !l.Linted {
+		return ""
+	}
+
+	sb := &strings.Builder{}
+	for _, e := range l.Errs {
+		for _, fieldErr := range FieldErrors(e) {
+			b, _ := json.Marshal(jsonLintError{
+				File:       l.File,
+				Message:    fieldErr.Error(),
+				Path:       fieldErr.Path,
+				Value:      fieldErr.Value,
+				Suggestion: fieldErr.Suggestion,
+			})
+			sb.Write(b)
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func (f formatterJSON) Summarize(*LintResults) string {
+	return ""
+}
+
+// FieldErrors returns the linting error as field errors, which only have a message if the field is not known
+func FieldErrors(err error) []argoerrors.FieldError {
+	if strictErr, ok := runtime.AsStrictDecodingError(err); ok {
+		var fieldErrs []argoerrors.FieldError
+		for _, e := range strictErr.Errors() {
+			fieldErrs = append(fieldErrs, FieldErrors(e)...)
+		}
+		return fieldErrs
+	}
+	var fieldErr argoerrors.FieldError
+	if errors.As(err, &fieldErr) {
+		return []argoerrors.FieldError{fieldErr}
+	}
+	if fieldErr, ok := grpcutil.FieldErrorFromStatus(err); ok {
+		return []argoerrors.FieldError{fieldErr}
+	}
+	return []argoerrors.FieldError{{Message: err.Error()}}
+}
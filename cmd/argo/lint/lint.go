@@ -66,6 +66,7 @@ var (
 	formatters = map[string]Formatter{
 		"pretty": formatterPretty{},
 		"simple": formatterSimple{},
+		"json":   formatterJSON{},
 	}
 )
 
@@ -84,7 +85,7 @@ func RunLint(ctx context.Context, client apiclient.Client, kinds []string, outpu
 	if err != nil {
 		return err
 	}
-	clients, err := getLintClients(client, kinds)
+	clients, err := GetLintClients(client, kinds)
 	if err != nil {
 		return err
 	}
@@ -120,7 +121,7 @@ func Lint(ctx context.Context, opts *LintOptions) (*LintResults, error) {
 
 	for _, file := range opts.Files {
 		err := fileutil.WalkManifests(file, func(path string, data []byte) error {
-			res := lintData(ctx, path, data, opts)
+			res := LintData(ctx, path, data, opts)
 			results.Results = append(results.Results, res)
 
 			_, err := w.Write([]byte(results.fmtr.Format(res)))
@@ -136,7 +137,8 @@ func Lint(ctx context.Context, opts *LintOptions) (*LintResults, error) {
 	return results, err
 }
 
-func lintData(ctx context.Context, src string, data []byte, opts *LintOptions) *LintResult {
+// LintData lints the objects in the data, which is read from the source, e.g. a file or a document open in an editor
+func LintData(ctx context.Context, src string, data []byte, opts *LintOptions) *LintResult {
 	res := &LintResult{
 		File: src,
 		Errs: []error{},
@@ -277,7 +279,8 @@ func getObjectName(kind string, obj metav1.Object, objIndex int) string {
 	return fmt.Sprintf(`"%s" (%s)`, name, kind)
 }
 
-func getLintClients(client apiclient.Client, kinds []string) (ServiceClients, error) {
+// GetLintClients returns the clients to lint the kinds with
+func GetLintClients(client apiclient.Client, kinds []string) (ServiceClients, error) {
 	res := ServiceClients{}
 	var err error
 	for _, kind := range kinds {
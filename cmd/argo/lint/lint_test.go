@@ -14,10 +14,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/lint/mocks"
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
 	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
 	wftemplatemocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate/mocks"
 	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 )
 
 var lintFileData = []byte(`
@@ -95,6 +97,33 @@ func TestLintFile(t *testing.T) {
 	wftServiceSclientMock.AssertNotCalled(t, "LintWorkflowTemplate")
 }
 
+func TestLintFileJSON(t *testing.T) {
+	file, err := os.CreateTemp("", "*.yaml")
+	require.NoError(t, err)
+	err = os.WriteFile(file.Name(), lintFileData, 0o600)
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	fmtr, err := GetFormatter("json")
+	require.NoError(t, err)
+
+	wfServiceClientMock := &workflowmocks.WorkflowServiceClient{}
+	fieldErr := argoerrors.FieldError{Path: "spec.entrypoint", Value: "whalsay", Message: "template name 'whalsay' undefined", Suggestion: "whalesay"}
+	wfServiceClientMock.On("LintWorkflow", mock.Anything, mock.Anything).Return(nil, grpcutil.TranslateError(fieldErr))
+
+	res, err := Lint(context.Background(), &LintOptions{
+		Files: []string{file.Name()},
+		ServiceClients: ServiceClients{
+			WorkflowsClient: wfServiceClientMock,
+		},
+		Formatter: fmtr,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.JSONEq(t, fmt.Sprintf(`{"file":%q,"message":"template name 'whalsay' undefined, did you mean 'whalesay'?","path":"spec.entrypoint","value":"whalsay","suggestion":"whalesay"}`, file.Name()), res.msg)
+}
+
 func TestLintMultipleKinds(t *testing.T) {
 	file, err := os.CreateTemp("", "*.yaml")
 	require.NoError(t, err)
@@ -271,6 +300,11 @@ func TestGetFormatter(t *testing.T) {
 			expectedErr:    nil,
 			expectedOutput: (&LintResults{fmtr: formatterSimple{}}).buildMsg(),
 		},
+		"json": {
+			formatterName:  "json",
+			expectedErr:    nil,
+			expectedOutput: (&LintResults{fmtr: formatterJSON{}}).buildMsg(),
+		},
 		"unknown name": {
 			formatterName:  "foo",
 			expectedErr:    fmt.Errorf("unknown formatter: foo"),
@@ -0,0 +1,203 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var (
+	// templateFieldRegex matches the start of a line that names the template of a step or task, e.g.
+	// `- - name: hello` followed by `template: wh`
+	templateFieldRegex = regexp.MustCompile(`^\s*(?:-\s+)*template:\s*["']?([\w-]*)$`)
+	// variablePrefixRegex matches the name of the variable that is being typed at the end of an expression
+	variablePrefixRegex = regexp.MustCompile(`[\w.-]*$`)
+
+	// globalVariables are the global variables that are always in scope
+	globalVariables = []string{
+		common.GlobalVarWorkflowName,
+		common.GlobalVarWorkflowNamespace,
+		common.GlobalVarWorkflowUID,
+		common.GlobalVarWorkflowServiceAccountName,
+		common.GlobalVarWorkflowCreationTimestamp,
+		common.GlobalVarWorkflowPriority,
+		common.GlobalVarWorkflowOwnerTeam,
+		common.GlobalVarWorkflowOwnerEmail,
+		common.GlobalVarWorkflowOwnerSlackChannel,
+		common.GlobalVarWorkflowMainEntrypoint,
+		common.GlobalVarWorkflowStatus,
+		common.GlobalVarWorkflowDuration,
+		common.GlobalVarWorkflowFailures,
+		common.GlobalVarWorkflowParametersJSON,
+		common.GlobalVarWorkflowLabelsJSON,
+		common.GlobalVarWorkflowAnnotationsJSON,
+	}
+)
+
+// complete returns the completions at the position in the text: the templates of the document for the template of a
+// step or task, and the variables in scope within an expression
+func complete(text string, pos position) []completionItem {
+	lines := strings.Split(text, "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return []completionItem{}
+	}
+	prefix := lines[pos.Line][:min(max(pos.Character, 0), len(lines[pos.Line]))]
+	doc, spec := workflowSpecAt(text, pos.Line)
+	if i := strings.LastIndex(prefix, "{{"); i >= 0 && !strings.Contains(prefix[i:], "}}") {
+		tmpl := doc.templateAt(spec, pos.Line)
+		return filterCompletions(variableCompletions(spec, tmpl), variablePrefixRegex.FindString(prefix[i+2:]))
+	}
+	if match := templateFieldRegex.FindStringSubmatch(prefix); match != nil && spec != nil {
+		var items []completionItem
+		for _, t := range spec.Templates {
+			items = append(items, completionItem{Label: t.Name, Kind: completionItemKindField, Detail: string(t.GetType()) + " template"})
+		}
+		return filterCompletions(items, match[1])
+	}
+	return []completionItem{}
+}
+
+// workflowSpecAt returns the document of the text at the line, and its spec if it is the manifest of a kind of
+// Workflow. The line is ignored if the document cannot be parsed with it, as it is likely being typed.
+func workflowSpecAt(text string, line int) (document, *wfv1.WorkflowSpec) {
+	var doc document
+	for _, d := range splitDocuments(text) {
+		if d.line > line {
+			break
+		}
+		doc = d
+	}
+	spec := doc.workflowSpec()
+	if spec == nil {
+		lines := strings.Split(doc.text, "\n")
+		if i := line - doc.line; i < len(lines) {
+			lines[i] = ""
+			doc = newDocument(strings.Join(lines, "\n"), doc.line)
+			spec = doc.workflowSpec()
+		}
+	}
+	return doc, spec
+}
+
+// workflowSpec returns the spec of the document if it is the manifest of a kind of Workflow
+func (d document) workflowSpec() *wfv1.WorkflowSpec {
+	for _, res := range common.ParseObjects([]byte(d.text), false) {
+		switch v := res.Object.(type) {
+		case *wfv1.Workflow:
+			return &v.Spec
+		case *wfv1.WorkflowTemplate:
+			return &v.Spec
+		case *wfv1.ClusterWorkflowTemplate:
+			return &v.Spec
+		case *wfv1.CronWorkflow:
+			return &v.Spec.WorkflowSpec
+		}
+	}
+	return nil
+}
+
+// templateAt returns the template of the spec of the document at the line, if any
+func (d document) templateAt(spec *wfv1.WorkflowSpec, line int) *wfv1.Template {
+	if spec == nil {
+		return nil
+	}
+	templates, _ := d.node("spec.templates")
+	if kind, _ := d.node("kind"); kind != nil && kind.Value == "CronWorkflow" {
+		templates, _ = d.node("spec.workflowSpec.templates")
+	}
+	if templates == nil || templates.Kind != yaml.SequenceNode || len(templates.Content) != len(spec.Templates) {
+		return nil
+	}
+	var tmpl *wfv1.Template
+	for i, node := range templates.Content {
+		if d.line+node.Line-1 > line {
+			break
+		}
+		tmpl = &spec.Templates[i]
+	}
+	return tmpl
+}
+
+// variableCompletions returns the variables in scope of the template of the spec
+func variableCompletions(spec *wfv1.WorkflowSpec, tmpl *wfv1.Template) []completionItem {
+	var items []completionItem
+	add := func(detail string, format string, args ...interface{}) {
+		items = append(items, completionItem{Label: fmt.Sprintf(format, args...), Kind: completionItemKindVariable, Detail: detail})
+	}
+	for _, v := range globalVariables {
+		add("workflow variable", "%s", v)
+	}
+	if spec == nil {
+		return items
+	}
+	for _, p := range spec.Arguments.Parameters {
+		add("workflow parameter", "workflow.parameters.%s", p.Name)
+	}
+	if tmpl == nil {
+		return items
+	}
+	add("template variable", "%s", common.LocalVarPodName)
+	add("template variable", "%s", common.LocalVarRetries)
+	for _, p := range tmpl.Inputs.Parameters {
+		add("input parameter", "inputs.parameters.%s", p.Name)
+	}
+	for _, a := range tmpl.Inputs.Artifacts {
+		add("input artifact", "inputs.artifacts.%s", a.Name)
+	}
+	hasItems := false
+	addNode := func(prefix, detail, name, templateName string) {
+		add(detail, "%s.%s.id", prefix, name)
+		add(detail, "%s.%s.status", prefix, name)
+		add(detail, "%s.%s.outputs.result", prefix, name)
+		add(detail, "%s.%s.exitCode", prefix, name)
+		outputs := templateOutputs(spec, templateName)
+		for _, p := range outputs.Parameters {
+			add("output parameter", "%s.%s.outputs.parameters.%s", prefix, name, p.Name)
+		}
+		for _, a := range outputs.Artifacts {
+			add("output artifact", "%s.%s.outputs.artifacts.%s", prefix, name, a.Name)
+		}
+	}
+	for _, group := range tmpl.Steps {
+		for _, step := range group.Steps {
+			addNode("steps", "step", step.Name, step.Template)
+			hasItems = hasItems || step.ShouldExpand()
+		}
+	}
+	if tmpl.DAG != nil {
+		for _, task := range tmpl.DAG.Tasks {
+			addNode("tasks", "task", task.Name, task.Template)
+			hasItems = hasItems || task.ShouldExpand()
+		}
+	}
+	if hasItems {
+		add("loop variable", "item")
+	}
+	return items
+}
+
+// templateOutputs returns the outputs of the template of the spec with the name, if there is one
+func templateOutputs(spec *wfv1.WorkflowSpec, name string) wfv1.Outputs {
+	for _, t := range spec.Templates {
+		if t.Name == name {
+			return t.Outputs
+		}
+	}
+	return wfv1.Outputs{}
+}
+
+// filterCompletions returns the completions that start with the prefix
+func filterCompletions(items []completionItem, prefix string) []completionItem {
+	filtered := []completionItem{}
+	for _, item := range items {
+		if strings.HasPrefix(item.Label, prefix) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
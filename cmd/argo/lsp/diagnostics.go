@@ -0,0 +1,121 @@
+package lsp
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/lint"
+)
+
+var (
+	// yamlSeparator separates the YAML documents of a text, like common.ParseObjects
+	yamlSeparator = regexp.MustCompile(`\n---`)
+	// pathElementRegex matches the fields and indexes of a path, e.g. `spec`, `templates` and `[0]` of
+	// `spec.templates[0]`
+	pathElementRegex = regexp.MustCompile(`[^.\[\]]+|\[\d+\]`)
+)
+
+// document is a YAML document of a text
+type document struct {
+	text string
+	// line is the line of the text that the document starts at
+	line int
+	// root is the parsed document, which is nil if it is not valid YAML
+	root *yaml.Node
+}
+
+// splitDocuments splits the text into its YAML documents
+func splitDocuments(text string) []document {
+	var docs []document
+	start, line := 0, 0
+	for _, loc := range yamlSeparator.FindAllStringIndex(text, -1) {
+		docs = append(docs, newDocument(text[start:loc[0]], line))
+		line += strings.Count(text[start:loc[1]], "\n")
+		start = loc[1]
+	}
+	return append(docs, newDocument(text[start:], line))
+}
+
+func newDocument(text string, line int) document {
+	doc := document{text: text, line: line}
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &root); err == nil && len(root.Content) > 0 {
+		doc.root = root.Content[0]
+	}
+	return doc
+}
+
+// node returns the node of the field at the path, or the closest parent that exists, and the node of the key of the
+// field if it is the field of an object
+func (d document) node(path string) (node, key *yaml.Node) {
+	node = d.root
+	for _, element := range pathElementRegex.FindAllString(path, -1) {
+		if node == nil {
+			break
+		}
+		var next *yaml.Node
+		var nextKey *yaml.Node
+		if strings.HasPrefix(element, "[") {
+			i, _ := strconv.Atoi(strings.Trim(element, "[]"))
+			if node.Kind == yaml.SequenceNode && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		} else if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == element {
+					next, nextKey = node.Content[i+1], node.Content[i]
+					break
+				}
+			}
+		}
+		if next == nil {
+			break
+		}
+		node, key = next, nextKey
+	}
+	return node, key
+}
+
+// fieldRange returns the range of the field at the path with the value in the text. The key of the field is the
+// range if the value is the key, e.g. an unknown field, and the range of the closest parent that exists otherwise.
+func (d document) fieldRange(path, value string) textRange {
+	node, key := d.node(path)
+	if node == nil || path == "" {
+		return textRange{Start: position{Line: d.line}, End: position{Line: d.line}}
+	}
+	if key != nil && (key.Value == value || node.Kind != yaml.ScalarNode) {
+		node = key
+	}
+	start := position{Line: d.line + node.Line - 1, Character: node.Column - 1}
+	end := start
+	if node.Kind == yaml.ScalarNode {
+		end.Character += len(node.Value)
+	}
+	return textRange{Start: start, End: end}
+}
+
+// diagnose lints each YAML document of the text, and returns the errors as diagnostics of the fields that they are
+// of, or of the start of the document if these are not known
+func (s *Server) diagnose(ctx context.Context, text string) []diagnostic {
+	diagnostics := []diagnostic{}
+	for _, doc := range splitDocuments(text) {
+		if strings.TrimSpace(doc.text) == "" {
+			continue
+		}
+		for _, err := range s.lint(ctx, []byte(doc.text)) {
+			for _, fieldErr := range lint.FieldErrors(err) {
+				diagnostics = append(diagnostics, diagnostic{
+					Range:    doc.fieldRange(fieldErr.Path, fieldErr.Value),
+					Severity: severityError,
+					Source:   "argo",
+					Message:  fieldErr.Error(),
+				})
+			}
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,190 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-workflows/v3"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/lint"
+)
+
+// LintFunc lints a YAML document of the manifest of a Workflow, WorkflowTemplate, CronWorkflow or
+// ClusterWorkflowTemplate
+type LintFunc func(ctx context.Context, data []byte) []error
+
+// Linter returns a LintFunc that lints documents like `argo lint`
+func Linter(opts *lint.LintOptions) LintFunc {
+	return func(ctx context.Context, data []byte) []error {
+		return lint.LintData(ctx, "", data, opts).Errs
+	}
+}
+
+// Server is a language server for the manifests of Workflows and their templates. It validates the documents open in
+// an editor, and completes the names of templates and the variables in scope of expressions.
+type Server struct {
+	lint      LintFunc
+	documents map[string]string
+	mu        sync.Mutex
+	out       io.Writer
+}
+
+func NewServer(lintFunc LintFunc) *Server {
+	return &Server{lint: lintFunc, documents: make(map[string]string)}
+}
+
+// Serve serves the requests read from in, writing the responses to out, until the client exits or in is closed
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.out = out
+	r := bufio.NewReader(in)
+	for {
+		msg, err := readMessage(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, msg *message) error {
+	var result interface{}
+	var rpcErr *responseError
+	switch msg.Method {
+	case "initialize":
+		result = initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncFull,
+				CompletionProvider: completionOptions{TriggerCharacters: []string{"{", "."}},
+			},
+			ServerInfo: serverInfo{Name: "argo", Version: argo.GetVersion().Version},
+		}
+	case "shutdown":
+		result = nil
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			rpcErr = invalidParams(err)
+			break
+		}
+		s.documents[params.TextDocument.URI] = params.TextDocument.Text
+		return s.publishDiagnostics(ctx, params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			rpcErr = invalidParams(err)
+			break
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil
+		}
+		// the full text of the document is synced, so only the last change matters
+		s.documents[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		return s.publishDiagnostics(ctx, params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			rpcErr = invalidParams(err)
+			break
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []diagnostic{}})
+	case "textDocument/completion":
+		var params completionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			rpcErr = invalidParams(err)
+			break
+		}
+		result = complete(s.documents[params.TextDocument.URI], params.Position)
+	default:
+		if msg.ID == nil {
+			// notifications that are not supported are ignored
+			return nil
+		}
+		rpcErr = &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q is not supported", msg.Method)}
+	}
+	if msg.ID == nil {
+		if rpcErr != nil {
+			log.WithField("method", msg.Method).Warn(rpcErr.Message)
+		}
+		return nil
+	}
+	return s.reply(msg.ID, result, rpcErr)
+}
+
+func (s *Server) publishDiagnostics(ctx context.Context, uri string) error {
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: s.diagnose(ctx, s.documents[uri])})
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}, rpcErr *responseError) error {
+	msg := &message{JSONRPC: jsonrpcVersion, ID: id, Error: rpcErr}
+	if rpcErr == nil {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		msg.Result = data
+	}
+	return s.write(msg)
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.write(&message{JSONRPC: jsonrpcVersion, Method: method, Params: data})
+}
+
+func (s *Server) write(msg *message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	return err
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+// readMessage reads a message, which is its headers followed by its JSON content
+func readMessage(r *bufio.Reader) (*message, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read message header: %w", err)
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length: %w", err)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("failed to read message content: %w", err)
+	}
+	msg := &message{}
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	return msg, nil
+}
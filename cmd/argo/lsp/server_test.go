@@ -0,0 +1,166 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+)
+
+var stepsWorkflow = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-workflow
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: steps-
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: greeting
+        value: hello
+  templates:
+    - name: main
+      steps:
+        - - name: generate
+            template: whalsay
+            arguments:
+              parameters:
+                - name: message
+                  value: "{{workflow.parameters.greeting}}"
+    - name: whalesay
+      inputs:
+        parameters:
+          - name: message
+      outputs:
+        parameters:
+          - name: said
+            valueFrom:
+              path: /tmp/said
+      container:
+        image: argoproj/argosay:v2
+        args: ["{{inputs.parameters.message}}"]
+`
+
+func writeMessage(t *testing.T, w io.Writer, id int, method string, params interface{}) {
+	t.Helper()
+	msg := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+	if id > 0 {
+		msg["id"] = id
+	}
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	require.NoError(t, err)
+}
+
+func TestServer(t *testing.T) {
+	in := &bytes.Buffer{}
+	writeMessage(t, in, 1, "initialize", map[string]interface{}{})
+	writeMessage(t, in, 0, "initialized", map[string]interface{}{})
+	writeMessage(t, in, 0, "textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": "file:///steps.yaml", "text": stepsWorkflow},
+	})
+	writeMessage(t, in, 2, "textDocument/completion", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": "file:///steps.yaml"},
+		"position":     map[string]interface{}{"line": 19, "character": 26},
+	})
+	writeMessage(t, in, 3, "textDocument/hover", map[string]interface{}{})
+	writeMessage(t, in, 4, "shutdown", nil)
+	writeMessage(t, in, 0, "exit", nil)
+
+	var linted []string
+	s := NewServer(func(ctx context.Context, data []byte) []error {
+		linted = append(linted, string(data))
+		return []error{
+			argoerrors.FieldError{Path: "spec.templates[0].steps[0][0].template", Value: "whalsay", Message: "template name 'whalsay' undefined", Suggestion: "whalesay"},
+			fmt.Errorf("something else"),
+		}
+	})
+	out := &bytes.Buffer{}
+	require.NoError(t, s.Serve(context.Background(), in, out))
+	assert.Len(t, linted, 2)
+
+	r := bufio.NewReader(out)
+	var msgs []*message
+	for {
+		msg, err := readMessage(r)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		msgs = append(msgs, msg)
+	}
+	require.Len(t, msgs, 5)
+
+	assert.JSONEq(t, "1", string(*msgs[0].ID))
+	var initialize initializeResult
+	require.NoError(t, json.Unmarshal(msgs[0].Result, &initialize))
+	assert.Equal(t, textDocumentSyncFull, initialize.Capabilities.TextDocumentSync)
+
+	assert.Equal(t, "textDocument/publishDiagnostics", msgs[1].Method)
+	var diagnostics publishDiagnosticsParams
+	require.NoError(t, json.Unmarshal(msgs[1].Params, &diagnostics))
+	assert.Equal(t, "file:///steps.yaml", diagnostics.URI)
+	require.Len(t, diagnostics.Diagnostics, 4)
+	assert.Equal(t, diagnostic{
+		Range:    textRange{Start: position{Line: 19, Character: 22}, End: position{Line: 19, Character: 29}},
+		Severity: severityError,
+		Source:   "argo",
+		Message:  "template name 'whalsay' undefined, did you mean 'whalesay'?",
+	}, diagnostics.Diagnostics[2])
+	assert.Equal(t, textRange{Start: position{Line: 4}, End: position{Line: 4}}, diagnostics.Diagnostics[3].Range)
+	assert.Equal(t, "something else", diagnostics.Diagnostics[3].Message)
+
+	var completions []completionItem
+	require.NoError(t, json.Unmarshal(msgs[2].Result, &completions))
+	assert.Equal(t, []completionItem{{Label: "whalesay", Kind: completionItemKindField, Detail: "Container template"}}, completions)
+
+	require.NotNil(t, msgs[3].Error)
+	assert.Equal(t, codeMethodNotFound, msgs[3].Error.Code)
+
+	assert.JSONEq(t, "4", string(*msgs[4].ID))
+	assert.JSONEq(t, "null", string(msgs[4].Result))
+}
+
+func TestComplete(t *testing.T) {
+	labels := func(items []completionItem) []string {
+		var labels []string
+		for _, item := range items {
+			labels = append(labels, item.Label)
+		}
+		return labels
+	}
+
+	t.Run("Templates", func(t *testing.T) {
+		assert.Equal(t, []string{"main", "whalesay"}, labels(complete(stepsWorkflow, position{Line: 19, Character: 22})))
+	})
+	t.Run("InputParameters", func(t *testing.T) {
+		assert.Equal(t, []string{"inputs.parameters.message"}, labels(complete(stepsWorkflow, position{Line: 35, Character: 36})))
+	})
+	t.Run("StepOutputs", func(t *testing.T) {
+		text := stepsWorkflow + "    - name: echo\n      steps:\n        - - name: print\n            template: whalesay\n            arguments:\n              parameters:\n                - name: message\n                  value: \"{{steps.print.outputs.p"
+		assert.Equal(t, []string{"steps.print.outputs.parameters.said"}, labels(complete(text, position{Line: 43, Character: 49})))
+	})
+	t.Run("WorkflowParameters", func(t *testing.T) {
+		items := complete(stepsWorkflow, position{Line: 23, Character: 28})
+		assert.Contains(t, labels(items), "workflow.parameters.greeting")
+		assert.Contains(t, labels(items), "workflow.name")
+		assert.Contains(t, labels(items), "steps.generate.status")
+		assert.NotContains(t, labels(items), "inputs.parameters.message")
+	})
+	t.Run("NotAWorkflow", func(t *testing.T) {
+		assert.Empty(t, complete(stepsWorkflow, position{Line: 3, Character: 8}))
+	})
+}
@@ -0,0 +1,73 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	executor "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+)
+
+func NewArtifactCopyCommand() *cobra.Command {
+	var from, to string
+	cmd := &cobra.Command{
+		Use:   "copy",
+		Short: "copy an artifact to another location, which may be in another type of repository",
+		Example: `  argoexec artifact copy \
+    --from '{"name": "model", "s3": {"endpoint": "s3.amazonaws.com", "bucket": "my-bucket", "key": "model.tgz", ...}}' \
+    --to '{"name": "model", "oci": {"reference": "ghcr.io/my-org/model:v1", ...}}'`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var fromArt, toArt v1alpha1.Artifact
+			if err := yaml.UnmarshalStrict([]byte(from), &fromArt); err != nil {
+				return fmt.Errorf("failed to parse --from: %w", err)
+			}
+			if err := yaml.UnmarshalStrict([]byte(to), &toArt); err != nil {
+				return fmt.Errorf("failed to parse --to: %w", err)
+			}
+			config, err := client.GetConfig().ClientConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+			ri := secretResources{kubeClient: kubeClient, namespace: client.Namespace()}
+			return executor.Copy(cmd.Context(), &fromArt, &toArt, ri, executor.NewDriver)
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "the artifact to copy, as JSON or YAML")
+	cmd.Flags().StringVar(&to, "to", "", "the artifact to copy it to, as JSON or YAML")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// secretResources reads the secrets and config maps of artifacts from the namespace of the pod, with its service account
+type secretResources struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func (r secretResources) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeClient.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r secretResources) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeClient.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/retry"
 	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	executor "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 )
 
 func NewArtifactDeleteCommand() *cobra.Command {
@@ -32,6 +34,7 @@ func NewArtifactDeleteCommand() *cobra.Command {
 			clientConfig := client.GetConfig()
 
 			if podName, ok := os.LookupEnv(common.EnvVarArtifactGCPodHash); ok {
+				defer metrics.RunExecutor(cmd.Context())()
 
 				config, err := clientConfig.ClientConfig()
 				workflowInterface := workflow.NewForConfigOrDie(config)
@@ -42,7 +45,7 @@ func NewArtifactDeleteCommand() *cobra.Command {
 				artifactGCTaskInterface := workflowInterface.ArgoprojV1alpha1().WorkflowArtifactGCTasks(namespace)
 				labelSelector := fmt.Sprintf("%s = %s", common.LabelKeyArtifactGCPodHash, podName)
 
-				err = deleteArtifacts(labelSelector, cmd.Context(), artifactGCTaskInterface)
+				err = deleteArtifacts(labelSelector, cmd.Context(), artifactGCTaskInterface, executor.NewDriver)
 				if err != nil {
 					return err
 				}
@@ -52,7 +55,7 @@ func NewArtifactDeleteCommand() *cobra.Command {
 	}
 }
 
-func deleteArtifacts(labelSelector string, ctx context.Context, artifactGCTaskInterface wfv1alpha1.WorkflowArtifactGCTaskInterface) error {
+func deleteArtifacts(labelSelector string, ctx context.Context, artifactGCTaskInterface wfv1alpha1.WorkflowArtifactGCTaskInterface, newDriver executor.NewDriverFunc) error {
 
 	taskList, err := artifactGCTaskInterface.List(context.Background(), metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
@@ -79,21 +82,21 @@ func deleteArtifacts(labelSelector string, ctx context.Context, artifactGCTaskIn
 					}
 				}
 
-				drv, err := executor.NewDriver(ctx, &artifact, resources)
-				if err != nil {
-					return err
-				}
-
-				err = waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
-					err = drv.Delete(&artifact)
+				// the replicas of the artifact are deleted with it, and it is only deleted once they all are
+				var errString *string
+				for _, location := range append([]v1alpha1.ArtifactLocation{artifact.ArtifactLocation}, artifact.ReplicateTo...) {
+					art := artifact.DeepCopy()
+					art.ArtifactLocation = location
+					art.ReplicateTo = nil
+					errString, err = deleteArtifact(ctx, art, resources, newDriver)
 					if err != nil {
-						errString := err.Error()
-						artResultNodeStatus.ArtifactResults[artifact.Name] = v1alpha1.ArtifactResult{Name: artifact.Name, Success: false, Error: &errString}
-						return false, err
+						return err
 					}
-					artResultNodeStatus.ArtifactResults[artifact.Name] = v1alpha1.ArtifactResult{Name: artifact.Name, Success: true, Error: nil}
-					return true, err
-				})
+					if errString != nil {
+						break
+					}
+				}
+				artResultNodeStatus.ArtifactResults[artifact.Name] = v1alpha1.ArtifactResult{Name: artifact.Name, Success: errString == nil, Error: errString}
 			}
 
 			task.Status.ArtifactResultsByNode[nodeName] = artResultNodeStatus
@@ -111,6 +114,46 @@ func deleteArtifacts(labelSelector string, ctx context.Context, artifactGCTaskIn
 	return nil
 }
 
+// deleteArtifact deletes the artifact, retrying errors that may not happen again, and returns the message of the error
+// it could not be deleted with, if any
+func deleteArtifact(ctx context.Context, artifact *v1alpha1.Artifact, resources resources, newDriver executor.NewDriverFunc) (*string, error) {
+	drv, err := newDriver(ctx, artifact, resources)
+	if err != nil {
+		return nil, err
+	}
+	if !drv.Capabilities().Delete {
+		// retrying would fail in the same way
+		errString := artifactscommon.ErrDeleteNotSupported.Error()
+		return &errString, nil
+	}
+	var errString *string
+	_ = waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+		err := drv.Delete(artifact)
+		if artifactscommon.ErrorKindOf(err) == artifactscommon.ErrorKindNotFound {
+			// there is nothing left to delete
+			err = nil
+		}
+		if err != nil {
+			s := deleteErrorString(err)
+			errString = &s
+			// errors that would happen again, such as forbidden errors, are not retried
+			return !artifactscommon.IsRetryable(err), err
+		}
+		errString = nil
+		return true, nil
+	})
+	return errString, nil
+}
+
+// deleteErrorString returns the message of the error of a failed delete, prefixed with its kind if the driver returned
+// one, so that e.g. permission errors can be told apart from the storage being unavailable
+func deleteErrorString(err error) string {
+	if kind := artifactscommon.ErrorKindOf(err); kind != "" {
+		return fmt.Sprintf("%s: %v", kind, err)
+	}
+	return err.Error()
+}
+
 type resources struct {
 	Files map[string][]byte
 }
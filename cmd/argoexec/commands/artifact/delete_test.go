@@ -0,0 +1,91 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+// deletingArtifactDriver records the keys of the artifacts that it deletes
+type deletingArtifactDriver struct {
+	artifactscommon.ArtifactDriver
+	deleted   *[]string
+	deleteErr error
+}
+
+func (d *deletingArtifactDriver) Delete(a *wfv1.Artifact) error {
+	if d.deleteErr != nil {
+		return d.deleteErr
+	}
+	key, err := a.GetKey()
+	if err != nil {
+		return err
+	}
+	*d.deleted = append(*d.deleted, key)
+	return nil
+}
+
+func (d *deletingArtifactDriver) Capabilities() artifactscommon.Capabilities {
+	return artifactscommon.Capabilities{Delete: true}
+}
+
+func TestDeleteArtifactsWithReplicas(t *testing.T) {
+	task := &wfv1.WorkflowArtifactGCTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "my-ns"},
+		Spec: wfv1.ArtifactGCSpec{ArtifactsByNode: map[string]wfv1.ArtifactNodeSpec{
+			"my-node": {
+				ArchiveLocation: &wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}}},
+				Artifacts: map[string]wfv1.Artifact{
+					"my-art": {
+						Name:             "my-art",
+						ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-wf/my-art.tgz"}},
+						ReplicateTo: []wfv1.ArtifactLocation{
+							{GCS: &wfv1.GCSArtifact{GCSBucket: wfv1.GCSBucket{Bucket: "my-bucket"}, Key: "replicas/my-art.tgz"}},
+						},
+					},
+				},
+			},
+		}},
+	}
+	run := func(t *testing.T, gcsErr error) wfv1.ArtifactResult {
+		t.Helper()
+		ctx := context.Background()
+		tasks := fake.NewSimpleClientset(task).ArgoprojV1alpha1().WorkflowArtifactGCTasks("my-ns")
+		var deleted []string
+		newDriver := func(_ context.Context, art *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+			assert.Empty(t, art.ReplicateTo)
+			if art.GCS != nil {
+				return &deletingArtifactDriver{deleted: &deleted, deleteErr: gcsErr}, nil
+			}
+			return &deletingArtifactDriver{deleted: &deleted}, nil
+		}
+		require.NoError(t, deleteArtifacts("", ctx, tasks, newDriver))
+		if gcsErr == nil {
+			assert.Equal(t, []string{"my-wf/my-art.tgz", "replicas/my-art.tgz"}, deleted, "the artifact and its replica are deleted")
+		}
+		patched, err := tasks.Get(ctx, "my-task", metav1.GetOptions{})
+		require.NoError(t, err)
+		return patched.Status.ArtifactResultsByNode["my-node"].ArtifactResults["my-art"]
+	}
+
+	t.Run("Deleted", func(t *testing.T) {
+		result := run(t, nil)
+		assert.True(t, result.Success)
+		assert.Nil(t, result.Error)
+	})
+	t.Run("ReplicaNotDeleted", func(t *testing.T) {
+		result := run(t, errors.New("forbidden"))
+		assert.False(t, result.Success, "the artifact is not deleted until its replicas are")
+		require.NotNil(t, result.Error)
+		assert.Contains(t, *result.Error, "forbidden")
+	})
+}
@@ -8,6 +8,7 @@ func NewArtifactCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "artifact",
 	}
+	cmd.AddCommand(NewArtifactCopyCommand())
 	cmd.AddCommand(NewArtifactDeleteCommand())
 	return cmd
 }
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -17,6 +18,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/util/retry"
 
 	"github.com/argoproj/argo-workflows/v3/util/errors"
@@ -33,6 +35,7 @@ var (
 	includeScriptOutput = os.Getenv(common.EnvVarIncludeScriptOutput) == "true" // capture stdout/combined
 	template            = &wfv1.Template{}
 	logger              = log.WithField("argo", true)
+	terminationLog      = "/dev/termination-log"
 )
 
 func NewEmissaryCommand() *cobra.Command {
@@ -85,13 +88,8 @@ func NewEmissaryCommand() *cobra.Command {
 							select {
 							// If we receive a terminated or killed signal, we should exit immediately.
 							case s := <-signals:
-								switch s {
-								case osspecific.Term:
-									// exit with 128 + 15 (SIGTERM)
-									return errors.NewExitErr(143)
-								case os.Kill:
-									// exit with 128 + 9 (SIGKILL)
-									return errors.NewExitErr(137)
+								if err := signalExitErr(s); err != nil {
+									return err
 								}
 							default:
 								data, _ := os.ReadFile(filepath.Clean(varRunArgo + "/ctr/" + y + "/exitcode"))
@@ -111,6 +109,12 @@ func NewEmissaryCommand() *cobra.Command {
 				}
 			}
 
+			if template.WaitForSidecars == wfv1.WaitForSidecarsReadinessProbe && !slices.Contains(template.GetSidecarNames(), containerName) {
+				if err := waitForSidecars(template, signals); err != nil {
+					return err
+				}
+			}
+
 			name, err = exec.LookPath(name)
 			if err != nil {
 				return fmt.Errorf("failed to find name in PATH: %w", err)
@@ -134,6 +138,9 @@ func NewEmissaryCommand() *cobra.Command {
 				return fmt.Errorf("failed to get retry strategy: %w", err)
 			}
 
+			// the kills of the container before the command starts are not the command's
+			oomKills, _ := osspecific.OOMKills()
+
 			cmdErr := retry.OnError(backoff, func(error) bool { return true }, func() error {
 
 				command, closer, err := startCommand(name, args, template)
@@ -200,6 +207,11 @@ func NewEmissaryCommand() *cobra.Command {
 				}
 			}
 
+			if message := exitMessage(cmdErr, oomKills); message != "" {
+				logger.Info(message)
+				writeTerminationMessage(message)
+			}
+
 			if containerName == common.MainContainerName {
 				for _, x := range template.Outputs.Parameters {
 					if x.ValueFrom != nil && x.ValueFrom.Path != "" {
@@ -209,14 +221,29 @@ func NewEmissaryCommand() *cobra.Command {
 					}
 				}
 				for _, x := range template.Outputs.Artifacts {
-					if x.Path != "" {
+					if x.Path != "" && x.Container == "" {
+						if err := saveArtifact(x.Path); err != nil {
+							return err
+						}
+					}
+				}
+				if template.Checkpoint != nil {
+					for _, x := range template.Checkpoint.Artifacts {
 						if err := saveArtifact(x.Path); err != nil {
 							return err
 						}
 					}
 				}
 			} else {
-				logger.Info("not saving outputs - not main container")
+				// the wait container cannot see the filesystem of a sidecar, so the sidecar saves its artifacts itself
+				// once it has been stopped
+				for _, x := range template.Outputs.Artifacts {
+					if x.Path != "" && x.Container == containerName {
+						if err := saveSidecarArtifact(x.Path); err != nil {
+							return err
+						}
+					}
+				}
 			}
 
 			return cmdErr // this is the error returned from cmd.Wait(), which maybe an exitError
@@ -224,6 +251,35 @@ func NewEmissaryCommand() *cobra.Command {
 	}
 }
 
+// exitMessage returns why the command was killed, if it was: the signal that killed it, and whether the kernel killed
+// processes of the container because it ran out of memory since there were oomKillsBefore kills
+func exitMessage(cmdErr error, oomKillsBefore int) string {
+	var reasons []string
+	if signaled, ok := cmdErr.(errors.Signaled); ok {
+		reasons = append(reasons, fmt.Sprintf("killed by signal %d (%s)", signaled.Signal(), signaled.Signal()))
+	}
+	if oomKills, ok := osspecific.OOMKills(); ok && oomKills > oomKillsBefore {
+		reason := fmt.Sprintf("out of memory: the kernel killed %d process(es) of the container", oomKills-oomKillsBefore)
+		if limit, ok := osspecific.MemoryLimit(); ok {
+			reason += fmt.Sprintf(" as it reached its memory limit of %s", resource.NewQuantity(limit, resource.BinarySI))
+		}
+		reasons = append(reasons, reason)
+	}
+	return strings.Join(reasons, ": ")
+}
+
+// writeTerminationMessage writes the message to the termination log of the container, which Kubernetes reports as the
+// message of the container, and so the controller as the message of the node. A message that the command wrote
+// itself is kept.
+func writeTerminationMessage(message string) {
+	if info, err := os.Stat(terminationLog); err == nil && info.Size() > 0 {
+		return
+	}
+	if err := os.WriteFile(terminationLog, []byte(message), 0o644); err != nil {
+		logger.WithError(err).Warn("failed to write termination message")
+	}
+}
+
 func startCommand(name string, args []string, template *wfv1.Template) (*exec.Cmd, func(), error) {
 	command := exec.Command(name, args...)
 	command.Env = os.Environ()
@@ -275,11 +331,19 @@ func saveArtifact(srcPath string) error {
 		logger.Infof("no need to save artifact - on overlapping volume: %s", srcPath)
 		return nil
 	}
+	return tarArtifact(srcPath, filepath.Join(varRunArgo, "/outputs/artifacts/", strings.TrimSuffix(srcPath, "/")+".tgz"))
+}
+
+// saveSidecarArtifact saves an output artifact of a sidecar to its ctr directory, where the wait container copies it from
+func saveSidecarArtifact(srcPath string) error {
+	return tarArtifact(srcPath, filepath.Join(varRunArgo, "/ctr/", containerName, "/outputs/artifacts/", strings.TrimSuffix(srcPath, "/")+".tgz"))
+}
+
+func tarArtifact(srcPath, dstPath string) error {
 	if _, err := os.Stat(srcPath); os.IsNotExist(err) { // might be optional, so we ignore
 		logger.WithError(err).Warnf("cannot save artifact %s", srcPath)
 		return nil
 	}
-	dstPath := filepath.Join(varRunArgo, "/outputs/artifacts/", strings.TrimSuffix(srcPath, "/")+".tgz")
 	logger.Infof("%s -> %s", srcPath, dstPath)
 	z := filepath.Dir(dstPath)
 	if err := os.MkdirAll(z, 0o755); err != nil { // chmod rwxr-xr-x
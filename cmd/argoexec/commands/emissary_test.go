@@ -20,6 +20,7 @@ func TestEmissary(t *testing.T) {
 	tmp := t.TempDir()
 
 	varRunArgo = tmp
+	terminationLog = tmp + "/termination-log"
 	includeScriptOutput = true
 
 	err := os.WriteFile(varRunArgo+"/template", []byte(`{}`), 0o600)
@@ -80,6 +81,18 @@ func TestEmissary(t *testing.T) {
 			wg.Wait()
 		}
 	})
+	t.Run("SignalMessage", func(t *testing.T) {
+		_ = os.Remove(terminationLog)
+		err := os.WriteFile(varRunArgo+"/ctr/main/signal", []byte(strconv.Itoa(int(syscall.SIGKILL))), 0o600)
+		require.NoError(t, err)
+		err = run("sleep 3")
+		signaled, ok := err.(errors.Signaled)
+		require.True(t, ok)
+		assert.Equal(t, syscall.SIGKILL, signaled.Signal())
+		data, err := os.ReadFile(terminationLog)
+		require.NoError(t, err)
+		assert.Equal(t, "killed by signal 9 (killed)", string(data))
+	})
 	t.Run("Artifact", func(t *testing.T) {
 		err = os.WriteFile(varRunArgo+"/template", []byte(`
 {
@@ -6,6 +6,8 @@ import (
 
 	"github.com/argoproj/pkg/stats"
 	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 )
 
 func NewInitCommand() *cobra.Command {
@@ -28,6 +30,7 @@ func loadArtifacts(ctx context.Context) error {
 	wfExecutor := initExecutor()
 	defer wfExecutor.HandleError(ctx)
 	defer stats.LogStats()
+	defer metrics.RunExecutor(ctx)()
 
 	if err := wfExecutor.Init(); err != nil {
 		wfExecutor.AddError(err)
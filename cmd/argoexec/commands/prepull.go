@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/executor/emissary"
+)
+
+// NewPrePullCommand returns the command of the first init container of the pods that pre-pull images. It copies
+// argoexec into their shared volume, so that each of the images that are pre-pulled can run it, as they may not have a
+// shell.
+func NewPrePullCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pre-pull",
+		Short: "Copy argoexec for the containers of a pod that pre-pulls images",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return emissary.CopyBinary()
+		},
+	}
+}
@@ -69,6 +69,7 @@ func NewRootCommand() *cobra.Command {
 	command.AddCommand(NewEmissaryCommand())
 	command.AddCommand(NewInitCommand())
 	command.AddCommand(NewKillCommand())
+	command.AddCommand(NewPrePullCommand())
 	command.AddCommand(NewResourceCommand())
 	command.AddCommand(NewWaitCommand())
 	command.AddCommand(NewDataCommand())
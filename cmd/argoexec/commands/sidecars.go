@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/executor/osspecific"
+)
+
+// sidecarProbe is the readiness probe of a sidecar that a main container waits for
+type sidecarProbe struct {
+	container apiv1.Container
+	successes int32
+	next      time.Time
+}
+
+// waitForSidecars waits until the readiness probes of the sidecars of the template have passed, so that a main
+// container that depends on a sidecar, e.g. a proxy or a database, does not start before the sidecar is ready. The
+// containers of a pod share its network, so the probes connect to the sidecars on localhost unless they set a host.
+func waitForSidecars(tmpl *wfv1.Template, signals <-chan os.Signal) error {
+	probes := map[string]*sidecarProbe{}
+	for _, s := range tmpl.Sidecars {
+		if s.ReadinessProbe != nil {
+			logger.Infof("waiting for sidecar %q to be ready", s.Name)
+			probes[s.Name] = &sidecarProbe{
+				container: s.Container,
+				next:      time.Now().Add(time.Duration(s.ReadinessProbe.InitialDelaySeconds) * time.Second),
+			}
+		}
+	}
+	for len(probes) > 0 {
+		next := time.Time{}
+		for name, p := range probes {
+			if time.Now().Before(p.next) {
+				if next.IsZero() || p.next.Before(next) {
+					next = p.next
+				}
+				continue
+			}
+			probe := p.container.ReadinessProbe
+			p.next = time.Now().Add(probeDuration(probe.PeriodSeconds, 10))
+			if next.IsZero() || p.next.Before(next) {
+				next = p.next
+			}
+			if err := runProbe(p.container); err != nil {
+				logger.WithError(err).Infof("sidecar %q is not ready", name)
+				p.successes = 0
+				continue
+			}
+			p.successes++
+			if p.successes >= max(probe.SuccessThreshold, 1) {
+				logger.Infof("sidecar %q is ready", name)
+				delete(probes, name)
+			}
+		}
+		if len(probes) == 0 {
+			break
+		}
+		select {
+		case s := <-signals:
+			if err := signalExitErr(s); err != nil {
+				return err
+			}
+		case <-time.After(time.Until(next)):
+		}
+	}
+	return nil
+}
+
+// signalExitErr returns the error to exit with for a signal received before the command has started, or nil if the
+// signal is ignored until then
+func signalExitErr(s os.Signal) error {
+	switch s {
+	case osspecific.Term:
+		// exit with 128 + 15 (SIGTERM)
+		return errors.NewExitErr(143)
+	case os.Kill:
+		// exit with 128 + 9 (SIGKILL)
+		return errors.NewExitErr(137)
+	}
+	return nil
+}
+
+func probeDuration(seconds, defaultSeconds int32) time.Duration {
+	if seconds <= 0 {
+		seconds = defaultSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// runProbe runs the readiness probe of the container once, as the kubelet would
+func runProbe(ctr apiv1.Container) error {
+	probe := ctr.ReadinessProbe
+	timeout := probeDuration(probe.TimeoutSeconds, 1)
+	switch {
+	case probe.HTTPGet != nil:
+		get := probe.HTTPGet
+		port, err := containerPort(ctr, get.Port)
+		if err != nil {
+			return err
+		}
+		scheme := strings.ToLower(string(get.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		path := get.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(probeHost(get.Host), port), path), nil)
+		if err != nil {
+			return err
+		}
+		for _, h := range get.HTTPHeaders {
+			if strings.EqualFold(h.Name, "Host") {
+				req.Host = h.Value
+			} else {
+				req.Header.Add(h.Name, h.Value)
+			}
+		}
+		client := &http.Client{
+			Timeout: timeout,
+			// like the kubelet, the certificates of sidecars are not verified
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("HTTP probe failed with status code %d", resp.StatusCode)
+		}
+		return nil
+	case probe.TCPSocket != nil:
+		port, err := containerPort(ctr, probe.TCPSocket.Port)
+		if err != nil {
+			return err
+		}
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(probeHost(probe.TCPSocket.Host), port), timeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	return fmt.Errorf("readiness probe must have an httpGet or tcpSocket handler")
+}
+
+func probeHost(host string) string {
+	if host == "" {
+		return "localhost"
+	}
+	return host
+}
+
+// containerPort returns the number of the port of a probe, which may be the name of a port of the container
+func containerPort(ctr apiv1.Container, port intstr.IntOrString) (string, error) {
+	if port.Type == intstr.Int {
+		return strconv.Itoa(port.IntValue()), nil
+	}
+	for _, p := range ctr.Ports {
+		if p.Name == port.StrVal {
+			return strconv.Itoa(int(p.ContainerPort)), nil
+		}
+	}
+	if _, err := strconv.Atoi(port.StrVal); err == nil {
+		return port.StrVal, nil
+	}
+	return "", fmt.Errorf("container %q has no port named %q", ctr.Name, port.StrVal)
+}
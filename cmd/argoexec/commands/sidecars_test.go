@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/executor/osspecific"
+)
+
+func sidecarTemplate(probe *apiv1.Probe) *wfv1.Template {
+	return &wfv1.Template{
+		WaitForSidecars: wfv1.WaitForSidecarsReadinessProbe,
+		Sidecars: []wfv1.UserContainer{
+			{Container: apiv1.Container{Name: "no-probe"}},
+			{Container: apiv1.Container{
+				Name:           "proxy",
+				Ports:          []apiv1.ContainerPort{{Name: "admin", ContainerPort: 9901}},
+				ReadinessProbe: probe,
+			}},
+		},
+	}
+}
+
+func TestWaitForSidecars(t *testing.T) {
+	t.Run("HTTP", func(t *testing.T) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/ready", r.URL.Path)
+			assert.Equal(t, "bar", r.Header.Get("X-Foo"))
+			if atomic.AddInt32(&requests, 1) < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		host, port, err := net.SplitHostPort(u.Host)
+		require.NoError(t, err)
+		p, err := strconv.Atoi(port)
+		require.NoError(t, err)
+
+		err = waitForSidecars(sidecarTemplate(&apiv1.Probe{
+			ProbeHandler: apiv1.ProbeHandler{HTTPGet: &apiv1.HTTPGetAction{
+				Host:        host,
+				Path:        "ready",
+				Port:        intstr.FromInt32(int32(p)),
+				HTTPHeaders: []apiv1.HTTPHeader{{Name: "X-Foo", Value: "bar"}},
+			}},
+			PeriodSeconds: 1,
+		}), make(chan os.Signal))
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+	t.Run("TCP", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		err = waitForSidecars(sidecarTemplate(&apiv1.Probe{
+			ProbeHandler: apiv1.ProbeHandler{TCPSocket: &apiv1.TCPSocketAction{
+				Host: "127.0.0.1",
+				Port: intstr.FromInt32(int32(listener.Addr().(*net.TCPAddr).Port)),
+			}},
+		}), make(chan os.Signal))
+		require.NoError(t, err)
+	})
+	t.Run("Terminated", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := listener.Addr().(*net.TCPAddr).Port
+		require.NoError(t, listener.Close())
+
+		signals := make(chan os.Signal, 1)
+		signals <- osspecific.Term
+		err = waitForSidecars(sidecarTemplate(&apiv1.Probe{
+			ProbeHandler: apiv1.ProbeHandler{TCPSocket: &apiv1.TCPSocketAction{Host: "127.0.0.1", Port: intstr.FromInt32(int32(port))}},
+		}), signals)
+		require.Error(t, err)
+		assert.Equal(t, 143, err.(errors.Exited).ExitCode())
+	})
+}
+
+func TestContainerPort(t *testing.T) {
+	ctr := apiv1.Container{Name: "proxy", Ports: []apiv1.ContainerPort{{Name: "admin", ContainerPort: 9901}}}
+
+	port, err := containerPort(ctr, intstr.FromInt32(8080))
+	require.NoError(t, err)
+	assert.Equal(t, "8080", port)
+
+	port, err = containerPort(ctr, intstr.FromString("admin"))
+	require.NoError(t, err)
+	assert.Equal(t, "9901", port)
+
+	_, err = containerPort(ctr, intstr.FromString("http"))
+	assert.EqualError(t, err, `container "proxy" has no port named "http"`)
+}
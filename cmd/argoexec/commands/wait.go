@@ -7,6 +7,8 @@ import (
 
 	"github.com/argoproj/pkg/stats"
 	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 )
 
 func NewWaitCommand() *cobra.Command {
@@ -35,6 +37,7 @@ func waitContainer(ctx context.Context) error {
 	defer wfExecutor.FinalizeOutput(bgCtx) // Ensures the LabelKeyReportOutputsCompleted is set to true.
 	defer stats.LogStats()
 	stats.StartStatsTicker(5 * time.Minute)
+	defer metrics.RunExecutor(bgCtx)()
 
 	// Create a new empty (placeholder) task result with LabelKeyReportOutputsCompleted set to false.
 	wfExecutor.InitializeOutput(bgCtx)
@@ -45,6 +48,14 @@ func waitContainer(ctx context.Context) error {
 		wfExecutor.AddError(err)
 	}
 
+	// Saving the checkpoint, if the pod is being stopped, e.g. as it is being preempted
+	if ctx.Err() != nil {
+		err = wfExecutor.SaveCheckpoint(bgCtx)
+		if err != nil {
+			wfExecutor.AddError(err)
+		}
+	}
+
 	if wfExecutor.Template.Resource != nil {
 		// Save log artifacts for resource template
 		err = wfExecutor.ReportOutputsLogs(bgCtx)
@@ -29,6 +29,7 @@ import (
 	wfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	cmdutil "github.com/argoproj/argo-workflows/v3/util/cmd"
 	"github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/util/faults"
 	kubecli "github.com/argoproj/argo-workflows/v3/util/kube/cli"
 	"github.com/argoproj/argo-workflows/v3/util/logs"
 	pprofutil "github.com/argoproj/argo-workflows/v3/util/pprof"
@@ -93,6 +94,7 @@ func NewRootCommand() *cobra.Command {
 
 			logs.AddK8SLogTransportWrapper(config)
 			metrics.AddMetricsTransportWrapper(ctx, config)
+			faults.AddK8SFaultTransportWrapper(config)
 
 			namespace, _, err := clientConfig.Namespace()
 			if err != nil {
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AgentTimeouts bounds how long the agent waits for the requests of HTTP templates and the calls of executor plugins,
+// so that a single request or call cannot keep the agent, and its workflow, waiting indefinitely
+type AgentTimeouts struct {
+	// Default is the timeout of the requests and calls of templates that do not set one, which is 30s if it is not set
+	Default *metav1.Duration `json:"default,omitempty"`
+	// Max is the longest timeout that a template may set, longer ones are limited to it. There is no maximum if it is
+	// not set.
+	Max *metav1.Duration `json:"max,omitempty"`
+}
+
+func (t *AgentTimeouts) GetDefault() time.Duration {
+	if t == nil || t.Default == nil || t.Default.Duration <= 0 {
+		return 30 * time.Second
+	}
+	return t.Default.Duration
+}
+
+func (t *AgentTimeouts) GetMax() time.Duration {
+	if t == nil || t.Max == nil || t.Max.Duration <= 0 {
+		return 0
+	}
+	return t.Max.Duration
+}
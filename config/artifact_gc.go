@@ -0,0 +1,36 @@
+package config
+
+// ArtifactGCRBACMode is how the controller makes sure that artifact GC pods are allowed to do their work
+type ArtifactGCRBACMode string
+
+const (
+	// ArtifactGCRBACProvision creates the service account, Role, and RoleBinding in each namespace
+	ArtifactGCRBACProvision ArtifactGCRBACMode = "Provision"
+	// ArtifactGCRBACValidate checks that the service account is allowed, and reports on the workflow if it is not
+	ArtifactGCRBACValidate ArtifactGCRBACMode = "Validate"
+)
+
+// ArtifactGCConfig configures the pods that delete the artifacts of workflows
+type ArtifactGCConfig struct {
+	// ServiceAccountName is the service account of the pods of workflows and artifacts that do not specify their own,
+	// instead of the default service account of their namespace
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// RBAC is "Provision" to create the default service account, and a Role and RoleBinding that allow it to delete
+	// artifacts, in each namespace that artifacts are deleted in, or "Validate" to check that the service account of
+	// each pod is allowed to, and report on the workflow if it is not. Nothing is done if it is not set.
+	RBAC ArtifactGCRBACMode `json:"rbac,omitempty"`
+}
+
+func (c *ArtifactGCConfig) GetServiceAccountName() string {
+	if c == nil {
+		return ""
+	}
+	return c.ServiceAccountName
+}
+
+func (c *ArtifactGCConfig) GetRBAC() ArtifactGCRBACMode {
+	if c == nil {
+		return ""
+	}
+	return c.RBAC
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArtifactOperationsConfig configures how the loads and saves of artifacts are retried, and how the controller stops
+// creating pods that use an artifact repository which is down
+type ArtifactOperationsConfig struct {
+	// Retry is the budget of retries of each load and save of the executor
+	Retry *ArtifactRetryBudget `json:"retry,omitempty"`
+	// CircuitBreaker fails pods that use an artifact repository which is down, rather than creating them
+	CircuitBreaker *ArtifactCircuitBreaker `json:"circuitBreaker,omitempty"`
+	// QuotaPause postpones new workflows that save artifacts to an artifact repository which is out of space
+	QuotaPause *ArtifactQuotaPause `json:"quotaPause,omitempty"`
+	// MemoryStagingLimit is the size of the memory-backed volume of a pod that its input artifacts with stageInMemory
+	// are loaded into, e.g. "256Mi", which is 64Mi if it is not set
+	MemoryStagingLimit *resource.Quantity `json:"memoryStagingLimit,omitempty"`
+}
+
+// ArtifactRetryBudget limits the retries of the transient failures of a load or save of an artifact, which are
+// retried with the backoff of the executor otherwise
+type ArtifactRetryBudget struct {
+	// MaxAttempts is the most times that a load or save is attempted, including the first time
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// MaxBackoff caps the time between two attempts
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// ArtifactCircuitBreaker opens for an artifact repository after loads or saves of it fail transiently in a number of
+// pods in a row. Whilst it is open, nodes that would load or save artifacts with the repository fail with an error
+// rather than creating their pods. Once it closes, a single failure opens it again, until a pod succeeds.
+type ArtifactCircuitBreaker struct {
+	// FailureThreshold is the number of pods in a row that open the circuit breaker, which is 5 if it is not set
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// OpenDuration is how long the circuit breaker stays open, which is 1m if it is not set
+	OpenDuration *metav1.Duration `json:"openDuration,omitempty"`
+}
+
+// ArtifactQuotaPause postpones starting workflows that save artifacts to an artifact repository once a pod fails
+// because the repository is out of space, or over its quota. The workflows start once a pod saves artifacts to the
+// repository successfully, or the duration has passed.
+type ArtifactQuotaPause struct {
+	// Duration is the longest that workflows are postponed for, which is 10m if it is not set
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+func (c *ArtifactOperationsConfig) GetRetry() *ArtifactRetryBudget {
+	if c == nil {
+		return nil
+	}
+	return c.Retry
+}
+
+func (c *ArtifactOperationsConfig) GetCircuitBreaker() *ArtifactCircuitBreaker {
+	if c == nil {
+		return nil
+	}
+	return c.CircuitBreaker
+}
+
+func (c *ArtifactOperationsConfig) GetQuotaPause() *ArtifactQuotaPause {
+	if c == nil {
+		return nil
+	}
+	return c.QuotaPause
+}
+
+func (c *ArtifactOperationsConfig) GetMemoryStagingLimit() resource.Quantity {
+	if c == nil || c.MemoryStagingLimit == nil || c.MemoryStagingLimit.Sign() <= 0 {
+		return resource.MustParse("64Mi")
+	}
+	return *c.MemoryStagingLimit
+}
+
+func (b *ArtifactCircuitBreaker) GetFailureThreshold() int {
+	if b.FailureThreshold <= 0 {
+		return 5
+	}
+	return b.FailureThreshold
+}
+
+func (b *ArtifactCircuitBreaker) GetOpenDuration() time.Duration {
+	if b.OpenDuration == nil || b.OpenDuration.Duration <= 0 {
+		return time.Minute
+	}
+	return b.OpenDuration.Duration
+}
+
+func (p *ArtifactQuotaPause) GetDuration() time.Duration {
+	if p.Duration == nil || p.Duration.Duration <= 0 {
+		return 10 * time.Minute
+	}
+	return p.Duration.Duration
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// ClientCertConfig configures the client-cert auth mode, which authenticates clients of the Argo Server by the
+// certificates that they present in the TLS handshake. Clients are then authorized as the service account whose
+// workflows.argoproj.io/rbac-rule matches their identity, like SSO RBAC.
+type ClientCertConfig struct {
+	// CA references a secret containing the PEM encoded certificates of the authorities that sign client certificates
+	CA apiv1.SecretKeySelector `json:"ca"`
+	// Rules map the subject alternative names of client certificates to identities. The first rule that matches one
+	// of the names of a certificate is used, and certificates that match none of them are not authenticated.
+	Rules []ClientCertRule `json:"rules,omitempty"`
+}
+
+// ClientCertRule maps the subject alternative names of client certificates that match it to an identity
+type ClientCertRule struct {
+	// SAN is a regular expression that must match the whole of a DNS name, email address, URI, e.g. a SPIFFE ID, or IP
+	// address of the certificate
+	SAN string `json:"san"`
+	// Subject is the subject of the identity, which may refer to the submatches of SAN, e.g. `$1`. It is the name that
+	// matched if it is not set.
+	Subject string `json:"subject,omitempty"`
+	// Groups are the groups of the identity, which may refer to the submatches of SAN too
+	Groups []string `json:"groups,omitempty"`
+}
@@ -116,8 +116,68 @@ type Config struct {
 	// SSO in settings for single-sign on
 	SSO SSOConfig `json:"sso,omitempty"`
 
+	// ClientCert configures the client-cert auth mode of the Argo Server, which authenticates clients by their TLS
+	// certificates
+	ClientCert *ClientCertConfig `json:"clientCert,omitempty"`
+
 	// Synchronization via databases config
 	Synchronization *SyncConfig `json:"synchronization,omitempty"`
+
+	// PodNetwork is the DNS config, proxy, and trusted CA bundle applied to all the Pods the controller creates
+	PodNetwork *PodNetworkConfig `json:"podNetwork,omitempty"`
+
+	// SubmissionQueue configures the Argo Server to queue the workflows submitted durably whilst the Kubernetes API is
+	// unavailable, and to create them once it is available again
+	SubmissionQueue *SubmissionQueueConfig `json:"submissionQueue,omitempty"`
+
+	// PodPriorityClasses maps ranges of the priorities of workflows to the PriorityClasses of their pods, so that higher
+	// priority workflows are also scheduled first. The first range that a priority is in is used.
+	PodPriorityClasses []PodPriorityClass `json:"podPriorityClasses,omitempty"`
+
+	// GCPause pauses the garbage collection of workflows and archived workflows, e.g. whilst backups are taken
+	GCPause *GCPause `json:"gcPause,omitempty"`
+
+	// ImagePrePull pre-pulls the images of the templates of cron workflows that set prePull onto the nodes shortly
+	// before their runs
+	ImagePrePull *ImagePrePull `json:"imagePrePull,omitempty"`
+
+	// ResultDelivery lists the URLs that the results of workflows may be delivered to. Results are not delivered if it
+	// is not set.
+	ResultDelivery *ResultDelivery `json:"resultDelivery,omitempty"`
+
+	// ArtifactGC configures the service account of artifact GC pods, and how the controller makes sure it is allowed to
+	// delete artifacts
+	ArtifactGC *ArtifactGCConfig `json:"artifactGC,omitempty"`
+
+	// CheckInputArtifacts makes the controller check that the required input artifacts of a pod exist before it
+	// creates the pod, and fail the node if one does not. The controller needs to be able to read the artifacts'
+	// credentials and reach their storage.
+	CheckInputArtifacts bool `json:"checkInputArtifacts,omitempty"`
+
+	// TemplateCompileCacheSize is the number of validated workflows that the controller remembers, by the versions of
+	// the templates their validation resolved and their spec, so that workflows submitted again from the same template
+	// skip validation. Nothing is cached if it is not set.
+	TemplateCompileCacheSize int `json:"templateCompileCacheSize,omitempty"`
+
+	// ArtifactOperations configures the retries of the loads and saves of artifacts, and a circuit breaker per artifact
+	// repository
+	ArtifactOperations *ArtifactOperationsConfig `json:"artifactOperations,omitempty"`
+
+	// ExitCodeClasses are classes of the exit codes of failed nodes, e.g. transient or permanent, that the expressions
+	// of retry strategies can retry by. Defaults to 137 and 143 being transient, and 126 and 127 being permanent.
+	ExitCodeClasses map[string][]int `json:"exitCodeClasses,omitempty"`
+
+	// AgentTimeouts are the default and maximum timeouts of the requests of HTTP templates and the calls of executor
+	// plugins, which templates may override within the maximum
+	AgentTimeouts *AgentTimeouts `json:"agentTimeouts,omitempty"`
+
+	// Memoization configures the memoization of templates, such as the maximum age of the cache entries they hit
+	Memoization *MemoizationConfig `json:"memoization,omitempty"`
+
+	// PodNameTemplate is the template of the names of the pods of workflows that do not have their own
+	// workflows.argoproj.io/pod-name-template annotation, e.g. "{{workflow.name}}-{{template.name}}-{{node.hash}}". It is
+	// set on workflows when they start, so that changing it does not rename the pods of running workflows.
+	PodNameTemplate string `json:"podNameTemplate,omitempty"`
 }
 
 func (c Config) GetExecutor() *apiv1.Container {
@@ -215,10 +275,56 @@ type PersistConfig struct {
 	ArchiveLabelSelector *metav1.LabelSelector `json:"archiveLabelSelector,omitempty"`
 	// ArchiveTTL is the time to live for archived Workflows
 	ArchiveTTL TTL `json:"archiveTTL,omitempty"`
+	// ArchiveOutputParameters are the names of the Workflows' output parameters whose values are indexed in the archive,
+	// so that archived Workflows can be listed by them
+	ArchiveOutputParameters []string `json:"archiveOutputParameters,omitempty"`
 	// ClusterName is the name of the cluster (or technically controller) for the persistence database
 	ClusterName string `json:"clusterName,omitempty"`
 	// SkipMigration skips database migration even if needed
 	SkipMigration bool `json:"skipMigration,omitempty"`
+	// MemoizationCache saves the entries of memoization caches in the persistence database rather than in ConfigMaps,
+	// which are limited to 1MB
+	MemoizationCache *MemoizationCacheConfig `json:"memoizationCache,omitempty"`
+	// StatusHistory saves periodic snapshots of the statuses of workflows in the persistence database, so that the
+	// states of their nodes at a time in the past can be viewed
+	StatusHistory *StatusHistoryConfig `json:"statusHistory,omitempty"`
+}
+
+// MemoizationCacheConfig configures the memoization caches that are saved in the persistence database
+type MemoizationCacheConfig struct {
+	// TTL is how long an entry is kept for after it was last hit, or saved if it has not been hit, which is 7d if it is
+	// not set
+	TTL TTL `json:"ttl,omitempty"`
+}
+
+func (c *MemoizationCacheConfig) GetTTL() time.Duration {
+	if c == nil || c.TTL <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(c.TTL)
+}
+
+// StatusHistoryConfig configures the snapshots of the statuses of workflows that are saved in the persistence database
+type StatusHistoryConfig struct {
+	// Interval is the minimum time between the snapshots of a workflow, which is 1m if it is not set. A snapshot is
+	// also saved when the workflow completes.
+	Interval TTL `json:"interval,omitempty"`
+	// TTL is how long a snapshot is kept for after it was saved, which is 7d if it is not set
+	TTL TTL `json:"ttl,omitempty"`
+}
+
+func (c *StatusHistoryConfig) GetInterval() time.Duration {
+	if c == nil || c.Interval <= 0 {
+		return time.Minute
+	}
+	return time.Duration(c.Interval)
+}
+
+func (c *StatusHistoryConfig) GetTTL() time.Duration {
+	if c == nil || c.TTL <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(c.TTL)
 }
 
 func (c PersistConfig) GetArchiveLabelSelector() (labels.Selector, error) {
@@ -235,6 +341,13 @@ func (c PersistConfig) GetClusterName() string {
 	return "default"
 }
 
+// SubmissionQueueConfig configures the durable queue of workflow submissions of the Argo Server
+type SubmissionQueueConfig struct {
+	// SQLitePath is the path of a SQLite database to queue submissions in, which must be on a persistent volume.
+	// The persistence database is used if this is not set.
+	SQLitePath string `json:"sqlitePath,omitempty"`
+}
+
 // SyncConfig contains synchronization configuration for database locks (semaphores and mutexes)
 type SyncConfig struct {
 	DBConfig
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,12 @@ func Test_parseConfigMap(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotEmpty(t, c.ArtifactRepository)
 	})
+	t.Run("MemoizationMaxAge", func(t *testing.T) {
+		c := &Config{}
+		err := parseConfigMap(&apiv1.ConfigMap{Data: map[string]string{"memoization": "maxAge: 7d\n"}}, c)
+		require.NoError(t, err)
+		assert.Equal(t, 7*24*time.Hour, c.Memoization.GetMaxAge())
+	})
 	t.Run("Garbage", func(t *testing.T) {
 		c := &Config{}
 		err := parseConfigMap(&apiv1.ConfigMap{Data: map[string]string{"garbage": "garbage"}}, c)
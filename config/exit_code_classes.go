@@ -0,0 +1,33 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// defaultExitCodeClasses are the classes of exit codes if none are configured: pods that were killed, e.g. because they
+// were evicted or preempted, are transient, and commands that could not be run are permanent
+var defaultExitCodeClasses = map[string][]int{
+	"transient": {137, 143},
+	"permanent": {126, 127},
+}
+
+// GetExitCodeClass returns the class of the exit code, in order of the names of the classes, or an empty string if it is
+// in none of them, or is not a number
+func (c Config) GetExitCodeClass(exitCode string) string {
+	code, err := strconv.Atoi(exitCode)
+	if err != nil {
+		return ""
+	}
+	classes := c.ExitCodeClasses
+	if classes == nil {
+		classes = defaultExitCodeClasses
+	}
+	for _, class := range slices.Sorted(maps.Keys(classes)) {
+		if slices.Contains(classes[class], code) {
+			return class
+		}
+	}
+	return ""
+}
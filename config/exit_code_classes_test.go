@@ -0,0 +1,20 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExitCodeClass(t *testing.T) {
+	assert.Equal(t, "transient", Config{}.GetExitCodeClass("137"))
+	assert.Equal(t, "permanent", Config{}.GetExitCodeClass("127"))
+	assert.Empty(t, Config{}.GetExitCodeClass("1"))
+	assert.Empty(t, Config{}.GetExitCodeClass("-1"))
+	assert.Empty(t, Config{}.GetExitCodeClass(""))
+
+	c := Config{ExitCodeClasses: map[string][]int{"quota": {75}, "transient": {75, 137}}}
+	assert.Equal(t, "quota", c.GetExitCodeClass("75"))
+	assert.Equal(t, "transient", c.GetExitCodeClass("137"))
+	assert.Empty(t, c.GetExitCodeClass("127"), "the configured classes replace the default ones")
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GCPause pauses the garbage collection of workflows, by their TTLs and the retention policy, and of archived workflows,
+// so that backups of the cluster and of the database capture a consistent snapshot. Garbage collection resumes by
+// itself once the pause ends, and the workflows that expired during it are deleted then.
+type GCPause struct {
+	// Until pauses garbage collection until the time, e.g. for the length of a one-off backup
+	Until *metav1.Time `json:"until,omitempty"`
+	// Windows pause garbage collection regularly, e.g. for the scheduled backups
+	Windows []GCPauseWindow `json:"windows,omitempty"`
+}
+
+// GCPauseWindow pauses garbage collection for the duration from each of the times of the schedule
+type GCPauseWindow struct {
+	// Schedule is the cron schedule of the starts of the window
+	Schedule string `json:"schedule"`
+	// Duration is how long the window is
+	Duration metav1.Duration `json:"duration"`
+	// Timezone is the timezone of the schedule, which is the timezone of the controller if it is not set
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// end returns the end of the window that the time is in, or the zero time if it is in none of them
+func (w GCPauseWindow) end(now time.Time) (time.Time, error) {
+	schedule := w.Schedule
+	if w.Timezone != "" {
+		schedule = "CRON_TZ=" + w.Timezone + " " + schedule
+	}
+	s, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q of garbage collection pause window: %w", w.Schedule, err)
+	}
+	var end time.Time
+	// the time is in the windows that started less than their duration before it
+	for start := s.Next(now.Add(-w.Duration.Duration)); !start.IsZero() && !start.After(now); start = s.Next(start) {
+		end = start.Add(w.Duration.Duration)
+	}
+	return end, nil
+}
+
+// GetGCPausedUntil returns when the garbage collection pause that the time is in ends, or the zero time if garbage
+// collection is not paused. Windows with invalid schedules are returned as errors, and otherwise ignored.
+func (c Config) GetGCPausedUntil(now time.Time) (time.Time, error) {
+	if c.GCPause == nil {
+		return time.Time{}, nil
+	}
+	var until time.Time
+	if c.GCPause.Until != nil && c.GCPause.Until.After(now) {
+		until = c.GCPause.Until.Time
+	}
+	var errs []error
+	for _, w := range c.GCPause.Windows {
+		end, err := w.end(now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if end.After(until) {
+			until = end
+		}
+	}
+	return until, errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetGCPausedUntil(t *testing.T) {
+	now := time.Date(2024, 1, 1, 2, 30, 0, 0, time.UTC)
+	t.Run("NotPaused", func(t *testing.T) {
+		until, err := Config{}.GetGCPausedUntil(now)
+		require.NoError(t, err)
+		assert.True(t, until.IsZero())
+	})
+	t.Run("Until", func(t *testing.T) {
+		c := Config{GCPause: &GCPause{Until: &metav1.Time{Time: now.Add(time.Hour)}}}
+		until, err := c.GetGCPausedUntil(now)
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Hour), until)
+		until, err = c.GetGCPausedUntil(now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, until.IsZero())
+	})
+	t.Run("Windows", func(t *testing.T) {
+		c := Config{GCPause: &GCPause{Windows: []GCPauseWindow{
+			{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}, Timezone: "UTC"},
+			{Schedule: "0 3 * * 0", Duration: metav1.Duration{Duration: 2 * time.Hour}, Timezone: "Europe/London"},
+		}}}
+		until, err := c.GetGCPausedUntil(now)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), until.UTC())
+		until, err = c.GetGCPausedUntil(now.Add(-31 * time.Minute))
+		require.NoError(t, err)
+		assert.True(t, until.IsZero())
+		until, err = c.GetGCPausedUntil(now.Add(30 * time.Minute))
+		require.NoError(t, err)
+		assert.True(t, until.IsZero())
+		// 2024-01-07 is a Sunday
+		until, err = c.GetGCPausedUntil(time.Date(2024, 1, 7, 4, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 7, 5, 0, 0, 0, time.UTC), until.UTC())
+	})
+	t.Run("InvalidSchedule", func(t *testing.T) {
+		c := Config{GCPause: &GCPause{
+			Until:   &metav1.Time{Time: now.Add(time.Hour)},
+			Windows: []GCPauseWindow{{Schedule: "invalid", Duration: metav1.Duration{Duration: time.Hour}}},
+		}}
+		until, err := c.GetGCPausedUntil(now)
+		require.Error(t, err)
+		assert.Equal(t, now.Add(time.Hour), until)
+	})
+}
@@ -0,0 +1,32 @@
+package config
+
+import "time"
+
+// ImagePrePull configures the pre-pulling of the images of the templates of cron workflows that set prePull. Shortly
+// before each run of such a cron workflow, the controller creates a DaemonSet whose pods pull the images onto the
+// nodes that the workflow's pods run on, and it deletes the DaemonSet after the run starts. The images stay on the
+// nodes until the kubelet garbage-collects them.
+type ImagePrePull struct {
+	// LeadTime is how long before a run of a cron workflow its images are pre-pulled, which is 10m if it is not set
+	LeadTime TTL `json:"leadTime,omitempty"`
+	// PriorityClassName is the priority class of the pods that pre-pull images, which should be a low one, so that
+	// they are preempted by the pods of workflows rather than keep them from being scheduled
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// PauseImage is the image of the container that keeps the pods that pre-pull images running, which is
+	// registry.k8s.io/pause:3.10 if it is not set
+	PauseImage string `json:"pauseImage,omitempty"`
+}
+
+func (c *ImagePrePull) GetLeadTime() time.Duration {
+	if c == nil || c.LeadTime <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(c.LeadTime)
+}
+
+func (c *ImagePrePull) GetPauseImage() string {
+	if c == nil || c.PauseImage == "" {
+		return "registry.k8s.io/pause:3.10"
+	}
+	return c.PauseImage
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"time"
+)
+
+// MemoizationConfig configures the memoization of templates across all the caches of the controller
+type MemoizationConfig struct {
+	// MaxAge is the maximum age of the cache entries that templates hit. Templates may set a shorter maxAge, but a
+	// longer one, or none, is limited to it, so that results computed by earlier runs are not reused indefinitely. There
+	// is no maximum if it is not set.
+	MaxAge TTL `json:"maxAge,omitempty"`
+}
+
+func (c *MemoizationConfig) GetMaxAge() time.Duration {
+	if c == nil || c.MaxAge <= 0 {
+		return 0
+	}
+	return time.Duration(c.MaxAge)
+}
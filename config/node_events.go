@@ -1,13 +1,32 @@
 package config
 
+import apiv1 "k8s.io/api/core/v1"
+
 // NodeEvents configures how node events are emitted
 type NodeEvents struct {
 	// Enabled controls whether node events are emitted
 	Enabled *bool `json:"enabled,omitempty"`
 	// SendAsPod emits events as if from the Pod instead of the Workflow with annotations linking the event to the Workflow
 	SendAsPod bool `json:"sendAsPod,omitempty"`
+	// EventTypes overrides the type, Normal or Warning, of events by their reason, e.g. to emit WorkflowNodeSkipped
+	// events as Normal rather than Warning
+	EventTypes map[string]string `json:"eventTypes,omitempty"`
 }
 
 func (e NodeEvents) IsEnabled() bool {
 	return e.Enabled == nil || *e.Enabled
 }
+
+// GetEventType returns the type of events with the reason
+func (e NodeEvents) GetEventType(reason, defaultType string) string {
+	return getEventType(e.EventTypes, reason, defaultType)
+}
+
+// getEventType returns the type that the event types override the reason with, if it is a valid type
+func getEventType(eventTypes map[string]string, reason, defaultType string) string {
+	switch eventType := eventTypes[reason]; eventType {
+	case apiv1.EventTypeNormal, apiv1.EventTypeWarning:
+		return eventType
+	}
+	return defaultType
+}
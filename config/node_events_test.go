@@ -12,3 +12,11 @@ func TestNodeEvents_IsEnabled(t *testing.T) {
 	assert.False(t, NodeEvents{Enabled: ptr.To(false)}.IsEnabled())
 	assert.True(t, NodeEvents{Enabled: ptr.To(true)}.IsEnabled())
 }
+
+func TestNodeEvents_GetEventType(t *testing.T) {
+	e := NodeEvents{EventTypes: map[string]string{"WorkflowNodeSkipped": "Normal", "WorkflowNodeOmitted": "Invalid"}}
+	assert.Equal(t, "Normal", e.GetEventType("WorkflowNodeSkipped", "Warning"))
+	assert.Equal(t, "Warning", e.GetEventType("WorkflowNodeOmitted", "Warning"), "invalid types are ignored")
+	assert.Equal(t, "Warning", e.GetEventType("WorkflowNodeFailed", "Warning"))
+	assert.Equal(t, "Warning", NodeEvents{}.GetEventType("WorkflowNodeFailed", "Warning"))
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// PodNetworkConfig contains the network settings applied to all the Pods the controller creates,
+// e.g. for air-gapped or proxied clusters
+type PodNetworkConfig struct {
+	// DNSConfig is the DNS config of Pods, unless the Workflow specifies its own `dnsConfig`
+	DNSConfig *apiv1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// HTTPProxy is set as the HTTP_PROXY environment variable of containers that do not set it themselves
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is set as the HTTPS_PROXY environment variable of containers that do not set it themselves
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is set as the NO_PROXY environment variable of containers that do not set it themselves
+	NoProxy string `json:"noProxy,omitempty"`
+	// TrustedCABundle is the key of a ConfigMap, in the Workflow's namespace, containing PEM encoded CA certificates.
+	// It is mounted into every container, and SSL_CERT_FILE is set to its path.
+	TrustedCABundle *apiv1.ConfigMapKeySelector `json:"trustedCABundle,omitempty"`
+}
+
+// ProxyEnv returns the proxy environment variables, in both upper and lower case as tools differ in which they read
+func (c *PodNetworkConfig) ProxyEnv() []apiv1.EnvVar {
+	if c == nil {
+		return nil
+	}
+	var env []apiv1.EnvVar
+	for _, x := range []apiv1.EnvVar{{Name: "HTTP_PROXY", Value: c.HTTPProxy}, {Name: "HTTPS_PROXY", Value: c.HTTPSProxy}, {Name: "NO_PROXY", Value: c.NoProxy}} {
+		if x.Value != "" {
+			env = append(env, x, apiv1.EnvVar{Name: strings.ToLower(x.Name), Value: x.Value})
+		}
+	}
+	return env
+}
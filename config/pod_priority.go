@@ -0,0 +1,26 @@
+package config
+
+// PodPriorityClass maps a range of workflow priorities to the PriorityClass of the pods of the workflows
+type PodPriorityClass struct {
+	// Min is the lowest priority of the range, which is unbounded if it is not set
+	Min *int32 `json:"min,omitempty"`
+	// Max is the highest priority of the range, which is unbounded if it is not set
+	Max *int32 `json:"max,omitempty"`
+	// PriorityClassName is the PriorityClass of the pods of workflows whose priority is in the range
+	PriorityClassName string `json:"priorityClassName"`
+}
+
+// GetPodPriorityClassName returns the PriorityClass of the first range that the priority is in, or an empty string if it
+// is in none of them. Workflows without a priority have priority 0, as they do in the queue of the controller.
+func (c Config) GetPodPriorityClassName(priority *int32) string {
+	p := int32(0)
+	if priority != nil {
+		p = *priority
+	}
+	for _, x := range c.PodPriorityClasses {
+		if (x.Min == nil || p >= *x.Min) && (x.Max == nil || p <= *x.Max) {
+			return x.PriorityClassName
+		}
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetPodPriorityClassName(t *testing.T) {
+	c := Config{PodPriorityClasses: []PodPriorityClass{
+		{Min: ptr.To(int32(100)), PriorityClassName: "high"},
+		{Min: ptr.To(int32(1)), Max: ptr.To(int32(99)), PriorityClassName: "medium"},
+		{Max: ptr.To(int32(-1)), PriorityClassName: "low"},
+	}}
+	assert.Equal(t, "high", c.GetPodPriorityClassName(ptr.To(int32(100))))
+	assert.Equal(t, "medium", c.GetPodPriorityClassName(ptr.To(int32(99))))
+	assert.Equal(t, "medium", c.GetPodPriorityClassName(ptr.To(int32(1))))
+	assert.Empty(t, c.GetPodPriorityClassName(ptr.To(int32(0))))
+	assert.Empty(t, c.GetPodPriorityClassName(nil))
+	assert.Equal(t, "low", c.GetPodPriorityClassName(ptr.To(int32(-5))))
+	assert.Empty(t, Config{}.GetPodPriorityClassName(ptr.To(int32(100))))
+}
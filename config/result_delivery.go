@@ -0,0 +1,64 @@
+package config
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ResultDelivery configures the delivery of the results of workflows to the URLs in their spec.resultDelivery. The
+// controller makes those requests from inside the cluster, so a workflow author could otherwise have it reach
+// services that they cannot, such as cloud metadata endpoints or the Kubernetes API. Results are only delivered to
+// URLs that match one of AllowedURLPrefixes, and not at all if there are none.
+type ResultDelivery struct {
+	// AllowedURLPrefixes are the prefixes of the URLs that results may be delivered to, e.g.
+	// https://callbacks.example.com/argo. A URL matches a prefix if it has the same scheme, host and port, and its path
+	// is the path of the prefix or under it.
+	AllowedURLPrefixes []string `json:"allowedURLPrefixes,omitempty"`
+}
+
+// IsURLAllowed returns whether results may be delivered to the URL
+func (c *ResultDelivery) IsURLAllowed(rawURL string) bool {
+	if c == nil {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	for _, prefix := range c.AllowedURLPrefixes {
+		p, err := url.Parse(prefix)
+		if err != nil || p.Host == "" {
+			continue
+		}
+		if strings.EqualFold(u.Scheme, p.Scheme) &&
+			strings.EqualFold(u.Hostname(), p.Hostname()) &&
+			urlPort(u) == urlPort(p) &&
+			isPathUnder(u.Path, p.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// urlPort returns the port of the URL, or the default port of its scheme
+func urlPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	}
+	return ""
+}
+
+// isPathUnder returns whether the path is the prefix, or under it, once any dot segments have been removed, so that
+// /argo/../admin is not under /argo
+func isPathUnder(p, prefix string) bool {
+	p = path.Clean("/" + p)
+	prefix = path.Clean("/" + prefix)
+	return prefix == "/" || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultDeliveryIsURLAllowed(t *testing.T) {
+	var c *ResultDelivery
+	assert.False(t, c.IsURLAllowed("https://callbacks.example.com/argo"), "disabled when not configured")
+	assert.False(t, (&ResultDelivery{}).IsURLAllowed("https://callbacks.example.com/argo"), "disabled without prefixes")
+	c = &ResultDelivery{AllowedURLPrefixes: []string{"", "https://callbacks.example.com/"}}
+	assert.True(t, c.IsURLAllowed("https://callbacks.example.com/argo"))
+	assert.True(t, c.IsURLAllowed("https://CALLBACKS.example.com:443/argo"))
+	assert.False(t, c.IsURLAllowed("https://callbacks.example.com.evil.io/argo"))
+	assert.False(t, c.IsURLAllowed("https://callbacks.example.com@evil.io/argo"))
+	assert.False(t, c.IsURLAllowed("https://callbacks.example.com:8443/argo"))
+	assert.False(t, c.IsURLAllowed("http://callbacks.example.com/argo"))
+	assert.False(t, c.IsURLAllowed("http://169.254.169.254/latest/meta-data/"))
+	assert.False(t, c.IsURLAllowed("/argo"))
+	assert.False(t, c.IsURLAllowed("://callbacks.example.com/argo"))
+
+	t.Run("Path", func(t *testing.T) {
+		c := &ResultDelivery{AllowedURLPrefixes: []string{"https://callbacks.example.com/argo"}}
+		assert.True(t, c.IsURLAllowed("https://callbacks.example.com/argo"))
+		assert.True(t, c.IsURLAllowed("https://callbacks.example.com/argo/done?wf=1"))
+		assert.False(t, c.IsURLAllowed("https://callbacks.example.com/argo-admin"))
+		assert.False(t, c.IsURLAllowed("https://callbacks.example.com/argo/../admin"))
+		assert.False(t, c.IsURLAllowed("https://callbacks.example.com/"))
+	})
+}
@@ -4,8 +4,16 @@ package config
 type WorkflowEvents struct {
 	// Enabled controls whether workflow events are emitted
 	Enabled *bool `json:"enabled,omitempty"`
+	// EventTypes overrides the type, Normal or Warning, of events by their reason, e.g. to emit WorkflowFailed
+	// events as Normal rather than Warning
+	EventTypes map[string]string `json:"eventTypes,omitempty"`
 }
 
 func (e WorkflowEvents) IsEnabled() bool {
 	return e.Enabled == nil || *e.Enabled
 }
+
+// GetEventType returns the type of events with the reason
+func (e WorkflowEvents) GetEventType(reason, defaultType string) string {
+	return getEventType(e.EventTypes, reason, defaultType)
+}
@@ -161,8 +161,8 @@ func (e argoerr) HTTPCode() int {
 
 // IsCode is a helper to determine if the error is of a specific code
 func IsCode(code string, err error) bool {
-	if argoErr, ok := err.(argoerr); ok {
-		return argoErr.code == code
+	if argoErr, ok := err.(ArgoError); ok {
+		return argoErr.Code() == code
 	}
 	return false
 }
@@ -38,3 +38,15 @@ func TestInternalError(t *testing.T) {
 	intWrap = errors.InternalWrapErrorf(err, "hello %s", "world")
 	assert.Equal(t, "hello world", intWrap.Error())
 }
+
+func TestFieldError(t *testing.T) {
+	err := errors.NewFieldError("spec.entrypoint", "mian", "main", "template name 'mian' undefined")
+	assert.Equal(t, "template name 'mian' undefined, did you mean 'main'?", err.Error())
+	assert.True(t, errors.IsCode(errors.CodeBadRequest, err))
+	argoErr, ok := err.(errors.ArgoError)
+	assert.True(t, ok)
+	assert.JSONEq(t, `{"code":"ERR_BAD_REQUEST","path":"spec.entrypoint","value":"mian","message":"template name 'mian' undefined","suggestion":"main"}`, string(argoErr.JSON()))
+
+	err = errors.NewFieldErrorf("spec.entrypoint", "foo", "", "template name '%s' undefined", "foo")
+	assert.Equal(t, "template name 'foo' undefined", err.Error())
+}
@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// FieldError is a bad request error for a field of a manifest. As well as the message, it has the path of the field,
+// e.g. `spec.templates[0].steps[0][1].template`, the offending value, and a suggestion of what was probably meant, so
+// that tools can point at the field.
+type FieldError struct {
+	Path       string `json:"path,omitempty"`
+	Value      string `json:"value,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+var _ ArgoError = FieldError{}
+
+// NewFieldError returns a FieldError. The path, value and suggestion may be empty.
+func NewFieldError(path, value, suggestion, message string) error {
+	return FieldError{Path: path, Value: value, Message: message, Suggestion: suggestion}
+}
+
+// NewFieldErrorf returns a FieldError, formatting the message according to a format specifier
+func NewFieldErrorf(path, value, suggestion, format string, args ...interface{}) error {
+	return NewFieldError(path, value, suggestion, fmt.Sprintf(format, args...))
+}
+
+func (e FieldError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("%s, did you mean '%s'?", e.Message, e.Suggestion)
+	}
+	return e.Message
+}
+
+func (e FieldError) Code() string {
+	return CodeBadRequest
+}
+
+func (e FieldError) HTTPCode() int {
+	return http.StatusBadRequest
+}
+
+func (e FieldError) JSON() []byte {
+	type errBean struct {
+		Code string `json:"code"`
+		FieldError
+	}
+	j, _ := json.Marshal(errBean{e.Code(), e})
+	return j
+}
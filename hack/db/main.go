@@ -72,7 +72,7 @@ func NewFakeDataCommand() *cobra.Command {
 			for i := 0; i < rows; i++ {
 				wf := randomizeWorkflow(wfTmpl, namespaces)
 				cluster := clusters[rand.Intn(len(clusters))]
-				wfArchive := sqldb.NewWorkflowArchive(session, cluster, "", instanceIDService)
+				wfArchive := sqldb.NewWorkflowArchive(session, cluster, "", instanceIDService, nil)
 				if err := wfArchive.ArchiveWorkflow(wf); err != nil {
 					return err
 				}
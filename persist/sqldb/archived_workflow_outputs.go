@@ -0,0 +1,53 @@
+package sqldb
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/upper/db/v4"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// maxArchivedOutputValueLength is the longest output parameter value that is indexed, longer values cannot be searched
+const maxArchivedOutputValueLength = 256
+
+type archivedWorkflowOutputRecord struct {
+	ClusterName string `db:"clustername"`
+	UID         string `db:"uid"`
+	Name        string `db:"name"`
+	Value       string `db:"value"`
+}
+
+// archivedOutputParameters returns the workflow's output parameters that are indexed in the archive
+func archivedOutputParameters(wf *wfv1.Workflow, names []string) map[string]string {
+	if wf.Status.Outputs == nil || len(names) == 0 {
+		return nil
+	}
+	values := make(map[string]string)
+	for _, p := range wf.Status.Outputs.Parameters {
+		if p.Value == nil || !slices.Contains(names, p.Name) || len(p.Value.String()) > maxArchivedOutputValueLength {
+			continue
+		}
+		values[p.Name] = p.Value.String()
+	}
+	return values
+}
+
+// outputParametersClause returns the condition that the workflows have the values of the output parameters
+func outputParametersClause(selector db.Selector, outputParameters map[string]string, tableName, outputsTableName string, hasClusterName bool) db.Selector {
+	for _, name := range slices.Sorted(maps.Keys(outputParameters)) {
+		selector = selector.And(outputParameterCondition(name, outputParameters[name], tableName, outputsTableName, hasClusterName))
+	}
+	return selector
+}
+
+func outputParameterCondition(name, value, tableName, outputsTableName string, hasClusterName bool) *db.RawExpr {
+	clusterNameSelector := ""
+	if hasClusterName {
+		clusterNameSelector = fmt.Sprintf("clustername = %s.clustername and", tableName)
+	}
+	// unlike labels, the values are not restricted, so they must be arguments
+	return db.Raw(fmt.Sprintf("exists (select 1 from %s where %s uid = %s.uid and name = ? and value = ?)", outputsTableName, clusterNameSelector, tableName), name, value)
+}
@@ -0,0 +1,28 @@
+package sqldb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/upper/db/v4"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_archivedOutputParameters(t *testing.T) {
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Outputs: &wfv1.Outputs{Parameters: []wfv1.Parameter{
+		{Name: "model-version", Value: wfv1.AnyStringPtr("v3")},
+		{Name: "accuracy", Value: wfv1.AnyStringPtr("0.97")},
+		{Name: "report", Value: wfv1.AnyStringPtr(strings.Repeat("x", maxArchivedOutputValueLength+1))},
+		{Name: "no-value"},
+	}}}}
+	assert.Empty(t, archivedOutputParameters(wf, nil))
+	assert.Empty(t, archivedOutputParameters(&wfv1.Workflow{}, []string{"model-version"}))
+	assert.Equal(t, map[string]string{"model-version": "v3"}, archivedOutputParameters(wf, []string{"model-version", "report", "no-value"}))
+}
+
+func Test_outputParameterCondition(t *testing.T) {
+	got := outputParameterCondition("model-version", "v3'", archiveTableName, archiveOutputsTableName, true)
+	assert.Equal(t, *db.Raw("exists (select 1 from argo_archived_workflows_outputs where clustername = argo_archived_workflows.clustername and uid = argo_archived_workflows.uid and name = ? and value = ?)", "model-version", "v3'"), *got)
+}
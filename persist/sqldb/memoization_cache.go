@@ -0,0 +1,166 @@
+package sqldb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/upper/db/v4"
+
+	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+)
+
+const (
+	memoizationCacheTableName    = "argo_memoization_cache"
+	memoizationCacheVersionTable = "argo_memoization_cache_schema_history"
+)
+
+// MigrateMemoizationCache creates the table of the entries of memoization caches. It has its own version table, so that
+// it is only created when memoization caches are saved in the database.
+func MigrateMemoizationCache(ctx context.Context, session db.Session) error {
+	dbType := sqldb.DBTypeFor(session)
+	// Why is the key called "cachekey"? Key is an SQL reserved word. The entries are keyed by the hash of their key, as
+	// the key itself may be too long to be indexed.
+	return sqldb.Migrate(ctx, session, memoizationCacheVersionTable, []sqldb.Change{
+		sqldb.ByType(dbType, sqldb.TypedChanges{
+			sqldb.MySQL: sqldb.AnsiSQLChange(`create table if not exists ` + memoizationCacheTableName + ` (
+	clustername varchar(64) not null,
+	namespace varchar(256) not null,
+	cachename varchar(253) not null,
+	keyhash char(64) not null,
+	cachekey text not null,
+	nodeid varchar(256) not null,
+	outputs json not null,
+	createdat timestamp not null default CURRENT_TIMESTAMP,
+	lasthitat timestamp not null default CURRENT_TIMESTAMP,
+	primary key (clustername, namespace, cachename, keyhash)
+)`),
+			sqldb.Postgres: sqldb.AnsiSQLChange(`create table if not exists ` + memoizationCacheTableName + ` (
+	clustername varchar(64) not null,
+	namespace varchar(256) not null,
+	cachename varchar(253) not null,
+	keyhash char(64) not null,
+	cachekey text not null,
+	nodeid varchar(256) not null,
+	outputs jsonb not null,
+	createdat timestamp not null default CURRENT_TIMESTAMP,
+	lasthitat timestamp not null default CURRENT_TIMESTAMP,
+	primary key (clustername, namespace, cachename, keyhash)
+)`),
+		}),
+		// index to find the entries that have expired
+		sqldb.AnsiSQLChange(`create index ` + memoizationCacheTableName + `_i1 on ` + memoizationCacheTableName + ` (clustername,lasthitat)`),
+	})
+}
+
+// memoizationCacheKeyHash returns the hash of the key of an entry, which the entries are keyed by
+func memoizationCacheKeyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// MemoizationCacheRecord is an entry of a memoization cache, whose outputs are the JSON of the outputs of the node that
+// saved it
+type MemoizationCacheRecord struct {
+	ClusterName string    `db:"clustername"`
+	Namespace   string    `db:"namespace"`
+	CacheName   string    `db:"cachename"`
+	KeyHash     string    `db:"keyhash"`
+	Key         string    `db:"cachekey"`
+	NodeID      string    `db:"nodeid"`
+	Outputs     string    `db:"outputs"`
+	CreatedAt   time.Time `db:"createdat"`
+	LastHitAt   time.Time `db:"lasthitat"`
+}
+
+// MemoizationCacheRepo saves the entries of memoization caches in the database, rather than in ConfigMaps
+type MemoizationCacheRepo interface {
+	// Get returns the entry of the key of the cache, or nil if there is none
+	Get(namespace, cacheName, key string) (*MemoizationCacheRecord, error)
+	// Hit records that the entry of the key of the cache was hit at the time, so that it is not deleted for its TTL
+	Hit(namespace, cacheName, key string, at time.Time) error
+	// Save saves the entry, replacing the entry of its key if there is one
+	Save(record *MemoizationCacheRecord) error
+	// DeleteExpired deletes the entries that have not been hit, or saved, for the TTL
+	DeleteExpired(ttl time.Duration) error
+}
+
+type memoizationCacheRepo struct {
+	session     db.Session
+	clusterName string
+	dbType      sqldb.DBType
+}
+
+func NewMemoizationCacheRepo(session db.Session, clusterName string) MemoizationCacheRepo {
+	return &memoizationCacheRepo{session: session, clusterName: clusterName, dbType: sqldb.DBTypeFor(session)}
+}
+
+func (r *memoizationCacheRepo) entry(namespace, cacheName, key string) db.Cond {
+	return db.Cond{"clustername": r.clusterName, "namespace": namespace, "cachename": cacheName, "keyhash": memoizationCacheKeyHash(key)}
+}
+
+func (r *memoizationCacheRepo) Get(namespace, cacheName, key string) (*MemoizationCacheRecord, error) {
+	record := &MemoizationCacheRecord{}
+	err := r.session.SQL().
+		SelectFrom(memoizationCacheTableName).
+		Where(r.entry(namespace, cacheName, key)).
+		One(record)
+	if err != nil {
+		if err == db.ErrNoMoreRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if r.dbType == sqldb.Postgres {
+		record.Outputs = strings.ReplaceAll(record.Outputs, postgresNullReplacement, "\\u0000")
+	}
+	return record, nil
+}
+
+func (r *memoizationCacheRepo) Hit(namespace, cacheName, key string, at time.Time) error {
+	_, err := r.session.SQL().
+		Update(memoizationCacheTableName).
+		Set("lasthitat", at.UTC()).
+		Where(r.entry(namespace, cacheName, key)).
+		Exec()
+	return err
+}
+
+func (r *memoizationCacheRepo) Save(record *MemoizationCacheRecord) error {
+	record.ClusterName = r.clusterName
+	record.KeyHash = memoizationCacheKeyHash(record.Key)
+	if r.dbType == sqldb.Postgres {
+		record.Outputs = strings.ReplaceAll(record.Outputs, "\\u0000", postgresNullReplacement)
+	}
+	return r.session.Tx(func(sess db.Session) error {
+		_, err := sess.SQL().
+			DeleteFrom(memoizationCacheTableName).
+			Where(r.entry(record.Namespace, record.CacheName, record.Key)).
+			Exec()
+		if err != nil {
+			return err
+		}
+		_, err = sess.Collection(memoizationCacheTableName).Insert(record)
+		return err
+	})
+}
+
+func (r *memoizationCacheRepo) DeleteExpired(ttl time.Duration) error {
+	rs, err := r.session.SQL().
+		DeleteFrom(memoizationCacheTableName).
+		Where(db.Cond{"clustername": r.clusterName}).
+		And(db.Cond{"lasthitat <": time.Now().UTC().Add(-ttl)}).
+		Exec()
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := rs.RowsAffected()
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"rowsAffected": rowsAffected, "ttl": ttl}).Info("Deleted expired memoization cache entries")
+	return nil
+}
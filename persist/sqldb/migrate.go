@@ -217,5 +217,46 @@ func Migrate(ctx context.Context, session db.Session, clusterName, tableName str
 			sqldb.Postgres: sqldb.AnsiSQLChange(`drop index argo_archived_workflows_i4`),
 		}),
 		sqldb.AnsiSQLChange(`create index argo_archived_workflows_i4 on argo_archived_workflows (clustername, startedat)`),
+		// The argo_archived_workflows_outputs indexes the values of the output parameters named in the archiveOutputParameters,
+		// so that archived workflows can be searched by them without reading every workflow.
+		sqldb.AnsiSQLChange(`create table if not exists argo_archived_workflows_outputs (
+	clustername varchar(64) not null,
+	uid varchar(128) not null,
+    name varchar(256) not null,
+    value varchar(256) not null,
+    primary key (clustername, uid, name),
+ 	foreign key (clustername, uid) references argo_archived_workflows(clustername, uid) on delete cascade
+)`),
+		sqldb.AnsiSQLChange(`create index argo_archived_workflows_outputs_i1 on argo_archived_workflows_outputs (name,value)`),
+		// The argo_workflow_status_snapshots holds periodic snapshots of the statuses of workflows, so that the states of
+		// their nodes at a time in the past can be viewed
+		sqldb.ByType(dbType, sqldb.TypedChanges{
+			sqldb.MySQL: sqldb.AnsiSQLChange(`create table if not exists argo_workflow_status_snapshots (
+	clustername varchar(64) not null,
+	uid varchar(128) not null,
+	namespace varchar(256) not null,
+	name varchar(256) not null,
+	phase varchar(25) not null,
+	message text,
+	nodes json not null,
+	createdat timestamp not null default CURRENT_TIMESTAMP,
+	primary key (clustername, uid, createdat)
+)`),
+			sqldb.Postgres: sqldb.AnsiSQLChange(`create table if not exists argo_workflow_status_snapshots (
+	clustername varchar(64) not null,
+	uid varchar(128) not null,
+	namespace varchar(256) not null,
+	name varchar(256) not null,
+	phase varchar(25) not null,
+	message text,
+	nodes jsonb not null,
+	createdat timestamp not null default CURRENT_TIMESTAMP,
+	primary key (clustername, uid, createdat)
+)`),
+		}),
+		// index to find the snapshots of a workflow by its name
+		sqldb.AnsiSQLChange(`create index argo_workflow_status_snapshots_i1 on argo_workflow_status_snapshots (clustername,namespace,name,createdat)`),
+		// index to find the snapshots that have expired
+		sqldb.AnsiSQLChange(`create index argo_workflow_status_snapshots_i2 on argo_workflow_status_snapshots (clustername,createdat)`),
 	})
 }
@@ -0,0 +1,37 @@
+package sqldb
+
+import (
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var NullSubmissionQueue SubmissionQueue = &nullSubmissionQueue{}
+
+type nullSubmissionQueue struct{}
+
+func (q *nullSubmissionQueue) IsEnabled() bool {
+	return false
+}
+
+func (q *nullSubmissionQueue) Enqueue(string, *wfv1.Workflow, *authenticationv1.UserInfo) error {
+	return fmt.Errorf("the submission queue is not configured")
+}
+
+func (q *nullSubmissionQueue) ListPending() ([]QueuedSubmission, error) {
+	return []QueuedSubmission{}, nil
+}
+
+func (q *nullSubmissionQueue) RecordAttempt(string, string) error {
+	return nil
+}
+
+func (q *nullSubmissionQueue) MarkFailed(string, string) error {
+	return nil
+}
+
+func (q *nullSubmissionQueue) Remove(string) error {
+	return nil
+}
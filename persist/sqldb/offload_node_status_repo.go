@@ -12,6 +12,7 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/util/faults"
 )
 
 const OffloadNodeStatusDisabled = "Workflow has offloaded nodes, but offloading has been disabled"
@@ -69,6 +70,10 @@ func nodeStatusVersion(s wfv1.Nodes) (string, string, error) {
 }
 
 func (wdc *nodeOffloadRepo) Save(uid, namespace string, nodes wfv1.Nodes) (string, error) {
+	before, after := faults.Inject("persistence/OffloadNodeStatus")
+	if before != nil {
+		return "", before
+	}
 	marshalled, version, err := nodeStatusVersion(nodes)
 	if err != nil {
 		return "", err
@@ -97,6 +102,9 @@ func (wdc *nodeOffloadRepo) Save(uid, namespace string, nodes wfv1.Nodes) (strin
 	}
 	// Don't need to clean up the old records here, we have a scheduled cleanup mechanism.
 	// If we clean them up here, when we update, if there is an update conflict, we will not be able to go back.
+	if after != nil {
+		return "", after
+	}
 	return version, nil
 }
 
@@ -114,6 +122,9 @@ func isDuplicateKeyError(err error) bool {
 
 func (wdc *nodeOffloadRepo) Get(uid, version string) (wfv1.Nodes, error) {
 	log.WithFields(log.Fields{"uid": uid, "version": version}).Debug("Getting offloaded nodes")
+	if before, _ := faults.Inject("persistence/GetOffloadedNodeStatus"); before != nil {
+		return nil, before
+	}
 	r := &nodesRecord{}
 	err := wdc.session.SQL().
 		SelectFrom(wdc.tableName).
@@ -1,6 +1,9 @@
 package sqldb
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/upper/db/v4"
@@ -21,6 +24,8 @@ func BuildArchivedWorkflowSelector(selector db.Selector, tableName, labelTableNa
 	if err != nil {
 		return nil, err
 	}
+	selector = outputParametersClause(selector, options.OutputParameters, tableName, archiveOutputsTableName, true)
+	selector = ownerClause(selector, t, options.Owner)
 	if count {
 		return selector, nil
 	}
@@ -78,6 +83,13 @@ func BuildWorkflowSelector(in string, inArgs []any, tableName, labelTableName st
 		}
 		clauses = append(clauses, q)
 	}
+	// the output parameters are not indexed, so they are searched for in the workflow
+	for _, name := range slices.Sorted(maps.Keys(options.OutputParameters)) {
+		clauses = append(clauses, db.Raw("exists (select 1 from json_each(workflow, '$.status.outputs.parameters') where json_extract(value, '$.name') = ? and json_extract(value, '$.value') = ?)", name, options.OutputParameters[name]))
+	}
+	for _, field := range slices.Sorted(maps.Keys(options.Owner)) {
+		clauses = append(clauses, ownerCondition(sqldb.SQLite, field, options.Owner[field]))
+	}
 	out = in
 	outArgs = inArgs
 	for _, c := range clauses {
@@ -108,3 +120,25 @@ func BuildWorkflowSelector(in string, inArgs []any, tableName, labelTableName st
 	outArgs = append(outArgs, options.Offset)
 	return out, outArgs, nil
 }
+
+// ownerClause returns the condition that the workflows have the owner. The owner of a workflow of a workflow template
+// is in the stored workflow template spec, unless the workflow overrides it.
+func ownerClause(selector db.Selector, t sqldb.DBType, owner map[string]string) db.Selector {
+	for _, field := range slices.Sorted(maps.Keys(owner)) {
+		selector = selector.And(ownerCondition(t, field, owner[field]))
+	}
+	return selector
+}
+
+// ownerCondition returns the condition that the field of the owner has the value. The field is one of the owner's JSON
+// fields, which are checked by utils.BuildListOptions, so it is safe to format into the query.
+func ownerCondition(t sqldb.DBType, field, value string) *db.RawExpr {
+	switch t {
+	case sqldb.MySQL:
+		return db.Raw(fmt.Sprintf("coalesce(workflow->>'$.spec.owner.%[1]s', workflow->>'$.status.storedWorkflowTemplateSpec.owner.%[1]s') = ?", field), value)
+	case sqldb.Postgres:
+		return db.Raw(fmt.Sprintf("coalesce(workflow->'spec'->'owner'->>'%[1]s', workflow->'status'->'storedWorkflowTemplateSpec'->'owner'->>'%[1]s') = ?", field), value)
+	default:
+		return db.Raw(fmt.Sprintf("coalesce(json_extract(workflow, '$.spec.owner.%[1]s'), json_extract(workflow, '$.status.storedWorkflowTemplateSpec.owner.%[1]s')) = ?", field), value)
+	}
+}
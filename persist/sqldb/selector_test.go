@@ -0,0 +1,27 @@
+package sqldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/upper/db/v4"
+
+	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+)
+
+func Test_ownerCondition(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType sqldb.DBType
+		want   db.RawExpr
+	}{
+		{"MySQL", sqldb.MySQL, *db.Raw("coalesce(workflow->>'$.spec.owner.team', workflow->>'$.status.storedWorkflowTemplateSpec.owner.team') = ?", "ml-platform")},
+		{"Postgres", sqldb.Postgres, *db.Raw("coalesce(workflow->'spec'->'owner'->>'team', workflow->'status'->'storedWorkflowTemplateSpec'->'owner'->>'team') = ?", "ml-platform")},
+		{"SQLite", sqldb.SQLite, *db.Raw("coalesce(json_extract(workflow, '$.spec.owner.team'), json_extract(workflow, '$.status.storedWorkflowTemplateSpec.owner.team')) = ?", "ml-platform")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, *ownerCondition(tt.dbType, "team", "ml-platform"))
+		})
+	}
+}
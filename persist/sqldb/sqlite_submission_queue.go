@@ -0,0 +1,122 @@
+package sqldb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const sqliteSubmissionQueueInitializationQuery = `create table if not exists argo_workflow_submissions (
+  clustername varchar(64) not null,
+  id varchar(128) not null,
+  namespace varchar(256) not null,
+  name varchar(256) not null,
+  phase varchar(25) not null,
+  workflow text not null,
+  submitter text,
+  attempts int not null,
+  message text,
+  createdat timestamp not null,
+  updatedat timestamp not null,
+  primary key (clustername, id)
+);
+create index if not exists argo_workflow_submissions_i1 on argo_workflow_submissions (clustername, phase, createdat);
+`
+
+// sqliteSubmissionQueue is a submission queue in a SQLite database file, for Argo Servers without a persistence
+// database. The file must be on a volume that outlives the pod for the queue to be durable.
+type sqliteSubmissionQueue struct {
+	conn        *sqlite.Conn
+	clusterName string
+	mtx         sync.Mutex
+}
+
+// NewSQLiteSubmissionQueue returns a submission queue in the SQLite database at the path, creating it if needed
+func NewSQLiteSubmissionQueue(path, clusterName string) (SubmissionQueue, error) {
+	conn, err := sqlite.OpenConn(path, sqlite.OpenReadWrite, sqlite.OpenCreate, sqlite.OpenWAL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the submission queue %q: %w", path, err)
+	}
+	if err := sqlitex.ExecuteScript(conn, sqliteSubmissionQueueInitializationQuery, nil); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &sqliteSubmissionQueue{conn: conn, clusterName: clusterName}, nil
+}
+
+func (q *sqliteSubmissionQueue) IsEnabled() bool {
+	return true
+}
+
+func (q *sqliteSubmissionQueue) Enqueue(id string, wf *wfv1.Workflow, submitter *authenticationv1.UserInfo) error {
+	r, err := newSubmissionRecord(q.clusterName, id, wf, submitter)
+	if err != nil {
+		return err
+	}
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return sqlitex.Execute(q.conn, `insert into argo_workflow_submissions (clustername, id, namespace, name, phase, workflow, submitter, attempts, message, createdat, updatedat) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, &sqlitex.ExecOptions{
+		Args: []any{r.ClusterName, r.ID, r.Namespace, r.Name, r.Phase, r.Workflow, r.Submitter, r.Attempts, r.Message, r.CreatedAt.Format(time.RFC3339Nano), r.UpdatedAt.Format(time.RFC3339Nano)},
+	})
+}
+
+func (q *sqliteSubmissionQueue) ListPending() ([]QueuedSubmission, error) {
+	var records []submissionRecord
+	q.mtx.Lock()
+	err := sqlitex.Execute(q.conn, `select id, workflow, submitter, attempts, message from argo_workflow_submissions where clustername = ? and phase = ? order by rowid`, &sqlitex.ExecOptions{
+		Args: []any{q.clusterName, submissionPending},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			records = append(records, submissionRecord{
+				ID:        stmt.ColumnText(0),
+				Workflow:  stmt.ColumnText(1),
+				Submitter: stmt.ColumnText(2),
+				Attempts:  stmt.ColumnInt(3),
+				Message:   stmt.ColumnText(4),
+			})
+			return nil
+		},
+	})
+	q.mtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	submissions := make([]QueuedSubmission, 0, len(records))
+	for _, r := range records {
+		s, err := r.queuedSubmission()
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, s)
+	}
+	return submissions, nil
+}
+
+func (q *sqliteSubmissionQueue) RecordAttempt(id, message string) error {
+	return q.recordAttempt(id, submissionPending, message)
+}
+
+func (q *sqliteSubmissionQueue) MarkFailed(id, message string) error {
+	return q.recordAttempt(id, submissionFailed, message)
+}
+
+func (q *sqliteSubmissionQueue) recordAttempt(id, phase, message string) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return sqlitex.Execute(q.conn, `update argo_workflow_submissions set attempts = attempts + 1, phase = ?, message = ?, updatedat = ? where clustername = ? and id = ?`, &sqlitex.ExecOptions{
+		Args: []any{phase, message, time.Now().UTC().Format(time.RFC3339Nano), q.clusterName, id},
+	})
+}
+
+func (q *sqliteSubmissionQueue) Remove(id string) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return sqlitex.Execute(q.conn, `delete from argo_workflow_submissions where clustername = ? and id = ?`, &sqlitex.ExecOptions{
+		Args: []any{q.clusterName, id},
+	})
+}
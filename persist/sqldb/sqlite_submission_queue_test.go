@@ -0,0 +1,52 @@
+package sqldb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestSQLiteSubmissionQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "submissions.db")
+	q, err := NewSQLiteSubmissionQueue(path, "default")
+	require.NoError(t, err)
+	assert.True(t, q.IsEnabled())
+
+	submitter := &authenticationv1.UserInfo{Username: "system:serviceaccount:argo:my-user", Groups: []string{"system:serviceaccounts"}}
+	for _, name := range []string{"first", "second", "third"} {
+		require.NoError(t, q.Enqueue(name+"-id", &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argo"}}, submitter))
+	}
+	require.NoError(t, q.RecordAttempt("first-id", "connection refused"))
+	require.NoError(t, q.MarkFailed("second-id", "forbidden"))
+
+	// the queue survives the server restarting
+	q, err = NewSQLiteSubmissionQueue(path, "default")
+	require.NoError(t, err)
+	pending, err := q.ListPending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "first-id", pending[0].ID)
+	assert.Equal(t, "first", pending[0].Workflow.Name)
+	assert.Equal(t, submitter, pending[0].Submitter)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, "connection refused", pending[0].Message)
+	assert.Equal(t, "third-id", pending[1].ID)
+
+	require.NoError(t, q.Remove("first-id"))
+	pending, err = q.ListPending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "third-id", pending[0].ID)
+
+	other, err := NewSQLiteSubmissionQueue(path, "other")
+	require.NoError(t, err)
+	pending, err = other.ListPending()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
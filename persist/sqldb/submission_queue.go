@@ -0,0 +1,204 @@
+package sqldb
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/upper/db/v4"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+)
+
+const (
+	submissionQueueTableName    = "argo_workflow_submissions"
+	submissionQueueVersionTable = "argo_workflow_submissions_schema_history"
+	submissionPending           = "Pending"
+	submissionFailed            = "Failed"
+)
+
+// QueuedSubmission is a workflow that was submitted whilst the Kubernetes API was unavailable, and is yet to be created
+type QueuedSubmission struct {
+	ID       string
+	Workflow *wfv1.Workflow
+	// Submitter is who submitted the workflow, who it is created as
+	Submitter *authenticationv1.UserInfo
+	// Attempts is the number of times that creating the workflow has failed
+	Attempts int
+	// Message is the error of the last attempt
+	Message string
+}
+
+// SubmissionQueue durably queues the workflows that are submitted whilst the Kubernetes API is unavailable, so they
+// can be created once it is available again
+type SubmissionQueue interface {
+	IsEnabled() bool
+	// Enqueue queues the workflow, which is tracked by the ID, to be created as its submitter
+	Enqueue(id string, wf *wfv1.Workflow, submitter *authenticationv1.UserInfo) error
+	// ListPending lists the submissions that are pending, in the order that they were queued
+	ListPending() ([]QueuedSubmission, error)
+	// RecordAttempt records that an attempt to create the workflow of the submission failed, and will be retried
+	RecordAttempt(id, message string) error
+	// MarkFailed marks the submission as failed, so its workflow is not created
+	MarkFailed(id, message string) error
+	// Remove removes the submission, once its workflow has been created
+	Remove(id string) error
+}
+
+type submissionRecord struct {
+	ClusterName string    `db:"clustername"`
+	ID          string    `db:"id"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Phase       string    `db:"phase"`
+	Workflow    string    `db:"workflow"`
+	Submitter   string    `db:"submitter"`
+	Attempts    int       `db:"attempts"`
+	Message     string    `db:"message"`
+	CreatedAt   time.Time `db:"createdat"`
+	UpdatedAt   time.Time `db:"updatedat"`
+}
+
+func newSubmissionRecord(clusterName, id string, wf *wfv1.Workflow, submitter *authenticationv1.UserInfo) (*submissionRecord, error) {
+	data, err := json.Marshal(wf)
+	if err != nil {
+		return nil, err
+	}
+	submitterData, err := json.Marshal(submitter)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &submissionRecord{
+		ClusterName: clusterName,
+		ID:          id,
+		Namespace:   wf.Namespace,
+		Name:        wf.Name,
+		Phase:       submissionPending,
+		Workflow:    string(data),
+		Submitter:   string(submitterData),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func (r submissionRecord) queuedSubmission() (QueuedSubmission, error) {
+	wf := &wfv1.Workflow{}
+	if err := json.Unmarshal([]byte(r.Workflow), wf); err != nil {
+		return QueuedSubmission{}, err
+	}
+	var submitter *authenticationv1.UserInfo
+	if r.Submitter != "" {
+		if err := json.Unmarshal([]byte(r.Submitter), &submitter); err != nil {
+			return QueuedSubmission{}, err
+		}
+	}
+	return QueuedSubmission{ID: r.ID, Workflow: wf, Submitter: submitter, Attempts: r.Attempts, Message: r.Message}, nil
+}
+
+type submissionQueue struct {
+	session     db.Session
+	clusterName string
+}
+
+// NewSubmissionQueue returns a submission queue in the persistence database, creating its table if needed
+func NewSubmissionQueue(ctx context.Context, session db.Session, clusterName string) (SubmissionQueue, error) {
+	dbType := sqldb.DBTypeFor(session)
+	err := sqldb.Migrate(ctx, session, submissionQueueVersionTable, []sqldb.Change{
+		sqldb.ByType(dbType, sqldb.TypedChanges{
+			// MySQL can only store 64k in a TEXT field
+			sqldb.MySQL: sqldb.AnsiSQLChange(`create table if not exists ` + submissionQueueTableName + ` (
+    clustername varchar(64) not null,
+    id varchar(128) not null,
+    namespace varchar(256) not null,
+    name varchar(256) not null,
+    phase varchar(25) not null,
+    workflow longtext not null,
+    attempts int not null,
+    message text,
+    createdat timestamp not null default current_timestamp,
+    updatedat timestamp not null default current_timestamp,
+    primary key (clustername, id)
+)`),
+			sqldb.Postgres: sqldb.AnsiSQLChange(`create table if not exists ` + submissionQueueTableName + ` (
+    clustername varchar(64) not null,
+    id varchar(128) not null,
+    namespace varchar(256) not null,
+    name varchar(256) not null,
+    phase varchar(25) not null,
+    workflow text not null,
+    attempts int not null,
+    message text,
+    createdat timestamp not null default current_timestamp,
+    updatedat timestamp not null default current_timestamp,
+    primary key (clustername, id)
+)`),
+		}),
+		sqldb.AnsiSQLChange(`create index ` + submissionQueueTableName + `_i1 on ` + submissionQueueTableName + ` (clustername, phase, createdat)`),
+		sqldb.AnsiSQLChange(`alter table ` + submissionQueueTableName + ` add column submitter text`),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &submissionQueue{session: session, clusterName: clusterName}, nil
+}
+
+func (q *submissionQueue) IsEnabled() bool {
+	return true
+}
+
+func (q *submissionQueue) Enqueue(id string, wf *wfv1.Workflow, submitter *authenticationv1.UserInfo) error {
+	record, err := newSubmissionRecord(q.clusterName, id, wf, submitter)
+	if err != nil {
+		return err
+	}
+	_, err = q.session.Collection(submissionQueueTableName).Insert(record)
+	return err
+}
+
+func (q *submissionQueue) ListPending() ([]QueuedSubmission, error) {
+	var records []submissionRecord
+	err := q.session.SQL().
+		SelectFrom(submissionQueueTableName).
+		Where(db.Cond{"clustername": q.clusterName}).
+		And(db.Cond{"phase": submissionPending}).
+		OrderBy("createdat").
+		All(&records)
+	if err != nil {
+		return nil, err
+	}
+	submissions := make([]QueuedSubmission, 0, len(records))
+	for _, r := range records {
+		s, err := r.queuedSubmission()
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, s)
+	}
+	return submissions, nil
+}
+
+func (q *submissionQueue) RecordAttempt(id, message string) error {
+	return q.recordAttempt(id, submissionPending, message)
+}
+
+func (q *submissionQueue) MarkFailed(id, message string) error {
+	return q.recordAttempt(id, submissionFailed, message)
+}
+
+func (q *submissionQueue) recordAttempt(id, phase, message string) error {
+	_, err := q.session.SQL().Exec(`update `+submissionQueueTableName+` set attempts = attempts + 1, phase = ?, message = ?, updatedat = ? where clustername = ? and id = ?`,
+		phase, message, time.Now().UTC(), q.clusterName, id)
+	return err
+}
+
+func (q *submissionQueue) Remove(id string) error {
+	_, err := q.session.SQL().
+		DeleteFrom(submissionQueueTableName).
+		Where(db.Cond{"clustername": q.clusterName}).
+		And(db.Cond{"id": id}).
+		Exec()
+	return err
+}
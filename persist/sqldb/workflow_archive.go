@@ -17,6 +17,7 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	"github.com/argoproj/argo-workflows/v3/util/faults"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/sqldb"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
@@ -25,6 +26,7 @@ import (
 const (
 	archiveTableName        = "argo_archived_workflows"
 	archiveLabelsTableName  = archiveTableName + "_labels"
+	archiveOutputsTableName = archiveTableName + "_outputs"
 	postgresNullReplacement = "ARGO_POSTGRES_NULL_REPLACEMENT"
 )
 
@@ -88,20 +90,26 @@ type workflowArchive struct {
 	managedNamespace  string
 	instanceIDService instanceid.Service
 	dbType            sqldb.DBType
+	// outputParameters are the names of the output parameters that are indexed
+	outputParameters []string
 }
 
 func (r *workflowArchive) IsEnabled() bool {
 	return true
 }
 
-// NewWorkflowArchive returns a new workflowArchive
-func NewWorkflowArchive(session db.Session, clusterName, managedNamespace string, instanceIDService instanceid.Service) WorkflowArchive {
-	return &workflowArchive{session: session, clusterName: clusterName, managedNamespace: managedNamespace, instanceIDService: instanceIDService, dbType: sqldb.DBTypeFor(session)}
+// NewWorkflowArchive returns a new workflowArchive, which indexes the values of the named output parameters
+func NewWorkflowArchive(session db.Session, clusterName, managedNamespace string, instanceIDService instanceid.Service, outputParameters []string) WorkflowArchive {
+	return &workflowArchive{session: session, clusterName: clusterName, managedNamespace: managedNamespace, instanceIDService: instanceIDService, dbType: sqldb.DBTypeFor(session), outputParameters: outputParameters}
 }
 
 func (r *workflowArchive) ArchiveWorkflow(wf *wfv1.Workflow) error {
 	logCtx := log.WithFields(log.Fields{"uid": wf.UID, "labels": wf.GetLabels()})
 	logCtx.Debug("Archiving workflow")
+	before, after := faults.Inject("persistence/ArchiveWorkflow")
+	if before != nil {
+		return before
+	}
 	wf.Labels[common.LabelKeyWorkflowArchivingStatus] = "Persisted"
 	workflow, err := json.Marshal(wf)
 	if err != nil {
@@ -110,7 +118,7 @@ func (r *workflowArchive) ArchiveWorkflow(wf *wfv1.Workflow) error {
 	if r.dbType == sqldb.Postgres {
 		workflow = bytes.ReplaceAll(workflow, []byte("\\u0000"), []byte(postgresNullReplacement))
 	}
-	return r.session.Tx(func(sess db.Session) error {
+	err = r.session.Tx(func(sess db.Session) error {
 		_, err := sess.SQL().
 			DeleteFrom(archiveTableName).
 			Where(r.clusterManagedNamespaceAndInstanceID()).
@@ -158,8 +166,34 @@ func (r *workflowArchive) ArchiveWorkflow(wf *wfv1.Workflow) error {
 				return err
 			}
 		}
+
+		_, err = sess.SQL().
+			DeleteFrom(archiveOutputsTableName).
+			Where(db.Cond{"clustername": r.clusterName}).
+			And(db.Cond{"uid": wf.UID}).
+			Exec()
+		if err != nil {
+			return err
+		}
+		// insert the output parameters that are searchable
+		for name, value := range archivedOutputParameters(wf, r.outputParameters) {
+			_, err := sess.Collection(archiveOutputsTableName).
+				Insert(&archivedWorkflowOutputRecord{
+					ClusterName: r.clusterName,
+					UID:         string(wf.UID),
+					Name:        name,
+					Value:       value,
+				})
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	return after
 }
 
 func (r *workflowArchive) ListWorkflows(options sutils.ListOptions) (wfv1.Workflows, error) {
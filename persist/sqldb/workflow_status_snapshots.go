@@ -0,0 +1,109 @@
+package sqldb
+
+import (
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/upper/db/v4"
+
+	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+)
+
+const workflowStatusSnapshotsTableName = "argo_workflow_status_snapshots"
+
+// WorkflowStatusSnapshotRecord is a snapshot of the status of a workflow, whose nodes are the JSON of its nodes at the
+// time it was created
+type WorkflowStatusSnapshotRecord struct {
+	ClusterName string    `db:"clustername"`
+	UID         string    `db:"uid"`
+	Namespace   string    `db:"namespace"`
+	Name        string    `db:"name"`
+	Phase       string    `db:"phase"`
+	Message     string    `db:"message"`
+	Nodes       string    `db:"nodes"`
+	CreatedAt   time.Time `db:"createdat"`
+}
+
+// WorkflowStatusSnapshotRepo saves periodic snapshots of the statuses of workflows, so that the states of their nodes
+// at a time in the past can be viewed
+type WorkflowStatusSnapshotRepo interface {
+	// Save saves the snapshot, replacing the snapshot of the workflow at the same time if there is one
+	Save(record *WorkflowStatusSnapshotRecord) error
+	// Get returns the latest snapshot of the named workflow that was created at or before the time, or nil if there
+	// is none. If the uid is not empty, only the snapshots of the workflow with that uid are considered, which
+	// distinguishes workflows of the same name.
+	Get(namespace, name, uid string, at time.Time) (*WorkflowStatusSnapshotRecord, error)
+	// DeleteExpired deletes the snapshots that were created more than the TTL ago
+	DeleteExpired(ttl time.Duration) error
+}
+
+type workflowStatusSnapshotRepo struct {
+	session     db.Session
+	clusterName string
+	dbType      sqldb.DBType
+}
+
+func NewWorkflowStatusSnapshotRepo(session db.Session, clusterName string) WorkflowStatusSnapshotRepo {
+	return &workflowStatusSnapshotRepo{session: session, clusterName: clusterName, dbType: sqldb.DBTypeFor(session)}
+}
+
+func (r *workflowStatusSnapshotRepo) Save(record *WorkflowStatusSnapshotRecord) error {
+	record.ClusterName = r.clusterName
+	record.CreatedAt = record.CreatedAt.UTC()
+	if r.dbType == sqldb.Postgres {
+		record.Nodes = strings.ReplaceAll(record.Nodes, "\\u0000", postgresNullReplacement)
+	}
+	return r.session.Tx(func(sess db.Session) error {
+		_, err := sess.SQL().
+			DeleteFrom(workflowStatusSnapshotsTableName).
+			Where(db.Cond{"clustername": r.clusterName, "uid": record.UID, "createdat": record.CreatedAt}).
+			Exec()
+		if err != nil {
+			return err
+		}
+		_, err = sess.Collection(workflowStatusSnapshotsTableName).Insert(record)
+		return err
+	})
+}
+
+func (r *workflowStatusSnapshotRepo) Get(namespace, name, uid string, at time.Time) (*WorkflowStatusSnapshotRecord, error) {
+	cond := db.Cond{"clustername": r.clusterName, "namespace": namespace, "name": name, "createdat <=": at.UTC()}
+	if uid != "" {
+		cond["uid"] = uid
+	}
+	record := &WorkflowStatusSnapshotRecord{}
+	err := r.session.SQL().
+		SelectFrom(workflowStatusSnapshotsTableName).
+		Where(cond).
+		OrderBy("-createdat").
+		Limit(1).
+		One(record)
+	if err != nil {
+		if err == db.ErrNoMoreRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if r.dbType == sqldb.Postgres {
+		record.Nodes = strings.ReplaceAll(record.Nodes, postgresNullReplacement, "\\u0000")
+	}
+	return record, nil
+}
+
+func (r *workflowStatusSnapshotRepo) DeleteExpired(ttl time.Duration) error {
+	rs, err := r.session.SQL().
+		DeleteFrom(workflowStatusSnapshotsTableName).
+		Where(db.Cond{"clustername": r.clusterName}).
+		And(db.Cond{"createdat <": time.Now().UTC().Add(-ttl)}).
+		Exec()
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := rs.RowsAffected()
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"rowsAffected": rowsAffected, "ttl": ttl}).Info("Deleted expired workflow status snapshots")
+	return nil
+}
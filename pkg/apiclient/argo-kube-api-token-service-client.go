@@ -0,0 +1,31 @@
+package apiclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
+)
+
+type argoKubeAPITokenServiceClient struct {
+	delegate apitokenpkg.APITokenServiceServer
+}
+
+var _ apitokenpkg.APITokenServiceClient = &argoKubeAPITokenServiceClient{}
+
+func (c *argoKubeAPITokenServiceClient) CreateAPIToken(ctx context.Context, req *apitokenpkg.CreateAPITokenRequest, _ ...grpc.CallOption) (*apitokenpkg.APIToken, error) {
+	return c.delegate.CreateAPIToken(ctx, req)
+}
+
+func (c *argoKubeAPITokenServiceClient) ListAPITokens(ctx context.Context, req *apitokenpkg.ListAPITokensRequest, _ ...grpc.CallOption) (*apitokenpkg.APITokenList, error) {
+	return c.delegate.ListAPITokens(ctx, req)
+}
+
+func (c *argoKubeAPITokenServiceClient) RotateAPIToken(ctx context.Context, req *apitokenpkg.RotateAPITokenRequest, _ ...grpc.CallOption) (*apitokenpkg.APIToken, error) {
+	return c.delegate.RotateAPIToken(ctx, req)
+}
+
+func (c *argoKubeAPITokenServiceClient) RevokeAPIToken(ctx context.Context, req *apitokenpkg.RevokeAPITokenRequest, _ ...grpc.CallOption) (*apitokenpkg.RevokeAPITokenResponse, error) {
+	return c.delegate.RevokeAPIToken(ctx, req)
+}
@@ -12,13 +12,16 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
 	workflow "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	apitokenserver "github.com/argoproj/argo-workflows/v3/server/apitoken"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	clusterworkflowtmplserver "github.com/argoproj/argo-workflows/v3/server/clusterworkflowtemplate"
 	cronworkflowserver "github.com/argoproj/argo-workflows/v3/server/cronworkflow"
@@ -28,6 +31,7 @@ import (
 	workflowtemplateserver "github.com/argoproj/argo-workflows/v3/server/workflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/util/help"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/defaults"
 )
 
 var (
@@ -108,7 +112,7 @@ func newArgoKubeClient(ctx context.Context, opts ArgoKubeOpts, clientConfig clie
 		Kubernetes: kubeClient,
 		Workflow:   wfClient,
 	}
-	gatekeeper, err := auth.NewGatekeeper(auth.Modes{auth.Server: true}, clients, restConfig, nil, auth.DefaultClientForAuthorization, "unused", "unused", false, nil)
+	gatekeeper, err := auth.NewGatekeeper(auth.Modes{auth.Server: true}, clients, restConfig, nil, auth.DefaultClientForAuthorization, "unused", "unused", false, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -168,14 +172,25 @@ func (a *argoKubeClient) startStores(restConfig *restclient.Config) error {
 }
 
 func (a *argoKubeClient) NewWorkflowServiceClient() workflowpkg.WorkflowServiceClient {
+	return &errorTranslatingWorkflowServiceClient{&argoKubeWorkflowServiceClient{a.newWorkflowServer()}}
+}
+
+func (a *argoKubeClient) NewWorkflowBatchServiceClient() (workflowbatchpkg.WorkflowBatchServiceClient, error) {
+	return &argoKubeWorkflowBatchServiceClient{a.newWorkflowServer()}, nil
+}
+
+func (a *argoKubeClient) newWorkflowServer() interface {
+	workflowpkg.WorkflowServiceServer
+	workflowbatchpkg.WorkflowBatchServiceServer
+} {
 	wfArchive := sqldb.NullWorkflowArchive
-	wfServer := workflowserver.NewWorkflowServer(a.instanceIDService, argoKubeOffloadNodeStatusRepo, wfArchive, a.wfClient, a.wfLister, a.wfStore, a.wfTmplStore, a.cwfTmplStore, nil, &a.namespace)
+	wfServer := workflowserver.NewWorkflowServer(a.instanceIDService, argoKubeOffloadNodeStatusRepo, wfArchive, sqldb.NullSubmissionQueue, nil, a.wfClient, a.wfLister, a.wfStore, a.wfTmplStore, a.cwfTmplStore, defaults.Static(nil), &a.namespace)
 	go wfServer.Run(a.opts.CachingCloseCh)
-	return &errorTranslatingWorkflowServiceClient{&argoKubeWorkflowServiceClient{wfServer}}
+	return wfServer
 }
 
 func (a *argoKubeClient) NewCronWorkflowServiceClient() (cronworkflow.CronWorkflowServiceClient, error) {
-	return &errorTranslatingCronWorkflowServiceClient{&argoKubeCronWorkflowServiceClient{cronworkflowserver.NewCronWorkflowServer(a.instanceIDService, a.wfTmplStore, a.cwfTmplStore, nil)}}, nil
+	return &errorTranslatingCronWorkflowServiceClient{&argoKubeCronWorkflowServiceClient{cronworkflowserver.NewCronWorkflowServer(a.instanceIDService, a.wfTmplStore, a.cwfTmplStore, defaults.Static(nil))}}, nil
 }
 
 func (a *argoKubeClient) NewWorkflowTemplateServiceClient() (workflowtemplate.WorkflowTemplateServiceClient, error) {
@@ -190,6 +205,10 @@ func (a *argoKubeClient) NewInfoServiceClient() (infopkg.InfoServiceClient, erro
 	return nil, ErrNoArgoServer
 }
 
+func (a *argoKubeClient) NewAPITokenServiceClient() (apitokenpkg.APITokenServiceClient, error) {
+	return &argoKubeAPITokenServiceClient{apitokenserver.NewAPITokenServer()}, nil
+}
+
 func (a *argoKubeClient) NewClusterWorkflowTemplateServiceClient() (clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient, error) {
-	return &errorTranslatingWorkflowClusterTemplateServiceClient{&argoKubeWorkflowClusterTemplateServiceClient{clusterworkflowtmplserver.NewClusterWorkflowTemplateServer(a.instanceIDService, a.cwfTmplStore, nil)}}, nil
+	return &errorTranslatingWorkflowClusterTemplateServiceClient{&argoKubeWorkflowClusterTemplateServiceClient{clusterworkflowtmplserver.NewClusterWorkflowTemplateServer(a.instanceIDService, a.cwfTmplStore, defaults.Static(nil))}}, nil
 }
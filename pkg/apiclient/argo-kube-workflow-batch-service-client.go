@@ -0,0 +1,23 @@
+package apiclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
+)
+
+type argoKubeWorkflowBatchServiceClient struct {
+	delegate workflowbatchpkg.WorkflowBatchServiceServer
+}
+
+var _ workflowbatchpkg.WorkflowBatchServiceClient = &argoKubeWorkflowBatchServiceClient{}
+
+func (c *argoKubeWorkflowBatchServiceClient) SubmitWorkflowBatch(ctx context.Context, req *workflowbatchpkg.WorkflowBatchSubmitRequest, _ ...grpc.CallOption) (*workflowbatchpkg.WorkflowBatchSubmitResponse, error) {
+	return c.delegate.SubmitWorkflowBatch(ctx, req)
+}
+
+func (c *argoKubeWorkflowBatchServiceClient) RetryWorkflowBatch(ctx context.Context, req *workflowbatchpkg.WorkflowBatchRetryRequest, _ ...grpc.CallOption) (*workflowbatchpkg.WorkflowBatchRetryResponse, error) {
+	return c.delegate.RetryWorkflowBatch(ctx, req)
+}
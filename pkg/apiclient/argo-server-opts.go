@@ -1,6 +1,7 @@
 package apiclient
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 )
@@ -12,6 +13,10 @@ type ArgoServerOpts struct {
 	Path               string
 	Secure             bool
 	InsecureSkipVerify bool
+	// ClientCertificate and ClientKey are the files of the certificate, and its key, that the client presents to the
+	// Argo Server, for the client-cert auth mode
+	ClientCertificate string
+	ClientKey         string
 	// whether or not to use HTTP1
 	HTTP1 bool
 	// use custom http client
@@ -26,6 +31,19 @@ func (o ArgoServerOpts) GetURL() string {
 	return "http://" + o.URL + o.Path
 }
 
+// TLSConfig returns the TLS config to connect to the Argo Server with
+func (o ArgoServerOpts) TLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: o.InsecureSkipVerify}
+	if o.ClientCertificate != "" || o.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(o.ClientCertificate, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
 func (o ArgoServerOpts) String() string {
 	return fmt.Sprintf("(url=%s,path=%s,secure=%v,insecureSkipVerify=%v,http=%v)", o.URL, o.Path, o.Secure, o.InsecureSkipVerify, o.HTTP1)
 }
@@ -0,0 +1,31 @@
+package http1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
+)
+
+type APITokenServiceClient = Facade
+
+func (h APITokenServiceClient) CreateAPIToken(ctx context.Context, in *apitokenpkg.CreateAPITokenRequest, _ ...grpc.CallOption) (*apitokenpkg.APIToken, error) {
+	out := &apitokenpkg.APIToken{}
+	return out, h.Post(ctx, in, out, "/api/v1/api-tokens/{namespace}")
+}
+
+func (h APITokenServiceClient) ListAPITokens(ctx context.Context, in *apitokenpkg.ListAPITokensRequest, _ ...grpc.CallOption) (*apitokenpkg.APITokenList, error) {
+	out := &apitokenpkg.APITokenList{}
+	return out, h.Get(ctx, in, out, "/api/v1/api-tokens/{namespace}")
+}
+
+func (h APITokenServiceClient) RotateAPIToken(ctx context.Context, in *apitokenpkg.RotateAPITokenRequest, _ ...grpc.CallOption) (*apitokenpkg.APIToken, error) {
+	out := &apitokenpkg.APIToken{}
+	return out, h.Post(ctx, in, out, "/api/v1/api-tokens/{namespace}/{name}/rotate")
+}
+
+func (h APITokenServiceClient) RevokeAPIToken(ctx context.Context, in *apitokenpkg.RevokeAPITokenRequest, _ ...grpc.CallOption) (*apitokenpkg.RevokeAPITokenResponse, error) {
+	out := &apitokenpkg.RevokeAPITokenResponse{}
+	return out, h.Delete(ctx, in, out, "/api/v1/api-tokens/{namespace}/{name}")
+}
@@ -0,0 +1,21 @@
+package http1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
+)
+
+type WorkflowBatchServiceClient = Facade
+
+func (h WorkflowBatchServiceClient) SubmitWorkflowBatch(ctx context.Context, in *workflowbatchpkg.WorkflowBatchSubmitRequest, _ ...grpc.CallOption) (*workflowbatchpkg.WorkflowBatchSubmitResponse, error) {
+	out := &workflowbatchpkg.WorkflowBatchSubmitResponse{}
+	return out, h.Post(ctx, in, out, "/api/v1/workflow-batches/{namespace}")
+}
+
+func (h WorkflowBatchServiceClient) RetryWorkflowBatch(ctx context.Context, in *workflowbatchpkg.WorkflowBatchRetryRequest, _ ...grpc.CallOption) (*workflowbatchpkg.WorkflowBatchRetryResponse, error) {
+	out := &workflowbatchpkg.WorkflowBatchRetryResponse{}
+	return out, h.Post(ctx, in, out, "/api/v1/workflow-batches/{namespace}/retry")
+}
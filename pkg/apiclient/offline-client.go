@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/file"
@@ -136,6 +138,14 @@ func (c *offlineClient) NewInfoServiceClient() (infopkg.InfoServiceClient, error
 	return nil, ErrNoArgoServer
 }
 
+func (c *offlineClient) NewAPITokenServiceClient() (apitokenpkg.APITokenServiceClient, error) {
+	return nil, ErrOffline
+}
+
+func (c *offlineClient) NewWorkflowBatchServiceClient() (workflowbatchpkg.WorkflowBatchServiceClient, error) {
+	return nil, ErrOffline
+}
+
 type offlineWorkflowTemplateNamespacedGetter struct {
 	namespace         string
 	workflowTemplates map[string]*wfv1.WorkflowTemplate
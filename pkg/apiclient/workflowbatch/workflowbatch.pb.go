@@ -0,0 +1,2062 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pkg/apiclient/workflowbatch/workflowbatch.proto
+
+// Batch submissions validate and create many workflows in one request, for systems that fan out many submissions at
+// once. Batch retries retry every failed workflow that matches a selector, e.g. after an outage failed many workflows
+// at once.
+
+package workflowbatch
+
+import (
+	context "context"
+	fmt "fmt"
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	proto "github.com/gogo/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type WorkflowBatchSubmitRequest struct {
+	Namespace string               `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Workflows []*v1alpha1.Workflow `protobuf:"bytes,2,rep,name=workflows,proto3" json:"workflows,omitempty"`
+	// create none of the workflows unless all of them are valid, and delete the workflows that were created if one of
+	// them cannot be
+	AllOrNothing bool `protobuf:"varint,3,opt,name=allOrNothing,proto3" json:"allOrNothing,omitempty"`
+	// validate the workflows without creating them
+	DryRun bool `protobuf:"varint,4,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	// validate the workflows, and have the Kubernetes API validate their creation, without creating them
+	ServerDryRun         bool     `protobuf:"varint,5,opt,name=serverDryRun,proto3" json:"serverDryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkflowBatchSubmitRequest) Reset()         { *m = WorkflowBatchSubmitRequest{} }
+func (m *WorkflowBatchSubmitRequest) String() string { return proto.CompactTextString(m) }
+func (*WorkflowBatchSubmitRequest) ProtoMessage()    {}
+func (*WorkflowBatchSubmitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_03b4c953de465661, []int{0}
+}
+func (m *WorkflowBatchSubmitRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WorkflowBatchSubmitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WorkflowBatchSubmitRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WorkflowBatchSubmitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowBatchSubmitRequest.Merge(m, src)
+}
+func (m *WorkflowBatchSubmitRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WorkflowBatchSubmitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowBatchSubmitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowBatchSubmitRequest proto.InternalMessageInfo
+
+func (m *WorkflowBatchSubmitRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *WorkflowBatchSubmitRequest) GetWorkflows() []*v1alpha1.Workflow {
+	if m != nil {
+		return m.Workflows
+	}
+	return nil
+}
+
+func (m *WorkflowBatchSubmitRequest) GetAllOrNothing() bool {
+	if m != nil {
+		return m.AllOrNothing
+	}
+	return false
+}
+
+func (m *WorkflowBatchSubmitRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *WorkflowBatchSubmitRequest) GetServerDryRun() bool {
+	if m != nil {
+		return m.ServerDryRun
+	}
+	return false
+}
+
+// WorkflowBatchSubmitResult is the result of one of the workflows of a batch, in the order they were submitted in
+type WorkflowBatchSubmitResult struct {
+	// the created workflow, if it was created
+	Workflow *v1alpha1.Workflow `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	// why the workflow was not created, if it was not
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkflowBatchSubmitResult) Reset()         { *m = WorkflowBatchSubmitResult{} }
+func (m *WorkflowBatchSubmitResult) String() string { return proto.CompactTextString(m) }
+func (*WorkflowBatchSubmitResult) ProtoMessage()    {}
+func (*WorkflowBatchSubmitResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_03b4c953de465661, []int{1}
+}
+func (m *WorkflowBatchSubmitResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WorkflowBatchSubmitResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WorkflowBatchSubmitResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WorkflowBatchSubmitResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowBatchSubmitResult.Merge(m, src)
+}
+func (m *WorkflowBatchSubmitResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *WorkflowBatchSubmitResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowBatchSubmitResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowBatchSubmitResult proto.InternalMessageInfo
+
+func (m *WorkflowBatchSubmitResult) GetWorkflow() *v1alpha1.Workflow {
+	if m != nil {
+		return m.Workflow
+	}
+	return nil
+}
+
+func (m *WorkflowBatchSubmitResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type WorkflowBatchSubmitResponse struct {
+	Items                []*WorkflowBatchSubmitResult `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *WorkflowBatchSubmitResponse) Reset()         { *m = WorkflowBatchSubmitResponse{} }
+func (m *WorkflowBatchSubmitResponse) String() string { return proto.CompactTextString(m) }
+func (*WorkflowBatchSubmitResponse) ProtoMessage()    {}
+func (*WorkflowBatchSubmitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_03b4c953de465661, []int{2}
+}
+func (m *WorkflowBatchSubmitResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WorkflowBatchSubmitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WorkflowBatchSubmitResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WorkflowBatchSubmitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowBatchSubmitResponse.Merge(m, src)
+}
+func (m *WorkflowBatchSubmitResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WorkflowBatchSubmitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowBatchSubmitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowBatchSubmitResponse proto.InternalMessageInfo
+
+func (m *WorkflowBatchSubmitResponse) GetItems() []*WorkflowBatchSubmitResult {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type WorkflowBatchRetryRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// selects the workflows to retry, of which only those that failed or errored are retried
+	LabelSelector string `protobuf:"bytes,2,opt,name=labelSelector,proto3" json:"labelSelector,omitempty"`
+	// how many of the workflows are retried at once, 10 by default
+	Parallelism       int32    `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	RestartSuccessful bool     `protobuf:"varint,4,opt,name=restartSuccessful,proto3" json:"restartSuccessful,omitempty"`
+	NodeFieldSelector string   `protobuf:"bytes,5,opt,name=nodeFieldSelector,proto3" json:"nodeFieldSelector,omitempty"`
+	Parameters        []string `protobuf:"bytes,6,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// return the workflows that would be retried without retrying them
+	DryRun               bool     `protobuf:"varint,7,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkflowBatchRetryRequest) Reset()         { *m = WorkflowBatchRetryRequest{} }
+func (m *WorkflowBatchRetryRequest) String() string { return proto.CompactTextString(m) }
+func (*WorkflowBatchRetryRequest) ProtoMessage()    {}
+func (*WorkflowBatchRetryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_03b4c953de465661, []int{3}
+}
+func (m *WorkflowBatchRetryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WorkflowBatchRetryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WorkflowBatchRetryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WorkflowBatchRetryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowBatchRetryRequest.Merge(m, src)
+}
+func (m *WorkflowBatchRetryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WorkflowBatchRetryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowBatchRetryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowBatchRetryRequest proto.InternalMessageInfo
+
+func (m *WorkflowBatchRetryRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *WorkflowBatchRetryRequest) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+func (m *WorkflowBatchRetryRequest) GetParallelism() int32 {
+	if m != nil {
+		return m.Parallelism
+	}
+	return 0
+}
+
+func (m *WorkflowBatchRetryRequest) GetRestartSuccessful() bool {
+	if m != nil {
+		return m.RestartSuccessful
+	}
+	return false
+}
+
+func (m *WorkflowBatchRetryRequest) GetNodeFieldSelector() string {
+	if m != nil {
+		return m.NodeFieldSelector
+	}
+	return ""
+}
+
+func (m *WorkflowBatchRetryRequest) GetParameters() []string {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *WorkflowBatchRetryRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+// WorkflowBatchRetryResult is the result of one of the workflows that were retried
+type WorkflowBatchRetryResult struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the retried workflow, if it was retried
+	Workflow *v1alpha1.Workflow `protobuf:"bytes,2,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	// why the workflow was not retried, if it was not
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkflowBatchRetryResult) Reset()         { *m = WorkflowBatchRetryResult{} }
+func (m *WorkflowBatchRetryResult) String() string { return proto.CompactTextString(m) }
+func (*WorkflowBatchRetryResult) ProtoMessage()    {}
+func (*WorkflowBatchRetryResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_03b4c953de465661, []int{4}
+}
+func (m *WorkflowBatchRetryResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WorkflowBatchRetryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WorkflowBatchRetryResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WorkflowBatchRetryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowBatchRetryResult.Merge(m, src)
+}
+func (m *WorkflowBatchRetryResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *WorkflowBatchRetryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowBatchRetryResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowBatchRetryResult proto.InternalMessageInfo
+
+func (m *WorkflowBatchRetryResult) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *WorkflowBatchRetryResult) GetWorkflow() *v1alpha1.Workflow {
+	if m != nil {
+		return m.Workflow
+	}
+	return nil
+}
+
+func (m *WorkflowBatchRetryResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type WorkflowBatchRetryResponse struct {
+	// in the order of the names of the workflows
+	Items                []*WorkflowBatchRetryResult `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *WorkflowBatchRetryResponse) Reset()         { *m = WorkflowBatchRetryResponse{} }
+func (m *WorkflowBatchRetryResponse) String() string { return proto.CompactTextString(m) }
+func (*WorkflowBatchRetryResponse) ProtoMessage()    {}
+func (*WorkflowBatchRetryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_03b4c953de465661, []int{5}
+}
+func (m *WorkflowBatchRetryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WorkflowBatchRetryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WorkflowBatchRetryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WorkflowBatchRetryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowBatchRetryResponse.Merge(m, src)
+}
+func (m *WorkflowBatchRetryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WorkflowBatchRetryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowBatchRetryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowBatchRetryResponse proto.InternalMessageInfo
+
+func (m *WorkflowBatchRetryResponse) GetItems() []*WorkflowBatchRetryResult {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*WorkflowBatchSubmitRequest)(nil), "workflowbatch.WorkflowBatchSubmitRequest")
+	proto.RegisterType((*WorkflowBatchSubmitResult)(nil), "workflowbatch.WorkflowBatchSubmitResult")
+	proto.RegisterType((*WorkflowBatchSubmitResponse)(nil), "workflowbatch.WorkflowBatchSubmitResponse")
+	proto.RegisterType((*WorkflowBatchRetryRequest)(nil), "workflowbatch.WorkflowBatchRetryRequest")
+	proto.RegisterType((*WorkflowBatchRetryResult)(nil), "workflowbatch.WorkflowBatchRetryResult")
+	proto.RegisterType((*WorkflowBatchRetryResponse)(nil), "workflowbatch.WorkflowBatchRetryResponse")
+}
+
+func init() {
+	proto.RegisterFile("pkg/apiclient/workflowbatch/workflowbatch.proto", fileDescriptor_03b4c953de465661)
+}
+
+var fileDescriptor_03b4c953de465661 = []byte{
+	// 637 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x95, 0xcf, 0x6b, 0xd4, 0x40,
+	0x14, 0xc7, 0x99, 0x6c, 0xb7, 0x76, 0xa7, 0xf6, 0xe0, 0x58, 0x24, 0xae, 0x65, 0x59, 0x42, 0xc1,
+	0x74, 0xb1, 0x09, 0x6d, 0xf1, 0x52, 0xb0, 0x87, 0x22, 0x1e, 0xc4, 0x1f, 0x90, 0x1e, 0x04, 0x45,
+	0x64, 0x36, 0xfb, 0x9a, 0x8d, 0x9d, 0x64, 0xe2, 0xcc, 0x64, 0x4b, 0x11, 0x2f, 0xe2, 0x7f, 0x20,
+	0x1e, 0x3c, 0x08, 0xfe, 0x01, 0xfe, 0x21, 0x1e, 0x05, 0x4f, 0xde, 0x64, 0xf1, 0x0f, 0x91, 0x4c,
+	0x36, 0xd9, 0xa4, 0x5d, 0x25, 0x07, 0xf1, 0x36, 0xf3, 0xde, 0xbc, 0xf7, 0xbe, 0xef, 0xcd, 0x27,
+	0x13, 0xec, 0x26, 0x27, 0x81, 0x4b, 0x93, 0xd0, 0x67, 0x21, 0xc4, 0xca, 0x3d, 0xe5, 0xe2, 0xe4,
+	0x98, 0xf1, 0xd3, 0x21, 0x55, 0xfe, 0xb8, 0xbe, 0x73, 0x12, 0xc1, 0x15, 0x27, 0x6b, 0x35, 0x63,
+	0x77, 0x23, 0xe0, 0x3c, 0x60, 0x90, 0xa5, 0x70, 0x69, 0x1c, 0x73, 0x45, 0x55, 0xc8, 0x63, 0x99,
+	0x1f, 0xee, 0x3e, 0x0c, 0x42, 0x35, 0x4e, 0x87, 0x8e, 0xcf, 0x23, 0x97, 0x8a, 0x80, 0x27, 0x82,
+	0xbf, 0xd4, 0x8b, 0xed, 0x22, 0x8b, 0x2c, 0x04, 0xc8, 0xb2, 0x9a, 0x3b, 0xd9, 0xa1, 0x2c, 0x19,
+	0xd3, 0x1d, 0x37, 0x80, 0x18, 0x04, 0x55, 0x30, 0xca, 0xd3, 0x59, 0xef, 0x0c, 0xdc, 0x7d, 0x32,
+	0x3b, 0x75, 0x98, 0x95, 0x3f, 0x4a, 0x87, 0x51, 0xa8, 0x3c, 0x78, 0x95, 0x82, 0x54, 0x64, 0x03,
+	0x77, 0x62, 0x1a, 0x81, 0x4c, 0xa8, 0x0f, 0x26, 0xea, 0x23, 0xbb, 0xe3, 0xcd, 0x0d, 0x64, 0x8c,
+	0x3b, 0x65, 0x51, 0xd3, 0xe8, 0xb7, 0xec, 0xd5, 0xdd, 0xfb, 0xce, 0x5c, 0x9f, 0x53, 0xe8, 0xd3,
+	0x8b, 0x17, 0xe5, 0x51, 0x67, 0xb2, 0xe7, 0x24, 0x27, 0x81, 0x93, 0x49, 0x74, 0x0a, 0xab, 0x53,
+	0x48, 0x74, 0x0a, 0x39, 0xde, 0x3c, 0x39, 0xb1, 0xf0, 0x65, 0xca, 0xd8, 0x63, 0xf1, 0x88, 0xab,
+	0x71, 0x18, 0x07, 0x66, 0xab, 0x8f, 0xec, 0x15, 0xaf, 0x66, 0x23, 0xd7, 0xf0, 0xf2, 0x48, 0x9c,
+	0x79, 0x69, 0x6c, 0x2e, 0x69, 0xef, 0x6c, 0x97, 0xc5, 0x4a, 0x10, 0x13, 0x10, 0x77, 0x73, 0x6f,
+	0x3b, 0x8f, 0xad, 0xda, 0xac, 0x8f, 0x08, 0x5f, 0x5f, 0x38, 0x06, 0x99, 0x32, 0x45, 0x8e, 0xf1,
+	0x4a, 0x21, 0x45, 0x0f, 0xe1, 0xdf, 0xb6, 0x59, 0xe6, 0x26, 0xeb, 0xb8, 0x0d, 0x42, 0x70, 0x61,
+	0x1a, 0x7a, 0xd2, 0xf9, 0xc6, 0x7a, 0x8e, 0x6f, 0x2c, 0x96, 0x96, 0xf0, 0x58, 0x02, 0x39, 0xc0,
+	0xed, 0x50, 0x41, 0x24, 0x4d, 0xa4, 0x2f, 0xc0, 0x76, 0xea, 0x88, 0xfd, 0xb1, 0x2b, 0x2f, 0x0f,
+	0xb3, 0x3e, 0x18, 0xe7, 0x5a, 0xf7, 0x40, 0x89, 0xb3, 0x66, 0x00, 0x6c, 0xe2, 0x35, 0x46, 0x87,
+	0xc0, 0x8e, 0x80, 0x81, 0xaf, 0x4a, 0xe1, 0x75, 0x23, 0xe9, 0xe3, 0xd5, 0x84, 0x0a, 0xca, 0x18,
+	0xb0, 0x50, 0x46, 0xfa, 0xee, 0xda, 0x5e, 0xd5, 0x44, 0x6e, 0xe1, 0x2b, 0x02, 0xa4, 0xa2, 0x42,
+	0x1d, 0xa5, 0xbe, 0x0f, 0x52, 0x1e, 0xa7, 0x6c, 0x76, 0x8b, 0x17, 0x1d, 0xd9, 0xe9, 0x98, 0x8f,
+	0xe0, 0x5e, 0x08, 0x6c, 0x54, 0x56, 0x6e, 0xeb, 0xca, 0x17, 0x1d, 0xa4, 0x87, 0x71, 0x56, 0x2a,
+	0x02, 0x05, 0x42, 0x9a, 0xcb, 0xfd, 0x96, 0xdd, 0xf1, 0x2a, 0x96, 0x0a, 0x36, 0x97, 0xaa, 0xd8,
+	0x58, 0x5f, 0x10, 0x36, 0x17, 0xcd, 0x45, 0x13, 0x41, 0xf0, 0x52, 0x36, 0x85, 0xd9, 0x44, 0xf4,
+	0xba, 0x46, 0x89, 0xf1, 0x3f, 0x28, 0x69, 0x55, 0x29, 0x79, 0x76, 0xee, 0x3b, 0x2e, 0xd4, 0xe6,
+	0x90, 0xdc, 0xa9, 0x43, 0x72, 0xf3, 0x6f, 0x90, 0x54, 0xfa, 0x9c, 0x31, 0xb2, 0xfb, 0xc3, 0xc0,
+	0xeb, 0x75, 0x90, 0x40, 0x4c, 0x42, 0x1f, 0xc8, 0x27, 0x84, 0xaf, 0xe6, 0x50, 0xd5, 0xdc, 0x64,
+	0xab, 0x09, 0x85, 0x9a, 0xb0, 0xee, 0xa0, 0x11, 0xb0, 0xba, 0x0d, 0xcb, 0x7d, 0xfb, 0xfd, 0xd7,
+	0x7b, 0x63, 0xcb, 0xda, 0xd4, 0x8f, 0xe3, 0x64, 0xa7, 0x7c, 0xe0, 0xb6, 0x75, 0x2c, 0x48, 0xf7,
+	0x75, 0x09, 0xe7, 0x9b, 0x7d, 0x34, 0x20, 0x9f, 0x11, 0x26, 0xba, 0x9f, 0xba, 0x3c, 0xbb, 0x41,
+	0xff, 0xb9, 0xba, 0xad, 0x26, 0x93, 0xca, 0xc5, 0xdd, 0xd6, 0xe2, 0x5c, 0x6b, 0xd0, 0x44, 0x9c,
+	0x2b, 0xb2, 0xd8, 0x7d, 0x34, 0x38, 0x7c, 0xf0, 0x75, 0xda, 0x43, 0xdf, 0xa6, 0x3d, 0xf4, 0x73,
+	0xda, 0x43, 0x4f, 0x0f, 0x9a, 0x3f, 0xef, 0x8b, 0xfe, 0x2f, 0xc3, 0x65, 0xfd, 0xac, 0xef, 0xfd,
+	0x0e, 0x00, 0x00, 0xff, 0xff, 0xe7, 0x13, 0xe3, 0xcb, 0x85, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WorkflowBatchServiceClient is the client API for WorkflowBatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WorkflowBatchServiceClient interface {
+	// SubmitWorkflowBatch returns the result of each of the workflows. It only returns an error if the batch itself is
+	// invalid, e.g. too large.
+	SubmitWorkflowBatch(ctx context.Context, in *WorkflowBatchSubmitRequest, opts ...grpc.CallOption) (*WorkflowBatchSubmitResponse, error)
+	// RetryWorkflowBatch retries each of the failed workflows that match the selector, and returns the result of each of
+	// them. It only returns an error if the workflows cannot be listed.
+	RetryWorkflowBatch(ctx context.Context, in *WorkflowBatchRetryRequest, opts ...grpc.CallOption) (*WorkflowBatchRetryResponse, error)
+}
+
+type workflowBatchServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWorkflowBatchServiceClient(cc *grpc.ClientConn) WorkflowBatchServiceClient {
+	return &workflowBatchServiceClient{cc}
+}
+
+func (c *workflowBatchServiceClient) SubmitWorkflowBatch(ctx context.Context, in *WorkflowBatchSubmitRequest, opts ...grpc.CallOption) (*WorkflowBatchSubmitResponse, error) {
+	out := new(WorkflowBatchSubmitResponse)
+	err := c.cc.Invoke(ctx, "/workflowbatch.WorkflowBatchService/SubmitWorkflowBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowBatchServiceClient) RetryWorkflowBatch(ctx context.Context, in *WorkflowBatchRetryRequest, opts ...grpc.CallOption) (*WorkflowBatchRetryResponse, error) {
+	out := new(WorkflowBatchRetryResponse)
+	err := c.cc.Invoke(ctx, "/workflowbatch.WorkflowBatchService/RetryWorkflowBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkflowBatchServiceServer is the server API for WorkflowBatchService service.
+type WorkflowBatchServiceServer interface {
+	// SubmitWorkflowBatch returns the result of each of the workflows. It only returns an error if the batch itself is
+	// invalid, e.g. too large.
+	SubmitWorkflowBatch(context.Context, *WorkflowBatchSubmitRequest) (*WorkflowBatchSubmitResponse, error)
+	// RetryWorkflowBatch retries each of the failed workflows that match the selector, and returns the result of each of
+	// them. It only returns an error if the workflows cannot be listed.
+	RetryWorkflowBatch(context.Context, *WorkflowBatchRetryRequest) (*WorkflowBatchRetryResponse, error)
+}
+
+// UnimplementedWorkflowBatchServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWorkflowBatchServiceServer struct {
+}
+
+func (*UnimplementedWorkflowBatchServiceServer) SubmitWorkflowBatch(ctx context.Context, req *WorkflowBatchSubmitRequest) (*WorkflowBatchSubmitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitWorkflowBatch not implemented")
+}
+func (*UnimplementedWorkflowBatchServiceServer) RetryWorkflowBatch(ctx context.Context, req *WorkflowBatchRetryRequest) (*WorkflowBatchRetryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryWorkflowBatch not implemented")
+}
+
+func RegisterWorkflowBatchServiceServer(s *grpc.Server, srv WorkflowBatchServiceServer) {
+	s.RegisterService(&_WorkflowBatchService_serviceDesc, srv)
+}
+
+func _WorkflowBatchService_SubmitWorkflowBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowBatchSubmitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowBatchServiceServer).SubmitWorkflowBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflowbatch.WorkflowBatchService/SubmitWorkflowBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowBatchServiceServer).SubmitWorkflowBatch(ctx, req.(*WorkflowBatchSubmitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowBatchService_RetryWorkflowBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowBatchRetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowBatchServiceServer).RetryWorkflowBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflowbatch.WorkflowBatchService/RetryWorkflowBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowBatchServiceServer).RetryWorkflowBatch(ctx, req.(*WorkflowBatchRetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WorkflowBatchService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "workflowbatch.WorkflowBatchService",
+	HandlerType: (*WorkflowBatchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitWorkflowBatch",
+			Handler:    _WorkflowBatchService_SubmitWorkflowBatch_Handler,
+		},
+		{
+			MethodName: "RetryWorkflowBatch",
+			Handler:    _WorkflowBatchService_RetryWorkflowBatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/apiclient/workflowbatch/workflowbatch.proto",
+}
+
+func (m *WorkflowBatchSubmitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowBatchSubmitRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowBatchSubmitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ServerDryRun {
+		i--
+		if m.ServerDryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.DryRun {
+		i--
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.AllOrNothing {
+		i--
+		if m.AllOrNothing {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Workflows) > 0 {
+		for iNdEx := len(m.Workflows) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Workflows[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWorkflowbatch(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintWorkflowbatch(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WorkflowBatchSubmitResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowBatchSubmitResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowBatchSubmitResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintWorkflowbatch(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Workflow != nil {
+		{
+			size, err := m.Workflow.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWorkflowbatch(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WorkflowBatchSubmitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowBatchSubmitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowBatchSubmitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWorkflowbatch(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WorkflowBatchRetryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowBatchRetryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowBatchRetryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.DryRun {
+		i--
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.Parameters) > 0 {
+		for iNdEx := len(m.Parameters) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Parameters[iNdEx])
+			copy(dAtA[i:], m.Parameters[iNdEx])
+			i = encodeVarintWorkflowbatch(dAtA, i, uint64(len(m.Parameters[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.NodeFieldSelector) > 0 {
+		i -= len(m.NodeFieldSelector)
+		copy(dAtA[i:], m.NodeFieldSelector)
+		i = encodeVarintWorkflowbatch(dAtA, i, uint64(len(m.NodeFieldSelector)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.RestartSuccessful {
+		i--
+		if m.RestartSuccessful {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Parallelism != 0 {
+		i = encodeVarintWorkflowbatch(dAtA, i, uint64(m.Parallelism))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.LabelSelector) > 0 {
+		i -= len(m.LabelSelector)
+		copy(dAtA[i:], m.LabelSelector)
+		i = encodeVarintWorkflowbatch(dAtA, i, uint64(len(m.LabelSelector)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintWorkflowbatch(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WorkflowBatchRetryResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowBatchRetryResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowBatchRetryResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintWorkflowbatch(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Workflow != nil {
+		{
+			size, err := m.Workflow.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWorkflowbatch(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintWorkflowbatch(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WorkflowBatchRetryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowBatchRetryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowBatchRetryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWorkflowbatch(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWorkflowbatch(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWorkflowbatch(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *WorkflowBatchSubmitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	if len(m.Workflows) > 0 {
+		for _, e := range m.Workflows {
+			l = e.Size()
+			n += 1 + l + sovWorkflowbatch(uint64(l))
+		}
+	}
+	if m.AllOrNothing {
+		n += 2
+	}
+	if m.DryRun {
+		n += 2
+	}
+	if m.ServerDryRun {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WorkflowBatchSubmitResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Workflow != nil {
+		l = m.Workflow.Size()
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WorkflowBatchSubmitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovWorkflowbatch(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WorkflowBatchRetryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	l = len(m.LabelSelector)
+	if l > 0 {
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	if m.Parallelism != 0 {
+		n += 1 + sovWorkflowbatch(uint64(m.Parallelism))
+	}
+	if m.RestartSuccessful {
+		n += 2
+	}
+	l = len(m.NodeFieldSelector)
+	if l > 0 {
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	if len(m.Parameters) > 0 {
+		for _, s := range m.Parameters {
+			l = len(s)
+			n += 1 + l + sovWorkflowbatch(uint64(l))
+		}
+	}
+	if m.DryRun {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WorkflowBatchRetryResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	if m.Workflow != nil {
+		l = m.Workflow.Size()
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovWorkflowbatch(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WorkflowBatchRetryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovWorkflowbatch(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovWorkflowbatch(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWorkflowbatch(x uint64) (n int) {
+	return sovWorkflowbatch(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *WorkflowBatchSubmitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowbatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkflowBatchSubmitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkflowBatchSubmitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Workflows", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Workflows = append(m.Workflows, &v1alpha1.Workflow{})
+			if err := m.Workflows[len(m.Workflows)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllOrNothing", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllOrNothing = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerDryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ServerDryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowbatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkflowBatchSubmitResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowbatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkflowBatchSubmitResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkflowBatchSubmitResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Workflow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Workflow == nil {
+				m.Workflow = &v1alpha1.Workflow{}
+			}
+			if err := m.Workflow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowbatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkflowBatchSubmitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowbatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkflowBatchSubmitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkflowBatchSubmitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &WorkflowBatchSubmitResult{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowbatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkflowBatchRetryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowbatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkflowBatchRetryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkflowBatchRetryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelSelector", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelSelector = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parallelism", wireType)
+			}
+			m.Parallelism = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Parallelism |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RestartSuccessful", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RestartSuccessful = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeFieldSelector", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeFieldSelector = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowbatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkflowBatchRetryResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowbatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkflowBatchRetryResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkflowBatchRetryResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Workflow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Workflow == nil {
+				m.Workflow = &v1alpha1.Workflow{}
+			}
+			if err := m.Workflow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowbatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkflowBatchRetryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowbatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkflowBatchRetryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkflowBatchRetryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &WorkflowBatchRetryResult{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowbatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowbatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipWorkflowbatch(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowWorkflowbatch
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWorkflowbatch
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthWorkflowbatch
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupWorkflowbatch
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthWorkflowbatch
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthWorkflowbatch        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowWorkflowbatch          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupWorkflowbatch = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,1220 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pkg/apiclient/workflowhistory/workflowhistory.proto
+
+// The status history of workflows returns the states of the nodes of a workflow as of a time in the past, from the
+// periodic snapshots of its status, e.g. to reconstruct what was running when an incident started.
+
+package workflowhistory
+
+import (
+	context "context"
+	fmt "fmt"
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	proto "github.com/gogo/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetWorkflowStatusAtRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// selects the workflow of the name with the UID, if there were more than one
+	Uid string `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	// the RFC 3339 time to return the status of the workflow as of, which is now if it is not set
+	Time                 string   `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWorkflowStatusAtRequest) Reset()         { *m = GetWorkflowStatusAtRequest{} }
+func (m *GetWorkflowStatusAtRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWorkflowStatusAtRequest) ProtoMessage()    {}
+func (*GetWorkflowStatusAtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f74f77836ae8300, []int{0}
+}
+func (m *GetWorkflowStatusAtRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetWorkflowStatusAtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetWorkflowStatusAtRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetWorkflowStatusAtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWorkflowStatusAtRequest.Merge(m, src)
+}
+func (m *GetWorkflowStatusAtRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetWorkflowStatusAtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWorkflowStatusAtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWorkflowStatusAtRequest proto.InternalMessageInfo
+
+func (m *GetWorkflowStatusAtRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GetWorkflowStatusAtRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetWorkflowStatusAtRequest) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *GetWorkflowStatusAtRequest) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+// WorkflowStatusSnapshot is the status of a workflow as of the latest snapshot of it that was saved at or before the
+// time that was requested
+type WorkflowStatusSnapshot struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Uid       string `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	// when the snapshot was saved, which is at most the interval of the snapshots before the time that was requested,
+	// unless the workflow had completed by then
+	Time                 *v1.Time                        `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	Phase                string                          `protobuf:"bytes,5,opt,name=phase,proto3" json:"phase,omitempty"`
+	Message              string                          `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Nodes                map[string]*v1alpha1.NodeStatus `protobuf:"bytes,7,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *WorkflowStatusSnapshot) Reset()         { *m = WorkflowStatusSnapshot{} }
+func (m *WorkflowStatusSnapshot) String() string { return proto.CompactTextString(m) }
+func (*WorkflowStatusSnapshot) ProtoMessage()    {}
+func (*WorkflowStatusSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f74f77836ae8300, []int{1}
+}
+func (m *WorkflowStatusSnapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WorkflowStatusSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WorkflowStatusSnapshot.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WorkflowStatusSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowStatusSnapshot.Merge(m, src)
+}
+func (m *WorkflowStatusSnapshot) XXX_Size() int {
+	return m.Size()
+}
+func (m *WorkflowStatusSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowStatusSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowStatusSnapshot proto.InternalMessageInfo
+
+func (m *WorkflowStatusSnapshot) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *WorkflowStatusSnapshot) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *WorkflowStatusSnapshot) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *WorkflowStatusSnapshot) GetTime() *v1.Time {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *WorkflowStatusSnapshot) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *WorkflowStatusSnapshot) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *WorkflowStatusSnapshot) GetNodes() map[string]*v1alpha1.NodeStatus {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetWorkflowStatusAtRequest)(nil), "workflowhistory.GetWorkflowStatusAtRequest")
+	proto.RegisterType((*WorkflowStatusSnapshot)(nil), "workflowhistory.WorkflowStatusSnapshot")
+	proto.RegisterMapType((map[string]*v1alpha1.NodeStatus)(nil), "workflowhistory.WorkflowStatusSnapshot.NodesEntry")
+}
+
+func init() {
+	proto.RegisterFile("pkg/apiclient/workflowhistory/workflowhistory.proto", fileDescriptor_1f74f77836ae8300)
+}
+
+var fileDescriptor_1f74f77836ae8300 = []byte{
+	// 507 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcf, 0x8a, 0xd3, 0x40,
+	0x18, 0x27, 0xed, 0x76, 0x97, 0x9d, 0x1e, 0x94, 0x51, 0x24, 0x94, 0xa5, 0x2c, 0xbd, 0xb8, 0xb8,
+	0x74, 0x42, 0x53, 0x0f, 0x8b, 0x07, 0x51, 0x41, 0xdc, 0x83, 0xee, 0xa1, 0x15, 0x04, 0x2f, 0x32,
+	0x4d, 0x3f, 0x93, 0x31, 0xc9, 0x4c, 0x9c, 0x99, 0x64, 0x29, 0xb2, 0x17, 0x0f, 0xbe, 0x80, 0x0f,
+	0xa0, 0xef, 0xe1, 0x0b, 0x78, 0x14, 0x7c, 0x01, 0x29, 0x3e, 0x88, 0xcc, 0x4c, 0xd3, 0xac, 0xdd,
+	0x0a, 0x7b, 0xf0, 0xf6, 0xfb, 0x7e, 0xf9, 0xe6, 0xfb, 0xf3, 0xcb, 0xef, 0x43, 0xe3, 0x22, 0x8d,
+	0x03, 0x5a, 0xb0, 0x28, 0x63, 0xc0, 0x75, 0x70, 0x2e, 0x64, 0xfa, 0x36, 0x13, 0xe7, 0x09, 0x53,
+	0x5a, 0xc8, 0xc5, 0x66, 0x4c, 0x0a, 0x29, 0xb4, 0xc0, 0x37, 0x36, 0xe8, 0xde, 0x41, 0x2c, 0x44,
+	0x9c, 0x81, 0x29, 0x14, 0x50, 0xce, 0x85, 0xa6, 0x9a, 0x09, 0xae, 0x5c, 0x7a, 0xef, 0x7e, 0x7a,
+	0xa2, 0x08, 0x13, 0xe6, 0x6b, 0x4e, 0xa3, 0x84, 0x71, 0x90, 0x8b, 0x60, 0xd5, 0x57, 0x05, 0x39,
+	0x68, 0x1a, 0x54, 0xa3, 0x20, 0x06, 0x0e, 0x92, 0x6a, 0x98, 0xaf, 0x5e, 0xbd, 0x88, 0x99, 0x4e,
+	0xca, 0x19, 0x89, 0x44, 0x1e, 0x50, 0x19, 0x8b, 0x42, 0x8a, 0x77, 0x16, 0x0c, 0xeb, 0xee, 0xaa,
+	0x29, 0x52, 0x53, 0x41, 0x35, 0xa2, 0x59, 0x91, 0xd0, 0x2b, 0xe5, 0x06, 0x1a, 0xf5, 0x9e, 0x81,
+	0x7e, 0xb5, 0xca, 0x9b, 0x6a, 0xaa, 0x4b, 0xf5, 0x58, 0x4f, 0xe0, 0x7d, 0x09, 0x4a, 0xe3, 0x03,
+	0xb4, 0xcf, 0x69, 0x0e, 0xaa, 0xa0, 0x11, 0xf8, 0xde, 0xa1, 0x77, 0xb4, 0x3f, 0x69, 0x08, 0x8c,
+	0xd1, 0x8e, 0x09, 0xfc, 0x96, 0xfd, 0x60, 0x31, 0xbe, 0x89, 0xda, 0x25, 0x9b, 0xfb, 0x6d, 0x4b,
+	0x19, 0x68, 0xb2, 0x34, 0xcb, 0xc1, 0xdf, 0x71, 0x59, 0x06, 0x0f, 0xbe, 0xb4, 0xd1, 0x9d, 0xbf,
+	0x7b, 0x4e, 0x39, 0x2d, 0x54, 0x22, 0xfe, 0x4f, 0xcb, 0x87, 0x97, 0x5a, 0x76, 0xc3, 0x7b, 0xc4,
+	0x09, 0x4d, 0x2e, 0x0b, 0x4d, 0x8a, 0x34, 0x36, 0x84, 0x22, 0x46, 0x68, 0x52, 0x8d, 0xc8, 0x4b,
+	0x96, 0x83, 0x1b, 0x0f, 0xdf, 0x46, 0x9d, 0x22, 0xa1, 0x0a, 0xfc, 0x8e, 0xad, 0xe9, 0x02, 0xec,
+	0xa3, 0xbd, 0x1c, 0x94, 0xa2, 0x31, 0xf8, 0xbb, 0x96, 0xaf, 0x43, 0x7c, 0x8a, 0x3a, 0x5c, 0xcc,
+	0x41, 0xf9, 0x7b, 0x87, 0xed, 0xa3, 0x6e, 0x18, 0x92, 0x4d, 0x7f, 0x6c, 0xdf, 0x95, 0x9c, 0x99,
+	0x47, 0x4f, 0xb9, 0x96, 0x8b, 0x89, 0x2b, 0xd0, 0xfb, 0xe4, 0x21, 0xd4, 0xb0, 0x66, 0xb5, 0x14,
+	0x16, 0x2b, 0x19, 0x0c, 0xc4, 0x33, 0xd4, 0xa9, 0x68, 0x56, 0x3a, 0x05, 0xba, 0xe1, 0x73, 0xd2,
+	0xd8, 0x81, 0xd4, 0x76, 0xb0, 0xe0, 0xcd, 0xda, 0x0e, 0xa4, 0x1a, 0x37, 0xdb, 0xd6, 0x2c, 0xa9,
+	0x1d, 0x61, 0x87, 0x70, 0x73, 0x4d, 0x5c, 0xe9, 0x07, 0xad, 0x13, 0x2f, 0xfc, 0xe6, 0x35, 0x7f,
+	0xe8, 0xd4, 0x6d, 0x31, 0x05, 0x59, 0xb1, 0x08, 0xf0, 0x57, 0x0f, 0xdd, 0xda, 0xe2, 0x19, 0x7c,
+	0x7c, 0x65, 0xed, 0x7f, 0x3b, 0xab, 0x77, 0xf7, 0x9a, 0x1a, 0x0d, 0xc6, 0x1f, 0x7f, 0xfe, 0xfe,
+	0xdc, 0x1a, 0xe2, 0x63, 0x7b, 0x45, 0xd5, 0x68, 0xed, 0xe9, 0x61, 0x7d, 0x8c, 0x1f, 0xd6, 0xf6,
+	0xb8, 0x70, 0xf8, 0xe2, 0xc9, 0xd9, 0xf7, 0x65, 0xdf, 0xfb, 0xb1, 0xec, 0x7b, 0xbf, 0x96, 0x7d,
+	0xef, 0xf5, 0xa3, 0xeb, 0x9f, 0xcc, 0xf6, 0x7b, 0x9f, 0xed, 0xda, 0x63, 0x19, 0xff, 0x09, 0x00,
+	0x00, 0xff, 0xff, 0x3a, 0xa3, 0x06, 0x18, 0x17, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WorkflowHistoryServiceClient is the client API for WorkflowHistoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WorkflowHistoryServiceClient interface {
+	GetWorkflowStatusAt(ctx context.Context, in *GetWorkflowStatusAtRequest, opts ...grpc.CallOption) (*WorkflowStatusSnapshot, error)
+}
+
+type workflowHistoryServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWorkflowHistoryServiceClient(cc *grpc.ClientConn) WorkflowHistoryServiceClient {
+	return &workflowHistoryServiceClient{cc}
+}
+
+func (c *workflowHistoryServiceClient) GetWorkflowStatusAt(ctx context.Context, in *GetWorkflowStatusAtRequest, opts ...grpc.CallOption) (*WorkflowStatusSnapshot, error) {
+	out := new(WorkflowStatusSnapshot)
+	err := c.cc.Invoke(ctx, "/workflowhistory.WorkflowHistoryService/GetWorkflowStatusAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkflowHistoryServiceServer is the server API for WorkflowHistoryService service.
+type WorkflowHistoryServiceServer interface {
+	GetWorkflowStatusAt(context.Context, *GetWorkflowStatusAtRequest) (*WorkflowStatusSnapshot, error)
+}
+
+// UnimplementedWorkflowHistoryServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWorkflowHistoryServiceServer struct {
+}
+
+func (*UnimplementedWorkflowHistoryServiceServer) GetWorkflowStatusAt(ctx context.Context, req *GetWorkflowStatusAtRequest) (*WorkflowStatusSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowStatusAt not implemented")
+}
+
+func RegisterWorkflowHistoryServiceServer(s *grpc.Server, srv WorkflowHistoryServiceServer) {
+	s.RegisterService(&_WorkflowHistoryService_serviceDesc, srv)
+}
+
+func _WorkflowHistoryService_GetWorkflowStatusAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkflowStatusAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowHistoryServiceServer).GetWorkflowStatusAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflowhistory.WorkflowHistoryService/GetWorkflowStatusAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowHistoryServiceServer).GetWorkflowStatusAt(ctx, req.(*GetWorkflowStatusAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WorkflowHistoryService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "workflowhistory.WorkflowHistoryService",
+	HandlerType: (*WorkflowHistoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWorkflowStatusAt",
+			Handler:    _WorkflowHistoryService_GetWorkflowStatusAt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/apiclient/workflowhistory/workflowhistory.proto",
+}
+
+func (m *GetWorkflowStatusAtRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetWorkflowStatusAtRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetWorkflowStatusAtRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Time) > 0 {
+		i -= len(m.Time)
+		copy(dAtA[i:], m.Time)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Time)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Uid) > 0 {
+		i -= len(m.Uid)
+		copy(dAtA[i:], m.Uid)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Uid)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WorkflowStatusSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowStatusSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowStatusSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Nodes) > 0 {
+		for k := range m.Nodes {
+			v := m.Nodes[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintWorkflowhistory(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintWorkflowhistory(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Phase) > 0 {
+		i -= len(m.Phase)
+		copy(dAtA[i:], m.Phase)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Phase)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Time != nil {
+		{
+			size, err := m.Time.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWorkflowhistory(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Uid) > 0 {
+		i -= len(m.Uid)
+		copy(dAtA[i:], m.Uid)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Uid)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintWorkflowhistory(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWorkflowhistory(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWorkflowhistory(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetWorkflowStatusAtRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	l = len(m.Time)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WorkflowStatusSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	if m.Time != nil {
+		l = m.Time.Size()
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	l = len(m.Phase)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovWorkflowhistory(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for k, v := range m.Nodes {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovWorkflowhistory(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovWorkflowhistory(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovWorkflowhistory(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovWorkflowhistory(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWorkflowhistory(x uint64) (n int) {
+	return sovWorkflowhistory(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetWorkflowStatusAtRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowhistory
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetWorkflowStatusAtRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetWorkflowStatusAtRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Time = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowhistory(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkflowStatusSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowhistory
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkflowStatusSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkflowStatusSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Time == nil {
+				m.Time = &v1.Time{}
+			}
+			if err := m.Time.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Phase", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Phase = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Nodes == nil {
+				m.Nodes = make(map[string]*v1alpha1.NodeStatus)
+			}
+			var mapkey string
+			var mapvalue *v1alpha1.NodeStatus
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWorkflowhistory
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWorkflowhistory
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthWorkflowhistory
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthWorkflowhistory
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWorkflowhistory
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthWorkflowhistory
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthWorkflowhistory
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &v1alpha1.NodeStatus{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipWorkflowhistory(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthWorkflowhistory
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Nodes[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowhistory(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowhistory
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipWorkflowhistory(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowWorkflowhistory
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWorkflowhistory
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthWorkflowhistory
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupWorkflowhistory
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthWorkflowhistory
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthWorkflowhistory        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowWorkflowhistory          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupWorkflowhistory = fmt.Errorf("proto: unexpected end of group")
+)
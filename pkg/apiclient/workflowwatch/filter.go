@@ -0,0 +1,61 @@
+package workflowwatch
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// Filter returns whether an event is received
+type Filter func(event *Event) bool
+
+// Types filters events by their type
+func Types(types ...EventType) Filter {
+	return func(event *Event) bool {
+		for _, t := range types {
+			if event.Type == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Names filters events by the name of their workflow
+func Names(names ...string) Filter {
+	return func(event *Event) bool {
+		for _, n := range names {
+			if event.Workflow.Name == n {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Phases filters events by the phase of their workflow
+func Phases(phases ...wfv1.WorkflowPhase) Filter {
+	return func(event *Event) bool {
+		for _, p := range phases {
+			if event.Workflow.Status.Phase == p {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Completed filters events by whether their workflow has completed
+func Completed() Filter {
+	return func(event *Event) bool {
+		return event.Workflow.Status.Fulfilled()
+	}
+}
+
+// Labels filters events by the labels of their workflow, e.g. to select by labels that the server cannot, such as those
+// that change while the workflow is watched
+func Labels(selector labels.Selector) Filter {
+	return func(event *Event) bool {
+		return selector.Matches(labels.Set(event.Workflow.Labels))
+	}
+}
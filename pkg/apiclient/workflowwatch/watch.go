@@ -0,0 +1,203 @@
+// Package workflowwatch watches workflows through the workflow service as a stream of typed events. The watch is
+// re-established when it ends, e.g. because the server closed it or restarted, and resumes from the resource version of
+// the last event, so that consumers neither miss events nor have to reimplement this themselves.
+package workflowwatch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+type EventType string
+
+const (
+	EventTypeAdded    EventType = "ADDED"
+	EventTypeModified EventType = "MODIFIED"
+	EventTypeDeleted  EventType = "DELETED"
+)
+
+// Event is a change of a workflow
+type Event struct {
+	Type     EventType
+	Workflow *wfv1.Workflow
+}
+
+// Options configures a watch
+type Options struct {
+	// Namespace is the namespace of the workflows, or all namespaces if it is empty
+	Namespace string
+	// ListOptions selects the workflows by their labels and fields. Its resource version is the one to start the watch
+	// from, which sends an added event for every existing workflow first if it is empty.
+	ListOptions metav1.ListOptions
+	// Fields are the fields of the workflows to return, e.g. "metadata.name,status.phase", or all of them if it is
+	// empty. It must include "metadata.resourceVersion" for the watch to resume where it ended.
+	Fields string
+	// Filters are the filters that events must all match to be received
+	Filters []Filter
+	// Backoff is the backoff between the attempts to re-establish the watch, which starts at 1s and is capped at 30s if
+	// it is not set
+	Backoff *wait.Backoff
+}
+
+// Stream is a watch of workflows that is re-established whenever it ends
+type Stream struct {
+	ctx     context.Context
+	client  workflowpkg.WorkflowServiceClient
+	opts    Options
+	stream  workflowpkg.WorkflowService_WatchWorkflowsClient
+	backoff wait.Backoff
+	// resourceVersion is the resource version of the last event, which the watch is resumed from
+	resourceVersion string
+	// seen is the resource version of each workflow that an event was received for, so that the events of the same
+	// versions are not received again when the watch is restarted after its resource version expired
+	seen map[string]string
+}
+
+// Watch returns a stream of the events of the workflows. The stream ends when the context is done.
+func Watch(ctx context.Context, client workflowpkg.WorkflowServiceClient, opts Options) *Stream {
+	return &Stream{
+		ctx:             ctx,
+		client:          client,
+		opts:            opts,
+		backoff:         newBackoff(opts.Backoff),
+		resourceVersion: opts.ListOptions.ResourceVersion,
+		seen:            map[string]string{},
+	}
+}
+
+func newBackoff(b *wait.Backoff) wait.Backoff {
+	if b != nil {
+		return *b
+	}
+	return wait.Backoff{Duration: time.Second, Factor: 2, Jitter: 0.1, Steps: 10, Cap: 30 * time.Second}
+}
+
+// Recv returns the next event that matches the filters, re-establishing the watch as many times as it takes. It
+// returns the error of the context once it is done, and any error that re-establishing the watch cannot recover from.
+func (s *Stream) Recv() (*Event, error) {
+	for {
+		if err := s.ctx.Err(); err != nil {
+			return nil, err
+		}
+		if s.stream == nil {
+			stream, err := s.client.WatchWorkflows(s.ctx, s.request())
+			if err != nil {
+				if err := s.retry(err); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			s.stream = stream
+		}
+		e, err := s.stream.Recv()
+		if err != nil {
+			s.stream = nil
+			if err := s.retry(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if e == nil || e.Object == nil {
+			continue
+		}
+		s.backoff = newBackoff(s.opts.Backoff)
+		event := &Event{Type: EventType(e.Type), Workflow: e.Object}
+		if !s.record(event) {
+			continue
+		}
+		if s.matches(event) {
+			return event, nil
+		}
+	}
+}
+
+func (s *Stream) request() *workflowpkg.WatchWorkflowsRequest {
+	opts := s.opts.ListOptions
+	opts.ResourceVersion = s.resourceVersion
+	return &workflowpkg.WatchWorkflowsRequest{Namespace: s.opts.Namespace, ListOptions: &opts, Fields: s.opts.Fields}
+}
+
+// record records the resource version of the event, and returns whether it is a new version of its workflow
+func (s *Stream) record(event *Event) bool {
+	wf := event.Workflow
+	if wf.ResourceVersion == "" {
+		return true
+	}
+	s.resourceVersion = wf.ResourceVersion
+	key := wf.Namespace + "/" + wf.Name
+	if event.Type == EventTypeDeleted {
+		delete(s.seen, key)
+		return true
+	}
+	if s.seen[key] == wf.ResourceVersion {
+		return false
+	}
+	s.seen[key] = wf.ResourceVersion
+	return true
+}
+
+func (s *Stream) matches(event *Event) bool {
+	for _, f := range s.opts.Filters {
+		if !f(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// retry waits to re-establish the watch after the error, or returns the error if it cannot be recovered from
+func (s *Stream) retry(err error) error {
+	if ctxErr := s.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	switch {
+	case isExpired(err):
+		// the resource version is too old to resume from, so the watch restarts from the current workflows, whose
+		// events are only received if they have changed
+		log.WithError(err).Debug("Restarting workflow watch")
+		s.resourceVersion = ""
+	case isTransient(err):
+		log.WithError(err).Debug("Re-establishing workflow watch")
+	default:
+		return err
+	}
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case <-time.After(s.backoff.Step()):
+		return nil
+	}
+}
+
+// isTransient returns whether the watch ended, or could not be established, for a reason that re-establishing it may
+// resolve
+func isTransient(err error) bool {
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	switch status.Code(err) {
+	// the server closes the watch with ResourceExhausted when the watch of the Kubernetes API ends
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// isExpired returns whether the resource version of the watch has expired, which is a 410 Gone error of the Kubernetes
+// API that the server does not have a code for
+func isExpired(err error) bool {
+	msg := status.Convert(err).Message()
+	return strings.Contains(msg, "too old resource version") || strings.Contains(msg, "resourceVersion for the provided watch is too old")
+}
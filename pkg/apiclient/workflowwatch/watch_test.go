@@ -0,0 +1,133 @@
+package workflowwatch
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// fakeClient returns a stream of the events of each watch in turn, each of which ends with its error, or io.EOF
+type fakeClient struct {
+	workflowpkg.WorkflowServiceClient
+	watches  []fakeWatch
+	requests []*workflowpkg.WatchWorkflowsRequest
+}
+
+type fakeWatch struct {
+	events []*workflowpkg.WorkflowWatchEvent
+	err    error
+}
+
+func (c *fakeClient) WatchWorkflows(ctx context.Context, req *workflowpkg.WatchWorkflowsRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowsClient, error) {
+	c.requests = append(c.requests, req)
+	if len(c.watches) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "no more watches")
+	}
+	w := c.watches[0]
+	c.watches = c.watches[1:]
+	return &fakeStream{fakeWatch: w}, nil
+}
+
+type fakeStream struct {
+	grpc.ClientStream
+	fakeWatch
+}
+
+func (s *fakeStream) Recv() (*workflowpkg.WorkflowWatchEvent, error) {
+	if len(s.events) == 0 {
+		if s.err == nil {
+			return nil, io.EOF
+		}
+		return nil, s.err
+	}
+	e := s.events[0]
+	s.events = s.events[1:]
+	return e, nil
+}
+
+func event(eventType EventType, name, resourceVersion string, phase wfv1.WorkflowPhase) *workflowpkg.WorkflowWatchEvent {
+	return &workflowpkg.WorkflowWatchEvent{
+		Type: string(eventType),
+		Object: &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argo", ResourceVersion: resourceVersion},
+			Status:     wfv1.WorkflowStatus{Phase: phase},
+		},
+	}
+}
+
+var noBackoff = &wait.Backoff{Duration: time.Millisecond}
+
+func recvAll(t *testing.T, s *Stream) []string {
+	var received []string
+	for {
+		e, err := s.Recv()
+		if err != nil {
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			return received
+		}
+		received = append(received, string(e.Type)+" "+e.Workflow.Name+" "+e.Workflow.ResourceVersion)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("Resume", func(t *testing.T) {
+		client := &fakeClient{watches: []fakeWatch{
+			{events: []*workflowpkg.WorkflowWatchEvent{event(EventTypeAdded, "my-wf", "1", wfv1.WorkflowRunning)}, err: io.EOF},
+			{events: []*workflowpkg.WorkflowWatchEvent{event(EventTypeModified, "my-wf", "2", wfv1.WorkflowSucceeded)}, err: status.Error(codes.ResourceExhausted, "EOF")},
+		}}
+		s := Watch(context.Background(), client, Options{Namespace: "argo", ListOptions: metav1.ListOptions{LabelSelector: "a=b"}, Backoff: noBackoff})
+
+		assert.Equal(t, []string{"ADDED my-wf 1", "MODIFIED my-wf 2"}, recvAll(t, s))
+		require.Len(t, client.requests, 3)
+		assert.Equal(t, "", client.requests[0].ListOptions.ResourceVersion)
+		assert.Equal(t, "1", client.requests[1].ListOptions.ResourceVersion)
+		assert.Equal(t, "2", client.requests[2].ListOptions.ResourceVersion)
+		assert.Equal(t, "a=b", client.requests[2].ListOptions.LabelSelector)
+		assert.Equal(t, "argo", client.requests[2].Namespace)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		client := &fakeClient{watches: []fakeWatch{
+			{events: []*workflowpkg.WorkflowWatchEvent{event(EventTypeAdded, "my-wf", "1", wfv1.WorkflowRunning)}, err: status.Error(codes.InvalidArgument, "too old resource version: 1 (5)")},
+			{events: []*workflowpkg.WorkflowWatchEvent{event(EventTypeAdded, "my-wf", "1", wfv1.WorkflowRunning), event(EventTypeAdded, "other-wf", "5", wfv1.WorkflowRunning)}},
+		}}
+		s := Watch(context.Background(), client, Options{Backoff: noBackoff})
+
+		assert.Equal(t, []string{"ADDED my-wf 1", "ADDED other-wf 5"}, recvAll(t, s))
+		require.Len(t, client.requests, 3)
+		assert.Equal(t, "", client.requests[1].ListOptions.ResourceVersion)
+	})
+	t.Run("Error", func(t *testing.T) {
+		client := &fakeClient{watches: []fakeWatch{{err: status.Error(codes.Unauthenticated, "bad token")}}}
+		_, err := Watch(context.Background(), client, Options{Backoff: noBackoff}).Recv()
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Len(t, client.requests, 1)
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := Watch(ctx, &fakeClient{}, Options{}).Recv()
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("Filters", func(t *testing.T) {
+		client := &fakeClient{watches: []fakeWatch{{events: []*workflowpkg.WorkflowWatchEvent{
+			event(EventTypeAdded, "my-wf", "1", wfv1.WorkflowRunning),
+			event(EventTypeAdded, "other-wf", "2", wfv1.WorkflowSucceeded),
+			event(EventTypeModified, "my-wf", "3", wfv1.WorkflowFailed),
+		}}}}
+		s := Watch(context.Background(), client, Options{Filters: []Filter{Names("my-wf"), Completed()}, Backoff: noBackoff})
+
+		assert.Equal(t, []string{"MODIFIED my-wf 3"}, recvAll(t, s))
+	})
+}
@@ -27,6 +27,16 @@ type ArtifactRepository struct {
 	GCS *GCSArtifactRepository `json:"gcs,omitempty" protobuf:"bytes,6,opt,name=gcs"`
 	// Azure stores artifact in an Azure Storage account
 	Azure *AzureArtifactRepository `json:"azure,omitempty" protobuf:"bytes,7,opt,name=azure"`
+	// Retention is the default retention of the output artifacts of Workflows using this repository
+	Retention *ArtifactRetention `json:"retention,omitempty" protobuf:"bytes,8,opt,name=retention"`
+	// SFTP stores artifacts on an SFTP server
+	SFTP *SFTPArtifactRepository `json:"sftp,omitempty" protobuf:"bytes,9,opt,name=sftp"`
+	// Filesystem stores artifacts on a mounted volume, such as an NFS share or a persistent volume claim
+	Filesystem *FilesystemArtifactRepository `json:"filesystem,omitempty" protobuf:"bytes,10,opt,name=filesystem"`
+	// B2 stores artifacts in a Backblaze B2 bucket
+	B2 *B2ArtifactRepository `json:"b2,omitempty" protobuf:"bytes,11,opt,name=b2"`
+	// IBMCOS stores artifacts in an IBM Cloud Object Storage bucket
+	IBMCOS *IBMCOSArtifactRepository `json:"ibmcos,omitempty" protobuf:"bytes,12,opt,name=ibmcos"`
 }
 
 func (a *ArtifactRepository) IsArchiveLogs() bool {
@@ -44,14 +54,22 @@ func (a *ArtifactRepository) Get() ArtifactRepositoryType {
 		return a.Artifactory
 	} else if a.Azure != nil {
 		return a.Azure
+	} else if a.B2 != nil {
+		return a.B2
+	} else if a.Filesystem != nil {
+		return a.Filesystem
 	} else if a.GCS != nil {
 		return a.GCS
 	} else if a.HDFS != nil {
 		return a.HDFS
+	} else if a.IBMCOS != nil {
+		return a.IBMCOS
 	} else if a.OSS != nil {
 		return a.OSS
 	} else if a.S3 != nil {
 		return a.S3
+	} else if a.SFTP != nil {
+		return a.SFTP
 	}
 	return nil
 }
@@ -178,4 +196,68 @@ func (r *HDFSArtifactRepository) IntoArtifactLocation(l *ArtifactLocation) {
 	l.HDFS = &HDFSArtifact{HDFSConfig: r.HDFSConfig, Path: p, Force: r.Force}
 }
 
+// SFTPArtifactRepository defines the controller configuration for an SFTP artifact repository
+type SFTPArtifactRepository struct {
+	SFTPConfig `json:",inline" protobuf:"bytes,1,opt,name=sFTPConfig"`
+
+	// PathFormat defines the format of the path to store artifacts at. Can reference workflow variables
+	PathFormat string `json:"pathFormat,omitempty" protobuf:"bytes,2,opt,name=pathFormat"`
+}
+
+func (r *SFTPArtifactRepository) IntoArtifactLocation(l *ArtifactLocation) {
+	p := r.PathFormat
+	if p == "" {
+		p = DefaultArchivePattern
+	}
+	l.SFTP = &SFTPArtifact{SFTPConfig: r.SFTPConfig, Path: p}
+}
+
+// FilesystemArtifactRepository defines the controller configuration for an artifact repository on a mounted volume
+type FilesystemArtifactRepository struct {
+	FilesystemConfig `json:",inline" protobuf:"bytes,1,opt,name=filesystemConfig"`
+
+	// PathFormat defines the format of the path to store artifacts at, relative to the root of the volume. Can reference workflow variables
+	PathFormat string `json:"pathFormat,omitempty" protobuf:"bytes,2,opt,name=pathFormat"`
+}
+
+func (r *FilesystemArtifactRepository) IntoArtifactLocation(l *ArtifactLocation) {
+	p := r.PathFormat
+	if p == "" {
+		p = DefaultArchivePattern
+	}
+	l.Filesystem = &FilesystemArtifact{FilesystemConfig: r.FilesystemConfig, Path: p}
+}
+
+// B2ArtifactRepository defines the controller configuration for a Backblaze B2 artifact repository
+type B2ArtifactRepository struct {
+	B2Config `json:",inline" protobuf:"bytes,1,opt,name=b2Config"`
+
+	// KeyFormat defines the format of how to store keys and can reference workflow variables.
+	KeyFormat string `json:"keyFormat,omitempty" protobuf:"bytes,2,opt,name=keyFormat"`
+}
+
+func (r *B2ArtifactRepository) IntoArtifactLocation(l *ArtifactLocation) {
+	k := r.KeyFormat
+	if k == "" {
+		k = DefaultArchivePattern
+	}
+	l.B2 = &B2Artifact{B2Config: r.B2Config, Key: k}
+}
+
+// IBMCOSArtifactRepository defines the controller configuration for an IBM Cloud Object Storage artifact repository
+type IBMCOSArtifactRepository struct {
+	IBMCOSConfig `json:",inline" protobuf:"bytes,1,opt,name=ibmCOSConfig"`
+
+	// KeyFormat defines the format of how to store keys and can reference workflow variables.
+	KeyFormat string `json:"keyFormat,omitempty" protobuf:"bytes,2,opt,name=keyFormat"`
+}
+
+func (r *IBMCOSArtifactRepository) IntoArtifactLocation(l *ArtifactLocation) {
+	k := r.KeyFormat
+	if k == "" {
+		k = DefaultArchivePattern
+	}
+	l.IBMCOS = &IBMCOSArtifact{IBMCOSConfig: r.IBMCOSConfig, Key: k}
+}
+
 // MetricsConfig defines a config for a metrics server
@@ -6,10 +6,15 @@ package v1alpha1
 type Link struct {
 	// The name of the link, E.g. "Workflow Logs" or "Pod Logs"
 	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
-	// "workflow", "pod", "pod-logs", "event-source-logs", "sensor-logs", "workflow-list" or "chat"
+	// "workflow", "pod", "node", "artifact", "pod-logs", "event-source-logs", "sensor-logs", "workflow-list", "nav" or "chat"
 	Scope string `json:"scope" protobuf:"bytes,2,opt,name=scope"`
-	// The URL. Can contain "${metadata.namespace}", "${metadata.name}", "${status.startedAt}", "${status.finishedAt}" or any other element in workflow yaml, e.g. "${workflow.metadata.annotations.userDefinedKey}"
+	// The URL. Can contain "${metadata.namespace}", "${metadata.name}", "${status.startedAt}", "${status.finishedAt}" or any other element in workflow yaml, e.g. "${workflow.metadata.annotations.userDefinedKey}".
+	// Links of the "node" and "artifact" scopes can also contain the fields of the node, e.g. "${node.podName}", and those of the "artifact" scope the fields of the artifact, e.g. "${artifact.key}"
 	URL string `json:"url" protobuf:"bytes,3,opt,name=url"`
+	// Namespaces are the namespaces that the link is shown for, or all namespaces if there are none
+	Namespaces []string `json:"namespaces,omitempty" protobuf:"bytes,4,rep,name=namespaces"`
+	// Icon is the Font Awesome class of the icon of a link of the "nav" scope, e.g. "fa fa-book"
+	Icon string `json:"icon,omitempty" protobuf:"bytes,5,opt,name=icon"`
 }
 
 // Column is a custom column that will be exposed in the Workflow List View.
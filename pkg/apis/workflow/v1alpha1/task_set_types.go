@@ -35,6 +35,13 @@ type NodeResult struct {
 	Message  string    `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
 	Outputs  *Outputs  `json:"outputs,omitempty" protobuf:"bytes,3,opt,name=outputs"`
 	Progress Progress  `json:"progress,omitempty" protobuf:"bytes,4,opt,name=progress,casttype=Progress"`
+	// ArtifactProgress is the progress of saving the output artifacts
+	ArtifactProgress *ArtifactProgress `json:"artifactProgress,omitempty" protobuf:"bytes,5,opt,name=artifactProgress"`
+	// Provenance is the runtime context that the node ran in, as recorded by the executor
+	Provenance *NodeProvenance `json:"provenance,omitempty" protobuf:"bytes,6,opt,name=provenance"`
+	// TruncatedOutputs are the outputs that were truncated because they were larger than the limits of the template,
+	// e.g. `result`, `parameters.my-param`, or `logs.main`
+	TruncatedOutputs []string `json:"truncatedOutputs,omitempty" protobuf:"bytes,7,rep,name=truncatedOutputs"`
 }
 
 func (in NodeResult) Fulfilled() bool {
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterWorkflowDefaults are default values for the Workflows in every namespace
+// +genclient
+// +genclient:noStatus
+// +genclient:nonNamespaced
+// +resourceName=clusterworkflowdefaults
+// +kubebuilder:resource:scope=Cluster,shortName=cwfdefaults,singular=clusterworkflowdefault
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterWorkflowDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              WorkflowDefaultsSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// ClusterWorkflowDefaultsList is list of ClusterWorkflowDefaults resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterWorkflowDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ClusterWorkflowDefaults `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// NamespaceWorkflowDefaults are default values for the Workflows in its namespace, which take precedence over
+// ClusterWorkflowDefaults
+// +genclient
+// +genclient:noStatus
+// +resourceName=namespaceworkflowdefaults
+// +kubebuilder:resource:shortName=wfdefaults,singular=namespaceworkflowdefault
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NamespaceWorkflowDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              WorkflowDefaultsSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// NamespaceWorkflowDefaultsList is list of NamespaceWorkflowDefaults resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NamespaceWorkflowDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []NamespaceWorkflowDefaults `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// WorkflowDefaultsSpec is the default values for Workflows. They are merged into each Workflow using a strategic
+// merge, with the values of the Workflow taking precedence.
+type WorkflowDefaultsSpec struct {
+	// Priority orders the defaults of the same scope. The defaults with a higher priority take precedence over those
+	// with a lower one. Of those with the same priority, the first by name takes precedence.
+	Priority int32 `json:"priority,omitempty" protobuf:"varint,1,opt,name=priority"`
+	// Labels are the default labels of the Workflows
+	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,2,rep,name=labels"`
+	// Annotations are the default annotations of the Workflows
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,3,rep,name=annotations"`
+	// WorkflowSpec is the default spec of the Workflows
+	WorkflowSpec WorkflowSpec `json:"workflowSpec,omitempty" protobuf:"bytes,4,opt,name=workflowSpec"`
+}
+
+// ToWorkflow returns the defaults as a Workflow, as they are set in the workflow controller ConfigMap
+func (s *WorkflowDefaultsSpec) ToWorkflow() *Workflow {
+	return &Workflow{
+		ObjectMeta: metav1.ObjectMeta{Labels: s.Labels, Annotations: s.Annotations},
+		Spec:       *s.WorkflowSpec.DeepCopy(),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"maps"
 	"net/url"
 	"os"
 	"path"
@@ -13,9 +14,11 @@ import (
 	"runtime"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -27,6 +30,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	"github.com/argoproj/argo-workflows/v3/util/suggest"
 )
 
 // TemplateType is the type of a template
@@ -44,6 +48,8 @@ const (
 	TemplateTypeData         TemplateType = "Data"
 	TemplateTypeHTTP         TemplateType = "HTTP"
 	TemplateTypePlugin       TemplateType = "Plugin"
+	TemplateTypeJob          TemplateType = "Job"
+	TemplateTypeGang         TemplateType = "Gang"
 	TemplateTypeUnknown      TemplateType = "Unknown"
 )
 
@@ -95,6 +101,12 @@ const (
 	ArtifactGCOnWorkflowDeletion   ArtifactGCStrategy = "OnWorkflowDeletion"
 	ArtifactGCNever                ArtifactGCStrategy = "Never"
 	ArtifactGCStrategyUndefined    ArtifactGCStrategy = ""
+	// ArtifactGCOnRetentionExpiry is used by the controller for artifacts deleted by their retention, it cannot be
+	// specified as a strategy
+	ArtifactGCOnRetentionExpiry ArtifactGCStrategy = "OnRetentionExpiry"
+	// ArtifactGCOnRequest is used by the controller for artifacts deleted on request, e.g. by `argo artifact rm`, it
+	// cannot be specified as a strategy
+	ArtifactGCOnRequest ArtifactGCStrategy = "OnRequest"
 )
 
 var AnyArtifactGCStrategy = map[ArtifactGCStrategy]bool{
@@ -323,6 +335,14 @@ type WorkflowSpec struct {
 	// +patchMergeKey=key
 	Tolerations []apiv1.Toleration `json:"tolerations,omitempty" patchStrategy:"merge" patchMergeKey:"key" protobuf:"bytes,12,opt,name=tolerations"`
 
+	// MergeStrategy is how the nodeSelector, affinity, and tolerations of the workflow are combined with those of its
+	// templates: "Replace" (the default) uses those of the template, if it has them, instead of the workflow's, and
+	// "Merge" combines them, so that defaults of the platform and the settings of templates both apply. A pod then needs
+	// a node that both the workflow and the template select, and tolerates the taints that either tolerates.
+	// +kubebuilder:validation:Enum="";Replace;Merge
+	// +optional
+	MergeStrategy SchedulingMergeStrategy `json:"mergeStrategy,omitempty" protobuf:"bytes,49,opt,name=mergeStrategy,casttype=SchedulingMergeStrategy"`
+
 	// ImagePullSecrets is a list of references to secrets in the same namespace to use for pulling any images
 	// in pods that reference this ServiceAccount. ImagePullSecrets are distinct from Secrets because Secrets
 	// can be mounted in the pod, but ImagePullSecrets are only accessed by the kubelet.
@@ -362,6 +382,20 @@ type WorkflowSpec struct {
 	// terminate a Running workflow
 	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty" protobuf:"bytes,19,opt,name=activeDeadlineSeconds"`
 
+	// PendingTimeout is how long a pod of the workflow may be pending (e.g. unschedulable, or not created because of a
+	// resource quota) before the controller fails the workflow, as if its active deadline was exceeded.
+	// Pods of exit handlers are not subject to it.
+	PendingTimeout *metav1.Duration `json:"pendingTimeout,omitempty" protobuf:"bytes,44,opt,name=pendingTimeout"`
+
+	// ResourceBudget limits the resources duration of the workflow. When the resources duration of its completed pods
+	// exceeds the budget, the controller terminates or suspends the workflow.
+	ResourceBudget *ResourceBudget `json:"resourceBudget,omitempty" protobuf:"bytes,46,opt,name=resourceBudget"`
+
+	// SchedulingWindow restricts when the pods of the workflow start to the windows of its schedules, e.g. to off-peak
+	// hours. Outside of them, a workflow that has not started yet is pending, and the pods of a running workflow are
+	// not created until the next window starts. Pods that have already started keep running.
+	SchedulingWindow *SchedulingWindow `json:"schedulingWindow,omitempty" protobuf:"bytes,50,opt,name=schedulingWindow"`
+
 	// Priority is used if controller is configured to process limited number of workflows in parallel. Workflows with higher priority are processed first.
 	Priority *int32 `json:"priority,omitempty" protobuf:"bytes,20,opt,name=priority"`
 
@@ -433,6 +467,112 @@ type WorkflowSpec struct {
 	// ArtifactGC describes the strategy to use when deleting artifacts from completed or deleted workflows (applies to all output Artifacts
 	// unless Artifact.ArtifactGC is specified, which overrides this)
 	ArtifactGC *WorkflowLevelArtifactGC `json:"artifactGC,omitempty" protobuf:"bytes,43,opt,name=artifactGC"`
+
+	// Owner is who to contact about the workflow. It is added to the events of the workflow and its nodes, so that
+	// notifications can be routed to the owner, is available to exit handlers as workflow.owner.*, and archived
+	// workflows can be searched by it.
+	Owner *WorkflowOwner `json:"owner,omitempty" protobuf:"bytes,45,opt,name=owner"`
+
+	// ResultDelivery delivers the outputs of the workflow to another system once it has completed, for systems that
+	// cannot poll the Argo API
+	ResultDelivery *ResultDelivery `json:"resultDelivery,omitempty" protobuf:"bytes,47,opt,name=resultDelivery"`
+
+	// TaskSelector is a label selector of the DAG tasks to execute, e.g. "canary=true". The tasks it selects and the
+	// tasks they depend on are executed, and the other tasks are skipped, so that a new branch of a large pipeline can
+	// be tried without running the whole pipeline. A DAG with no tasks that the selector selects executes all of its
+	// tasks.
+	TaskSelector string `json:"taskSelector,omitempty" protobuf:"bytes,48,opt,name=taskSelector"`
+}
+
+// SchedulingMergeStrategy is how the scheduling constraints of a workflow are combined with those of its templates
+type SchedulingMergeStrategy string
+
+const (
+	SchedulingMergeStrategyReplace SchedulingMergeStrategy = "Replace"
+	SchedulingMergeStrategyMerge   SchedulingMergeStrategy = "Merge"
+)
+
+// ResultDelivery is how the outputs of a workflow are delivered once it has completed
+type ResultDelivery struct {
+	// HTTP POSTs the outputs of the workflow as JSON to a URL
+	HTTP *HTTPResultDelivery `json:"http,omitempty" protobuf:"bytes,1,opt,name=http"`
+}
+
+// HTTPResultDelivery POSTs the phase and outputs of a completed workflow as JSON to a URL
+type HTTPResultDelivery struct {
+	// URL is the URL to POST to
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+
+	// Headers are an optional list of headers to send with the request
+	Headers []Header `json:"headers,omitempty" protobuf:"bytes,2,rep,name=headers"`
+
+	// Auth is how the controller authenticates to the URL, with secrets in the namespace of the workflow. The controller
+	// reads them with its own service account, not the service account of the workflow.
+	Auth *HTTPAuth `json:"auth,omitempty" protobuf:"bytes,3,opt,name=auth"`
+
+	// Retry is how failed deliveries are retried, 3 times with a delay of 1 second doubling up to 1 minute by default
+	Retry *HTTPArtifactRetry `json:"retry,omitempty" protobuf:"bytes,4,opt,name=retry"`
+}
+
+// WorkflowOwner is the team, and how to reach them, that owns a workflow
+type WorkflowOwner struct {
+	// Team is the name of the team, e.g. "data-platform"
+	Team string `json:"team,omitempty" protobuf:"bytes,1,opt,name=team"`
+	// Email is the email address to contact, e.g. "data-platform@example.com"
+	Email string `json:"email,omitempty" protobuf:"bytes,2,opt,name=email"`
+	// SlackChannel is the Slack channel to notify, e.g. "#data-platform-alerts"
+	SlackChannel string `json:"slackChannel,omitempty" protobuf:"bytes,3,opt,name=slackChannel"`
+}
+
+// SchedulingWindow is the windows that the pods of a workflow may start in, each of which starts at a time of one of
+// the schedules and lasts for the duration
+type SchedulingWindow struct {
+	// Schedules are the cron schedules of the starts of the windows, e.g. "0 22 * * *"
+	Schedules []string `json:"schedules" protobuf:"bytes,1,rep,name=schedules"`
+	// Duration is how long each window is, e.g. "8h"
+	Duration metav1.Duration `json:"duration" protobuf:"bytes,2,opt,name=duration"`
+	// Timezone is the timezone of the schedules, e.g. "America/Los_Angeles", which is the timezone of the controller
+	// if it is not set
+	Timezone string `json:"timezone,omitempty" protobuf:"bytes,3,opt,name=timezone"`
+}
+
+// ResourceBudget is the most resources duration that a workflow may use
+type ResourceBudget struct {
+	// Limits are the most resources duration of each resource, in the same units as the resourcesDuration of the
+	// workflow's status, e.g. {"cpu": 3600} is one hour of one CPU, and {"memory": 3600} is one hour of 100Mi of memory
+	Limits ResourcesDuration `json:"limits" protobuf:"bytes,1,opt,name=limits"`
+	// Action is what the controller does when the budget is exceeded: Terminate (default) fails the workflow as if its
+	// active deadline was exceeded, and Suspend suspends it, so that it can be resumed
+	Action ResourceBudgetAction `json:"action,omitempty" protobuf:"bytes,2,opt,name=action,casttype=ResourceBudgetAction"`
+}
+
+// ResourceBudgetAction is what the controller does when the resource budget of a workflow is exceeded
+type ResourceBudgetAction string
+
+const (
+	ResourceBudgetActionTerminate ResourceBudgetAction = "Terminate"
+	ResourceBudgetActionSuspend   ResourceBudgetAction = "Suspend"
+)
+
+// GetAction returns the action of the budget, which defaults to Terminate
+func (b *ResourceBudget) GetAction() ResourceBudgetAction {
+	if b == nil || b.Action == "" {
+		return ResourceBudgetActionTerminate
+	}
+	return b.Action
+}
+
+// Exceeded returns the first resource, in order of name, whose duration exceeds its limit, if any
+func (b *ResourceBudget) Exceeded(d ResourcesDuration) (apiv1.ResourceName, bool) {
+	if b == nil {
+		return "", false
+	}
+	for _, name := range slices.Sorted(maps.Keys(b.Limits)) {
+		if d[name] > b.Limits[name] {
+			return name, true
+		}
+	}
+	return "", false
 }
 
 type LabelValueFrom struct {
@@ -443,6 +583,10 @@ type WorkflowMetadata struct {
 	Labels      map[string]string         `json:"labels,omitempty" protobuf:"bytes,1,rep,name=labels"`
 	Annotations map[string]string         `json:"annotations,omitempty" protobuf:"bytes,2,rep,name=annotations"`
 	LabelsFrom  map[string]LabelValueFrom `json:"labelsFrom,omitempty" protobuf:"bytes,3,rep,name=labelsFrom"`
+	// AnnotationsFrom are annotations whose values are computed by expressions, e.g. from the workflow's parameters
+	AnnotationsFrom map[string]LabelValueFrom `json:"annotationsFrom,omitempty" protobuf:"bytes,4,rep,name=annotationsFrom"`
+	// PropagateToPods adds the labels and annotations to the workflow's pods too, e.g. for cost attribution
+	PropagateToPods bool `json:"propagateToPods,omitempty" protobuf:"varint,5,opt,name=propagateToPods"`
 }
 
 func (in *WorkflowMetadata) AsObjectMeta() *metav1.ObjectMeta {
@@ -532,7 +676,9 @@ type ShutdownStrategy string
 const (
 	ShutdownStrategyTerminate ShutdownStrategy = "Terminate"
 	ShutdownStrategyStop      ShutdownStrategy = "Stop"
-	ShutdownStrategyNone      ShutdownStrategy = ""
+	// ShutdownStrategyDrain does not start any more nodes, except onExit handlers, but lets the running ones finish
+	ShutdownStrategyDrain ShutdownStrategy = "Drain"
+	ShutdownStrategyNone  ShutdownStrategy = ""
 )
 
 func (s ShutdownStrategy) Enabled() bool {
@@ -543,13 +689,25 @@ func (s ShutdownStrategy) ShouldExecute(isOnExitPod bool) bool {
 	switch s {
 	case ShutdownStrategyTerminate:
 		return false
-	case ShutdownStrategyStop:
+	case ShutdownStrategyStop, ShutdownStrategyDrain:
 		return isOnExitPod
 	default:
 		return true
 	}
 }
 
+// ShouldTerminate returns whether a node that is already running should be terminated
+func (s ShutdownStrategy) ShouldTerminate(isOnExitPod bool) bool {
+	switch s {
+	case ShutdownStrategyTerminate:
+		return true
+	case ShutdownStrategyStop:
+		return !isOnExitPod
+	default:
+		return false
+	}
+}
+
 // swagger:ignore
 type ParallelSteps struct {
 	// Note: the `json:"steps"` part exists to workaround kubebuilder limitations.
@@ -582,7 +740,8 @@ func (p *ParallelSteps) UnmarshalJSON(value []byte) error {
 	for _, step := range candidate {
 		for key := range step {
 			if _, ok := availableFields[key]; !ok {
-				return fmt.Errorf(`json: unknown field "%s"`, key)
+				suggestion := suggest.Closest(key, slices.Sorted(maps.Keys(availableFields)))
+				return argoerrs.NewFieldErrorf("", key, suggestion, `json: unknown field "%s"`, key)
 			}
 		}
 	}
@@ -667,6 +826,17 @@ type Template struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Plugin *Plugin `json:"plugin,omitempty" protobuf:"bytes,43,opt,name=plugin"`
 
+	// JobTemplate creates a Kubernetes Job, e.g. to use its completions, parallelism and indexed completion mode, and
+	// completes once the Job does. The Job is owned by the workflow, and is named after it unless its metadata names it.
+	// Note: the schema of a Job template would make the CRDs too large, so we need
+	// "x-kubernetes-preserve-unknown-fields: true" in the validation schema, and validate it when validating the workflow.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	JobTemplate *batchv1.JobTemplateSpec `json:"jobTemplate,omitempty" protobuf:"bytes,49,opt,name=jobTemplate"`
+
+	// Gang runs a gang of pods as one node, e.g. for the workers of a distributed MPI, Ray or PyTorch step
+	Gang *GangTemplate `json:"gang,omitempty" protobuf:"bytes,50,opt,name=gang"`
+
 	// Volumes is a list of volumes that can be mounted by containers in a template.
 	// +patchStrategy=merge
 	// +patchMergeKey=name
@@ -759,6 +929,134 @@ type Template struct {
 
 	// Annotations is a list of annotations to add to the template at runtime
 	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,44,opt,name=annotations"`
+
+	// EnvSets are sets of environment variables merged into the main containers of container, script, and container set
+	// templates. Later sets take precedence over earlier ones, and the containers' own `env` and `envFrom` take
+	// precedence over all sets.
+	EnvSets []EnvSet `json:"envSets,omitempty" protobuf:"bytes,45,rep,name=envSets"`
+
+	// Liveness is a probe of the main container of a container or script template that is interpreted by the
+	// controller: if the probe fails for longer than its failure timeout, the node fails, so that a process that hangs
+	// rather than exits is retried according to the retry strategy
+	Liveness *Liveness `json:"liveness,omitempty" protobuf:"bytes,46,opt,name=liveness"`
+
+	// OutputLimits caps the size of the result, the output parameters, and the captured logs of the template, to protect
+	// the workflow, and so etcd and the workflow archive, from accidentally large outputs
+	OutputLimits *OutputLimits `json:"outputLimits,omitempty" protobuf:"bytes,47,opt,name=outputLimits"`
+
+	// WaitForSidecars is what the main containers wait for of the sidecars before they start. With "readinessProbe",
+	// they start once the readiness probes of the sidecars that have one, which must be httpGet or tcpSocket probes,
+	// have passed.
+	// +kubebuilder:validation:Enum="";readinessProbe
+	// +optional
+	WaitForSidecars WaitForSidecars `json:"waitForSidecars,omitempty" protobuf:"bytes,48,opt,name=waitForSidecars,casttype=WaitForSidecars"`
+
+	// PreemptionPolicy is what happens when the Kubernetes node of the pod of the template is preempted, e.g. when a
+	// spot instance is reclaimed. With "checkpoint", the containers are signalled, the checkpoint artifacts are saved,
+	// and the node is restarted, loading the checkpoint, regardless of the retry strategy.
+	// +kubebuilder:validation:Enum="";checkpoint
+	// +optional
+	PreemptionPolicy PreemptionPolicy `json:"preemptionPolicy,omitempty" protobuf:"bytes,51,opt,name=preemptionPolicy,casttype=PreemptionPolicy"`
+
+	// Checkpoint is the state of the main container that is saved when it is preempted, and loaded when it is
+	// restarted. Required by the "checkpoint" preemption policy.
+	Checkpoint *Checkpoint `json:"checkpoint,omitempty" protobuf:"bytes,52,opt,name=checkpoint"`
+
+	// PrePull pre-pulls the images of a container, script, or container set template onto the nodes shortly before
+	// each run of a cron workflow, if the controller is configured to pre-pull images, so that its pods do not wait for
+	// large images to be pulled
+	PrePull bool `json:"prePull,omitempty" protobuf:"varint,53,opt,name=prePull"`
+}
+
+// PreemptionPolicy is what happens when the Kubernetes node of the pod of a template is preempted
+type PreemptionPolicy string
+
+const (
+	PreemptionPolicyCheckpoint PreemptionPolicy = "checkpoint"
+)
+
+// Checkpoint is the state of the main container of a template that can be preempted
+type Checkpoint struct {
+	// Artifacts are the files or directories of the checkpoint. They are saved to the artifact repository when the pod
+	// is stopped, and loaded at their paths before the main container starts, if they have been saved.
+	Artifacts Artifacts `json:"artifacts" protobuf:"bytes,1,rep,name=artifacts"`
+	// MaxRestarts is how many times the node may be restarted after it is preempted, after which it fails. Defaults to
+	// 10.
+	MaxRestarts *int32 `json:"maxRestarts,omitempty" protobuf:"varint,2,opt,name=maxRestarts"`
+}
+
+// DefaultCheckpointMaxRestarts is how many times a preempted node is restarted if its checkpoint does not say
+const DefaultCheckpointMaxRestarts int32 = 10
+
+// GetMaxRestarts returns how many times a preempted node may be restarted from the checkpoint
+func (c *Checkpoint) GetMaxRestarts() int32 {
+	if c == nil || c.MaxRestarts == nil {
+		return DefaultCheckpointMaxRestarts
+	}
+	return *c.MaxRestarts
+}
+
+// WaitForSidecars is what the main containers of a template wait for of its sidecars before they start
+type WaitForSidecars string
+
+const (
+	WaitForSidecarsReadinessProbe WaitForSidecars = "readinessProbe"
+)
+
+// Liveness is a probe of the main container, and how long it may fail for before the node fails
+type Liveness struct {
+	// Probe is the probe of the main container. It is run by Kubernetes as the readiness probe of the container, so
+	// failing it does not restart the container.
+	Probe apiv1.Probe `json:"probe" protobuf:"bytes,1,opt,name=probe"`
+	// FailureTimeout is how long the probe may fail for before the node fails, e.g. "10m". It is counted from when
+	// the containers of the pod were last ready, or from when the pod started if they have never been ready.
+	FailureTimeout string `json:"failureTimeout" protobuf:"bytes,2,opt,name=failureTimeout"`
+}
+
+// OutputTruncationPolicy is what happens to outputs that are larger than their limits
+type OutputTruncationPolicy string
+
+const (
+	// OutputTruncationKeepLast keeps the end of the output, e.g. the error at the end of a log
+	OutputTruncationKeepLast OutputTruncationPolicy = "KeepLast"
+	// OutputTruncationKeepFirst keeps the start of the output
+	OutputTruncationKeepFirst OutputTruncationPolicy = "KeepFirst"
+	// OutputTruncationFail fails the node rather than truncating the output
+	OutputTruncationFail OutputTruncationPolicy = "Fail"
+)
+
+// OutputLimits are the largest outputs of a template. Outputs that are larger are truncated according to the policy,
+// with a marker that says that they were truncated, and the workflow has the OutputsTruncated condition.
+type OutputLimits struct {
+	// MaxResultBytes is the largest `outputs.result`
+	MaxResultBytes *int64 `json:"maxResultBytes,omitempty" protobuf:"varint,1,opt,name=maxResultBytes"`
+	// MaxParameterBytes is the largest value of each output parameter
+	MaxParameterBytes *int64 `json:"maxParameterBytes,omitempty" protobuf:"varint,2,opt,name=maxParameterBytes"`
+	// MaxLogBytes is the largest captured log of each container, when logs are archived
+	MaxLogBytes *int64 `json:"maxLogBytes,omitempty" protobuf:"varint,3,opt,name=maxLogBytes"`
+	// Policy is what happens to outputs that are larger than their limits: KeepLast (default), KeepFirst, or Fail
+	Policy OutputTruncationPolicy `json:"policy,omitempty" protobuf:"bytes,4,opt,name=policy,casttype=OutputTruncationPolicy"`
+}
+
+// GetPolicy returns the policy of outputs that are larger than their limits
+func (l *OutputLimits) GetPolicy() OutputTruncationPolicy {
+	if l == nil || l.Policy == "" {
+		return OutputTruncationKeepLast
+	}
+	return l.Policy
+}
+
+// EnvSet is a set of environment variables from a ConfigMap or Secret, and values which may be computed by expressions
+type EnvSet struct {
+	// ConfigMapRef adds every key of the ConfigMap as an environment variable
+	ConfigMapRef *apiv1.ConfigMapEnvSource `json:"configMapRef,omitempty" protobuf:"bytes,1,opt,name=configMapRef"`
+	// SecretRef adds every key of the Secret as an environment variable
+	SecretRef *apiv1.SecretEnvSource `json:"secretRef,omitempty" protobuf:"bytes,2,opt,name=secretRef"`
+	// Prefix is prepended to the name of every environment variable from the ConfigMap or Secret
+	Prefix string `json:"prefix,omitempty" protobuf:"bytes,3,opt,name=prefix"`
+	// Values are environment variables by name, e.g. `REGION: "{{=sprig.upper(inputs.parameters.region)}}"`.
+	// Values take precedence over the keys of ConfigMaps and Secrets.
+	Values map[string]string `json:"values,omitempty" protobuf:"bytes,4,rep,name=values"`
 }
 
 // SetType will set the template object based on template type.
@@ -929,7 +1227,8 @@ type ValueFrom struct {
 	// Supplied value to be filled in directly, either through the CLI, API, etc.
 	Supplied *SuppliedValueFrom `json:"supplied,omitempty" protobuf:"bytes,6,opt,name=supplied"`
 
-	// ConfigMapKeyRef is configmap selector for input parameter configuration
+	// ConfigMapKeyRef is configmap selector for input parameter configuration, and for output parameters of suspend
+	// templates, which are read again until the node is resumed
 	ConfigMapKeyRef *apiv1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty" protobuf:"bytes,9,opt,name=configMapKeyRef"`
 
 	// Default specifies a value to be used if retrieving the value from the specified source fails
@@ -998,6 +1297,48 @@ type Artifact struct {
 
 	// Has this been deleted?
 	Deleted bool `json:"deleted,omitempty" protobuf:"varint,13,opt,name=deleted"`
+
+	// Encryption, if specified, encrypts the artifact client-side before it is saved and decrypts it when it is loaded
+	Encryption *ArtifactEncryption `json:"encryption,omitempty" protobuf:"bytes,14,opt,name=encryption"`
+
+	// ReplicateTo is a list of additional locations that an output artifact is copied to once it has been saved,
+	// e.g. to mirror an artifact saved to S3 into Azure. Locations without a key use the key of the saved artifact.
+	// Replicas are deleted with the artifact by artifact garbage collection.
+	// Note: the schema of a list of artifact locations would make the CRDs too large, so we need
+	// "x-kubernetes-preserve-unknown-fields: true" in the validation schema, and validate it when validating the workflow.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ReplicateTo []ArtifactLocation `json:"replicateTo,omitempty" protobuf:"bytes,15,rep,name=replicateTo"`
+
+	// ContentAddressable, if true and the artifact has no key, saves an output artifact to a key derived from the
+	// SHA-256 digest of its content, and skips the upload if that key already exists. Content-addressable input
+	// artifacts are downloaded via the executor's artifact cache directory, if there is one.
+	// Content-addressable artifacts are shared between workflows, so they are never garbage collected.
+	ContentAddressable bool `json:"contentAddressable,omitempty" protobuf:"varint,16,opt,name=contentAddressable"`
+
+	// Timeout is how long the executor may take to load or save the artifact, e.g. "10m", including retries, before the
+	// step fails
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,17,opt,name=timeout"`
+
+	// MaxBandwidth limits the bytes per second that the executor loads or saves the artifact at, e.g. "50Mi"
+	MaxBandwidth *resource.Quantity `json:"maxBandwidth,omitempty" protobuf:"bytes,18,opt,name=maxBandwidth"`
+
+	// Container is the name of the sidecar whose filesystem the path of an output artifact is in, e.g. of a
+	// log-shipping sidecar. The artifact is saved once the sidecar has stopped. Defaults to the main container.
+	Container string `json:"container,omitempty" protobuf:"bytes,19,opt,name=container"`
+
+	// SizeBytes is the number of bytes of an output artifact that were saved, set by the executor
+	SizeBytes int64 `json:"size,omitempty" protobuf:"varint,20,opt,name=size"`
+
+	// Checksum is the SHA-256 digest of the bytes of an output artifact that were saved, e.g. "sha256:2c26b4...", set by
+	// the executor. Artifacts saved as directories have no checksum.
+	Checksum string `json:"checksum,omitempty" protobuf:"bytes,21,opt,name=checksum"`
+
+	// StageInMemory loads an input artifact into a memory-backed volume rather than onto the disk of the node, so that
+	// a template which reads it repeatedly, e.g. a configuration file or model weights, avoids disk I/O. Artifacts
+	// staged in memory count towards the memory of the pod, and the pod fails if together they are larger than the
+	// memory staging limit of the controller, which is 64Mi if it is not set.
+	StageInMemory bool `json:"stageInMemory,omitempty" protobuf:"varint,22,opt,name=stageInMemory"`
 }
 
 // ArtifactGC returns the ArtifactGC that was defined by the artifact.  If none was provided, a default value is returned.
@@ -1094,6 +1435,39 @@ type WorkflowLevelArtifactGC struct {
 
 	// PodSpecPatch holds strategic merge patch to apply against the artgc pod spec.
 	PodSpecPatch string `json:"podSpecPatch,omitempty" protobuf:"bytes,3,opt,name=podSpecPatch"`
+
+	// Retry is how artifacts that failed to be deleted are retried. By default, they are retried 5 times, backing off
+	// from 1 minute, doubling up to 1 hour.
+	Retry *ArtifactGCRetry `json:"retry,omitempty" protobuf:"bytes,4,opt,name=retry"`
+
+	// Ephemeral is whether the output artifacts that are saved to the default artifact repository with its default
+	// key are ephemeral: they are saved under a prefix that is unique to the Workflow, which the controller deletes
+	// as a whole once the Workflow completes, rather than deleting them one at a time
+	Ephemeral bool `json:"ephemeral,omitempty" protobuf:"varint,5,opt,name=ephemeral"`
+}
+
+// GetRetry returns how artifacts that failed to be deleted are retried
+func (agc *WorkflowLevelArtifactGC) GetRetry() *ArtifactGCRetry {
+	if agc == nil {
+		return nil
+	}
+	return agc.Retry
+}
+
+// IsEphemeral returns whether the intermediate artifacts of the Workflow are saved under a prefix that is deleted as
+// a whole once it completes
+func (agc *WorkflowLevelArtifactGC) IsEphemeral() bool {
+	return agc != nil && agc.Ephemeral
+}
+
+// ArtifactGCRetry describes how artifacts that failed to be deleted are retried
+type ArtifactGCRetry struct {
+	// Limit is the maximum number of times to retry deleting an artifact, after which it is dead-lettered: it is no
+	// longer retried, and is reported by an Event and the `artifact_gc_failures` metric
+	Limit *int32 `json:"limit,omitempty" protobuf:"varint,1,opt,name=limit"`
+
+	// Backoff is the delay before each retry
+	Backoff *Backoff `json:"backoff,omitempty" protobuf:"bytes,2,opt,name=backoff"`
 }
 
 // ArtifactGC describes how to delete artifacts from completed Workflows - this is embedded into the WorkflowLevelArtifactGC, and also used for individual Artifacts to override that as needed
@@ -1107,6 +1481,10 @@ type ArtifactGC struct {
 
 	// ServiceAccountName is an optional field for specifying the Service Account that should be assigned to the Pod doing the deletion
 	ServiceAccountName string `json:"serviceAccountName,omitempty" protobuf:"bytes,3,opt,name=serviceAccountName"`
+
+	// Retention is an optional field for deleting artifacts of completed Workflows once they are too old, or once
+	// too many more recent Workflows have completed, without waiting for the Workflow to be deleted
+	Retention *ArtifactRetention `json:"retention,omitempty" protobuf:"bytes,4,opt,name=retention"`
 }
 
 // GetStrategy returns the VolumeClaimGCStrategy to use for the workflow
@@ -1117,6 +1495,46 @@ func (agc *ArtifactGC) GetStrategy() ArtifactGCStrategy {
 	return ArtifactGCStrategyUndefined
 }
 
+// GetRetention returns the retention of the artifacts, if any
+func (agc *ArtifactGC) GetRetention() *ArtifactRetention {
+	if agc != nil {
+		return agc.Retention
+	}
+	return nil
+}
+
+// ArtifactRetention limits how long artifacts are kept for, and for how many Workflows
+type ArtifactRetention struct {
+	// MaxAge is how long after the node completed that its artifacts are deleted, e.g. "12h" or "30d"
+	MaxAge string `json:"maxAge,omitempty" protobuf:"bytes,1,opt,name=maxAge"`
+
+	// MaxCount is the number of most recent Workflows created from the same WorkflowTemplate,
+	// ClusterWorkflowTemplate, or CronWorkflow whose artifacts are kept
+	MaxCount *int32 `json:"maxCount,omitempty" protobuf:"varint,2,opt,name=maxCount"`
+}
+
+// GetMaxAge returns the maximum age of the artifacts, or zero if there is none.
+// As well as the units of durations, days may be specified with "d", e.g. "30d".
+func (r *ArtifactRetention) GetMaxAge() (time.Duration, error) {
+	if r == nil || r.MaxAge == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(r.MaxAge, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse %s as a number of days: %w", r.MaxAge, err)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return ParseStringToDuration(r.MaxAge)
+}
+
+// ArtifactEncryption describes client-side encryption of an artifact
+type ArtifactEncryption struct {
+	// KeySecret is the secret selector to the AES key used to encrypt the artifact. The key must be 16, 24 or 32 bytes long.
+	KeySecret *apiv1.SecretKeySelector `json:"keySecret" protobuf:"bytes,1,opt,name=keySecret"`
+}
+
 // VolumeClaimGC describes how to delete volumes from completed Workflows
 type VolumeClaimGC struct {
 	// Strategy is the strategy to use. One of "OnWorkflowCompletion", "OnWorkflowSuccess". Defaults to "OnWorkflowSuccess"
@@ -1194,6 +1612,21 @@ type ArtifactLocation struct {
 
 	// Azure contains Azure Storage artifact location details
 	Azure *AzureArtifact `json:"azure,omitempty" protobuf:"bytes,10,opt,name=azure"`
+
+	// SFTP contains SFTP artifact location details
+	SFTP *SFTPArtifact `json:"sftp,omitempty" protobuf:"bytes,11,opt,name=sftp"`
+
+	// Filesystem contains the location of an artifact on a mounted volume
+	Filesystem *FilesystemArtifact `json:"filesystem,omitempty" protobuf:"bytes,12,opt,name=filesystem"`
+
+	// B2 contains Backblaze B2 artifact location details
+	B2 *B2Artifact `json:"b2,omitempty" protobuf:"bytes,13,opt,name=b2"`
+
+	// IBMCOS contains IBM Cloud Object Storage artifact location details
+	IBMCOS *IBMCOSArtifact `json:"ibmcos,omitempty" protobuf:"bytes,14,opt,name=ibmcos"`
+
+	// OCI contains the location of an OCI artifact in a container registry
+	OCI *OCIArtifact `json:"oci,omitempty" protobuf:"bytes,15,opt,name=oci"`
 }
 
 func (a *ArtifactLocation) Get() (ArtifactLocationType, error) {
@@ -1203,6 +1636,10 @@ func (a *ArtifactLocation) Get() (ArtifactLocationType, error) {
 		return a.Artifactory, nil
 	} else if a.Azure != nil {
 		return a.Azure, nil
+	} else if a.B2 != nil {
+		return a.B2, nil
+	} else if a.Filesystem != nil {
+		return a.Filesystem, nil
 	} else if a.Git != nil {
 		return a.Git, nil
 	} else if a.GCS != nil {
@@ -1211,12 +1648,18 @@ func (a *ArtifactLocation) Get() (ArtifactLocationType, error) {
 		return a.HDFS, nil
 	} else if a.HTTP != nil {
 		return a.HTTP, nil
+	} else if a.IBMCOS != nil {
+		return a.IBMCOS, nil
+	} else if a.OCI != nil {
+		return a.OCI, nil
 	} else if a.OSS != nil {
 		return a.OSS, nil
 	} else if a.Raw != nil {
 		return a.Raw, nil
 	} else if a.S3 != nil {
 		return a.S3, nil
+	} else if a.SFTP != nil {
+		return a.SFTP, nil
 	}
 	return nil, fmt.Errorf("You need to configure artifact storage. More information on how to do this can be found in the docs: https://argo-workflows.readthedocs.io/en/latest/configure-artifact-repository/")
 }
@@ -1229,18 +1672,28 @@ func (a *ArtifactLocation) SetType(x ArtifactLocationType) error {
 		a.Artifactory = &ArtifactoryArtifact{}
 	case *AzureArtifact:
 		a.Azure = &AzureArtifact{}
+	case *B2Artifact:
+		a.B2 = &B2Artifact{}
+	case *FilesystemArtifact:
+		a.Filesystem = &FilesystemArtifact{}
 	case *GCSArtifact:
 		a.GCS = &GCSArtifact{}
 	case *HDFSArtifact:
 		a.HDFS = &HDFSArtifact{}
 	case *HTTPArtifact:
 		a.HTTP = &HTTPArtifact{}
+	case *IBMCOSArtifact:
+		a.IBMCOS = &IBMCOSArtifact{}
+	case *OCIArtifact:
+		a.OCI = &OCIArtifact{}
 	case *OSSArtifact:
 		a.OSS = &OSSArtifact{}
 	case *RawArtifact:
 		a.Raw = &RawArtifact{}
 	case *S3Artifact:
 		a.S3 = &S3Artifact{}
+	case *SFTPArtifact:
+		a.SFTP = &SFTPArtifact{}
 	default:
 		return fmt.Errorf("set type not supported for type: %v", reflect.TypeOf(v))
 	}
@@ -1287,7 +1740,15 @@ func (a *ArtifactLocation) Relocate(l *ArtifactLocation) error {
 	if err != nil {
 		return err
 	}
+	// the storage class, ACL, and S3 Select query are per-artifact, so they are kept
+	s3, gcs := a.S3, a.GCS
 	*a = *l.DeepCopy()
+	if s3 != nil && a.S3 != nil {
+		a.S3.StorageClass, a.S3.ACL, a.S3.Select = s3.StorageClass, s3.ACL, s3.Select
+	}
+	if gcs != nil && a.GCS != nil {
+		a.GCS.StorageClass, a.GCS.PredefinedACL = gcs.StorageClass, gcs.PredefinedACL
+	}
 	return a.SetKey(key)
 }
 
@@ -1381,6 +1842,42 @@ type ArtGCStatus struct {
 
 	// if this is true, we already checked to see if we need to do it and we don't
 	NotSpecified bool `json:"notSpecified,omitempty" protobuf:"varint,3,opt,name=notSpecified"`
+
+	// Failures are the artifacts that failed to be deleted (mapped by node ID and artifact name)
+	Failures map[string]ArtifactGCFailure `json:"failures,omitempty" protobuf:"bytes,4,rep,name=failures"`
+
+	// EphemeralPrefixProcessed is whether the prefix of the ephemeral artifacts has been deleted, or its artifacts
+	// were left to be retried one at a time as it failed to be deleted
+	EphemeralPrefixProcessed bool `json:"ephemeralPrefixProcessed,omitempty" protobuf:"varint,5,opt,name=ephemeralPrefixProcessed"`
+}
+
+// ArtifactGCFailure describes an artifact that failed to be deleted
+type ArtifactGCFailure struct {
+	// NodeID is the ID of the node that output the artifact
+	NodeID string `json:"nodeID" protobuf:"bytes,1,opt,name=nodeID"`
+
+	// ArtifactName is the name of the artifact
+	ArtifactName string `json:"artifactName" protobuf:"bytes,2,opt,name=artifactName"`
+
+	// Strategy is the strategy the artifact was deleted by
+	Strategy ArtifactGCStrategy `json:"strategy,omitempty" protobuf:"bytes,3,opt,name=strategy,casttype=ArtifactGCStrategy"`
+
+	// Message is the error of the last attempt to delete the artifact
+	Message string `json:"message,omitempty" protobuf:"bytes,4,opt,name=message"`
+
+	// Attempts is the number of attempts to delete the artifact that failed
+	Attempts int32 `json:"attempts,omitempty" protobuf:"varint,5,opt,name=attempts"`
+
+	// LastAttemptAt is when the last attempt to delete the artifact failed
+	LastAttemptAt metav1.Time `json:"lastAttemptAt,omitempty" protobuf:"bytes,6,opt,name=lastAttemptAt"`
+
+	// DeadLettered is whether the artifact is no longer retried, as it failed to be deleted too many times
+	DeadLettered bool `json:"deadLettered,omitempty" protobuf:"varint,7,opt,name=deadLettered"`
+}
+
+// ArtifactGCFailureKey returns the key of the failure to delete an artifact
+func ArtifactGCFailureKey(nodeID, artifactName string) string {
+	return nodeID + "/" + artifactName
 }
 
 func (gcStatus *ArtGCStatus) SetArtifactGCStrategyProcessed(strategy ArtifactGCStrategy, processed bool) {
@@ -2001,6 +2498,64 @@ type WorkflowStatus struct {
 
 	// TaskResultsCompletionStatus tracks task result completion status (mapped by node ID). Used to prevent premature archiving and garbage collection.
 	TaskResultsCompletionStatus map[string]bool `json:"taskResultsCompletionStatus,omitempty" protobuf:"bytes,20,opt,name=taskResultsCompletionStatus"`
+
+	// NodePatches are the patches of script nodes re-run by a patched retry (mapped by node ID). The nodes run the
+	// patched script instead of their template's until the workflow is resubmitted.
+	NodePatches map[string]NodePatch `json:"nodePatches,omitempty" protobuf:"bytes,21,rep,name=nodePatches"`
+
+	// ArtifactManifest lists the output artifacts that the workflow produced, recorded once it has completed, so that
+	// they can be found without walking its nodes
+	ArtifactManifest []ArtifactManifestEntry `json:"artifactManifest,omitempty" protobuf:"bytes,22,rep,name=artifactManifest"`
+}
+
+// ArtifactManifestEntry is an output artifact produced by a workflow
+type ArtifactManifestEntry struct {
+	// NodeID is the ID of the node that produced the artifact
+	NodeID string `json:"nodeID" protobuf:"bytes,1,opt,name=nodeID"`
+
+	// Name is the name of the artifact
+	Name string `json:"name" protobuf:"bytes,2,opt,name=name"`
+
+	// Key is the key of the artifact in its repository
+	Key string `json:"key,omitempty" protobuf:"bytes,3,opt,name=key"`
+
+	// Repository is where the artifact is saved, e.g. "s3://my-bucket"
+	Repository string `json:"repository,omitempty" protobuf:"bytes,4,opt,name=repository"`
+
+	// SizeBytes is the number of bytes of the artifact, if known
+	SizeBytes int64 `json:"size,omitempty" protobuf:"varint,5,opt,name=size"`
+
+	// Checksum is the SHA-256 digest of the artifact, if known, e.g. "sha256:2c26b4..."
+	Checksum string `json:"checksum,omitempty" protobuf:"bytes,6,opt,name=checksum"`
+}
+
+// NodePatch is a patch of a script node, used to debug a single step by re-running it with an edited script
+type NodePatch struct {
+	// Image replaces the image of the script
+	Image string `json:"image,omitempty" protobuf:"bytes,1,opt,name=image"`
+
+	// Source replaces the source of the script
+	Source string `json:"source,omitempty" protobuf:"bytes,2,opt,name=source"`
+}
+
+// IsEmpty returns whether the patch does not change anything
+func (p NodePatch) IsEmpty() bool {
+	return p.Image == "" && p.Source == ""
+}
+
+// Apply returns a copy of the script template with the patch applied
+func (p NodePatch) Apply(tmpl *Template) *Template {
+	tmpl = tmpl.DeepCopy()
+	if tmpl.Script == nil {
+		return tmpl
+	}
+	if p.Image != "" {
+		tmpl.Script.Image = p.Image
+	}
+	if p.Source != "" {
+		tmpl.Script.Source = p.Source
+	}
+	return tmpl
 }
 
 func (in *WorkflowStatus) MarkTaskResultIncomplete(name string) {
@@ -2078,6 +2633,14 @@ type Backoff struct {
 	// multiplication by the factor parameter would make the duration
 	// exceed the cap then the duration is set to the cap
 	Cap string `json:"cap,omitempty" protobuf:"varint,4,opt,name=cap"`
+	// Jitter is the most that is taken off each backoff of a retryStrategy, as a percentage of it, so that the retries
+	// of the nodes of a large fan-out do not all happen at the same time. The amount is random between nodes, but does
+	// not change when the controller restarts.
+	Jitter *intstr.IntOrString `json:"jitter,omitempty" protobuf:"bytes,5,opt,name=jitter"`
+	// MaxAttemptDuration is the maximum amount of time that each attempt of a retryStrategy may run for, after which it
+	// fails and may be retried. It is only supported by templates that run a pod. Default unit is seconds, but could
+	// also be a duration (e.g. "2m", "1h")
+	MaxAttemptDuration string `json:"maxAttemptDuration,omitempty" protobuf:"bytes,6,opt,name=maxAttemptDuration"`
 }
 
 // RetryNodeAntiAffinity is a placeholder for future expansion, only empty nodeAntiAffinity is allowed.
@@ -2249,6 +2812,14 @@ const (
 	ConditionTypeMetricsError ConditionType = "MetricsError"
 	//ConditionTypeArtifactGCError is an error on artifact garbage collection
 	ConditionTypeArtifactGCError ConditionType = "ArtifactGCError"
+	// ConditionTypeOutputsTruncated is when outputs of nodes were truncated because they were larger than their limits
+	ConditionTypeOutputsTruncated ConditionType = "OutputsTruncated"
+	// ConditionTypePendingTimeout is when a pod of the workflow was pending for longer than the pending timeout of the workflow
+	ConditionTypePendingTimeout ConditionType = "PendingTimeout"
+	// ConditionTypeResourceBudgetExceeded is when the resources duration of the workflow exceeded its resource budget
+	ConditionTypeResourceBudgetExceeded ConditionType = "ResourceBudgetExceeded"
+	// ConditionTypeArtifactQuotaExceeded is when a pod of the workflow failed to save artifacts because the artifact repository was out of space, or over its quota
+	ConditionTypeArtifactQuotaExceeded ConditionType = "ArtifactQuotaExceeded"
 )
 
 type Condition struct {
@@ -2312,6 +2883,9 @@ type NodeStatus struct {
 	// Progress to completion
 	Progress Progress `json:"progress,omitempty" protobuf:"bytes,26,opt,name=progress,casttype=Progress"`
 
+	// ArtifactProgress is the progress of packing and uploading the output artifacts of a pod node
+	ArtifactProgress *ArtifactProgress `json:"artifactProgress,omitempty" protobuf:"bytes,28,opt,name=artifactProgress"`
+
 	// ResourcesDuration is indicative, but not accurate, resource duration. This is populated when the nodes completes.
 	ResourcesDuration ResourcesDuration `json:"resourcesDuration,omitempty" protobuf:"bytes,21,opt,name=resourcesDuration"`
 
@@ -2324,6 +2898,16 @@ type NodeStatus struct {
 	// NodeFlag tracks some history of node. e.g.) hooked, retried, etc.
 	NodeFlag *NodeFlag `json:"nodeFlag,omitempty" protobuf:"bytes,27,opt,name=nodeFlag"`
 
+	// Patched is whether this node ran a patched script, rather than its template's, because of a patched retry
+	Patched bool `json:"patched,omitempty" protobuf:"varint,29,opt,name=patched"`
+
+	// Provenance is the runtime context that a pod node ran in, which is recorded when it completes
+	Provenance *NodeProvenance `json:"provenance,omitempty" protobuf:"bytes,30,opt,name=provenance"`
+
+	// SchedulingLatency is how long the pod of a pod node took to start running after the node was created, which is
+	// recorded once its containers start
+	SchedulingLatency *NodeSchedulingLatency `json:"schedulingLatency,omitempty" protobuf:"bytes,31,opt,name=schedulingLatency"`
+
 	// Inputs captures input parameter values and artifact locations supplied to this template invocation
 	Inputs *Inputs `json:"inputs,omitempty" protobuf:"bytes,14,opt,name=inputs"`
 
@@ -2584,6 +3168,9 @@ type S3Bucket struct {
 
 	// CASecret specifies the secret that contains the CA, used to verify the TLS connection
 	CASecret *apiv1.SecretKeySelector `json:"caSecret,omitempty" protobuf:"bytes,11,opt,name=caSecret"`
+
+	// RequesterPays tells the driver to accept the charges of reading from and saving to a requester pays bucket, such as a public dataset
+	RequesterPays bool `json:"requesterPays,omitempty" protobuf:"varint,13,opt,name=requesterPays"`
 }
 
 // S3EncryptionOptions used to determine encryption options during s3 operations
@@ -2613,8 +3200,69 @@ type S3Artifact struct {
 
 	// Key is the key in the bucket where the artifact resides
 	Key string `json:"key,omitempty" protobuf:"bytes,2,opt,name=key"`
+
+	// StorageClass is the storage class the artifact is saved with, e.g. STANDARD_IA or GLACIER_IR. Defaults to the bucket's default storage class.
+	StorageClass string `json:"storageClass,omitempty" protobuf:"bytes,3,opt,name=storageClass"`
+
+	// ACL is the canned ACL the artifact is saved with, e.g. private or bucket-owner-full-control
+	ACL string `json:"acl,omitempty" protobuf:"bytes,4,opt,name=acl"`
+
+	// Select loads only the records of the object that match an S3 Select query, rather than the whole object.
+	// Only valid for input artifacts.
+	Select *S3Select `json:"select,omitempty" protobuf:"bytes,5,opt,name=select"`
+}
+
+// S3Select is an S3 Select query that filters the records of a CSV, JSON, or Parquet object
+type S3Select struct {
+	// Expression is the SQL expression, e.g. `SELECT * FROM S3Object s WHERE s.status = 'failed'`
+	Expression string `json:"expression" protobuf:"bytes,1,opt,name=expression"`
+
+	// Input is the format of the object
+	Input S3SelectInput `json:"input" protobuf:"bytes,2,opt,name=input"`
+
+	// Output is the format the matching records are loaded in. Defaults to JSON for JSON objects, otherwise CSV.
+	Output *S3SelectOutput `json:"output,omitempty" protobuf:"bytes,3,opt,name=output"`
+}
+
+// S3SelectInput is the format of the object that is queried. Exactly one of CSV, JSON, or Parquet must be set.
+type S3SelectInput struct {
+	// CompressionType is the compression of the object: NONE (default), GZIP, or BZIP2
+	CompressionType string `json:"compressionType,omitempty" protobuf:"bytes,1,opt,name=compressionType"`
+
+	CSV *S3SelectCSV `json:"csv,omitempty" protobuf:"bytes,2,opt,name=csv"`
+
+	JSON *S3SelectJSON `json:"json,omitempty" protobuf:"bytes,3,opt,name=json"`
+
+	Parquet *S3SelectParquet `json:"parquet,omitempty" protobuf:"bytes,4,opt,name=parquet"`
 }
 
+// S3SelectOutput is the format of the records that are loaded. At most one of CSV or JSON may be set.
+type S3SelectOutput struct {
+	CSV *S3SelectCSV `json:"csv,omitempty" protobuf:"bytes,1,opt,name=csv"`
+
+	JSON *S3SelectJSON `json:"json,omitempty" protobuf:"bytes,2,opt,name=json"`
+}
+
+// S3SelectCSV is the format of CSV records
+type S3SelectCSV struct {
+	// FileHeaderInfo is how the first line of the object is used: NONE (default), USE to refer to the columns by
+	// name, or IGNORE. Only valid for the input.
+	FileHeaderInfo string `json:"fileHeaderInfo,omitempty" protobuf:"bytes,1,opt,name=fileHeaderInfo"`
+
+	// FieldDelimiter is the character that separates the fields. Defaults to ",".
+	FieldDelimiter string `json:"fieldDelimiter,omitempty" protobuf:"bytes,2,opt,name=fieldDelimiter"`
+}
+
+// S3SelectJSON is the format of JSON records
+type S3SelectJSON struct {
+	// Type is whether the object is a single JSON DOCUMENT, or one JSON object per line, LINES. Only valid, and
+	// required, for the input.
+	Type string `json:"type,omitempty" protobuf:"bytes,1,opt,name=type"`
+}
+
+// S3SelectParquet is the format of Parquet objects, which has no options
+type S3SelectParquet struct{}
+
 func (s *S3Artifact) GetKey() (string, error) {
 	return s.Key, nil
 }
@@ -2666,6 +3314,17 @@ type GitArtifact struct {
 
 	// InsecureSkipTLS disables server certificate verification resulting in insecure HTTPS connections
 	InsecureSkipTLS bool `json:"insecureSkipTLS,omitempty" protobuf:"varint,12,opt,name=insecureSkipTLS"`
+
+	// SparsePaths are the directories to checkout, relative to the root of the repository. All are checked out
+	// if none are specified.
+	SparsePaths []string `json:"sparsePaths,omitempty" protobuf:"bytes,13,rep,name=sparsePaths"`
+
+	// Filter is the object filter of a partial clone, e.g. `blob:none`, so that only the objects that are needed are
+	// downloaded. The repository must support partial clones, and the executor image must have the git CLI.
+	Filter string `json:"filter,omitempty" protobuf:"bytes,14,opt,name=filter"`
+
+	// CommitMessage is the message of the commit of an output artifact, which is pushed to the `branch`
+	CommitMessage string `json:"commitMessage,omitempty" protobuf:"bytes,15,opt,name=commitMessage"`
 }
 
 func (g *GitArtifact) HasLocation() bool {
@@ -2831,6 +3490,192 @@ type HDFSKrbConfig struct {
 	KrbServicePrincipalName string `json:"krbServicePrincipalName,omitempty" protobuf:"bytes,6,opt,name=krbServicePrincipalName"`
 }
 
+// SFTPArtifact is the location of an artifact on an SFTP server
+type SFTPArtifact struct {
+	SFTPConfig `json:",inline" protobuf:"bytes,1,opt,name=sFTPConfig"`
+
+	// Path is the path of the file or directory on the server
+	Path string `json:"path" protobuf:"bytes,2,opt,name=path"`
+}
+
+func (s *SFTPArtifact) GetKey() (string, error) {
+	return s.Path, nil
+}
+
+func (s *SFTPArtifact) SetKey(key string) error {
+	s.Path = key
+	return nil
+}
+
+func (s *SFTPArtifact) HasLocation() bool {
+	return s != nil && s.Host != "" && s.Path != ""
+}
+
+// SFTPConfig is configurations for an SFTP server
+type SFTPConfig struct {
+	// Host is the host name or address of the server
+	Host string `json:"host,omitempty" protobuf:"bytes,1,opt,name=host"`
+
+	// Port is the port of the server, 22 if not set
+	Port int32 `json:"port,omitempty" protobuf:"varint,2,opt,name=port"`
+
+	// UsernameSecret is the secret selector to the username
+	UsernameSecret *apiv1.SecretKeySelector `json:"usernameSecret,omitempty" protobuf:"bytes,3,opt,name=usernameSecret"`
+
+	// PasswordSecret is the secret selector to the password
+	PasswordSecret *apiv1.SecretKeySelector `json:"passwordSecret,omitempty" protobuf:"bytes,4,opt,name=passwordSecret"`
+
+	// PrivateKeySecret is the secret selector to the SSH private key
+	PrivateKeySecret *apiv1.SecretKeySelector `json:"privateKeySecret,omitempty" protobuf:"bytes,5,opt,name=privateKeySecret"`
+
+	// KnownHostsConfigMap is the configmap selector to the host keys of the server, in the format of an OpenSSH
+	// known_hosts file. It must be set unless InsecureIgnoreHostKey is.
+	KnownHostsConfigMap *apiv1.ConfigMapKeySelector `json:"knownHostsConfigMap,omitempty" protobuf:"bytes,6,opt,name=knownHostsConfigMap"`
+
+	// InsecureIgnoreHostKey disables the verification of the host key of the server
+	InsecureIgnoreHostKey bool `json:"insecureIgnoreHostKey,omitempty" protobuf:"varint,7,opt,name=insecureIgnoreHostKey"`
+}
+
+// FilesystemArtifact is the location of an artifact on a volume, such as an NFS share or a persistent volume claim,
+// that is mounted by the pods that load and save it
+type FilesystemArtifact struct {
+	FilesystemConfig `json:",inline" protobuf:"bytes,1,opt,name=filesystemConfig"`
+
+	// Path is the path of the file or directory, relative to the root of the volume
+	Path string `json:"path" protobuf:"bytes,2,opt,name=path"`
+}
+
+func (f *FilesystemArtifact) GetKey() (string, error) {
+	return f.Path, nil
+}
+
+func (f *FilesystemArtifact) SetKey(key string) error {
+	f.Path = key
+	return nil
+}
+
+func (f *FilesystemArtifact) HasLocation() bool {
+	return f != nil && f.Volume.Name != "" && f.Path != ""
+}
+
+// FilesystemConfig is configurations for a volume that stores artifacts
+type FilesystemConfig struct {
+	// Volume is the volume that stores the artifacts. It is mounted read-write by the pods that load and save
+	// artifacts, so it must support being mounted by more than one pod at once, e.g. an NFS share or a persistent
+	// volume claim with the ReadWriteMany access mode.
+	// Note: the schema of a volume would make the CRDs too large, so we need
+	// "x-kubernetes-preserve-unknown-fields: true" in the validation schema, and validate it when validating the workflow.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Volume apiv1.Volume `json:"volume,omitempty" protobuf:"bytes,1,opt,name=volume"`
+}
+
+// B2Artifact is the location of a Backblaze B2 artifact
+type B2Artifact struct {
+	B2Config `json:",inline" protobuf:"bytes,1,opt,name=b2Config"`
+
+	// Key is the name of the file in the bucket, or the prefix of the names of the files of a directory
+	Key string `json:"key" protobuf:"bytes,2,opt,name=key"`
+}
+
+func (b *B2Artifact) GetKey() (string, error) {
+	return b.Key, nil
+}
+
+func (b *B2Artifact) SetKey(key string) error {
+	b.Key = key
+	return nil
+}
+
+func (b *B2Artifact) HasLocation() bool {
+	return b != nil && b.Bucket != "" && b.Key != ""
+}
+
+// B2Config is configurations for a Backblaze B2 bucket, which is accessed with its native API
+type B2Config struct {
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket,omitempty" protobuf:"bytes,1,opt,name=bucket"`
+
+	// ApplicationKeyIDSecret is the secret selector to the ID of the application key
+	ApplicationKeyIDSecret *apiv1.SecretKeySelector `json:"applicationKeyIDSecret,omitempty" protobuf:"bytes,2,opt,name=applicationKeyIDSecret"`
+
+	// ApplicationKeySecret is the secret selector to the application key, which may be restricted to the bucket
+	ApplicationKeySecret *apiv1.SecretKeySelector `json:"applicationKeySecret,omitempty" protobuf:"bytes,3,opt,name=applicationKeySecret"`
+}
+
+// IBMCOSArtifact is the location of an IBM Cloud Object Storage artifact
+type IBMCOSArtifact struct {
+	IBMCOSConfig `json:",inline" protobuf:"bytes,1,opt,name=ibmCOSConfig"`
+
+	// Key is the key of the object in the bucket, or the prefix of the keys of the objects of a directory
+	Key string `json:"key" protobuf:"bytes,2,opt,name=key"`
+}
+
+func (c *IBMCOSArtifact) GetKey() (string, error) {
+	return c.Key, nil
+}
+
+func (c *IBMCOSArtifact) SetKey(key string) error {
+	c.Key = key
+	return nil
+}
+
+func (c *IBMCOSArtifact) HasLocation() bool {
+	return c != nil && c.Endpoint != "" && c.Bucket != "" && c.Key != ""
+}
+
+// IBMCOSConfig is configurations for an IBM Cloud Object Storage bucket, which is authenticated to with IAM, either
+// with an API key or with a trusted profile
+type IBMCOSConfig struct {
+	// Endpoint is the endpoint of the bucket, e.g. s3.us-south.cloud-object-storage.appdomain.cloud, which may be a URL
+	Endpoint string `json:"endpoint,omitempty" protobuf:"bytes,1,opt,name=endpoint"`
+
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket,omitempty" protobuf:"bytes,2,opt,name=bucket"`
+
+	// APIKeySecret is the secret selector to an IAM API key
+	APIKeySecret *apiv1.SecretKeySelector `json:"apiKeySecret,omitempty" protobuf:"bytes,3,opt,name=apiKeySecret"`
+
+	// TrustedProfileID is the ID of an IAM trusted profile, which is authenticated to with the compute resource token
+	// of the pod rather than an API key
+	TrustedProfileID string `json:"trustedProfileID,omitempty" protobuf:"bytes,4,opt,name=trustedProfileID"`
+
+	// ComputeResourceTokenPath is the path of the compute resource token of the pod, which is
+	// /var/run/secrets/tokens/vault-token unless it is set
+	ComputeResourceTokenPath string `json:"computeResourceTokenPath,omitempty" protobuf:"bytes,5,opt,name=computeResourceTokenPath"`
+
+	// IAMEndpoint is the URL of IAM, which is https://iam.cloud.ibm.com unless it is set
+	IAMEndpoint string `json:"iamEndpoint,omitempty" protobuf:"bytes,6,opt,name=iamEndpoint"`
+}
+
+// OCIArtifact is the location of a file or directory that is packaged as an OCI artifact, e.g. with ORAS, in a
+// container registry. It can only be an input artifact.
+type OCIArtifact struct {
+	// Reference is the reference to the artifact, e.g. ghcr.io/my-org/my-data:v1 or ghcr.io/my-org/my-data@sha256:...
+	Reference string `json:"reference" protobuf:"bytes,1,opt,name=reference"`
+
+	// UsernameSecret is the secret selector to the username of the registry
+	UsernameSecret *apiv1.SecretKeySelector `json:"usernameSecret,omitempty" protobuf:"bytes,2,opt,name=usernameSecret"`
+
+	// PasswordSecret is the secret selector to the password, or the token, of the registry
+	PasswordSecret *apiv1.SecretKeySelector `json:"passwordSecret,omitempty" protobuf:"bytes,3,opt,name=passwordSecret"`
+
+	// Insecure connects to the registry over HTTP rather than HTTPS
+	Insecure bool `json:"insecure,omitempty" protobuf:"varint,4,opt,name=insecure"`
+}
+
+func (o *OCIArtifact) HasLocation() bool {
+	return o != nil && o.Reference != ""
+}
+
+func (o *OCIArtifact) GetKey() (string, error) {
+	return "", fmt.Errorf("key unsupported: oci artifact does not have a key")
+}
+
+func (o *OCIArtifact) SetKey(string) error {
+	return fmt.Errorf("key unsupported: cannot set key on oci artifact")
+}
+
 // RawArtifact allows raw string content to be placed as an artifact in a container
 type RawArtifact struct {
 	// Data is the string contents of the artifact
@@ -2907,6 +3752,42 @@ type HTTPArtifact struct {
 
 	// Auth contains information for client authentication
 	Auth *HTTPAuth `json:"auth,omitempty" protobuf:"bytes,3,opt,name=auth"`
+
+	// Retry is how failed downloads of the artifact are retried. Interrupted downloads are resumed where they left off,
+	// if the server supports range requests.
+	Retry *HTTPArtifactRetry `json:"retry,omitempty" protobuf:"bytes,4,opt,name=retry"`
+
+	// SHA256 is the expected hex-encoded SHA256 checksum of the artifact, which is verified once it is downloaded
+	SHA256 string `json:"sha256,omitempty" protobuf:"bytes,5,opt,name=sha256"`
+
+	// Method is the HTTP method used to upload output artifacts, PUT by default
+	// +kubebuilder:validation:Enum=PUT;POST;PATCH
+	Method string `json:"method,omitempty" protobuf:"bytes,6,opt,name=method"`
+
+	// Form uploads output artifacts as multipart/form-data, rather than as the body of the request
+	Form *HTTPArtifactForm `json:"form,omitempty" protobuf:"bytes,7,opt,name=form"`
+}
+
+// HTTPArtifactForm describes the multipart/form-data form that an output artifact is uploaded with
+type HTTPArtifactForm struct {
+	// FileField is the name of the field of the file of the artifact, "file" by default
+	FileField string `json:"fileField,omitempty" protobuf:"bytes,1,opt,name=fileField"`
+
+	// FileName is the name of the file of the artifact, by default the name of the file it is saved to, e.g.
+	// `<artifact name>.tgz` if it is archived
+	FileName string `json:"fileName,omitempty" protobuf:"bytes,2,opt,name=fileName"`
+
+	// Fields are the other fields of the form
+	Fields map[string]string `json:"fields,omitempty" protobuf:"bytes,3,rep,name=fields"`
+}
+
+// HTTPArtifactRetry describes how failed downloads of an HTTP artifact are retried
+type HTTPArtifactRetry struct {
+	// Limit is the maximum number of times to retry a failed download, 3 by default
+	Limit *int32 `json:"limit,omitempty" protobuf:"varint,1,opt,name=limit"`
+
+	// Backoff is the delay before each retry, 1 second doubling up to 1 minute by default
+	Backoff *Backoff `json:"backoff,omitempty" protobuf:"bytes,2,opt,name=backoff"`
 }
 
 func (h *HTTPArtifact) GetKey() (string, error) {
@@ -2938,6 +3819,10 @@ type GCSBucket struct {
 
 	// ServiceAccountKeySecret is the secret selector to the bucket's service account key
 	ServiceAccountKeySecret *apiv1.SecretKeySelector `json:"serviceAccountKeySecret,omitempty" protobuf:"bytes,2,opt,name=serviceAccountKeySecret"`
+
+	// ExternalAccountSecret is the secret selector to the credential configuration of an external account, which
+	// uses workload identity federation to access the bucket without a service account key
+	ExternalAccountSecret *apiv1.SecretKeySelector `json:"externalAccountSecret,omitempty" protobuf:"bytes,3,opt,name=externalAccountSecret"`
 }
 
 // GCSArtifact is the location of a GCS artifact
@@ -2946,6 +3831,12 @@ type GCSArtifact struct {
 
 	// Key is the path in the bucket where the artifact resides
 	Key string `json:"key" protobuf:"bytes,2,opt,name=key"`
+
+	// StorageClass is the storage class the artifact is saved with, e.g. NEARLINE or COLDLINE. Defaults to the bucket's default storage class.
+	StorageClass string `json:"storageClass,omitempty" protobuf:"bytes,3,opt,name=storageClass"`
+
+	// PredefinedACL is the predefined ACL the artifact is saved with, e.g. private or bucketOwnerFullControl
+	PredefinedACL string `json:"predefinedACL,omitempty" protobuf:"bytes,4,opt,name=predefinedACL"`
 }
 
 func (g *GCSArtifact) GetKey() (string, error) {
@@ -3068,6 +3959,27 @@ type ResourceTemplate struct {
 	Flags []string `json:"flags,omitempty" protobuf:"varint,7,opt,name=flags"`
 }
 
+// GangTemplate runs a gang of pods as one node. The pods are created together, as an indexed Job, and can reach each
+// other by their hostnames through a headless service. They fail together: as soon as one of them fails, the others
+// are stopped and the node fails.
+type GangTemplate struct {
+	// Pods is the number of pods of the gang
+	Pods int32 `json:"size" protobuf:"varint,1,opt,name=size"`
+
+	// Template is the template of the pods of the gang. The pods have their rank, the size of the gang, and the address
+	// of its leader, the pod of rank 0, in the ARGO_GANG_RANK, ARGO_GANG_SIZE and ARGO_GANG_LEADER environment
+	// variables. Their restart policy is always Never.
+	// Note: the schema of a pod template would make the CRDs too large, so we need
+	// "x-kubernetes-preserve-unknown-fields: true" in the validation schema, and validate it when validating the workflow.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Template apiv1.PodTemplateSpec `json:"template" protobuf:"bytes,2,opt,name=template"`
+
+	// LeaderCompletes completes the gang, stopping its other pods, as soon as its leader succeeds, e.g. for workers that
+	// serve the leader until they are stopped. Otherwise, every pod of the gang must succeed.
+	LeaderCompletes bool `json:"leaderCompletes,omitempty" protobuf:"varint,3,opt,name=leaderCompletes"`
+}
+
 type ManifestFrom struct {
 	// Artifact contains the artifact to use
 	Artifact *Artifact `json:"artifact" protobuf:"bytes,1,opt,name=artifact"`
@@ -3105,6 +4017,12 @@ func (tmpl *Template) GetType() TemplateType {
 	if tmpl.Plugin != nil {
 		return TemplateTypePlugin
 	}
+	if tmpl.JobTemplate != nil {
+		return TemplateTypeJob
+	}
+	if tmpl.Gang != nil {
+		return TemplateTypeGang
+	}
 	return TemplateTypeUnknown
 }
 
@@ -3113,7 +4031,7 @@ func (tmpl *Template) GetNodeType() NodeType {
 		return NodeTypeRetry
 	}
 	switch tmpl.GetType() {
-	case TemplateTypeContainer, TemplateTypeContainerSet, TemplateTypeScript, TemplateTypeResource, TemplateTypeData:
+	case TemplateTypeContainer, TemplateTypeContainerSet, TemplateTypeScript, TemplateTypeResource, TemplateTypeData, TemplateTypeJob, TemplateTypeGang:
 		return NodeTypePod
 	case TemplateTypeDAG:
 		return NodeTypeDAG
@@ -3132,7 +4050,7 @@ func (tmpl *Template) GetNodeType() NodeType {
 // IsPodType returns whether or not the template is a pod type
 func (tmpl *Template) IsPodType() bool {
 	switch tmpl.GetType() {
-	case TemplateTypeContainer, TemplateTypeContainerSet, TemplateTypeScript, TemplateTypeResource, TemplateTypeData:
+	case TemplateTypeContainer, TemplateTypeContainerSet, TemplateTypeScript, TemplateTypeResource, TemplateTypeData, TemplateTypeJob, TemplateTypeGang:
 		return true
 	}
 	return false
@@ -3141,7 +4059,7 @@ func (tmpl *Template) IsPodType() bool {
 // IsLeaf returns whether or not the template is a leaf
 func (tmpl *Template) IsLeaf() bool {
 	switch tmpl.GetType() {
-	case TemplateTypeContainer, TemplateTypeContainerSet, TemplateTypeScript, TemplateTypeResource, TemplateTypeData, TemplateTypeHTTP, TemplateTypePlugin:
+	case TemplateTypeContainer, TemplateTypeContainerSet, TemplateTypeScript, TemplateTypeResource, TemplateTypeData, TemplateTypeHTTP, TemplateTypePlugin, TemplateTypeJob, TemplateTypeGang:
 		return true
 	}
 	return false
@@ -3281,6 +4199,20 @@ type DAGTask struct {
 	// Hooks hold the lifecycle hook which is invoked at lifecycle of
 	// task, irrespective of the success, failure, or error status of the primary task
 	Hooks LifecycleHooks `json:"hooks,omitempty" protobuf:"bytes,13,opt,name=hooks"`
+
+	// MaxFanout limits how many of the tasks that withItems, withParam, or withSequence expand the task into can run at
+	// once, regardless of the parallelism of the workflow and template, so that a task with many items does not
+	// starve the rest of the DAG
+	MaxFanout *int64 `json:"maxFanout,omitempty" protobuf:"varint,15,opt,name=maxFanout"`
+
+	// DAGTemplateFrom is a parameter, e.g. "{{tasks.plan.outputs.result}}", whose JSON value is the DAG template that
+	// the task runs, or just its tasks, so that the tasks and their dependencies are decided by an earlier task at
+	// runtime. The tasks reference the templates of the workflow like any other task. Template, templateRef and inline
+	// must be empty if this is set.
+	DAGTemplateFrom string `json:"dagTemplateFrom,omitempty" protobuf:"bytes,16,opt,name=dagTemplateFrom"`
+
+	// Labels of the task, which spec.taskSelector of the workflow selects the tasks to execute by
+	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,17,rep,name=labels"`
 }
 
 func (t *DAGTask) GetName() string {
@@ -3332,6 +4264,24 @@ type SuspendTemplate struct {
 	// Duration is the seconds to wait before automatically resuming a template. Must be a string. Default unit is seconds.
 	// Could also be a Duration, e.g.: "2m", "6h"
 	Duration string `json:"duration,omitempty" protobuf:"bytes,1,opt,name=duration"`
+
+	// Event, if specified, resumes the node when an event matching the selector is received on the event API
+	Event *SuspendEvent `json:"event,omitempty" protobuf:"bytes,2,opt,name=event"`
+}
+
+// GetEventSelector returns the selector of the event that resumes the node, or an empty string if there is none
+func (s *SuspendTemplate) GetEventSelector() string {
+	if s == nil || s.Event == nil {
+		return ""
+	}
+	return s.Event.Selector
+}
+
+// SuspendEvent describes an event that resumes a suspended node
+type SuspendEvent struct {
+	// Selector (https://github.com/expr-lang/expr) that must match the event. E.g. `payload.message == "approved"`.
+	// The node's input parameters are available as `inputs.parameters.<name>`.
+	Selector string `json:"selector" protobuf:"bytes,1,opt,name=selector"`
 }
 
 // GetArtifactByName returns an input artifact by its name
@@ -3766,13 +4716,37 @@ type Counter struct {
 
 // Memoization enables caching for the Outputs of the template
 type Memoize struct {
-	// Key is the key to use as the caching key
-	Key string `json:"key" protobuf:"bytes,1,opt,name=key"`
+	// Key is the key to use as the caching key. If it is empty, the key is derived from a hash of the template's name
+	// and resolved inputs: the values of its parameters, and the checksums of its artifacts.
+	// +optional
+	Key string `json:"key,omitempty" protobuf:"bytes,1,opt,name=key"`
 	// Cache sets and configures the kind of cache
 	Cache *Cache `json:"cache" protobuf:"bytes,2,opt,name=cache"`
 	// MaxAge is the maximum age (e.g. "180s", "24h") of an entry that is still considered valid. If an entry is older
 	// than the MaxAge, it will be ignored.
 	MaxAge string `json:"maxAge" protobuf:"bytes,3,opt,name=maxAge"`
+	// Scope is the scope of the entries of the cache that are hit: "Namespace" (the default) hits the entries saved by
+	// any Workflow in the namespace, and "Workflow" only hits the entries saved by runs of the same workflow, i.e.
+	// Workflows of the same WorkflowTemplate, ClusterWorkflowTemplate or CronWorkflow, or of the same name.
+	// +kubebuilder:validation:Enum="";Namespace;Workflow
+	// +optional
+	Scope MemoizeScope `json:"scope,omitempty" protobuf:"bytes,4,opt,name=scope,casttype=MemoizeScope"`
+}
+
+// MemoizeScope is the scope of the entries of a memoization cache that a template hits
+type MemoizeScope string
+
+const (
+	MemoizeScopeNamespace MemoizeScope = "Namespace"
+	MemoizeScopeWorkflow  MemoizeScope = "Workflow"
+)
+
+// GetScope returns the scope of the entries of the cache that are hit, which is the namespace if it is not set
+func (m *Memoize) GetScope() MemoizeScope {
+	if m == nil || m.Scope == "" {
+		return MemoizeScopeNamespace
+	}
+	return m.Scope
 }
 
 // MemoizationStatus is the status of this memoized node
@@ -4004,6 +4978,77 @@ type NodeFlag struct {
 	Retried bool `json:"retried,omitempty" protobuf:"varint,2,opt,name=retried"`
 }
 
+// ArtifactProgressPhase is the stage of saving an output artifact
+type ArtifactProgressPhase string
+
+const (
+	// ArtifactProgressPacking is archiving the artifact before it is uploaded
+	ArtifactProgressPacking ArtifactProgressPhase = "Packing"
+	// ArtifactProgressUploading is uploading the artifact to the artifact repository
+	ArtifactProgressUploading ArtifactProgressPhase = "Uploading"
+)
+
+// ArtifactProgress is the progress of the output artifact that is currently being saved by the executor
+type ArtifactProgress struct {
+	// Artifact is the name of the artifact
+	Artifact string `json:"artifact,omitempty" protobuf:"bytes,1,opt,name=artifact"`
+	// Phase is whether the artifact is being packed or uploaded
+	Phase ArtifactProgressPhase `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase,casttype=ArtifactProgressPhase"`
+	// BytesDone is the number of bytes packed or uploaded so far
+	BytesDone int64 `json:"bytesDone,omitempty" protobuf:"varint,3,opt,name=bytesDone"`
+	// BytesTotal is the number of bytes to pack or upload, zero if it is not known
+	BytesTotal int64 `json:"bytesTotal,omitempty" protobuf:"varint,4,opt,name=bytesTotal"`
+	// ETA is the estimated time that the phase completes, based on the rate so far
+	ETA *metav1.Time `json:"eta,omitempty" protobuf:"bytes,5,opt,name=eta"`
+}
+
+// NodeProvenance is the runtime context that a pod node ran in, so that how it ran can be reconstructed later
+type NodeProvenance struct {
+	// ImageIDs are the IDs of the images that the containers of the pod ran, which include their digests, keyed by
+	// the name of the container
+	ImageIDs map[string]string `json:"imageIDs,omitempty" protobuf:"bytes,1,rep,name=imageIDs"`
+	// OS is the operating system of the node that the pod ran on
+	OS string `json:"os,omitempty" protobuf:"bytes,2,opt,name=os"`
+	// Arch is the architecture of the node that the pod ran on
+	Arch string `json:"arch,omitempty" protobuf:"bytes,3,opt,name=arch"`
+	// EnvVarNames are the names, but not the values, of the environment variables of the containers of the pod
+	EnvVarNames []string `json:"envVarNames,omitempty" protobuf:"bytes,4,rep,name=envVarNames"`
+	// ExecutorVersion is the version of the executor that ran the pod
+	ExecutorVersion string `json:"executorVersion,omitempty" protobuf:"bytes,5,opt,name=executorVersion"`
+	// StartedAt is the time that the executor started waiting for the main containers
+	StartedAt *metav1.Time `json:"startedAt,omitempty" protobuf:"bytes,6,opt,name=startedAt"`
+	// FinishedAt is the time that the main containers completed
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,7,opt,name=finishedAt"`
+	// Duration is how long the main containers ran for, measured with a monotonic clock, so unlike the difference
+	// of StartedAt and FinishedAt it is not affected by changes to the clock of the node
+	Duration *metav1.Duration `json:"duration,omitempty" protobuf:"bytes,8,opt,name=duration"`
+}
+
+// NodeSchedulingLatency is how long the pod of a node waited before its containers started, split into the stages of
+// starting it, so that a slow cluster can be told apart from a slow template
+type NodeSchedulingLatency struct {
+	// Scheduling is from the node being created until its pod was scheduled to a Kubernetes node, which includes
+	// waiting for the pod to be created, e.g. because of parallelism, and for the resources of the cluster
+	Scheduling *metav1.Duration `json:"scheduling,omitempty" protobuf:"bytes,1,opt,name=scheduling"`
+	// Initializing is from the pod being scheduled until its init containers completed, which includes pulling their
+	// images and loading the input artifacts
+	Initializing *metav1.Duration `json:"initializing,omitempty" protobuf:"bytes,2,opt,name=initializing"`
+	// Starting is from the init containers completing until the containers started, which is mostly pulling the
+	// images of the containers
+	Starting *metav1.Duration `json:"starting,omitempty" protobuf:"bytes,3,opt,name=starting"`
+}
+
+// Total returns how long the pod waited before its containers started
+func (l *NodeSchedulingLatency) Total() time.Duration {
+	var total time.Duration
+	for _, d := range []*metav1.Duration{l.Scheduling, l.Initializing, l.Starting} {
+		if d != nil {
+			total += d.Duration
+		}
+	}
+	return total
+}
+
 type TemplateAnnotation string
 
 const (
@@ -430,6 +430,20 @@ func TestArtifactLocation_Relocate(t *testing.T) {
 		assert.Equal(t, "my-bucket", l.S3.Bucket, "bucket copied from argument")
 		assert.Equal(t, "my-key", l.S3.Key, "key is unchanged")
 	})
+	t.Run("StorageClass", func(t *testing.T) {
+		l := &ArtifactLocation{S3: &S3Artifact{Key: "my-key", StorageClass: "GLACIER_IR", ACL: "private"}}
+		require.NoError(t, l.Relocate(&ArtifactLocation{S3: &S3Artifact{S3Bucket: S3Bucket{Bucket: "my-bucket"}}}))
+		assert.Equal(t, "my-bucket", l.S3.Bucket, "bucket copied from argument")
+		assert.Equal(t, "GLACIER_IR", l.S3.StorageClass, "storage class is unchanged")
+		assert.Equal(t, "private", l.S3.ACL, "ACL is unchanged")
+	})
+	t.Run("S3Select", func(t *testing.T) {
+		query := &S3Select{Expression: "SELECT * FROM S3Object", Input: S3SelectInput{CSV: &S3SelectCSV{}}}
+		l := &ArtifactLocation{S3: &S3Artifact{Key: "my-key", Select: query}}
+		require.NoError(t, l.Relocate(&ArtifactLocation{S3: &S3Artifact{S3Bucket: S3Bucket{Bucket: "my-bucket"}}}))
+		assert.Equal(t, "my-bucket", l.S3.Bucket, "bucket copied from argument")
+		assert.Equal(t, query, l.S3.Select, "query is unchanged")
+	})
 }
 
 func TestArtifactLocation_Get(t *testing.T) {
@@ -852,6 +866,18 @@ func TestShutdownStrategy_ShouldExecute(t *testing.T) {
 	assert.False(t, ShutdownStrategyTerminate.ShouldExecute(false))
 	assert.False(t, ShutdownStrategyStop.ShouldExecute(false))
 	assert.True(t, ShutdownStrategyStop.ShouldExecute(true))
+	assert.False(t, ShutdownStrategyDrain.ShouldExecute(false))
+	assert.True(t, ShutdownStrategyDrain.ShouldExecute(true))
+}
+
+func TestShutdownStrategy_ShouldTerminate(t *testing.T) {
+	assert.True(t, ShutdownStrategyTerminate.ShouldTerminate(true))
+	assert.True(t, ShutdownStrategyTerminate.ShouldTerminate(false))
+	assert.True(t, ShutdownStrategyStop.ShouldTerminate(false))
+	assert.False(t, ShutdownStrategyStop.ShouldTerminate(true))
+	assert.False(t, ShutdownStrategyDrain.ShouldTerminate(false))
+	assert.False(t, ShutdownStrategyDrain.ShouldTerminate(true))
+	assert.False(t, ShutdownStrategyNone.ShouldTerminate(false))
 }
 
 func TestCronWorkflowConditions(t *testing.T) {
@@ -1681,3 +1707,18 @@ func TestInlineStore(t *testing.T) {
 		})
 	}
 }
+
+func TestArtifactRetentionGetMaxAge(t *testing.T) {
+	for maxAge, expected := range map[string]time.Duration{
+		"":    0,
+		"30d": 30 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+		"60":  time.Minute,
+	} {
+		d, err := (&ArtifactRetention{MaxAge: maxAge}).GetMaxAge()
+		require.NoError(t, err)
+		assert.Equal(t, expected, d, maxAge)
+	}
+	_, err := (&ArtifactRetention{MaxAge: "xd"}).GetMaxAge()
+	require.Error(t, err)
+}
@@ -8,9 +8,10 @@ package v1alpha1
 import (
 	json "encoding/json"
 
-	v1 "k8s.io/api/core/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -109,6 +110,13 @@ func (in *ArtGCStatus) DeepCopyInto(out *ArtGCStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make(map[string]ArtifactGCFailure, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -141,6 +149,28 @@ func (in *Artifact) DeepCopyInto(out *Artifact) {
 		*out = new(ArtifactGC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ArtifactEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicateTo != nil {
+		in, out := &in.ReplicateTo, &out.ReplicateTo
+		*out = make([]ArtifactLocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBandwidth != nil {
+		in, out := &in.MaxBandwidth, &out.MaxBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
@@ -154,6 +184,27 @@ func (in *Artifact) DeepCopy() *Artifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactEncryption) DeepCopyInto(out *ArtifactEncryption) {
+	*out = *in
+	if in.KeySecret != nil {
+		in, out := &in.KeySecret, &out.KeySecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactEncryption.
+func (in *ArtifactEncryption) DeepCopy() *ArtifactEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactGC) DeepCopyInto(out *ArtifactGC) {
 	*out = *in
@@ -162,6 +213,11 @@ func (in *ArtifactGC) DeepCopyInto(out *ArtifactGC) {
 		*out = new(Metadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ArtifactRetention)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -175,6 +231,49 @@ func (in *ArtifactGC) DeepCopy() *ArtifactGC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactGCFailure) DeepCopyInto(out *ArtifactGCFailure) {
+	*out = *in
+	in.LastAttemptAt.DeepCopyInto(&out.LastAttemptAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactGCFailure.
+func (in *ArtifactGCFailure) DeepCopy() *ArtifactGCFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactGCFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactGCRetry) DeepCopyInto(out *ArtifactGCRetry) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactGCRetry.
+func (in *ArtifactGCRetry) DeepCopy() *ArtifactGCRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactGCRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactGCSpec) DeepCopyInto(out *ArtifactGCSpec) {
 	*out = *in
@@ -274,6 +373,31 @@ func (in *ArtifactLocation) DeepCopyInto(out *ArtifactLocation) {
 		*out = new(AzureArtifact)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(SFTPArtifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(FilesystemArtifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.B2 != nil {
+		in, out := &in.B2, &out.B2
+		*out = new(B2Artifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IBMCOS != nil {
+		in, out := &in.IBMCOS, &out.IBMCOS
+		*out = new(IBMCOSArtifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIArtifact)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -287,6 +411,22 @@ func (in *ArtifactLocation) DeepCopy() *ArtifactLocation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactManifestEntry) DeepCopyInto(out *ArtifactManifestEntry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactManifestEntry.
+func (in *ArtifactManifestEntry) DeepCopy() *ArtifactManifestEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactManifestEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactNodeSpec) DeepCopyInto(out *ArtifactNodeSpec) {
 	*out = *in
@@ -332,6 +472,26 @@ func (in *ArtifactPaths) DeepCopy() *ArtifactPaths {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactProgress) DeepCopyInto(out *ArtifactProgress) {
+	*out = *in
+	if in.ETA != nil {
+		in, out := &in.ETA, &out.ETA
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactProgress.
+func (in *ArtifactProgress) DeepCopy() *ArtifactProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactRepository) DeepCopyInto(out *ArtifactRepository) {
 	*out = *in
@@ -370,6 +530,31 @@ func (in *ArtifactRepository) DeepCopyInto(out *ArtifactRepository) {
 		*out = new(AzureArtifactRepository)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ArtifactRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(SFTPArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(FilesystemArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.B2 != nil {
+		in, out := &in.B2, &out.B2
+		*out = new(B2ArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IBMCOS != nil {
+		in, out := &in.IBMCOS, &out.IBMCOS
+		*out = new(IBMCOSArtifactRepository)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -465,6 +650,27 @@ func (in *ArtifactResultNodeStatus) DeepCopy() *ArtifactResultNodeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactRetention) DeepCopyInto(out *ArtifactRetention) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactRetention.
+func (in *ArtifactRetention) DeepCopy() *ArtifactRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactSearchQuery) DeepCopyInto(out *ArtifactSearchQuery) {
 	*out = *in
@@ -578,12 +784,12 @@ func (in *ArtifactoryAuth) DeepCopyInto(out *ArtifactoryAuth) {
 	*out = *in
 	if in.UsernameSecret != nil {
 		in, out := &in.UsernameSecret, &out.UsernameSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PasswordSecret != nil {
 		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -660,7 +866,7 @@ func (in *AzureBlobContainer) DeepCopyInto(out *AzureBlobContainer) {
 	*out = *in
 	if in.AccountKeySecret != nil {
 		in, out := &in.AccountKeySecret, &out.AccountKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -676,6 +882,66 @@ func (in *AzureBlobContainer) DeepCopy() *AzureBlobContainer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *B2Artifact) DeepCopyInto(out *B2Artifact) {
+	*out = *in
+	in.B2Config.DeepCopyInto(&out.B2Config)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new B2Artifact.
+func (in *B2Artifact) DeepCopy() *B2Artifact {
+	if in == nil {
+		return nil
+	}
+	out := new(B2Artifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *B2ArtifactRepository) DeepCopyInto(out *B2ArtifactRepository) {
+	*out = *in
+	in.B2Config.DeepCopyInto(&out.B2Config)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new B2ArtifactRepository.
+func (in *B2ArtifactRepository) DeepCopy() *B2ArtifactRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(B2ArtifactRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *B2Config) DeepCopyInto(out *B2Config) {
+	*out = *in
+	if in.ApplicationKeyIDSecret != nil {
+		in, out := &in.ApplicationKeyIDSecret, &out.ApplicationKeyIDSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationKeySecret != nil {
+		in, out := &in.ApplicationKeySecret, &out.ApplicationKeySecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new B2Config.
+func (in *B2Config) DeepCopy() *B2Config {
+	if in == nil {
+		return nil
+	}
+	out := new(B2Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backoff) DeepCopyInto(out *Backoff) {
 	*out = *in
@@ -684,6 +950,11 @@ func (in *Backoff) DeepCopyInto(out *Backoff) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	return
 }
 
@@ -702,12 +973,12 @@ func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
 	if in.UsernameSecret != nil {
 		in, out := &in.UsernameSecret, &out.UsernameSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PasswordSecret != nil {
 		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -728,7 +999,7 @@ func (in *Cache) DeepCopyInto(out *Cache) {
 	*out = *in
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -744,17 +1015,45 @@ func (in *Cache) DeepCopy() *Cache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Checkpoint) DeepCopyInto(out *Checkpoint) {
+	*out = *in
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make(Artifacts, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Checkpoint.
+func (in *Checkpoint) DeepCopy() *Checkpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Checkpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientCertAuth) DeepCopyInto(out *ClientCertAuth) {
 	*out = *in
 	if in.ClientCertSecret != nil {
 		in, out := &in.ClientCertSecret, &out.ClientCertSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ClientKeySecret != nil {
 		in, out := &in.ClientKeySecret, &out.ClientKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -770,6 +1069,66 @@ func (in *ClientCertAuth) DeepCopy() *ClientCertAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWorkflowDefaults) DeepCopyInto(out *ClusterWorkflowDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWorkflowDefaults.
+func (in *ClusterWorkflowDefaults) DeepCopy() *ClusterWorkflowDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWorkflowDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterWorkflowDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWorkflowDefaultsList) DeepCopyInto(out *ClusterWorkflowDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterWorkflowDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWorkflowDefaultsList.
+func (in *ClusterWorkflowDefaultsList) DeepCopy() *ClusterWorkflowDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWorkflowDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterWorkflowDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterWorkflowTemplate) DeepCopyInto(out *ClusterWorkflowTemplate) {
 	*out = *in
@@ -959,7 +1318,7 @@ func (in *ContainerSetTemplate) DeepCopyInto(out *ContainerSetTemplate) {
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1112,7 +1471,7 @@ func (in *CronWorkflowSpec) DeepCopyInto(out *CronWorkflowSpec) {
 	}
 	if in.WorkflowMetadata != nil {
 		in, out := &in.WorkflowMetadata, &out.WorkflowMetadata
-		*out = new(metav1.ObjectMeta)
+		*out = new(v1.ObjectMeta)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.StopStrategy != nil {
@@ -1143,7 +1502,7 @@ func (in *CronWorkflowStatus) DeepCopyInto(out *CronWorkflowStatus) {
 	*out = *in
 	if in.Active != nil {
 		in, out := &in.Active, &out.Active
-		*out = make([]v1.ObjectReference, len(*in))
+		*out = make([]corev1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.LastScheduledTime != nil {
@@ -1211,6 +1570,18 @@ func (in *DAGTask) DeepCopyInto(out *DAGTask) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.MaxFanout != nil {
+		in, out := &in.MaxFanout, &out.MaxFanout
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1296,15 +1667,48 @@ func (in *DataSource) DeepCopy() *DataSource {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Event) DeepCopyInto(out *Event) {
+func (in *EnvSet) DeepCopyInto(out *EnvSet) {
 	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Event.
-func (in *Event) DeepCopy() *Event {
-	if in == nil {
-		return nil
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.ConfigMapEnvSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretEnvSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvSet.
+func (in *EnvSet) DeepCopy() *EnvSet {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Event) DeepCopyInto(out *Event) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Event.
+func (in *Event) DeepCopy() *Event {
+	if in == nil {
+		return nil
 	}
 	out := new(Event)
 	in.DeepCopyInto(out)
@@ -1327,6 +1731,57 @@ func (in *ExecutorConfig) DeepCopy() *ExecutorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemArtifact) DeepCopyInto(out *FilesystemArtifact) {
+	*out = *in
+	in.FilesystemConfig.DeepCopyInto(&out.FilesystemConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemArtifact.
+func (in *FilesystemArtifact) DeepCopy() *FilesystemArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemArtifactRepository) DeepCopyInto(out *FilesystemArtifactRepository) {
+	*out = *in
+	in.FilesystemConfig.DeepCopyInto(&out.FilesystemConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemArtifactRepository.
+func (in *FilesystemArtifactRepository) DeepCopy() *FilesystemArtifactRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemArtifactRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemConfig) DeepCopyInto(out *FilesystemConfig) {
+	*out = *in
+	in.Volume.DeepCopyInto(&out.Volume)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemConfig.
+func (in *FilesystemConfig) DeepCopy() *FilesystemConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCSArtifact) DeepCopyInto(out *GCSArtifact) {
 	*out = *in
@@ -1366,7 +1821,12 @@ func (in *GCSBucket) DeepCopyInto(out *GCSBucket) {
 	*out = *in
 	if in.ServiceAccountKeySecret != nil {
 		in, out := &in.ServiceAccountKeySecret, &out.ServiceAccountKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalAccountSecret != nil {
+		in, out := &in.ExternalAccountSecret, &out.ExternalAccountSecret
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -1382,6 +1842,23 @@ func (in *GCSBucket) DeepCopy() *GCSBucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GangTemplate) DeepCopyInto(out *GangTemplate) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GangTemplate.
+func (in *GangTemplate) DeepCopy() *GangTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(GangTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Gauge) DeepCopyInto(out *Gauge) {
 	*out = *in
@@ -1418,19 +1895,24 @@ func (in *GitArtifact) DeepCopyInto(out *GitArtifact) {
 	}
 	if in.UsernameSecret != nil {
 		in, out := &in.UsernameSecret, &out.UsernameSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PasswordSecret != nil {
 		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SSHPrivateKeySecret != nil {
 		in, out := &in.SSHPrivateKeySecret, &out.SSHPrivateKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SparsePaths != nil {
+		in, out := &in.SparsePaths, &out.SparsePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1505,17 +1987,17 @@ func (in *HDFSKrbConfig) DeepCopyInto(out *HDFSKrbConfig) {
 	*out = *in
 	if in.KrbCCacheSecret != nil {
 		in, out := &in.KrbCCacheSecret, &out.KrbCCacheSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.KrbKeytabSecret != nil {
 		in, out := &in.KrbKeytabSecret, &out.KrbKeytabSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.KrbConfigConfigMap != nil {
 		in, out := &in.KrbConfigConfigMap, &out.KrbConfigConfigMap
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -1577,6 +2059,16 @@ func (in *HTTPArtifact) DeepCopyInto(out *HTTPArtifact) {
 		*out = new(HTTPAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(HTTPArtifactRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Form != nil {
+		in, out := &in.Form, &out.Form
+		*out = new(HTTPArtifactForm)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1590,6 +2082,55 @@ func (in *HTTPArtifact) DeepCopy() *HTTPArtifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPArtifactForm) DeepCopyInto(out *HTTPArtifactForm) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPArtifactForm.
+func (in *HTTPArtifactForm) DeepCopy() *HTTPArtifactForm {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPArtifactForm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPArtifactRetry) DeepCopyInto(out *HTTPArtifactRetry) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPArtifactRetry.
+func (in *HTTPArtifactRetry) DeepCopy() *HTTPArtifactRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPArtifactRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPAuth) DeepCopyInto(out *HTTPAuth) {
 	*out = *in
@@ -1656,7 +2197,7 @@ func (in *HTTPHeaderSource) DeepCopyInto(out *HTTPHeaderSource) {
 	*out = *in
 	if in.SecretKeyRef != nil {
 		in, out := &in.SecretKeyRef, &out.SecretKeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -1694,6 +2235,37 @@ func (in HTTPHeaders) DeepCopy() HTTPHeaders {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPResultDelivery) DeepCopyInto(out *HTTPResultDelivery) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(HTTPAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(HTTPArtifactRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPResultDelivery.
+func (in *HTTPResultDelivery) DeepCopy() *HTTPResultDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPResultDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Header) DeepCopyInto(out *Header) {
 	*out = *in
@@ -1731,6 +2303,61 @@ func (in *Histogram) DeepCopy() *Histogram {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMCOSArtifact) DeepCopyInto(out *IBMCOSArtifact) {
+	*out = *in
+	in.IBMCOSConfig.DeepCopyInto(&out.IBMCOSConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMCOSArtifact.
+func (in *IBMCOSArtifact) DeepCopy() *IBMCOSArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMCOSArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMCOSArtifactRepository) DeepCopyInto(out *IBMCOSArtifactRepository) {
+	*out = *in
+	in.IBMCOSConfig.DeepCopyInto(&out.IBMCOSConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMCOSArtifactRepository.
+func (in *IBMCOSArtifactRepository) DeepCopy() *IBMCOSArtifactRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMCOSArtifactRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMCOSConfig) DeepCopyInto(out *IBMCOSConfig) {
+	*out = *in
+	if in.APIKeySecret != nil {
+		in, out := &in.APIKeySecret, &out.APIKeySecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMCOSConfig.
+func (in *IBMCOSConfig) DeepCopy() *IBMCOSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMCOSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Inputs) DeepCopyInto(out *Inputs) {
 	*out = *in
@@ -1876,6 +2503,11 @@ func (in LifecycleHooks) DeepCopy() LifecycleHooks {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Link) DeepCopyInto(out *Link) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1889,6 +2521,23 @@ func (in *Link) DeepCopy() *Link {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Liveness) DeepCopyInto(out *Liveness) {
+	*out = *in
+	in.Probe.DeepCopyInto(&out.Probe)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Liveness.
+func (in *Liveness) DeepCopy() *Liveness {
+	if in == nil {
+		return nil
+	}
+	out := new(Liveness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManifestFrom) DeepCopyInto(out *ManifestFrom) {
 	*out = *in
@@ -2078,6 +2727,66 @@ func (in *MutexStatus) DeepCopy() *MutexStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceWorkflowDefaults) DeepCopyInto(out *NamespaceWorkflowDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceWorkflowDefaults.
+func (in *NamespaceWorkflowDefaults) DeepCopy() *NamespaceWorkflowDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceWorkflowDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceWorkflowDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceWorkflowDefaultsList) DeepCopyInto(out *NamespaceWorkflowDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceWorkflowDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceWorkflowDefaultsList.
+func (in *NamespaceWorkflowDefaultsList) DeepCopy() *NamespaceWorkflowDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceWorkflowDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceWorkflowDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeFlag) DeepCopyInto(out *NodeFlag) {
 	*out = *in
@@ -2094,6 +2803,63 @@ func (in *NodeFlag) DeepCopy() *NodeFlag {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePatch) DeepCopyInto(out *NodePatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePatch.
+func (in *NodePatch) DeepCopy() *NodePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeProvenance) DeepCopyInto(out *NodeProvenance) {
+	*out = *in
+	if in.ImageIDs != nil {
+		in, out := &in.ImageIDs, &out.ImageIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnvVarNames != nil {
+		in, out := &in.EnvVarNames, &out.EnvVarNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeProvenance.
+func (in *NodeProvenance) DeepCopy() *NodeProvenance {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeProvenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeResult) DeepCopyInto(out *NodeResult) {
 	*out = *in
@@ -2102,6 +2868,21 @@ func (in *NodeResult) DeepCopyInto(out *NodeResult) {
 		*out = new(Outputs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArtifactProgress != nil {
+		in, out := &in.ArtifactProgress, &out.ArtifactProgress
+		*out = new(ArtifactProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(NodeProvenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TruncatedOutputs != nil {
+		in, out := &in.TruncatedOutputs, &out.TruncatedOutputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2115,6 +2896,37 @@ func (in *NodeResult) DeepCopy() *NodeResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSchedulingLatency) DeepCopyInto(out *NodeSchedulingLatency) {
+	*out = *in
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Initializing != nil {
+		in, out := &in.Initializing, &out.Initializing
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Starting != nil {
+		in, out := &in.Starting, &out.Starting
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSchedulingLatency.
+func (in *NodeSchedulingLatency) DeepCopy() *NodeSchedulingLatency {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSchedulingLatency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
@@ -2125,6 +2937,11 @@ func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	}
 	in.StartedAt.DeepCopyInto(&out.StartedAt)
 	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+	if in.ArtifactProgress != nil {
+		in, out := &in.ArtifactProgress, &out.ArtifactProgress
+		*out = new(ArtifactProgress)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ResourcesDuration != nil {
 		in, out := &in.ResourcesDuration, &out.ResourcesDuration
 		*out = make(ResourcesDuration, len(*in))
@@ -2142,6 +2959,16 @@ func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 		*out = new(NodeFlag)
 		**out = **in
 	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(NodeProvenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchedulingLatency != nil {
+		in, out := &in.SchedulingLatency, &out.SchedulingLatency
+		*out = new(NodeSchedulingLatency)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Inputs != nil {
 		in, out := &in.Inputs, &out.Inputs
 		*out = new(Inputs)
@@ -2244,17 +3071,17 @@ func (in *OAuth2Auth) DeepCopyInto(out *OAuth2Auth) {
 	*out = *in
 	if in.ClientIDSecret != nil {
 		in, out := &in.ClientIDSecret, &out.ClientIDSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ClientSecretSecret != nil {
 		in, out := &in.ClientSecretSecret, &out.ClientSecretSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.TokenURLSecret != nil {
 		in, out := &in.TokenURLSecret, &out.TokenURLSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Scopes != nil {
@@ -2296,6 +3123,32 @@ func (in *OAuth2EndpointParam) DeepCopy() *OAuth2EndpointParam {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
+	*out = *in
+	if in.UsernameSecret != nil {
+		in, out := &in.UsernameSecret, &out.UsernameSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIArtifact.
+func (in *OCIArtifact) DeepCopy() *OCIArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OSSArtifact) DeepCopyInto(out *OSSArtifact) {
 	*out = *in
@@ -2335,12 +3188,12 @@ func (in *OSSBucket) DeepCopyInto(out *OSSBucket) {
 	*out = *in
 	if in.AccessKeySecret != nil {
 		in, out := &in.AccessKeySecret, &out.AccessKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SecretKeySecret != nil {
 		in, out := &in.SecretKeySecret, &out.SecretKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.LifecycleRule != nil {
@@ -2398,6 +3251,37 @@ func (in *Object) DeepCopy() *Object {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputLimits) DeepCopyInto(out *OutputLimits) {
+	*out = *in
+	if in.MaxResultBytes != nil {
+		in, out := &in.MaxResultBytes, &out.MaxResultBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxParameterBytes != nil {
+		in, out := &in.MaxParameterBytes, &out.MaxParameterBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxLogBytes != nil {
+		in, out := &in.MaxLogBytes, &out.MaxLogBytes
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputLimits.
+func (in *OutputLimits) DeepCopy() *OutputLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Outputs) DeepCopyInto(out *Outputs) {
 	*out = *in
@@ -2524,7 +3408,7 @@ func (in *PodGC) DeepCopyInto(out *PodGC) {
 	*out = *in
 	if in.LabelSelector != nil {
 		in, out := &in.LabelSelector, &out.LabelSelector
-		*out = new(metav1.LabelSelector)
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -2598,6 +3482,29 @@ func (in *RawArtifact) DeepCopy() *RawArtifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceBudget) DeepCopyInto(out *ResourceBudget) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(ResourcesDuration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBudget.
+func (in *ResourceBudget) DeepCopy() *ResourceBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceTemplate) DeepCopyInto(out *ResourceTemplate) {
 	*out = *in
@@ -2636,14 +3543,35 @@ func (in ResourcesDuration) DeepCopyInto(out *ResourcesDuration) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcesDuration.
-func (in ResourcesDuration) DeepCopy() ResourcesDuration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcesDuration.
+func (in ResourcesDuration) DeepCopy() ResourcesDuration {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcesDuration)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultDelivery) DeepCopyInto(out *ResultDelivery) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPResultDelivery)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultDelivery.
+func (in *ResultDelivery) DeepCopy() *ResultDelivery {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourcesDuration)
+	out := new(ResultDelivery)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -2718,6 +3646,11 @@ func (in *RetryStrategy) DeepCopy() *RetryStrategy {
 func (in *S3Artifact) DeepCopyInto(out *S3Artifact) {
 	*out = *in
 	in.S3Bucket.DeepCopyInto(&out.S3Bucket)
+	if in.Select != nil {
+		in, out := &in.Select, &out.Select
+		*out = new(S3Select)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2758,17 +3691,17 @@ func (in *S3Bucket) DeepCopyInto(out *S3Bucket) {
 	}
 	if in.AccessKeySecret != nil {
 		in, out := &in.AccessKeySecret, &out.AccessKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SecretKeySecret != nil {
 		in, out := &in.SecretKeySecret, &out.SecretKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SessionTokenSecret != nil {
 		in, out := &in.SessionTokenSecret, &out.SessionTokenSecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CreateBucketIfNotPresent != nil {
@@ -2783,7 +3716,7 @@ func (in *S3Bucket) DeepCopyInto(out *S3Bucket) {
 	}
 	if in.CASecret != nil {
 		in, out := &in.CASecret, &out.CASecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -2804,7 +3737,7 @@ func (in *S3EncryptionOptions) DeepCopyInto(out *S3EncryptionOptions) {
 	*out = *in
 	if in.ServerSideCustomerKeySecret != nil {
 		in, out := &in.ServerSideCustomerKeySecret, &out.ServerSideCustomerKeySecret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -2820,6 +3753,225 @@ func (in *S3EncryptionOptions) DeepCopy() *S3EncryptionOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Select) DeepCopyInto(out *S3Select) {
+	*out = *in
+	in.Input.DeepCopyInto(&out.Input)
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(S3SelectOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Select.
+func (in *S3Select) DeepCopy() *S3Select {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Select)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3SelectCSV) DeepCopyInto(out *S3SelectCSV) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3SelectCSV.
+func (in *S3SelectCSV) DeepCopy() *S3SelectCSV {
+	if in == nil {
+		return nil
+	}
+	out := new(S3SelectCSV)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3SelectInput) DeepCopyInto(out *S3SelectInput) {
+	*out = *in
+	if in.CSV != nil {
+		in, out := &in.CSV, &out.CSV
+		*out = new(S3SelectCSV)
+		**out = **in
+	}
+	if in.JSON != nil {
+		in, out := &in.JSON, &out.JSON
+		*out = new(S3SelectJSON)
+		**out = **in
+	}
+	if in.Parquet != nil {
+		in, out := &in.Parquet, &out.Parquet
+		*out = new(S3SelectParquet)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3SelectInput.
+func (in *S3SelectInput) DeepCopy() *S3SelectInput {
+	if in == nil {
+		return nil
+	}
+	out := new(S3SelectInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3SelectJSON) DeepCopyInto(out *S3SelectJSON) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3SelectJSON.
+func (in *S3SelectJSON) DeepCopy() *S3SelectJSON {
+	if in == nil {
+		return nil
+	}
+	out := new(S3SelectJSON)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3SelectOutput) DeepCopyInto(out *S3SelectOutput) {
+	*out = *in
+	if in.CSV != nil {
+		in, out := &in.CSV, &out.CSV
+		*out = new(S3SelectCSV)
+		**out = **in
+	}
+	if in.JSON != nil {
+		in, out := &in.JSON, &out.JSON
+		*out = new(S3SelectJSON)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3SelectOutput.
+func (in *S3SelectOutput) DeepCopy() *S3SelectOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(S3SelectOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3SelectParquet) DeepCopyInto(out *S3SelectParquet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3SelectParquet.
+func (in *S3SelectParquet) DeepCopy() *S3SelectParquet {
+	if in == nil {
+		return nil
+	}
+	out := new(S3SelectParquet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SFTPArtifact) DeepCopyInto(out *SFTPArtifact) {
+	*out = *in
+	in.SFTPConfig.DeepCopyInto(&out.SFTPConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SFTPArtifact.
+func (in *SFTPArtifact) DeepCopy() *SFTPArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(SFTPArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SFTPArtifactRepository) DeepCopyInto(out *SFTPArtifactRepository) {
+	*out = *in
+	in.SFTPConfig.DeepCopyInto(&out.SFTPConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SFTPArtifactRepository.
+func (in *SFTPArtifactRepository) DeepCopy() *SFTPArtifactRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(SFTPArtifactRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SFTPConfig) DeepCopyInto(out *SFTPConfig) {
+	*out = *in
+	if in.UsernameSecret != nil {
+		in, out := &in.UsernameSecret, &out.UsernameSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateKeySecret != nil {
+		in, out := &in.PrivateKeySecret, &out.PrivateKeySecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KnownHostsConfigMap != nil {
+		in, out := &in.KnownHostsConfigMap, &out.KnownHostsConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SFTPConfig.
+func (in *SFTPConfig) DeepCopy() *SFTPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SFTPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingWindow) DeepCopyInto(out *SchedulingWindow) {
+	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingWindow.
+func (in *SchedulingWindow) DeepCopy() *SchedulingWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptTemplate) DeepCopyInto(out *ScriptTemplate) {
 	*out = *in
@@ -2863,7 +4015,7 @@ func (in *SemaphoreRef) DeepCopyInto(out *SemaphoreRef) {
 	*out = *in
 	if in.ConfigMapKeyRef != nil {
 		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Database != nil {
@@ -2994,7 +4146,7 @@ func (in *SubmitOpts) DeepCopyInto(out *SubmitOpts) {
 	}
 	if in.OwnerReference != nil {
 		in, out := &in.OwnerReference, &out.OwnerReference
-		*out = new(metav1.OwnerReference)
+		*out = new(v1.OwnerReference)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Priority != nil {
@@ -3031,9 +4183,30 @@ func (in *SuppliedValueFrom) DeepCopy() *SuppliedValueFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuspendEvent) DeepCopyInto(out *SuspendEvent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuspendEvent.
+func (in *SuspendEvent) DeepCopy() *SuspendEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(SuspendEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuspendTemplate) DeepCopyInto(out *SuspendTemplate) {
 	*out = *in
+	if in.Event != nil {
+		in, out := &in.Event, &out.Event
+		*out = new(SuspendEvent)
+		**out = **in
+	}
 	return
 }
 
@@ -3203,7 +4376,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	in.Metadata.DeepCopyInto(&out.Metadata)
@@ -3221,7 +4394,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.Container != nil {
 		in, out := &in.Container, &out.Container
-		*out = new(v1.Container)
+		*out = new(corev1.Container)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ContainerSet != nil {
@@ -3247,7 +4420,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	if in.Suspend != nil {
 		in, out := &in.Suspend, &out.Suspend
 		*out = new(SuspendTemplate)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Data != nil {
 		in, out := &in.Data, &out.Data
@@ -3264,9 +4437,19 @@ func (in *Template) DeepCopyInto(out *Template) {
 		*out = new(Plugin)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JobTemplate != nil {
+		in, out := &in.JobTemplate, &out.JobTemplate
+		*out = new(batchv1.JobTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gang != nil {
+		in, out := &in.Gang, &out.Gang
+		*out = new(GangTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3312,7 +4495,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3329,14 +4512,14 @@ func (in *Template) DeepCopyInto(out *Template) {
 	}
 	if in.HostAliases != nil {
 		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]v1.HostAlias, len(*in))
+		*out = make([]corev1.HostAlias, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Metrics != nil {
@@ -3361,6 +4544,28 @@ func (in *Template) DeepCopyInto(out *Template) {
 			(*out)[key] = val
 		}
 	}
+	if in.EnvSets != nil {
+		in, out := &in.EnvSets, &out.EnvSets
+		*out = make([]EnvSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(Liveness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutputLimits != nil {
+		in, out := &in.OutputLimits, &out.OutputLimits
+		*out = new(OutputLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		*out = new(Checkpoint)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3458,7 +4663,7 @@ func (in *ValueFrom) DeepCopyInto(out *ValueFrom) {
 	}
 	if in.ConfigMapKeyRef != nil {
 		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Default != nil {
@@ -3600,6 +4805,37 @@ func (in *WorkflowArtifactGCTaskList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowDefaultsSpec) DeepCopyInto(out *WorkflowDefaultsSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.WorkflowSpec.DeepCopyInto(&out.WorkflowSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowDefaultsSpec.
+func (in *WorkflowDefaultsSpec) DeepCopy() *WorkflowDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowEventBinding) DeepCopyInto(out *WorkflowEventBinding) {
 	*out = *in
@@ -3686,6 +4922,11 @@ func (in *WorkflowEventBindingSpec) DeepCopy() *WorkflowEventBindingSpec {
 func (in *WorkflowLevelArtifactGC) DeepCopyInto(out *WorkflowLevelArtifactGC) {
 	*out = *in
 	in.ArtifactGC.DeepCopyInto(&out.ArtifactGC)
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ArtifactGCRetry)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3756,6 +4997,13 @@ func (in *WorkflowMetadata) DeepCopyInto(out *WorkflowMetadata) {
 			(*out)[key] = val
 		}
 	}
+	if in.AnnotationsFrom != nil {
+		in, out := &in.AnnotationsFrom, &out.AnnotationsFrom
+		*out = make(map[string]LabelValueFrom, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -3769,6 +5017,22 @@ func (in *WorkflowMetadata) DeepCopy() *WorkflowMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowOwner) DeepCopyInto(out *WorkflowOwner) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowOwner.
+func (in *WorkflowOwner) DeepCopy() *WorkflowOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	*out = *in
@@ -3792,14 +5056,14 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeClaimTemplates != nil {
 		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3828,19 +5092,19 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.HostNetwork != nil {
@@ -3850,12 +5114,12 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.DNSPolicy != nil {
 		in, out := &in.DNSPolicy, &out.DNSPolicy
-		*out = new(v1.DNSPolicy)
+		*out = new(corev1.DNSPolicy)
 		**out = **in
 	}
 	if in.DNSConfig != nil {
 		in, out := &in.DNSConfig, &out.DNSConfig
-		*out = new(v1.PodDNSConfig)
+		*out = new(corev1.PodDNSConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.TTLStrategy != nil {
@@ -3868,6 +5132,21 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.PendingTimeout != nil {
+		in, out := &in.PendingTimeout, &out.PendingTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ResourceBudget != nil {
+		in, out := &in.ResourceBudget, &out.ResourceBudget
+		*out = new(ResourceBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchedulingWindow != nil {
+		in, out := &in.SchedulingWindow, &out.SchedulingWindow
+		*out = new(SchedulingWindow)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Priority != nil {
 		in, out := &in.Priority, &out.Priority
 		*out = new(int32)
@@ -3880,14 +5159,14 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	}
 	if in.HostAliases != nil {
 		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]v1.HostAlias, len(*in))
+		*out = make([]corev1.HostAlias, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodDisruptionBudget != nil {
@@ -3952,6 +5231,16 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 		*out = new(WorkflowLevelArtifactGC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(WorkflowOwner)
+		**out = **in
+	}
+	if in.ResultDelivery != nil {
+		in, out := &in.ResultDelivery, &out.ResultDelivery
+		*out = new(ResultDelivery)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3986,7 +5275,7 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 	}
 	if in.PersistentVolumeClaims != nil {
 		in, out := &in.PersistentVolumeClaims, &out.PersistentVolumeClaims
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4035,6 +5324,18 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.NodePatches != nil {
+		in, out := &in.NodePatches, &out.NodePatches
+		*out = make(map[string]NodePatch, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ArtifactManifest != nil {
+		in, out := &in.ArtifactManifest, &out.ArtifactManifest
+		*out = make([]ArtifactManifestEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
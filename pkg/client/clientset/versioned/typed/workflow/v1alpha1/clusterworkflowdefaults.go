@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	scheme "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterWorkflowDefaultsesGetter has a method to return a ClusterWorkflowDefaultsInterface.
+// A group's client should implement this interface.
+type ClusterWorkflowDefaultsesGetter interface {
+	ClusterWorkflowDefaultses() ClusterWorkflowDefaultsInterface
+}
+
+// ClusterWorkflowDefaultsInterface has methods to work with ClusterWorkflowDefaults resources.
+type ClusterWorkflowDefaultsInterface interface {
+	Create(ctx context.Context, clusterWorkflowDefaults *v1alpha1.ClusterWorkflowDefaults, opts v1.CreateOptions) (*v1alpha1.ClusterWorkflowDefaults, error)
+	Update(ctx context.Context, clusterWorkflowDefaults *v1alpha1.ClusterWorkflowDefaults, opts v1.UpdateOptions) (*v1alpha1.ClusterWorkflowDefaults, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ClusterWorkflowDefaults, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterWorkflowDefaultsList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterWorkflowDefaults, err error)
+	ClusterWorkflowDefaultsExpansion
+}
+
+// clusterWorkflowDefaultses implements ClusterWorkflowDefaultsInterface
+type clusterWorkflowDefaultses struct {
+	client rest.Interface
+}
+
+// newClusterWorkflowDefaultses returns a ClusterWorkflowDefaultses
+func newClusterWorkflowDefaultses(c *ArgoprojV1alpha1Client) *clusterWorkflowDefaultses {
+	return &clusterWorkflowDefaultses{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterWorkflowDefaults, and returns the corresponding clusterWorkflowDefaults object, and an error if there is any.
+func (c *clusterWorkflowDefaultses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterWorkflowDefaults, err error) {
+	result = &v1alpha1.ClusterWorkflowDefaults{}
+	err = c.client.Get().
+		Resource("clusterworkflowdefaults").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterWorkflowDefaultses that match those selectors.
+func (c *clusterWorkflowDefaultses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterWorkflowDefaultsList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterWorkflowDefaultsList{}
+	err = c.client.Get().
+		Resource("clusterworkflowdefaults").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterWorkflowDefaultses.
+func (c *clusterWorkflowDefaultses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterworkflowdefaults").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterWorkflowDefaults and creates it.  Returns the server's representation of the clusterWorkflowDefaults, and an error, if there is any.
+func (c *clusterWorkflowDefaultses) Create(ctx context.Context, clusterWorkflowDefaults *v1alpha1.ClusterWorkflowDefaults, opts v1.CreateOptions) (result *v1alpha1.ClusterWorkflowDefaults, err error) {
+	result = &v1alpha1.ClusterWorkflowDefaults{}
+	err = c.client.Post().
+		Resource("clusterworkflowdefaults").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterWorkflowDefaults).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterWorkflowDefaults and updates it. Returns the server's representation of the clusterWorkflowDefaults, and an error, if there is any.
+func (c *clusterWorkflowDefaultses) Update(ctx context.Context, clusterWorkflowDefaults *v1alpha1.ClusterWorkflowDefaults, opts v1.UpdateOptions) (result *v1alpha1.ClusterWorkflowDefaults, err error) {
+	result = &v1alpha1.ClusterWorkflowDefaults{}
+	err = c.client.Put().
+		Resource("clusterworkflowdefaults").
+		Name(clusterWorkflowDefaults.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterWorkflowDefaults).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterWorkflowDefaults and deletes it. Returns an error if one occurs.
+func (c *clusterWorkflowDefaultses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterworkflowdefaults").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterWorkflowDefaultses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterworkflowdefaults").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterWorkflowDefaults.
+func (c *clusterWorkflowDefaultses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterWorkflowDefaults, err error) {
+	result = &v1alpha1.ClusterWorkflowDefaults{}
+	err = c.client.Patch(pt).
+		Resource("clusterworkflowdefaults").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
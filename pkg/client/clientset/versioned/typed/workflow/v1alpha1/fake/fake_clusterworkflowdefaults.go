@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterWorkflowDefaultses implements ClusterWorkflowDefaultsInterface
+type FakeClusterWorkflowDefaultses struct {
+	Fake *FakeArgoprojV1alpha1
+}
+
+var clusterworkflowdefaultsesResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "clusterworkflowdefaults"}
+
+var clusterworkflowdefaultsesKind = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "ClusterWorkflowDefaults"}
+
+// Get takes name of the clusterWorkflowDefaults, and returns the corresponding clusterWorkflowDefaults object, and an error if there is any.
+func (c *FakeClusterWorkflowDefaultses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterWorkflowDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterworkflowdefaultsesResource, name), &v1alpha1.ClusterWorkflowDefaults{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowDefaults), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterWorkflowDefaultses that match those selectors.
+func (c *FakeClusterWorkflowDefaultses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterWorkflowDefaultsList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterworkflowdefaultsesResource, clusterworkflowdefaultsesKind, opts), &v1alpha1.ClusterWorkflowDefaultsList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterWorkflowDefaultsList{ListMeta: obj.(*v1alpha1.ClusterWorkflowDefaultsList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterWorkflowDefaultsList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterWorkflowDefaultses.
+func (c *FakeClusterWorkflowDefaultses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterworkflowdefaultsesResource, opts))
+}
+
+// Create takes the representation of a clusterWorkflowDefaults and creates it.  Returns the server's representation of the clusterWorkflowDefaults, and an error, if there is any.
+func (c *FakeClusterWorkflowDefaultses) Create(ctx context.Context, clusterWorkflowDefaults *v1alpha1.ClusterWorkflowDefaults, opts v1.CreateOptions) (result *v1alpha1.ClusterWorkflowDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterworkflowdefaultsesResource, clusterWorkflowDefaults), &v1alpha1.ClusterWorkflowDefaults{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowDefaults), err
+}
+
+// Update takes the representation of a clusterWorkflowDefaults and updates it. Returns the server's representation of the clusterWorkflowDefaults, and an error, if there is any.
+func (c *FakeClusterWorkflowDefaultses) Update(ctx context.Context, clusterWorkflowDefaults *v1alpha1.ClusterWorkflowDefaults, opts v1.UpdateOptions) (result *v1alpha1.ClusterWorkflowDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterworkflowdefaultsesResource, clusterWorkflowDefaults), &v1alpha1.ClusterWorkflowDefaults{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowDefaults), err
+}
+
+// Delete takes name of the clusterWorkflowDefaults and deletes it. Returns an error if one occurs.
+func (c *FakeClusterWorkflowDefaultses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterworkflowdefaultsesResource, name), &v1alpha1.ClusterWorkflowDefaults{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterWorkflowDefaultses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterworkflowdefaultsesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterWorkflowDefaultsList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterWorkflowDefaults.
+func (c *FakeClusterWorkflowDefaultses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterWorkflowDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterworkflowdefaultsesResource, name, pt, data, subresources...), &v1alpha1.ClusterWorkflowDefaults{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowDefaults), err
+}
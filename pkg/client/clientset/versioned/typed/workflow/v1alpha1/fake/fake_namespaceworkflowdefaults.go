@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeNamespaceWorkflowDefaultses implements NamespaceWorkflowDefaultsInterface
+type FakeNamespaceWorkflowDefaultses struct {
+	Fake *FakeArgoprojV1alpha1
+	ns   string
+}
+
+var namespaceworkflowdefaultsesResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "namespaceworkflowdefaults"}
+
+var namespaceworkflowdefaultsesKind = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "NamespaceWorkflowDefaults"}
+
+// Get takes name of the namespaceWorkflowDefaults, and returns the corresponding namespaceWorkflowDefaults object, and an error if there is any.
+func (c *FakeNamespaceWorkflowDefaultses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NamespaceWorkflowDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(namespaceworkflowdefaultsesResource, c.ns, name), &v1alpha1.NamespaceWorkflowDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespaceWorkflowDefaults), err
+}
+
+// List takes label and field selectors, and returns the list of NamespaceWorkflowDefaultses that match those selectors.
+func (c *FakeNamespaceWorkflowDefaultses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NamespaceWorkflowDefaultsList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(namespaceworkflowdefaultsesResource, namespaceworkflowdefaultsesKind, c.ns, opts), &v1alpha1.NamespaceWorkflowDefaultsList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.NamespaceWorkflowDefaultsList{ListMeta: obj.(*v1alpha1.NamespaceWorkflowDefaultsList).ListMeta}
+	for _, item := range obj.(*v1alpha1.NamespaceWorkflowDefaultsList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested namespaceWorkflowDefaultses.
+func (c *FakeNamespaceWorkflowDefaultses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(namespaceworkflowdefaultsesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a namespaceWorkflowDefaults and creates it.  Returns the server's representation of the namespaceWorkflowDefaults, and an error, if there is any.
+func (c *FakeNamespaceWorkflowDefaultses) Create(ctx context.Context, namespaceWorkflowDefaults *v1alpha1.NamespaceWorkflowDefaults, opts v1.CreateOptions) (result *v1alpha1.NamespaceWorkflowDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(namespaceworkflowdefaultsesResource, c.ns, namespaceWorkflowDefaults), &v1alpha1.NamespaceWorkflowDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespaceWorkflowDefaults), err
+}
+
+// Update takes the representation of a namespaceWorkflowDefaults and updates it. Returns the server's representation of the namespaceWorkflowDefaults, and an error, if there is any.
+func (c *FakeNamespaceWorkflowDefaultses) Update(ctx context.Context, namespaceWorkflowDefaults *v1alpha1.NamespaceWorkflowDefaults, opts v1.UpdateOptions) (result *v1alpha1.NamespaceWorkflowDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(namespaceworkflowdefaultsesResource, c.ns, namespaceWorkflowDefaults), &v1alpha1.NamespaceWorkflowDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespaceWorkflowDefaults), err
+}
+
+// Delete takes name of the namespaceWorkflowDefaults and deletes it. Returns an error if one occurs.
+func (c *FakeNamespaceWorkflowDefaultses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(namespaceworkflowdefaultsesResource, c.ns, name), &v1alpha1.NamespaceWorkflowDefaults{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNamespaceWorkflowDefaultses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(namespaceworkflowdefaultsesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.NamespaceWorkflowDefaultsList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched namespaceWorkflowDefaults.
+func (c *FakeNamespaceWorkflowDefaultses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespaceWorkflowDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(namespaceworkflowdefaultsesResource, c.ns, name, pt, data, subresources...), &v1alpha1.NamespaceWorkflowDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.NamespaceWorkflowDefaults), err
+}
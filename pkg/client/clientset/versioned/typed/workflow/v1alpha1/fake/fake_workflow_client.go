@@ -12,6 +12,10 @@ type FakeArgoprojV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeArgoprojV1alpha1) ClusterWorkflowDefaultses() v1alpha1.ClusterWorkflowDefaultsInterface {
+	return &FakeClusterWorkflowDefaultses{c}
+}
+
 func (c *FakeArgoprojV1alpha1) ClusterWorkflowTemplates() v1alpha1.ClusterWorkflowTemplateInterface {
 	return &FakeClusterWorkflowTemplates{c}
 }
@@ -20,6 +24,10 @@ func (c *FakeArgoprojV1alpha1) CronWorkflows(namespace string) v1alpha1.CronWork
 	return &FakeCronWorkflows{c, namespace}
 }
 
+func (c *FakeArgoprojV1alpha1) NamespaceWorkflowDefaultses(namespace string) v1alpha1.NamespaceWorkflowDefaultsInterface {
+	return &FakeNamespaceWorkflowDefaultses{c, namespace}
+}
+
 func (c *FakeArgoprojV1alpha1) Workflows(namespace string) v1alpha1.WorkflowInterface {
 	return &FakeWorkflows{c, namespace}
 }
@@ -2,10 +2,14 @@
 
 package v1alpha1
 
+type ClusterWorkflowDefaultsExpansion interface{}
+
 type ClusterWorkflowTemplateExpansion interface{}
 
 type CronWorkflowExpansion interface{}
 
+type NamespaceWorkflowDefaultsExpansion interface{}
+
 type WorkflowExpansion interface{}
 
 type WorkflowArtifactGCTaskExpansion interface{}
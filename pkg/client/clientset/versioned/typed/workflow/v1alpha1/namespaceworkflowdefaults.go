@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	scheme "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// NamespaceWorkflowDefaultsesGetter has a method to return a NamespaceWorkflowDefaultsInterface.
+// A group's client should implement this interface.
+type NamespaceWorkflowDefaultsesGetter interface {
+	NamespaceWorkflowDefaultses(namespace string) NamespaceWorkflowDefaultsInterface
+}
+
+// NamespaceWorkflowDefaultsInterface has methods to work with NamespaceWorkflowDefaults resources.
+type NamespaceWorkflowDefaultsInterface interface {
+	Create(ctx context.Context, namespaceWorkflowDefaults *v1alpha1.NamespaceWorkflowDefaults, opts v1.CreateOptions) (*v1alpha1.NamespaceWorkflowDefaults, error)
+	Update(ctx context.Context, namespaceWorkflowDefaults *v1alpha1.NamespaceWorkflowDefaults, opts v1.UpdateOptions) (*v1alpha1.NamespaceWorkflowDefaults, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.NamespaceWorkflowDefaults, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.NamespaceWorkflowDefaultsList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespaceWorkflowDefaults, err error)
+	NamespaceWorkflowDefaultsExpansion
+}
+
+// namespaceWorkflowDefaultses implements NamespaceWorkflowDefaultsInterface
+type namespaceWorkflowDefaultses struct {
+	client rest.Interface
+	ns     string
+}
+
+// newNamespaceWorkflowDefaultses returns a NamespaceWorkflowDefaultses
+func newNamespaceWorkflowDefaultses(c *ArgoprojV1alpha1Client, namespace string) *namespaceWorkflowDefaultses {
+	return &namespaceWorkflowDefaultses{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the namespaceWorkflowDefaults, and returns the corresponding namespaceWorkflowDefaults object, and an error if there is any.
+func (c *namespaceWorkflowDefaultses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NamespaceWorkflowDefaults, err error) {
+	result = &v1alpha1.NamespaceWorkflowDefaults{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("namespaceworkflowdefaults").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of NamespaceWorkflowDefaultses that match those selectors.
+func (c *namespaceWorkflowDefaultses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NamespaceWorkflowDefaultsList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.NamespaceWorkflowDefaultsList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("namespaceworkflowdefaults").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested namespaceWorkflowDefaultses.
+func (c *namespaceWorkflowDefaultses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("namespaceworkflowdefaults").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a namespaceWorkflowDefaults and creates it.  Returns the server's representation of the namespaceWorkflowDefaults, and an error, if there is any.
+func (c *namespaceWorkflowDefaultses) Create(ctx context.Context, namespaceWorkflowDefaults *v1alpha1.NamespaceWorkflowDefaults, opts v1.CreateOptions) (result *v1alpha1.NamespaceWorkflowDefaults, err error) {
+	result = &v1alpha1.NamespaceWorkflowDefaults{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("namespaceworkflowdefaults").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespaceWorkflowDefaults).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a namespaceWorkflowDefaults and updates it. Returns the server's representation of the namespaceWorkflowDefaults, and an error, if there is any.
+func (c *namespaceWorkflowDefaultses) Update(ctx context.Context, namespaceWorkflowDefaults *v1alpha1.NamespaceWorkflowDefaults, opts v1.UpdateOptions) (result *v1alpha1.NamespaceWorkflowDefaults, err error) {
+	result = &v1alpha1.NamespaceWorkflowDefaults{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("namespaceworkflowdefaults").
+		Name(namespaceWorkflowDefaults.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespaceWorkflowDefaults).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the namespaceWorkflowDefaults and deletes it. Returns an error if one occurs.
+func (c *namespaceWorkflowDefaultses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("namespaceworkflowdefaults").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *namespaceWorkflowDefaultses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("namespaceworkflowdefaults").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched namespaceWorkflowDefaults.
+func (c *namespaceWorkflowDefaultses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NamespaceWorkflowDefaults, err error) {
+	result = &v1alpha1.NamespaceWorkflowDefaults{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("namespaceworkflowdefaults").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
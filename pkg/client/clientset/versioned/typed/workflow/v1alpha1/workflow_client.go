@@ -10,8 +10,10 @@ import (
 
 type ArgoprojV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterWorkflowDefaultsesGetter
 	ClusterWorkflowTemplatesGetter
 	CronWorkflowsGetter
+	NamespaceWorkflowDefaultsesGetter
 	WorkflowsGetter
 	WorkflowArtifactGCTasksGetter
 	WorkflowEventBindingsGetter
@@ -25,6 +27,10 @@ type ArgoprojV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ArgoprojV1alpha1Client) ClusterWorkflowDefaultses() ClusterWorkflowDefaultsInterface {
+	return newClusterWorkflowDefaultses(c)
+}
+
 func (c *ArgoprojV1alpha1Client) ClusterWorkflowTemplates() ClusterWorkflowTemplateInterface {
 	return newClusterWorkflowTemplates(c)
 }
@@ -33,6 +39,10 @@ func (c *ArgoprojV1alpha1Client) CronWorkflows(namespace string) CronWorkflowInt
 	return newCronWorkflows(c, namespace)
 }
 
+func (c *ArgoprojV1alpha1Client) NamespaceWorkflowDefaultses(namespace string) NamespaceWorkflowDefaultsInterface {
+	return newNamespaceWorkflowDefaultses(c, namespace)
+}
+
 func (c *ArgoprojV1alpha1Client) Workflows(namespace string) WorkflowInterface {
 	return newWorkflows(c, namespace)
 }
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	workflowv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	versioned "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/client/listers/workflow/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterWorkflowDefaultsInformer provides access to a shared informer and lister for
+// ClusterWorkflowDefaultses.
+type ClusterWorkflowDefaultsInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ClusterWorkflowDefaultsLister
+}
+
+type clusterWorkflowDefaultsInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterWorkflowDefaultsInformer constructs a new informer for ClusterWorkflowDefaults type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterWorkflowDefaultsInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterWorkflowDefaultsInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterWorkflowDefaultsInformer constructs a new informer for ClusterWorkflowDefaults type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterWorkflowDefaultsInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ArgoprojV1alpha1().ClusterWorkflowDefaultses().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ArgoprojV1alpha1().ClusterWorkflowDefaultses().Watch(context.TODO(), options)
+			},
+		},
+		&workflowv1alpha1.ClusterWorkflowDefaults{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterWorkflowDefaultsInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterWorkflowDefaultsInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterWorkflowDefaultsInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&workflowv1alpha1.ClusterWorkflowDefaults{}, f.defaultInformer)
+}
+
+func (f *clusterWorkflowDefaultsInformer) Lister() v1alpha1.ClusterWorkflowDefaultsLister {
+	return v1alpha1.NewClusterWorkflowDefaultsLister(f.Informer().GetIndexer())
+}
@@ -8,10 +8,14 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterWorkflowDefaultses returns a ClusterWorkflowDefaultsInformer.
+	ClusterWorkflowDefaultses() ClusterWorkflowDefaultsInformer
 	// ClusterWorkflowTemplates returns a ClusterWorkflowTemplateInformer.
 	ClusterWorkflowTemplates() ClusterWorkflowTemplateInformer
 	// CronWorkflows returns a CronWorkflowInformer.
 	CronWorkflows() CronWorkflowInformer
+	// NamespaceWorkflowDefaultses returns a NamespaceWorkflowDefaultsInformer.
+	NamespaceWorkflowDefaultses() NamespaceWorkflowDefaultsInformer
 	// Workflows returns a WorkflowInformer.
 	Workflows() WorkflowInformer
 	// WorkflowArtifactGCTasks returns a WorkflowArtifactGCTaskInformer.
@@ -37,6 +41,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterWorkflowDefaultses returns a ClusterWorkflowDefaultsInformer.
+func (v *version) ClusterWorkflowDefaultses() ClusterWorkflowDefaultsInformer {
+	return &clusterWorkflowDefaultsInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterWorkflowTemplates returns a ClusterWorkflowTemplateInformer.
 func (v *version) ClusterWorkflowTemplates() ClusterWorkflowTemplateInformer {
 	return &clusterWorkflowTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -47,6 +56,11 @@ func (v *version) CronWorkflows() CronWorkflowInformer {
 	return &cronWorkflowInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// NamespaceWorkflowDefaultses returns a NamespaceWorkflowDefaultsInformer.
+func (v *version) NamespaceWorkflowDefaultses() NamespaceWorkflowDefaultsInformer {
+	return &namespaceWorkflowDefaultsInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Workflows returns a WorkflowInformer.
 func (v *version) Workflows() WorkflowInformer {
 	return &workflowInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
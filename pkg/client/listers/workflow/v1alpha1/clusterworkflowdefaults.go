@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterWorkflowDefaultsLister helps list ClusterWorkflowDefaultses.
+// All objects returned here must be treated as read-only.
+type ClusterWorkflowDefaultsLister interface {
+	// List lists all ClusterWorkflowDefaultses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ClusterWorkflowDefaults, err error)
+	// Get retrieves the ClusterWorkflowDefaults from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ClusterWorkflowDefaults, error)
+	ClusterWorkflowDefaultsListerExpansion
+}
+
+// clusterWorkflowDefaultsLister implements the ClusterWorkflowDefaultsLister interface.
+type clusterWorkflowDefaultsLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterWorkflowDefaultsLister returns a new ClusterWorkflowDefaultsLister.
+func NewClusterWorkflowDefaultsLister(indexer cache.Indexer) ClusterWorkflowDefaultsLister {
+	return &clusterWorkflowDefaultsLister{indexer: indexer}
+}
+
+// List lists all ClusterWorkflowDefaultses in the indexer.
+func (s *clusterWorkflowDefaultsLister) List(selector labels.Selector) (ret []*v1alpha1.ClusterWorkflowDefaults, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ClusterWorkflowDefaults))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterWorkflowDefaults from the index for a given name.
+func (s *clusterWorkflowDefaultsLister) Get(name string) (*v1alpha1.ClusterWorkflowDefaults, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("clusterworkflowdefaults"), name)
+	}
+	return obj.(*v1alpha1.ClusterWorkflowDefaults), nil
+}
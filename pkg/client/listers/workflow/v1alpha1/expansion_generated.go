@@ -2,6 +2,10 @@
 
 package v1alpha1
 
+// ClusterWorkflowDefaultsListerExpansion allows custom methods to be added to
+// ClusterWorkflowDefaultsLister.
+type ClusterWorkflowDefaultsListerExpansion interface{}
+
 // ClusterWorkflowTemplateListerExpansion allows custom methods to be added to
 // ClusterWorkflowTemplateLister.
 type ClusterWorkflowTemplateListerExpansion interface{}
@@ -14,6 +18,14 @@ type CronWorkflowListerExpansion interface{}
 // CronWorkflowNamespaceLister.
 type CronWorkflowNamespaceListerExpansion interface{}
 
+// NamespaceWorkflowDefaultsListerExpansion allows custom methods to be added to
+// NamespaceWorkflowDefaultsLister.
+type NamespaceWorkflowDefaultsListerExpansion interface{}
+
+// NamespaceWorkflowDefaultsNamespaceListerExpansion allows custom methods to be added to
+// NamespaceWorkflowDefaultsNamespaceLister.
+type NamespaceWorkflowDefaultsNamespaceListerExpansion interface{}
+
 // WorkflowListerExpansion allows custom methods to be added to
 // WorkflowLister.
 type WorkflowListerExpansion interface{}
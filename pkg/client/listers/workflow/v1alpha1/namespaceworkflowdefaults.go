@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespaceWorkflowDefaultsLister helps list NamespaceWorkflowDefaultses.
+// All objects returned here must be treated as read-only.
+type NamespaceWorkflowDefaultsLister interface {
+	// List lists all NamespaceWorkflowDefaultses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.NamespaceWorkflowDefaults, err error)
+	// NamespaceWorkflowDefaultses returns an object that can list and get NamespaceWorkflowDefaultses.
+	NamespaceWorkflowDefaultses(namespace string) NamespaceWorkflowDefaultsNamespaceLister
+	NamespaceWorkflowDefaultsListerExpansion
+}
+
+// namespaceWorkflowDefaultsLister implements the NamespaceWorkflowDefaultsLister interface.
+type namespaceWorkflowDefaultsLister struct {
+	indexer cache.Indexer
+}
+
+// NewNamespaceWorkflowDefaultsLister returns a new NamespaceWorkflowDefaultsLister.
+func NewNamespaceWorkflowDefaultsLister(indexer cache.Indexer) NamespaceWorkflowDefaultsLister {
+	return &namespaceWorkflowDefaultsLister{indexer: indexer}
+}
+
+// List lists all NamespaceWorkflowDefaultses in the indexer.
+func (s *namespaceWorkflowDefaultsLister) List(selector labels.Selector) (ret []*v1alpha1.NamespaceWorkflowDefaults, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.NamespaceWorkflowDefaults))
+	})
+	return ret, err
+}
+
+// NamespaceWorkflowDefaultses returns an object that can list and get NamespaceWorkflowDefaultses.
+func (s *namespaceWorkflowDefaultsLister) NamespaceWorkflowDefaultses(namespace string) NamespaceWorkflowDefaultsNamespaceLister {
+	return namespaceWorkflowDefaultsNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// NamespaceWorkflowDefaultsNamespaceLister helps list and get NamespaceWorkflowDefaultses.
+// All objects returned here must be treated as read-only.
+type NamespaceWorkflowDefaultsNamespaceLister interface {
+	// List lists all NamespaceWorkflowDefaultses in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.NamespaceWorkflowDefaults, err error)
+	// Get retrieves the NamespaceWorkflowDefaults from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.NamespaceWorkflowDefaults, error)
+	NamespaceWorkflowDefaultsNamespaceListerExpansion
+}
+
+// namespaceWorkflowDefaultsNamespaceLister implements the NamespaceWorkflowDefaultsNamespaceLister
+// interface.
+type namespaceWorkflowDefaultsNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all NamespaceWorkflowDefaultses in the indexer for a given namespace.
+func (s namespaceWorkflowDefaultsNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.NamespaceWorkflowDefaults, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.NamespaceWorkflowDefaults))
+	})
+	return ret, err
+}
+
+// Get retrieves the NamespaceWorkflowDefaults from the indexer for a given namespace and name.
+func (s namespaceWorkflowDefaultsNamespaceLister) Get(name string) (*v1alpha1.NamespaceWorkflowDefaults, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("namespaceworkflowdefaults"), name)
+	}
+	return obj.(*v1alpha1.NamespaceWorkflowDefaults), nil
+}
@@ -0,0 +1,72 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+
+	"github.com/soheilhy/cmux"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/util"
+)
+
+// configureClientCertAuth configures the TLS config of the server to verify the certificates that clients present, for
+// the client-cert auth mode. Clients that do not present one can still authenticate with the other modes.
+func configureClientCertAuth(ctx context.Context, opts ArgoServerOpts, c *config.ClientCertConfig) error {
+	if opts.TLSConfig == nil {
+		return fmt.Errorf("the client-cert auth mode requires the Argo Server to be secure")
+	}
+	if c == nil {
+		return fmt.Errorf("the client-cert auth mode requires clientCert to be configured")
+	}
+	caPEM, err := util.GetSecrets(ctx, opts.Clients.Kubernetes, opts.Namespace, c.CA.Name, c.CA.Key)
+	if err != nil {
+		return fmt.Errorf("failed to get the client certificate authorities: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("secret %s has no PEM encoded client certificate authorities in key %s", c.CA.Name, c.CA.Key)
+	}
+	opts.TLSConfig.ClientCAs = pool
+	opts.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	return nil
+}
+
+// tlsInfoCredentials are the transport credentials of the gRPC server, whose connections have already been handshaken
+// by the TLS listener. They only report the state of the TLS connections, so that the certificates that clients
+// presented are available from the peers of requests.
+type tlsInfoCredentials struct{}
+
+func (tlsInfoCredentials) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, nil, nil
+}
+
+func (tlsInfoCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	raw := conn
+	if mc, ok := raw.(*cmux.MuxConn); ok {
+		raw = mc.Conn
+	}
+	if tc, ok := raw.(*tls.Conn); ok {
+		return conn, credentials.TLSInfo{
+			State:          tc.ConnectionState(),
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}, nil
+	}
+	return conn, nil, nil
+}
+
+func (tlsInfoCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c tlsInfoCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (tlsInfoCredentials) OverrideServerName(string) error {
+	return nil
+}
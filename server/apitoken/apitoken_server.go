@@ -0,0 +1,183 @@
+package apitoken
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/ptr"
+
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/creator"
+)
+
+const (
+	defaultExpiration = 24 * time.Hour
+	// minExpiration is the shortest expiration that Kubernetes allows tokens to be requested with
+	minExpiration = 10 * time.Minute
+)
+
+// apiTokenServer mints API tokens with the TokenRequest API, binding each token to a secret that records it. Deleting
+// the secret revokes the token, as Kubernetes rejects tokens whose bound object no longer exists. Tokens are minted
+// with the Kubernetes client of the caller, so the caller must be allowed to create tokens of the service account and
+// to manage secrets in its namespace.
+type apiTokenServer struct{}
+
+func NewAPITokenServer() apitokenpkg.APITokenServiceServer {
+	return &apiTokenServer{}
+}
+
+func (s *apiTokenServer) CreateAPIToken(ctx context.Context, req *apitokenpkg.CreateAPITokenRequest) (*apitokenpkg.APIToken, error) {
+	if req.ServiceAccount == "" {
+		return nil, status.Error(codes.InvalidArgument, "serviceAccount is required")
+	}
+	expiration := defaultExpiration
+	if req.ExpirationSeconds != 0 {
+		expiration = time.Duration(req.ExpirationSeconds) * time.Second
+	}
+	return createAPIToken(ctx, req.Namespace, req.ServiceAccount, req.Description, expiration)
+}
+
+func createAPIToken(ctx context.Context, namespace, serviceAccount, description string, expiration time.Duration) (*apitokenpkg.APIToken, error) {
+	if expiration < minExpiration {
+		return nil, status.Errorf(codes.InvalidArgument, "API tokens must be valid for at least %v", minExpiration)
+	}
+	kubeClient := auth.GetKubeClient(ctx)
+	secrets := kubeClient.CoreV1().Secrets(namespace)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceAccount + "-api-token-" + rand.String(5),
+			Namespace: namespace,
+			Labels:    map[string]string{common.LabelKeyAPITokenServiceAccount: serviceAccount},
+			Annotations: map[string]string{
+				common.AnnotationKeyAPITokenExpirationTimestamp: time.Now().Add(expiration).UTC().Format(time.RFC3339),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if description != "" {
+		secret.Annotations[common.AnnotationKeyAPITokenDescription] = description
+	}
+	creator.LabelCreator(ctx, secret)
+	secret, err := secrets.Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	tokenRequest, err := kubeClient.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, serviceAccount, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: ptr.To(int64(expiration.Seconds())),
+			BoundObjectRef:    &authenticationv1.BoundObjectReference{Kind: "Secret", APIVersion: "v1", Name: secret.Name, UID: secret.UID},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		if err := secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil {
+			log.WithError(err).WithField("secret", secret.Name).Warn("Failed to delete the secret of an API token that could not be created")
+		}
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	// Kubernetes may grant a shorter expiration than was requested
+	granted := tokenRequest.Status.ExpirationTimestamp.UTC().Format(time.RFC3339)
+	if secret.Annotations[common.AnnotationKeyAPITokenExpirationTimestamp] != granted {
+		secret.Annotations[common.AnnotationKeyAPITokenExpirationTimestamp] = granted
+		if updated, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			log.WithError(err).WithField("secret", secret.Name).Warn("Failed to record the expiration of an API token")
+		} else {
+			secret = updated
+		}
+	}
+	token := apiToken(secret)
+	token.ExpirationTimestamp = &tokenRequest.Status.ExpirationTimestamp
+	token.Token = tokenRequest.Status.Token
+	log.WithFields(log.Fields{"namespace": namespace, "name": token.Name, "serviceAccount": serviceAccount, "creator": token.Creator}).Info("Created API token")
+	return token, nil
+}
+
+func (s *apiTokenServer) ListAPITokens(ctx context.Context, req *apitokenpkg.ListAPITokensRequest) (*apitokenpkg.APITokenList, error) {
+	selector := common.LabelKeyAPITokenServiceAccount
+	if req.ServiceAccount != "" {
+		selector += "=" + req.ServiceAccount
+	}
+	list, err := auth.GetKubeClient(ctx).CoreV1().Secrets(req.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	items := make([]*apitokenpkg.APIToken, len(list.Items))
+	for i := range list.Items {
+		items[i] = apiToken(&list.Items[i])
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreationTimestamp.Before(items[j].CreationTimestamp)
+	})
+	return &apitokenpkg.APITokenList{Items: items}, nil
+}
+
+func (s *apiTokenServer) RotateAPIToken(ctx context.Context, req *apitokenpkg.RotateAPITokenRequest) (*apitokenpkg.APIToken, error) {
+	secret, err := getAPITokenSecret(ctx, req.Namespace, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	old := apiToken(secret)
+	expiration := old.ExpirationTimestamp.Sub(old.CreationTimestamp.Time).Round(time.Second)
+	if req.ExpirationSeconds != 0 {
+		expiration = time.Duration(req.ExpirationSeconds) * time.Second
+	}
+	token, err := createAPIToken(ctx, req.Namespace, old.ServiceAccount, old.Description, max(expiration, minExpiration))
+	if err != nil {
+		return nil, err
+	}
+	if err := deleteAPITokenSecret(ctx, secret); err != nil {
+		return nil, status.Errorf(codes.Internal, "created API token %s, but failed to revoke API token %s: %v", token.Name, old.Name, err)
+	}
+	return token, nil
+}
+
+func (s *apiTokenServer) RevokeAPIToken(ctx context.Context, req *apitokenpkg.RevokeAPITokenRequest) (*apitokenpkg.RevokeAPITokenResponse, error) {
+	secret, err := getAPITokenSecret(ctx, req.Namespace, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := deleteAPITokenSecret(ctx, secret); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	log.WithFields(log.Fields{"namespace": req.Namespace, "name": req.Name}).Info("Revoked API token")
+	return &apitokenpkg.RevokeAPITokenResponse{}, nil
+}
+
+// getAPITokenSecret returns the secret of the API token, and not any other secret of the same name
+func getAPITokenSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	secret, err := auth.GetKubeClient(ctx).CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if _, ok := secret.Labels[common.LabelKeyAPITokenServiceAccount]; !ok {
+		return nil, status.Errorf(codes.NotFound, "API token %s not found", name)
+	}
+	return secret, nil
+}
+
+func deleteAPITokenSecret(ctx context.Context, secret *corev1.Secret) error {
+	return auth.GetKubeClient(ctx).CoreV1().Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &secret.UID}})
+}
+
+func apiToken(secret *corev1.Secret) *apitokenpkg.APIToken {
+	expiration, _ := time.Parse(time.RFC3339, secret.Annotations[common.AnnotationKeyAPITokenExpirationTimestamp])
+	return &apitokenpkg.APIToken{
+		Name:                secret.Name,
+		Namespace:           secret.Namespace,
+		ServiceAccount:      secret.Labels[common.LabelKeyAPITokenServiceAccount],
+		Description:         secret.Annotations[common.AnnotationKeyAPITokenDescription],
+		Creator:             secret.Labels[common.LabelKeyCreator],
+		CreationTimestamp:   secret.CreationTimestamp.DeepCopy(),
+		ExpirationTimestamp: &metav1.Time{Time: expiration},
+	}
+}
@@ -0,0 +1,141 @@
+package apitoken
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	apitokenpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/apitoken"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func newKubeClient(objects ...runtime.Object) *fake.Clientset {
+	kubeClient := fake.NewSimpleClientset(objects...)
+	kubeClient.PrependReactor("create", "serviceaccounts", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		create := action.(ktesting.CreateActionImpl)
+		req := create.GetObject().(*authenticationv1.TokenRequest)
+		if create.Name == "missing" {
+			return true, nil, fmt.Errorf("service account missing not found")
+		}
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{
+			Token:               "token-bound-to-" + req.Spec.BoundObjectRef.Name,
+			ExpirationTimestamp: metav1.NewTime(time.Now().Add(time.Duration(*req.Spec.ExpirationSeconds) * time.Second)),
+		}}, nil
+	})
+	return kubeClient
+}
+
+func newContext(kubeClient *fake.Clientset) context.Context {
+	return context.WithValue(context.WithValue(context.Background(), auth.KubeKey, kubeClient), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}})
+}
+
+func apiTokenSecret(name, serviceAccount string, created time.Time, expiration time.Duration) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         "my-ns",
+		CreationTimestamp: metav1.NewTime(created),
+		Labels:            map[string]string{common.LabelKeyAPITokenServiceAccount: serviceAccount},
+		Annotations:       map[string]string{common.AnnotationKeyAPITokenExpirationTimestamp: created.Add(expiration).UTC().Format(time.RFC3339)},
+	}}
+}
+
+func TestCreateAPIToken(t *testing.T) {
+	s := NewAPITokenServer()
+	t.Run("Created", func(t *testing.T) {
+		kubeClient := newKubeClient()
+		ctx := newContext(kubeClient)
+		token, err := s.CreateAPIToken(ctx, &apitokenpkg.CreateAPITokenRequest{Namespace: "my-ns", ServiceAccount: "ci", Description: "my-ci", ExpirationSeconds: 3600})
+		require.NoError(t, err)
+		assert.Equal(t, "ci", token.ServiceAccount)
+		assert.Equal(t, "my-ci", token.Description)
+		assert.Equal(t, "my-sub", token.Creator)
+		assert.Equal(t, "token-bound-to-"+token.Name, token.Token)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpirationTimestamp.Time, time.Minute)
+		secret, err := kubeClient.CoreV1().Secrets("my-ns").Get(ctx, token.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "ci", secret.Labels[common.LabelKeyAPITokenServiceAccount])
+		assert.Empty(t, secret.Data, "the token is not stored")
+	})
+	t.Run("Required", func(t *testing.T) {
+		_, err := s.CreateAPIToken(newContext(newKubeClient()), &apitokenpkg.CreateAPITokenRequest{Namespace: "my-ns"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("TooShort", func(t *testing.T) {
+		_, err := s.CreateAPIToken(newContext(newKubeClient()), &apitokenpkg.CreateAPITokenRequest{Namespace: "my-ns", ServiceAccount: "ci", ExpirationSeconds: 60})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Failed", func(t *testing.T) {
+		kubeClient := newKubeClient()
+		ctx := newContext(kubeClient)
+		_, err := s.CreateAPIToken(ctx, &apitokenpkg.CreateAPITokenRequest{Namespace: "my-ns", ServiceAccount: "missing"})
+		require.Error(t, err)
+		secrets, err := kubeClient.CoreV1().Secrets("my-ns").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, secrets.Items, "the secret of the token is deleted")
+	})
+}
+
+func TestListAPITokens(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	kubeClient := newKubeClient(
+		apiTokenSecret("ci-2", "ci", now, time.Hour),
+		apiTokenSecret("ci-1", "ci", now.Add(-time.Hour), 2*time.Hour),
+		apiTokenSecret("other-1", "other", now, time.Hour),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "my-ns"}},
+	)
+	ctx := newContext(kubeClient)
+	s := NewAPITokenServer()
+	list, err := s.ListAPITokens(ctx, &apitokenpkg.ListAPITokensRequest{Namespace: "my-ns"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 3)
+	assert.Equal(t, "ci-1", list.Items[0].Name, "oldest first")
+	assert.True(t, now.Add(time.Hour).Equal(list.Items[0].ExpirationTimestamp.Time))
+	list, err = s.ListAPITokens(ctx, &apitokenpkg.ListAPITokensRequest{Namespace: "my-ns", ServiceAccount: "other"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "other-1", list.Items[0].Name)
+}
+
+func TestRotateAPIToken(t *testing.T) {
+	kubeClient := newKubeClient(apiTokenSecret("ci-1", "ci", time.Now().Add(-time.Hour), 2*time.Hour))
+	ctx := newContext(kubeClient)
+	token, err := NewAPITokenServer().RotateAPIToken(ctx, &apitokenpkg.RotateAPITokenRequest{Namespace: "my-ns", Name: "ci-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "ci", token.ServiceAccount)
+	assert.NotEmpty(t, token.Token)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), token.ExpirationTimestamp.Time, time.Minute, "as long as the old token")
+	_, err = kubeClient.CoreV1().Secrets("my-ns").Get(ctx, "ci-1", metav1.GetOptions{})
+	assert.Error(t, err, "the old token is revoked")
+}
+
+func TestRevokeAPIToken(t *testing.T) {
+	kubeClient := newKubeClient(apiTokenSecret("ci-1", "ci", time.Now(), time.Hour), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "my-ns"}})
+	ctx := newContext(kubeClient)
+	s := NewAPITokenServer()
+	_, err := s.RevokeAPIToken(ctx, &apitokenpkg.RevokeAPITokenRequest{Namespace: "my-ns", Name: "ci-1"})
+	require.NoError(t, err)
+	_, err = kubeClient.CoreV1().Secrets("my-ns").Get(ctx, "ci-1", metav1.GetOptions{})
+	require.Error(t, err)
+	_, err = s.RevokeAPIToken(ctx, &apitokenpkg.RevokeAPITokenRequest{Namespace: "my-ns", Name: "my-secret"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "other secrets cannot be deleted")
+	_, err = s.RevokeAPIToken(ctx, &apitokenpkg.RevokeAPITokenRequest{Namespace: "my-ns", Name: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,153 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+// zipFormat is the value of the `format` query parameter to download artifacts as zip archives
+const zipFormat = "zip"
+
+// returnZip writes the artifact to the response as a zip archive. Directories are archived a file at a time, and
+// artifacts that are stored as tarballs are converted an entry at a time, so the memory that is used does not depend
+// on the size of the artifact.
+func (a *ArtifactServer) returnZip(w http.ResponseWriter, art *wfv1.Artifact, driver common.ArtifactDriver) error {
+	key, _ := art.GetKey()
+	isDir, err := driver.IsDirectory(art)
+	if err != nil && !argoerrors.IsCode(argoerrors.CodeNotImplemented, err) {
+		return err
+	}
+	if !isDir {
+		// the stream is opened before the response is started, so that errors such as the artifact not existing are
+		// returned as the status of the response
+		stream, err := driver.OpenStream(art)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = stream.Close() }()
+		if strings.HasSuffix(key, ".tgz") {
+			gz, err := gzip.NewReader(stream)
+			if err != nil {
+				return fmt.Errorf("failed to read the tarball of the artifact: %w", err)
+			}
+			return streamZip(w, strings.TrimSuffix(path.Base(key), ".tgz"), func(zw *zip.Writer) error {
+				return tarToZip(tar.NewReader(gz), zw)
+			})
+		}
+		return streamZip(w, path.Base(key), func(zw *zip.Writer) error {
+			return addFile(stream, path.Base(key), zw)
+		})
+	}
+	if !driver.Capabilities().ListObjects {
+		return argoerrors.New(argoerrors.CodeNotImplemented, "listing the files of directories is not supported by the artifact driver")
+	}
+	objects, err := driver.ListObjects(art)
+	if err != nil {
+		return err
+	}
+	prefix := strings.TrimSuffix(key, "/") + "/"
+	return streamZip(w, path.Base(key), func(zw *zip.Writer) error {
+		for _, object := range objects {
+			name := strings.TrimPrefix(object, prefix)
+			if name == object || name == "" || strings.HasSuffix(name, "/") {
+				// not a file of the directory
+				continue
+			}
+			file := art.DeepCopy()
+			if err := file.SetKey(object); err != nil {
+				return err
+			}
+			stream, err := driver.OpenStream(file)
+			if err != nil {
+				return err
+			}
+			err = addFile(stream, name, zw)
+			_ = stream.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// streamZip writes the headers of a zip archive named after the name, and then the archive. Errors once the archive
+// has started abort the response, as its status has already been sent.
+func streamZip(w http.ResponseWriter, name string, write func(zw *zip.Writer) error) error {
+	filename := name + ".zip"
+	addFileHeaders(w, filename)
+	w.Header().Set("Content-Type", "application/zip")
+	w.WriteHeader(http.StatusOK)
+	zw := zip.NewWriter(w)
+	err := write(zw)
+	if err == nil {
+		err = zw.Close()
+	}
+	if err != nil {
+		log.WithError(err).WithField("filename", filename).Error("Failed to stream artifact as a zip archive")
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
+// addFile adds the file with the name and the content to the zip archive
+func addFile(content io.Reader, name string, zw *zip.Writer) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, content)
+	return err
+}
+
+// tarToZip adds the files and directories of the tarball to the zip archive
+func tarToZip(tr *tar.Reader, zw *zip.Writer) error {
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the tarball of the artifact: %w", err)
+		}
+		// names are made relative, so that the archive cannot be extracted outside of its directory
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if name == "" {
+			continue
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			zh := &zip.FileHeader{Name: name + "/", Modified: header.ModTime}
+			zh.SetMode(header.FileInfo().Mode())
+			if _, err := zw.CreateHeader(zh); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			zh := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: header.ModTime}
+			zh.SetMode(header.FileInfo().Mode())
+			fw, err := zw.CreateHeader(zh)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(fw, tr); err != nil {
+				return err
+			}
+		default:
+			// links are not portable to the platforms that zip archives are usually for
+			log.WithFields(log.Fields{"name": header.Name, "type": header.Typeflag}).Debug("Skipping tarball entry that is not a file or directory")
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+// readZip returns the content of the files of the zip archive, and the names of its directories with empty content
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestArtifactServer_GetArtifactZip(t *testing.T) {
+	s := newServer()
+
+	tests := []struct {
+		name     string
+		handler  func(s *ArtifactServer, w http.ResponseWriter, r *http.Request)
+		path     string
+		fileName string
+		files    map[string]string
+	}{
+		{
+			name:     "Directory",
+			handler:  (*ArtifactServer).GetArtifactFile,
+			path:     "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact-directory?format=zip",
+			fileName: "my-s3-artifact-directory.zip",
+			files: map[string]string{
+				"a.txt":              "my-data",
+				"index.html":         "my-data",
+				"subdirectory/b.txt": "my-data",
+				"subdirectory/c.txt": "my-data",
+			},
+		},
+		{
+			name:     "File",
+			handler:  (*ArtifactServer).GetOutputArtifact,
+			path:     "/artifacts/my-ns/my-wf/my-node-1/my-gcs-artifact?format=zip",
+			fileName: "my-gcs-artifact.zip",
+			files:    map[string]string{"my-gcs-artifact": "my-data"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{}
+			r.URL = mustParse(tt.path)
+			recorder := httptest.NewRecorder()
+
+			tt.handler(s, recorder, r)
+			require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+			assert.Equal(t, `filename="`+tt.fileName+`"`, recorder.Header().Get("Content-Disposition"))
+			assert.Equal(t, "application/zip", recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.files, readZip(t, recorder.Body.Bytes()))
+		})
+	}
+}
+
+func TestArtifactServer_GetArtifactZipFromTarball(t *testing.T) {
+	var tgz bytes.Buffer
+	gz := gzip.NewWriter(&tgz)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct {
+		header  tar.Header
+		content string
+	}{
+		{header: tar.Header{Typeflag: tar.TypeDir, Name: "my-s3-artifact/", Mode: 0o755}},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "my-s3-artifact/a.txt", Mode: 0o644}, content: "a"},
+		{header: tar.Header{Typeflag: tar.TypeSymlink, Name: "my-s3-artifact/link", Linkname: "a.txt"}},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "../outside.txt", Mode: 0o644}, content: "outside"},
+	} {
+		f.header.Size = int64(len(f.content))
+		require.NoError(t, tw.WriteHeader(&f.header))
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	s := newServer()
+	s.artDriverFactory = func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return &fakeArtifactDriver{data: tgz.Bytes()}, nil
+	}
+
+	r := &http.Request{}
+	r.URL = mustParse("/artifacts/my-ns/my-wf/my-node-1/my-s3-artifact?format=zip")
+	recorder := httptest.NewRecorder()
+
+	s.GetOutputArtifact(recorder, r)
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	assert.Equal(t, `filename="my-s3-artifact.zip"`, recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, map[string]string{
+		"my-s3-artifact/":      "",
+		"my-s3-artifact/a.txt": "a",
+		"outside.txt":          "outside",
+	}, readZip(t, recorder.Body.Bytes()))
+}
+
+func TestArtifactServer_GetArtifactZipErrors(t *testing.T) {
+	s := newServer()
+
+	tests := []struct {
+		name       string
+		path       string
+		statusCode int
+	}{
+		{
+			name:       "UnsupportedFormat",
+			path:       "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact-directory?format=rar",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "NotFound",
+			path:       "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact-directory/deletedFile.txt?format=zip",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "NotTarball",
+			path:       "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-gcs-artifact-file/my-gcs-artifact.tgz?format=zip",
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{}
+			r.URL = mustParse(tt.path)
+			recorder := httptest.NewRecorder()
+
+			s.GetArtifactFile(recorder, r)
+			assert.Equal(t, tt.statusCode, recorder.Result().StatusCode)
+		})
+	}
+}
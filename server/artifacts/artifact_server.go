@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"path"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -162,6 +163,12 @@ func (a *ArtifactServer) GetArtifactFile(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if r.URL.Query().Has("format") || r.URL.Query().Has(previewParam) {
+		// download files and directories as archives, or preview files, rather than browsing them
+		a.httpFromError(a.returnArtifact(w, r, artifact, driver), w)
+		return
+	}
+
 	isDir := strings.HasSuffix(r.URL.Path, "/")
 
 	if !isDir {
@@ -181,6 +188,10 @@ func (a *ArtifactServer) GetArtifactFile(w http.ResponseWriter, r *http.Request)
 	if isDir {
 		// return an html page to the user
 
+		if !driver.Capabilities().ListObjects {
+			http.Error(w, "listing the files of directories is not supported by the artifact driver", http.StatusNotImplemented)
+			return
+		}
 		objects, err := driver.ListObjects(artifact)
 		if err != nil {
 			a.httpFromError(err, w)
@@ -230,7 +241,7 @@ func (a *ArtifactServer) GetArtifactFile(w http.ResponseWriter, r *http.Request)
 	} else { // stream the file itself
 		log.Debugf("not a directory, artifact: %+v", artifact)
 
-		err = a.returnArtifact(w, artifact, driver)
+		err = a.returnArtifact(w, r, artifact, driver)
 
 		if err != nil {
 			a.httpFromError(err, w)
@@ -269,7 +280,7 @@ func (a *ArtifactServer) getArtifact(w http.ResponseWriter, r *http.Request, isI
 		return
 	}
 
-	err = a.returnArtifact(w, art, driver)
+	err = a.returnArtifact(w, r, art, driver)
 
 	if err != nil {
 		a.httpFromError(err, w)
@@ -322,7 +333,7 @@ func (a *ArtifactServer) getArtifactByUID(w http.ResponseWriter, r *http.Request
 
 	log.WithFields(log.Fields{"uid": uid, "nodeId": nodeID, "artifactName": artifactName, "isInput": isInput}).Info("Download artifact")
 
-	err = a.returnArtifact(w, art, driver)
+	err = a.returnArtifact(w, r, art, driver)
 
 	if err != nil {
 		a.httpFromError(err, w)
@@ -342,7 +353,7 @@ func (a *ArtifactServer) gateKeeping(r *http.Request, ns types.NamespacedRequest
 			token = cookie.Value
 		}
 	}
-	ctx := metadata.NewIncomingContext(r.Context(), metadata.MD{"authorization": []string{token}})
+	ctx := metadata.NewIncomingContext(auth.ContextWithClientCertificate(r.Context(), r.TLS), metadata.MD{"authorization": []string{token}})
 	return a.gatekeeper.ContextWithRequest(ctx, ns)
 }
 
@@ -453,7 +464,38 @@ func (a *ArtifactServer) getArtifactAndDriver(ctx context.Context, nodeID, artif
 	return art, driver, nil
 }
 
-func (a *ArtifactServer) returnArtifact(w http.ResponseWriter, art *wfv1.Artifact, driver common.ArtifactDriver) error {
+// returnArtifact writes the artifact to the response, as a zip archive if the `format` query parameter is zip, or its
+// first bytes if the `preview` query parameter is set. Range requests are served if the driver supports ranged reads.
+func (a *ArtifactServer) returnArtifact(w http.ResponseWriter, r *http.Request, art *wfv1.Artifact, driver common.ArtifactDriver) error {
+	if r.URL.Query().Has(previewParam) {
+		return a.returnPreview(w, art, driver, r.URL.Query().Get(previewParam))
+	}
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+	case zipFormat:
+		return a.returnZip(w, art, driver)
+	default:
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "unsupported format %q, the only format is %s", format, zipFormat)
+	}
+	if driver.Capabilities().RangedReads {
+		stream, err := common.OpenSeekableStream(driver, art)
+		// some artifacts of drivers that support ranged reads cannot be read from an offset, e.g. the results of queries,
+		// so they are streamed from the start
+		if err == nil {
+			defer func() {
+				if err := stream.Close(); err != nil {
+					log.WithFields(log.Fields{"stream": stream}).WithError(err).Warning("Error closing stream")
+				}
+			}()
+			key := addArtifactHeaders(w, art)
+			http.ServeContent(w, r, path.Base(key), time.Time{}, stream)
+			return nil
+		}
+		if !argoerrors.IsCode(argoerrors.CodeNotImplemented, err) {
+			return err
+		}
+	}
+
 	stream, err := driver.OpenStream(art)
 	if err != nil {
 		return err
@@ -465,11 +507,7 @@ func (a *ArtifactServer) returnArtifact(w http.ResponseWriter, art *wfv1.Artifac
 		}
 	}()
 
-	key, _ := art.GetKey()
-	w.Header().Add("Content-Disposition", fmt.Sprintf(`filename="%s"`, path.Base(key)))
-	w.Header().Add("Content-Type", mime.TypeByExtension(path.Ext(key)))
-	w.Header().Add("Content-Security-Policy", env.GetString("ARGO_ARTIFACT_CONTENT_SECURITY_POLICY", "sandbox; base-uri 'none'; default-src 'none'; img-src 'self'; style-src 'self' 'unsafe-inline'"))
-	w.Header().Add("X-Frame-Options", env.GetString("ARGO_ARTIFACT_X_FRAME_OPTIONS", "SAMEORIGIN"))
+	addArtifactHeaders(w, art)
 
 	_, err = io.Copy(w, stream)
 	if err != nil {
@@ -483,6 +521,21 @@ func (a *ArtifactServer) returnArtifact(w http.ResponseWriter, art *wfv1.Artifac
 	return nil
 }
 
+// addArtifactHeaders adds the headers of the response of the artifact, returning its key
+func addArtifactHeaders(w http.ResponseWriter, art *wfv1.Artifact) string {
+	key, _ := art.GetKey()
+	addFileHeaders(w, path.Base(key))
+	return key
+}
+
+// addFileHeaders adds the headers of a response of the file with the name
+func addFileHeaders(w http.ResponseWriter, filename string) {
+	w.Header().Add("Content-Disposition", fmt.Sprintf(`filename="%s"`, filename))
+	w.Header().Add("Content-Type", mime.TypeByExtension(path.Ext(filename)))
+	w.Header().Add("Content-Security-Policy", env.GetString("ARGO_ARTIFACT_CONTENT_SECURITY_POLICY", "sandbox; base-uri 'none'; default-src 'none'; img-src 'self'; style-src 'self' 'unsafe-inline'"))
+	w.Header().Add("X-Frame-Options", env.GetString("ARGO_ARTIFACT_X_FRAME_OPTIONS", "SAMEORIGIN"))
+}
+
 func (a *ArtifactServer) getWorkflowAndValidate(ctx context.Context, namespace string, workflowName string) (*wfv1.Workflow, error) {
 	wfClient := auth.GetWfClient(ctx)
 	wf, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, workflowName, metav1.GetOptions{})
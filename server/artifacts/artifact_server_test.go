@@ -58,7 +58,9 @@ var bucketsOfKeys = map[string][]string{
 		"my-wf/my-node-1/my-s3-input-artifact.tgz",
 		"my-wf/my-node-1/my-s3-artifact-directory",
 		"my-wf/my-node-1/my-s3-artifact-directory/a.txt",
+		"my-wf/my-node-1/my-s3-artifact-directory/index.html",
 		"my-wf/my-node-1/my-s3-artifact-directory/subdirectory/b.txt",
+		"my-wf/my-node-1/my-s3-artifact-directory/subdirectory/c.txt",
 		"my-wf/my-node-1/my-gcs-artifact",
 		"my-wf/my-node-1/my-gcs-artifact.tgz",
 		"my-wf/my-node-1/my-oss-artifact.zip",
@@ -114,6 +116,29 @@ func (a *fakeArtifactDriver) Save(_ string, _ *wfv1.Artifact) error {
 	return fmt.Errorf("not implemented")
 }
 
+func (a *fakeArtifactDriver) Capabilities() artifactscommon.Capabilities {
+	return artifactscommon.Capabilities{Delete: true, ListObjects: true}
+}
+
+// rangedArtifactDriver is a fakeArtifactDriver that supports ranged reads
+type rangedArtifactDriver struct {
+	fakeArtifactDriver
+}
+
+func (a *rangedArtifactDriver) Capabilities() artifactscommon.Capabilities {
+	return artifactscommon.Capabilities{RangedReads: true}
+}
+
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+func (a *rangedArtifactDriver) OpenSeekableStream(_ *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	return nopSeekCloser{bytes.NewReader(a.data)}, nil
+}
+
 func (a *fakeArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
 	key, err := artifact.GetKey()
 	if err != nil {
@@ -527,6 +552,69 @@ func TestArtifactServer_GetOutputArtifact(t *testing.T) {
 	}
 }
 
+func TestArtifactServer_GetOutputArtifactRange(t *testing.T) {
+	s := newServer()
+	s.artDriverFactory = func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return &rangedArtifactDriver{fakeArtifactDriver{data: []byte("my-data")}}, nil
+	}
+
+	r := &http.Request{Method: http.MethodGet, Header: http.Header{"Range": []string{"bytes=3-"}}}
+	r.URL = mustParse("/artifacts/my-ns/my-wf/my-node-1/my-s3-artifact")
+	recorder := httptest.NewRecorder()
+
+	s.GetOutputArtifact(recorder, r)
+	require.Equal(t, http.StatusPartialContent, recorder.Result().StatusCode)
+	assert.Equal(t, "bytes 3-6/7", recorder.Header().Get("Content-Range"))
+	assert.Equal(t, "data", recorder.Body.String())
+
+	r = &http.Request{Method: http.MethodGet, Header: http.Header{"Range": []string{"bytes=0-1"}}}
+	r.URL = mustParse("/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact")
+	recorder = httptest.NewRecorder()
+
+	s.GetArtifactFile(recorder, r)
+	require.Equal(t, http.StatusPartialContent, recorder.Result().StatusCode)
+	assert.Equal(t, "bytes 0-1/7", recorder.Header().Get("Content-Range"))
+	assert.Equal(t, "my", recorder.Body.String())
+}
+
+// unseekableArtifactDriver is a driver that supports ranged reads, but not of its artifacts
+type unseekableArtifactDriver struct {
+	rangedArtifactDriver
+}
+
+func (a *unseekableArtifactDriver) OpenSeekableStream(_ *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	return nil, argoerrors.New(argoerrors.CodeNotImplemented, "not seekable")
+}
+
+func TestArtifactServer_GetOutputArtifactRangeUnsupported(t *testing.T) {
+	s := newServer()
+	s.artDriverFactory = func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return &unseekableArtifactDriver{rangedArtifactDriver{fakeArtifactDriver{data: []byte("my-data")}}}, nil
+	}
+
+	r := &http.Request{Method: http.MethodGet, Header: http.Header{"Range": []string{"bytes=3-"}}}
+	r.URL = mustParse("/artifacts/my-ns/my-wf/my-node-1/my-s3-artifact")
+	recorder := httptest.NewRecorder()
+
+	s.GetOutputArtifact(recorder, r)
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	assert.Equal(t, "my-data", recorder.Body.String(), "the whole artifact is returned")
+}
+
+func TestArtifactServer_GetArtifactFileListingUnsupported(t *testing.T) {
+	s := newServer()
+	s.artDriverFactory = func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return &rangedArtifactDriver{fakeArtifactDriver{data: []byte("my-data")}}, nil
+	}
+
+	r := &http.Request{}
+	r.URL = mustParse("/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact-directory/")
+	recorder := httptest.NewRecorder()
+
+	s.GetArtifactFile(recorder, r)
+	assert.Equal(t, http.StatusNotImplemented, recorder.Result().StatusCode)
+}
+
 func TestArtifactServer_GetOutputArtifactWithTemplate(t *testing.T) {
 	s := newServer()
 
@@ -0,0 +1,166 @@
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/types"
+)
+
+// ArtifactCopyRequest is where to copy an output artifact to, which is either a configured artifact repository, or the
+// artifact repository of another workflow of the same namespace
+type ArtifactCopyRequest struct {
+	// ArtifactRepositoryRef is the artifact repository to copy the artifact to
+	ArtifactRepositoryRef *wfv1.ArtifactRepositoryRef `json:"artifactRepositoryRef,omitempty"`
+	// Workflow is the name of the workflow whose artifact repository the artifact is copied to
+	Workflow string `json:"workflow,omitempty"`
+	// Key is the key of the copy. It is the key of the artifact if it is copied to an artifact repository, and the
+	// file name of its key under the name of the workflow if it is copied to a workflow, if it is not set.
+	Key string `json:"key,omitempty"`
+}
+
+type ArtifactCopyResponse struct {
+	// Artifact is the copy of the artifact
+	Artifact *wfv1.Artifact `json:"artifact"`
+}
+
+// CopyArtifact copies an output artifact of a workflow with the drivers of the Argo Server, so that it is not
+// downloaded by the client:
+//
+//	POST /artifact-copies/{namespace}/{workflowName}/{nodeID}/{artifactName}
+func (a *ArtifactServer) CopyArtifact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	requestPath := strings.SplitN(r.URL.Path, "/", 6)
+	if len(requestPath) != 6 {
+		a.httpBadRequestError(w)
+		return
+	}
+	namespace := requestPath[2]
+	workflowName := requestPath[3]
+	nodeID := requestPath[4]
+	artifactName := requestPath[5]
+
+	ctx, err := a.gateKeeping(r, types.NamespaceHolder(namespace))
+	if err != nil {
+		a.unauthorizedError(w)
+		return
+	}
+	req := &ArtifactCopyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if (req.ArtifactRepositoryRef == nil) == (req.Workflow == "") {
+		http.Error(w, "exactly one of artifactRepositoryRef and workflow is required", http.StatusBadRequest)
+		return
+	}
+
+	log.WithFields(log.Fields{"namespace": namespace, "workflowName": workflowName, "nodeID": nodeID, "artifactName": artifactName, "to": req}).Info("Copy artifact")
+
+	wf, err := a.getWorkflowAndValidate(ctx, namespace, workflowName)
+	if err != nil {
+		a.httpFromError(err, w)
+		return
+	}
+	art, driver, err := a.getArtifactAndDriver(ctx, nodeID, artifactName, false, wf, nil)
+	if err != nil {
+		a.serverInternalError(err, w)
+		return
+	}
+	dst, err := a.copyDestination(ctx, namespace, art, req)
+	if err != nil {
+		a.httpFromError(err, w)
+		return
+	}
+	dstDriver, err := a.artDriverFactory(ctx, dst, resources{auth.GetKubeClient(ctx), namespace})
+	if err != nil {
+		a.serverInternalError(err, w)
+		return
+	}
+
+	// the artifact is copied through the disk of the Argo Server, as drivers can only save files and directories
+	tmpDir, err := os.MkdirTemp("", "artifact-copy-")
+	if err != nil {
+		a.serverInternalError(err, w)
+		return
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	tmpPath := filepath.Join(tmpDir, "artifact")
+	if err := driver.Load(art, tmpPath); err != nil {
+		a.httpFromError(err, w)
+		return
+	}
+	if err := dstDriver.Save(tmpPath, dst); err != nil {
+		a.httpFromError(err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&ArtifactCopyResponse{Artifact: dst}); err != nil {
+		log.WithError(err).Error("Failed to write the response of the artifact copy")
+	}
+}
+
+// copyDestination returns the copy of the artifact, in the location that the request copies it to
+func (a *ArtifactServer) copyDestination(ctx context.Context, namespace string, art *wfv1.Artifact, req *ArtifactCopyRequest) (*wfv1.Artifact, error) {
+	key, err := art.GetKey()
+	if err != nil {
+		return nil, err
+	}
+	var ref *wfv1.ArtifactRepositoryRefStatus
+	if req.ArtifactRepositoryRef != nil {
+		ref, err = a.artifactRepositories.Resolve(ctx, req.ArtifactRepositoryRef, namespace)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		target, err := a.getWorkflowAndValidate(ctx, namespace, req.Workflow)
+		if err != nil {
+			return nil, err
+		}
+		// copying an artifact to a workflow is a change to it
+		allowed, err := auth.CanI(ctx, "update", "workflows", namespace, target.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, argoerrors.Errorf(argoerrors.CodeForbidden, "not allowed to update workflow %s", target.Name)
+		}
+		if target.Status.ArtifactRepositoryRef == nil {
+			return nil, argoerrors.Errorf(argoerrors.CodeBadRequest, "workflow %s has no artifact repository yet", target.Name)
+		}
+		ref = target.Status.ArtifactRepositoryRef
+		key = path.Join(target.Name, path.Base(key))
+	}
+	repo, err := a.artifactRepositories.Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, argoerrors.New(argoerrors.CodeBadRequest, "there is no artifact repository to copy the artifact to")
+	}
+	if req.Key != "" {
+		key = req.Key
+	}
+	dst := art.DeepCopy()
+	dst.ArtifactLocation = *repo.ToArtifactLocation()
+	dst.ArchiveLogs = nil
+	if err := dst.SetKey(key); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
@@ -0,0 +1,79 @@
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+// savingArtifactDriver records the data of the artifacts that it saves, by their keys
+type savingArtifactDriver struct {
+	fakeArtifactDriver
+	saved map[string]string
+}
+
+func (a *savingArtifactDriver) Save(path string, art *wfv1.Artifact) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	key, err := art.GetKey()
+	if err != nil {
+		return err
+	}
+	a.saved[key] = string(data)
+	return nil
+}
+
+func TestArtifactServer_CopyArtifact(t *testing.T) {
+	s := newServer()
+	driver := &savingArtifactDriver{fakeArtifactDriver{data: []byte("my-data")}, map[string]string{}}
+	s.artDriverFactory = func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return driver, nil
+	}
+	copyArtifact := func(method, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/artifact-copies/my-ns/my-wf/my-node-1/my-s3-artifact", strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		s.CopyArtifact(recorder, r)
+		return recorder
+	}
+
+	t.Run("ToArtifactRepository", func(t *testing.T) {
+		recorder := copyArtifact(http.MethodPost, `{"artifactRepositoryRef": {"configMap": "my-repositories"}, "key": "copies/my-s3-artifact.tgz"}`)
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+		resp := &ArtifactCopyResponse{}
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(resp))
+		require.NotNil(t, resp.Artifact.S3)
+		assert.Equal(t, "my-bucket", resp.Artifact.S3.Bucket)
+		assert.Equal(t, "copies/my-s3-artifact.tgz", resp.Artifact.S3.Key)
+		assert.Equal(t, "my-data", driver.saved["copies/my-s3-artifact.tgz"])
+	})
+	t.Run("KeyOfArtifact", func(t *testing.T) {
+		recorder := copyArtifact(http.MethodPost, `{"artifactRepositoryRef": {"configMap": "my-repositories"}}`)
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+		assert.Equal(t, "my-data", driver.saved["my-wf/my-node-1/my-s3-artifact.tgz"])
+	})
+	t.Run("NoDestination", func(t *testing.T) {
+		recorder := copyArtifact(http.MethodPost, `{}`)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+	t.Run("BothDestinations", func(t *testing.T) {
+		recorder := copyArtifact(http.MethodPost, `{"artifactRepositoryRef": {}, "workflow": "your-wf"}`)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+	t.Run("Get", func(t *testing.T) {
+		recorder := copyArtifact(http.MethodGet, ``)
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
@@ -0,0 +1,99 @@
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/types"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// DeleteArtifact requests that an output artifact of a completed workflow is deleted. The artifact is deleted by the
+// artifact GC of the controller, so the request is accepted before the artifact is deleted:
+//
+//	POST /artifact-deletions/{namespace}/{workflowName}/{nodeID}/{artifactName}
+func (a *ArtifactServer) DeleteArtifact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	requestPath := strings.SplitN(r.URL.Path, "/", 6)
+	if len(requestPath) != 6 {
+		a.httpBadRequestError(w)
+		return
+	}
+	namespace := requestPath[2]
+	workflowName := requestPath[3]
+	nodeID := requestPath[4]
+	artifactName := requestPath[5]
+
+	ctx, err := a.gateKeeping(r, types.NamespaceHolder(namespace))
+	if err != nil {
+		a.unauthorizedError(w)
+		return
+	}
+
+	log.WithFields(log.Fields{"namespace": namespace, "workflowName": workflowName, "nodeID": nodeID, "artifactName": artifactName}).Info("Delete artifact")
+
+	if err := a.requestArtifactDeletion(ctx, namespace, workflowName, nodeID, artifactName); err != nil {
+		a.httpFromError(err, w)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// requestArtifactDeletion adds the artifact to the artifact GC request annotation of the workflow, unless it has
+// already been deleted or requested to be
+func (a *ArtifactServer) requestArtifactDeletion(ctx context.Context, namespace, workflowName, nodeID, artifactName string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		wf, err := a.getWorkflowAndValidate(ctx, namespace, workflowName)
+		if err != nil {
+			return err
+		}
+		if wf.Labels[wfcommon.LabelKeyCompleted] != "true" {
+			return argoerrors.Errorf(argoerrors.CodeBadRequest, "workflow %s has not completed, so its artifacts cannot be deleted yet", workflowName)
+		}
+		node, err := wf.Status.Nodes.Get(nodeID)
+		if err != nil {
+			return argoerrors.Errorf(argoerrors.CodeNotFound, "node %s of workflow %s not found", nodeID, workflowName)
+		}
+		art := node.GetOutputs().GetArtifactByName(artifactName)
+		if art == nil {
+			return argoerrors.Errorf(argoerrors.CodeNotFound, "output artifact %s of node %s not found", artifactName, nodeID)
+		}
+		if art.Deleted {
+			return nil
+		}
+		request := nodeID + "/" + artifactName
+		var requests []string
+		if value := wf.Annotations[wfcommon.AnnotationKeyArtifactGCRequest]; value != "" {
+			requests = strings.Split(value, ",")
+		}
+		if slices.Contains(requests, request) {
+			return nil
+		}
+		// the resource version makes the patch fail with a conflict if another request was added since the workflow
+		// was read, rather than losing one of them
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"resourceVersion": wf.ResourceVersion,
+				"annotations":     map[string]string{wfcommon.AnnotationKeyArtifactGCRequest: strings.Join(append(requests, request), ",")},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(namespace).Patch(ctx, workflowName, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	})
+}
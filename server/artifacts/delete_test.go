@@ -0,0 +1,72 @@
+package artifacts
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/types"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestArtifactServer_DeleteArtifact(t *testing.T) {
+	s := newServer()
+	deleteArtifact := func(method, nodeID, artifactName string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/artifact-deletions/my-ns/my-wf/"+nodeID+"/"+artifactName, nil)
+		recorder := httptest.NewRecorder()
+		s.DeleteArtifact(recorder, r)
+		return recorder
+	}
+	ctx, err := s.gateKeeping(httptest.NewRequest(http.MethodPost, "/", nil), types.NamespaceHolder("my-ns"))
+	require.NoError(t, err)
+	wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("my-ns")
+	requested := func() string {
+		wf, err := wfClient.Get(ctx, "my-wf", metav1.GetOptions{})
+		require.NoError(t, err)
+		return wf.Annotations[common.AnnotationKeyArtifactGCRequest]
+	}
+
+	t.Run("NotCompleted", func(t *testing.T) {
+		recorder := deleteArtifact(http.MethodPost, "my-node-1", "my-s3-artifact")
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	wf, err := wfClient.Get(ctx, "my-wf", metav1.GetOptions{})
+	require.NoError(t, err)
+	wf.Labels[common.LabelKeyCompleted] = "true"
+	_, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	t.Run("Delete", func(t *testing.T) {
+		recorder := deleteArtifact(http.MethodPost, "my-node-1", "my-s3-artifact")
+		require.Equal(t, http.StatusAccepted, recorder.Code, recorder.Body.String())
+		assert.Equal(t, "my-node-1/my-s3-artifact", requested())
+	})
+	t.Run("AlreadyRequested", func(t *testing.T) {
+		recorder := deleteArtifact(http.MethodPost, "my-node-1", "my-s3-artifact")
+		require.Equal(t, http.StatusAccepted, recorder.Code, recorder.Body.String())
+		assert.Equal(t, "my-node-1/my-s3-artifact", requested())
+	})
+	t.Run("Another", func(t *testing.T) {
+		recorder := deleteArtifact(http.MethodPost, "my-node-1", "my-gcs-artifact")
+		require.Equal(t, http.StatusAccepted, recorder.Code, recorder.Body.String())
+		assert.Equal(t, "my-node-1/my-s3-artifact,my-node-1/my-gcs-artifact", requested())
+	})
+	t.Run("ArtifactNotFound", func(t *testing.T) {
+		recorder := deleteArtifact(http.MethodPost, "my-node-1", "missing")
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+	t.Run("NodeNotFound", func(t *testing.T) {
+		recorder := deleteArtifact(http.MethodPost, "missing", "my-s3-artifact")
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+	t.Run("Get", func(t *testing.T) {
+		recorder := deleteArtifact(http.MethodGet, "my-node-1", "my-s3-artifact")
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
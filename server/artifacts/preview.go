@@ -0,0 +1,115 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/env"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+const (
+	// previewParam is the query parameter to preview artifacts, whose value is the number of bytes of the preview
+	previewParam = "preview"
+	// defaultPreviewSize is the number of bytes of previews whose size is not specified
+	defaultPreviewSize = 64 << 10
+	// defaultMaxPreviewSize is the largest preview, unless ARGO_ARTIFACT_PREVIEW_MAX_BYTES is set
+	defaultMaxPreviewSize = 1 << 20
+	// previewTruncatedHeader is true if the artifact is larger than its preview
+	previewTruncatedHeader = "X-Artifact-Preview-Truncated"
+)
+
+// returnPreview writes the first bytes of the artifact to the response, with the content type that is detected from
+// them, so that the UI can preview artifacts without downloading all of them. Artifacts that are stored as tarballs
+// are previewed as their first file.
+func (a *ArtifactServer) returnPreview(w http.ResponseWriter, art *wfv1.Artifact, driver common.ArtifactDriver, size string) error {
+	n, err := previewSize(size)
+	if err != nil {
+		return err
+	}
+	stream, err := driver.OpenStream(art)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+	key, _ := art.GetKey()
+	filename := path.Base(key)
+	var r io.Reader = stream
+	if strings.HasSuffix(key, ".tgz") {
+		gz, err := gzip.NewReader(stream)
+		if err != nil {
+			return fmt.Errorf("failed to read the tarball of the artifact: %w", err)
+		}
+		tr := tar.NewReader(gz)
+		for {
+			header, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				return argoerrors.New(argoerrors.CodeNotFound, "the tarball of the artifact has no files")
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read the tarball of the artifact: %w", err)
+			}
+			if header.Typeflag == tar.TypeReg {
+				filename = path.Base(header.Name)
+				break
+			}
+		}
+		r = tr
+	}
+	// one more byte than the preview is read, to know whether the artifact is larger than it
+	data, err := io.ReadAll(io.LimitReader(r, n+1))
+	if err != nil {
+		return fmt.Errorf("failed to read the artifact: %w", err)
+	}
+	truncated := int64(len(data)) > n
+	if truncated {
+		data = data[:n]
+	}
+	addFileHeaders(w, filename)
+	w.Header().Set("Content-Type", detectContentType(filename, data))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set(previewTruncatedHeader, strconv.FormatBool(truncated))
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	return err
+}
+
+// previewSize returns the number of bytes of the preview, which is at most the largest preview
+func previewSize(size string) (int64, error) {
+	maxSize, err := env.GetInt("ARGO_ARTIFACT_PREVIEW_MAX_BYTES", defaultMaxPreviewSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ARGO_ARTIFACT_PREVIEW_MAX_BYTES: %w", err)
+	}
+	if size == "" {
+		return int64(min(defaultPreviewSize, maxSize)), nil
+	}
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, argoerrors.Errorf(argoerrors.CodeBadRequest, "%s must be a positive number of bytes, not %q", previewParam, size)
+	}
+	return min(n, int64(maxSize)), nil
+}
+
+// detectContentType returns the content type of the file, which is sniffed from its data, unless that only finds that
+// it is text or binary, and the extension of the file is more specific, e.g. for JSON
+func detectContentType(filename string, data []byte) string {
+	contentType := http.DetectContentType(data)
+	if strings.HasPrefix(contentType, "text/plain") || contentType == "application/octet-stream" {
+		if byExtension := mime.TypeByExtension(path.Ext(filename)); byExtension != "" {
+			return byExtension
+		}
+	}
+	return contentType
+}
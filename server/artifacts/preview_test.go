@@ -0,0 +1,114 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+func TestArtifactServer_GetArtifactPreview(t *testing.T) {
+	s := newServer()
+
+	tests := []struct {
+		name        string
+		handler     func(s *ArtifactServer, w http.ResponseWriter, r *http.Request)
+		path        string
+		statusCode  int
+		content     string
+		contentType string
+		truncated   string
+	}{
+		{
+			name:        "File",
+			handler:     (*ArtifactServer).GetArtifactFile,
+			path:        "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact-directory/a.txt?preview",
+			statusCode:  http.StatusOK,
+			content:     "my-data",
+			contentType: "text/plain; charset=utf-8",
+			truncated:   "false",
+		},
+		{
+			name:        "Truncated",
+			handler:     (*ArtifactServer).GetOutputArtifact,
+			path:        "/artifacts/my-ns/my-wf/my-node-1/my-gcs-artifact?preview=3",
+			statusCode:  http.StatusOK,
+			content:     "my-",
+			contentType: "text/plain; charset=utf-8",
+			truncated:   "true",
+		},
+		{
+			name:       "InvalidSize",
+			handler:    (*ArtifactServer).GetOutputArtifact,
+			path:       "/artifacts/my-ns/my-wf/my-node-1/my-gcs-artifact?preview=0",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "NotFound",
+			handler:    (*ArtifactServer).GetArtifactFile,
+			path:       "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact-directory/deletedFile.txt?preview",
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{}
+			r.URL = mustParse(tt.path)
+			recorder := httptest.NewRecorder()
+
+			tt.handler(s, recorder, r)
+			require.Equal(t, tt.statusCode, recorder.Result().StatusCode)
+			if tt.statusCode == http.StatusOK {
+				assert.Equal(t, tt.content, recorder.Body.String())
+				assert.Equal(t, tt.contentType, recorder.Header().Get("Content-Type"))
+				assert.Equal(t, tt.truncated, recorder.Header().Get(previewTruncatedHeader))
+			}
+		})
+	}
+}
+
+func TestArtifactServer_GetArtifactPreviewFromTarball(t *testing.T) {
+	var tgz bytes.Buffer
+	gz := gzip.NewWriter(&tgz)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "my-s3-artifact/", Mode: 0o755}))
+	content := `{"a": 1}`
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "my-s3-artifact/data.json", Mode: 0o644, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	s := newServer()
+	s.artDriverFactory = func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+		return &fakeArtifactDriver{data: tgz.Bytes()}, nil
+	}
+
+	r := &http.Request{}
+	r.URL = mustParse("/artifacts/my-ns/my-wf/my-node-1/my-s3-artifact?preview")
+	recorder := httptest.NewRecorder()
+
+	s.GetOutputArtifact(recorder, r)
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	assert.Equal(t, `filename="data.json"`, recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, content, recorder.Body.String())
+}
+
+func TestDetectContentType(t *testing.T) {
+	assert.Equal(t, "image/png", detectContentType("image", []byte("\x89PNG\x0D\x0A\x1A\x0A")))
+	assert.Equal(t, "application/json", detectContentType("data.json", []byte(`{"a": 1}`)))
+	assert.Equal(t, "text/plain; charset=utf-8", detectContentType("stdout", []byte("hello")))
+	assert.Equal(t, "text/html; charset=utf-8", detectContentType("index.html", []byte("<html></html>")))
+}
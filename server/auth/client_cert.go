@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+const (
+	// ClientCertificateKey is the context key of the verified client certificates of HTTP requests
+	ClientCertificateKey ContextKey = "x509.Certificate"
+	// clientCertificateHeader forwards the verified client certificates of HTTP requests to the gRPC server through the
+	// gateway, which is the only client that does not present the certificate itself
+	clientCertificateHeader = "X-Argo-Client-Certificate"
+)
+
+// forwardingKey signs the forwarded client certificates, so that clients cannot forge them. It is only known to this
+// process.
+var forwardingKey = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// clientCertRule is a config.ClientCertRule with its regular expression compiled
+type clientCertRule struct {
+	config.ClientCertRule
+	san *regexp.Regexp
+}
+
+func compileClientCertRules(rules []config.ClientCertRule) ([]clientCertRule, error) {
+	compiled := make([]clientCertRule, len(rules))
+	for i, rule := range rules {
+		san, err := regexp.Compile("^(?:" + rule.SAN + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid san of client certificate rule %d: %w", i, err)
+		}
+		compiled[i] = clientCertRule{rule, san}
+	}
+	return compiled, nil
+}
+
+// subjectAltNames returns the DNS names, email addresses, URIs and IP addresses of the certificate
+func subjectAltNames(cert *x509.Certificate) []string {
+	names := append(append([]string{}, cert.DNSNames...), cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return names
+}
+
+// claimsForCertificate returns the claims of the identity of the first rule that matches a name of the certificate
+func claimsForCertificate(rules []clientCertRule, cert *x509.Certificate) (*types.Claims, error) {
+	names := subjectAltNames(cert)
+	for _, rule := range rules {
+		for _, name := range names {
+			match := rule.san.FindStringSubmatchIndex(name)
+			if match == nil {
+				continue
+			}
+			expand := func(template string) string {
+				return string(rule.san.ExpandString(nil, template, name, match))
+			}
+			claims := &types.Claims{Claims: jwt.Claims{Subject: name, Issuer: cert.Issuer.String()}}
+			if rule.Subject != "" {
+				claims.Subject = expand(rule.Subject)
+			}
+			for _, group := range rule.Groups {
+				claims.Groups = append(claims.Groups, expand(group))
+			}
+			for _, email := range cert.EmailAddresses {
+				if email == name {
+					claims.Email = email
+				}
+			}
+			return claims, nil
+		}
+	}
+	return nil, fmt.Errorf("no client certificate rule matches the names %q of the certificate", names)
+}
+
+// ContextWithClientCertificate returns the context with the verified client certificate of the connection, if there is
+// one, for HTTP handlers that authenticate requests with Gatekeeper.Context
+func ContextWithClientCertificate(ctx context.Context, state *tls.ConnectionState) context.Context {
+	if state == nil || len(state.VerifiedChains) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, ClientCertificateKey, state.VerifiedChains[0][0])
+}
+
+// ForwardClientCertificate sets the header that forwards the verified client certificate of the request to the gRPC
+// server, replacing any that the client set
+func ForwardClientCertificate(r *http.Request) {
+	r.Header.Del(clientCertificateHeader)
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return
+	}
+	raw := r.TLS.VerifiedChains[0][0].Raw
+	r.Header.Set(clientCertificateHeader, base64.StdEncoding.EncodeToString(raw)+"."+base64.StdEncoding.EncodeToString(sign(raw)))
+}
+
+func sign(raw []byte) []byte {
+	mac := hmac.New(sha256.New, forwardingKey)
+	mac.Write(raw)
+	return mac.Sum(nil)
+}
+
+// forwardedCertificate returns the client certificate that the gateway forwarded, if it was signed with the forwarding
+// key
+func forwardedCertificate(md metadata.MD) *x509.Certificate {
+	for _, value := range md.Get(clientCertificateHeader) {
+		encodedRaw, encodedMAC, ok := strings.Cut(value, ".")
+		if !ok {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(encodedRaw)
+		if err != nil {
+			continue
+		}
+		mac, err := base64.StdEncoding.DecodeString(encodedMAC)
+		if err != nil || !hmac.Equal(mac, sign(raw)) {
+			log.Warn("Ignoring forwarded client certificate with an invalid signature")
+			continue
+		}
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			continue
+		}
+		return cert
+	}
+	return nil
+}
+
+// clientCertificate returns the verified certificate that the client presented, either to the HTTP server, the gRPC
+// server, or to the HTTP server and forwarded by the gateway
+func clientCertificate(ctx context.Context, md metadata.MD) *x509.Certificate {
+	if cert, ok := ctx.Value(ClientCertificateKey).(*x509.Certificate); ok {
+		return cert
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			return info.State.VerifiedChains[0][0]
+		}
+	}
+	return forwardedCertificate(md)
+}
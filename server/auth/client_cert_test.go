@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+func newTestCertificate(t *testing.T, template *x509.Certificate) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(1)
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestClaimsForCertificate(t *testing.T) {
+	spiffeID, err := url.Parse("spiffe://example.com/ns/ci/sa/builder")
+	require.NoError(t, err)
+	cert := &x509.Certificate{
+		DNSNames:       []string{"builder.example.com"},
+		EmailAddresses: []string{"alice@example.com"},
+		URIs:           []*url.URL{spiffeID},
+	}
+	rules, err := compileClientCertRules([]config.ClientCertRule{
+		{SAN: `spiffe://example.com/ns/([a-z]+)/sa/([a-z]+)`, Subject: "$2", Groups: []string{"$1", "robots"}},
+		{SAN: `.*@example\.com`, Groups: []string{"engineers"}},
+	})
+	require.NoError(t, err)
+
+	t.Run("FirstRule", func(t *testing.T) {
+		claims, err := claimsForCertificate(rules, cert)
+		require.NoError(t, err)
+		assert.Equal(t, "builder", claims.Subject)
+		assert.Equal(t, []string{"ci", "robots"}, claims.Groups)
+		assert.Empty(t, claims.Email)
+	})
+	t.Run("Email", func(t *testing.T) {
+		claims, err := claimsForCertificate(rules[1:], cert)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", claims.Subject)
+		assert.Equal(t, "alice@example.com", claims.Email)
+		assert.Equal(t, []string{"engineers"}, claims.Groups)
+	})
+	t.Run("WholeName", func(t *testing.T) {
+		rules, err := compileClientCertRules([]config.ClientCertRule{{SAN: "example.com"}})
+		require.NoError(t, err)
+		_, err = claimsForCertificate(rules, cert)
+		require.ErrorContains(t, err, "no client certificate rule matches")
+	})
+	t.Run("InvalidRule", func(t *testing.T) {
+		_, err := compileClientCertRules([]config.ClientCertRule{{SAN: "("}})
+		require.ErrorContains(t, err, "invalid san of client certificate rule 0")
+	})
+}
+
+func TestClientCertificate(t *testing.T) {
+	cert := newTestCertificate(t, &x509.Certificate{DNSNames: []string{"builder.example.com"}})
+	state := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	t.Run("None", func(t *testing.T) {
+		assert.Nil(t, clientCertificate(context.Background(), metadata.MD{}))
+	})
+	t.Run("Context", func(t *testing.T) {
+		ctx := ContextWithClientCertificate(context.Background(), state)
+		assert.Equal(t, cert, clientCertificate(ctx, metadata.MD{}))
+	})
+	t.Run("Forwarded", func(t *testing.T) {
+		r := &http.Request{Header: http.Header{}, TLS: state}
+		ForwardClientCertificate(r)
+		md := metadata.Pairs(clientCertificateHeader, r.Header.Get(clientCertificateHeader))
+		forwarded := clientCertificate(context.Background(), md)
+		require.NotNil(t, forwarded)
+		assert.Equal(t, cert.Raw, forwarded.Raw)
+	})
+	t.Run("ForgedHeaderRemoved", func(t *testing.T) {
+		r := &http.Request{Header: http.Header{clientCertificateHeader: []string{"forged"}}}
+		ForwardClientCertificate(r)
+		assert.Empty(t, r.Header.Get(clientCertificateHeader))
+	})
+	t.Run("ForgedSignature", func(t *testing.T) {
+		r := &http.Request{Header: http.Header{}, TLS: state}
+		ForwardClientCertificate(r)
+		value := r.Header.Get(clientCertificateHeader)
+		forged := value[:len(value)-4] + "AAA="
+		assert.Nil(t, clientCertificate(context.Background(), metadata.Pairs(clientCertificateHeader, forged)))
+	})
+}
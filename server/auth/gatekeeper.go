@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"os"
@@ -22,6 +23,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	workflow "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-workflows/v3/server/auth/serviceaccount"
 	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
@@ -67,12 +69,18 @@ type gatekeeper struct {
 	ssoNamespace string
 	namespaced   bool
 	cache        *cache.ResourceCache
+	// clientCertRules map client certificates to identities, for the client-cert mode
+	clientCertRules []clientCertRule
 }
 
-func NewGatekeeper(modes Modes, clients *servertypes.Clients, restConfig *rest.Config, ssoIf sso.Interface, clientForAuthorization ClientForAuthorization, namespace string, ssoNamespace string, namespaced bool, cache *cache.ResourceCache) (Gatekeeper, error) {
+func NewGatekeeper(modes Modes, clients *servertypes.Clients, restConfig *rest.Config, ssoIf sso.Interface, clientForAuthorization ClientForAuthorization, namespace string, ssoNamespace string, namespaced bool, cache *cache.ResourceCache, clientCertRules []config.ClientCertRule) (Gatekeeper, error) {
 	if len(modes) == 0 {
 		return nil, fmt.Errorf("must specify at least one auth mode")
 	}
+	rules, err := compileClientCertRules(clientCertRules)
+	if err != nil {
+		return nil, err
+	}
 	return &gatekeeper{
 		modes,
 		clients,
@@ -83,6 +91,7 @@ func NewGatekeeper(modes Modes, clients *servertypes.Clients, restConfig *rest.C
 		ssoNamespace,
 		namespaced,
 		cache,
+		rules,
 	}, nil
 
 }
@@ -165,6 +174,12 @@ func getAuthHeaders(md metadata.MD) []string {
 
 func (s gatekeeper) getClients(ctx context.Context, req interface{}) (*servertypes.Clients, *types.Claims, error) {
 	md, _ := metadata.FromIncomingContext(ctx)
+	// certificates are verified by the TLS handshake, so take precedence over authorization headers
+	if s.Modes[ClientCert] {
+		if cert := clientCertificate(ctx, md); cert != nil {
+			return s.clientCertAuthorization(ctx, cert, req)
+		}
+	}
 	authorizations := getAuthHeaders(md)
 	// Required for GetMode() with Server auth when no auth header specified
 	if len(authorizations) == 0 {
@@ -218,6 +233,21 @@ func (s gatekeeper) getClients(ctx context.Context, req interface{}) (*servertyp
 	}
 }
 
+// clientCertAuthorization returns the clients of the service account that the identity of the certificate is
+// authorized as
+func (s gatekeeper) clientCertAuthorization(ctx context.Context, cert *x509.Certificate, req interface{}) (*servertypes.Clients, *types.Claims, error) {
+	claims, err := claimsForCertificate(s.clientCertRules, cert)
+	if err != nil {
+		return nil, nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	clients, err := s.rbacAuthorization(ctx, claims, req)
+	if err != nil {
+		log.WithError(err).Error("failed to perform RBAC authorization of client certificate")
+		return nil, nil, status.Error(codes.PermissionDenied, "not allowed")
+	}
+	return clients, claims, nil
+}
+
 func getNamespace(req interface{}) string {
 	if req == nil {
 		return ""
@@ -15,11 +15,13 @@ const (
 	Client Mode = "client"
 	Server Mode = "server"
 	SSO    Mode = "sso"
+	// ClientCert authenticates clients by the certificates that they present in the TLS handshake
+	ClientCert Mode = "client-cert"
 )
 
 func (m Modes) Add(value string) error {
 	switch value {
-	case "client", "server", "sso":
+	case "client", "server", "sso", "client-cert":
 		m[Mode(value)] = true
 	case "hybrid":
 		m[Client] = true
@@ -32,6 +32,11 @@ func TestModes_Add(t *testing.T) {
 		require.NoError(t, m.Add("sso"))
 		assert.Contains(t, m, SSO)
 	})
+	t.Run("ClientCert", func(t *testing.T) {
+		m := Modes{}
+		require.NoError(t, m.Add("client-cert"))
+		assert.Contains(t, m, ClientCert)
+	})
 }
 
 func TestModes_GetMode(t *testing.T) {
@@ -14,6 +14,7 @@ import (
 	servertypes "github.com/argoproj/argo-workflows/v3/server/types"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
+	"github.com/argoproj/argo-workflows/v3/workflow/defaults"
 	"github.com/argoproj/argo-workflows/v3/workflow/validate"
 
 	serverutils "github.com/argoproj/argo-workflows/v3/server/utils"
@@ -22,10 +23,10 @@ import (
 type ClusterWorkflowTemplateServer struct {
 	instanceIDService instanceid.Service
 	cwftmplStore      servertypes.ClusterWorkflowTemplateStore
-	wfDefaults        *v1alpha1.Workflow
+	wfDefaults        defaults.Getter
 }
 
-func NewClusterWorkflowTemplateServer(instanceID instanceid.Service, cwftmplStore servertypes.ClusterWorkflowTemplateStore, wfDefaults *v1alpha1.Workflow) clusterwftmplpkg.ClusterWorkflowTemplateServiceServer {
+func NewClusterWorkflowTemplateServer(instanceID instanceid.Service, cwftmplStore servertypes.ClusterWorkflowTemplateStore, wfDefaults defaults.Getter) clusterwftmplpkg.ClusterWorkflowTemplateServiceServer {
 	if cwftmplStore == nil {
 		cwftmplStore = NewClusterWorkflowTemplateClientStore()
 	}
@@ -40,7 +41,12 @@ func (cwts *ClusterWorkflowTemplateServer) CreateClusterWorkflowTemplate(ctx con
 	cwts.instanceIDService.Label(req.Template)
 	creator.LabelCreator(ctx, req.Template)
 	cwftmplGetter := cwts.cwftmplStore.Getter(ctx)
-	err := validate.ValidateClusterWorkflowTemplate(nil, cwftmplGetter, req.Template, cwts.wfDefaults, validate.ValidateOpts{})
+	// ClusterWorkflowTemplates are not in any namespace, so only the ClusterWorkflowDefaults apply to them
+	wfDefaults, err := cwts.wfDefaults.GetWorkflowDefaults("")
+	if err != nil {
+		return nil, serverutils.ToStatusError(err, codes.Internal)
+	}
+	err = validate.ValidateClusterWorkflowTemplate(nil, cwftmplGetter, req.Template, wfDefaults, validate.ValidateOpts{})
 	if err != nil {
 		return nil, serverutils.ToStatusError(err, codes.InvalidArgument)
 	}
@@ -107,7 +113,11 @@ func (cwts *ClusterWorkflowTemplateServer) LintClusterWorkflowTemplate(ctx conte
 	creator.LabelCreator(ctx, req.Template)
 	cwftmplGetter := cwts.cwftmplStore.Getter(ctx)
 
-	err := validate.ValidateClusterWorkflowTemplate(nil, cwftmplGetter, req.Template, cwts.wfDefaults, validate.ValidateOpts{Lint: true})
+	wfDefaults, err := cwts.wfDefaults.GetWorkflowDefaults("")
+	if err != nil {
+		return nil, serverutils.ToStatusError(err, codes.Internal)
+	}
+	err = validate.ValidateClusterWorkflowTemplate(nil, cwftmplGetter, req.Template, wfDefaults, validate.ValidateOpts{Lint: true})
 	if err != nil {
 		return nil, serverutils.ToStatusError(err, codes.InvalidArgument)
 	}
@@ -127,7 +137,11 @@ func (cwts *ClusterWorkflowTemplateServer) UpdateClusterWorkflowTemplate(ctx con
 	wfClient := auth.GetWfClient(ctx)
 	cwftmplGetter := cwts.cwftmplStore.Getter(ctx)
 
-	err = validate.ValidateClusterWorkflowTemplate(nil, cwftmplGetter, req.Template, cwts.wfDefaults, validate.ValidateOpts{})
+	wfDefaults, err := cwts.wfDefaults.GetWorkflowDefaults("")
+	if err != nil {
+		return nil, serverutils.ToStatusError(err, codes.Internal)
+	}
+	err = validate.ValidateClusterWorkflowTemplate(nil, cwftmplGetter, req.Template, wfDefaults, validate.ValidateOpts{})
 	if err != nil {
 		return nil, serverutils.ToStatusError(err, codes.InvalidArgument)
 	}
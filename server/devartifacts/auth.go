@@ -0,0 +1,134 @@
+package devartifacts
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const signV4Algorithm = "AWS4-HMAC-SHA256"
+
+// authorization is a parsed AWS Signature Version 4 authorization header
+type authorization struct {
+	accessKey     string
+	scope         string
+	signedHeaders []string
+	signature     string
+}
+
+func parseAuthorization(header string) (*authorization, bool) {
+	rest, ok := strings.CutPrefix(header, signV4Algorithm+" ")
+	if !ok {
+		return nil, false
+	}
+	a := &authorization{}
+	for _, field := range strings.Split(rest, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch name {
+		case "Credential":
+			a.accessKey, a.scope, _ = strings.Cut(value, "/")
+		case "SignedHeaders":
+			a.signedHeaders = strings.Split(value, ";")
+		case "Signature":
+			a.signature = value
+		}
+	}
+	return a, a.accessKey != "" && a.scope != "" && len(a.signedHeaders) > 0 && a.signature != ""
+}
+
+// authenticate verifies the signature of a request that is signed with AWS Signature Version 4 in its headers. The
+// signatures of payloads and of the chunks of streamed payloads are not verified.
+func (s *Server) authenticate(r *http.Request) error {
+	a, ok := parseAuthorization(r.Header.Get("Authorization"))
+	if !ok {
+		return errAccessDenied
+	}
+	if a.accessKey != s.accessKey {
+		return newError(http.StatusForbidden, "InvalidAccessKeyId", "The access key ID you provided does not exist in our records.")
+	}
+	date := r.Header.Get("X-Amz-Date")
+	if date == "" {
+		return errAccessDenied
+	}
+	scopeParts := strings.Split(a.scope, "/")
+	if len(scopeParts) != 4 {
+		return errAccessDenied
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		encodePath(r.URL.Path),
+		strings.ReplaceAll(r.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders(r, a.signedHeaders),
+		strings.Join(a.signedHeaders, ";"),
+		payloadHash(r),
+	}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{signV4Algorithm, date, a.scope, hex.EncodeToString(hash[:])}, "\n")
+	key := []byte("AWS4" + s.secretKey)
+	for _, part := range scopeParts {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	if !hmac.Equal([]byte(signature), []byte(a.signature)) {
+		return newError(http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided.")
+	}
+	return nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func canonicalHeaders(r *http.Request, names []string) string {
+	var b strings.Builder
+	for _, name := range names {
+		var value string
+		switch name {
+		case "host":
+			value = r.Host
+		case "content-length":
+			value = strconv.FormatInt(r.ContentLength, 10)
+		default:
+			var values []string
+			for _, v := range r.Header.Values(name) {
+				values = append(values, strings.Join(strings.Fields(v), " "))
+			}
+			value = strings.Join(values, ",")
+		}
+		b.WriteString(name + ":" + value + "\n")
+	}
+	return b.String()
+}
+
+func payloadHash(r *http.Request) string {
+	if v := r.Header.Get("X-Amz-Content-Sha256"); v != "" {
+		return v
+	}
+	return "UNSIGNED-PAYLOAD"
+}
+
+var unreservedPath = regexp.MustCompile(`^[a-zA-Z0-9\-_.~/]*$`)
+
+// encodePath encodes a path as clients do when they sign it, which is every byte except unreserved characters and
+// slashes
+func encodePath(p string) string {
+	if unreservedPath.MatchString(p) {
+		return p
+	}
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if unreservedPath.Match([]byte{c}) {
+			b.WriteByte(c)
+		} else {
+			b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package devartifacts
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// body returns the payload of a request, decoding it if the client streamed it in signed chunks
+func body(r *http.Request) io.Reader {
+	if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") || strings.Contains(r.Header.Get("Content-Encoding"), "aws-chunked") {
+		return &chunkedReader{r: bufio.NewReader(r.Body)}
+	}
+	return r.Body
+}
+
+// chunkedReader decodes an aws-chunked payload, in which each chunk is prefixed with its size in hex and an optional
+// signature, and the last chunk is empty and may be followed by trailing headers
+type chunkedReader struct {
+	r         *bufio.Reader
+	remaining int64
+	done      bool
+}
+
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	if c.remaining == 0 {
+		if c.done {
+			return 0, io.EOF
+		}
+		if err := c.readHeader(); err != nil {
+			return 0, err
+		}
+		if c.done {
+			return 0, io.EOF
+		}
+	}
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	if errors.Is(err, io.EOF) {
+		return n, io.ErrUnexpectedEOF
+	}
+	if err == nil && c.remaining == 0 {
+		err = c.readCRLF()
+	}
+	return n, err
+}
+
+func (c *chunkedReader) readHeader() error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	size, _, _ := strings.Cut(line, ";")
+	c.remaining, err = strconv.ParseInt(size, 16, 64)
+	if err != nil || c.remaining < 0 {
+		return fmt.Errorf("malformed chunk header %q", line)
+	}
+	if c.remaining > 0 {
+		return nil
+	}
+	c.done = true
+	// skip any trailing headers, up to the empty line that ends the payload
+	for {
+		line, err := c.readLine()
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil || line == "" {
+			return err
+		}
+	}
+}
+
+func (c *chunkedReader) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if errors.Is(err, io.EOF) {
+		return "", io.ErrUnexpectedEOF
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+func (c *chunkedReader) readCRLF() error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if line != "" {
+		return fmt.Errorf("malformed chunk, expected CRLF but got %q", line)
+	}
+	return nil
+}
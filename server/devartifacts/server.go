@@ -0,0 +1,495 @@
+// Package devartifacts is a small artifact store, backed by a local directory, that implements the subset of the S3 API
+// that Argo uses. It lets local development and testing run without an external object store, and is not meant for
+// production use.
+package devartifacts
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// uploadsDir holds the parts of multipart uploads, and tmpDir holds objects whilst they are written. Bucket names
+	// cannot start with a dot, so neither can be mistaken for a bucket.
+	uploadsDir = ".uploads"
+	tmpDir     = ".tmp"
+	// maxKeys is the most keys that are listed at once
+	maxKeys    = 1000
+	timeFormat = "2006-01-02T15:04:05.000Z"
+)
+
+var (
+	bucketName = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	uploadID   = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	// listParameters are the query parameters of requests to list objects. Any other parameter of a request to a
+	// bucket is for a sub-resource, such as its policy, which is not implemented.
+	listParameters = map[string]bool{"list-type": true, "prefix": true, "delimiter": true, "max-keys": true, "marker": true, "continuation-token": true, "start-after": true, "encoding-type": true, "fetch-owner": true, "metadata": true}
+)
+
+// Server serves the buckets that are the directories of its root, each object of which is a file
+type Server struct {
+	root      string
+	accessKey string
+	secretKey string
+}
+
+// New returns a server of the buckets in root, which requests must be signed for with the credentials
+func New(root, accessKey, secretKey string) (*Server, error) {
+	for _, dir := range []string{root, filepath.Join(root, uploadsDir), filepath.Join(root, tmpDir)} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, err
+		}
+	}
+	return &Server{root: root, accessKey: accessKey, secretKey: secretKey}, nil
+}
+
+// CreateBucket creates a bucket, unless it already exists
+func (s *Server) CreateBucket(bucket string) error {
+	if !bucketName.MatchString(bucket) {
+		return errInvalidBucketName
+	}
+	return os.MkdirAll(filepath.Join(s.root, bucket), 0o700)
+}
+
+// Run serves requests on the port until the context is done
+func (s *Server) Run(ctx context.Context, port int) error {
+	srv := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: s, ReadHeaderTimeout: time.Minute}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	log.WithFields(log.Fields{"port": port, "root": s.root}).Info("Dev artifact store started")
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := s.authenticate(r)
+	if err == nil {
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		switch {
+		case bucket == "":
+			err = s.listBuckets(w, r)
+		case !bucketName.MatchString(bucket):
+			err = errInvalidBucketName
+		case key == "":
+			err = s.serveBucket(w, r, bucket)
+		default:
+			err = s.serveObject(w, r, bucket, key)
+		}
+	}
+	if err != nil {
+		writeError(w, r, err)
+	}
+}
+
+func (s *Server) listBuckets(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errMethodNotAllowed
+	}
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		return err
+	}
+	result := listAllMyBucketsResult{Owner: owner{ID: s.accessKey, DisplayName: s.accessKey}}
+	for _, e := range entries {
+		if !e.IsDir() || !bucketName.MatchString(e.Name()) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		result.Buckets = append(result.Buckets, bucketXML{Name: e.Name(), CreationDate: info.ModTime().UTC().Format(timeFormat)})
+	}
+	writeXML(w, http.StatusOK, result)
+	return nil
+}
+
+// bucketDir returns the directory of the bucket, or an error if the bucket does not exist
+func (s *Server) bucketDir(bucket string) (string, error) {
+	dir := filepath.Join(s.root, bucket)
+	info, err := os.Stat(dir)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && !info.IsDir()) {
+		return "", errNoSuchBucket
+	}
+	return dir, err
+}
+
+func (s *Server) serveBucket(w http.ResponseWriter, r *http.Request, bucket string) error {
+	q := r.URL.Query()
+	if r.Method == http.MethodPut {
+		if len(q) > 0 {
+			return errNotImplemented
+		}
+		if _, err := s.bucketDir(bucket); err == nil {
+			return newError(http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it.")
+		}
+		return s.CreateBucket(bucket)
+	}
+	dir, err := s.bucketDir(bucket)
+	if err != nil {
+		return err
+	}
+	switch {
+	case r.Method == http.MethodHead:
+		return nil
+	case r.Method == http.MethodGet && q.Has("location"):
+		// an empty location is the default region
+		writeXML(w, http.StatusOK, locationConstraint{})
+		return nil
+	}
+	for name := range q {
+		if !listParameters[name] {
+			return errNotImplemented
+		}
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return listObjects(w, r, bucket, dir)
+	case http.MethodDelete:
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return newError(http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty.")
+		}
+		if err := os.Remove(dir); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	default:
+		return errMethodNotAllowed
+	}
+}
+
+// listObjects lists the objects of a bucket, with either version of the API, in the order of their keys
+func listObjects(w http.ResponseWriter, r *http.Request, bucket, dir string) error {
+	q := r.URL.Query()
+	prefix, delimiter := q.Get("prefix"), q.Get("delimiter")
+	v2 := q.Get("list-type") == "2"
+	result := listBucketResult{Name: bucket, Prefix: prefix, Delimiter: delimiter, MaxKeys: maxKeys}
+	after := q.Get("marker")
+	result.Marker = after
+	if v2 {
+		result.ContinuationToken, result.StartAfter = q.Get("continuation-token"), q.Get("start-after")
+		after = max(result.ContinuationToken, result.StartAfter)
+	}
+	if v := q.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return newError(http.StatusBadRequest, "InvalidArgument", "max-keys must be a non-negative integer.")
+		}
+		result.MaxKeys = min(n, maxKeys)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	objects := map[string]fs.DirEntry{}
+	var keys []string
+	for _, e := range entries {
+		key, err := url.PathUnescape(e.Name())
+		if err != nil || !e.Type().IsRegular() || !strings.HasPrefix(key, prefix) || key <= after {
+			continue
+		}
+		objects[key] = e
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	count, last := 0, ""
+	for _, key := range keys {
+		commonPrefix := ""
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				commonPrefix = key[:len(prefix)+i+len(delimiter)]
+			}
+		}
+		if commonPrefix != "" && (commonPrefix == last || commonPrefix <= after) {
+			continue
+		}
+		if count == result.MaxKeys {
+			result.IsTruncated = true
+			break
+		}
+		count++
+		if commonPrefix != "" {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefixXML{Prefix: commonPrefix})
+			last = commonPrefix
+			continue
+		}
+		info, err := objects[key].Info()
+		if err != nil {
+			return err
+		}
+		result.Contents = append(result.Contents, objectXML{Key: key, LastModified: info.ModTime().UTC().Format(timeFormat), ETag: etag(info), Size: info.Size(), StorageClass: "STANDARD"})
+		last = key
+	}
+	if v2 {
+		result.KeyCount = count
+		result.Marker = ""
+	}
+	if result.IsTruncated {
+		if v2 {
+			result.NextContinuationToken = last
+		} else {
+			result.NextMarker = last
+		}
+	}
+	writeXML(w, http.StatusOK, result)
+	return nil
+}
+
+// fileName returns the name of the file of an object. Keys are escaped, so that every object is a file in the
+// directory of its bucket and one key can be the prefix of another, as in S3.
+func fileName(key string) (string, error) {
+	name := url.PathEscape(key)
+	if strings.HasPrefix(name, ".") {
+		name = "%2E" + name[1:]
+	}
+	if len(name) > 255 {
+		return "", newError(http.StatusBadRequest, "KeyTooLongError", "Your key is too long.")
+	}
+	return name, nil
+}
+
+// etag returns the entity tag of an object, which changes whenever the object is written
+func etag(info fs.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	dir, err := s.bucketDir(bucket)
+	if err != nil {
+		return err
+	}
+	name, err := fileName(key)
+	if err != nil {
+		return err
+	}
+	p := filepath.Join(dir, name)
+	q := r.URL.Query()
+	id := q.Get("uploadId")
+	switch {
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		return s.createMultipartUpload(w, bucket, key)
+	case r.Method == http.MethodPut && id != "":
+		return s.uploadPart(w, r, id)
+	case r.Method == http.MethodPost && id != "":
+		return s.completeMultipartUpload(w, r, bucket, key, id, p)
+	case r.Method == http.MethodDelete && id != "":
+		return s.abortMultipartUpload(w, id)
+	case len(q) > 0 && !(len(q) == 1 && q.Has("x-id")):
+		return errNotImplemented
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		f, err := os.Open(filepath.Clean(p))
+		if errors.Is(err, fs.ErrNotExist) {
+			return errNoSuchKey
+		}
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		w.Header().Set("ETag", etag(info))
+		http.ServeContent(w, r, path.Base(key), info.ModTime(), f)
+		return nil
+	case http.MethodPut:
+		if r.Header.Get("X-Amz-Copy-Source") != "" {
+			return errNotImplemented
+		}
+		if _, err := s.writeFile(p, body(r), r.Header.Get("Content-MD5")); err != nil {
+			return err
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		w.Header().Set("ETag", etag(info))
+		return nil
+	case http.MethodDelete:
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	default:
+		return errMethodNotAllowed
+	}
+}
+
+// writeFile writes the file from the reader, replacing it only once the whole of it has been read, and returns the
+// MD5 digest of its content. If the client sent a digest, then the content must match it.
+func (s *Server) writeFile(p string, r io.Reader, contentMD5 string) ([]byte, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.root, tmpDir), "object-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	h := md5.New() //nolint:gosec
+	if _, err := io.Copy(io.MultiWriter(tmp, h), r); err != nil {
+		_ = tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	sum := h.Sum(nil)
+	if contentMD5 != "" && contentMD5 != base64.StdEncoding.EncodeToString(sum) {
+		return nil, newError(http.StatusBadRequest, "BadDigest", "The Content-MD5 you specified did not match what we received.")
+	}
+	return sum, os.Rename(tmp.Name(), p)
+}
+
+// upload is the object that a multipart upload is for
+type upload struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+}
+
+// uploadDir returns the directory of the parts of a multipart upload, or an error if the upload does not exist
+func (s *Server) uploadDir(id string) (string, error) {
+	if !uploadID.MatchString(id) {
+		return "", errNoSuchUpload
+	}
+	dir := filepath.Join(s.root, uploadsDir, id)
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return "", errNoSuchUpload
+	} else if err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func (s *Server) createMultipartUpload(w http.ResponseWriter, bucket, key string) error {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	id := hex.EncodeToString(b)
+	dir := filepath.Join(s.root, uploadsDir, id)
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(upload{Bucket: bucket, Key: key})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "upload.json"), data, 0o600); err != nil {
+		return err
+	}
+	writeXML(w, http.StatusOK, initiateMultipartUploadResult{Bucket: bucket, Key: key, UploadID: id})
+	return nil
+}
+
+func (s *Server) uploadPart(w http.ResponseWriter, r *http.Request, id string) error {
+	dir, err := s.uploadDir(id)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || n < 1 || n > 10000 {
+		return newError(http.StatusBadRequest, "InvalidArgument", "Part number must be an integer between 1 and 10000, inclusive.")
+	}
+	sum, err := s.writeFile(filepath.Join(dir, strconv.Itoa(n)), body(r), r.Header.Get("Content-MD5"))
+	if err != nil {
+		return err
+	}
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum)+`"`)
+	return nil
+}
+
+func (s *Server) completeMultipartUpload(w http.ResponseWriter, r *http.Request, bucket, key, id, p string) error {
+	dir, err := s.uploadDir(id)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "upload.json"))
+	if err != nil {
+		return err
+	}
+	var u upload
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if u.Bucket != bucket || u.Key != key {
+		return errNoSuchUpload
+	}
+	var req completeMultipartUpload
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Parts) == 0 {
+		return newError(http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema.")
+	}
+	var readers []io.Reader
+	for _, part := range req.Parts {
+		f, err := os.Open(filepath.Join(dir, strconv.Itoa(part.PartNumber)))
+		if errors.Is(err, fs.ErrNotExist) {
+			return newError(http.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found.")
+		}
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		readers = append(readers, f)
+	}
+	if _, err := s.writeFile(p, io.MultiReader(readers...), ""); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return err
+	}
+	writeXML(w, http.StatusOK, completeMultipartUploadResult{Location: r.URL.Path, Bucket: bucket, Key: key, ETag: etag(info)})
+	return nil
+}
+
+func (s *Server) abortMultipartUpload(w http.ResponseWriter, id string) error {
+	dir, err := s.uploadDir(id)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func writeXML(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, xml.Header)
+	_ = xml.NewEncoder(w).Encode(v)
+}
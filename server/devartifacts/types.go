@@ -0,0 +1,125 @@
+package devartifacts
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type owner struct {
+	ID          string
+	DisplayName string
+}
+
+type bucketXML struct {
+	Name         string
+	CreationDate string
+}
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name    `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+	Owner   owner       `xml:"Owner"`
+	Buckets []bucketXML `xml:"Buckets>Bucket"`
+}
+
+type locationConstraint struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
+}
+
+type objectXML struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+type commonPrefixXML struct {
+	Prefix string
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name                  string
+	Prefix                string
+	Marker                string `xml:",omitempty"`
+	NextMarker            string `xml:",omitempty"`
+	ContinuationToken     string `xml:",omitempty"`
+	NextContinuationToken string `xml:",omitempty"`
+	StartAfter            string `xml:",omitempty"`
+	KeyCount              int    `xml:",omitempty"`
+	MaxKeys               int
+	Delimiter             string `xml:",omitempty"`
+	IsTruncated           bool
+	Contents              []objectXML
+	CommonPrefixes        []commonPrefixXML
+}
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ InitiateMultipartUploadResult"`
+	Bucket   string
+	Key      string
+	UploadID string `xml:"UploadId"`
+}
+
+type completeMultipartUpload struct {
+	Parts []struct {
+		PartNumber int
+		ETag       string
+	} `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CompleteMultipartUploadResult"`
+	Location string
+	Bucket   string
+	Key      string
+	ETag     string
+}
+
+type errorResponse struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string
+	Message  string
+	Resource string
+}
+
+// apiError is an error that is returned to the client with its S3 error code
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func newError(status int, code, message string) *apiError {
+	return &apiError{status: status, code: code, message: message}
+}
+
+func (e *apiError) Error() string {
+	return e.code + ": " + e.message
+}
+
+var (
+	errAccessDenied      = newError(http.StatusForbidden, "AccessDenied", "Access Denied.")
+	errInvalidBucketName = newError(http.StatusBadRequest, "InvalidBucketName", "The specified bucket is not valid.")
+	errMethodNotAllowed  = newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	errNoSuchBucket      = newError(http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+	errNoSuchKey         = newError(http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+	errNoSuchUpload      = newError(http.StatusNotFound, "NoSuchUpload", "The specified multipart upload does not exist.")
+	errNotImplemented    = newError(http.StatusNotImplemented, "NotImplemented", "A header or query you provided implies functionality that is not implemented.")
+)
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		log.WithError(err).WithFields(log.Fields{"method": r.Method, "path": r.URL.Path}).Error("Dev artifact store request failed")
+		apiErr = newError(http.StatusInternalServerError, "InternalError", "We encountered an internal error. Please try again.")
+	}
+	if r.Method == http.MethodHead {
+		w.WriteHeader(apiErr.status)
+		return
+	}
+	writeXML(w, apiErr.status, errorResponse{Code: apiErr.code, Message: apiErr.message, Resource: r.URL.Path})
+}
@@ -25,6 +25,7 @@ import (
 	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
@@ -32,11 +33,13 @@ type Operation struct {
 	ctx               context.Context
 	eventRecorder     record.EventRecorder
 	instanceIDService instanceid.Service
+	hydrator          hydrator.Interface
 	events            []wfv1.WorkflowEventBinding
+	namespace         string
 	env               map[string]interface{}
 }
 
-func NewOperation(ctx context.Context, instanceIDService instanceid.Service, eventRecorder record.EventRecorder, events []wfv1.WorkflowEventBinding, namespace, discriminator string, payload *wfv1.Item) (*Operation, error) {
+func NewOperation(ctx context.Context, instanceIDService instanceid.Service, eventRecorder record.EventRecorder, hydrator hydrator.Interface, events []wfv1.WorkflowEventBinding, namespace, discriminator string, payload *wfv1.Item) (*Operation, error) {
 	env, err := expressionEnvironment(ctx, namespace, discriminator, payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create workflow template expression environment: %w", err)
@@ -45,7 +48,9 @@ func NewOperation(ctx context.Context, instanceIDService instanceid.Service, eve
 		ctx:               ctx,
 		eventRecorder:     eventRecorder,
 		instanceIDService: instanceIDService,
+		hydrator:          hydrator,
 		events:            events,
+		namespace:         namespace,
 		env:               env,
 	}, nil
 }
@@ -70,6 +75,10 @@ func (o *Operation) Dispatch(ctx context.Context) error {
 			errs = append(errs, err)
 		}
 	}
+	if err := o.resumeSuspendedNodes(ctx); err != nil {
+		log.WithError(err).WithField("namespace", o.namespace).Error("failed to resume suspended nodes from event")
+		errs = append(errs, err)
+	}
 	if len(errs) > 0 {
 		return fmt.Errorf("failed to dispatch event: %v", errs)
 	}
@@ -145,6 +154,76 @@ func (o *Operation) dispatch(ctx context.Context, wfeb wfv1.WorkflowEventBinding
 	return nil, nil
 }
 
+// resumeSuspendedNodes resumes the active suspend nodes in the namespace whose event selector matches the event. Only
+// the incomplete workflows that the controller labelled as awaiting an event are listed. A workflow whose nodes cannot
+// be evaluated or resumed is logged and recorded as an event of the workflow, rather than failing the dispatch, so that
+// it does not keep the nodes of other workflows from being resumed.
+func (o *Operation) resumeSuspendedNodes(ctx context.Context) error {
+	options := metav1.ListOptions{LabelSelector: common.LabelKeyCompleted + "!=true," + common.LabelKeyAwaitingEvent + "=true"}
+	o.instanceIDService.With(&options)
+	wfIf := auth.GetWfClient(o.ctx).ArgoprojV1alpha1().Workflows(o.namespace)
+	list, err := wfIf.List(ctx, options)
+	if err != nil {
+		return fmt.Errorf("failed to list workflows: %w", err)
+	}
+	for _, wf := range list.Items {
+		if o.hydrator.IsHydrated(&wf) && !wf.Status.AnyActiveSuspendNode() {
+			continue
+		}
+		if err := o.hydrator.Hydrate(&wf); err != nil {
+			o.workflowError(&wf, fmt.Errorf("failed to hydrate workflow: %w", err))
+			continue
+		}
+		for _, node := range wf.Status.Nodes {
+			if !node.IsActiveSuspendNode() {
+				continue
+			}
+			tmpl := wf.GetTemplateByName(util.GetTemplateFromNode(node))
+			if tmpl == nil {
+				continue
+			}
+			selector := tmpl.Suspend.GetEventSelector()
+			if selector == "" {
+				continue
+			}
+			matched, err := argoexpr.EvalBool(selector, o.nodeEnv(node))
+			if err != nil {
+				o.workflowError(&wf, fmt.Errorf("failed to evaluate suspend event selector of node %s: %w", node.ID, err))
+				continue
+			}
+			log.WithFields(log.Fields{"namespace": wf.Namespace, "workflow": wf.Name, "node": node.ID, "selector": selector, "matched": matched}).Debug("Suspend event selector evaluation")
+			if !matched {
+				continue
+			}
+			if err := util.ResumeWorkflow(ctx, wfIf, o.hydrator, wf.Name, "id="+node.ID); err != nil {
+				o.workflowError(&wf, fmt.Errorf("failed to resume node %s: %w", node.ID, err))
+			}
+		}
+	}
+	return nil
+}
+
+func (o *Operation) workflowError(wf *wfv1.Workflow, err error) {
+	log.WithError(err).WithFields(log.Fields{"namespace": wf.Namespace, "workflow": wf.Name}).Error("failed to resume suspended nodes from event")
+	o.eventRecorder.Event(wf, corev1.EventTypeWarning, "WorkflowEventError", "failed to resume suspended nodes from event: "+err.Error())
+}
+
+// nodeEnv returns the expression environment for the node, which is the event environment plus the node's inputs
+func (o *Operation) nodeEnv(node wfv1.NodeStatus) map[string]interface{} {
+	parameters := make(map[string]interface{})
+	if node.Inputs != nil {
+		for _, p := range node.Inputs.Parameters {
+			parameters[p.Name] = p.GetValue()
+		}
+	}
+	env := make(map[string]interface{}, len(o.env)+1)
+	for k, v := range o.env {
+		env[k] = v
+	}
+	env["inputs"] = map[string]interface{}{"parameters": parameters}
+	return env
+}
+
 func (o *Operation) populateWorkflowMetadata(wf *wfv1.Workflow, metadata *metav1.ObjectMeta) error {
 	if len(metadata.Name) > 0 {
 		evalName, err := o.evaluateStringExpression(metadata.Name, "name")
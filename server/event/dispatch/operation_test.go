@@ -20,6 +20,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
 )
 
 func Test_metaData(t *testing.T) {
@@ -61,7 +62,7 @@ func TestNewOperation(t *testing.T) {
 	recorder := record.NewFakeRecorder(6)
 
 	// act
-	operation, err := NewOperation(ctx, instanceid.NewService("my-instanceid"), recorder, []wfv1.WorkflowEventBinding{
+	operation, err := NewOperation(ctx, instanceid.NewService("my-instanceid"), recorder, hydratorfake.Noop, []wfv1.WorkflowEventBinding{
 		// test a malformed binding
 		{
 			ObjectMeta: metav1.ObjectMeta{Name: "malformed", Namespace: "my-ns"},
@@ -207,7 +208,7 @@ func Test_populateWorkflowMetadata(t *testing.T) {
 	recorder := record.NewFakeRecorder(10)
 
 	// act
-	operation, err := NewOperation(ctx, instanceid.NewService("my-instanceid"), recorder, []wfv1.WorkflowEventBinding{
+	operation, err := NewOperation(ctx, instanceid.NewService("my-instanceid"), recorder, hydratorfake.Noop, []wfv1.WorkflowEventBinding{
 		{
 			// No name specified
 			ObjectMeta: metav1.ObjectMeta{Name: "my-wfeb-1", Namespace: "my-ns"},
@@ -412,3 +413,55 @@ func Test_expressionEnvironment(t *testing.T) {
 	assert.Contains(t, env, "metadata")
 	assert.Equal(t, map[string]interface{}{"foo": "bar"}, env["payload"], "make sure we parse an object as a map")
 }
+
+func TestResumeSuspendedNodes(t *testing.T) {
+	suspendedWorkflow := func(name, selector string) *wfv1.Workflow {
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns", Labels: map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid", common.LabelKeyAwaitingEvent: "true"}},
+			Spec: wfv1.WorkflowSpec{
+				Templates: []wfv1.Template{{Name: "approve", Suspend: &wfv1.SuspendTemplate{Event: &wfv1.SuspendEvent{Selector: selector}}}},
+			},
+			Status: wfv1.WorkflowStatus{
+				Phase: wfv1.WorkflowRunning,
+				Nodes: wfv1.Nodes{
+					name: wfv1.NodeStatus{
+						ID:           name,
+						Name:         name,
+						Type:         wfv1.NodeTypeSuspend,
+						Phase:        wfv1.NodeRunning,
+						TemplateName: "approve",
+						Inputs:       &wfv1.Inputs{Parameters: []wfv1.Parameter{{Name: "ticket", Value: wfv1.AnyStringPtr("123")}}},
+					},
+				},
+			},
+		}
+	}
+	unlabelled := suspendedWorkflow("my-wf-3", `payload.ticket == inputs.parameters.ticket`)
+	delete(unlabelled.Labels, common.LabelKeyAwaitingEvent)
+	client := fake.NewSimpleClientset(
+		suspendedWorkflow("my-wf-0", `payload.ticket`),
+		suspendedWorkflow("my-wf-1", `payload.ticket == inputs.parameters.ticket`),
+		suspendedWorkflow("my-wf-2", `payload.ticket == "456"`),
+		unlabelled,
+	)
+	ctx := context.WithValue(context.WithValue(context.Background(), auth.WfKey, client), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}})
+	recorder := record.NewFakeRecorder(10)
+
+	operation, err := NewOperation(ctx, instanceid.NewService("my-instanceid"), recorder, hydratorfake.Noop, nil, "my-ns", "my-discriminator", &wfv1.Item{Value: json.RawMessage(`{"ticket": "123"}`)})
+	require.NoError(t, err)
+	err = operation.Dispatch(ctx)
+	require.NoError(t, err, "a workflow whose selector cannot be evaluated does not fail the dispatch")
+	assert.Contains(t, <-recorder.Events, "WorkflowEventError")
+
+	wf, err := client.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeSucceeded, wf.Status.Nodes["my-wf-1"].Phase)
+
+	wf, err = client.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf-2", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["my-wf-2"].Phase)
+
+	wf, err = client.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf-3", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["my-wf-3"].Phase, "workflows that are not awaiting an event are not listed")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/event/dispatch"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
 )
@@ -22,6 +23,7 @@ import (
 type Controller struct {
 	instanceIDService    instanceid.Service
 	eventRecorderManager events.EventRecorderManager
+	hydrator             hydrator.Interface
 	// a channel for operations to be executed async on
 	operationQueue chan dispatch.Operation
 	workerCount    int
@@ -30,12 +32,13 @@ type Controller struct {
 
 var _ eventpkg.EventServiceServer = &Controller{}
 
-func NewController(instanceIDService instanceid.Service, eventRecorderManager events.EventRecorderManager, operationQueueSize, workerCount int, asyncDispatch bool) *Controller {
+func NewController(instanceIDService instanceid.Service, eventRecorderManager events.EventRecorderManager, hydrator hydrator.Interface, operationQueueSize, workerCount int, asyncDispatch bool) *Controller {
 	log.WithFields(log.Fields{"workerCount": workerCount, "operationQueueSize": operationQueueSize, "asyncDispatch": asyncDispatch}).Info("Creating event controller")
 
 	return &Controller{
 		instanceIDService:    instanceIDService,
 		eventRecorderManager: eventRecorderManager,
+		hydrator:             hydrator,
 		//  so we can have `operationQueueSize` operations outstanding before we start putting back pressure on the senders
 		operationQueue: make(chan dispatch.Operation, operationQueueSize),
 		workerCount:    workerCount,
@@ -77,7 +80,7 @@ func (s *Controller) ReceiveEvent(ctx context.Context, req *eventpkg.EventReques
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	operation, err := dispatch.NewOperation(ctx, s.instanceIDService, s.eventRecorderManager.Get(req.Namespace), list.Items, req.Namespace, req.Discriminator, req.Payload)
+	operation, err := dispatch.NewOperation(ctx, s.instanceIDService, s.eventRecorderManager.Get(req.Namespace), s.hydrator, list.Items, req.Namespace, req.Discriminator, req.Payload)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
+	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
 )
 
 func TestController(t *testing.T) {
@@ -23,7 +24,7 @@ func TestController(t *testing.T) {
 	instanceIDService := instanceid.NewService("my-instanceid")
 	eventRecorderManager := events.NewEventRecorderManager(fakekube.NewSimpleClientset())
 	newController := func(asyncDispatch bool) *Controller {
-		return NewController(instanceIDService, eventRecorderManager, 1, 1, asyncDispatch)
+		return NewController(instanceIDService, eventRecorderManager, hydratorfake.Noop, 1, 1, asyncDispatch)
 	}
 	e1 := &eventpkg.EventRequest{Namespace: "my-ns", Payload: &wfv1.Item{}}
 	e2 := &eventpkg.EventRequest{}
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -73,6 +74,10 @@ func ToStatusError(err error, code codes.Code) error {
 	if alreadyConverted {
 		return err
 	}
+	var fieldErr argoerrors.FieldError
+	if errors.As(err, &fieldErr) {
+		return grpcutil.TranslateError(err)
+	}
 	var argoerr argoerrors.ArgoError
 	if errors.As(err, &argoerr) {
 		newErr, converted := httpToStatusError(argoerr.HTTPCode(), err.Error())
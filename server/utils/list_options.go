@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +19,8 @@ type ListOptions struct {
 	MinStartedAt, MaxStartedAt   time.Time
 	CreatedAfter, FinishedBefore time.Time
 	LabelRequirements            labels.Requirements
+	OutputParameters             map[string]string
+	Owner                        map[string]string
 	Limit, Offset                int
 	ShowRemainingItemCount       bool
 	StartedAtAscending           bool
@@ -53,6 +56,15 @@ func (l ListOptions) WithStartedAtAscending(ascending bool) ListOptions {
 	return l
 }
 
+// outputParameterSelectorPrefix is the prefix of the field selectors on the values of the workflows' output parameters
+const outputParameterSelectorPrefix = "status.outputs.parameters."
+
+// ownerSelectorPrefix is the prefix of the field selectors on the owner of the workflows
+const ownerSelectorPrefix = "spec.owner."
+
+// ownerFields are the fields of the owner of the workflows that can be selected on
+var ownerFields = []string{"team", "email", "slackChannel"}
+
 func BuildListOptions(options metav1.ListOptions, ns, namePrefix, nameFilter, createdAfter, finishedBefore string) (ListOptions, error) {
 	if options.Continue == "" {
 		options.Continue = "0"
@@ -92,6 +104,8 @@ func BuildListOptions(options metav1.ListOptions, ns, namePrefix, nameFilter, cr
 		}
 	}
 	showRemainingItemCount := false
+	var outputParameters map[string]string
+	var owner map[string]string
 	for _, selector := range strings.Split(options.FieldSelector, ",") {
 		if len(selector) == 0 {
 			continue
@@ -123,6 +137,24 @@ func BuildListOptions(options metav1.ListOptions, ns, namePrefix, nameFilter, cr
 				// no need to use sutils here
 				return ListOptions{}, ToStatusError(err, codes.Internal)
 			}
+		} else if strings.HasPrefix(selector, outputParameterSelectorPrefix) {
+			name, value, ok := strings.Cut(strings.TrimPrefix(selector, outputParameterSelectorPrefix), "=")
+			if !ok || name == "" {
+				return ListOptions{}, status.Errorf(codes.InvalidArgument, "%s must be of the form %s<name>=<value>", selector, outputParameterSelectorPrefix)
+			}
+			if outputParameters == nil {
+				outputParameters = make(map[string]string)
+			}
+			outputParameters[name] = value
+		} else if strings.HasPrefix(selector, ownerSelectorPrefix) {
+			field, value, ok := strings.Cut(strings.TrimPrefix(selector, ownerSelectorPrefix), "=")
+			if !ok || !slices.Contains(ownerFields, field) {
+				return ListOptions{}, status.Errorf(codes.InvalidArgument, "%s must be of the form %s<field>=<value>, where the field is one of %s", selector, ownerSelectorPrefix, strings.Join(ownerFields, ", "))
+			}
+			if owner == nil {
+				owner = make(map[string]string)
+			}
+			owner[field] = value
 		} else if strings.HasPrefix(selector, "ext.showRemainingItemCount") {
 			showRemainingItemCount, err = strconv.ParseBool(strings.TrimPrefix(selector, "ext.showRemainingItemCount="))
 			if err != nil {
@@ -147,6 +179,8 @@ func BuildListOptions(options metav1.ListOptions, ns, namePrefix, nameFilter, cr
 		MinStartedAt:           minStartedAt,
 		MaxStartedAt:           maxStartedAt,
 		LabelRequirements:      requirements,
+		OutputParameters:       outputParameters,
+		Owner:                  owner,
 		Limit:                  limit,
 		Offset:                 offset,
 		ShowRemainingItemCount: showRemainingItemCount,
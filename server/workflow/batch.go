@@ -0,0 +1,198 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// maxWorkflowBatchSize is the most workflows that can be submitted in one batch, so that a batch is submitted well
+// within the timeouts of clients and proxies
+const maxWorkflowBatchSize = 1000
+
+// defaultWorkflowBatchRetryParallelism and maxWorkflowBatchRetryParallelism bound how many workflows of a batch are
+// retried at once, so that a batch retry does not flood the Kubernetes API
+const (
+	defaultWorkflowBatchRetryParallelism = 10
+	maxWorkflowBatchRetryParallelism     = 100
+)
+
+// SubmitWorkflowBatch creates each of the workflows as CreateWorkflow does, and returns the result of each
+func (s *workflowServer) SubmitWorkflowBatch(ctx context.Context, req *workflowbatchpkg.WorkflowBatchSubmitRequest) (*workflowbatchpkg.WorkflowBatchSubmitResponse, error) {
+	if len(req.Workflows) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "workflows are required")
+	}
+	if len(req.Workflows) > maxWorkflowBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "a batch may not have more than %d workflows, but has %d", maxWorkflowBatchSize, len(req.Workflows))
+	}
+	items := make([]*workflowbatchpkg.WorkflowBatchSubmitResult, len(req.Workflows))
+	for i := range items {
+		items[i] = &workflowbatchpkg.WorkflowBatchSubmitResult{}
+	}
+	if req.DryRun || req.AllOrNothing {
+		invalid := false
+		for i := range req.Workflows {
+			wf, err := s.CreateWorkflow(ctx, &workflowpkg.WorkflowCreateRequest{
+				Namespace:     req.Namespace,
+				Workflow:      req.Workflows[i].DeepCopy(),
+				CreateOptions: &metav1.CreateOptions{DryRun: []string{"All"}},
+			})
+			if err != nil {
+				items[i].Error = status.Convert(err).Message()
+				invalid = true
+			} else if req.DryRun {
+				items[i].Workflow = wf
+			}
+		}
+		if invalid && req.AllOrNothing {
+			markNotCreated(items, "not created, as another workflow of the batch is invalid")
+			return &workflowbatchpkg.WorkflowBatchSubmitResponse{Items: items}, nil
+		}
+		if req.DryRun {
+			return &workflowbatchpkg.WorkflowBatchSubmitResponse{Items: items}, nil
+		}
+	}
+	for i := range req.Workflows {
+		wf, err := s.CreateWorkflow(ctx, &workflowpkg.WorkflowCreateRequest{
+			Namespace:    req.Namespace,
+			Workflow:     req.Workflows[i].DeepCopy(),
+			ServerDryRun: req.ServerDryRun,
+		})
+		if err != nil {
+			items[i].Error = status.Convert(err).Message()
+			if req.AllOrNothing {
+				if !req.ServerDryRun {
+					s.deleteWorkflowBatch(ctx, items)
+				}
+				markNotCreated(items, "not created, as another workflow of the batch could not be created")
+				break
+			}
+			continue
+		}
+		items[i].Workflow = wf
+	}
+	return &workflowbatchpkg.WorkflowBatchSubmitResponse{Items: items}, nil
+}
+
+// markNotCreated sets the error of the items that have neither a workflow nor an error
+func markNotCreated(items []*workflowbatchpkg.WorkflowBatchSubmitResult, message string) {
+	for i := range items {
+		if items[i].Workflow == nil && items[i].Error == "" {
+			items[i].Error = message
+		}
+	}
+}
+
+// deleteWorkflowBatch deletes the workflows of the batch that were created. Workflows that cannot be deleted are
+// left with an error that says so.
+func (s *workflowServer) deleteWorkflowBatch(ctx context.Context, items []*workflowbatchpkg.WorkflowBatchSubmitResult) {
+	wfClient := auth.GetWfClient(ctx)
+	for i, item := range items {
+		if item.Workflow == nil {
+			continue
+		}
+		err := wfClient.ArgoprojV1alpha1().Workflows(item.Workflow.Namespace).Delete(ctx, item.Workflow.Name, metav1.DeleteOptions{})
+		if err != nil {
+			log.WithError(err).WithField("workflow", item.Workflow.Name).Error("Failed to delete workflow of batch")
+			items[i].Error = fmt.Sprintf("created, but could not be deleted after another workflow of the batch could not be created: %v", err)
+			continue
+		}
+		items[i] = &workflowbatchpkg.WorkflowBatchSubmitResult{Error: "deleted, as another workflow of the batch could not be created"}
+	}
+}
+
+// RetryWorkflowBatch retries each of the failed and errored workflows that match the selector as RetryWorkflow does,
+// at most req.Parallelism at once, and returns the result of each
+func (s *workflowServer) RetryWorkflowBatch(ctx context.Context, req *workflowbatchpkg.WorkflowBatchRetryRequest) (*workflowbatchpkg.WorkflowBatchRetryResponse, error) {
+	if req.LabelSelector == "" {
+		return nil, status.Error(codes.InvalidArgument, "labelSelector is required")
+	}
+	selector, err := labels.Parse(req.LabelSelector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid labelSelector: %v", err)
+	}
+	parallelism := int(req.Parallelism)
+	if parallelism == 0 {
+		parallelism = defaultWorkflowBatchRetryParallelism
+	}
+	if parallelism < 0 || parallelism > maxWorkflowBatchRetryParallelism {
+		return nil, status.Errorf(codes.InvalidArgument, "parallelism must be between 1 and %d", maxWorkflowBatchRetryParallelism)
+	}
+	failed, err := labels.NewRequirement(common.LabelKeyPhase, selection.In, []string{string(wfv1.WorkflowFailed), string(wfv1.WorkflowError)})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	listOptions := metav1.ListOptions{LabelSelector: selector.Add(*failed).String()}
+	s.instanceIDService.With(&listOptions)
+	list, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(req.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	var wfs wfv1.Workflows
+	for _, wf := range list.Items {
+		// the phase label may lag behind the status
+		if wf.Status.Phase == wfv1.WorkflowFailed || wf.Status.Phase == wfv1.WorkflowError {
+			wfs = append(wfs, wf)
+		}
+	}
+	sort.Slice(wfs, func(i, j int) bool { return wfs[i].Name < wfs[j].Name })
+
+	items := make([]*workflowbatchpkg.WorkflowBatchRetryResult, len(wfs))
+	if req.DryRun {
+		for i := range wfs {
+			items[i] = &workflowbatchpkg.WorkflowBatchRetryResult{Name: wfs[i].Name, Workflow: &wfs[i]}
+		}
+		return &workflowbatchpkg.WorkflowBatchRetryResponse{Items: items}, nil
+	}
+	logCtx := log.WithFields(log.Fields{"namespace": req.Namespace, "labelSelector": req.LabelSelector, "workflows": len(wfs)})
+	logCtx.Info("Retrying workflow batch")
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i := range wfs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			items[i] = &workflowbatchpkg.WorkflowBatchRetryResult{Name: wfs[i].Name}
+			wf, err := s.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{
+				Name:              wfs[i].Name,
+				Namespace:         wfs[i].Namespace,
+				RestartSuccessful: req.RestartSuccessful,
+				NodeFieldSelector: req.NodeFieldSelector,
+				Parameters:        req.Parameters,
+			})
+			if err != nil {
+				items[i].Error = status.Convert(err).Message()
+				return
+			}
+			items[i].Workflow = wf
+		}(i)
+	}
+	wg.Wait()
+	errored := 0
+	for _, item := range items {
+		if item.Error != "" {
+			errored++
+		}
+	}
+	logCtx.WithField("errors", errored).Info("Retried workflow batch")
+	return &workflowbatchpkg.WorkflowBatchRetryResponse{Items: items}, nil
+}
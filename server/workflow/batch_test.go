@@ -0,0 +1,168 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	v1alpha "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestSubmitWorkflowBatch(t *testing.T) {
+	// the fake clientset does not generate names, so each workflow is named
+	newWorkflows := func(names ...string) []*v1alpha1.Workflow {
+		var wfs []*v1alpha1.Workflow
+		for _, name := range names {
+			var req workflowpkg.WorkflowCreateRequest
+			v1alpha1.MustUnmarshal(workflow1, &req)
+			req.Workflow.Name = name
+			wfs = append(wfs, req.Workflow)
+		}
+		return wfs
+	}
+	invalid := func(wfs []*v1alpha1.Workflow, i int) []*v1alpha1.Workflow {
+		wfs[i].Spec.Entrypoint = "missing"
+		return wfs
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		server, ctx := getWorkflowServer()
+		_, err := server.(*workflowServer).SubmitWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchSubmitRequest{Namespace: "default"})
+		require.Error(t, err)
+	})
+	t.Run("TooMany", func(t *testing.T) {
+		server, ctx := getWorkflowServer()
+		wfs := make([]*v1alpha1.Workflow, maxWorkflowBatchSize+1)
+		_, err := server.(*workflowServer).SubmitWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchSubmitRequest{Namespace: "default", Workflows: wfs})
+		require.Error(t, err)
+	})
+	t.Run("Partial", func(t *testing.T) {
+		server, ctx := getWorkflowServer()
+		resp, err := server.(*workflowServer).SubmitWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchSubmitRequest{
+			Namespace: "default",
+			Workflows: invalid(newWorkflows("batch-0", "batch-1", "batch-2"), 1),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 3)
+		assert.NotNil(t, resp.Items[0].Workflow)
+		assert.Nil(t, resp.Items[1].Workflow)
+		assert.NotEmpty(t, resp.Items[1].Error)
+		assert.NotNil(t, resp.Items[2].Workflow)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		server, ctx := getWorkflowServer()
+		resp, err := server.(*workflowServer).SubmitWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchSubmitRequest{
+			Namespace: "default",
+			Workflows: newWorkflows("batch-0", "batch-1"),
+			DryRun:    true,
+		})
+		require.NoError(t, err)
+		for _, item := range resp.Items {
+			assert.NotNil(t, item.Workflow)
+			assert.Empty(t, item.Error)
+		}
+		_, err = auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("default").Get(ctx, "batch-0", metav1.GetOptions{})
+		assert.True(t, apierr.IsNotFound(err))
+	})
+	t.Run("AllOrNothingInvalid", func(t *testing.T) {
+		server, ctx := getWorkflowServer()
+		resp, err := server.(*workflowServer).SubmitWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchSubmitRequest{
+			Namespace:    "default",
+			Workflows:    invalid(newWorkflows("batch-0", "batch-1"), 1),
+			AllOrNothing: true,
+		})
+		require.NoError(t, err)
+		for _, item := range resp.Items {
+			assert.Nil(t, item.Workflow)
+			assert.NotEmpty(t, item.Error)
+		}
+		_, err = auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("default").Get(ctx, "batch-0", metav1.GetOptions{})
+		assert.True(t, apierr.IsNotFound(err))
+	})
+	t.Run("AllOrNothingCreateFailed", func(t *testing.T) {
+		server, ctx := getWorkflowServer()
+		ctx.Value(auth.WfKey).(*v1alpha.Clientset).PrependReactor("create", "workflows", func(action ktesting.Action) (bool, runtime.Object, error) {
+			if action.(ktesting.CreateAction).GetObject().(*v1alpha1.Workflow).Name == "batch-1" {
+				return true, nil, fmt.Errorf("quota exceeded")
+			}
+			return false, nil, nil
+		})
+		resp, err := server.(*workflowServer).SubmitWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchSubmitRequest{
+			Namespace:    "default",
+			Workflows:    newWorkflows("batch-0", "batch-1", "batch-2"),
+			AllOrNothing: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 3)
+		assert.Contains(t, resp.Items[0].Error, "deleted")
+		assert.Contains(t, resp.Items[1].Error, "quota exceeded")
+		assert.Contains(t, resp.Items[2].Error, "not created")
+		for _, name := range []string{"batch-0", "batch-2"} {
+			_, err = auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("default").Get(ctx, name, metav1.GetOptions{})
+			assert.True(t, apierr.IsNotFound(err), name)
+		}
+	})
+}
+
+func TestRetryWorkflowBatch(t *testing.T) {
+	newServer := func(t *testing.T) (*workflowServer, context.Context) {
+		t.Helper()
+		server, ctx := getWorkflowServer()
+		for name, phase := range map[string]v1alpha1.WorkflowPhase{"batch-0": v1alpha1.WorkflowFailed, "batch-1": v1alpha1.WorkflowError, "batch-2": v1alpha1.WorkflowSucceeded} {
+			var wf v1alpha1.Workflow
+			v1alpha1.MustUnmarshal(failedWf, &wf)
+			wf.Name = name
+			wf.Labels["batch"] = "retry"
+			wf.Labels[common.LabelKeyPhase] = string(phase)
+			wf.Status.Phase = phase
+			_, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows").Create(ctx, &wf, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+		return server.(*workflowServer), ctx
+	}
+
+	t.Run("NoSelector", func(t *testing.T) {
+		server, ctx := newServer(t)
+		_, err := server.RetryWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchRetryRequest{Namespace: "workflows"})
+		require.Error(t, err)
+	})
+	t.Run("TooParallel", func(t *testing.T) {
+		server, ctx := newServer(t)
+		_, err := server.RetryWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchRetryRequest{Namespace: "workflows", LabelSelector: "batch=retry", Parallelism: maxWorkflowBatchRetryParallelism + 1})
+		require.Error(t, err)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		server, ctx := newServer(t)
+		resp, err := server.RetryWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchRetryRequest{Namespace: "workflows", LabelSelector: "batch=retry", DryRun: true})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 2)
+		assert.Equal(t, "batch-0", resp.Items[0].Name)
+		assert.Equal(t, "batch-1", resp.Items[1].Name)
+		wf, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows").Get(ctx, "batch-0", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.WorkflowFailed, wf.Status.Phase)
+	})
+	t.Run("Retry", func(t *testing.T) {
+		server, ctx := newServer(t)
+		resp, err := server.RetryWorkflowBatch(ctx, &workflowbatchpkg.WorkflowBatchRetryRequest{Namespace: "workflows", LabelSelector: "batch=retry", Parallelism: 1})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 2, "the workflow that succeeded is not retried")
+		for _, item := range resp.Items {
+			assert.Empty(t, item.Error)
+			require.NotNil(t, item.Workflow)
+			assert.Equal(t, v1alpha1.WorkflowRunning, item.Workflow.Status.Phase)
+		}
+	})
+}
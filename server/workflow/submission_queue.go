@@ -0,0 +1,158 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/rest"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	"github.com/argoproj/argo-workflows/v3/util/env"
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// submissionQueueRetryPeriod is how often the workflows of queued submissions are created
+var submissionQueueRetryPeriod = env.LookupEnvDurationOr("SUBMISSION_QUEUE_RETRY_PERIOD", 10*time.Second)
+
+// prepareDurableSubmission labels the workflow with a tracking ID, and names it if it is to be generated, so that
+// creating it again after a failure cannot create a second workflow
+func prepareDurableSubmission(wf *wfv1.Workflow) string {
+	id := string(uuid.NewUUID())
+	if wf.Labels == nil {
+		wf.Labels = map[string]string{}
+	}
+	wf.Labels[common.LabelKeySubmissionID] = id
+	if wf.Name == "" && wf.GenerateName != "" {
+		wf.Name = wf.GenerateName + rand.String(5)
+	}
+	return id
+}
+
+// authorizeDurableSubmission checks that the caller can create workflows in the namespace, and returns who they are,
+// so that a queued workflow is created as them rather than as the Argo Server. Both are answered by the Kubernetes API
+// server without etcd, so usually succeed whilst creating workflows does not.
+func authorizeDurableSubmission(ctx context.Context, namespace string) (*authenticationv1.UserInfo, error) {
+	allowed, err := auth.CanI(ctx, "create", workflow.WorkflowPlural, namespace, "")
+	if err != nil {
+		return nil, sutils.ToStatusError(fmt.Errorf("failed to check that you can create workflows: %w", err), codes.Unavailable)
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("Permission denied, you are not allowed to create workflows in namespace \"%s\"", namespace))
+	}
+	review, err := auth.GetKubeClient(ctx).AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(fmt.Errorf("failed to determine who you are: %w", err), codes.Unavailable)
+	}
+	return &review.Status.UserInfo, nil
+}
+
+// impersonatingWfClient returns a function that returns a workflow client that impersonates the user, with the REST
+// config of the Argo Server, which must be allowed to impersonate users
+func impersonatingWfClient(restConfig *rest.Config) func(user *authenticationv1.UserInfo) (versioned.Interface, error) {
+	return func(user *authenticationv1.UserInfo) (versioned.Interface, error) {
+		if restConfig == nil {
+			return nil, fmt.Errorf("the Argo Server cannot impersonate %q", user.Username)
+		}
+		config := rest.CopyConfig(restConfig)
+		config.Impersonate = rest.ImpersonationConfig{UserName: user.Username, UID: user.UID, Groups: user.Groups}
+		if len(user.Extra) > 0 {
+			config.Impersonate.Extra = map[string][]string{}
+			for k, v := range user.Extra {
+				config.Impersonate.Extra[k] = v
+			}
+		}
+		return versioned.NewForConfig(config)
+	}
+}
+
+// isAPIUnavailable returns whether the error is because the Kubernetes API is unavailable, rather than the workflow
+// being rejected
+func isAPIUnavailable(err error) bool {
+	return errorsutil.IsTransientErr(err) || apierr.IsTimeout(err)
+}
+
+// runSubmissionQueue periodically creates the workflows of the queued submissions, until the stop channel is closed
+func (s *workflowServer) runSubmissionQueue(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(submissionQueueRetryPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			s.createQueuedSubmissions(context.Background())
+		}
+	}
+}
+
+// createQueuedSubmissions creates the workflows of the pending submissions in the order that they were queued. It stops
+// at the first that fails because the Kubernetes API is still unavailable, to retry them all later.
+func (s *workflowServer) createQueuedSubmissions(ctx context.Context) {
+	submissions, err := s.submissionQueue.ListPending()
+	if err != nil {
+		log.WithError(err).Error("Failed to list the queued submissions")
+		return
+	}
+	for _, submission := range submissions {
+		logCtx := log.WithFields(log.Fields{"submissionID": submission.ID, "namespace": submission.Workflow.Namespace, "name": submission.Workflow.Name})
+		err := s.createQueuedSubmission(ctx, submission)
+		switch {
+		case err == nil:
+			logCtx.Info("Created the workflow of the queued submission")
+			err = s.submissionQueue.Remove(submission.ID)
+		case isAPIUnavailable(err):
+			logCtx.WithError(err).Warn("Failed to create the workflow of the queued submission, will retry")
+			if err := s.submissionQueue.RecordAttempt(submission.ID, err.Error()); err != nil {
+				logCtx.WithError(err).Error("Failed to record the attempt of the queued submission")
+			}
+			return
+		default:
+			logCtx.WithError(err).Error("Failed to create the workflow of the queued submission")
+			err = s.submissionQueue.MarkFailed(submission.ID, err.Error())
+		}
+		if err != nil {
+			logCtx.WithError(err).Error("Failed to update the queued submission")
+		}
+	}
+}
+
+// createQueuedSubmission creates the workflow of the submission as its submitter, which was created already if a
+// workflow of the same name has its tracking ID
+func (s *workflowServer) createQueuedSubmission(ctx context.Context, submission sqldb.QueuedSubmission) error {
+	wf := submission.Workflow
+	if submission.Submitter == nil {
+		return fmt.Errorf("the submission does not record who submitted it")
+	}
+	wfClient, err := s.impersonate(submission.Submitter)
+	if err != nil {
+		return err
+	}
+	workflows := wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace)
+	_, err = workflows.Create(ctx, wf, metav1.CreateOptions{})
+	if !apierr.IsAlreadyExists(err) {
+		return err
+	}
+	existing, err := workflows.Get(ctx, wf.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if existing.Labels[common.LabelKeySubmissionID] != submission.ID {
+		return fmt.Errorf("a different workflow named %q already exists", wf.Name)
+	}
+	return nil
+}
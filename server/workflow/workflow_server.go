@@ -13,17 +13,20 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowbatchpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowbatch"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
@@ -37,6 +40,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/logs"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
+	"github.com/argoproj/argo-workflows/v3/workflow/defaults"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 	"github.com/argoproj/argo-workflows/v3/workflow/validate"
@@ -53,22 +57,29 @@ type workflowServer struct {
 	offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo
 	hydrator              hydrator.Interface
 	wfArchive             sqldb.WorkflowArchive
+	submissionQueue       sqldb.SubmissionQueue
+	impersonate           func(user *authenticationv1.UserInfo) (versioned.Interface, error)
 	wfLister              store.WorkflowLister
 	wfReflector           *cache.Reflector
 	wftmplStore           servertypes.WorkflowTemplateStore
 	cwftmplStore          servertypes.ClusterWorkflowTemplateStore
-	wfDefaults            *wfv1.Workflow
+	wfDefaults            defaults.Getter
 }
 
-var _ workflowpkg.WorkflowServiceServer = &workflowServer{}
+var (
+	_ workflowpkg.WorkflowServiceServer           = &workflowServer{}
+	_ workflowbatchpkg.WorkflowBatchServiceServer = &workflowServer{}
+)
 
 // NewWorkflowServer returns a new WorkflowServer
-func NewWorkflowServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchive sqldb.WorkflowArchive, wfClientSet versioned.Interface, wfLister store.WorkflowLister, wfStore store.WorkflowStore, wftmplStore servertypes.WorkflowTemplateStore, cwftmplStore servertypes.ClusterWorkflowTemplateStore, wfDefaults *wfv1.Workflow, namespace *string) *workflowServer {
+func NewWorkflowServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchive sqldb.WorkflowArchive, submissionQueue sqldb.SubmissionQueue, restConfig *rest.Config, wfClientSet versioned.Interface, wfLister store.WorkflowLister, wfStore store.WorkflowStore, wftmplStore servertypes.WorkflowTemplateStore, cwftmplStore servertypes.ClusterWorkflowTemplateStore, wfDefaults defaults.Getter, namespace *string) *workflowServer {
 	ws := &workflowServer{
 		instanceIDService:     instanceIDService,
 		offloadNodeStatusRepo: offloadNodeStatusRepo,
 		hydrator:              hydrator.New(offloadNodeStatusRepo),
 		wfArchive:             wfArchive,
+		submissionQueue:       submissionQueue,
+		impersonate:           impersonatingWfClient(restConfig),
 		wfLister:              wfLister,
 		wftmplStore:           wftmplStore,
 		cwftmplStore:          cwftmplStore,
@@ -90,6 +101,9 @@ func NewWorkflowServer(instanceIDService instanceid.Service, offloadNodeStatusRe
 }
 
 func (s *workflowServer) Run(stopCh <-chan struct{}) {
+	if s.submissionQueue.IsEnabled() {
+		go s.runSubmissionQueue(stopCh)
+	}
 	if s.wfReflector != nil {
 		s.wfReflector.Run(stopCh)
 	}
@@ -112,7 +126,11 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 	wftmplGetter := s.wftmplStore.Getter(ctx, req.Workflow.Namespace)
 	cwftmplGetter := s.cwftmplStore.Getter(ctx)
 
-	err := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, s.wfDefaults, validate.ValidateOpts{})
+	wfDefaults, err := s.wfDefaults.GetWorkflowDefaults(req.Workflow.Namespace)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, wfDefaults, validate.ValidateOpts{})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
@@ -129,8 +147,29 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 		return workflow, nil
 	}
 
+	var submissionID string
+	if req.Durable {
+		if !s.submissionQueue.IsEnabled() {
+			return nil, sutils.ToStatusError(fmt.Errorf("durable submissions require the submission queue of the Argo Server to be configured"), codes.FailedPrecondition)
+		}
+		submissionID = prepareDurableSubmission(req.Workflow)
+	}
+
 	wf, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, req.Workflow, metav1.CreateOptions{})
 	if err != nil {
+		if req.Durable && isAPIUnavailable(err) {
+			submitter, authErr := authorizeDurableSubmission(ctx, req.Namespace)
+			if authErr != nil {
+				return nil, authErr
+			}
+			// the workflow is returned without a UID, as it is only created once the Kubernetes API is available
+			if err := s.submissionQueue.Enqueue(submissionID, req.Workflow, submitter); err != nil {
+				return nil, sutils.ToStatusError(fmt.Errorf("failed to queue the submission: %w", err), codes.Internal)
+			}
+			log.WithFields(log.Fields{"submissionID": submissionID, "namespace": req.Workflow.Namespace, "name": req.Workflow.Name}).
+				WithError(err).Warn("Queued the submission as the Kubernetes API is unavailable")
+			return req.Workflow, nil
+		}
 		if apierr.IsServerTimeout(err) && req.Workflow.GenerateName != "" && req.Workflow.Name != "" {
 			errWithHint := fmt.Errorf(`create request failed due to timeout, but it's possible that workflow "%s" already exists. Original error: %w`, req.Workflow.Name, err)
 			log.WithError(errWithHint).Error(errWithHint.Error())
@@ -455,7 +494,12 @@ func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.Wor
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	wf, podsToDelete, err := util.FormulateRetryWorkflow(ctx, wf, req.RestartSuccessful, req.NodeFieldSelector, req.Parameters)
+	var podsToDelete []string
+	if patch := (wfv1.NodePatch{Image: req.PatchImage, Source: req.PatchSource}); !patch.IsEmpty() {
+		wf, podsToDelete, err = util.FormulatePatchedRetryWorkflow(ctx, wf, req.NodeFieldSelector, req.Parameters, patch)
+	} else {
+		wf, podsToDelete, err = util.FormulateRetryWorkflow(ctx, wf, req.RestartSuccessful, req.NodeFieldSelector, req.Parameters)
+	}
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -512,7 +556,11 @@ func (s *workflowServer) ResubmitWorkflow(ctx context.Context, req *workflowpkg.
 	}
 	creator.LabelCreator(ctx, newWF)
 
-	created, err := util.SubmitWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), wfClient, req.Namespace, newWF, s.wfDefaults, &wfv1.SubmitOpts{})
+	wfDefaults, err := s.wfDefaults.GetWorkflowDefaults(req.Namespace)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	created, err := util.SubmitWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), wfClient, req.Namespace, newWF, wfDefaults, &wfv1.SubmitOpts{})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -559,7 +607,11 @@ func (s *workflowServer) SuspendWorkflow(ctx context.Context, req *workflowpkg.W
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
 
-	err = util.SuspendWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace), wf.Name)
+	if req.After != "" {
+		err = util.SuspendWorkflowAfter(ctx, wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace), wf.Name, req.After)
+	} else {
+		err = util.SuspendWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace), wf.Name)
+	}
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -607,7 +659,14 @@ func (s *workflowServer) StopWorkflow(ctx context.Context, req *workflowpkg.Work
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
-	err = util.StopWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector, req.Message)
+	if req.Drain {
+		if req.NodeFieldSelector != "" {
+			return nil, sutils.ToStatusError(fmt.Errorf("cannot drain a single node"), codes.InvalidArgument)
+		}
+		err = util.DrainWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), wf.Name)
+	} else {
+		err = util.StopWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector, req.Message)
+	}
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -673,7 +732,11 @@ func (s *workflowServer) LintWorkflow(ctx context.Context, req *workflowpkg.Work
 	s.instanceIDService.Label(req.Workflow)
 	creator.LabelCreator(ctx, req.Workflow)
 
-	err := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, s.wfDefaults, validate.ValidateOpts{Lint: true})
+	wfDefaults, err := s.wfDefaults.GetWorkflowDefaults(req.Workflow.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, wfDefaults, validate.ValidateOpts{Lint: true})
 	if err != nil {
 		return nil, err
 	}
@@ -796,7 +859,11 @@ func (s *workflowServer) SubmitWorkflow(ctx context.Context, req *workflowpkg.Wo
 	wftmplGetter := s.wftmplStore.Getter(ctx, req.Namespace)
 	cwftmplGetter := s.cwftmplStore.Getter(ctx)
 
-	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, s.wfDefaults, validate.ValidateOpts{Submit: true})
+	wfDefaults, err := s.wfDefaults.GetWorkflowDefaults(req.Namespace)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, wfDefaults, validate.ValidateOpts{Submit: true})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
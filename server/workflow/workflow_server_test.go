@@ -3,14 +3,19 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,6 +39,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
+	"github.com/argoproj/argo-workflows/v3/workflow/defaults"
 )
 
 const unlabelled = `{
@@ -646,7 +652,7 @@ func getWorkflowServer() (workflowpkg.WorkflowServiceServer, context.Context) {
 	namespaceAll := metav1.NamespaceAll
 	wftmplStore := workflowtemplate.NewWorkflowTemplateClientStore()
 	cwftmplStore := clusterworkflowtemplate.NewClusterWorkflowTemplateClientStore()
-	server := NewWorkflowServer(instanceIDSvc, offloadNodeStatusRepo, archivedRepo, wfClientset, wfStore, wfStore, wftmplStore, cwftmplStore, nil, &namespaceAll)
+	server := NewWorkflowServer(instanceIDSvc, offloadNodeStatusRepo, archivedRepo, sqldb.NullSubmissionQueue, nil, wfClientset, wfStore, wfStore, wftmplStore, cwftmplStore, defaults.Static(nil), &namespaceAll)
 	return server, ctx
 }
 
@@ -680,6 +686,97 @@ func TestCreateWorkflow(t *testing.T) {
 	assert.Equal(t, userEmailLabel, wf.Labels[common.LabelKeyCreatorEmail])
 }
 
+func TestCreateWorkflowDurable(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	newReq := func() *workflowpkg.WorkflowCreateRequest {
+		req := &workflowpkg.WorkflowCreateRequest{}
+		v1alpha1.MustUnmarshal(workflow1, req)
+		req.Durable = true
+		return req
+	}
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, err := server.CreateWorkflow(ctx, newReq())
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	queue, err := sqldb.NewSQLiteSubmissionQueue(filepath.Join(t.TempDir(), "submissions.db"), "default")
+	require.NoError(t, err)
+	server.(*workflowServer).submissionQueue = queue
+	available := true
+	wfClientset := ctx.Value(auth.WfKey).(*v1alpha.Clientset)
+	wfClientset.PrependReactor("create", "workflows", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if available {
+			return false, nil, nil
+		}
+		return true, nil, apierr.NewServiceUnavailable("etcdserver: leader changed")
+	})
+	canCreate := true
+	kubeClientSet := ctx.Value(auth.KubeKey).(*fake.Clientset)
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		assert.Equal(t, "create", review.Spec.ResourceAttributes.Verb)
+		assert.Equal(t, "default", review.Spec.ResourceAttributes.Namespace)
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: canCreate}}, nil
+	})
+	submitter := authenticationv1.UserInfo{Username: "system:serviceaccount:default:my-user", Groups: []string{"system:serviceaccounts"}}
+	kubeClientSet.PrependReactor("create", "selfsubjectreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, &authenticationv1.SelfSubjectReview{Status: authenticationv1.SelfSubjectReviewStatus{UserInfo: submitter}}, nil
+	})
+	var impersonated []string
+	server.(*workflowServer).impersonate = func(user *authenticationv1.UserInfo) (versioned.Interface, error) {
+		impersonated = append(impersonated, user.Username)
+		return wfClientset, nil
+	}
+
+	t.Run("Available", func(t *testing.T) {
+		wf, err := server.CreateWorkflow(ctx, newReq())
+		require.NoError(t, err)
+		assert.Contains(t, wf.Labels, common.LabelKeySubmissionID)
+		_, err = getWorkflow(ctx, server, "default", wf.Name)
+		require.NoError(t, err, "the workflow is created straight away")
+		pending, err := queue.ListPending()
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+	t.Run("Unavailable", func(t *testing.T) {
+		available = false
+		wf, err := server.CreateWorkflow(ctx, newReq())
+		require.NoError(t, err)
+		assert.Empty(t, wf.UID)
+		assert.Regexp(t, `^hello-world-`, wf.Name)
+		id := wf.Labels[common.LabelKeySubmissionID]
+		require.NotEmpty(t, id)
+
+		server.(*workflowServer).createQueuedSubmissions(ctx)
+		pending, err := queue.ListPending()
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, id, pending[0].ID)
+		assert.Equal(t, 1, pending[0].Attempts)
+		assert.Equal(t, &submitter, pending[0].Submitter)
+
+		available = true
+		server.(*workflowServer).createQueuedSubmissions(ctx)
+		pending, err = queue.ListPending()
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+		created, err := getWorkflow(ctx, server, "default", wf.Name)
+		require.NoError(t, err)
+		assert.Equal(t, id, created.Labels[common.LabelKeySubmissionID])
+		assert.Equal(t, []string{submitter.Username, submitter.Username}, impersonated, "the workflow is created as its submitter")
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		available = false
+		canCreate = false
+		_, err := server.CreateWorkflow(ctx, newReq())
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		pending, err := queue.ListPending()
+		require.NoError(t, err)
+		assert.Empty(t, pending, "the submission is not queued")
+	})
+}
+
 type testWatchWorkflowServer struct {
 	testServerStream
 }
@@ -0,0 +1,74 @@
+package workflowhistory
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	workflowhistorypkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowhistory"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+)
+
+// workflowHistoryServer returns the statuses of workflows as of times in the past, from the snapshots of them that the
+// controller saves. It only reads the snapshots, so it serves those that were saved whilst the status history was
+// enabled, even if it no longer is.
+type workflowHistoryServer struct {
+	repo sqldb.WorkflowStatusSnapshotRepo
+}
+
+// NewWorkflowHistoryServer returns the server of the status history, of which the repo is nil if persistence is not
+// configured
+func NewWorkflowHistoryServer(repo sqldb.WorkflowStatusSnapshotRepo) workflowhistorypkg.WorkflowHistoryServiceServer {
+	return &workflowHistoryServer{repo}
+}
+
+func (s *workflowHistoryServer) GetWorkflowStatusAt(ctx context.Context, req *workflowhistorypkg.GetWorkflowStatusAtRequest) (*workflowhistorypkg.WorkflowStatusSnapshot, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "the status history of workflows requires persistence to be configured")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	allowed, err := auth.CanI(ctx, "get", workflow.WorkflowPlural, req.Namespace, req.Name)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	at := time.Now()
+	if req.Time != "" {
+		at, err = time.Parse(time.RFC3339, req.Time)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "time must be an RFC 3339 time: %v", err)
+		}
+	}
+	record, err := s.repo.Get(req.Namespace, req.Name, req.Uid, at)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if record == nil {
+		return nil, status.Errorf(codes.NotFound, "no snapshot of the status of workflow %s was saved at or before %s", req.Name, at.UTC().Format(time.RFC3339))
+	}
+	nodes := map[string]*wfv1.NodeStatus{}
+	if err := json.Unmarshal([]byte(record.Nodes), &nodes); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return &workflowhistorypkg.WorkflowStatusSnapshot{
+		Namespace: record.Namespace,
+		Name:      record.Name,
+		Uid:       record.UID,
+		Time:      &metav1.Time{Time: record.CreatedAt},
+		Phase:     record.Phase,
+		Message:   record.Message,
+		Nodes:     nodes,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package workflowhistory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	workflowhistorypkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowhistory"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+// fakeWorkflowStatusSnapshotRepo holds the snapshots of one workflow, in the order they were saved
+type fakeWorkflowStatusSnapshotRepo []sqldb.WorkflowStatusSnapshotRecord
+
+func (r fakeWorkflowStatusSnapshotRepo) Save(*sqldb.WorkflowStatusSnapshotRecord) error { return nil }
+
+func (r fakeWorkflowStatusSnapshotRepo) Get(namespace, name, uid string, at time.Time) (*sqldb.WorkflowStatusSnapshotRecord, error) {
+	var latest *sqldb.WorkflowStatusSnapshotRecord
+	for i, record := range r {
+		if record.Namespace == namespace && record.Name == name && (uid == "" || record.UID == uid) && !record.CreatedAt.After(at) {
+			latest = &r[i]
+		}
+	}
+	return latest, nil
+}
+
+func (r fakeWorkflowStatusSnapshotRepo) DeleteExpired(time.Duration) error { return nil }
+
+var (
+	started = time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	repo    = fakeWorkflowStatusSnapshotRepo{
+		{UID: "my-uid", Namespace: "my-ns", Name: "my-wf", Phase: "Running", Nodes: `{"my-wf":{"id":"my-wf","phase":"Running"}}`, CreatedAt: started},
+		{UID: "my-uid", Namespace: "my-ns", Name: "my-wf", Phase: "Failed", Message: "child failed", Nodes: `{"my-wf":{"id":"my-wf","phase":"Failed"}}`, CreatedAt: started.Add(time.Minute)},
+	}
+)
+
+func newContext(allowed bool) context.Context {
+	kubeClient := &kubefake.Clientset{}
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed},
+		}, nil
+	})
+	return context.WithValue(context.Background(), auth.KubeKey, kubeClient)
+}
+
+func TestGetWorkflowStatusAt(t *testing.T) {
+	s := NewWorkflowHistoryServer(repo)
+	ctx := newContext(true)
+	t.Run("Before", func(t *testing.T) {
+		_, err := s.GetWorkflowStatusAt(ctx, &workflowhistorypkg.GetWorkflowStatusAtRequest{Namespace: "my-ns", Name: "my-wf", Time: started.Add(-time.Second).Format(time.RFC3339)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("Between", func(t *testing.T) {
+		snapshot, err := s.GetWorkflowStatusAt(ctx, &workflowhistorypkg.GetWorkflowStatusAtRequest{Namespace: "my-ns", Name: "my-wf", Time: started.Add(30 * time.Second).Format(time.RFC3339)})
+		require.NoError(t, err)
+		assert.Equal(t, "my-uid", snapshot.Uid)
+		assert.True(t, started.Equal(snapshot.Time.Time))
+		assert.Equal(t, string(wfv1.WorkflowRunning), snapshot.Phase)
+		assert.Equal(t, wfv1.NodeRunning, snapshot.Nodes["my-wf"].Phase)
+	})
+	t.Run("Now", func(t *testing.T) {
+		snapshot, err := s.GetWorkflowStatusAt(ctx, &workflowhistorypkg.GetWorkflowStatusAtRequest{Namespace: "my-ns", Name: "my-wf"})
+		require.NoError(t, err)
+		assert.Equal(t, string(wfv1.WorkflowFailed), snapshot.Phase)
+		assert.Equal(t, "child failed", snapshot.Message)
+		assert.Equal(t, wfv1.NodeFailed, snapshot.Nodes["my-wf"].Phase)
+	})
+	t.Run("OtherUID", func(t *testing.T) {
+		_, err := s.GetWorkflowStatusAt(ctx, &workflowhistorypkg.GetWorkflowStatusAtRequest{Namespace: "my-ns", Name: "my-wf", Uid: "other-uid"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("InvalidTime", func(t *testing.T) {
+		_, err := s.GetWorkflowStatusAt(ctx, &workflowhistorypkg.GetWorkflowStatusAtRequest{Namespace: "my-ns", Name: "my-wf", Time: "yesterday"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("PermissionDenied", func(t *testing.T) {
+		_, err := s.GetWorkflowStatusAt(newContext(false), &workflowhistorypkg.GetWorkflowStatusAtRequest{Namespace: "my-ns", Name: "my-wf"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("NotEnabled", func(t *testing.T) {
+		_, err := NewWorkflowHistoryServer(nil).GetWorkflowStatusAt(ctx, &workflowhistorypkg.GetWorkflowStatusAtRequest{Namespace: "my-ns", Name: "my-wf"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
@@ -0,0 +1,92 @@
+//go:build executor
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/test/e2e/fixtures"
+)
+
+// FaultsSuite injects faults into the executor, by setting ARGO_FAULTS on its container with podSpecPatch
+type FaultsSuite struct {
+	fixtures.E2ESuite
+}
+
+func (s *FaultsSuite) TestArtifactSaveError() {
+	s.Given().
+		Workflow(`
+metadata:
+  generateName: faults-save-error-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+        args: [echo, hello, /tmp/hello]
+      outputs:
+        artifacts:
+          - name: hello
+            path: /tmp/hello
+      podSpecPatch: '{"containers":[{"name":"wait","env":[{"name":"ARGO_FAULTS","value":"[{\"operation\":\"artifacts/Save\",\"error\":\"unavailable\"}]"}]}]}'
+`).
+		When().
+		SubmitWorkflow().
+		WaitForWorkflow(fixtures.ToBeFailed).
+		Then().
+		ExpectWorkflowNode(wfv1.FailedPodNode, func(t *testing.T, n *wfv1.NodeStatus, _ *apiv1.Pod) {
+			assert.Contains(t, n.Message, "injected fault: unavailable")
+		})
+}
+
+func (s *FaultsSuite) TestArtifactLatency() {
+	s.Given().
+		Workflow(`
+metadata:
+  generateName: faults-latency-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      steps:
+        - - name: save
+            template: save
+        - - name: load
+            template: load
+            arguments:
+              artifacts:
+                - name: hello
+                  from: "{{steps.save.outputs.artifacts.hello}}"
+    - name: save
+      container:
+        image: argoproj/argosay:v2
+        args: [echo, hello, /tmp/hello]
+      outputs:
+        artifacts:
+          - name: hello
+            path: /tmp/hello
+      podSpecPatch: '{"containers":[{"name":"wait","env":[{"name":"ARGO_FAULTS","value":"[{\"operation\":\"artifacts/*\",\"latency\":\"5s\"}]"}]}]}'
+    - name: load
+      inputs:
+        artifacts:
+          - name: hello
+            path: /tmp/hello
+      container:
+        image: argoproj/argosay:v2
+        args: [cat, /tmp/hello]
+      podSpecPatch: '{"initContainers":[{"name":"init","env":[{"name":"ARGO_FAULTS","value":"[{\"operation\":\"artifacts/Load\",\"latency\":\"5s\"}]"}]}]}'
+`).
+		When().
+		SubmitWorkflow().
+		WaitForWorkflow(fixtures.ToBeSucceeded)
+}
+
+func TestFaultsSuite(t *testing.T) {
+	suite.Run(t, new(FaultsSuite))
+}
@@ -95,8 +95,7 @@ func (t *Then) ExpectWorkflowNode(selector func(status wfv1.NodeStatus) bool, f
 				wf := &wfv1.Workflow{
 					ObjectMeta: *metadata,
 				}
-				version := util.GetWorkflowPodNameVersion(wf)
-				podName := util.GeneratePodName(t.wf.Name, n.Name, util.GetTemplateFromNode(*n), n.ID, version)
+				podName := util.GenerateWorkflowPodName(wf, n.Name, util.GetTemplateFromNode(*n), n.ID)
 
 				var err error
 				ctx := context.Background()
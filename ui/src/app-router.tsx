@@ -22,7 +22,8 @@ import sensors from './sensors';
 import {uiUrl} from './shared/base';
 import {ChatButton} from './shared/components/chat-button';
 import ErrorBoundary from './shared/components/error-boundary';
-import {Version} from './shared/models';
+import {filterLinks, NavLink} from './shared/components/links';
+import {Link, Version} from './shared/models';
 import * as nsUtils from './shared/namespaces';
 import {services} from './shared/services';
 import userinfo from './userinfo';
@@ -46,6 +47,7 @@ const userInfoUrl = uiUrl('userinfo');
 const loginUrl = uiUrl('login');
 const timelineUrl = uiUrl('timeline');
 const reportsUrl = uiUrl('reports');
+const navLinksUrl = uiUrl('links');
 
 export function AppRouter({popupManager, history, notificationsManager}: {popupManager: PopupManager; history: H.History; notificationsManager: NotificationsManager}) {
     const [popupProps, setPopupProps] = useState<PopupProps>();
@@ -53,6 +55,7 @@ export function AppRouter({popupManager, history, notificationsManager}: {popupM
     const [version, setVersion] = useState<Version>();
     const [namespace, setNamespace] = useState<string>();
     const [navBarBackgroundColor, setNavBarBackgroundColor] = useState<string>();
+    const [links, setLinks] = useState<Link[]>();
     const setError = (error: Error) => {
         notificationsManager.show({
             content: 'Failed to load version/info ' + error,
@@ -76,6 +79,7 @@ export function AppRouter({popupManager, history, notificationsManager}: {popupM
                 setNamespace(nsUtils.getCurrentNamespace());
                 setModals(info.modals);
                 setNavBarBackgroundColor(info.navColor);
+                setLinks(info.links);
             })
             .then(() => services.info.getVersion())
             .then(setVersion)
@@ -157,7 +161,12 @@ export function AppRouter({popupManager, history, notificationsManager}: {popupM
                                 title: 'Help',
                                 path: helpUrl,
                                 iconClassName: 'fa fa-question-circle'
-                            }
+                            },
+                            ...filterLinks(links, 'nav', namespace).map(link => ({
+                                title: link.name,
+                                path: navLinksUrl + '/' + encodeURIComponent(link.name),
+                                iconClassName: link.icon || 'fa fa-external-link-alt'
+                            }))
                         ]}
                         version={() => <>{version ? version.version : 'unknown'}</>}>
                         <Notifications notifications={notificationsManager.notifications} />
@@ -177,6 +186,7 @@ export function AppRouter({popupManager, history, notificationsManager}: {popupM
                                 <Route path={reportsUrl} component={reports.component} />
                                 <Route path={pluginsUrl} component={plugins.component} />
                                 <Route exact={true} strict={true} path={helpUrl} component={help.component} />
+                                <Route path={navLinksUrl + '/:name'} component={NavLink} />
                                 <Route exact={true} strict={true} path={apiDocsUrl} component={apiDocs.component} />
                                 <Route exact={true} strict={true} path={userInfoUrl} component={userinfo.component} />
                                 <Route exact={true} strict={true} path={loginUrl} component={login.component} />
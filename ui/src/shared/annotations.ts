@@ -1,3 +1,4 @@
 export const ANNOTATION_KEY_POD_NAME_VERSION = 'workflows.argoproj.io/pod-name-format';
+export const ANNOTATION_KEY_POD_NAME_TEMPLATE = 'workflows.argoproj.io/pod-name-template';
 export const ANNOTATION_TITLE = 'workflows.argoproj.io/title';
 export const ANNOTATION_DESCRIPTION = 'workflows.argoproj.io/description';
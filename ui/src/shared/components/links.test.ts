@@ -1,4 +1,4 @@
-import {processURL} from './links';
+import {filterLinks, processURL} from './links';
 
 describe('process URL', () => {
     test('original timestamp', () => {
@@ -71,4 +71,28 @@ describe('process URL', () => {
 
         expect(processURL('https://logging?${workflow.metadata.annotations.workflows.argoproj.io/pod-name-format}', object)).toBe('https://logging?v2');
     });
+
+    test('node and artifact fields', () => {
+        const object = {
+            status: {},
+            node: {podName: 'my-pod'},
+            artifact: {key: 'my-wf/my-pod/out.tgz'}
+        };
+
+        expect(processURL('https://storage?pod=${node.podName}&key=${artifact.key}', object)).toBe('https://storage?pod=my-pod&key=my-wf/my-pod/out.tgz');
+    });
+});
+
+describe('filter links', () => {
+    const links = [
+        {name: 'all', scope: 'workflow', url: 'https://all'},
+        {name: 'my-ns', scope: 'workflow', url: 'https://my-ns', namespaces: ['my-ns']},
+        {name: 'pod', scope: 'pod', url: 'https://pod'}
+    ];
+
+    test('by scope and namespace', () => {
+        expect(filterLinks(links, 'workflow', 'my-ns').map(x => x.name)).toEqual(['all', 'my-ns']);
+        expect(filterLinks(links, 'workflow', 'other-ns').map(x => x.name)).toEqual(['all']);
+        expect(filterLinks(undefined, 'workflow', 'my-ns')).toEqual([]);
+    });
 });
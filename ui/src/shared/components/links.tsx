@@ -1,8 +1,10 @@
 import {ObjectMeta} from 'argo-ui/src/models/kubernetes';
 import {useEffect, useState} from 'react';
 import * as React from 'react';
+import {RouteComponentProps} from 'react-router-dom';
 
 import {Link, Workflow} from '../models';
+import * as nsUtils from '../namespaces';
 import {services} from '../services';
 import {Button} from './button';
 
@@ -56,17 +58,22 @@ export function openLinkWithKey(url: string) {
     }
 }
 
-export function Links({scope, object, button}: {scope: string; object: {metadata: ObjectMeta; workflow?: Workflow; status?: any}; button?: boolean}) {
+// filterLinks returns the links of the scope that are shown for the namespace
+export function filterLinks(links: Link[], scope: string, namespace?: string) {
+    return (links || []).filter(x => x.scope === scope && (!x.namespaces?.length || x.namespaces.includes(namespace)));
+}
+
+export function Links({scope, object, button}: {scope: string; object: {metadata: ObjectMeta; workflow?: Workflow; status?: any; [key: string]: any}; button?: boolean}) {
     const [links, setLinks] = useState<Link[]>();
     const [error, setError] = useState<Error>();
 
     useEffect(() => {
         services.info
             .getInfo()
-            .then(x => (x.links || []).filter(y => y.scope === scope))
+            .then(x => filterLinks(x.links, scope, object.metadata.namespace))
             .then(setLinks)
             .catch(setError);
-    }, []);
+    }, [object.metadata.namespace]);
 
     return (
         <>
@@ -89,3 +96,25 @@ export function Links({scope, object, button}: {scope: string; object: {metadata
         </>
     );
 }
+
+// NavLink opens the link of the "nav" scope whose name is in the URL, as the items of the navigation bar can only be
+// paths of the UI
+export function NavLink({match}: RouteComponentProps<{name: string}>) {
+    const [error, setError] = useState<Error>();
+
+    useEffect(() => {
+        services.info
+            .getInfo()
+            .then(info => {
+                const namespace = nsUtils.getCurrentNamespace();
+                const link = filterLinks(info.links, 'nav', namespace).find(x => x.name === match.params.name);
+                if (!link) {
+                    throw new Error(`Link "${match.params.name}" not found`);
+                }
+                document.location.href = processURL(link.url, {metadata: {namespace}, status: {}});
+            })
+            .catch(setError);
+    }, [match.params.name]);
+
+    return <>{error && error.message}</>;
+}
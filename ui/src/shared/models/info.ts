@@ -2,6 +2,8 @@ export interface Link {
     name: string;
     scope: string;
     url: string;
+    namespaces?: string[];
+    icon?: string;
 }
 
 export interface Column {
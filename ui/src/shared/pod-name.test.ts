@@ -1,6 +1,17 @@
-import {ANNOTATION_KEY_POD_NAME_VERSION} from './annotations';
+import {ANNOTATION_KEY_POD_NAME_TEMPLATE, ANNOTATION_KEY_POD_NAME_VERSION} from './annotations';
 import {NodeStatus, Workflow} from './models';
-import {createFNVHash, ensurePodNamePrefixLength, getPodName, getTemplateNameFromNode, k8sNamingHashLength, maxK8sResourceNameLength, POD_NAME_V1, POD_NAME_V2} from './pod-name';
+import {
+    createFNVHash,
+    ensurePodNamePrefixLength,
+    getPodName,
+    getPodNameFromTemplate,
+    getTemplateNameFromNode,
+    isValidPodNameTemplate,
+    k8sNamingHashLength,
+    maxK8sResourceNameLength,
+    POD_NAME_V1,
+    POD_NAME_V2
+} from './pod-name';
 
 describe('pod names', () => {
     test('createFNVHash', () => {
@@ -59,6 +70,35 @@ describe('pod names', () => {
         expect(name.length).toEqual(maxK8sResourceNameLength);
     });
 
+    // note: the below is intended to be equivalent to the server-side Go code in workflow/util/pod_name_template_test.go
+    test('getPodNameFromTemplate', () => {
+        const nodeName = 'my-wf.train(1)';
+        const hash = createFNVHash(nodeName);
+        expect(getPodNameFromTemplate('{{workflow.name}}-{{template.name}}-{{retry.attempt}}-{{node.hash}}', 'my-wf', nodeName, 'train', 'my-wf-1')).toEqual(
+            `my-wf-train-1-${hash}`
+        );
+        expect(getPodNameFromTemplate('{{node.id}}', 'my-wf', 'my-wf.train(0:a)', 'train', 'my-wf-1')).toEqual('my-wf-1');
+        expect(getPodNameFromTemplate('_{{template.name}}.{{node.hash}}_', 'my-wf', nodeName, 'My_Template', 'my-wf-1')).toEqual(`my-template-${hash}`);
+        const long = 'a'.repeat(maxK8sResourceNameLength);
+        expect(getPodNameFromTemplate('{{template.name}}-{{node.hash}}', 'my-wf', nodeName, long, 'my-wf-1').length).toBeLessThanOrEqual(maxK8sResourceNameLength);
+    });
+
+    test('isValidPodNameTemplate', () => {
+        expect(isValidPodNameTemplate('{{workflow.name}}-{{node.hash}}')).toBe(true);
+        expect(isValidPodNameTemplate('{{node.id}}')).toBe(true);
+        expect(isValidPodNameTemplate('{{workflow.name}}')).toBe(false);
+        expect(isValidPodNameTemplate('{{workflow.namespace}}-{{node.hash}}')).toBe(false);
+    });
+
+    test('getPodName with a pod name template', () => {
+        const node = {name: 'wfname.step', id: 'wfname-1', templateName: 'templatename'} as unknown as NodeStatus;
+        const wf = {
+            metadata: {name: 'wfname', annotations: {[ANNOTATION_KEY_POD_NAME_TEMPLATE]: '{{template.name}}-{{node.hash}}'}}
+        } as unknown as Workflow;
+        expect(getPodName(wf, node)).toEqual(`templatename-${createFNVHash(node.name)}`);
+        expect(getPodName(wf, {...node, name: node.name + '.mycontainername', type: 'Container'})).toEqual(`templatename-${createFNVHash(node.name)}`);
+    });
+
     test('getTemplateNameFromNode', () => {
         // case: no template ref or template name
         // expect fallback to empty string
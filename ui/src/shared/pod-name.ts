@@ -1,4 +1,4 @@
-import {ANNOTATION_KEY_POD_NAME_VERSION} from './annotations';
+import {ANNOTATION_KEY_POD_NAME_TEMPLATE, ANNOTATION_KEY_POD_NAME_VERSION} from './annotations';
 import {NodeStatus, Workflow} from './models';
 
 export const POD_NAME_V1 = 'v1';
@@ -9,24 +9,32 @@ export const k8sNamingHashLength = 10;
 const maxPrefixLength = maxK8sResourceNameLength - k8sNamingHashLength;
 
 // getPodName returns a deterministic pod name
+// In case the workflow has a pod name template, it will return the name that the template gives the pod
 // In case templateName is not defined or that version is explicitly set to  POD_NAME_V1, it will return the nodeID (v1)
 // In other cases it will return a combination of workflow name, template name, and a hash (v2)
-// note: this is intended to be equivalent to the server-side Go code in workflow/util/pod_name.go#GeneratePodName
+// note: this is intended to be equivalent to the server-side Go code in workflow/util/pod_name.go#GenerateWorkflowPodName
 export function getPodName(workflow: Workflow, node: NodeStatus): string {
+    const workflowName = workflow.metadata.name;
+    // convert containerSet node name to its corresponding pod node name by removing the ".<containerName>" postfix
+    // this part is from workflow/controller/container_set_template.go#executeContainerSet; the inverse never happens in the back-end, so is unique to the front-end
+    const podNodeName = node.type == 'Container' ? node.name.replace(/\.[^/.]+$/, '') : node.name;
+    const templateName = getTemplateNameFromNode(node);
+
+    const podNameTemplate = workflow.metadata?.annotations?.[ANNOTATION_KEY_POD_NAME_TEMPLATE];
+    if (podNameTemplate && isValidPodNameTemplate(podNameTemplate)) {
+        const podNodeID = workflowName === podNodeName ? workflowName : `${workflowName}-${createFNVHash(podNodeName)}`;
+        return getPodNameFromTemplate(podNameTemplate, workflowName, podNodeName, templateName, podNodeID);
+    }
+
     const version = workflow.metadata?.annotations?.[ANNOTATION_KEY_POD_NAME_VERSION];
     if (version === POD_NAME_V1) {
         return node.id;
     }
 
-    const workflowName = workflow.metadata.name;
-    // convert containerSet node name to its corresponding pod node name by removing the ".<containerName>" postfix
-    // this part is from workflow/controller/container_set_template.go#executeContainerSet; the inverse never happens in the back-end, so is unique to the front-end
-    const podNodeName = node.type == 'Container' ? node.name.replace(/\.[^/.]+$/, '') : node.name;
     if (workflowName === podNodeName) {
         return workflowName;
     }
 
-    const templateName = getTemplateNameFromNode(node);
     let prefix = workflowName;
     if (templateName) {
         prefix += `-${templateName}`;
@@ -37,6 +45,37 @@ export function getPodName(workflow: Workflow, node: NodeStatus): string {
     return `${prefix}-${hash}`;
 }
 
+const podNameVars = ['{{workflow.name}}', '{{template.name}}', '{{node.id}}', '{{node.hash}}', '{{retry.attempt}}'];
+
+// note: this is intended to be equivalent to the server-side Go code in workflow/common/pod_name_template.go#ValidatePodNameTemplate
+export function isValidPodNameTemplate(podNameTemplate: string): boolean {
+    if (!podNameTemplate.includes('{{node.hash}}') && !podNameTemplate.includes('{{node.id}}')) {
+        return false;
+    }
+    return !podNameVars.reduce((unresolved, v) => unresolved.split(v).join(''), podNameTemplate).includes('{{');
+}
+
+// note: this is intended to be equivalent to the server-side Go code in workflow/util/pod_name.go#GeneratePodNameFromTemplate
+export function getPodNameFromTemplate(podNameTemplate: string, workflowName: string, nodeName: string, templateName: string, nodeID: string): string {
+    const retryAttempt = nodeName.match(/\((\d+)\)$/)?.[1] || '0';
+    const values: {[variable: string]: string} = {
+        '{{workflow.name}}': workflowName,
+        '{{template.name}}': templateName,
+        '{{node.id}}': nodeID,
+        '{{node.hash}}': `${createFNVHash(nodeName)}`,
+        '{{retry.attempt}}': retryAttempt
+    };
+    let name = podNameTemplate.replace(/{{(workflow\.name|template\.name|node\.id|node\.hash|retry\.attempt)}}/g, variable => values[variable]);
+    name = name
+        .toLowerCase()
+        .replace(/[^a-z0-9-]/g, '-')
+        .replace(/^[^a-z0-9]+/, '');
+    if (name.length > maxK8sResourceNameLength) {
+        name = `${ensurePodNamePrefixLength(name)}-${createFNVHash(name)}`;
+    }
+    return name.replace(/[^a-z0-9]+$/, '');
+}
+
 export function ensurePodNamePrefixLength(prefix: string): string {
     if (prefix.length > maxPrefixLength - 1) {
         return prefix.substring(0, maxPrefixLength - 1);
@@ -7,8 +7,10 @@ import {ErrorNotice} from '../../../shared/components/error-notice';
 import {FirstTimeUserPanel} from '../../../shared/components/first-time-user-panel';
 import {GiveFeedbackLink} from '../../../shared/components/give-feedback-link';
 import {LinkButton} from '../../../shared/components/link-button';
+import {Links} from '../../../shared/components/links';
 import {SuspenseMonacoEditor} from '../../../shared/components/suspense-monaco-editor';
 import {Artifact, ArtifactRepository, execSpec, Workflow} from '../../../shared/models';
+import {getPodName} from '../../../shared/pod-name';
 import {services} from '../../../shared/services';
 import requests from '../../../shared/services/requests';
 import {useCollectEvent} from '../../../shared/use-collect-event';
@@ -54,8 +56,9 @@ export function ArtifactPanel({
     }, [downloadUrl]);
     useCollectEvent('openedArtifactPanel');
 
+    const node = workflow.status.nodes[artifact.nodeId];
     const spec = execSpec(workflow);
-    const artifacts = spec.templates.find(t => t.name === workflow.status.nodes[artifact.nodeId].templateName)?.outputs?.artifacts;
+    const artifacts = spec.templates.find(t => t.name === node.templateName)?.outputs?.artifacts;
     let artifactGCStrategy = '';
     if (artifacts !== undefined) {
         artifactGCStrategy = artifacts.find(a => a.name === artifact.name)?.artifactGC?.strategy || spec.artifactGC?.strategy;
@@ -116,6 +119,25 @@ export function ArtifactPanel({
                                 <LinkButton to={downloadUrl}>
                                     <i className='fa fa-download' /> {filename || 'Download'}
                                 </LinkButton>
+                                {(tgz || isDir) && (
+                                    <>
+                                        {' '}
+                                        <LinkButton to={downloadUrl + '?format=zip'}>
+                                            <i className='fa fa-file-archive' /> Download as zip
+                                        </LinkButton>
+                                    </>
+                                )}{' '}
+                                <Links
+                                    button={true}
+                                    object={{
+                                        metadata: workflow.metadata,
+                                        workflow,
+                                        node: {...node, podName: getPodName(workflow, node)},
+                                        artifact: {...artifact, key, urn},
+                                        status: {}
+                                    }}
+                                    scope='artifact'
+                                />
                             </p>
                         )}
                         <GiveFeedbackLink href='https://github.com/argoproj/argo-workflows/issues/7743' />
@@ -236,7 +236,23 @@ function WorkflowNodeSummary(props: Props) {
                         }}
                         scope='pod'
                     />
-                )}
+                )}{' '}
+                <Links
+                    button={true}
+                    object={{
+                        metadata: {
+                            namespace: props.workflow.metadata.namespace,
+                            name: props.workflow.metadata.name
+                        },
+                        workflow: props.workflow,
+                        node: {...props.node, podName: props.node.type === 'Pod' ? podName : ''},
+                        status: {
+                            startedAt: props.node.startedAt,
+                            finishedAt: props.node.finishedAt
+                        }
+                    }}
+                    scope='node'
+                />
             </div>
         </div>
     );
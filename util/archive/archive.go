@@ -20,6 +20,12 @@ type flusher interface {
 
 // TarGzToWriter tar.gz's the source path to the supplied writer
 func TarGzToWriter(sourcePath string, level int, w io.Writer) error {
+	return TarGzToWriterWithProgress(sourcePath, level, w, nil)
+}
+
+// TarGzToWriterWithProgress tar.gz's the source path to the supplied writer, calling progress (if not nil) with the
+// number of bytes of file content as they are archived
+func TarGzToWriterWithProgress(sourcePath string, level int, w io.Writer, progress func(n int64)) error {
 	sourcePath, err := filepath.Abs(sourcePath)
 	if err != nil {
 		return errors.InternalErrorf("getting absolute path: %v", err)
@@ -47,13 +53,19 @@ func TarGzToWriter(sourcePath string, level int, w io.Writer) error {
 	defer util.Close(tw)
 
 	if sourceFi.IsDir() {
-		return tarDir(sourcePath, tw)
+		return tarDir(sourcePath, tw, progress)
 	}
-	return tarFile(sourcePath, tw)
+	return tarFile(sourcePath, tw, progress)
 }
 
 // ZipToWriter zip the source path to the supplied writer
 func ZipToWriter(sourcePath string, zw *zip.Writer) error {
+	return ZipToWriterWithProgress(sourcePath, zw, nil)
+}
+
+// ZipToWriterWithProgress zip the source path to the supplied writer, calling progress (if not nil) with the number
+// of bytes of file content as they are archived
+func ZipToWriterWithProgress(sourcePath string, zw *zip.Writer, progress func(n int64)) error {
 	sourcePath, err := filepath.Abs(sourcePath)
 	if err != nil {
 		return errors.InternalErrorf("getting absolute path: %v", err)
@@ -71,12 +83,32 @@ func ZipToWriter(sourcePath string, zw *zip.Writer) error {
 	}
 
 	if sourceFi.IsDir() {
-		return zipDir(sourcePath, zw)
+		return zipDir(sourcePath, zw, progress)
+	}
+	return zipFile(sourcePath, zw, progress)
+}
+
+// progressReader calls progress with the number of bytes read
+type progressReader struct {
+	io.Reader
+	progress func(n int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.progress(int64(n))
+	return n, err
+}
+
+// copyWithProgress copies r to w, calling progress (if not nil) with the number of bytes copied
+func copyWithProgress(w io.Writer, r io.Reader, progress func(n int64)) (int64, error) {
+	if progress != nil {
+		r = &progressReader{Reader: r, progress: progress}
 	}
-	return zipFile(sourcePath, zw)
+	return io.Copy(w, r)
 }
 
-func tarDir(sourcePath string, tw *tar.Writer) error {
+func tarDir(sourcePath string, tw *tar.Writer, progress func(n int64)) error {
 	baseName := filepath.Base(sourcePath)
 	count := 0
 	err := filepath.Walk(sourcePath, func(fpath string, info os.FileInfo, err error) error {
@@ -123,7 +155,7 @@ func tarDir(sourcePath string, tw *tar.Writer) error {
 		}
 
 		// copy file data into tar writer
-		_, err = io.Copy(tw, f)
+		_, err = copyWithProgress(tw, f, progress)
 		closeErr := f.Close()
 		if err != nil {
 			return err
@@ -137,7 +169,7 @@ func tarDir(sourcePath string, tw *tar.Writer) error {
 	return err
 }
 
-func tarFile(sourcePath string, tw *tar.Writer) error {
+func tarFile(sourcePath string, tw *tar.Writer, progress func(n int64)) error {
 	f, err := os.Open(filepath.Clean(sourcePath))
 	if err != nil {
 		return errors.InternalWrapError(err)
@@ -156,11 +188,11 @@ func tarFile(sourcePath string, tw *tar.Writer) error {
 	if err != nil {
 		return errors.InternalWrapError(err)
 	}
-	_, err = io.Copy(tw, f)
+	_, err = copyWithProgress(tw, f, progress)
 	return err
 }
 
-func zipDir(sourcePath string, zw *zip.Writer) error {
+func zipDir(sourcePath string, zw *zip.Writer, progress func(n int64)) error {
 	baseName := filepath.Base(sourcePath)
 	count := 0
 	err := filepath.Walk(sourcePath, func(fpath string, info os.FileInfo, err error) error {
@@ -193,7 +225,7 @@ func zipDir(sourcePath string, zw *zip.Writer) error {
 		defer f.Close()
 
 		// copy file data into zip writer
-		_, err = io.Copy(fileWriter, f)
+		_, err = copyWithProgress(fileWriter, f, progress)
 		if err != nil {
 			return err
 		}
@@ -204,7 +236,7 @@ func zipDir(sourcePath string, zw *zip.Writer) error {
 	return err
 }
 
-func zipFile(sourcePath string, zw *zip.Writer) error {
+func zipFile(sourcePath string, zw *zip.Writer, progress func(n int64)) error {
 	f, err := os.Open(filepath.Clean(sourcePath))
 	if err != nil {
 		return errors.InternalWrapError(err)
@@ -214,6 +246,6 @@ func zipFile(sourcePath string, zw *zip.Writer) error {
 	if err != nil {
 		return errors.InternalWrapError(err)
 	}
-	_, err = io.Copy(fileWriter, f)
+	_, err = copyWithProgress(fileWriter, f, progress)
 	return err
 }
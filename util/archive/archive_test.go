@@ -6,6 +6,7 @@ import (
 	"compress/gzip"
 	"crypto/rand"
 	"encoding/hex"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -205,3 +206,24 @@ func TestZipFile(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestArchiveWithProgress(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte("hello"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b"), []byte("world!"), 0o600))
+
+	t.Run("Tar", func(t *testing.T) {
+		var archived int64
+		err := TarGzToWriterWithProgress(dir, gzip.DefaultCompression, io.Discard, func(n int64) { archived += n })
+		require.NoError(t, err)
+		require.Equal(t, int64(11), archived)
+	})
+	t.Run("Zip", func(t *testing.T) {
+		var archived int64
+		zw := zip.NewWriter(io.Discard)
+		err := ZipToWriterWithProgress(dir, zw, func(n int64) { archived += n })
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		require.Equal(t, int64(11), archived)
+	})
+}
@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"syscall"
+)
 
 type Exited interface {
 	ExitCode() int
@@ -22,3 +25,29 @@ func (e execErr) ExitCode() int {
 func (e execErr) Error() string {
 	return fmt.Sprintf("exit status %d", e)
 }
+
+// Signaled is the error of a process that was killed by a signal, rather than exiting
+type Signaled interface {
+	Exited
+	Signal() syscall.Signal
+}
+
+// NewSignaledErr returns the error of a process that was killed by the signal, which has the exit code that a shell
+// would report for it
+func NewSignaledErr(signal syscall.Signal) error {
+	return signaledErr(signal)
+}
+
+type signaledErr syscall.Signal
+
+func (e signaledErr) Signal() syscall.Signal {
+	return syscall.Signal(e)
+}
+
+func (e signaledErr) ExitCode() int {
+	return 128 + int(e)
+}
+
+func (e signaledErr) Error() string {
+	return fmt.Sprintf("exit status %d", e.ExitCode())
+}
@@ -0,0 +1,130 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// aggregateFuncMap reduces the aggregated output parameters of fan-out (withItems, withParam, withSequence) steps
+// and tasks, e.g. `aggregate.sum(tasks.count.outputs.parameters.n)`.
+// Each function accepts either a list, or the JSON-encoded list that the controller aggregates the outputs into.
+var aggregateFuncMap = map[string]interface{}{
+	"sum":       aggregateSum,
+	"max":       aggregateMax,
+	"mean":      aggregateMean,
+	"concat":    aggregateConcat,
+	"jsonMerge": aggregateJSONMerge,
+}
+
+func aggregateSum(v interface{}) float64 {
+	sum := 0.0
+	for _, n := range toNumbers(v) {
+		sum += n
+	}
+	return sum
+}
+
+func aggregateMax(v interface{}) float64 {
+	numbers := toNumbers(v)
+	if len(numbers) == 0 {
+		panic("max of an empty list")
+	}
+	max := math.Inf(-1)
+	for _, n := range numbers {
+		max = math.Max(max, n)
+	}
+	return max
+}
+
+func aggregateMean(v interface{}) float64 {
+	numbers := toNumbers(v)
+	if len(numbers) == 0 {
+		panic("mean of an empty list")
+	}
+	return aggregateSum(numbers) / float64(len(numbers))
+}
+
+// aggregateConcat joins the items with the optional separator
+func aggregateConcat(v interface{}, separator ...string) string {
+	items := toList(v)
+	values := make([]string, len(items))
+	for i, item := range items {
+		if s, ok := item.(string); ok {
+			values[i] = s
+		} else {
+			values[i] = toJSON(item)
+		}
+	}
+	return strings.Join(values, strings.Join(separator, ""))
+}
+
+// aggregateJSONMerge merges the JSON objects, with later objects taking precedence
+func aggregateJSONMerge(v interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for _, item := range toList(v) {
+		if s, ok := item.(string); ok {
+			var m map[string]interface{}
+			if err := json.Unmarshal([]byte(s), &m); err != nil {
+				panic(fmt.Errorf("failed to merge %q: %w", s, err))
+			}
+			item = m
+		}
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			panic(fmt.Errorf("failed to merge %v: not a JSON object", item))
+		}
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func toList(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case []float64:
+		list := make([]interface{}, len(v))
+		for i, n := range v {
+			list[i] = n
+		}
+		return list
+	case string:
+		var list []interface{}
+		if err := json.Unmarshal([]byte(v), &list); err != nil {
+			panic(fmt.Errorf("failed to aggregate %q: %w", v, err))
+		}
+		return list
+	default:
+		panic(fmt.Errorf("failed to aggregate %v: not a list", v))
+	}
+}
+
+func toNumbers(v interface{}) []float64 {
+	if numbers, ok := v.([]float64); ok {
+		return numbers
+	}
+	items := toList(v)
+	numbers := make([]float64, len(items))
+	for i, item := range items {
+		switch item := item.(type) {
+		case float64:
+			numbers[i] = item
+		case int:
+			numbers[i] = float64(item)
+		case string:
+			n, err := strconv.ParseFloat(strings.TrimSpace(item), 64)
+			if err != nil {
+				panic(fmt.Errorf("failed to aggregate %q: not a number", item))
+			}
+			numbers[i] = n
+		default:
+			panic(fmt.Errorf("failed to aggregate %v: not a number", item))
+		}
+	}
+	return numbers
+}
@@ -0,0 +1,27 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	outputs := `["1", "2.5", "3"]`
+
+	assert.InEpsilon(t, 6.5, aggregateSum(outputs), 0.0001)
+	assert.InEpsilon(t, 3.0, aggregateMax(outputs), 0.0001)
+	assert.InEpsilon(t, 6.5/3, aggregateMean(outputs), 0.0001)
+	assert.InEpsilon(t, 3.0, aggregateSum([]interface{}{1, 2.0}), 0.0001)
+	assert.Equal(t, "1,2.5,3", aggregateConcat(outputs, ","))
+	assert.Equal(t, "12.53", aggregateConcat(outputs))
+	assert.Equal(t, map[string]interface{}{"a": 1.0, "b": 3.0}, aggregateJSONMerge(`["{\"a\": 1, \"b\": 2}", "{\"b\": 3}"]`))
+	assert.Equal(t, map[string]interface{}{"a": "x"}, aggregateJSONMerge([]interface{}{map[string]interface{}{"a": "x"}}))
+
+	assert.Zero(t, aggregateSum(`[]`))
+	assert.Panics(t, func() { aggregateMax(`[]`) }, "max of an empty list should panic")
+	assert.Panics(t, func() { aggregateMean(`[]`) }, "mean of an empty list should panic")
+	assert.Panics(t, func() { aggregateSum(`["not-a-number"]`) }, "non-numeric values should panic")
+	assert.Panics(t, func() { aggregateSum(`not-a-list`) }, "non-list values should panic")
+	assert.Panics(t, func() { aggregateJSONMerge(`["not-an-object"]`) }, "non-object values should panic")
+}
@@ -97,6 +97,7 @@ func GetFuncMap(m map[string]interface{}) map[string]interface{} {
 	env["jsonpath"] = jsonPath
 	env["toJson"] = toJSON
 	env["sprig"] = sprigFuncMap
+	env["aggregate"] = aggregateFuncMap
 	return env
 }
 
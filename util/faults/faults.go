@@ -0,0 +1,128 @@
+// Package faults injects latency, errors and partial failures into operations, so that e2e tests can check how
+// Argo copes with them. Nothing is injected unless the ARGO_FAULTS environment variable is set.
+package faults
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+// EnvVar is the environment variable of the faults to inject, as a JSON list of faults
+const EnvVar = "ARGO_FAULTS"
+
+// Fault is a fault to inject into operations. Operations are named by what they operate on and what they do, e.g.
+// "artifacts/Save", "k8s/Create/pods" or "persistence/ArchiveWorkflow".
+type Fault struct {
+	// Operation is the name of the operations to inject the fault into, which may be a pattern as used by path.Match
+	Operation string `json:"operation"`
+	// Latency is the duration to wait for before the operation, e.g. "2s"
+	Latency string `json:"latency,omitempty"`
+	// Error is the message of the error to fail the operation with, or empty if it does not fail
+	Error string `json:"error,omitempty"`
+	// Transient is whether the error is transient, so that it is retried
+	Transient bool `json:"transient,omitempty"`
+	// Partial is whether the operation is performed before it fails, as if its result was lost
+	Partial bool `json:"partial,omitempty"`
+	// Probability is the probability of the fault being injected into an operation, or 1 if it is zero
+	Probability float64 `json:"probability,omitempty"`
+	// Count is the number of operations to inject the fault into, or zero if there is no limit
+	Count int `json:"count,omitempty"`
+
+	latency  time.Duration
+	injected int
+}
+
+var (
+	mu     sync.Mutex
+	active []*Fault
+)
+
+func init() {
+	if err := Configure(os.Getenv(EnvVar)); err != nil {
+		log.WithError(err).Fatalf("Invalid %s", EnvVar)
+	}
+}
+
+// Configure replaces the faults that are injected with those of the JSON list, or removes them if it is empty
+func Configure(spec string) error {
+	var faults []*Fault
+	if spec != "" {
+		if err := json.Unmarshal([]byte(spec), &faults); err != nil {
+			return err
+		}
+	}
+	for _, f := range faults {
+		if _, err := path.Match(f.Operation, ""); err != nil || f.Operation == "" {
+			return fmt.Errorf("invalid operation %q", f.Operation)
+		}
+		if f.Latency != "" {
+			latency, err := time.ParseDuration(f.Latency)
+			if err != nil {
+				return fmt.Errorf("invalid latency of %s: %w", f.Operation, err)
+			}
+			f.latency = latency
+		}
+		if f.Probability < 0 || f.Probability > 1 {
+			return fmt.Errorf("invalid probability of %s: %v", f.Operation, f.Probability)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	active = faults
+	if len(faults) > 0 {
+		log.WithField("faults", spec).Warn("Fault injection is enabled, which is only for testing")
+	}
+	return nil
+}
+
+// Enabled returns whether there are faults to inject
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return len(active) > 0
+}
+
+// Inject injects the faults of the operation. It waits for their latency, then returns the error to fail the
+// operation with before it is performed, and the error to fail it with after it is performed.
+func Inject(operation string) (before, after error) {
+	var latency time.Duration
+	mu.Lock()
+	for _, f := range active {
+		if ok, _ := path.Match(f.Operation, operation); !ok {
+			continue
+		}
+		if f.Count > 0 && f.injected >= f.Count || f.Probability > 0 && rand.Float64() >= f.Probability { //nolint:gosec
+			continue
+		}
+		f.injected++
+		latency += f.latency
+		if f.Error == "" {
+			continue
+		}
+		err := errors.New("injected fault: " + f.Error)
+		if f.Transient {
+			err = errorsutil.NewErrTransient(err.Error())
+		}
+		if f.Partial {
+			after = errors.Join(after, err)
+		} else {
+			before = errors.Join(before, err)
+		}
+	}
+	mu.Unlock()
+	if before != nil || after != nil || latency > 0 {
+		log.WithFields(log.Fields{"operation": operation, "latency": latency, "before": before, "after": after}).Info("Injecting fault")
+	}
+	time.Sleep(latency)
+	return before, after
+}
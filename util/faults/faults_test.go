@@ -0,0 +1,102 @@
+package faults
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+func configure(t *testing.T, spec string) {
+	t.Helper()
+	require.NoError(t, Configure(spec))
+	t.Cleanup(func() { _ = Configure("") })
+}
+
+func TestConfigure(t *testing.T) {
+	require.NoError(t, Configure(""))
+	assert.False(t, Enabled())
+	require.EqualError(t, Configure(`[{"operation":"["}]`), `invalid operation "["`)
+	require.EqualError(t, Configure(`[{"operation":"artifacts/Save","latency":"1"}]`), `invalid latency of artifacts/Save: time: missing unit in duration "1"`)
+	require.EqualError(t, Configure(`[{"operation":"artifacts/Save","probability":2}]`), "invalid probability of artifacts/Save: 2")
+	assert.False(t, Enabled())
+	configure(t, `[{"operation":"artifacts/Save"}]`)
+	assert.True(t, Enabled())
+}
+
+func TestInject(t *testing.T) {
+	t.Run("Error", func(t *testing.T) {
+		configure(t, `[{"operation":"artifacts/*","error":"failed","count":1}]`)
+		before, after := Inject("persistence/ArchiveWorkflow")
+		require.NoError(t, before)
+		require.NoError(t, after)
+		before, after = Inject("artifacts/Save")
+		require.EqualError(t, before, "injected fault: failed")
+		require.NoError(t, after)
+		assert.False(t, errorsutil.IsTransientErr(before))
+		before, _ = Inject("artifacts/Save")
+		require.NoError(t, before, "the fault is only injected once")
+	})
+	t.Run("Partial", func(t *testing.T) {
+		configure(t, `[{"operation":"persistence/ArchiveWorkflow","error":"lost","partial":true,"transient":true}]`)
+		before, after := Inject("persistence/ArchiveWorkflow")
+		require.NoError(t, before)
+		require.EqualError(t, after, "injected fault: lost")
+		assert.True(t, errorsutil.IsTransientErr(after))
+	})
+	t.Run("Latency", func(t *testing.T) {
+		configure(t, `[{"operation":"artifacts/Load","latency":"50ms"}]`)
+		start := time.Now()
+		before, after := Inject("artifacts/Load")
+		require.NoError(t, before)
+		require.NoError(t, after)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+	t.Run("Probability", func(t *testing.T) {
+		configure(t, `[{"operation":"artifacts/Load","error":"failed","probability":0.5}]`)
+		failed := 0
+		for range 1000 {
+			if before, _ := Inject("artifacts/Load"); before != nil {
+				failed++
+			}
+		}
+		assert.InDelta(t, 500, failed, 100)
+	})
+}
+
+func TestK8SFaultTransportWrapper(t *testing.T) {
+	created := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		created++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"my-pod"}}`))
+	}))
+	defer ts.Close()
+	configure(t, `[{"operation":"k8s/Create/pods","error":"unavailable","transient":true,"count":1},{"operation":"k8s/Create/pods","error":"lost","partial":true}]`)
+	kubeclientset := kubernetes.NewForConfigOrDie(AddK8SFaultTransportWrapper(&rest.Config{Host: ts.URL}))
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pod"}}
+	ctx := context.Background()
+
+	_, err := kubeclientset.CoreV1().Pods("argo").Create(ctx, pod, metav1.CreateOptions{})
+	assert.True(t, apierr.IsServiceUnavailable(err), err)
+	assert.True(t, errorsutil.IsTransientErr(err))
+	assert.Equal(t, 0, created)
+
+	_, err = kubeclientset.CoreV1().Pods("argo").Create(ctx, pod, metav1.CreateOptions{})
+	assert.True(t, apierr.IsInternalError(err), err)
+	assert.Equal(t, 1, created, "the pod is created before the request fails")
+
+	_, err = kubeclientset.CoreV1().Pods("argo").Get(ctx, "my-pod", metav1.GetOptions{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,73 @@
+package faults
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/k8s"
+)
+
+type k8sFaultRoundTripper struct {
+	roundTripper http.RoundTripper
+}
+
+// RoundTrip injects the faults of "k8s/<verb>/<kind>", e.g. "k8s/Create/pods", into the request. Errors are returned
+// as responses of the API server, with 503 if they are transient, so that they are handled as real ones are.
+func (m k8sFaultRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	verb, kind := k8s.ParseRequest(r)
+	before, after := Inject("k8s/" + verb + "/" + kind)
+	if before != nil {
+		return errorResponse(r, before), nil
+	}
+	x, err := m.roundTripper.RoundTrip(r)
+	if err != nil || after == nil {
+		return x, err
+	}
+	_ = x.Body.Close()
+	return errorResponse(r, after), nil
+}
+
+func errorResponse(r *http.Request, err error) *http.Response {
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  err.Error(),
+		Reason:   metav1.StatusReasonInternalError,
+		Code:     http.StatusInternalServerError,
+	}
+	if errors.Is(err, errorsutil.NewErrTransient("")) {
+		status.Reason = metav1.StatusReasonServiceUnavailable
+		status.Code = http.StatusServiceUnavailable
+	}
+	body, _ := json.Marshal(status)
+	return &http.Response{
+		Status:        http.StatusText(int(status.Code)),
+		StatusCode:    int(status.Code),
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+}
+
+// AddK8SFaultTransportWrapper injects faults into the requests to the Kubernetes API, if there are faults to inject
+func AddK8SFaultTransportWrapper(config *rest.Config) *rest.Config {
+	if !Enabled() {
+		return config
+	}
+	wrap := config.WrapTransport
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return &k8sFaultRoundTripper{roundTripper: rt}
+	}
+	return config
+}
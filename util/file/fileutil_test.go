@@ -175,3 +175,13 @@ func TestExists(t *testing.T) {
 	randFilePath := fmt.Sprintf("/%s", path)
 	assert.False(t, file.Exists(randFilePath))
 }
+
+func TestSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte("hello"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "b"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b", "c"), []byte("world!"), 0o600))
+	assert.Equal(t, int64(11), file.Size(dir))
+	assert.Equal(t, int64(5), file.Size(filepath.Join(dir, "a")))
+	assert.Zero(t, file.Size(filepath.Join(dir, "doesnt-exist")))
+}
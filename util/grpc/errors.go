@@ -1,16 +1,27 @@
 package grpc
 
 import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
 )
 
+// fieldErrorReason is the reason of the ErrorInfo detail of a status for a FieldError
+const fieldErrorReason = "FIELD_ERROR"
+
 // translate a K8S errors into gRPC error - assume that we want to surface this - which we may not
 func TranslateError(err error) error {
+	var fieldErr argoerrors.FieldError
 	switch {
 	case err == nil:
 		return nil
+	case errors.As(err, &fieldErr):
+		return fieldErrorStatus(fieldErr, err.Error())
 	case apierr.IsNotFound(err):
 		return status.Error(codes.NotFound, err.Error())
 	case apierr.IsAlreadyExists(err):
@@ -34,3 +45,42 @@ func TranslateError(err error) error {
 	}
 	return err
 }
+
+// fieldErrorStatus returns the FieldError as an InvalidArgument status, with the path, value, and suggestion as the
+// metadata of an ErrorInfo detail, so that clients can get them back with FieldErrorFromStatus
+func fieldErrorStatus(fieldErr argoerrors.FieldError, msg string) error {
+	s := status.New(codes.InvalidArgument, msg)
+	withDetails, err := s.WithDetails(&errdetails.ErrorInfo{
+		Reason: fieldErrorReason,
+		Domain: "argoproj.io",
+		Metadata: map[string]string{
+			"path":       fieldErr.Path,
+			"value":      fieldErr.Value,
+			"message":    fieldErr.Message,
+			"suggestion": fieldErr.Suggestion,
+		},
+	})
+	if err != nil {
+		return s.Err()
+	}
+	return withDetails.Err()
+}
+
+// FieldErrorFromStatus returns the FieldError of a status returned by the server, if it is for one
+func FieldErrorFromStatus(err error) (argoerrors.FieldError, bool) {
+	s, ok := status.FromError(err)
+	if !ok {
+		return argoerrors.FieldError{}, false
+	}
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason == fieldErrorReason {
+			return argoerrors.FieldError{
+				Path:       info.Metadata["path"],
+				Value:      info.Metadata["value"],
+				Message:    info.Metadata["message"],
+				Suggestion: info.Metadata["suggestion"],
+			}, true
+		}
+	}
+	return argoerrors.FieldError{}, false
+}
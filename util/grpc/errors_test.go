@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+)
+
+func TestTranslateFieldError(t *testing.T) {
+	fieldErr := argoerrors.FieldError{Path: "spec.entrypoint", Value: "mian", Message: "template name 'mian' undefined", Suggestion: "main"}
+	err := TranslateError(fieldErr)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = template name 'mian' undefined, did you mean 'main'?")
+	got, ok := FieldErrorFromStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, fieldErr, got)
+
+	_, ok = FieldErrorFromStatus(status.Error(codes.InvalidArgument, "invalid"))
+	assert.False(t, ok)
+	_, ok = FieldErrorFromStatus(fmt.Errorf("invalid"))
+	assert.False(t, ok)
+}
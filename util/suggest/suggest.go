@@ -0,0 +1,55 @@
+package suggest
+
+import (
+	"strings"
+)
+
+// Closest returns the candidate that the value was most likely meant to be, e.g. when it has been mistyped, or the
+// empty string if no candidate is close enough. A candidate is close enough if it differs from the value by at most a
+// third of its characters, or only by case.
+func Closest(value string, candidates []string) string {
+	if value == "" {
+		return ""
+	}
+	closest := ""
+	closestDistance := max(1, len([]rune(value))/3) + 1
+	for _, candidate := range candidates {
+		if candidate == value {
+			return ""
+		}
+		if strings.EqualFold(candidate, value) {
+			return candidate
+		}
+		if d := distance(strings.ToLower(value), strings.ToLower(candidate)); d < closestDistance {
+			closest, closestDistance = candidate, d
+		}
+	}
+	return closest
+}
+
+// distance returns the optimal string alignment distance between the strings, i.e. the number of characters that
+// need to be inserted, deleted, substituted, or transposed with their neighbour to turn one into the other
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
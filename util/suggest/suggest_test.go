@@ -0,0 +1,27 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"whalesay", "main", "container", "containerSet"}
+	assert.Equal(t, "whalesay", Closest("whalsay", candidates))
+	assert.Equal(t, "main", Closest("mian", candidates))
+	assert.Equal(t, "container", Closest("contianer", candidates))
+	assert.Equal(t, "containerSet", Closest("containerset", candidates))
+	assert.Empty(t, Closest("main", candidates))
+	assert.Empty(t, Closest("garbage", candidates))
+	assert.Empty(t, Closest("", candidates))
+	assert.Empty(t, Closest("main", nil))
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("main", "main"))
+	assert.Equal(t, 1, distance("mian", "main"))
+	assert.Equal(t, 2, distance("mina", "main"))
+	assert.Equal(t, 1, distance("whalsay", "whalesay"))
+	assert.Equal(t, 4, distance("", "main"))
+}
@@ -1,6 +1,99 @@
 // Code generated by util/telemetry/builder. DO NOT EDIT.
 package telemetry
 
+var InstrumentArtifactBytes = BuiltinInstrument{
+	name:        "artifact_bytes",
+	description: "A counter of the bytes transferred by artifact drivers",
+	unit:        "By",
+	instType:    Int64Counter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribArtifactDriver,
+		},
+		{
+			name: AttribArtifactBucket,
+		},
+		{
+			name: AttribArtifactOperation,
+		},
+	},
+}
+
+var InstrumentArtifactErrors = BuiltinInstrument{
+	name:        "artifact_errors",
+	description: "A counter of the artifact driver operations that failed",
+	unit:        "{error}",
+	instType:    Int64Counter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribArtifactDriver,
+		},
+		{
+			name: AttribArtifactBucket,
+		},
+		{
+			name: AttribArtifactOperation,
+		},
+	},
+}
+
+var InstrumentArtifactGcFailures = BuiltinInstrument{
+	name:        "artifact_gc_failures",
+	description: "A counter of the failed attempts to garbage collect artifacts",
+	unit:        "{artifact}",
+	instType:    Int64Counter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribWorkflowNamespace,
+		},
+		{
+			name: AttribArtifactGCDeadLettered,
+		},
+	},
+}
+
+var InstrumentArtifactOperationDuration = BuiltinInstrument{
+	name:        "artifact_operation_duration",
+	description: "A histogram of the durations of artifact driver operations",
+	unit:        "s",
+	instType:    Float64Histogram,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribArtifactDriver,
+		},
+		{
+			name: AttribArtifactBucket,
+		},
+		{
+			name: AttribArtifactOperation,
+		},
+	},
+	defaultBuckets: []float64{
+		0.100000,
+		0.500000,
+		1.000000,
+		5.000000,
+		10.000000,
+		30.000000,
+		60.000000,
+		300.000000,
+		600.000000,
+		1800.000000,
+	},
+}
+
+var InstrumentArtifactQuotaExceeded = BuiltinInstrument{
+	name:        "artifact_quota_exceeded",
+	description: "A counter of the pods that failed to save artifacts because an artifact repository was out of space or over its quota",
+	unit:        "{pod}",
+	instType:    Int64Counter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribWorkflowNamespace,
+		},
+	},
+}
+
 var InstrumentCronworkflowsConcurrencypolicyTriggered = BuiltinInstrument{
 	name:        "cronworkflows_concurrencypolicy_triggered",
 	description: "A counter of the number of times a CronWorkflow has triggered its `concurrencyPolicy` to limit the number of workflows running",
@@ -178,6 +271,32 @@ var InstrumentPodPendingCount = BuiltinInstrument{
 	},
 }
 
+var InstrumentPodSchedulingLatency = BuiltinInstrument{
+	name:        "pod_scheduling_latency",
+	description: "A histogram of how long the pods of nodes waited before their containers started, by the stage of starting them",
+	unit:        "s",
+	instType:    Float64Histogram,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribPodSchedulingStage,
+		},
+		{
+			name: AttribPodNamespace,
+		},
+	},
+	defaultBuckets: []float64{
+		1.000000,
+		5.000000,
+		10.000000,
+		30.000000,
+		60.000000,
+		120.000000,
+		300.000000,
+		600.000000,
+		1800.000000,
+	},
+}
+
 var InstrumentPodsGauge = BuiltinInstrument{
 	name:        "pods_gauge",
 	description: "A gauge of the number of workflow created pods currently in the cluster in each phase",
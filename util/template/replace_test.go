@@ -170,3 +170,17 @@ func TestReplaceStringWithExpression(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, toJSONString("test world"), replacement)
 }
+
+func TestReplaceStringWithAggregateExpression(t *testing.T) {
+	replaceMap := map[string]string{"tasks.count.outputs.parameters.n": `["1","2","3"]`}
+
+	test := toJSONString(`{{= aggregate.sum(tasks.count.outputs.parameters.n) }}`)
+	replacement, err := Replace(test, replaceMap, false)
+	require.NoError(t, err)
+	assert.Equal(t, toJSONString("6"), replacement)
+
+	test = toJSONString(`{{= aggregate.concat(tasks.count.outputs.parameters.n, ",") }}`)
+	replacement, err = Replace(test, replaceMap, false)
+	require.NoError(t, err)
+	assert.Equal(t, toJSONString("1,2,3"), replacement)
+}
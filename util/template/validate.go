@@ -1,8 +1,10 @@
 package template
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/expr-lang/expr"
 	"github.com/valyala/fasttemplate"
 )
 
@@ -22,3 +24,23 @@ func Validate(s string, validator func(tag string) error) error {
 	})
 	return err
 }
+
+// ValidateExpressions checks that the expression templates in s compile, so that malformed expressions are reported
+// before they are evaluated. Their variables are not checked, as their values are not known until then.
+func ValidateExpressions(s string) error {
+	t, err := fasttemplate.NewTemplate(s, prefix, suffix)
+	if err != nil {
+		return err
+	}
+	_, err = t.ExecuteFunc(io.Discard, func(w io.Writer, tag string) (int, error) {
+		kind, expression := parseTag(tag)
+		if kind != kindExpression {
+			return 0, nil
+		}
+		if _, err := expr.Compile(expression); err != nil {
+			return 0, fmt.Errorf("invalid expression {{%s}}: %w", tag, err)
+		}
+		return 0, nil
+	})
+	return err
+}
@@ -25,3 +25,16 @@ func Test_Validate(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func Test_ValidateExpressions(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, ValidateExpressions("{{=sprig.trunc(8, workflow.uid)}}/{{workflow.name}}/outputs"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		err := ValidateExpressions("{{=sprig.trunc(8, workflow.uid}}/outputs")
+		require.ErrorContains(t, err, "invalid expression {{=sprig.trunc(8, workflow.uid}}")
+	})
+	t.Run("Simple", func(t *testing.T) {
+		require.NoError(t, ValidateExpressions("{{foo(}}"))
+	})
+}
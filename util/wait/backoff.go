@@ -1,6 +1,7 @@
 package wait
 
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -24,3 +25,21 @@ func Backoff(b wait.Backoff, f func() (bool, error)) error {
 	}
 	return err
 }
+
+// BackoffWithContext is Backoff, except that it stops waiting once the context is done
+func BackoffWithContext(ctx context.Context, b wait.Backoff, f func(ctx context.Context) (bool, error)) error {
+	var err error
+	waitErr := wait.ExponentialBackoffWithContext(ctx, b, func(ctx context.Context) (bool, error) {
+		var done bool
+		done, err = f(ctx)
+		return done, nil
+	})
+	if waitErr != nil {
+		if err != nil {
+			return fmt.Errorf("%v: %v", waitErr, err)
+		} else {
+			return waitErr
+		}
+	}
+	return err
+}
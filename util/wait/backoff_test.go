@@ -1,8 +1,10 @@
 package wait
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -35,3 +37,20 @@ func TestExponentialBackoff2(t *testing.T) {
 		require.EqualError(t, err, "timed out waiting for the condition: foo")
 	})
 }
+
+func TestBackoffWithContext(t *testing.T) {
+	t.Run("Error", func(t *testing.T) {
+		err := BackoffWithContext(context.Background(), wait.Backoff{Steps: 1}, func(context.Context) (bool, error) {
+			return true, errors.New("foo")
+		})
+		require.EqualError(t, err, "foo")
+	})
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := BackoffWithContext(ctx, wait.Backoff{Steps: 3, Duration: time.Hour}, func(context.Context) (bool, error) {
+			cancel()
+			return false, errors.New("foo")
+		})
+		require.EqualError(t, err, "context canceled: foo")
+	})
+}
@@ -7,16 +7,23 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/azure"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/b2"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/faults"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/filesystem"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/gcs"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/git"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/hdfs"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/http"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/ibmcos"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/oci"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/oss"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/raw"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/s3"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/sftp"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 )
 
 var ErrUnsupportedDriver = fmt.Errorf("unsupported artifact driver")
@@ -29,7 +36,18 @@ func NewDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 	if err != nil {
 		return nil, err
 	}
-	return logging.New(drv), nil
+	drv = faults.New(drv)
+	if art.Encryption != nil && art.Encryption.KeySecret != nil {
+		key, err := ri.GetSecret(ctx, art.Encryption.KeySecret.Name, art.Encryption.KeySecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		drv, err = common.NewEncryptingDriver(drv, []byte(key))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return logging.New(metrics.NewArtifactDriver(drv)), nil
 
 }
 func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (common.ArtifactDriver, error) {
@@ -104,61 +122,19 @@ func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 			KmsEncryptionContext:  kmsEncryptionContext,
 			EnableEncryption:      enableEncryption,
 			ServerSideCustomerKey: serverSideCustomerKey,
+			StorageClass:          art.S3.StorageClass,
+			ACL:                   art.S3.ACL,
+			RequesterPays:         art.S3.RequesterPays,
 		}
 
 		return &driver, nil
 	}
 	if art.HTTP != nil {
-		var client *gohttp.Client
-		driver := http.ArtifactDriver{}
-		if art.HTTP.Auth != nil && art.HTTP.Auth.BasicAuth.UsernameSecret != nil {
-			usernameBytes, err := ri.GetSecret(ctx, art.HTTP.Auth.BasicAuth.UsernameSecret.Name, art.HTTP.Auth.BasicAuth.UsernameSecret.Key)
-			if err != nil {
-				return nil, err
-			}
-			driver.Username = usernameBytes
-		}
-		if art.HTTP.Auth != nil && art.HTTP.Auth.BasicAuth.PasswordSecret != nil {
-			passwordBytes, err := ri.GetSecret(ctx, art.HTTP.Auth.BasicAuth.PasswordSecret.Name, art.HTTP.Auth.BasicAuth.PasswordSecret.Key)
-			if err != nil {
-				return nil, err
-			}
-			driver.Password = passwordBytes
-		}
-		if art.HTTP.Auth != nil && art.HTTP.Auth.OAuth2.ClientIDSecret != nil && art.HTTP.Auth.OAuth2.ClientSecretSecret != nil && art.HTTP.Auth.OAuth2.TokenURLSecret != nil {
-			clientID, err := ri.GetSecret(ctx, art.HTTP.Auth.OAuth2.ClientIDSecret.Name, art.HTTP.Auth.OAuth2.ClientIDSecret.Key)
-			if err != nil {
-				return nil, err
-			}
-			clientSecret, err := ri.GetSecret(ctx, art.HTTP.Auth.OAuth2.ClientSecretSecret.Name, art.HTTP.Auth.OAuth2.ClientSecretSecret.Key)
-			if err != nil {
-				return nil, err
-			}
-			tokenURL, err := ri.GetSecret(ctx, art.HTTP.Auth.OAuth2.TokenURLSecret.Name, art.HTTP.Auth.OAuth2.TokenURLSecret.Key)
-			if err != nil {
-				return nil, err
-			}
-			client = http.CreateOauth2Client(clientID, clientSecret, tokenURL, art.HTTP.Auth.OAuth2.Scopes, art.HTTP.Auth.OAuth2.EndpointParams)
-		}
-		if art.HTTP.Auth != nil && art.HTTP.Auth.ClientCert.ClientCertSecret != nil && art.HTTP.Auth.ClientCert.ClientKeySecret != nil {
-			clientCert, err := ri.GetSecret(ctx, art.HTTP.Auth.ClientCert.ClientCertSecret.Name, art.HTTP.Auth.ClientCert.ClientCertSecret.Key)
-			if err != nil {
-				return nil, err
-			}
-			clientKey, err := ri.GetSecret(ctx, art.HTTP.Auth.ClientCert.ClientKeySecret.Name, art.HTTP.Auth.ClientCert.ClientKeySecret.Key)
-			if err != nil {
-				return nil, err
-			}
-			client, err = http.CreateClientWithCertificate([]byte(clientCert), []byte(clientKey))
-			if err != nil {
-				return nil, err
-			}
-		}
-		if client == nil {
-			client = &gohttp.Client{}
+		drv, err := NewHTTPDriver(ctx, art.HTTP, ri)
+		if err != nil {
+			return nil, err
 		}
-		driver.Client = client
-		return &driver, nil
+		return drv, nil
 	}
 	if art.Git != nil {
 		gitDriver := git.ArtifactDriver{
@@ -200,9 +176,10 @@ func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 			return nil, err
 		}
 		driver := http.ArtifactDriver{
-			Username: usernameBytes,
-			Password: passwordBytes,
-			Client:   &gohttp.Client{},
+			Username:    usernameBytes,
+			Password:    passwordBytes,
+			Client:      &gohttp.Client{},
+			Artifactory: true,
 		}
 		return &driver, nil
 
@@ -210,6 +187,21 @@ func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 	if art.HDFS != nil {
 		return hdfs.CreateDriver(ctx, ri, art.HDFS)
 	}
+	if art.SFTP != nil {
+		return sftp.CreateDriver(ctx, ri, art.SFTP)
+	}
+	if art.B2 != nil {
+		return b2.CreateDriver(ctx, ri, art.B2)
+	}
+	if art.IBMCOS != nil {
+		return ibmcos.CreateDriver(ctx, ri, art.IBMCOS)
+	}
+	if art.OCI != nil {
+		return oci.CreateDriver(ctx, ri, art.OCI)
+	}
+	if art.Filesystem != nil {
+		return filesystem.CreateDriver(art.Filesystem), nil
+	}
 	if art.Raw != nil {
 		return &raw.ArtifactDriver{}, nil
 	}
@@ -251,7 +243,14 @@ func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 			serviceAccountKey := string(serviceAccountKeyBytes)
 			driver.ServiceAccountKey = serviceAccountKey
 		}
-		// key is not set, assume it is using Workload Idendity
+		if art.GCS.ExternalAccountSecret != nil && art.GCS.ExternalAccountSecret.Name != "" {
+			externalAccountBytes, err := ri.GetSecret(ctx, art.GCS.ExternalAccountSecret.Name, art.GCS.ExternalAccountSecret.Key)
+			if err != nil {
+				return nil, err
+			}
+			driver.ExternalAccount = externalAccountBytes
+		}
+		// neither is set, assume it is using Workload Idendity
 		return &driver, nil
 	}
 
@@ -271,8 +270,69 @@ func newDriver(ctx context.Context, art *wfv1.Artifact, ri resource.Interface) (
 			Endpoint:    art.Azure.Endpoint,
 			UseSDKCreds: art.Azure.UseSDKCreds,
 		}
+		if accountKey != "" {
+			secret := art.Azure.AccountKeySecret
+			driver.ReloadAccountKey = func() (string, error) {
+				return ri.GetSecret(ctx, secret.Name, secret.Key)
+			}
+		}
 		return &driver, nil
 	}
 
 	return nil, ErrUnsupportedDriver
 }
+
+// NewHTTPDriver initializes an HTTP artifact driver that authenticates as the artifact does, without the wrappers of
+// NewDriver, so that its client can be used for other requests
+func NewHTTPDriver(ctx context.Context, art *wfv1.HTTPArtifact, ri resource.Interface) (*http.ArtifactDriver, error) {
+	var client *gohttp.Client
+	driver := http.ArtifactDriver{}
+	if art.Auth != nil && art.Auth.BasicAuth.UsernameSecret != nil {
+		usernameBytes, err := ri.GetSecret(ctx, art.Auth.BasicAuth.UsernameSecret.Name, art.Auth.BasicAuth.UsernameSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		driver.Username = usernameBytes
+	}
+	if art.Auth != nil && art.Auth.BasicAuth.PasswordSecret != nil {
+		passwordBytes, err := ri.GetSecret(ctx, art.Auth.BasicAuth.PasswordSecret.Name, art.Auth.BasicAuth.PasswordSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		driver.Password = passwordBytes
+	}
+	if art.Auth != nil && art.Auth.OAuth2.ClientIDSecret != nil && art.Auth.OAuth2.ClientSecretSecret != nil && art.Auth.OAuth2.TokenURLSecret != nil {
+		clientID, err := ri.GetSecret(ctx, art.Auth.OAuth2.ClientIDSecret.Name, art.Auth.OAuth2.ClientIDSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		clientSecret, err := ri.GetSecret(ctx, art.Auth.OAuth2.ClientSecretSecret.Name, art.Auth.OAuth2.ClientSecretSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		tokenURL, err := ri.GetSecret(ctx, art.Auth.OAuth2.TokenURLSecret.Name, art.Auth.OAuth2.TokenURLSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		client = http.CreateOauth2Client(clientID, clientSecret, tokenURL, art.Auth.OAuth2.Scopes, art.Auth.OAuth2.EndpointParams)
+	}
+	if art.Auth != nil && art.Auth.ClientCert.ClientCertSecret != nil && art.Auth.ClientCert.ClientKeySecret != nil {
+		clientCert, err := ri.GetSecret(ctx, art.Auth.ClientCert.ClientCertSecret.Name, art.Auth.ClientCert.ClientCertSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		clientKey, err := ri.GetSecret(ctx, art.Auth.ClientCert.ClientKeySecret.Name, art.Auth.ClientCert.ClientKeySecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		client, err = http.CreateClientWithCertificate([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if client == nil {
+		client = &gohttp.Client{}
+	}
+	driver.Client = client
+	return &driver, nil
+}
@@ -2,13 +2,20 @@ package executor
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 
+	"k8s.io/utils/ptr"
+
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/s3"
 )
 
@@ -62,3 +69,37 @@ func TestNewDriverS3(t *testing.T) {
 	assert.Equal(t, art.S3.SecretKeySecret.Key+"-secret", artDriver.SecretKey)
 	assert.Equal(t, art.S3.SessionTokenSecret.Key+"-secret", artDriver.SessionToken)
 }
+
+// TestNewDriverETag checks that the entity tags of artifacts are returned through the wrappers of the drivers
+func TestNewDriverETag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.URL.Path != "/bucket/art" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"my-etag"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", "3")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	art := &wfv1.Artifact{
+		ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{
+			S3Bucket: wfv1.S3Bucket{
+				Endpoint:        strings.TrimPrefix(server.URL, "http://"),
+				Bucket:          "bucket",
+				Region:          "us-east-1",
+				Insecure:        ptr.To(true),
+				AccessKeySecret: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "accesskey"}, Key: "access-key"},
+				SecretKeySecret: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "secretkey"}, Key: "secret-key"},
+			},
+			Key: "art",
+		}},
+	}
+
+	drv, err := NewDriver(context.TODO(), art, &mockResourceInterface{})
+	require.NoError(t, err)
+	etag, err := common.ETag(drv, art)
+	require.NoError(t, err)
+	assert.Equal(t, "my-etag", etag)
+}
@@ -10,9 +10,13 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	log "github.com/sirupsen/logrus"
@@ -30,9 +34,22 @@ type ArtifactDriver struct {
 	Container   string
 	Endpoint    string
 	UseSDKCreds bool
+	// ReloadAccountKey reloads the account key from its secret, so that a rotated SAS token or account key is used
+	// without restarting the workflow
+	ReloadAccountKey func() (string, error)
+
+	mu         sync.Mutex
+	reloadedAt time.Time
 }
 
-var _ artifactscommon.ArtifactDriver = &ArtifactDriver{}
+var (
+	_ artifactscommon.ArtifactDriver = &ArtifactDriver{}
+	_ artifactscommon.RangeReader    = &ArtifactDriver{}
+	_ artifactscommon.StreamSaver    = &ArtifactDriver{}
+)
+
+// maxBlobSize is the size of the largest block blob, of 50,000 blocks of 4,000 MiB
+const maxBlobSize = int64(50000) * 4000 << 20
 
 // newAzureContainerClient creates a new container.Client for interacting with the specified Azure Blob Storage container
 // The container client is created with the default azblob.ClientOptions which does include retry behavior
@@ -57,14 +74,17 @@ func (azblobDriver *ArtifactDriver) newAzureContainerClient() (*container.Client
 		containerClient, err := container.NewClient(containerURL.String(), credential, nil)
 		return containerClient, err
 	} else {
-		if azblobDriver.AccountKey == "" {
+		accountKey := azblobDriver.accountKey(false)
+		if accountKey == "" {
 			return nil, fmt.Errorf("accountKey secret is required for Azure Blob Storage if useSDKCreds is false")
 		}
 
-		if isSASAccountKey(azblobDriver.AccountKey) {
+		if isSASAccountKey(accountKey) {
 			log.Infof("Provided account key is a SAS token. Using no-credential client.")
-			serviceURL := fmt.Sprintf("%s?%s", containerURL.String(), azblobDriver.AccountKey)
-			containerClient, err := container.NewClientWithNoCredential(serviceURL, nil)
+			// the SAS token is set on each request by the account key policy
+			options := &container.ClientOptions{}
+			options.PerCallPolicies = []policy.Policy{&accountKeyPolicy{driver: azblobDriver}}
+			containerClient, err := container.NewClientWithNoCredential(containerURL.String(), options)
 			return containerClient, err
 		}
 
@@ -72,11 +92,13 @@ func (azblobDriver *ArtifactDriver) newAzureContainerClient() (*container.Client
 		if err != nil {
 			return nil, err
 		}
-		credential, err := azblob.NewSharedKeyCredential(accountName, azblobDriver.AccountKey)
+		credential, err := azblob.NewSharedKeyCredential(accountName, accountKey)
 		if err != nil {
 			return nil, fmt.Errorf("unable to create Azure shared key credential: %s", err)
 		}
-		containerClient, err := container.NewClientWithSharedKeyCredential(containerURL.String(), credential, nil)
+		options := &container.ClientOptions{}
+		options.PerCallPolicies = []policy.Policy{&accountKeyPolicy{driver: azblobDriver, credential: credential}}
+		containerClient, err := container.NewClientWithSharedKeyCredential(containerURL.String(), credential, options)
 		return containerClient, err
 	}
 }
@@ -262,6 +284,45 @@ func (azblobDriver *ArtifactDriver) OpenStream(artifact *wfv1.Artifact) (io.Read
 	return response.Body, nil
 }
 
+// OpenSeekableStream opens the blob of the artifact, for reading from any offset
+func (azblobDriver *ArtifactDriver) OpenSeekableStream(artifact *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	containerClient, err := azblobDriver.newAzureContainerClient()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Azure Blob Container client: %s", err)
+	}
+	blobClient := containerClient.NewBlockBlobClient(artifact.Azure.Blob)
+	props, err := blobClient.GetProperties(context.TODO(), nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, argoerrors.New(argoerrors.CodeNotFound, err.Error())
+		}
+		return nil, fmt.Errorf("unable to get properties of blob %s: %s", artifact.Azure.Blob, err)
+	}
+	return artifactscommon.NewRangeStream(*props.ContentLength, func(offset int64) (io.ReadCloser, error) {
+		response, err := blobClient.DownloadStream(context.TODO(), &blob.DownloadStreamOptions{Range: blob.HTTPRange{Offset: offset}})
+		if err != nil {
+			return nil, fmt.Errorf("unable to open stream for blob %s: %s", artifact.Azure.Blob, err)
+		}
+		return response.Body, nil
+	}), nil
+}
+
+// ETag returns the entity tag of the blob of the artifact
+func (azblobDriver *ArtifactDriver) ETag(artifact *wfv1.Artifact) (string, error) {
+	containerClient, err := azblobDriver.newAzureContainerClient()
+	if err != nil {
+		return "", fmt.Errorf("unable to create Azure Blob Container client: %s", err)
+	}
+	props, err := containerClient.NewBlobClient(artifact.Azure.Blob).GetProperties(context.TODO(), nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to get properties of blob %s: %s", artifact.Azure.Blob, err)
+	}
+	if props.ETag == nil {
+		return "", nil
+	}
+	return string(*props.ETag), nil
+}
+
 // Save saves an artifact to Azure Blob Storage
 func (azblobDriver *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error {
 	log.WithFields(log.Fields{"endpoint": outputArtifact.Azure.Endpoint, "container": outputArtifact.Azure.Container,
@@ -292,6 +353,23 @@ func (azblobDriver *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artif
 	return nil
 }
 
+// SaveStream saves the content of the reader to Azure Blob Storage as a block blob, whose blocks are uploaded as they
+// are read
+func (azblobDriver *ArtifactDriver) SaveStream(r io.Reader, outputArtifact *wfv1.Artifact) error {
+	log.WithFields(log.Fields{"endpoint": outputArtifact.Azure.Endpoint, "container": outputArtifact.Azure.Container,
+		"blob": outputArtifact.Azure.Blob}).Info("Saving stream to Azure Blob Storage")
+
+	containerClient, err := azblobDriver.newAzureContainerClient()
+	if err != nil {
+		return fmt.Errorf("unable to create Azure Blob Container client for %s: %s", outputArtifact.Azure.Blob, err)
+	}
+	blobClient := containerClient.NewBlockBlobClient(outputArtifact.Azure.Blob)
+	if _, err := blobClient.UploadStream(context.TODO(), r, nil); err != nil {
+		return fmt.Errorf("unable to upload stream to Azure: %s", err)
+	}
+	return nil
+}
+
 // PutFile uploads a file to Azure Blob Storage
 func PutFile(containerClient *container.Client, blobName, path string) error {
 	blobClient := containerClient.NewBlockBlobClient(blobName)
@@ -376,6 +454,30 @@ func DeleteBlob(containerClient *container.Client, blobName string, allowNonExis
 	return err
 }
 
+// DeletePrefix deletes every blob whose name starts with the blob of the artifact, a page of listed blobs at a time
+func (azblobDriver *ArtifactDriver) DeletePrefix(artifact *wfv1.Artifact) error {
+	log.WithFields(log.Fields{"endpoint": artifact.Azure.Endpoint, "container": artifact.Azure.Container,
+		"blob": artifact.Azure.Blob}).Info("Deleting prefix from Azure Blob Storage")
+	containerClient, err := azblobDriver.newAzureContainerClient()
+	if err != nil {
+		return fmt.Errorf("unable to create Azure Blob Container client: %s", err)
+	}
+
+	pager := containerClient.NewListBlobsFlatPager(&azblob.ListBlobsFlatOptions{Prefix: &artifact.Azure.Blob})
+	for pager.More() {
+		resp, err := pager.NextPage(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error listing blobs %s in Azure Blob Storage container: %s", artifact.Azure.Blob, err)
+		}
+		for _, v := range resp.Segment.BlobItems {
+			if err := DeleteBlob(containerClient, *v.Name, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // ListObjects lists the files in Azure Blob Storage
 func (azblobDriver *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
 	var files []string
@@ -441,6 +543,11 @@ func (azblobDriver *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool,
 	return false, nil
 }
 
+// Capabilities returns the optional operations that Azure Blob Storage supports
+func (azblobDriver *ArtifactDriver) Capabilities() artifactscommon.Capabilities {
+	return artifactscommon.Capabilities{Delete: true, ListObjects: true, RangedReads: true, StreamingSaves: true, MaxObjectSize: maxBlobSize, PrefixDelete: true}
+}
+
 type uploadTask struct {
 	blobName string
 	path     string
@@ -2,14 +2,20 @@ package azure
 
 import (
 	"context"
+	"encoding/xml"
 	"errors"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
@@ -71,7 +77,7 @@ func TestArtifactDriver_WithServiceKey_DownloadDirectory_Subdir(t *testing.T) {
 	}
 
 	// test read/write operations to the azurite container  using the container client
-	testContainerClientReadWriteOperations(t, containerClient, driver)
+	testContainerClientReadWriteOperations(t, containerClient, &driver)
 }
 
 func TestArtifactDriver_WithSASToken_DownloadDirectory_Subdir(t *testing.T) {
@@ -110,11 +116,11 @@ func TestArtifactDriver_WithSASToken_DownloadDirectory_Subdir(t *testing.T) {
 	require.NoError(t, err)
 
 	// test read/write operations to the azurite container  using the container client
-	testContainerClientReadWriteOperations(t, containerClient, driver)
+	testContainerClientReadWriteOperations(t, containerClient, &driver)
 
 }
 
-func testContainerClientReadWriteOperations(t *testing.T, containerClient *container.Client, driver ArtifactDriver) {
+func testContainerClientReadWriteOperations(t *testing.T, containerClient *container.Client, driver *ArtifactDriver) {
 	// put a file in a subdir on the azurite blob storage
 	// download the dir, containing a subdir
 	blobClient := containerClient.NewBlockBlobClient("dir/subdir/file-in-subdir.txt")
@@ -156,3 +162,104 @@ func TestIsSASAccountKey(t *testing.T) {
 		})
 	}
 }
+
+// forbiddingTransport forbids the requests that are not signed by the SAS token with the valid signature
+type forbiddingTransport struct {
+	validSig string
+	sigs     []string
+}
+
+func (t *forbiddingTransport) Do(req *http.Request) (*http.Response, error) {
+	sig := req.URL.Query().Get("sig")
+	t.sigs = append(t.sigs, sig)
+	statusCode := http.StatusForbidden
+	if sig == t.validSig {
+		statusCode = http.StatusOK
+	}
+	return &http.Response{StatusCode: statusCode, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+}
+
+func TestAccountKeyPolicy(t *testing.T) {
+	send := func(t *testing.T, driver *ArtifactDriver, transport *forbiddingTransport) *http.Response {
+		pl := runtime.NewPipeline("test", "v0.0.0", runtime.PipelineOptions{PerCall: []policy.Policy{&accountKeyPolicy{driver: driver}}}, &policy.ClientOptions{Transport: transport})
+		req, err := runtime.NewRequest(context.Background(), http.MethodGet, "https://myaccount.blob.core.windows.net/my-container?restype=container")
+		require.NoError(t, err)
+		resp, err := pl.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	t.Run("Rotated", func(t *testing.T) {
+		driver := &ArtifactDriver{
+			AccountKey:       "sv=2022-11-02&sig=old",
+			ReloadAccountKey: func() (string, error) { return "sv=2022-11-02&sig=new", nil },
+		}
+		transport := &forbiddingTransport{validSig: "new"}
+		resp := send(t, driver, transport)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"old", "new"}, transport.sigs)
+		assert.Equal(t, "sv=2022-11-02&sig=new", driver.AccountKey)
+	})
+	t.Run("NotRotated", func(t *testing.T) {
+		driver := &ArtifactDriver{
+			AccountKey:       "sv=2022-11-02&sig=old",
+			ReloadAccountKey: func() (string, error) { return "sv=2022-11-02&sig=old", nil },
+		}
+		transport := &forbiddingTransport{validSig: "new"}
+		resp := send(t, driver, transport)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, []string{"old"}, transport.sigs)
+	})
+	t.Run("ReloadFailed", func(t *testing.T) {
+		driver := &ArtifactDriver{
+			AccountKey:       "sv=2022-11-02&sig=old",
+			ReloadAccountKey: func() (string, error) { return "", errors.New("secret not found") },
+		}
+		transport := &forbiddingTransport{validSig: "old"}
+		resp := send(t, driver, transport)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "sv=2022-11-02&sig=old", driver.accountKey(true))
+	})
+}
+
+func TestSetSASToken(t *testing.T) {
+	u, err := url.Parse("https://myaccount.blob.core.windows.net/my-container?restype=container&sig=old")
+	require.NoError(t, err)
+	require.NoError(t, setSASToken(u, "?sv=2022-11-02&sig=new"))
+	assert.Equal(t, url.Values{"restype": {"container"}, "sv": {"2022-11-02"}, "sig": {"new"}}, u.Query())
+}
+
+func TestDeletePrefix(t *testing.T) {
+	blobs := []string{"argo-ephemeral/other-uid/kept.tgz", "argo-ephemeral/my-uid/pod-1/a.tgz", "argo-ephemeral/my-uid/pod-2/b.tgz"}
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "new", query.Get("sig"), "each request is signed by the SAS token")
+		switch {
+		case r.Method == http.MethodGet && query.Get("comp") == "list":
+			type blob struct{ Name string }
+			result := struct {
+				XMLName xml.Name `xml:"EnumerationResults"`
+				Blobs   []blob   `xml:"Blobs>Blob"`
+			}{}
+			for _, name := range blobs {
+				if strings.HasPrefix(name, query.Get("prefix")) {
+					result.Blobs = append(result.Blobs, blob{name})
+				}
+			}
+			w.Header().Set("Content-Type", "application/xml")
+			assert.NoError(t, xml.NewEncoder(w).Encode(result))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/my-container/"))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer server.Close()
+	driver := &ArtifactDriver{AccountKey: "?sv=2022-11-02&sig=new", Container: "my-container", Endpoint: server.URL}
+	assert.True(t, driver.Capabilities().PrefixDelete)
+
+	err := driver.DeletePrefix(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Azure: &wfv1.AzureArtifact{Blob: "argo-ephemeral/my-uid/"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"argo-ephemeral/my-uid/pod-1/a.tgz", "argo-ephemeral/my-uid/pod-2/b.tgz"}, deleted)
+}
@@ -0,0 +1,93 @@
+package azure
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	log "github.com/sirupsen/logrus"
+)
+
+// accountKeyReloadInterval is how often the account key is reloaded from its secret, to pick up rotated keys
+const accountKeyReloadInterval = 30 * time.Second
+
+// accountKey returns the account key, which is reloaded from its secret if it has not been for a while, or if force
+// is set. The previous account key is used if it cannot be reloaded.
+func (azblobDriver *ArtifactDriver) accountKey(force bool) string {
+	azblobDriver.mu.Lock()
+	defer azblobDriver.mu.Unlock()
+	if azblobDriver.ReloadAccountKey == nil {
+		return azblobDriver.AccountKey
+	}
+	if azblobDriver.reloadedAt.IsZero() && !force {
+		// the account key was loaded when the driver was created
+		azblobDriver.reloadedAt = time.Now()
+	}
+	if !force && time.Since(azblobDriver.reloadedAt) < accountKeyReloadInterval {
+		return azblobDriver.AccountKey
+	}
+	azblobDriver.reloadedAt = time.Now()
+	accountKey, err := azblobDriver.ReloadAccountKey()
+	if err != nil {
+		log.WithError(err).Warn("Unable to reload Azure Blob Storage account key, using the previous one")
+	} else if accountKey != "" && accountKey != azblobDriver.AccountKey {
+		log.Info("Azure Blob Storage account key was rotated, using the new one")
+		azblobDriver.AccountKey = accountKey
+	}
+	return azblobDriver.AccountKey
+}
+
+// accountKeyPolicy applies the current account key to each request, so that a rotated SAS token or account key is used
+// by in-flight operations. A request that is forbidden is retried once if the account key was rotated since it was
+// sent.
+type accountKeyPolicy struct {
+	driver *ArtifactDriver
+	// credential is the credential of a client that uses an account key, rather than a SAS token
+	credential *azblob.SharedKeyCredential
+}
+
+func (p *accountKeyPolicy) Do(req *policy.Request) (*http.Response, error) {
+	accountKey := p.driver.accountKey(false)
+	resp, err := p.send(req, accountKey)
+	if err != nil || resp.StatusCode != http.StatusForbidden {
+		return resp, err
+	}
+	rotatedAccountKey := p.driver.accountKey(true)
+	if rotatedAccountKey == accountKey {
+		return resp, err
+	}
+	if err := req.RewindBody(); err != nil {
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	return p.send(req, rotatedAccountKey)
+}
+
+func (p *accountKeyPolicy) send(req *policy.Request, accountKey string) (*http.Response, error) {
+	req = req.Clone(req.Raw().Context())
+	if p.credential != nil {
+		if err := p.credential.SetAccountKey(accountKey); err != nil {
+			return nil, err
+		}
+	} else if err := setSASToken(req.Raw().URL, accountKey); err != nil {
+		return nil, err
+	}
+	return req.Next()
+}
+
+// setSASToken sets the query parameters of the SAS token on the URL, replacing those of any previous SAS token
+func setSASToken(u *url.URL, sasToken string) error {
+	sasQuery, err := url.ParseQuery(strings.TrimPrefix(sasToken, "?"))
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	for key, values := range sasQuery {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+	return nil
+}
@@ -0,0 +1,388 @@
+package b2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	blazer "github.com/Backblaze/blazer/b2"
+	"github.com/Backblaze/blazer/base"
+	log "github.com/sirupsen/logrus"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+const (
+	// maxFileSize is the size of the largest large file
+	maxFileSize = int64(10) << 40
+	// autoContentType asks B2 to set the content type of a file from the extension of its name
+	autoContentType = "b2/x-auto"
+	// defaultPartSize is the size of the parts of large files that B2 recommends
+	defaultPartSize = 100 << 20
+)
+
+// ArtifactDriver is the artifact driver for Backblaze B2, which uses its native API
+type ArtifactDriver struct {
+	ApplicationKeyID string
+	ApplicationKey   string
+	// APIBase is the URL root of the B2 API, which is that of Backblaze unless it is set
+	APIBase string
+	// Progress is called with the number of bytes of files uploaded, as they are uploaded
+	Progress func(n int64)
+	// partSize is the size of the parts of large files, which is defaultPartSize unless it is set
+	partSize int
+}
+
+var (
+	_ common.ArtifactDriver   = &ArtifactDriver{}
+	_ common.ExistenceChecker = &ArtifactDriver{}
+	_ common.ProgressReporter = &ArtifactDriver{}
+)
+
+// ValidateArtifact validates a B2 artifact
+func ValidateArtifact(errPrefix string, art *wfv1.B2Artifact) error {
+	if art.Key == "" {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.key is required", errPrefix)
+	}
+	if art.Bucket == "" {
+		// the rest of the location is from the artifact repository
+		return nil
+	}
+	if art.ApplicationKeyIDSecret == nil {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.applicationKeyIDSecret is required", errPrefix)
+	}
+	if art.ApplicationKeySecret == nil {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.applicationKeySecret is required", errPrefix)
+	}
+	return nil
+}
+
+// CreateDriver constructs ArtifactDriver
+func CreateDriver(ctx context.Context, ri resource.Interface, art *wfv1.B2Artifact) (*ArtifactDriver, error) {
+	driver := ArtifactDriver{}
+	var err error
+	if art.ApplicationKeyIDSecret != nil {
+		driver.ApplicationKeyID, err = ri.GetSecret(ctx, art.ApplicationKeyIDSecret.Name, art.ApplicationKeyIDSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if art.ApplicationKeySecret != nil {
+		driver.ApplicationKey, err = ri.GetSecret(ctx, art.ApplicationKeySecret.Name, art.ApplicationKeySecret.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &driver, nil
+}
+
+// SetProgress sets the function called with the number of bytes uploaded by Save
+func (d *ArtifactDriver) SetProgress(progress func(n int64)) bool {
+	d.Progress = progress
+	return true
+}
+
+// newBucket authorizes the application key and returns the bucket. Keys that are restricted to a bucket are allowed
+// to access only it.
+func (d *ArtifactDriver) newBucket(ctx context.Context, name string) (*blazer.Bucket, error) {
+	var opts []blazer.ClientOption
+	if d.APIBase != "" {
+		opts = append(opts, blazer.APIBase(d.APIBase))
+	}
+	c, err := blazer.NewClient(ctx, d.ApplicationKeyID, d.ApplicationKey, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize the application key: %w", err)
+	}
+	bucket, err := c.Bucket(ctx, name)
+	if blazer.IsNotExist(err) {
+		return nil, fmt.Errorf("bucket %s does not exist, or the application key is not allowed to access it", name)
+	}
+	return bucket, err
+}
+
+// directoryPrefix returns the prefix of the names of the files of a directory
+func directoryPrefix(key string) string {
+	return strings.TrimSuffix(key, "/") + "/"
+}
+
+// listNames lists the names of the files whose names start with the prefix, in order, up to the limit if it is not
+// zero
+func listNames(ctx context.Context, bucket *blazer.Bucket, prefix string, limit int) ([]string, error) {
+	opts := []blazer.ListOption{blazer.ListPrefix(prefix)}
+	if limit > 0 {
+		opts = append(opts, blazer.ListPageSize(limit))
+	}
+	iter := bucket.List(ctx, opts...)
+	var names []string
+	for (limit == 0 || len(names) < limit) && iter.Next() {
+		names = append(names, iter.Object().Name())
+	}
+	return names, iter.Err()
+}
+
+// fileExists returns whether there is a file of the name. Names are listed in order, so the file of the name, if
+// there is one, is the first name with the name as its prefix.
+func fileExists(ctx context.Context, bucket *blazer.Bucket, name string) (bool, error) {
+	names, err := listNames(ctx, bucket, name, 1)
+	return len(names) > 0 && names[0] == name, err
+}
+
+// Load downloads the file of the artifact, or the files of its directory
+func (d *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, localPath string) error {
+	a := inputArtifact.B2
+	log.WithFields(log.Fields{"bucket": a.Bucket, "key": a.Key, "path": localPath}).Info("Loading from B2")
+	ctx := context.Background()
+	bucket, err := d.newBucket(ctx, a.Bucket)
+	if err != nil {
+		return err
+	}
+	isFile, err := fileExists(ctx, bucket, a.Key)
+	if err != nil {
+		return err
+	}
+	if isFile {
+		return downloadFile(ctx, bucket, a.Key, localPath)
+	}
+	prefix := directoryPrefix(a.Key)
+	names, err := listNames(ctx, bucket, prefix, 0)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return argoerrors.Errorf(argoerrors.CodeNotFound, "%s does not exist in bucket %s", a.Key, a.Bucket)
+	}
+	// Follow umask for the permission
+	if err := os.MkdirAll(localPath, 0o777); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "/") {
+			// a folder that was created in the web UI
+			continue
+		}
+		if err := downloadFile(ctx, bucket, name, filepath.Join(localPath, filepath.FromSlash(strings.TrimPrefix(name, prefix)))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func downloadFile(ctx context.Context, bucket *blazer.Bucket, name, localPath string) error {
+	src := bucket.Object(name).NewReader(ctx)
+	defer func() { _ = src.Close() }()
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o777); err != nil {
+		return err
+	}
+	dst, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// OpenStream opens the file of the artifact
+func (d *ArtifactDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	log.WithFields(log.Fields{"bucket": a.B2.Bucket, "key": a.B2.Key}).Info("Streaming from B2")
+	ctx := context.Background()
+	bucket, err := d.newBucket(ctx, a.B2.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	isFile, err := fileExists(ctx, bucket, a.B2.Key)
+	if err != nil {
+		return nil, err
+	}
+	if isFile {
+		return bucket.Object(a.B2.Key).NewReader(ctx), nil
+	}
+	names, err := listNames(ctx, bucket, directoryPrefix(a.B2.Key), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > 0 {
+		return nil, argoerrors.New(argoerrors.CodeNotImplemented, "Directory Stream capability currently unimplemented for B2")
+	}
+	return nil, argoerrors.Errorf(argoerrors.CodeNotFound, "%s does not exist in bucket %s", a.B2.Key, a.B2.Bucket)
+}
+
+// Save uploads the file or the files of the directory to the key of the artifact
+func (d *ArtifactDriver) Save(localPath string, outputArtifact *wfv1.Artifact) error {
+	a := outputArtifact.B2
+	log.WithFields(log.Fields{"bucket": a.Bucket, "key": a.Key, "path": localPath}).Info("Saving to B2")
+	ctx := context.Background()
+	bucket, err := d.newBucket(ctx, a.Bucket)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return d.uploadFile(ctx, bucket, localPath, a.Key)
+	}
+	prefix := directoryPrefix(a.Key)
+	return filepath.WalkDir(localPath, func(p string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(localPath, p)
+		if err != nil {
+			return err
+		}
+		return d.uploadFile(ctx, bucket, p, path.Join(prefix, filepath.ToSlash(rel)))
+	})
+}
+
+// uploadFile uploads the file as the named file, in parts if it is larger than the part size. The file is streamed
+// rather than buffered, and an unfinished large file is cancelled if its upload fails, as its parts are charged for
+// until it is.
+func (d *ArtifactDriver) uploadFile(ctx context.Context, bucket *blazer.Bucket, localPath, name string) error {
+	f, err := os.Open(filepath.Clean(localPath))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	partSize := d.partSize
+	if partSize == 0 {
+		partSize = defaultPartSize
+	}
+	opts := []blazer.WriterOption{blazer.WithAttrsOption(&blazer.Attrs{ContentType: autoContentType})}
+	if info.Size() >= int64(partSize) {
+		// only large files can be cancelled
+		opts = append(opts, blazer.WithCancelOnError(func() context.Context { return ctx }, nil))
+	}
+	w := bucket.Object(name).NewWriter(ctx, opts...)
+	w.ChunkSize = partSize
+	var r io.Reader = f
+	if d.Progress != nil {
+		r = &progressFile{File: f, progress: d.Progress}
+	}
+	// empty files cannot be streamed, and are uploaded when the writer is closed
+	if info.Size() > 0 {
+		_, err = w.ReadFrom(r)
+	}
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		return nil
+	}
+	uploadErr := fmt.Errorf("failed to upload %s: %w", name, err)
+	if isCapExceeded(err) {
+		return common.NewDriverError(common.ErrorKindQuotaExceeded, uploadErr)
+	}
+	return uploadErr
+}
+
+// isCapExceeded returns whether an upload failed because the storage cap of the account has been reached
+func isCapExceeded(err error) bool {
+	status, code, _ := base.MsgCode(err)
+	return status == http.StatusForbidden && (code == "storage_cap_exceeded" || code == "cap_exceeded")
+}
+
+// progressFile reports the number of bytes of a file that are read to upload it. Uploads read the file at offsets,
+// so that it is streamed rather than buffered.
+type progressFile struct {
+	*os.File
+	progress func(n int64)
+}
+
+func (f *progressFile) ReadAt(b []byte, off int64) (int, error) {
+	n, err := f.File.ReadAt(b, off)
+	f.progress(int64(n))
+	return n, err
+}
+
+// Delete deletes every version of the file of the artifact, or of the files of its directory, so that no older
+// version is left visible in buckets that keep them
+func (d *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
+	a := artifact.B2
+	ctx := context.Background()
+	bucket, err := d.newBucket(ctx, a.Bucket)
+	if err != nil {
+		return err
+	}
+	prefix := directoryPrefix(a.Key)
+	iter := bucket.List(ctx, blazer.ListPrefix(a.Key), blazer.ListHidden())
+	for iter.Next() {
+		obj := iter.Object()
+		if obj.Name() != a.Key && !strings.HasPrefix(obj.Name(), prefix) {
+			continue
+		}
+		if err := obj.Delete(ctx); err != nil && !blazer.IsNotExist(err) {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+// ListObjects returns the name of the file of the artifact, or the names of the files of its directory
+func (d *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
+	a := artifact.B2
+	ctx := context.Background()
+	bucket, err := d.newBucket(ctx, a.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	names, err := listNames(ctx, bucket, a.Key, 0)
+	if err != nil {
+		return nil, err
+	}
+	prefix := directoryPrefix(a.Key)
+	var files []string
+	for _, name := range names {
+		if name == a.Key || strings.HasPrefix(name, prefix) && !strings.HasSuffix(name, "/") {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// IsDirectory returns whether there are files whose names start with the key of the artifact and a slash
+func (d *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
+	ctx := context.Background()
+	bucket, err := d.newBucket(ctx, artifact.B2.Bucket)
+	if err != nil {
+		return false, err
+	}
+	names, err := listNames(ctx, bucket, directoryPrefix(artifact.B2.Key), 1)
+	return len(names) > 0, err
+}
+
+// Capabilities returns the optional operations that B2 supports
+func (d *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{Delete: true, ListObjects: true, MaxObjectSize: maxFileSize}
+}
+
+// Exists returns whether the artifact is a file or a directory
+func (d *ArtifactDriver) Exists(artifact *wfv1.Artifact) (bool, error) {
+	ctx := context.Background()
+	bucket, err := d.newBucket(ctx, artifact.B2.Bucket)
+	if err != nil {
+		return false, err
+	}
+	isFile, err := fileExists(ctx, bucket, artifact.B2.Key)
+	if isFile || err != nil {
+		return isFile, err
+	}
+	names, err := listNames(ctx, bucket, directoryPrefix(artifact.B2.Key), 1)
+	return len(names) > 0, err
+}
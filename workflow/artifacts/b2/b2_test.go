@@ -0,0 +1,388 @@
+package b2
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+// apiPath is the path of the version of the B2 API that blazer uses
+const apiPath = "/b2api/v3/"
+
+// fileVersion is a version of a file, as it is listed
+type fileVersion struct {
+	FileID        string `json:"fileId"`
+	FileName      string `json:"fileName"`
+	Action        string `json:"action"`
+	ContentLength int64  `json:"contentLength"`
+}
+
+// largeFile is a large file that is started but not finished
+type largeFile struct {
+	name  string
+	parts map[int][]byte
+}
+
+// fakeB2 is a B2 server of one bucket, for the requests that blazer makes
+type fakeB2 struct {
+	mu         sync.Mutex
+	url        string
+	versions   []fileVersion
+	data       map[string][]byte
+	largeFiles map[string]*largeFile
+	nextID     int
+	// failUploads is the number of uploads that fail before they succeed
+	failUploads int
+	// capExceeded is whether uploads fail because the storage cap of the account has been reached
+	capExceeded bool
+}
+
+func newFakeB2(t *testing.T) (*fakeB2, *ArtifactDriver) {
+	t.Helper()
+	f := &fakeB2{data: map[string][]byte{}, largeFiles: map[string]*largeFile{}}
+	ts := httptest.NewServer(f)
+	t.Cleanup(ts.Close)
+	f.url = ts.URL
+	return f, &ArtifactDriver{ApplicationKeyID: "key-id", ApplicationKey: "key", APIBase: ts.URL, partSize: 10}
+}
+
+func (f *fakeB2) id() string {
+	f.nextID++
+	return strconv.Itoa(f.nextID)
+}
+
+func (f *fakeB2) add(name string, data []byte) fileVersion {
+	v := fileVersion{FileID: f.id(), FileName: name, Action: "upload", ContentLength: int64(len(data))}
+	f.versions = append(f.versions, v)
+	f.data[v.FileID] = data
+	return v
+}
+
+// uploadedContent returns the content of an upload, whose SHA-1 sum is either in a header or after the content
+func uploadedContent(r *http.Request) ([]byte, string, bool) {
+	data, _ := io.ReadAll(r.Body)
+	sum := r.Header.Get("X-Bz-Content-Sha1")
+	if sum == "hex_digits_at_end" && len(data) >= 40 {
+		data, sum = data[:len(data)-40], string(data[len(data)-40:])
+	}
+	actual := sha1.Sum(data) //nolint:gosec
+	return data, sum, hex.EncodeToString(actual[:]) == sum
+}
+
+func (f *fakeB2) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fail := func(status int, code string) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": status, "code": code, "message": code})
+	}
+	reply := func(v any) { _ = json.NewEncoder(w).Encode(v) }
+	if strings.HasPrefix(r.URL.Path, "/file/my-bucket/") {
+		// names are escaped as query strings are
+		name, _ := url.QueryUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/file/my-bucket/"))
+		for i := len(f.versions) - 1; i >= 0; i-- {
+			if v := f.versions[i]; v.FileName == name && v.Action == "upload" {
+				data := f.data[v.FileID]
+				sum := sha1.Sum(data) //nolint:gosec
+				w.Header().Set("X-Bz-File-Id", v.FileID)
+				w.Header().Set("X-Bz-Content-Sha1", hex.EncodeToString(sum[:]))
+				http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+				return
+			}
+		}
+		fail(http.StatusNotFound, "not_found")
+		return
+	}
+	if r.URL.Path == "/upload" || r.URL.Path == "/upload_part" {
+		if f.capExceeded {
+			fail(http.StatusForbidden, "storage_cap_exceeded")
+			return
+		}
+		if f.failUploads > 0 {
+			f.failUploads--
+			fail(http.StatusServiceUnavailable, "service_unavailable")
+			return
+		}
+		data, sum, ok := uploadedContent(r)
+		if !ok {
+			fail(http.StatusBadRequest, "bad_request")
+			return
+		}
+		if r.URL.Path == "/upload_part" {
+			n, _ := strconv.Atoi(r.Header.Get("X-Bz-Part-Number"))
+			f.largeFiles[r.Header.Get("Authorization")].parts[n] = data
+			reply(map[string]any{"partNumber": n, "contentSha1": sum})
+		} else {
+			name, _ := url.QueryUnescape(r.Header.Get("X-Bz-File-Name"))
+			reply(f.add(name, data))
+		}
+		return
+	}
+	var req struct {
+		BucketName    string   `json:"bucketName"`
+		FileID        string   `json:"fileId"`
+		FileName      string   `json:"fileName"`
+		Prefix        string   `json:"prefix"`
+		StartFileName string   `json:"startFileName"`
+		StartFileID   string   `json:"startFileId"`
+		MaxFileCount  int      `json:"maxFileCount"`
+		PartSha1Array []string `json:"partSha1Array"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	switch strings.TrimPrefix(r.URL.Path, apiPath) {
+	case "b2_authorize_account":
+		if id, key, _ := r.BasicAuth(); id != "key-id" || key != "key" {
+			fail(http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		reply(map[string]any{"accountId": "account", "authorizationToken": "token", "apiInfo": map[string]any{
+			"storageApi": map[string]any{"apiUrl": f.url, "downloadUrl": f.url, "recommendedPartSize": 10, "absoluteMinimumPartSize": 5},
+		}})
+	case "b2_list_buckets":
+		if req.BucketName == "my-bucket" {
+			reply(map[string]any{"buckets": []any{map[string]any{"bucketId": "bucket-id", "bucketName": "my-bucket"}}})
+		} else {
+			reply(map[string]any{"buckets": []any{}})
+		}
+	case "b2_get_upload_url":
+		reply(map[string]any{"uploadUrl": f.url + "/upload", "authorizationToken": "upload-token"})
+	case "b2_start_large_file":
+		id := f.id()
+		f.largeFiles[id] = &largeFile{name: req.FileName, parts: map[int][]byte{}}
+		reply(map[string]any{"fileId": id})
+	case "b2_get_upload_part_url":
+		// the token identifies the large file
+		reply(map[string]any{"uploadUrl": f.url + "/upload_part", "authorizationToken": req.FileID})
+	case "b2_finish_large_file":
+		file := f.largeFiles[req.FileID]
+		parts := file.parts
+		var data []byte
+		for i, sum := range req.PartSha1Array {
+			part := parts[i+1]
+			partSum := sha1.Sum(part) //nolint:gosec
+			if hex.EncodeToString(partSum[:]) != sum {
+				fail(http.StatusBadRequest, "bad_request")
+				return
+			}
+			data = append(data, part...)
+		}
+		if len(req.PartSha1Array) != len(parts) || len(parts) < 2 {
+			fail(http.StatusBadRequest, "bad_request")
+			return
+		}
+		delete(f.largeFiles, req.FileID)
+		reply(f.add(file.name, data))
+	case "b2_cancel_large_file":
+		delete(f.largeFiles, req.FileID)
+		reply(map[string]any{})
+	case "b2_list_file_names", "b2_list_file_versions":
+		latest := map[string]fileVersion{}
+		var versions []fileVersion
+		for _, v := range f.versions {
+			if strings.HasPrefix(v.FileName, req.Prefix) && v.FileName >= req.StartFileName {
+				latest[v.FileName] = v
+				versions = append(versions, v)
+			}
+		}
+		if strings.HasSuffix(r.URL.Path, "b2_list_file_names") {
+			versions = nil
+			for _, v := range latest {
+				versions = append(versions, v)
+			}
+		}
+		sort.SliceStable(versions, func(i, j int) bool { return versions[i].FileName < versions[j].FileName })
+		if i := slices.IndexFunc(versions, func(v fileVersion) bool { return v.FileID == req.StartFileID }); i >= 0 {
+			versions = versions[i:]
+		}
+		count := req.MaxFileCount
+		if count <= 0 {
+			count = 100
+		}
+		resp := map[string]any{"files": versions}
+		if len(versions) > count {
+			resp["files"] = versions[:count]
+			resp["nextFileName"] = versions[count].FileName
+			resp["nextFileId"] = versions[count].FileID
+		}
+		reply(resp)
+	case "b2_delete_file_version":
+		for i, v := range f.versions {
+			if v.FileID == req.FileID && v.FileName == req.FileName {
+				f.versions = append(f.versions[:i], f.versions[i+1:]...)
+				reply(map[string]any{"fileId": v.FileID, "fileName": v.FileName})
+				return
+			}
+		}
+		fail(http.StatusNotFound, "not_found")
+	default:
+		fail(http.StatusNotFound, "not_found")
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	f, d := newFakeB2(t)
+	local := t.TempDir()
+	large := strings.Repeat("0123456789", 3) + "tail"
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "dir", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "file.txt"), []byte("hello"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "large.bin"), []byte(large), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "a.txt"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "sub", "b c.txt"), []byte("b"), 0o600))
+	file := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: "out/file.txt"}}}
+	dir := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: "out/dir"}}}
+
+	t.Run("File", func(t *testing.T) {
+		require.NoError(t, d.Save(filepath.Join(local, "file.txt"), file))
+		loaded := filepath.Join(t.TempDir(), "file.txt")
+		require.NoError(t, d.Load(file, loaded))
+		data, err := os.ReadFile(loaded)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+	t.Run("LargeFile", func(t *testing.T) {
+		var progress int64
+		assert.True(t, d.SetProgress(func(n int64) { progress += n }))
+		defer d.SetProgress(nil)
+		art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: "out/large.bin"}}}
+		require.NoError(t, d.Save(filepath.Join(local, "large.bin"), art))
+		assert.Equal(t, int64(len(large)), progress)
+		assert.Empty(t, f.largeFiles, "no large file is left unfinished")
+		loaded := filepath.Join(t.TempDir(), "large.bin")
+		require.NoError(t, d.Load(art, loaded))
+		data, err := os.ReadFile(loaded)
+		require.NoError(t, err)
+		assert.Equal(t, large, string(data))
+	})
+	t.Run("Directory", func(t *testing.T) {
+		require.NoError(t, d.Save(filepath.Join(local, "dir"), dir))
+		isDir, err := d.IsDirectory(dir)
+		require.NoError(t, err)
+		assert.True(t, isDir)
+		names, err := d.ListObjects(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"out/dir/a.txt", "out/dir/sub/b c.txt"}, names)
+
+		loaded := filepath.Join(t.TempDir(), "dir")
+		require.NoError(t, d.Load(dir, loaded))
+		data, err := os.ReadFile(filepath.Join(loaded, "sub", "b c.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "b", string(data))
+	})
+	t.Run("Exists", func(t *testing.T) {
+		for key, expected := range map[string]bool{"out/file.txt": true, "out/dir": true, "out/fi": false, "missing": false} {
+			exists, err := d.Exists(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: key}}})
+			require.NoError(t, err)
+			assert.Equal(t, expected, exists, key)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		err := d.Load(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: "missing"}}}, filepath.Join(t.TempDir(), "missing"))
+		assert.True(t, argoerrors.IsCode(argoerrors.CodeNotFound, err))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		// an older version is deleted too
+		require.NoError(t, d.Save(filepath.Join(local, "dir", "a.txt"), file))
+		require.NoError(t, d.Delete(file))
+		require.NoError(t, d.Delete(dir))
+		var names []string
+		for _, v := range f.versions {
+			names = append(names, v.FileName)
+		}
+		assert.Equal(t, []string{"out/large.bin"}, names)
+	})
+}
+
+func TestOpenStream(t *testing.T) {
+	f, d := newFakeB2(t)
+	f.add("file.txt", []byte("hello"))
+	f.add("dir/a.txt", []byte("a"))
+
+	r, err := d.OpenStream(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: "file.txt"}}})
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	_ = r.Close()
+	assert.Equal(t, "hello", string(data))
+
+	_, err = d.OpenStream(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: "dir"}}})
+	assert.True(t, argoerrors.IsCode(argoerrors.CodeNotImplemented, err))
+	_, err = d.OpenStream(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: "missing"}}})
+	assert.True(t, argoerrors.IsCode(argoerrors.CodeNotFound, err))
+}
+
+func TestUploadRetry(t *testing.T) {
+	f, d := newFakeB2(t)
+	local := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(local, []byte("hello"), 0o600))
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{B2: &wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket"}, Key: "file.txt"}}}
+
+	// uploads to busy servers are retried with new upload URLs
+	f.failUploads = 2
+	require.NoError(t, d.Save(local, art))
+	exists, err := d.Exists(art)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	f.capExceeded = true
+	err = d.Save(local, art)
+	require.Error(t, err)
+	assert.Equal(t, common.ErrorKindQuotaExceeded, common.ErrorKindOf(err))
+}
+
+func TestAuthorize(t *testing.T) {
+	_, d := newFakeB2(t)
+	ctx := context.Background()
+	_, err := d.newBucket(ctx, "other-bucket")
+	require.EqualError(t, err, "bucket other-bucket does not exist, or the application key is not allowed to access it")
+	d.ApplicationKey = "wrong"
+	_, err = d.newBucket(ctx, "my-bucket")
+	require.Error(t, err)
+}
+
+func TestValidateArtifact(t *testing.T) {
+	secret := &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "b2"}, Key: "key"}
+	for name, tc := range map[string]struct {
+		art wfv1.B2Artifact
+		err string
+	}{
+		"Key":   {art: wfv1.B2Artifact{Key: "out.txt"}},
+		"NoKey": {art: wfv1.B2Artifact{}, err: "b2.key is required"},
+		"NoKeyID": {art: wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket", ApplicationKeySecret: secret}, Key: "out.txt"},
+			err: "b2.applicationKeyIDSecret is required"},
+		"NoApplicationKey": {art: wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket", ApplicationKeyIDSecret: secret}, Key: "out.txt"},
+			err: "b2.applicationKeySecret is required"},
+		"Bucket": {art: wfv1.B2Artifact{B2Config: wfv1.B2Config{Bucket: "my-bucket", ApplicationKeyIDSecret: secret, ApplicationKeySecret: secret}, Key: "out.txt"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateArtifact("b2", &tc.art)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
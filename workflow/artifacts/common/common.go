@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
@@ -28,6 +29,118 @@ type ArtifactDriver interface {
 	ListObjects(artifact *v1alpha1.Artifact) ([]string, error)
 
 	IsDirectory(artifact *v1alpha1.Artifact) (bool, error)
+
+	// Capabilities returns the optional operations that the driver supports, so callers can tell whether an operation
+	// is supported before they try it
+	Capabilities() Capabilities
+}
+
+// Capabilities are the optional operations that an artifact driver supports
+type Capabilities struct {
+	// StreamsDirectories is whether OpenStream streams a directory as a tarball
+	StreamsDirectories bool
+	// Delete is whether Delete deletes artifacts, rather than returning ErrDeleteNotSupported
+	Delete bool
+	// ListObjects is whether ListObjects lists the files of a directory
+	ListObjects bool
+	// RangedReads is whether the driver is a RangeReader, so parts of a file can be read without reading all of it
+	RangedReads bool
+	// StreamingSaves is whether the driver is a StreamSaver, so a file can be saved without staging it on disk
+	StreamingSaves bool
+	// MaxObjectSize is the size in bytes of the largest file that can be saved, or zero if there is no limit
+	MaxObjectSize int64
+	// PrefixDelete is whether the driver is a PrefixDeleter, so every file under a prefix can be deleted at once
+	PrefixDelete bool
+}
+
+// RangeReader is implemented by drivers that can open a file for reading from any offset
+type RangeReader interface {
+	OpenSeekableStream(a *v1alpha1.Artifact) (io.ReadSeekCloser, error)
+}
+
+// OpenSeekableStream opens the file of the artifact for reading from any offset, or returns a not implemented error
+// if the driver cannot
+func OpenSeekableStream(d ArtifactDriver, a *v1alpha1.Artifact) (io.ReadSeekCloser, error) {
+	if r, ok := d.(RangeReader); ok {
+		return r.OpenSeekableStream(a)
+	}
+	return nil, argoerrors.New(argoerrors.CodeNotImplemented, "ranged reads are not supported by the artifact driver")
+}
+
+// StreamSaver is implemented by drivers that can save a file of unknown size from a stream, e.g. with a multipart
+// upload, so that files such as tarballs can be saved as they are written rather than staged on disk first
+type StreamSaver interface {
+	// SaveStream saves the content of the reader as the file of the artifact. Implementations must not retry, as the
+	// content cannot be read again.
+	SaveStream(r io.Reader, outputArtifact *v1alpha1.Artifact) error
+}
+
+// SaveStream saves the content of the reader as the file of the artifact, or returns a not implemented error if the
+// driver cannot
+func SaveStream(d ArtifactDriver, r io.Reader, a *v1alpha1.Artifact) error {
+	if s, ok := d.(StreamSaver); ok {
+		return s.SaveStream(r, a)
+	}
+	return argoerrors.New(argoerrors.CodeNotImplemented, "streaming saves are not supported by the artifact driver")
+}
+
+// ExistenceChecker is implemented by drivers that can check whether an artifact exists without loading it
+type ExistenceChecker interface {
+	Exists(artifact *v1alpha1.Artifact) (bool, error)
+}
+
+// Exists returns whether the artifact exists, or false if the driver cannot check
+func Exists(d ArtifactDriver, a *v1alpha1.Artifact) (bool, error) {
+	if c, ok := d.(ExistenceChecker); ok {
+		return c.Exists(a)
+	}
+	return false, nil
+}
+
+// ETagger is implemented by drivers that can return the entity tag of the file of an artifact, which changes whenever
+// its content does
+type ETagger interface {
+	ETag(a *v1alpha1.Artifact) (string, error)
+}
+
+// ETag returns the entity tag of the file of the artifact, or an empty string if the driver cannot
+func ETag(d ArtifactDriver, a *v1alpha1.Artifact) (string, error) {
+	if e, ok := d.(ETagger); ok {
+		return e.ETag(a)
+	}
+	return "", nil
+}
+
+// PrefixDeleter is implemented by drivers that can delete every file whose key starts with a prefix, in as few requests
+// as the storage allows
+type PrefixDeleter interface {
+	// DeletePrefix deletes every file whose key starts with the key of the artifact. Deleting a prefix that no file
+	// starts with is not an error.
+	DeletePrefix(a *v1alpha1.Artifact) error
+}
+
+// DeletePrefix deletes every file whose key starts with the key of the artifact, or returns a not implemented error if
+// the driver cannot
+func DeletePrefix(d ArtifactDriver, a *v1alpha1.Artifact) error {
+	if p, ok := d.(PrefixDeleter); ok {
+		return p.DeletePrefix(a)
+	}
+	return argoerrors.New(argoerrors.CodeNotImplemented, "prefix deletes are not supported by the artifact driver")
+}
+
+// ProgressReporter is implemented by drivers that can report the progress of saving an artifact
+type ProgressReporter interface {
+	// SetProgress sets the function that is called with the number of bytes uploaded, as they are uploaded.
+	// Returns false if progress cannot be reported, e.g. by a wrapper of a driver that cannot.
+	SetProgress(progress func(n int64)) bool
+}
+
+// SetProgress sets the progress function of the driver, returning false if the driver cannot report progress
+func SetProgress(d ArtifactDriver, progress func(n int64)) bool {
+	if r, ok := d.(ProgressReporter); ok {
+		return r.SetProgress(progress)
+	}
+	return false
 }
 
 // ErrDeleteNotSupported Sentinel error definition for artifact deletion
@@ -0,0 +1,305 @@
+package common
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// Encrypted artifacts are written as a header followed by a sequence of segments:
+//
+//	header:  magic (8 bytes) | nonce prefix (12 bytes)
+//	segment: ciphertext length (uint32, big-endian) | AES-GCM ciphertext
+//
+// Each segment is sealed with the nonce prefix XOR'd with the segment index, and with additional data that marks
+// whether it is the final segment, so re-ordered or truncated artifacts fail to decrypt.
+// Segmenting allows artifacts to be decrypted as they are streamed, rather than having to be held in memory.
+const (
+	encryptionMagic       = "ARGOENC1"
+	encryptionSegmentSize = 64 * 1024
+)
+
+var (
+	segmentNotFinal = []byte{0}
+	segmentFinal    = []byte{1}
+)
+
+// ErrNotEncrypted is returned when decrypting an artifact that was not encrypted by the encrypting driver
+var ErrNotEncrypted = errors.New("artifact is not encrypted")
+
+// encryptingDriver encrypts artifacts before they are saved by the wrapped driver, and decrypts them after they are
+// loaded, so artifacts are protected even when the artifact repository does not support server-side encryption
+type encryptingDriver struct {
+	ArtifactDriver
+	aead cipher.AEAD
+}
+
+// NewEncryptingDriver wraps the driver with client-side AES-GCM encryption. The key must be 16, 24 or 32 bytes long.
+func NewEncryptingDriver(d ArtifactDriver, key []byte) (ArtifactDriver, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptingDriver{ArtifactDriver: d, aead: aead}, nil
+}
+
+func (d *encryptingDriver) Load(a *wfv1.Artifact, path string) error {
+	tmp, err := os.MkdirTemp("", "encrypted-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	encrypted := filepath.Join(tmp, "artifact")
+	if err := d.ArtifactDriver.Load(a, encrypted); err != nil {
+		return err
+	}
+	return transformPath(encrypted, path, d.decrypt)
+}
+
+func (d *encryptingDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	rc, err := d.ArtifactDriver.OpenStream(a)
+	if err != nil {
+		return nil, err
+	}
+	return &readCloser{Reader: d.newDecryptingReader(rc), Closer: rc}, nil
+}
+
+func (d *encryptingDriver) Save(path string, a *wfv1.Artifact) error {
+	tmp, err := os.MkdirTemp("", "encrypted-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	encrypted := filepath.Join(tmp, filepath.Base(path))
+	if err := transformPath(path, encrypted, d.encrypt); err != nil {
+		return err
+	}
+	return d.ArtifactDriver.Save(encrypted, a)
+}
+
+// SaveStream encrypts the content of the reader as it is saved by the wrapped driver
+func (d *encryptingDriver) SaveStream(r io.Reader, a *wfv1.Artifact) error {
+	pr, pw := io.Pipe()
+	go func() { _ = pw.CloseWithError(d.encrypt(pw, r)) }()
+	err := SaveStream(d.ArtifactDriver, pr, a)
+	// stop encrypting if the wrapped driver stopped reading
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+func (d *encryptingDriver) Exists(a *wfv1.Artifact) (bool, error) {
+	return Exists(d.ArtifactDriver, a)
+}
+
+func (d *encryptingDriver) ETag(a *wfv1.Artifact) (string, error) {
+	return ETag(d.ArtifactDriver, a)
+}
+
+func (d *encryptingDriver) DeletePrefix(a *wfv1.Artifact) error {
+	return DeletePrefix(d.ArtifactDriver, a)
+}
+
+func (d *encryptingDriver) SetProgress(progress func(n int64)) bool {
+	return SetProgress(d.ArtifactDriver, progress)
+}
+
+// Capabilities returns the capabilities of the wrapped driver, less streaming directories and ranged reads, as the
+// files of tarballs and offsets into files are encrypted
+func (d *encryptingDriver) Capabilities() Capabilities {
+	c := d.ArtifactDriver.Capabilities()
+	c.StreamsDirectories = false
+	c.RangedReads = false
+	return c
+}
+
+// encrypt writes the encrypted contents of r to w
+func (d *encryptingDriver) encrypt(w io.Writer, r io.Reader) error {
+	prefix := make([]byte, d.aead.NonceSize())
+	if _, err := rand.Read(prefix); err != nil {
+		return err
+	}
+	if _, err := w.Write(append([]byte(encryptionMagic), prefix...)); err != nil {
+		return err
+	}
+	br := bufio.NewReader(r)
+	buf := make([]byte, encryptionSegmentSize)
+	var length [4]byte
+	for i := uint64(0); ; i++ {
+		n, err := io.ReadFull(br, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		final := err != nil
+		if !final {
+			if _, err := br.Peek(1); err == io.EOF {
+				final = true
+			} else if err != nil {
+				return err
+			}
+		}
+		additionalData := segmentNotFinal
+		if final {
+			additionalData = segmentFinal
+		}
+		ciphertext := d.aead.Seal(nil, segmentNonce(prefix, i), buf[:n], additionalData)
+		binary.BigEndian.PutUint32(length[:], uint32(len(ciphertext)))
+		if _, err := w.Write(length[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(ciphertext); err != nil {
+			return err
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// decrypt writes the decrypted contents of r to w
+func (d *encryptingDriver) decrypt(w io.Writer, r io.Reader) error {
+	_, err := io.Copy(w, d.newDecryptingReader(r))
+	return err
+}
+
+// newDecryptingReader returns a reader of the decrypted contents of r
+func (d *encryptingDriver) newDecryptingReader(r io.Reader) io.Reader {
+	return &decryptingReader{r: r, aead: d.aead}
+}
+
+type decryptingReader struct {
+	r      io.Reader
+	aead   cipher.AEAD
+	prefix []byte
+	index  uint64
+	// buf is the decrypted data that has not been read yet
+	buf   []byte
+	final bool
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.readSegment(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptingReader) readSegment() error {
+	if d.prefix == nil {
+		header := make([]byte, len(encryptionMagic)+d.aead.NonceSize())
+		if _, err := io.ReadFull(d.r, header); err != nil || string(header[:len(encryptionMagic)]) != encryptionMagic {
+			return ErrNotEncrypted
+		}
+		d.prefix = header[len(encryptionMagic):]
+	}
+	var length [4]byte
+	if _, err := io.ReadFull(d.r, length[:]); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size > uint32(encryptionSegmentSize+d.aead.Overhead()) {
+		return fmt.Errorf("failed to decrypt artifact: invalid segment length %d", size)
+	}
+	ciphertext := make([]byte, size)
+	if _, err := io.ReadFull(d.r, ciphertext); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	nonce := segmentNonce(d.prefix, d.index)
+	plaintext, err := d.aead.Open(nil, nonce, ciphertext, segmentFinal)
+	if err == nil {
+		d.final = true
+	} else {
+		plaintext, err = d.aead.Open(nil, nonce, ciphertext, segmentNotFinal)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt artifact: %w", err)
+		}
+	}
+	d.index++
+	d.buf = plaintext
+	return nil
+}
+
+// segmentNonce returns the nonce of the i-th segment
+func segmentNonce(prefix []byte, i uint64) []byte {
+	nonce := make([]byte, len(prefix))
+	copy(nonce, prefix)
+	for j := 0; j < 8; j++ {
+		nonce[len(nonce)-1-j] ^= byte(i >> (8 * j))
+	}
+	return nonce
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// transformPath writes each file of src to the same relative location in dst, transformed by f
+func transformPath(src, dst string, f func(w io.Writer, r io.Reader) error) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return transformFile(src, dst, info.Mode(), f)
+	}
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+		return transformFile(path, target, info.Mode(), f)
+	})
+}
+
+func transformFile(src, dst string, mode os.FileMode, f func(w io.Writer, r io.Reader) error) error {
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if err := f(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,181 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// memoryArtifactDriver stores the saved files in memory, keyed by their path relative to the saved path
+type memoryArtifactDriver struct {
+	ArtifactDriver
+	files map[string][]byte
+}
+
+func (m *memoryArtifactDriver) Save(path string, _ *wfv1.Artifact) error {
+	m.files = map[string][]byte{}
+	return filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		m.files[rel] = data
+		return err
+	})
+}
+
+func (m *memoryArtifactDriver) Load(_ *wfv1.Artifact, path string) error {
+	for rel, data := range m.files {
+		p := filepath.Join(path, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, data, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryArtifactDriver) SaveStream(r io.Reader, _ *wfv1.Artifact) error {
+	data, err := io.ReadAll(r)
+	m.files = map[string][]byte{".": data}
+	return err
+}
+
+func (m *memoryArtifactDriver) OpenStream(_ *wfv1.Artifact) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m.files["."])), nil
+}
+
+func (m *memoryArtifactDriver) Capabilities() Capabilities {
+	return Capabilities{StreamsDirectories: true, Delete: true, ListObjects: true, RangedReads: true, MaxObjectSize: 1024}
+}
+
+func TestEncryptingDriverCapabilities(t *testing.T) {
+	d, err := NewEncryptingDriver(&memoryArtifactDriver{}, []byte("0123456789abcdef"))
+	require.NoError(t, err)
+	assert.Equal(t, Capabilities{Delete: true, ListObjects: true, MaxObjectSize: 1024}, d.Capabilities())
+	_, err = OpenSeekableStream(d, &wfv1.Artifact{})
+	require.Error(t, err)
+}
+
+func TestEncryptingDriver(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	// larger than a segment, and not a multiple of it
+	data := bytes.Repeat([]byte("my-data"), encryptionSegmentSize)
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		_, err := NewEncryptingDriver(&memoryArtifactDriver{}, []byte("too-short"))
+		require.ErrorContains(t, err, "invalid artifact encryption key")
+	})
+	t.Run("File", func(t *testing.T) {
+		m := &memoryArtifactDriver{}
+		d, err := NewEncryptingDriver(m, key)
+		require.NoError(t, err)
+		src := filepath.Join(t.TempDir(), "src")
+		require.NoError(t, os.WriteFile(src, data, 0o600))
+
+		require.NoError(t, d.Save(src, &wfv1.Artifact{}))
+		assert.NotContains(t, string(m.files["."]), "my-data")
+
+		dst := filepath.Join(t.TempDir(), "dst")
+		require.NoError(t, d.Load(&wfv1.Artifact{}, dst))
+		loaded, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		assert.Equal(t, data, loaded)
+
+		rc, err := d.OpenStream(&wfv1.Artifact{})
+		require.NoError(t, err)
+		streamed, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		assert.Equal(t, data, streamed)
+	})
+	t.Run("Stream", func(t *testing.T) {
+		m := &memoryArtifactDriver{}
+		d, err := NewEncryptingDriver(m, key)
+		require.NoError(t, err)
+
+		require.NoError(t, SaveStream(d, bytes.NewReader(data), &wfv1.Artifact{}))
+		assert.NotContains(t, string(m.files["."]), "my-data")
+
+		rc, err := d.OpenStream(&wfv1.Artifact{})
+		require.NoError(t, err)
+		streamed, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, data, streamed)
+	})
+	t.Run("StreamNotImplemented", func(t *testing.T) {
+		d, err := NewEncryptingDriver(&struct{ ArtifactDriver }{&memoryArtifactDriver{}}, key)
+		require.NoError(t, err)
+		err = SaveStream(d, bytes.NewReader(data), &wfv1.Artifact{})
+		assert.True(t, argoerrors.IsCode(argoerrors.CodeNotImplemented, err))
+	})
+	t.Run("EmptyFile", func(t *testing.T) {
+		m := &memoryArtifactDriver{}
+		d, err := NewEncryptingDriver(m, key)
+		require.NoError(t, err)
+		src := filepath.Join(t.TempDir(), "src")
+		require.NoError(t, os.WriteFile(src, nil, 0o600))
+
+		require.NoError(t, d.Save(src, &wfv1.Artifact{}))
+		rc, err := d.OpenStream(&wfv1.Artifact{})
+		require.NoError(t, err)
+		streamed, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Empty(t, streamed)
+	})
+	t.Run("Directory", func(t *testing.T) {
+		m := &memoryArtifactDriver{}
+		d, err := NewEncryptingDriver(m, key)
+		require.NoError(t, err)
+		src := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(src, "a"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "a", "b"), []byte("my-data"), 0o600))
+
+		require.NoError(t, d.Save(src, &wfv1.Artifact{}))
+		assert.Contains(t, m.files, filepath.Join("a", "b"))
+
+		dst := filepath.Join(t.TempDir(), "dst")
+		require.NoError(t, d.Load(&wfv1.Artifact{}, dst))
+		loaded, err := os.ReadFile(filepath.Join(dst, "a", "b"))
+		require.NoError(t, err)
+		assert.Equal(t, "my-data", string(loaded))
+	})
+	t.Run("Tampered", func(t *testing.T) {
+		m := &memoryArtifactDriver{}
+		d, err := NewEncryptingDriver(m, key)
+		require.NoError(t, err)
+		src := filepath.Join(t.TempDir(), "src")
+		require.NoError(t, os.WriteFile(src, data, 0o600))
+		require.NoError(t, d.Save(src, &wfv1.Artifact{}))
+
+		// truncating the final segment must be detected
+		m.files["."] = m.files["."][:len(encryptionMagic)+12+4+encryptionSegmentSize+16]
+		rc, err := d.OpenStream(&wfv1.Artifact{})
+		require.NoError(t, err)
+		_, err = io.ReadAll(rc)
+		require.Error(t, err)
+	})
+	t.Run("NotEncrypted", func(t *testing.T) {
+		m := &memoryArtifactDriver{files: map[string][]byte{".": []byte("my-data")}}
+		d, err := NewEncryptingDriver(m, key)
+		require.NoError(t, err)
+		rc, err := d.OpenStream(&wfv1.Artifact{})
+		require.NoError(t, err)
+		_, err = io.ReadAll(rc)
+		require.ErrorIs(t, err, ErrNotEncrypted)
+	})
+}
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+// ErrorKind is the kind of an error returned by an artifact driver, so that callers can decide whether to retry the
+// operation, fail it, or surface the error to the user, without knowing about the storage of the driver
+type ErrorKind string
+
+const (
+	// ErrorKindNotFound is when the artifact, or the bucket or container it would be in, does not exist
+	ErrorKindNotFound ErrorKind = "NotFound"
+	// ErrorKindForbidden is when the credentials of the artifact are not permitted to perform the operation
+	ErrorKindForbidden ErrorKind = "Forbidden"
+	// ErrorKindThrottled is when the storage rejected the request because of its rate limits
+	ErrorKindThrottled ErrorKind = "Throttled"
+	// ErrorKindTransient is when the operation failed in a way that may not happen again, e.g. a network error
+	ErrorKindTransient ErrorKind = "Transient"
+	// ErrorKindTooLarge is when the artifact is larger than the storage accepts
+	ErrorKindTooLarge ErrorKind = "TooLarge"
+	// ErrorKindQuotaExceeded is when the storage is out of space, or saving the artifact would exceed a quota of it
+	ErrorKindQuotaExceeded ErrorKind = "QuotaExceeded"
+)
+
+// Retryable is whether an operation that failed with an error of the kind may succeed if it is retried
+func (k ErrorKind) Retryable() bool {
+	return k == ErrorKindThrottled || k == ErrorKindTransient
+}
+
+// DriverError is an error returned by an artifact driver, which wraps the error of the storage with its kind
+type DriverError struct {
+	Kind ErrorKind
+	Err  error
+}
+
+var _ argoerrors.ArgoError = &DriverError{}
+
+// NewDriverError returns an error of the kind that wraps the error, or nil if the error is nil
+func NewDriverError(kind ErrorKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &DriverError{Kind: kind, Err: err}
+}
+
+func (e *DriverError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DriverError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the code of the Argo error that corresponds to the kind, so that errors.IsCode still works for drivers
+// that return driver errors
+func (e *DriverError) Code() string {
+	switch e.Kind {
+	case ErrorKindNotFound:
+		return argoerrors.CodeNotFound
+	case ErrorKindForbidden:
+		return argoerrors.CodeForbidden
+	case ErrorKindTooLarge:
+		return argoerrors.CodeBadRequest
+	default:
+		return argoerrors.CodeInternal
+	}
+}
+
+func (e *DriverError) HTTPCode() int {
+	switch e.Kind {
+	case ErrorKindNotFound:
+		return http.StatusNotFound
+	case ErrorKindForbidden:
+		return http.StatusForbidden
+	case ErrorKindThrottled:
+		return http.StatusTooManyRequests
+	case ErrorKindTransient:
+		return http.StatusServiceUnavailable
+	case ErrorKindTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case ErrorKindQuotaExceeded:
+		return http.StatusInsufficientStorage
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (e *DriverError) JSON() []byte {
+	type errBean struct {
+		Code    string    `json:"code"`
+		Kind    ErrorKind `json:"kind"`
+		Message string    `json:"message"`
+	}
+	j, _ := json.Marshal(errBean{e.Code(), e.Kind, e.Error()})
+	return j
+}
+
+// ErrorKindOf returns the kind of the error returned by an artifact driver, or an empty string if it has no kind.
+// Drivers that do not return driver errors yet may still return not found and forbidden Argo errors, which are of those
+// kinds.
+func ErrorKindOf(err error) ErrorKind {
+	var driverErr *DriverError
+	if errors.As(err, &driverErr) {
+		return driverErr.Kind
+	}
+	switch {
+	case argoerrors.IsCode(argoerrors.CodeNotFound, err):
+		return ErrorKindNotFound
+	case argoerrors.IsCode(argoerrors.CodeForbidden, err):
+		return ErrorKindForbidden
+	}
+	return ""
+}
+
+// IsRetryable returns whether the error returned by an artifact driver may not happen again if the operation is
+// retried. The kind of the error decides if it has one, so that e.g. a forbidden error is not retried, otherwise
+// whether it is a transient error, e.g. of the network.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if kind := ErrorKindOf(err); kind != "" {
+		return kind.Retryable()
+	}
+	return errorsutil.IsTransientErr(err)
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+)
+
+func TestDriverError(t *testing.T) {
+	assert.NoError(t, NewDriverError(ErrorKindNotFound, nil))
+
+	cause := errors.New("no such key")
+	err := NewDriverError(ErrorKindNotFound, cause)
+	assert.EqualError(t, err, "no such key")
+	assert.ErrorIs(t, err, cause)
+	assert.True(t, argoerrors.IsCode(argoerrors.CodeNotFound, err))
+	assert.Equal(t, http.StatusNotFound, err.(argoerrors.ArgoError).HTTPCode())
+	assert.JSONEq(t, `{"code":"ERR_NOT_FOUND","kind":"NotFound","message":"no such key"}`, string(err.(argoerrors.ArgoError).JSON()))
+}
+
+func TestErrorKindOf(t *testing.T) {
+	assert.Equal(t, ErrorKind(""), ErrorKindOf(nil))
+	assert.Equal(t, ErrorKind(""), ErrorKindOf(errors.New("unknown")))
+	assert.Equal(t, ErrorKindThrottled, ErrorKindOf(fmt.Errorf("failed to load: %w", NewDriverError(ErrorKindThrottled, errors.New("slow down")))))
+	assert.Equal(t, ErrorKindNotFound, ErrorKindOf(argoerrors.New(argoerrors.CodeNotFound, "not found")))
+	assert.Equal(t, ErrorKindForbidden, ErrorKindOf(argoerrors.New(argoerrors.CodeForbidden, "forbidden")))
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.False(t, IsRetryable(nil))
+	assert.True(t, IsRetryable(NewDriverError(ErrorKindThrottled, errors.New("slow down"))))
+	assert.True(t, IsRetryable(NewDriverError(ErrorKindTransient, errors.New("internal error"))))
+	assert.False(t, IsRetryable(NewDriverError(ErrorKindNotFound, errors.New("no such key"))))
+	assert.False(t, IsRetryable(NewDriverError(ErrorKindTooLarge, errors.New("entity too large"))))
+	assert.False(t, IsRetryable(NewDriverError(ErrorKindQuotaExceeded, errors.New("storage full"))))
+	// the kind decides, rather than the message
+	assert.False(t, IsRetryable(NewDriverError(ErrorKindForbidden, errorsutil.NewErrTransient("connection refused"))))
+	assert.True(t, IsRetryable(errorsutil.NewErrTransient("connection refused")))
+	assert.False(t, IsRetryable(errors.New("unknown")))
+}
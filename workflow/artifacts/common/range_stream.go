@@ -0,0 +1,71 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// rangeStream is a seekable stream of a file of a known size, for drivers that can read files from an offset. The file
+// is only opened from its offset when it is read, so seeking, e.g. to the end to find the size, does not read it.
+type rangeStream struct {
+	size   int64
+	offset int64
+	open   func(offset int64) (io.ReadCloser, error)
+	r      io.ReadCloser
+}
+
+// NewRangeStream returns a seekable stream of a file of the size, which is read by opening it from an offset
+func NewRangeStream(size int64, open func(offset int64) (io.ReadCloser, error)) io.ReadSeekCloser {
+	return &rangeStream{size: size, open: open}
+}
+
+func (s *rangeStream) Read(p []byte) (int, error) {
+	if s.offset >= s.size {
+		return 0, io.EOF
+	}
+	if s.r == nil {
+		r, err := s.open(s.offset)
+		if err != nil {
+			return 0, err
+		}
+		s.r = r
+	}
+	n, err := s.r.Read(p)
+	s.offset += int64(n)
+	return n, err
+}
+
+func (s *rangeStream) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += s.offset
+	case io.SeekEnd:
+		offset += s.size
+	case io.SeekStart:
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if offset != s.offset && s.r != nil {
+		// the file is opened again from the new offset when it is next read
+		err := s.r.Close()
+		s.r = nil
+		if err != nil {
+			return 0, err
+		}
+	}
+	s.offset = offset
+	return offset, nil
+}
+
+func (s *rangeStream) Close() error {
+	if s.r == nil {
+		return nil
+	}
+	err := s.r.Close()
+	s.r = nil
+	return err
+}
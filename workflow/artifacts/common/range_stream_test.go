@@ -0,0 +1,46 @@
+package common
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeStream(t *testing.T) {
+	content := "0123456789"
+	var opened []int64
+	s := NewRangeStream(int64(len(content)), func(offset int64) (io.ReadCloser, error) {
+		opened = append(opened, offset)
+		return io.NopCloser(strings.NewReader(content[offset:])), nil
+	})
+
+	size, err := s.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), size)
+	offset, err := s.Seek(3, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), offset)
+	assert.Empty(t, opened, "seeking does not open the file")
+
+	data := make([]byte, 2)
+	_, err = io.ReadFull(s, data)
+	require.NoError(t, err)
+	assert.Equal(t, "34", string(data))
+	offset, err = s.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), offset)
+
+	_, err = s.Seek(-2, io.SeekEnd)
+	require.NoError(t, err)
+	rest, err := io.ReadAll(s)
+	require.NoError(t, err)
+	assert.Equal(t, "89", string(rest))
+	assert.Equal(t, []int64{3, 8}, opened)
+
+	_, err = s.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+	require.NoError(t, s.Close())
+}
@@ -0,0 +1,54 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/retry"
+	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+// Copy copies an artifact to another location, which may be in another type of repository, e.g. from S3 to OCI. The
+// artifact is loaded into a temporary directory and saved from there as it is, so archived artifacts stay archived.
+// Loads and saves that fail with errors that may not happen again are retried.
+func Copy(ctx context.Context, from, to *wfv1.Artifact, ri resource.Interface, newDriver NewDriverFunc) error {
+	fromDriver, err := newDriver(ctx, from, ri)
+	if err != nil {
+		return err
+	}
+	toDriver, err := newDriver(ctx, to, ri)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "artifact-copy")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "artifact")
+	err = withRetry(func() error {
+		// each attempt loads the artifact from the start
+		_ = os.RemoveAll(path)
+		return fromDriver.Load(from, path)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load artifact %s: %w", from.Name, err)
+	}
+	err = withRetry(func() error { return toDriver.Save(path, to) })
+	if err != nil {
+		return fmt.Errorf("failed to save artifact %s: %w", to.Name, err)
+	}
+	return nil
+}
+
+func withRetry(f func() error) error {
+	return waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+		err := f()
+		return err == nil || !common.IsRetryable(err), err
+	})
+}
@@ -0,0 +1,84 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+// memoryArtifactDriver is a driver that keeps the content of files in memory, by key
+type memoryArtifactDriver struct {
+	common.ArtifactDriver
+	files   map[string]string
+	loadErr error
+}
+
+func (d *memoryArtifactDriver) Load(a *wfv1.Artifact, path string) error {
+	if d.loadErr != nil {
+		return d.loadErr
+	}
+	key, err := a.GetKey()
+	if err != nil {
+		return err
+	}
+	content, ok := d.files[key]
+	if !ok {
+		return common.NewDriverError(common.ErrorKindNotFound, errors.New("no such key"))
+	}
+	return os.WriteFile(path, []byte(content), 0o600)
+}
+
+func (d *memoryArtifactDriver) Save(path string, a *wfv1.Artifact) error {
+	key, err := a.GetKey()
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	d.files[key] = string(content)
+	return nil
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+	from := &wfv1.Artifact{Name: "my-art", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-wf/my-art.tgz"}}}
+	to := &wfv1.Artifact{Name: "my-art", ArtifactLocation: wfv1.ArtifactLocation{GCS: &wfv1.GCSArtifact{Key: "my-wf/my-art.tgz"}}}
+	drivers := func(s3, gcs *memoryArtifactDriver) NewDriverFunc {
+		return func(_ context.Context, art *wfv1.Artifact, _ resource.Interface) (common.ArtifactDriver, error) {
+			if art.S3 != nil {
+				return s3, nil
+			}
+			return gcs, nil
+		}
+	}
+
+	t.Run("Copied", func(t *testing.T) {
+		s3 := &memoryArtifactDriver{files: map[string]string{"my-wf/my-art.tgz": "foo"}}
+		gcs := &memoryArtifactDriver{files: map[string]string{}}
+		require.NoError(t, Copy(ctx, from, to, nil, drivers(s3, gcs)))
+		assert.Equal(t, map[string]string{"my-wf/my-art.tgz": "foo"}, gcs.files)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		s3 := &memoryArtifactDriver{files: map[string]string{}}
+		gcs := &memoryArtifactDriver{files: map[string]string{}}
+		err := Copy(ctx, from, to, nil, drivers(s3, gcs))
+		require.ErrorContains(t, err, "failed to load artifact my-art")
+		assert.Empty(t, gcs.files)
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		s3 := &memoryArtifactDriver{loadErr: common.NewDriverError(common.ErrorKindForbidden, errors.New("access denied"))}
+		gcs := &memoryArtifactDriver{files: map[string]string{}}
+		require.ErrorContains(t, Copy(ctx, from, to, nil, drivers(s3, gcs)), "access denied")
+		assert.Empty(t, gcs.files)
+	})
+}
@@ -0,0 +1,150 @@
+package faults
+
+import (
+	"io"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	faultsutil "github.com/argoproj/argo-workflows/v3/util/faults"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+// driver injects the faults of "artifacts/<operation>", e.g. "artifacts/Save", into the operations of a driver
+type driver struct {
+	common.ArtifactDriver
+}
+
+// New wraps the driver to inject faults into it, if there are faults to inject
+func New(d common.ArtifactDriver) common.ArtifactDriver {
+	if !faultsutil.Enabled() {
+		return d
+	}
+	return &driver{d}
+}
+
+func (d driver) Load(a *wfv1.Artifact, path string) error {
+	before, after := faultsutil.Inject("artifacts/Load")
+	if before != nil {
+		return before
+	}
+	if err := d.ArtifactDriver.Load(a, path); err != nil {
+		return err
+	}
+	return after
+}
+
+// OpenStream fails the stream after its first read if the fault is partial
+func (d driver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	before, after := faultsutil.Inject("artifacts/OpenStream")
+	if before != nil {
+		return nil, before
+	}
+	rc, err := d.ArtifactDriver.OpenStream(a)
+	if err != nil || after == nil {
+		return rc, err
+	}
+	return &failingReadCloser{ReadCloser: rc, err: after}, nil
+}
+
+func (d driver) OpenSeekableStream(a *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	before, _ := faultsutil.Inject("artifacts/OpenSeekableStream")
+	if before != nil {
+		return nil, before
+	}
+	return common.OpenSeekableStream(d.ArtifactDriver, a)
+}
+
+func (d driver) Save(path string, a *wfv1.Artifact) error {
+	before, after := faultsutil.Inject("artifacts/Save")
+	if before != nil {
+		return before
+	}
+	if err := d.ArtifactDriver.Save(path, a); err != nil {
+		return err
+	}
+	return after
+}
+
+func (d driver) SaveStream(r io.Reader, a *wfv1.Artifact) error {
+	before, after := faultsutil.Inject("artifacts/Save")
+	if before != nil {
+		return before
+	}
+	if err := common.SaveStream(d.ArtifactDriver, r, a); err != nil {
+		return err
+	}
+	return after
+}
+
+func (d driver) Delete(a *wfv1.Artifact) error {
+	before, after := faultsutil.Inject("artifacts/Delete")
+	if before != nil {
+		return before
+	}
+	if err := d.ArtifactDriver.Delete(a); err != nil {
+		return err
+	}
+	return after
+}
+
+func (d driver) ListObjects(a *wfv1.Artifact) ([]string, error) {
+	before, _ := faultsutil.Inject("artifacts/ListObjects")
+	if before != nil {
+		return nil, before
+	}
+	return d.ArtifactDriver.ListObjects(a)
+}
+
+func (d driver) IsDirectory(a *wfv1.Artifact) (bool, error) {
+	before, _ := faultsutil.Inject("artifacts/IsDirectory")
+	if before != nil {
+		return false, before
+	}
+	return d.ArtifactDriver.IsDirectory(a)
+}
+
+func (d driver) Exists(a *wfv1.Artifact) (bool, error) {
+	before, _ := faultsutil.Inject("artifacts/Exists")
+	if before != nil {
+		return false, before
+	}
+	return common.Exists(d.ArtifactDriver, a)
+}
+
+func (d driver) ETag(a *wfv1.Artifact) (string, error) {
+	before, _ := faultsutil.Inject("artifacts/ETag")
+	if before != nil {
+		return "", before
+	}
+	return common.ETag(d.ArtifactDriver, a)
+}
+
+func (d driver) DeletePrefix(a *wfv1.Artifact) error {
+	before, _ := faultsutil.Inject("artifacts/DeletePrefix")
+	if before != nil {
+		return before
+	}
+	return common.DeletePrefix(d.ArtifactDriver, a)
+}
+
+func (d driver) SetProgress(progress func(n int64)) bool {
+	return common.SetProgress(d.ArtifactDriver, progress)
+}
+
+// failingReadCloser returns its error after its first read, as if the connection was lost
+type failingReadCloser struct {
+	io.ReadCloser
+	err  error
+	read bool
+}
+
+func (r *failingReadCloser) Read(p []byte) (int, error) {
+	if r.read {
+		return 0, r.err
+	}
+	r.read = true
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF {
+		err = r.err
+	}
+	return n, err
+}
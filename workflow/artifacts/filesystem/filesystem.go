@@ -0,0 +1,303 @@
+package filesystem
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/archive"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// ArtifactDriver is the artifact driver for a volume that is mounted at Root
+type ArtifactDriver struct {
+	Root string
+}
+
+var (
+	_ common.ArtifactDriver   = &ArtifactDriver{}
+	_ common.ExistenceChecker = &ArtifactDriver{}
+	_ common.RangeReader      = &ArtifactDriver{}
+)
+
+// ValidateArtifact validates a filesystem artifact
+func ValidateArtifact(errPrefix string, art *wfv1.FilesystemArtifact) error {
+	if art.Path == "" {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.path is required", errPrefix)
+	}
+	v := art.Volume
+	if v.Name == "" {
+		// the volume is from the artifact repository
+		return nil
+	}
+	if v.VolumeSource == (apiv1.VolumeSource{}) {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.volume must have a source", errPrefix)
+	}
+	if v.EmptyDir != nil || v.Secret != nil || v.ConfigMap != nil || v.DownwardAPI != nil || v.Projected != nil {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.volume must be a persistent volume, such as an NFS share or a persistent volume claim", errPrefix)
+	}
+	return nil
+}
+
+// CreateDriver constructs ArtifactDriver
+func CreateDriver(art *wfv1.FilesystemArtifact) *ArtifactDriver {
+	return &ArtifactDriver{Root: filepath.Join(wfcommon.ArtifactVolumeMountPath, art.Volume.Name)}
+}
+
+// path returns the path of the key on the volume. Keys are relative to the root of the volume, and cannot refer to
+// paths outside of it.
+func (d *ArtifactDriver) path(key string) string {
+	return filepath.Join(d.Root, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+// resolve returns the path of the key on the volume, with its symbolic links resolved. Keys whose path resolves to
+// outside of the volume, e.g. because a directory of it is a link to another part of the filesystem of the pod, are
+// forbidden. The path may not exist yet, e.g. when an artifact is saved, so the links of its deepest existing parent
+// are resolved instead.
+func (d *ArtifactDriver) resolve(key string) (string, error) {
+	root, err := filepath.EvalSymlinks(d.Root)
+	if err != nil {
+		return "", err
+	}
+	existing, rest := d.path(key), ""
+	for {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			if resolved != root && !strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+				return "", common.NewDriverError(common.ErrorKindForbidden, fmt.Errorf("%s is a link to outside of the volume", key))
+			}
+			return filepath.Join(resolved, rest), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		if _, err := os.Lstat(existing); err == nil {
+			return "", common.NewDriverError(common.ErrorKindForbidden, fmt.Errorf("%s is a link that does not resolve", key))
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = filepath.Dir(existing)
+	}
+}
+
+// stat returns the file info of the path, or a not found error if it does not exist
+func stat(p string) (fs.FileInfo, error) {
+	info, err := os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, argoerrors.New(argoerrors.CodeNotFound, err.Error())
+	}
+	return info, err
+}
+
+// Load copies the file or directory of the artifact to the local path
+func (d *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, localPath string) error {
+	src, err := d.resolve(inputArtifact.Filesystem.Path)
+	if err != nil {
+		return err
+	}
+	if _, err := stat(src); err != nil {
+		return err
+	}
+	return copyPath(src, localPath)
+}
+
+// OpenStream opens the file of the artifact, or streams its directory as a tarball
+func (d *ArtifactDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	p, err := d.resolve(a.Filesystem.Path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		r, w := io.Pipe()
+		go func() {
+			_ = w.CloseWithError(archive.TarGzToWriter(p, gzip.DefaultCompression, w))
+		}()
+		return r, nil
+	}
+	return os.Open(filepath.Clean(p))
+}
+
+// Save copies the local file or directory to the path of the artifact, replacing anything that is already there. It
+// is copied alongside first, so that readers never see a partially saved artifact.
+func (d *ArtifactDriver) Save(localPath string, outputArtifact *wfv1.Artifact) error {
+	dst, err := d.resolve(outputArtifact.Filesystem.Path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dst), "."+filepath.Base(dst)+"-")
+	if err != nil {
+		return quotaError(err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	staged := filepath.Join(tmp, filepath.Base(dst))
+	if err := copyPath(localPath, staged); err != nil {
+		return quotaError(err)
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	return os.Rename(staged, dst)
+}
+
+// quotaError returns the error as a quota exceeded driver error if the volume is out of space, or the quota of the
+// user on it has been reached
+func quotaError(err error) error {
+	if errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EDQUOT) {
+		return common.NewDriverError(common.ErrorKindQuotaExceeded, err)
+	}
+	return err
+}
+
+// copyPath copies the file or directory at src to dst, keeping symbolic links as links
+func copyPath(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0o700)
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			return copyFile(p, target, info.Mode().Perm())
+		default:
+			return nil
+		}
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Delete deletes the file or directory of the artifact
+func (d *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
+	p, err := d.resolve(artifact.Filesystem.Path)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(p)
+}
+
+// ListObjects returns the keys of the files of the artifact
+func (d *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
+	key := artifact.Filesystem.Path
+	root, err := d.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	err = filepath.WalkDir(root, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && p == root {
+				return nil
+			}
+			return err
+		}
+		if e.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, path.Join(key, filepath.ToSlash(rel)))
+		return nil
+	})
+	return keys, err
+}
+
+// IsDirectory returns whether the artifact is a directory
+func (d *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
+	p, err := d.resolve(artifact.Filesystem.Path)
+	if err != nil {
+		return false, err
+	}
+	info, err := stat(p)
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// Capabilities returns the optional operations that a filesystem supports, which are all of them
+func (d *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{StreamsDirectories: true, Delete: true, ListObjects: true, RangedReads: true}
+}
+
+// OpenSeekableStream opens the file of the artifact, for reading from any offset
+func (d *ArtifactDriver) OpenSeekableStream(a *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	p, err := d.resolve(a.Filesystem.Path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, argoerrors.Errorf(argoerrors.CodeBadRequest, "%s is a directory", a.Filesystem.Path)
+	}
+	return os.Open(filepath.Clean(p))
+}
+
+// Exists returns whether the artifact exists
+func (d *ArtifactDriver) Exists(artifact *wfv1.Artifact) (bool, error) {
+	p, err := d.resolve(artifact.Filesystem.Path)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,222 @@
+package filesystem
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	d := &ArtifactDriver{Root: t.TempDir()}
+	local := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "dir", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "file.txt"), []byte("hello"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "a.txt"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "sub", "b.txt"), []byte("b"), 0o600))
+
+	t.Run("File", func(t *testing.T) {
+		art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "out/nested/file.txt"}}}
+		require.NoError(t, d.Save(filepath.Join(local, "file.txt"), art))
+		loaded := filepath.Join(t.TempDir(), "file.txt")
+		require.NoError(t, d.Load(art, loaded))
+		data, err := os.ReadFile(loaded)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+	t.Run("Directory", func(t *testing.T) {
+		art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "out/dir"}}}
+		require.NoError(t, d.Save(filepath.Join(local, "dir"), art))
+		isDir, err := d.IsDirectory(art)
+		require.NoError(t, err)
+		assert.True(t, isDir)
+		keys, err := d.ListObjects(art)
+		require.NoError(t, err)
+		sort.Strings(keys)
+		assert.Equal(t, []string{"out/dir/a.txt", "out/dir/sub/b.txt"}, keys)
+
+		loaded := filepath.Join(t.TempDir(), "dir")
+		require.NoError(t, d.Load(art, loaded))
+		data, err := os.ReadFile(filepath.Join(loaded, "sub", "b.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "b", string(data))
+	})
+	t.Run("Replace", func(t *testing.T) {
+		art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "out/dir"}}}
+		require.NoError(t, d.Save(filepath.Join(local, "file.txt"), art))
+		isDir, err := d.IsDirectory(art)
+		require.NoError(t, err)
+		assert.False(t, isDir)
+		entries, err := os.ReadDir(filepath.Join(d.Root, "out"))
+		require.NoError(t, err)
+		assert.Len(t, entries, 2, "no staging directories are left behind")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "missing"}}}
+		err := d.Load(art, filepath.Join(t.TempDir(), "missing"))
+		assert.True(t, argoerrors.IsCode(argoerrors.CodeNotFound, err))
+		exists, err := d.Exists(art)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "out"}}}
+		require.NoError(t, d.Delete(art))
+		exists, err := d.Exists(art)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestPath(t *testing.T) {
+	d := &ArtifactDriver{Root: "/argo/artifact-volumes/shared"}
+	assert.Equal(t, "/argo/artifact-volumes/shared/my-wf/out.tgz", d.path("my-wf/out.tgz"))
+	assert.Equal(t, "/argo/artifact-volumes/shared/my-wf/out.tgz", d.path("/my-wf/out.tgz"))
+	assert.Equal(t, "/argo/artifact-volumes/shared/etc/passwd", d.path("../../etc/passwd"))
+}
+
+func TestSymlinkEscape(t *testing.T) {
+	d := &ArtifactDriver{Root: t.TempDir()}
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0o600))
+	require.NoError(t, os.Symlink(outside, filepath.Join(d.Root, "escape")))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "missing"), filepath.Join(d.Root, "dangling")))
+	require.NoError(t, os.Mkdir(filepath.Join(d.Root, "dir"), 0o755))
+	require.NoError(t, os.Symlink("dir", filepath.Join(d.Root, "inside")))
+	local := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(local, []byte("hello"), 0o600))
+	art := func(key string) *wfv1.Artifact {
+		return &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: key}}}
+	}
+	forbidden := func(t *testing.T, err error) {
+		t.Helper()
+		assert.Equal(t, common.ErrorKindForbidden, common.ErrorKindOf(err))
+	}
+
+	for _, key := range []string{"escape/secret.txt", "escape/new.txt", "dangling/new.txt"} {
+		t.Run(key, func(t *testing.T) {
+			forbidden(t, d.Load(art(key), filepath.Join(t.TempDir(), "out")))
+			_, err := d.OpenStream(art(key))
+			forbidden(t, err)
+			_, err = d.OpenSeekableStream(art(key))
+			forbidden(t, err)
+			forbidden(t, d.Save(local, art(key)))
+			_, err = d.Exists(art(key))
+			forbidden(t, err)
+			_, err = d.IsDirectory(art(key))
+			forbidden(t, err)
+			_, err = d.ListObjects(art(key))
+			forbidden(t, err)
+			forbidden(t, d.Delete(art(key)))
+		})
+	}
+	_, err := os.Stat(filepath.Join(outside, "secret.txt"))
+	require.NoError(t, err, "files outside of the volume are not deleted")
+	_, err = os.Stat(filepath.Join(outside, "new.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "files are not saved outside of the volume")
+
+	t.Run("Inside", func(t *testing.T) {
+		require.NoError(t, d.Save(local, art("inside/file.txt")))
+		data, err := os.ReadFile(filepath.Join(d.Root, "dir", "file.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data), "links to inside of the volume are followed")
+	})
+}
+
+func TestQuotaError(t *testing.T) {
+	err := quotaError(&os.PathError{Op: "write", Path: "/argo/artifact-volumes/shared/out.tgz", Err: syscall.ENOSPC})
+	assert.Equal(t, common.ErrorKindQuotaExceeded, common.ErrorKindOf(err))
+	assert.ErrorIs(t, err, syscall.ENOSPC)
+	err = quotaError(&os.PathError{Op: "write", Path: "/argo/artifact-volumes/shared/out.tgz", Err: syscall.EACCES})
+	assert.Equal(t, common.ErrorKind(""), common.ErrorKindOf(err))
+}
+
+func TestOpenStream(t *testing.T) {
+	d := &ArtifactDriver{Root: t.TempDir()}
+	require.NoError(t, os.Mkdir(filepath.Join(d.Root, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(d.Root, "file.txt"), []byte("hello"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(d.Root, "dir", "a.txt"), []byte("a"), 0o600))
+
+	t.Run("File", func(t *testing.T) {
+		r, err := d.OpenStream(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "file.txt"}}})
+		require.NoError(t, err)
+		defer func() { _ = r.Close() }()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+	t.Run("Directory", func(t *testing.T) {
+		r, err := d.OpenStream(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "dir"}}})
+		require.NoError(t, err)
+		defer func() { _ = r.Close() }()
+		gzr, err := gzip.NewReader(r)
+		require.NoError(t, err)
+		tr := tar.NewReader(gzr)
+		contents := map[string]string{}
+		for {
+			h, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			contents[h.Name] = string(data)
+		}
+		assert.Equal(t, map[string]string{"dir": "", "dir/a.txt": "a"}, contents)
+	})
+}
+
+func TestOpenSeekableStream(t *testing.T) {
+	d := &ArtifactDriver{Root: t.TempDir()}
+	require.NoError(t, os.Mkdir(filepath.Join(d.Root, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(d.Root, "file.txt"), []byte("hello world"), 0o600))
+
+	r, err := d.OpenSeekableStream(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "file.txt"}}})
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+	_, err = r.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+
+	_, err = d.OpenSeekableStream(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Filesystem: &wfv1.FilesystemArtifact{Path: "dir"}}})
+	require.Error(t, err)
+}
+
+func TestValidateArtifact(t *testing.T) {
+	nfs := apiv1.Volume{Name: "shared", VolumeSource: apiv1.VolumeSource{NFS: &apiv1.NFSVolumeSource{Server: "nfs", Path: "/exports"}}}
+	for name, tc := range map[string]struct {
+		art wfv1.FilesystemArtifact
+		err string
+	}{
+		"Key":      {art: wfv1.FilesystemArtifact{Path: "out.txt"}},
+		"NoPath":   {art: wfv1.FilesystemArtifact{}, err: "filesystem.path is required"},
+		"NFS":      {art: wfv1.FilesystemArtifact{FilesystemConfig: wfv1.FilesystemConfig{Volume: nfs}, Path: "out.txt"}},
+		"NoSource": {art: wfv1.FilesystemArtifact{FilesystemConfig: wfv1.FilesystemConfig{Volume: apiv1.Volume{Name: "shared"}}, Path: "out.txt"}, err: "filesystem.volume must have a source"},
+		"EmptyDir": {art: wfv1.FilesystemArtifact{FilesystemConfig: wfv1.FilesystemConfig{Volume: apiv1.Volume{Name: "shared", VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}}}}, Path: "out.txt"}, err: "filesystem.volume must be a persistent volume, such as an NFS share or a persistent volume claim"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateArtifact("filesystem", &tc.art)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
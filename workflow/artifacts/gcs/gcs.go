@@ -2,6 +2,7 @@ package gcs
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -28,10 +29,19 @@ import (
 // ArtifactDriver is a driver for GCS
 type ArtifactDriver struct {
 	ServiceAccountKey string
+	// ExternalAccount is the credential configuration of an external account, for workload identity federation
+	ExternalAccount string
+	// Progress is called with the number of bytes uploaded by Save, as they are uploaded
+	Progress func(n int64)
 }
 
+// maxObjectSize is the size of the largest object that GCS can store, 5 TiB
+const maxObjectSize = int64(5) << 40
+
 var (
 	_            common.ArtifactDriver = &ArtifactDriver{}
+	_            common.RangeReader    = &ArtifactDriver{}
+	_            common.StreamSaver    = &ArtifactDriver{}
 	defaultRetry                       = wait.Backoff{Duration: time.Second * 2, Factor: 2.0, Steps: 5, Jitter: 0.1, Cap: time.Minute * 10}
 )
 
@@ -68,13 +78,43 @@ func isTransientGCSErr(err error) bool {
 }
 
 func (h *ArtifactDriver) newGCSClient() (*storage.Client, error) {
+	if h.ServiceAccountKey != "" && h.ExternalAccount != "" {
+		return nil, errors.New(errors.CodeBadRequest, "GCS serviceAccountKeySecret and externalAccountSecret may not both be set")
+	}
 	if h.ServiceAccountKey != "" {
 		return newGCSClientWithCredential(h.ServiceAccountKey)
 	}
+	if h.ExternalAccount != "" {
+		return newGCSClientWithExternalAccount(h.ExternalAccount)
+	}
 	// Assume it uses Workload Identity
 	return newGCSClientDefault()
 }
 
+// newGCSClientWithExternalAccount creates a client that exchanges the external account's credentials, such as an AWS,
+// Azure, or Kubernetes identity, for short-lived Google credentials
+func newGCSClientWithExternalAccount(externalAccountJSON string) (*storage.Client, error) {
+	if err := validateExternalAccount(externalAccountJSON); err != nil {
+		return nil, err
+	}
+	return newGCSClientWithCredential(externalAccountJSON)
+}
+
+// validateExternalAccount validates that the credential configuration is of an external account, so that a service
+// account key cannot be used in its place
+func validateExternalAccount(externalAccountJSON string) error {
+	var config struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(externalAccountJSON), &config); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "GCS external account credential configuration is invalid: %v", err)
+	}
+	if config.Type != "external_account" {
+		return errors.Errorf(errors.CodeBadRequest, "GCS external account credential configuration must be of type \"external_account\", not %q", config.Type)
+	}
+	return nil
+}
+
 func newGCSClientWithCredential(serviceAccountJSON string) (*storage.Client, error) {
 	ctx := context.Background()
 	creds, err := google.CredentialsFromJSON(ctx, []byte(serviceAccountJSON), storage.ScopeReadWrite)
@@ -217,6 +257,48 @@ func (h *ArtifactDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
 	return common.LoadToStream(a, h)
 }
 
+// OpenSeekableStream opens the object of the artifact, for reading from any offset
+func (h *ArtifactDriver) OpenSeekableStream(a *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	client, err := h.newGCSClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	obj := client.Bucket(a.GCS.Bucket).Object(a.GCS.Key)
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		_ = client.Close()
+		if err == storage.ErrObjectNotExist {
+			return nil, errors.New(errors.CodeNotFound, err.Error())
+		}
+		return nil, err
+	}
+	stream := common.NewRangeStream(attrs.Size, func(offset int64) (io.ReadCloser, error) {
+		return obj.NewRangeReader(ctx, offset, -1)
+	})
+	return &objectStream{ReadSeekCloser: stream, client: client}, nil
+}
+
+// objectStream is a stream of an object, which closes its client when it is closed
+type objectStream struct {
+	io.ReadSeekCloser
+	client *storage.Client
+}
+
+func (s *objectStream) Close() error {
+	err := s.ReadSeekCloser.Close()
+	if closeErr := s.client.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// SetProgress sets the function called with the number of bytes uploaded by Save
+func (h *ArtifactDriver) SetProgress(progress func(n int64)) bool {
+	h.Progress = progress
+	return true
+}
+
 // Save an artifact to GCS compliant storage, e.g., uploading a local file to GCS bucket
 func (h *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error {
 	err := waitutil.Backoff(defaultRetry,
@@ -228,7 +310,7 @@ func (h *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error
 				return !isTransientGCSErr(err), err
 			}
 			defer client.Close()
-			err = uploadObjects(client, outputArtifact.GCS.Bucket, key, path)
+			err = uploadObjects(client, outputArtifact.GCS, key, path, h.Progress)
 			if err != nil {
 				return !isTransientGCSErr(err), err
 			}
@@ -237,6 +319,19 @@ func (h *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error
 	return err
 }
 
+// SaveStream saves the content of the reader to GCS compliant storage with a resumable upload. It is not retried, as the
+// content cannot be read again.
+func (h *ArtifactDriver) SaveStream(r io.Reader, outputArtifact *wfv1.Artifact) error {
+	key := filepath.Clean(outputArtifact.GCS.Key)
+	log.Infof("GCS Save stream, key: %s", key)
+	client, err := h.newGCSClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return writeObject(client, outputArtifact.GCS, key, r, h.Progress)
+}
+
 // list all the file relative paths under a dir
 // path is suppoese to be a dir
 // relPath is a given relative path to be inserted in front
@@ -260,8 +355,8 @@ func listFileRelPaths(path string, relPath string) ([]string, error) {
 	return results, nil
 }
 
-// upload a local file or dir to GCS
-func uploadObjects(client *storage.Client, bucket, key, path string) error {
+// upload a local file or dir to GCS, with the artifact's storage class and ACL
+func uploadObjects(client *storage.Client, art *wfv1.GCSArtifact, key, path string, progress func(n int64)) error {
 	isDir, err := file.IsDirectory(path)
 	if err != nil {
 		return fmt.Errorf("test if %s is a dir: %w", path, err)
@@ -279,7 +374,7 @@ func uploadObjects(client *storage.Client, bucket, key, path string) error {
 				fullKey = strings.ReplaceAll(fullKey, "\\", "/")
 			}
 
-			err = uploadObject(client, bucket, fullKey, dirName+relPath)
+			err = uploadObject(client, art, fullKey, dirName+relPath, progress)
 			if err != nil {
 				return fmt.Errorf("upload %s: %w", dirName+relPath, err)
 			}
@@ -289,7 +384,7 @@ func uploadObjects(client *storage.Client, bucket, key, path string) error {
 		if os.PathSeparator == '\\' {
 			objectKey = strings.ReplaceAll(objectKey, "\\", "/")
 		}
-		err = uploadObject(client, bucket, objectKey, path)
+		err = uploadObject(client, art, objectKey, path, progress)
 		if err != nil {
 			return fmt.Errorf("upload %s: %w", path, err)
 		}
@@ -298,7 +393,7 @@ func uploadObjects(client *storage.Client, bucket, key, path string) error {
 }
 
 // upload an object to GCS
-func uploadObject(client *storage.Client, bucket, key, localPath string) error {
+func uploadObject(client *storage.Client, art *wfv1.GCSArtifact, key, localPath string, progress func(n int64)) error {
 	f, err := os.Open(filepath.Clean(localPath))
 	if err != nil {
 		return fmt.Errorf("os open: %w", err)
@@ -308,9 +403,27 @@ func uploadObject(client *storage.Client, bucket, key, localPath string) error {
 			log.Fatalf("Error closing file[%s]: %v", localPath, err)
 		}
 	}()
-	ctx := context.Background()
-	wc := client.Bucket(bucket).Object(key).NewWriter(ctx)
-	if _, err = io.Copy(wc, f); err != nil {
+	return writeObject(client, art, key, f, progress)
+}
+
+// writeObject writes the content of the reader to the object. The object is only created once all of the content has
+// been written.
+func writeObject(client *storage.Client, art *wfv1.GCSArtifact, key string, r io.Reader, progress func(n int64)) error {
+	// cancelling the context aborts the upload if the content could not be read
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wc := client.Bucket(art.Bucket).Object(key).NewWriter(ctx)
+	wc.StorageClass = art.StorageClass
+	wc.PredefinedACL = art.PredefinedACL
+	if progress != nil {
+		// the writer reports the total number of bytes uploaded, rather than each chunk
+		uploaded := int64(0)
+		wc.ProgressFunc = func(n int64) {
+			progress(n - uploaded)
+			uploaded = n
+		}
+	}
+	if _, err := io.Copy(wc, r); err != nil {
 		return fmt.Errorf("io copy: %w", err)
 	}
 	if err := wc.Close(); err != nil {
@@ -329,6 +442,26 @@ func deleteObject(client *storage.Client, bucket, key string) error {
 	return nil
 }
 
+// deletePrefix deletes the objects whose names start with the prefix. GCS cannot delete objects in batches, so each
+// one that is listed is deleted on its own.
+func deletePrefix(client *storage.Client, bucket, prefix string) error {
+	ctx := context.Background()
+	it := client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = client.Bucket(bucket).Object(attrs.Name).Delete(ctx)
+		if err != nil && err != storage.ErrObjectNotExist {
+			return fmt.Errorf("delete %s: %w", attrs.Name, err)
+		}
+	}
+}
+
 // Delete deletes an artifact from GCS
 func (h *ArtifactDriver) Delete(s *wfv1.Artifact) error {
 	err := waitutil.Backoff(defaultRetry,
@@ -348,6 +481,26 @@ func (h *ArtifactDriver) Delete(s *wfv1.Artifact) error {
 	return err
 }
 
+// DeletePrefix deletes every object whose name starts with the key of the artifact
+func (h *ArtifactDriver) DeletePrefix(s *wfv1.Artifact) error {
+	err := waitutil.Backoff(defaultRetry,
+		func() (bool, error) {
+			log.Infof("GCS Delete prefix: bucket: %s, key: %s", s.GCS.Bucket, s.GCS.Key)
+			client, err := h.newGCSClient()
+			if err != nil {
+				return !isTransientGCSErr(err), err
+			}
+			defer client.Close()
+			err = deletePrefix(client, s.GCS.Bucket, s.GCS.Key)
+			if err != nil {
+				return !isTransientGCSErr(err), err
+			}
+			return true, nil
+		},
+	)
+	return err
+}
+
 func (h *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
 	var files []string
 	err := waitutil.Backoff(defaultRetry,
@@ -368,6 +521,39 @@ func (h *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error)
 	return files, err
 }
 
+// Exists returns whether the artifact's key exists
+func (h *ArtifactDriver) Exists(artifact *wfv1.Artifact) (bool, error) {
+	client, err := h.newGCSClient()
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+	_, err = client.Bucket(artifact.GCS.Bucket).Object(artifact.GCS.Key).Attrs(context.Background())
+	if err == storage.ErrObjectNotExist {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ETag returns the entity tag of the artifact's key
+func (h *ArtifactDriver) ETag(artifact *wfv1.Artifact) (string, error) {
+	client, err := h.newGCSClient()
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	attrs, err := client.Bucket(artifact.GCS.Bucket).Object(artifact.GCS.Key).Attrs(context.Background())
+	if err != nil {
+		return "", err
+	}
+	return attrs.Etag, nil
+}
+
 func (h *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
 	return false, errors.New(errors.CodeNotImplemented, "IsDirectory currently unimplemented for GCS")
 }
+
+// Capabilities returns the optional operations that GCS supports
+func (h *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{Delete: true, ListObjects: true, RangedReads: true, StreamingSaves: true, MaxObjectSize: maxObjectSize, PrefixDelete: true}
+}
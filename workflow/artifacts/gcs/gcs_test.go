@@ -1,13 +1,22 @@
 package gcs
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
 
 	argoErrors "github.com/argoproj/argo-workflows/v3/errors"
 )
@@ -44,3 +53,46 @@ func TestIsTransientGCSErr(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateExternalAccount(t *testing.T) {
+	require.NoError(t, validateExternalAccount(`{"type": "external_account", "audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/my-pool/providers/my-provider"}`))
+	err := validateExternalAccount(`{"type": "service_account"}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `must be of type "external_account", not "service_account"`)
+	require.Error(t, validateExternalAccount(`not json`))
+}
+
+func TestNewGCSClientWithBothCredentials(t *testing.T) {
+	_, err := (&ArtifactDriver{ServiceAccountKey: "{}", ExternalAccount: "{}"}).newGCSClient()
+	require.EqualError(t, err, "GCS serviceAccountKeySecret and externalAccountSecret may not both be set")
+}
+
+func TestDeletePrefix(t *testing.T) {
+	keys := []string{"argo-ephemeral/other-uid/kept.tgz", "argo-ephemeral/my-uid/pod-1/a.tgz", "argo-ephemeral/my-uid/pod-2/b.tgz"}
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/storage/v1/b/my-bucket/o":
+			var items []map[string]string
+			for _, key := range keys {
+				if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+					items = append(items, map[string]string{"bucket": "my-bucket", "name": key})
+				}
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{"kind": "storage#objects", "items": items}))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/storage/v1/b/my-bucket/o/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/storage/v1/b/my-bucket/o/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer server.Close()
+	client, err := storage.NewClient(context.Background(), option.WithEndpoint(server.URL+"/storage/v1/"), option.WithoutAuthentication())
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, deletePrefix(client, "my-bucket", "argo-ephemeral/my-uid/"))
+	assert.Equal(t, []string{"argo-ephemeral/my-uid/pod-1/a.tgz", "argo-ephemeral/my-uid/pod-2/b.tgz"}, deleted)
+	assert.True(t, (&ArtifactDriver{}).Capabilities().PrefixDelete)
+}
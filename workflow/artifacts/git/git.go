@@ -1,15 +1,23 @@
 package git
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	ssh2 "github.com/go-git/go-git/v5/plumbing/transport/ssh"
@@ -73,9 +81,131 @@ func (g *ArtifactDriver) auth(sshUser string) (func(), transport.AuthMethod, err
 	return func() {}, nil, nil
 }
 
-// Save is unsupported for git output artifacts
-func (g *ArtifactDriver) Save(string, *wfv1.Artifact) error {
-	return errors.New("git output artifacts unsupported")
+// defaultCommitMessage is the message of the commit of an output artifact, unless the artifact specifies one
+const defaultCommitMessage = "Update from Argo Workflows"
+
+// commitAuthor is the author of the commits of output artifacts
+var commitAuthor = object.Signature{Name: "Argo Workflows", Email: "argo-workflows@argoproj.io"}
+
+// Save commits the output artifact to the branch of the repository, and pushes it. The files of the artifact are
+// copied over those of the branch, so files that are not in the artifact are kept. Nothing is pushed if the artifact
+// does not change the branch.
+func (g *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error {
+	if outputArtifact == nil || !outputArtifact.Git.HasLocation() {
+		return errors.New("git output artifacts require a repo")
+	}
+	a := outputArtifact.Git
+	if a.Branch == "" {
+		return errors.New("git output artifacts require a branch")
+	}
+	closer, auth, err := g.auth(GetUser(a.Repo))
+	if err != nil {
+		return err
+	}
+	defer closer()
+	dir, err := os.MkdirTemp("", "git-output-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	branch := plumbing.NewBranchReferenceName(a.Branch)
+	r, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:             a.Repo,
+		Auth:            auth,
+		Depth:           a.GetDepth(),
+		ReferenceName:   branch,
+		SingleBranch:    true,
+		InsecureSkipTLS: g.InsecureSkipTLS,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clone branch %q of %q: %w", a.Branch, a.Repo, err)
+	}
+	if err := copyToWorktree(path, dir); err != nil {
+		return fmt.Errorf("failed to copy %q to the work tree: %w", path, err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get work tree: %w", err)
+	}
+	if err := w.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return fmt.Errorf("failed to add files: %w", err)
+	}
+	status, err := w.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+	if status.IsClean() {
+		log.WithField("branch", a.Branch).Info("Output artifact does not change the branch, nothing to push")
+		return nil
+	}
+	message := a.CommitMessage
+	if message == "" {
+		message = defaultCommitMessage
+	}
+	author := commitAuthor
+	author.When = time.Now()
+	h, err := w.Commit(message, &git.CommitOptions{Author: &author})
+	if err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	refSpec := config.RefSpec(fmt.Sprintf("%s:%s", branch, branch))
+	if err := r.Push(&git.PushOptions{Auth: auth, RefSpecs: []config.RefSpec{refSpec}, InsecureSkipTLS: g.InsecureSkipTLS}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to push %q to branch %q: %w", h, a.Branch, err)
+	}
+	log.WithField("branch", a.Branch).WithField("commit", h.String()).Info("Pushed output artifact")
+	return nil
+}
+
+// copyToWorktree copies the file, or the files of the directory, to the work tree, keeping their modes. The `.git`
+// directory of a directory is not copied.
+func copyToWorktree(src, worktree string) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return copyFile(src, filepath.Join(worktree, filepath.Base(src)), fi.Mode())
+	}
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == git.GitDirName {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(worktree, rel), 0o755)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(p, filepath.Join(worktree, rel), info.Mode())
+	})
+}
+
+func copyFile(src, dst string, mode fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
 }
 
 // Delete is unsupported for git artifacts
@@ -85,6 +215,9 @@ func (g *ArtifactDriver) Delete(s *wfv1.Artifact) error {
 
 func (g *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string) error {
 	a := inputArtifact.Git
+	if a.Filter != "" {
+		return g.loadPartialClone(a, path)
+	}
 	sshUser := GetUser(a.Repo)
 	closer, auth, err := g.auth(sshUser)
 	if err != nil {
@@ -92,12 +225,15 @@ func (g *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string) error {
 	}
 	defer closer()
 	depth := a.GetDepth()
+	sparse := len(a.SparsePaths) > 0
 	cloneOptions := &git.CloneOptions{
 		URL:             a.Repo,
 		Auth:            auth,
 		Depth:           depth,
 		SingleBranch:    a.SingleBranch,
 		InsecureSkipTLS: g.InsecureSkipTLS,
+		// a sparse checkout is done once the repository has been cloned
+		NoCheckout: sparse,
 	}
 	if a.SingleBranch && a.Branch == "" {
 		return errors.New("single branch mode without a branch specified")
@@ -164,9 +300,17 @@ func (g *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string) error {
 		if err != nil {
 			return fmt.Errorf("failed to get resolve revision: %w", err)
 		}
-		if err := w.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(h.String())}); err != nil {
+		if err := w.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(h.String()), SparseCheckoutDirectories: a.SparsePaths}); err != nil {
 			return fmt.Errorf("failed to checkout %q: %w", h, err)
 		}
+	} else if sparse {
+		head, err := r.Head()
+		if err != nil {
+			return fmt.Errorf("failed to get HEAD: %w", err)
+		}
+		if err := w.Checkout(&git.CheckoutOptions{Branch: head.Name(), SparseCheckoutDirectories: a.SparsePaths}); err != nil {
+			return fmt.Errorf("failed to checkout %v: %w", a.SparsePaths, err)
+		}
 	}
 	if !a.DisableSubmodules {
 		s, err := w.Submodules()
@@ -184,6 +328,113 @@ func (g *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string) error {
 	return nil
 }
 
+// loadPartialClone makes a partial clone of the repository with the git CLI, as go-git cannot make partial clones or
+// fetch the objects that they leave out when checking out. It is otherwise like the clone that Load makes with go-git.
+func (g *ArtifactDriver) loadPartialClone(a *wfv1.GitArtifact, path string) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("partial clones of git artifacts require the git CLI in the executor image: %w", err)
+	}
+	env, closer, err := g.cliEnv()
+	if err != nil {
+		return err
+	}
+	defer closer()
+	run := func(dir string, args ...string) error {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+	sparse := len(a.SparsePaths) > 0
+	args := []string{"clone", "--quiet", "--filter=" + a.Filter}
+	if a.Depth != nil {
+		args = append(args, "--depth", strconv.FormatUint(*a.Depth, 10))
+	}
+	if a.SingleBranch {
+		if a.Branch == "" {
+			return errors.New("single branch mode without a branch specified")
+		}
+		args = append(args, "--single-branch", "--branch", a.Branch)
+	}
+	if a.DisableSubmodules {
+		args = append(args, "--no-recurse-submodules")
+	}
+	if sparse {
+		// only the files at the root are checked out until the sparse paths are set
+		args = append(args, "--sparse")
+	}
+	if a.Revision != "" {
+		// the revision is checked out once the repository has been cloned
+		args = append(args, "--no-checkout")
+	}
+	if err := run("", append(args, "--", a.Repo, path)...); err != nil {
+		return fmt.Errorf("failed to clone %q: %w", a.Repo, err)
+	}
+	if len(a.Fetch) > 0 {
+		args := []string{"fetch", "--quiet"}
+		if a.Depth != nil {
+			args = append(args, "--depth", strconv.FormatUint(*a.Depth, 10))
+		}
+		if err := run(path, append(append(args, git.DefaultRemoteName), a.Fetch...)...); err != nil {
+			return fmt.Errorf("failed to fetch %v: %w", a.Fetch, err)
+		}
+	}
+	if sparse {
+		if err := run(path, append([]string{"sparse-checkout", "set", "--"}, a.SparsePaths...)...); err != nil {
+			return fmt.Errorf("failed to checkout %v: %w", a.SparsePaths, err)
+		}
+	}
+	if a.Revision != "" {
+		if err := run(path, "checkout", "--quiet", a.Revision, "--"); err != nil {
+			return fmt.Errorf("failed to checkout %q: %w", a.Revision, err)
+		}
+	}
+	if !a.DisableSubmodules {
+		if err := run(path, "submodule", "update", "--quiet", "--init", "--recursive"); err != nil {
+			return fmt.Errorf("failed to update submodules: %w", err)
+		}
+	}
+	return nil
+}
+
+// cliEnv returns the environment variables that give the git CLI the credentials of the driver, without putting them
+// in its arguments, and a func that removes the private key file that it may write
+func (g *ArtifactDriver) cliEnv() ([]string, func(), error) {
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+	var configs [][2]string
+	if g.InsecureSkipTLS {
+		configs = append(configs, [2]string{"http.sslVerify", "false"})
+	}
+	closer := func() {}
+	if g.SSHPrivateKey != "" {
+		privateKeyFile, err := os.CreateTemp("", "id_rsa.")
+		if err != nil {
+			return nil, nil, err
+		}
+		closer = func() { _ = os.Remove(privateKeyFile.Name()) }
+		if err := os.WriteFile(privateKeyFile.Name(), []byte(g.SSHPrivateKey), 0o600); err != nil {
+			closer()
+			return nil, nil, err
+		}
+		sshCommand := fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes", privateKeyFile.Name())
+		if g.InsecureIgnoreHostKey {
+			sshCommand += " -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null"
+		}
+		env = append(env, "GIT_SSH_COMMAND="+sshCommand)
+	} else if g.Username != "" || g.Password != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(g.Username + ":" + g.Password))
+		configs = append(configs, [2]string{"http.extraHeader", "Authorization: Basic " + credentials})
+	}
+	env = append(env, fmt.Sprintf("GIT_CONFIG_COUNT=%d", len(configs)))
+	for i, c := range configs {
+		env = append(env, fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, c[0]), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, c[1]))
+	}
+	return env, closer, nil
+}
+
 func isFetchErr(err error) bool {
 	return err != nil && err.Error() != "already up-to-date"
 }
@@ -200,3 +451,8 @@ func (g *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error)
 func (g *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
 	return false, argoerrors.New(argoerrors.CodeNotImplemented, "IsDirectory currently unimplemented for Git")
 }
+
+// Capabilities returns the optional operations that Git supports, which are none
+func (g *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{}
+}
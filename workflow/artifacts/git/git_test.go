@@ -2,7 +2,16 @@ package git
 
 import (
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 
 	"k8s.io/client-go/util/homedir"
 
@@ -16,6 +25,59 @@ func TestGitArtifactDriver_Save(t *testing.T) {
 	driver := &ArtifactDriver{}
 	err := driver.Save("", nil)
 	require.Error(t, err)
+
+	// a bare repository with a commit on the main branch
+	repo := t.TempDir()
+	_, err = git.PlainInit(repo, true)
+	require.NoError(t, err)
+	src := t.TempDir()
+	r, err := git.PlainInit(src, false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(src, "README.md"), []byte("hello"), 0o600))
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add("README.md")
+	require.NoError(t, err)
+	_, err = w.Commit("initial", &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@argoproj.io", When: time.Now()}})
+	require.NoError(t, err)
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{repo}})
+	require.NoError(t, err)
+	require.NoError(t, r.Push(&git.PushOptions{RefSpecs: []config.RefSpec{"refs/heads/master:refs/heads/main"}}))
+
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Git: &wfv1.GitArtifact{Repo: repo, Branch: "main", CommitMessage: "my message"}}}
+	getHead := func() *object.Commit {
+		r, err := git.PlainOpen(repo)
+		require.NoError(t, err)
+		ref, err := r.Reference(plumbing.NewBranchReferenceName("main"), true)
+		require.NoError(t, err)
+		c, err := r.CommitObject(ref.Hash())
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("NoBranch", func(t *testing.T) {
+		require.Error(t, driver.Save(src, &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Git: &wfv1.GitArtifact{Repo: repo}}}))
+	})
+	t.Run("Directory", func(t *testing.T) {
+		out := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(out, "manifests"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(out, "manifests", "deployment.yaml"), []byte("kind: Deployment"), 0o600))
+		require.NoError(t, driver.Save(out, art))
+		head := getHead()
+		assert.Equal(t, "my message", head.Message)
+		f, err := head.File("manifests/deployment.yaml")
+		require.NoError(t, err)
+		contents, err := f.Contents()
+		require.NoError(t, err)
+		assert.Equal(t, "kind: Deployment", contents)
+		_, err = head.File("README.md")
+		require.NoError(t, err)
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		before := getHead().Hash
+		require.NoError(t, driver.Save(filepath.Join(src, "README.md"), art))
+		assert.Equal(t, before, getHead().Hash)
+	})
 }
 
 func TestGitArtifactDriver_Load(t *testing.T) {
@@ -110,6 +172,17 @@ func TestGitArtifactDriver_Load(t *testing.T) {
 			assert.FileExists(t, path+"/README.md")
 		})
 	})
+	t.Run("Sparse", func(t *testing.T) {
+		driver := &ArtifactDriver{}
+		t.Run("Paths", func(t *testing.T) {
+			require.NoError(t, load(driver, &wfv1.GitArtifact{Repo: "https://github.com/argoproj-labs/test-repo.git", SparsePaths: []string{"garbage"}, DisableSubmodules: true}))
+			assert.NoFileExists(t, path+"/README.md")
+		})
+		t.Run("Revision", func(t *testing.T) {
+			require.NoError(t, load(driver, &wfv1.GitArtifact{Repo: "https://github.com/argoproj-labs/test-repo.git", Revision: "main", SparsePaths: []string{"garbage"}, DisableSubmodules: true}))
+			assert.NoFileExists(t, path+"/README.md")
+		})
+	})
 	t.Run("Submodules", func(t *testing.T) {
 		driver := &ArtifactDriver{}
 		t.Run("Disabled", func(t *testing.T) {
@@ -171,6 +244,67 @@ func TestGitArtifactDriver_Load(t *testing.T) {
 	})
 }
 
+func TestGitArtifactDriver_LoadPartialClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("partial clones require the git CLI")
+	}
+	// a bare repository that supports partial clones, with two commits on its default branch
+	repo := t.TempDir()
+	_, err := git.PlainInit(repo, true)
+	require.NoError(t, err)
+	require.NoError(t, exec.Command("git", "-C", repo, "config", "uploadpack.allowFilter", "true").Run())
+	src := t.TempDir()
+	r, err := git.PlainInit(src, false)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	commit := func(contents string) plumbing.Hash {
+		for _, dir := range []string{"docs", "other"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(src, dir), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(src, dir, "file.txt"), []byte(contents), 0o600))
+		}
+		require.NoError(t, w.AddWithOptions(&git.AddOptions{All: true}))
+		h, err := w.Commit(contents, &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@argoproj.io", When: time.Now()}})
+		require.NoError(t, err)
+		return h
+	}
+	first := commit("first")
+	commit("second")
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{repo}})
+	require.NoError(t, err)
+	require.NoError(t, r.Push(&git.PushOptions{}))
+
+	driver := &ArtifactDriver{}
+	t.Run("Sparse", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "repo")
+		require.NoError(t, driver.Load(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Git: &wfv1.GitArtifact{
+			Repo:        "file://" + repo,
+			Filter:      "blob:none",
+			SparsePaths: []string{"docs"},
+		}}}, out))
+		contents, err := os.ReadFile(filepath.Join(out, "docs", "file.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "second", string(contents))
+		assert.NoFileExists(t, filepath.Join(out, "other", "file.txt"))
+		filter, err := exec.Command("git", "-C", out, "config", "remote.origin.partialclonefilter").Output()
+		require.NoError(t, err)
+		assert.Equal(t, "blob:none", strings.TrimSpace(string(filter)))
+	})
+	t.Run("Revision", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "repo")
+		require.NoError(t, driver.Load(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Git: &wfv1.GitArtifact{
+			Repo:        "file://" + repo,
+			Filter:      "blob:none",
+			Revision:    first.String(),
+			SparsePaths: []string{"other"},
+		}}}, out))
+		contents, err := os.ReadFile(filepath.Join(out, "other", "file.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(contents))
+		assert.NoFileExists(t, filepath.Join(out, "docs", "file.txt"))
+	})
+}
+
 const path = "/tmp/repo"
 
 func assertOnlyFile(t *testing.T, dir string, file string) {
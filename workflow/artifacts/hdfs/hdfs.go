@@ -251,3 +251,8 @@ func (driver *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, er
 func (driver *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
 	return false, errors.New(errors.CodeNotImplemented, "IsDirectory currently unimplemented for HDFS")
 }
+
+// Capabilities returns the optional operations that HDFS supports, which are none
+func (driver *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{}
+}
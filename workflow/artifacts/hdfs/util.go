@@ -1,13 +1,38 @@
 package hdfs
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sync"
+	"time"
 
 	hdfs "github.com/colinmarc/hdfs/v2"
 	krb "github.com/jcmturner/gokrb5/v8/client"
 	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	log "github.com/sirupsen/logrus"
 )
 
+// krbRenewInterval is how often the tickets of cached Kerberos clients are checked, and renewed if they are about to
+// expire
+const krbRenewInterval = time.Minute
+
+// krbClients caches the Kerberos clients by the principal they authenticate, so that a ticket obtained by one operation
+// is reused and kept renewed for the next, e.g. when loading an input and saving an output of a long-running step
+var krbClients = struct {
+	sync.Mutex
+	clients map[string]*cachedKrbClient
+}{clients: make(map[string]*cachedKrbClient)}
+
+// cachedKrbClient is a cached Kerberos client, whose ticket is renewed until stop is closed
+type cachedKrbClient struct {
+	client *krb.Client
+	// key is the cache key of the options the client was created with, which changes when the credentials rotate
+	key  string
+	stop chan struct{}
+}
+
 func createHDFSClient(addresses []string, user string, dataTransferProtection string, krbOptions *KrbOptions) (*hdfs.Client, error) {
 	options := hdfs.ClientOptions{
 		Addresses:              addresses,
@@ -15,7 +40,7 @@ func createHDFSClient(addresses []string, user string, dataTransferProtection st
 	}
 
 	if krbOptions != nil {
-		krbClient, err := createKrbClient(krbOptions)
+		krbClient, err := getKrbClient(krbOptions)
 		if err != nil {
 			return nil, err
 		}
@@ -28,6 +53,121 @@ func createHDFSClient(addresses []string, user string, dataTransferProtection st
 	return hdfs.NewClient(options)
 }
 
+// getKrbClient returns the cached Kerberos client for the options, creating it if there is none, or if the credentials
+// have rotated since it was created. A client with a keytab re-authenticates if its ticket has expired, and one with a
+// ccache fails if the ticket in the ccache has.
+func getKrbClient(krbOptions *KrbOptions) (*krb.Client, error) {
+	key, err := krbOptions.cacheKey()
+	if err != nil {
+		return nil, err
+	}
+	principal := krbOptions.principal()
+	krbClients.Lock()
+	defer krbClients.Unlock()
+	if cached, ok := krbClients.clients[principal]; ok {
+		if cached.key == key {
+			err := affirmKrbLogin(cached.client, krbOptions)
+			if err == nil {
+				return cached.client, nil
+			}
+			log.WithError(err).Warn("Cached Kerberos client could not be re-authenticated, creating a new one")
+		}
+		evictKrbClient(principal, cached)
+	}
+	client, err := createKrbClient(krbOptions)
+	if err != nil {
+		return nil, err
+	}
+	cached := &cachedKrbClient{client: client, key: key, stop: make(chan struct{})}
+	krbClients.clients[principal] = cached
+	go renewKrbTicket(cached, krbOptions)
+	return client, nil
+}
+
+// evictKrbClient removes the client from the cache, and stops renewing its ticket. It must be called with krbClients
+// locked.
+func evictKrbClient(principal string, cached *cachedKrbClient) {
+	close(cached.stop)
+	cached.client.Destroy()
+	delete(krbClients.clients, principal)
+}
+
+// renewKrbTicket keeps the ticket of the cached Kerberos client renewed, until the client is evicted
+func renewKrbTicket(cached *cachedKrbClient, krbOptions *KrbOptions) {
+	ticker := time.NewTicker(krbRenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cached.stop:
+			return
+		case <-ticker.C:
+			if err := affirmKrbLogin(cached.client, krbOptions); err != nil {
+				log.WithError(err).Warn("Failed to renew Kerberos ticket")
+			}
+		}
+	}
+}
+
+// affirmKrbLogin ensures the Kerberos client has a valid ticket. A ticket that is about to expire is renewed, if it
+// is renewable, and a client with a keytab logs in again otherwise.
+func affirmKrbLogin(client *krb.Client, krbOptions *KrbOptions) error {
+	if krbOptions.CCacheOptions != nil {
+		if err := checkCCacheExpiry(&krbOptions.CCacheOptions.CCache); err != nil {
+			return err
+		}
+		// getting a service ticket renews the ticket-granting ticket if it is about to expire
+		_, _, err := client.GetServiceTicket(krbOptions.ServicePrincipalName)
+		return err
+	}
+	return client.AffirmLogin()
+}
+
+// checkCCacheExpiry returns an error if the ticket-granting ticket in the ccache has expired, and cannot be renewed
+func checkCCacheExpiry(ccache *credentials.CCache) error {
+	now := time.Now()
+	for _, cred := range ccache.GetEntries() {
+		if len(cred.Server.PrincipalName.NameString) == 0 || cred.Server.PrincipalName.NameString[0] != "krbtgt" {
+			continue
+		}
+		if cred.EndTime.Before(now) {
+			return fmt.Errorf("Kerberos ticket in the ccache expired at %s, renew the ccache or use a keytab instead", cred.EndTime.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// principal returns the key of the principal authenticated with the options, and of the service it authenticates to,
+// which does not change when the credentials are rotated
+func (krbOptions *KrbOptions) principal() string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\x00%s\x00", krbOptions.Config, krbOptions.ServicePrincipalName)
+	if krbOptions.CCacheOptions != nil {
+		ccache := &krbOptions.CCacheOptions.CCache
+		_, _ = fmt.Fprintf(h, "ccache\x00%s\x00%s\x00", ccache.GetClientPrincipalName().PrincipalNameString(), ccache.GetClientRealm())
+	} else if krbOptions.KeytabOptions != nil {
+		_, _ = fmt.Fprintf(h, "keytab\x00%s\x00%s\x00", krbOptions.KeytabOptions.Username, krbOptions.KeytabOptions.Realm)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheKey returns the key of the Kerberos client for the options, which changes when the credentials are rotated
+func (krbOptions *KrbOptions) cacheKey() (string, error) {
+	h := sha256.New()
+	_, _ = h.Write([]byte(krbOptions.principal()))
+	if krbOptions.CCacheOptions != nil {
+		for _, cred := range krbOptions.CCacheOptions.CCache.GetEntries() {
+			_, _ = h.Write(cred.Ticket)
+		}
+	} else if krbOptions.KeytabOptions != nil {
+		ktb, err := krbOptions.KeytabOptions.Keytab.Marshal()
+		if err != nil {
+			return "", err
+		}
+		_, _ = h.Write(ktb)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func createKrbClient(krbOptions *KrbOptions) (*krb.Client, error) {
 	krbConfig, err := config.NewFromString(krbOptions.Config)
 	if err != nil {
@@ -35,6 +175,9 @@ func createKrbClient(krbOptions *KrbOptions) (*krb.Client, error) {
 	}
 
 	if krbOptions.CCacheOptions != nil {
+		if err := checkCCacheExpiry(&krbOptions.CCacheOptions.CCache); err != nil {
+			return nil, err
+		}
 		client, err := krb.NewFromCCache(&krbOptions.CCacheOptions.CCache, krbConfig)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,186 @@
+package hdfs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRealm  = "EXAMPLE.COM"
+	testSPN    = "hdfs/namenode"
+	testConfig = "[libdefaults]\n  default_realm = EXAMPLE.COM\n"
+)
+
+// newCredential returns a ccache entry for a ticket of the service, whose cipher tells tickets apart
+func newCredential(t *testing.T, sname types.PrincipalName, endTime time.Time, cipher string) *credentials.Credential {
+	t.Helper()
+	ticket, err := (&messages.Ticket{
+		TktVNO:  5,
+		Realm:   testRealm,
+		SName:   sname,
+		EncPart: types.EncryptedData{EType: etypeID.AES256_CTS_HMAC_SHA1_96, KVNO: 1, Cipher: []byte(cipher)},
+	}).Marshal()
+	require.NoError(t, err)
+	cred := &credentials.Credential{
+		AuthTime:  endTime.Add(-2 * time.Hour),
+		StartTime: endTime.Add(-2 * time.Hour),
+		EndTime:   endTime,
+		RenewTill: endTime,
+		Key:       types.EncryptionKey{KeyType: etypeID.AES256_CTS_HMAC_SHA1_96, KeyValue: make([]byte, 32)},
+		Ticket:    ticket,
+	}
+	cred.Server.Realm = testRealm
+	cred.Server.PrincipalName = sname
+	return cred
+}
+
+// newCCacheOptions returns options with a ccache holding a ticket-granting ticket and a ticket of the service
+func newCCacheOptions(t *testing.T, tgtEndTime time.Time, cipher string) *KrbOptions {
+	t.Helper()
+	ccache := credentials.CCache{Version: 4}
+	ccache.DefaultPrincipal.Realm = testRealm
+	ccache.DefaultPrincipal.PrincipalName = types.NewPrincipalName(nametype.KRB_NT_PRINCIPAL, "alice")
+	ccache.Credentials = []*credentials.Credential{
+		newCredential(t, types.NewPrincipalName(nametype.KRB_NT_SRV_INST, "krbtgt/"+testRealm), tgtEndTime, cipher),
+		newCredential(t, types.NewPrincipalName(nametype.KRB_NT_SRV_INST, testSPN), time.Now().Add(time.Hour), cipher),
+	}
+	return &KrbOptions{CCacheOptions: &CCacheOptions{CCache: ccache}, Config: testConfig, ServicePrincipalName: testSPN}
+}
+
+func newKeytabOptions(t *testing.T, password string, kvno uint8) *KrbOptions {
+	t.Helper()
+	ktb := keytab.New()
+	require.NoError(t, ktb.AddEntry("alice", testRealm, password, time.Unix(0, 0), kvno, etypeID.AES256_CTS_HMAC_SHA1_96))
+	return &KrbOptions{KeytabOptions: &KeytabOptions{Keytab: *ktb, Username: "alice", Realm: testRealm}, Config: testConfig, ServicePrincipalName: testSPN}
+}
+
+func TestKrbOptionsCacheKey(t *testing.T) {
+	validUntil := time.Now().Add(time.Hour)
+	tests := []struct {
+		name    string
+		a, b    *KrbOptions
+		changed bool
+	}{
+		{"SameCCache", newCCacheOptions(t, validUntil, "a"), newCCacheOptions(t, validUntil, "a"), false},
+		{"RotatedCCache", newCCacheOptions(t, validUntil, "a"), newCCacheOptions(t, validUntil, "b"), true},
+		{"SameKeytab", newKeytabOptions(t, "password", 1), newKeytabOptions(t, "password", 1), false},
+		{"RotatedKeytab", newKeytabOptions(t, "password", 1), newKeytabOptions(t, "new-password", 2), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyA, err := tt.a.cacheKey()
+			require.NoError(t, err)
+			keyB, err := tt.b.cacheKey()
+			require.NoError(t, err)
+			if tt.changed {
+				assert.NotEqual(t, keyA, keyB)
+			} else {
+				assert.Equal(t, keyA, keyB)
+			}
+			assert.Equal(t, tt.a.principal(), tt.b.principal(), "the principal must not change when the credentials rotate")
+		})
+	}
+	t.Run("DifferentService", func(t *testing.T) {
+		other := newCCacheOptions(t, validUntil, "a")
+		other.ServicePrincipalName = "hdfs/other"
+		keyA, err := newCCacheOptions(t, validUntil, "a").cacheKey()
+		require.NoError(t, err)
+		keyB, err := other.cacheKey()
+		require.NoError(t, err)
+		assert.NotEqual(t, keyA, keyB)
+	})
+}
+
+func TestCheckCCacheExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		ccache  func(t *testing.T) *credentials.CCache
+		wantErr string
+	}{
+		{"Valid", func(t *testing.T) *credentials.CCache {
+			return &newCCacheOptions(t, time.Now().Add(time.Hour), "a").CCacheOptions.CCache
+		}, ""},
+		{"ExpiredTGT", func(t *testing.T) *credentials.CCache {
+			return &newCCacheOptions(t, time.Now().Add(-time.Hour), "a").CCacheOptions.CCache
+		}, "Kerberos ticket in the ccache expired"},
+		{"ExpiredServiceTicket", func(t *testing.T) *credentials.CCache {
+			ccache := &newCCacheOptions(t, time.Now().Add(time.Hour), "a").CCacheOptions.CCache
+			ccache.Credentials[1] = newCredential(t, types.NewPrincipalName(nametype.KRB_NT_SRV_INST, testSPN), time.Now().Add(-time.Hour), "a")
+			return ccache
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCCacheExpiry(tt.ccache(t))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetKrbClient(t *testing.T) {
+	t.Cleanup(func() {
+		krbClients.Lock()
+		defer krbClients.Unlock()
+		for principal, cached := range krbClients.clients {
+			evictKrbClient(principal, cached)
+		}
+	})
+	validUntil := time.Now().Add(time.Hour)
+
+	client, err := getKrbClient(newCCacheOptions(t, validUntil, "a"))
+	require.NoError(t, err)
+	krbClients.Lock()
+	cached := krbClients.clients[newCCacheOptions(t, validUntil, "a").principal()]
+	krbClients.Unlock()
+	require.NotNil(t, cached)
+
+	tests := []struct {
+		name     string
+		options  *KrbOptions
+		reused   bool
+		evicted  bool
+		wantErr  string
+		isCached bool
+	}{
+		{name: "Reused", options: newCCacheOptions(t, validUntil, "a"), reused: true, isCached: true},
+		{name: "Rotated", options: newCCacheOptions(t, validUntil, "b"), evicted: true, isCached: true},
+		{name: "Expired", options: newCCacheOptions(t, time.Now().Add(-time.Hour), "c"), evicted: true, wantErr: "Kerberos ticket in the ccache expired"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getKrbClient(tt.options)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.reused, got == client)
+			}
+			select {
+			case <-cached.stop:
+				assert.True(t, tt.evicted, "the ticket of the evicted client must no longer be renewed")
+			default:
+				assert.False(t, tt.evicted)
+			}
+			krbClients.Lock()
+			current, ok := krbClients.clients[tt.options.principal()]
+			krbClients.Unlock()
+			assert.Equal(t, tt.isCached, ok)
+			if ok {
+				client, cached = current.client, current
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package http
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// artifactoryItem is an item of a folder in the responses of the Artifactory storage API
+type artifactoryItem struct {
+	// URI is the path of the item, relative to the folder
+	URI    string `json:"uri"`
+	Folder bool   `json:"folder"`
+}
+
+// artifactoryItemInfo is the response of the Artifactory storage API for a folder or a file
+type artifactoryItemInfo struct {
+	// Children are the items of a folder, which is nil for a file
+	Children []artifactoryItem `json:"children"`
+}
+
+// artifactoryFileList is the response of the Artifactory storage API for the files of a folder
+type artifactoryFileList struct {
+	Files []artifactoryItem `json:"files"`
+}
+
+// artifactoryStorageURL returns the URL of the Artifactory storage API for the artifact URL, e.g.
+// https://example.com/artifactory/api/storage/generic-local/my-dir for
+// https://example.com/artifactory/generic-local/my-dir
+func artifactoryStorageURL(artifactURL string) (string, error) {
+	u, err := url.Parse(artifactURL)
+	if err != nil {
+		return "", err
+	}
+	base, repoPath := "", u.Path
+	if i := strings.Index(u.Path, "/artifactory/"); i >= 0 {
+		base, repoPath = u.Path[:i+len("/artifactory")], u.Path[i+len("/artifactory"):]
+	}
+	u.Path = base + "/api/storage" + strings.TrimSuffix(repoPath, "/")
+	u.RawQuery = ""
+	return u.String(), nil
+}
+
+// getArtifactoryStorage gets the response of the Artifactory storage API for the artifact into v, returning false if
+// the artifact is not found
+func (h *ArtifactDriver) getArtifactoryStorage(art *wfv1.ArtifactoryArtifact, query string, v interface{}) (bool, error) {
+	storageURL, err := artifactoryStorageURL(art.URL)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequest(http.MethodGet, storageURL+query, nil)
+	if err != nil {
+		return false, err
+	}
+	req.SetBasicAuth(h.Username, h.Password)
+	res, err := h.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return false, errors.InternalErrorf("getting the storage info of %s failed with reason: %s", art.URL, res.Status)
+	}
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return false, errors.InternalErrorf("failed to decode the storage info of %s: %v", art.URL, err)
+	}
+	return true, nil
+}
+
+// isArtifactoryDirectory tests if the artifact is a folder of Artifactory
+func (h *ArtifactDriver) isArtifactoryDirectory(art *wfv1.ArtifactoryArtifact) (bool, error) {
+	info := artifactoryItemInfo{}
+	found, err := h.getArtifactoryStorage(art, "", &info)
+	return found && info.Children != nil, err
+}
+
+// listArtifactoryDirectory lists the paths, relative to the folder, of all the files within the folder of the
+// artifact
+func (h *ArtifactDriver) listArtifactoryDirectory(art *wfv1.ArtifactoryArtifact) ([]string, error) {
+	list := artifactoryFileList{}
+	found, err := h.getArtifactoryStorage(art, "?list&deep=1&listFolders=0", &list)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.Errorf(errors.CodeNotFound, "folder %s not found", art.URL)
+	}
+	var files []string
+	for _, item := range list.Files {
+		if !item.Folder {
+			files = append(files, strings.TrimPrefix(item.URI, "/"))
+		}
+	}
+	return files, nil
+}
+
+// loadArtifactoryDirectory downloads all the files within the folder of the artifact into the directory
+func (h *ArtifactDriver) loadArtifactoryDirectory(art *wfv1.ArtifactoryArtifact, path string) error {
+	files, err := h.listArtifactoryDirectory(art)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		filePath := filepath.Join(path, filepath.FromSlash(file))
+		if !strings.HasPrefix(filePath, filepath.Clean(path)+string(filepath.Separator)) {
+			return errors.InternalErrorf("file %s of %s is outside of the folder", file, art.URL)
+		}
+		fileURL, err := url.JoinPath(art.URL, file)
+		if err != nil {
+			return err
+		}
+		log.WithField("url", fileURL).Info("Downloading Artifactory file")
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			return err
+		}
+		if err := h.loadArtifactoryFile(&wfv1.ArtifactoryArtifact{URL: fileURL, ArtifactoryAuth: art.ArtifactoryAuth}, filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *ArtifactDriver) loadArtifactoryFile(art *wfv1.ArtifactoryArtifact, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	err = h.download(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Artifactory: art}}, f)
+	if permanentErr, ok := err.(permanentError); ok {
+		return permanentErr.error
+	}
+	return err
+}
+
+// saveArtifactoryDirectory uploads all the files within the directory into the folder of the artifact, which
+// Artifactory creates along with its sub-folders
+func (h *ArtifactDriver) saveArtifactoryDirectory(path string, art *wfv1.ArtifactoryArtifact) error {
+	return filepath.WalkDir(path, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil {
+			return err
+		}
+		fileURL, err := url.JoinPath(art.URL, filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		log.WithField("url", fileURL).Info("Uploading Artifactory file")
+		return h.Save(filePath, &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{
+			Artifactory: &wfv1.ArtifactoryArtifact{URL: fileURL, ArtifactoryAuth: art.ArtifactoryAuth},
+		}})
+	})
+}
@@ -1,14 +1,27 @@
 package http
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"maps"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/intstr"
+	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 )
 
@@ -17,11 +30,27 @@ type ArtifactDriver struct {
 	Username string
 	Password string
 	Client   *http.Client
+	// Artifactory is whether the artifacts are in Artifactory, which has folders
+	Artifactory bool
 }
 
 var _ common.ArtifactDriver = &ArtifactDriver{}
 
+// defaultRetry is how failed downloads are retried, if the artifact does not say otherwise
+var defaultRetry = wait.Backoff{Duration: time.Second, Factor: 2, Steps: 4, Cap: time.Minute}
+
+// permanentError is the error of a download that is not retried, as it would fail again
+type permanentError struct {
+	error
+}
+
 func (h *ArtifactDriver) retrieveContent(inputArtifact *wfv1.Artifact) (http.Response, error) {
+	return h.retrieveContentFrom(inputArtifact, 0)
+}
+
+// retrieveContentFrom requests the content of the artifact from the offset, which the server may ignore by responding
+// with all of it
+func (h *ArtifactDriver) retrieveContentFrom(inputArtifact *wfv1.Artifact, offset int64) (http.Response, error) {
 	var req *http.Request
 	var url string
 	var err error
@@ -47,6 +76,9 @@ func (h *ArtifactDriver) retrieveContent(inputArtifact *wfv1.Artifact) (http.Res
 	} else {
 		return http.Response{}, errors.InternalErrorf("Either Artifactory or HTTP artifact needs to be configured")
 	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
 
 	// Note that we will close the response body in either `Load()`
 	// or `ArtifactServer.returnArtifact()`, which is the caller of `OpenStream()`.
@@ -54,17 +86,39 @@ func (h *ArtifactDriver) retrieveContent(inputArtifact *wfv1.Artifact) (http.Res
 	if err != nil {
 		return http.Response{}, err
 	}
+	if offset > 0 && res.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// the artifact has changed since the download was interrupted, so all of it is requested again
+		_ = res.Body.Close()
+		return h.retrieveContentFrom(inputArtifact, 0)
+	}
 	if res.StatusCode == 404 {
-		return http.Response{}, errors.New(errors.CodeNotFound, res.Status)
+		_ = res.Body.Close()
+		return http.Response{}, permanentError{errors.New(errors.CodeNotFound, res.Status)}
 	}
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return http.Response{}, errors.InternalErrorf("loading content from %s failed with reason: %s", url, res.Status)
+		_ = res.Body.Close()
+		err := errors.InternalErrorf("loading content from %s failed with reason: %s", url, res.Status)
+		if res.StatusCode != http.StatusRequestTimeout && res.StatusCode != http.StatusTooManyRequests && res.StatusCode < 500 {
+			return http.Response{}, permanentError{err}
+		}
+		return http.Response{}, err
 	}
 	return *res, nil
 }
 
-// Load reads the artifact from the HTTP URL
+// Load reads the artifact from the HTTP URL. Failed downloads are retried as the artifact says, and the artifact is
+// verified against its checksum, if it has one. A folder of Artifactory is downloaded into a directory.
 func (h *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string) error {
+	if inputArtifact.Artifactory != nil && inputArtifact.HTTP == nil {
+		isDir, err := h.isArtifactoryDirectory(inputArtifact.Artifactory)
+		if err != nil {
+			// the server may not have the storage API, so the artifact is downloaded as a file
+			log.WithError(err).WithField("url", inputArtifact.Artifactory.URL).Warn("Failed to test if the Artifactory artifact is a folder")
+		}
+		if isDir {
+			return h.loadArtifactoryDirectory(inputArtifact.Artifactory, path)
+		}
+	}
 	lf, err := os.Create(path)
 	if err != nil {
 		return err
@@ -72,29 +126,162 @@ func (h *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string) error {
 	defer func() {
 		_ = lf.Close()
 	}()
-	res, err := h.retrieveContent(inputArtifact)
+	if inputArtifact.HTTP == nil || inputArtifact.HTTP.Retry == nil {
+		err := h.download(inputArtifact, lf)
+		if permanentErr, ok := err.(permanentError); ok {
+			return permanentErr.error
+		}
+		return err
+	}
+	backoff, err := RetryBackoff(inputArtifact.HTTP.Retry)
+	if err != nil {
+		return err
+	}
+	return waitutil.Backoff(backoff, func() (bool, error) {
+		err := h.download(inputArtifact, lf)
+		if permanentErr, ok := err.(permanentError); ok {
+			return true, permanentErr.error
+		}
+		if err != nil {
+			log.WithError(err).WithField("url", inputArtifact.HTTP.URL).Warn("Failed to download HTTP artifact, retrying")
+		}
+		return err == nil, err
+	})
+}
+
+// download downloads the artifact into the file, resuming the download where a previous one left off if the server
+// supports range requests, and starting it over otherwise
+func (h *ArtifactDriver) download(inputArtifact *wfv1.Artifact, f *os.File) error {
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return permanentError{err}
+	}
+	res, err := h.retrieveContentFrom(inputArtifact, offset)
 	if err != nil {
 		return err
 	}
 	defer func() {
 		_ = res.Body.Close()
 	}()
-	_, err = io.Copy(lf, res.Body)
+	if offset > 0 && (res.StatusCode != http.StatusPartialContent || !strings.HasPrefix(res.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset))) {
+		log.WithField("offset", offset).Info("HTTP artifact server does not support resuming downloads, downloading it again")
+		if err := restartDownload(f); err != nil {
+			return permanentError{err}
+		}
+	}
+	if _, err := io.Copy(f, res.Body); err != nil {
+		return err
+	}
+	if inputArtifact.HTTP == nil || inputArtifact.HTTP.SHA256 == "" {
+		return nil
+	}
+	return verifyChecksum(f, inputArtifact.HTTP.SHA256)
+}
+
+// restartDownload discards what was downloaded into the file
+func restartDownload(f *os.File) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := f.Seek(0, io.SeekStart)
 	return err
 }
 
+// verifyChecksum verifies the SHA256 checksum of the downloaded file, discarding it if it does not match so that it
+// is downloaded again if the download is retried
+func verifyChecksum(f *os.File, expected string) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return permanentError{err}
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return permanentError{err}
+	}
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if strings.EqualFold(actual, expected) {
+		return nil
+	}
+	if err := restartDownload(f); err != nil {
+		return permanentError{err}
+	}
+	return errors.InternalErrorf("checksum of the downloaded artifact is sha256:%s, expected sha256:%s", actual, expected)
+}
+
+// RetryBackoff returns the backoff of the retries of failed requests, which is the default if retry is nil
+func RetryBackoff(retry *wfv1.HTTPArtifactRetry) (wait.Backoff, error) {
+	backoff := defaultRetry
+	if retry == nil {
+		return backoff, nil
+	}
+	if retry.Limit != nil {
+		backoff.Steps = int(*retry.Limit) + 1
+	}
+	if retry.Backoff == nil {
+		return backoff, nil
+	}
+	if retry.Backoff.Duration != "" {
+		duration, err := wfv1.ParseStringToDuration(retry.Backoff.Duration)
+		if err != nil {
+			return backoff, err
+		}
+		backoff.Duration = duration
+	}
+	factor, err := intstr.Int32(retry.Backoff.Factor)
+	if err != nil {
+		return backoff, err
+	}
+	if factor != nil {
+		backoff.Factor = float64(*factor)
+	}
+	if retry.Backoff.Cap != "" {
+		capDuration, err := wfv1.ParseStringToDuration(retry.Backoff.Cap)
+		if err != nil {
+			return backoff, err
+		}
+		backoff.Cap = capDuration
+	}
+	return backoff, nil
+}
+
 func (h *ArtifactDriver) OpenStream(inputArtifact *wfv1.Artifact) (io.ReadCloser, error) {
 	res, err := h.retrieveContent(inputArtifact)
+	if permanentErr, ok := err.(permanentError); ok {
+		return nil, permanentErr.error
+	}
 	if err != nil {
 		return nil, err
 	}
 	return res.Body, nil
 }
 
-// Save writes the artifact to the URL
+// Save writes the artifact to the URL. A directory is uploaded into a folder of Artifactory.
 func (h *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error {
 	cleanPath := filepath.Clean(path)
-	f, err := os.Open(cleanPath)
+	if outputArtifact.Artifactory != nil && outputArtifact.HTTP == nil {
+		if info, err := os.Stat(cleanPath); err == nil && info.IsDir() {
+			return h.saveArtifactoryDirectory(cleanPath, outputArtifact.Artifactory)
+		}
+	}
+	// we set the GetBody func of the request in order to enable following 307 POST/PUT redirects, needed e.g. for webHDFS
+	getBody := func() (io.ReadCloser, error) {
+		return os.Open(cleanPath)
+	}
+	var contentLength int64
+	var contentType string
+	method := http.MethodPut
+	if outputArtifact.HTTP != nil {
+		if outputArtifact.HTTP.Method != "" {
+			method = outputArtifact.HTTP.Method
+		}
+		if outputArtifact.HTTP.Form != nil {
+			var err error
+			getBody, contentLength, contentType, err = formBody(cleanPath, outputArtifact.HTTP.Form)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	body, err := getBody()
 	if err != nil {
 		return err
 	}
@@ -102,14 +289,14 @@ func (h *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error
 	var url string
 	if outputArtifact.Artifactory != nil && outputArtifact.HTTP == nil {
 		url = outputArtifact.Artifactory.URL
-		req, err = http.NewRequest(http.MethodPut, url, f)
+		req, err = http.NewRequest(method, url, body)
 		if err != nil {
 			return err
 		}
 		req.SetBasicAuth(h.Username, h.Password)
 	} else {
 		url = outputArtifact.HTTP.URL
-		req, err = http.NewRequest(http.MethodPut, url, f)
+		req, err = http.NewRequest(method, url, body)
 		if err != nil {
 			return err
 		}
@@ -120,9 +307,10 @@ func (h *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error
 			req.SetBasicAuth(h.Username, h.Password)
 		}
 	}
-	// we set the GetBody func of the request in order to enable following 307 POST/PUT redirects, needed e.g. for webHDFS
-	req.GetBody = func() (io.ReadCloser, error) {
-		return os.Open(cleanPath)
+	req.GetBody = getBody
+	if contentType != "" {
+		req.ContentLength = contentLength
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	res, err := h.Client.Do(req)
@@ -133,20 +321,103 @@ func (h *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error
 		_ = res.Body.Close()
 	}()
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return errors.InternalErrorf("saving file %s to %s failed with reason: %s", path, url, res.Status)
+		err := errors.InternalErrorf("saving file %s to %s failed with reason: %s", path, url, res.Status)
+		if res.StatusCode == http.StatusInsufficientStorage {
+			// e.g. Artifactory, or a WebDAV server, is out of space
+			return common.NewDriverError(common.ErrorKindQuotaExceeded, err)
+		}
+		return err
 	}
 	return nil
 }
 
+// formBody returns a func that returns the body of a multipart/form-data request that uploads the file with the form,
+// along with the length and the content type of the body
+func formBody(path string, form *wfv1.HTTPArtifactForm) (func() (io.ReadCloser, error), int64, string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	fileField := form.FileField
+	if fileField == "" {
+		fileField = "file"
+	}
+	fileName := form.FileName
+	if fileName == "" {
+		fileName = filepath.Base(path)
+	}
+	// the parts of the form before and after the content of the file are built up front, so that the file is
+	// streamed rather than read into memory, and the length of the body is known
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	for _, name := range slices.Sorted(maps.Keys(form.Fields)) {
+		if err := w.WriteField(name, form.Fields[name]); err != nil {
+			return nil, 0, "", err
+		}
+	}
+	if _, err := w.CreateFormFile(fileField, fileName); err != nil {
+		return nil, 0, "", err
+	}
+	head := bytes.Clone(buf.Bytes())
+	buf.Reset()
+	if err := w.Close(); err != nil {
+		return nil, 0, "", err
+	}
+	tail := bytes.Clone(buf.Bytes())
+	getBody := func() (io.ReadCloser, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), f, bytes.NewReader(tail)), f}, nil
+	}
+	return getBody, int64(len(head)) + info.Size() + int64(len(tail)), w.FormDataContentType(), nil
+}
+
 // Delete is unsupported for the http artifacts
 func (h *ArtifactDriver) Delete(s *wfv1.Artifact) error {
 	return common.ErrDeleteNotSupported
 }
 
+// ListObjects lists the keys of the files of a folder of Artifactory, or the key of the artifact if it is a file
 func (h *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
-	return nil, fmt.Errorf("ListObjects is currently not supported for this artifact type, but it will be in a future version")
+	if artifact.Artifactory == nil || artifact.HTTP != nil {
+		return nil, fmt.Errorf("ListObjects is currently not supported for this artifact type, but it will be in a future version")
+	}
+	key, err := artifact.Artifactory.GetKey()
+	if err != nil {
+		return nil, err
+	}
+	isDir, err := h.isArtifactoryDirectory(artifact.Artifactory)
+	if err != nil {
+		return nil, err
+	}
+	if !isDir {
+		return []string{key}, nil
+	}
+	files, err := h.listArtifactoryDirectory(artifact.Artifactory)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(files))
+	for i, file := range files {
+		keys[i] = strings.TrimSuffix(key, "/") + "/" + file
+	}
+	return keys, nil
 }
 
+// IsDirectory tests if the artifact is a folder of Artifactory
 func (h *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
-	return false, errors.New(errors.CodeNotImplemented, "IsDirectory currently unimplemented for http")
+	if artifact.Artifactory == nil || artifact.HTTP != nil {
+		return false, errors.New(errors.CodeNotImplemented, "IsDirectory currently unimplemented for http")
+	}
+	return h.isArtifactoryDirectory(artifact.Artifactory)
+}
+
+// Capabilities returns the optional operations that the driver supports: only the folders of Artifactory can be listed
+func (h *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{ListObjects: h.Artifactory}
 }
@@ -2,17 +2,25 @@ package http
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 
 	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 )
 
 func TestHTTPArtifactDriver_Load(t *testing.T) {
@@ -137,3 +145,249 @@ func TestSaveHTTPArtifactRedirect(t *testing.T) {
 	})
 
 }
+
+func TestLoadHTTPArtifactRetry(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	checksum := sha256.Sum256(content)
+	retry := &wfv1.HTTPArtifactRetry{Limit: ptr.To(int32(2)), Backoff: &wfv1.Backoff{Duration: "1ms"}}
+	driver := &ArtifactDriver{Client: http.DefaultClient}
+	load := func(t *testing.T, url, sha string) ([]byte, error) {
+		t.Helper()
+		tempFile := filepath.Join(t.TempDir(), "artifact")
+		err := driver.Load(&wfv1.Artifact{
+			ArtifactLocation: wfv1.ArtifactLocation{
+				HTTP: &wfv1.HTTPArtifact{URL: url, Retry: retry, SHA256: sha},
+			},
+		}, tempFile)
+		if err != nil {
+			return nil, err
+		}
+		return os.ReadFile(tempFile)
+	}
+
+	t.Run("Resume", func(t *testing.T) {
+		var ranges []string
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ranges = append(ranges, r.Header.Get("Range"))
+			if len(ranges) == 1 {
+				// the connection is dropped half way through the first download
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				_, _ = w.Write(content[:len(content)/2])
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+		}))
+		defer svr.Close()
+
+		data, err := load(t, svr.URL, hex.EncodeToString(checksum[:]))
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+		assert.Equal(t, []string{"", fmt.Sprintf("bytes=%d-", len(content)/2)}, ranges)
+	})
+	t.Run("RangeNotSupported", func(t *testing.T) {
+		requests := 0
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			if requests == 1 {
+				_, _ = w.Write(content[:len(content)/2])
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			}
+			_, _ = w.Write(content)
+		}))
+		defer svr.Close()
+
+		data, err := load(t, svr.URL, "")
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("ServerError", func(t *testing.T) {
+		requests := 0
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write(content)
+		}))
+		defer svr.Close()
+
+		data, err := load(t, svr.URL, "")
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		requests := 0
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer svr.Close()
+
+		_, err := load(t, svr.URL, "")
+		require.Error(t, err)
+		argoError, ok := err.(errors.ArgoError)
+		require.True(t, ok)
+		assert.Equal(t, errors.CodeNotFound, argoError.Code())
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		requests := 0
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_, _ = w.Write(content)
+		}))
+		defer svr.Close()
+
+		wrong := sha256.Sum256([]byte("something else"))
+		_, err := load(t, svr.URL, hex.EncodeToString(wrong[:]))
+		require.ErrorContains(t, err, fmt.Sprintf("checksum of the downloaded artifact is sha256:%x, expected sha256:%x", checksum, wrong))
+		assert.Equal(t, 3, requests)
+	})
+}
+
+func TestSaveHTTPArtifactForm(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "report.tgz")
+	content := "temporary file's content"
+	require.NoError(t, os.WriteFile(tempFile, []byte(content), 0o600))
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "bar", r.Header.Get("X-Foo"))
+		assert.Positive(t, r.ContentLength)
+		if !assert.NoError(t, r.ParseMultipartForm(1<<20)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "my-wf", r.FormValue("workflow"))
+		f, header, err := r.FormFile("report")
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		assert.Equal(t, "report.tgz", header.Filename)
+		buf := new(bytes.Buffer)
+		_, err = buf.ReadFrom(f)
+		require.NoError(t, err)
+		assert.Equal(t, content, buf.String())
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer svr.Close()
+
+	driver := ArtifactDriver{Client: &http.Client{}}
+	err := driver.Save(tempFile, &wfv1.Artifact{
+		ArtifactLocation: wfv1.ArtifactLocation{
+			HTTP: &wfv1.HTTPArtifact{
+				URL:     svr.URL,
+				Headers: []wfv1.Header{{Name: "X-Foo", Value: "bar"}},
+				Method:  http.MethodPost,
+				Form: &wfv1.HTTPArtifactForm{
+					FileField: "report",
+					Fields:    map[string]string{"workflow": "my-wf"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestSaveHTTPArtifactInsufficientStorage(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "report.tgz")
+	require.NoError(t, os.WriteFile(tempFile, []byte("temporary file's content"), 0o600))
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInsufficientStorage)
+	}))
+	defer svr.Close()
+
+	driver := ArtifactDriver{Client: &http.Client{}}
+	err := driver.Save(tempFile, &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: svr.URL}}})
+	require.Error(t, err)
+	assert.Equal(t, common.ErrorKindQuotaExceeded, common.ErrorKindOf(err))
+}
+
+func TestArtifactoryDirectory(t *testing.T) {
+	files := map[string]string{
+		"/artifactory/generic-local/my-dir/a.txt":     "a",
+		"/artifactory/generic-local/my-dir/sub/b.txt": "b",
+	}
+	uploads := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); username != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			uploads[r.URL.Path] = string(data)
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/artifactory/api/storage/generic-local/my-dir" && r.URL.Query().Has("list"):
+			_, _ = w.Write([]byte(`{"uri": "/my-dir", "files": [{"uri": "/a.txt", "folder": false}, {"uri": "/sub", "folder": true}, {"uri": "/sub/b.txt", "folder": false}]}`))
+		case r.URL.Path == "/artifactory/api/storage/generic-local/my-dir":
+			_, _ = w.Write([]byte(`{"repo": "generic-local", "path": "/my-dir", "children": [{"uri": "/a.txt", "folder": false}, {"uri": "/sub", "folder": true}]}`))
+		case r.URL.Path == "/artifactory/api/storage/generic-local/my-dir/a.txt":
+			_, _ = w.Write([]byte(`{"repo": "generic-local", "path": "/my-dir/a.txt", "size": "1"}`))
+		case files[r.URL.Path] != "":
+			_, _ = w.Write([]byte(files[r.URL.Path]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	driver := &ArtifactDriver{Username: "admin", Password: "password", Client: http.DefaultClient}
+	artifact := func(path string) *wfv1.Artifact {
+		return &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Artifactory: &wfv1.ArtifactoryArtifact{URL: server.URL + path}}}
+	}
+
+	t.Run("IsDirectory", func(t *testing.T) {
+		isDir, err := driver.IsDirectory(artifact("/artifactory/generic-local/my-dir"))
+		require.NoError(t, err)
+		assert.True(t, isDir)
+		isDir, err = driver.IsDirectory(artifact("/artifactory/generic-local/my-dir/a.txt"))
+		require.NoError(t, err)
+		assert.False(t, isDir)
+	})
+	t.Run("ListObjects", func(t *testing.T) {
+		keys, err := driver.ListObjects(artifact("/artifactory/generic-local/my-dir"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/artifactory/generic-local/my-dir/a.txt", "/artifactory/generic-local/my-dir/sub/b.txt"}, keys)
+		keys, err = driver.ListObjects(artifact("/artifactory/generic-local/my-dir/a.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/artifactory/generic-local/my-dir/a.txt"}, keys)
+	})
+	t.Run("Load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "my-dir")
+		require.NoError(t, driver.Load(artifact("/artifactory/generic-local/my-dir"), path))
+		data, err := os.ReadFile(filepath.Join(path, "a.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "a", string(data))
+		data, err = os.ReadFile(filepath.Join(path, "sub", "b.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "b", string(data))
+	})
+	t.Run("LoadFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "a.txt")
+		require.NoError(t, driver.Load(artifact("/artifactory/generic-local/my-dir/a.txt"), path))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "a", string(data))
+	})
+	t.Run("Save", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "c.txt"), []byte("c"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "d.txt"), []byte("d"), 0o600))
+		require.NoError(t, driver.Save(dir, artifact("/artifactory/generic-local/out")))
+		assert.Equal(t, map[string]string{
+			"/artifactory/generic-local/out/c.txt":     "c",
+			"/artifactory/generic-local/out/sub/d.txt": "d",
+		}, uploads)
+	})
+}
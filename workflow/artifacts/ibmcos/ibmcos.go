@@ -0,0 +1,400 @@
+package ibmcos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/aws/awserr"
+	"github.com/IBM/ibm-cos-sdk-go/aws/credentials/ibmiam"
+	"github.com/IBM/ibm-cos-sdk-go/aws/request"
+	"github.com/IBM/ibm-cos-sdk-go/aws/session"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3/s3manager"
+	log "github.com/sirupsen/logrus"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+const (
+	// DefaultIAMEndpoint is the URL of IBM Cloud IAM
+	DefaultIAMEndpoint = "https://iam.cloud.ibm.com"
+	// DefaultComputeResourceTokenPath is where IBM Cloud Kubernetes Service mounts the compute resource token of pods
+	DefaultComputeResourceTokenPath = "/var/run/secrets/tokens/vault-token"
+	// region is the signing region, which Cloud Object Storage ignores for requests that are authorized with IAM tokens
+	region = "us-standard"
+	// maxObjectSize is the size of the largest object
+	maxObjectSize = int64(10) << 40
+	// maxKeys is the most keys that are listed at once
+	maxKeys = 1000
+)
+
+// ArtifactDriver is the artifact driver for IBM Cloud Object Storage, which authenticates with IAM rather than with
+// HMAC credentials, so that API keys and trusted profiles can be used
+type ArtifactDriver struct {
+	Endpoint string
+	APIKey   string
+	// TrustedProfileID is the ID of the trusted profile to authenticate as, with the compute resource token at
+	// ComputeResourceTokenPath, rather than with the API key
+	TrustedProfileID         string
+	ComputeResourceTokenPath string
+	IAMEndpoint              string
+	// Progress is called with the number of bytes of objects uploaded, as they are uploaded
+	Progress func(n int64)
+
+	partSize int64
+	once     sync.Once
+	client   *s3.S3
+	err      error
+}
+
+var (
+	_ common.ArtifactDriver   = &ArtifactDriver{}
+	_ common.ExistenceChecker = &ArtifactDriver{}
+	_ common.ProgressReporter = &ArtifactDriver{}
+)
+
+// ValidateArtifact validates an IBM Cloud Object Storage artifact
+func ValidateArtifact(errPrefix string, art *wfv1.IBMCOSArtifact) error {
+	if art.Key == "" {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.key is required", errPrefix)
+	}
+	if art.Bucket == "" {
+		// the rest of the location is from the artifact repository
+		return nil
+	}
+	if art.Endpoint == "" {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.endpoint is required", errPrefix)
+	}
+	if (art.APIKeySecret == nil) == (art.TrustedProfileID == "") {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s must have either apiKeySecret or trustedProfileID", errPrefix)
+	}
+	return nil
+}
+
+// CreateDriver constructs ArtifactDriver
+func CreateDriver(ctx context.Context, ri resource.Interface, art *wfv1.IBMCOSArtifact) (*ArtifactDriver, error) {
+	driver := ArtifactDriver{
+		Endpoint:                 art.Endpoint,
+		TrustedProfileID:         art.TrustedProfileID,
+		ComputeResourceTokenPath: art.ComputeResourceTokenPath,
+		IAMEndpoint:              art.IAMEndpoint,
+	}
+	if art.APIKeySecret != nil {
+		apiKey, err := ri.GetSecret(ctx, art.APIKeySecret.Name, art.APIKeySecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		driver.APIKey = apiKey
+	}
+	return &driver, nil
+}
+
+// SetProgress sets the function called with the number of bytes uploaded by Save
+func (d *ArtifactDriver) SetProgress(progress func(n int64)) bool {
+	d.Progress = progress
+	return true
+}
+
+// newClient returns the client of the S3 API of Cloud Object Storage, which is shared by the operations of the
+// driver, so that IAM tokens are only requested when they are about to expire
+func (d *ArtifactDriver) newClient() (*s3.S3, error) {
+	d.once.Do(func() {
+		iamEndpoint := d.IAMEndpoint
+		if iamEndpoint == "" {
+			iamEndpoint = DefaultIAMEndpoint
+		}
+		tokenURL := strings.TrimSuffix(iamEndpoint, "/") + "/identity/token"
+		creds := ibmiam.NewStaticCredentials(aws.NewConfig(), tokenURL, d.APIKey, "")
+		if d.TrustedProfileID != "" {
+			crTokenPath := d.ComputeResourceTokenPath
+			if crTokenPath == "" {
+				crTokenPath = DefaultComputeResourceTokenPath
+			}
+			creds = ibmiam.NewTrustedProfileCredentialsCR(aws.NewConfig(), tokenURL, d.TrustedProfileID, crTokenPath, "")
+		}
+		endpoint := d.Endpoint
+		if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			endpoint = "https://" + endpoint
+		}
+		var sess *session.Session
+		sess, d.err = session.NewSession(aws.NewConfig().
+			WithEndpoint(endpoint).
+			WithRegion(region).
+			WithCredentials(creds).
+			WithS3ForcePathStyle(true))
+		if d.err == nil {
+			d.client = s3.New(sess)
+		}
+	})
+	return d.client, d.err
+}
+
+func isNotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	return errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound
+}
+
+// listObjects lists the keys of the objects that start with the prefix, up to the limit if it is not zero
+func listObjects(c *s3.S3, bucket, prefix string, limit int) ([]string, error) {
+	var keys []string
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(bucket), Prefix: aws.String(prefix), MaxKeys: aws.Int64(maxKeys)}
+	if limit > 0 {
+		input.MaxKeys = aws.Int64(int64(min(limit, maxKeys)))
+	}
+	err := c.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			keys = append(keys, aws.StringValue(o.Key))
+		}
+		return limit == 0 || len(keys) < limit
+	})
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys, err
+}
+
+// directoryPrefix returns the prefix of the keys of the objects of a directory
+func directoryPrefix(key string) string {
+	return strings.TrimSuffix(key, "/") + "/"
+}
+
+// Load downloads the object of the artifact, or the objects of its directory
+func (d *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, localPath string) error {
+	a := inputArtifact.IBMCOS
+	log.WithFields(log.Fields{"endpoint": a.Endpoint, "bucket": a.Bucket, "key": a.Key, "path": localPath}).Info("Loading from IBM Cloud Object Storage")
+	c, err := d.newClient()
+	if err != nil {
+		return err
+	}
+	err = downloadObject(c, a.Bucket, a.Key, localPath)
+	if !isNotFound(err) {
+		return err
+	}
+	prefix := directoryPrefix(a.Key)
+	keys, err := listObjects(c, a.Bucket, prefix, 0)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return argoerrors.Errorf(argoerrors.CodeNotFound, "%s does not exist in bucket %s", a.Key, a.Bucket)
+	}
+	// Follow umask for the permission
+	if err := os.MkdirAll(localPath, 0o777); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if strings.HasSuffix(key, "/") {
+			// a folder that was created in the console
+			continue
+		}
+		if err := downloadObject(c, a.Bucket, key, filepath.Join(localPath, filepath.FromSlash(strings.TrimPrefix(key, prefix)))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func downloadObject(c *s3.S3, bucket, key, localPath string) error {
+	obj, err := c.GetObject(&s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = obj.Body.Close() }()
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o777); err != nil {
+		return err
+	}
+	dst, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, obj.Body)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// OpenStream opens the object of the artifact
+func (d *ArtifactDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	log.WithFields(log.Fields{"endpoint": a.IBMCOS.Endpoint, "bucket": a.IBMCOS.Bucket, "key": a.IBMCOS.Key}).Info("Streaming from IBM Cloud Object Storage")
+	c, err := d.newClient()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.GetObject(&s3.GetObjectInput{Bucket: aws.String(a.IBMCOS.Bucket), Key: aws.String(a.IBMCOS.Key)})
+	if err == nil {
+		return obj.Body, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+	keys, listErr := listObjects(c, a.IBMCOS.Bucket, directoryPrefix(a.IBMCOS.Key), 1)
+	if listErr != nil {
+		return nil, listErr
+	}
+	if len(keys) > 0 {
+		return nil, argoerrors.New(argoerrors.CodeNotImplemented, "Directory Stream capability currently unimplemented for IBM Cloud Object Storage")
+	}
+	return nil, argoerrors.New(argoerrors.CodeNotFound, err.Error())
+}
+
+// Save uploads the file, or the files of the directory, to the key of the artifact
+func (d *ArtifactDriver) Save(localPath string, outputArtifact *wfv1.Artifact) error {
+	a := outputArtifact.IBMCOS
+	log.WithFields(log.Fields{"endpoint": a.Endpoint, "bucket": a.Bucket, "key": a.Key, "path": localPath}).Info("Saving to IBM Cloud Object Storage")
+	c, err := d.newClient()
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	// the uploader aborts the multipart uploads that fail, as their parts are kept, and charged for, until they are
+	// aborted
+	uploader := s3manager.NewUploaderWithClient(c, func(u *s3manager.Uploader) {
+		if d.partSize > 0 {
+			u.PartSize = d.partSize
+		}
+		if d.Progress != nil {
+			u.RequestOptions = append(u.RequestOptions, d.reportProgress)
+		}
+	})
+	if !info.IsDir() {
+		return d.uploadFile(uploader, localPath, a.Bucket, a.Key)
+	}
+	prefix := directoryPrefix(a.Key)
+	return filepath.WalkDir(localPath, func(p string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(localPath, p)
+		if err != nil {
+			return err
+		}
+		return d.uploadFile(uploader, p, a.Bucket, path.Join(prefix, filepath.ToSlash(rel)))
+	})
+}
+
+func (d *ArtifactDriver) uploadFile(uploader *s3manager.Uploader, localPath, bucket, key string) error {
+	f, err := os.Open(filepath.Clean(localPath))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = uploader.Upload(&s3manager.UploadInput{Bucket: aws.String(bucket), Key: aws.String(key), Body: f})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+// reportProgress reports the bytes of the objects that are uploaded as they are sent, rather than as they are read,
+// as the client also reads them to checksum them
+func (d *ArtifactDriver) reportProgress(r *request.Request) {
+	r.Handlers.Send.PushFront(func(r *request.Request) {
+		if r.Operation.Name != "PutObject" && r.Operation.Name != "UploadPart" {
+			return
+		}
+		if r.HTTPRequest.Body != nil && r.HTTPRequest.Body != http.NoBody {
+			r.HTTPRequest.Body = &progressReader{ReadCloser: r.HTTPRequest.Body, progress: d.Progress}
+		}
+	})
+}
+
+type progressReader struct {
+	io.ReadCloser
+	progress func(n int64)
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.ReadCloser.Read(b)
+	r.progress(int64(n))
+	return n, err
+}
+
+// Delete deletes the object of the artifact, and the objects of its directory
+func (d *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
+	a := artifact.IBMCOS
+	c, err := d.newClient()
+	if err != nil {
+		return err
+	}
+	keys, err := listObjects(c, a.Bucket, directoryPrefix(a.Key), 0)
+	if err != nil {
+		return err
+	}
+	for _, key := range append(keys, a.Key) {
+		_, err := c.DeleteObject(&s3.DeleteObjectInput{Bucket: aws.String(a.Bucket), Key: aws.String(key)})
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListObjects returns the key of the object of the artifact, or the keys of the objects of its directory
+func (d *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
+	a := artifact.IBMCOS
+	c, err := d.newClient()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := listObjects(c, a.Bucket, a.Key, 0)
+	if err != nil {
+		return nil, err
+	}
+	prefix := directoryPrefix(a.Key)
+	var objects []string
+	for _, key := range keys {
+		if key == a.Key || strings.HasPrefix(key, prefix) && !strings.HasSuffix(key, "/") {
+			objects = append(objects, key)
+		}
+	}
+	return objects, nil
+}
+
+// IsDirectory returns whether there are objects whose keys start with the key of the artifact and a slash
+func (d *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
+	c, err := d.newClient()
+	if err != nil {
+		return false, err
+	}
+	keys, err := listObjects(c, artifact.IBMCOS.Bucket, directoryPrefix(artifact.IBMCOS.Key), 1)
+	return len(keys) > 0, err
+}
+
+// Capabilities returns the optional operations that IBM Cloud Object Storage supports
+func (d *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{Delete: true, ListObjects: true, MaxObjectSize: maxObjectSize}
+}
+
+// Exists returns whether the artifact is an object or a directory
+func (d *ArtifactDriver) Exists(artifact *wfv1.Artifact) (bool, error) {
+	c, err := d.newClient()
+	if err != nil {
+		return false, err
+	}
+	_, err = c.HeadObject(&s3.HeadObjectInput{Bucket: aws.String(artifact.IBMCOS.Bucket), Key: aws.String(artifact.IBMCOS.Key)})
+	if err == nil {
+		return true, nil
+	}
+	if !isNotFound(err) {
+		return false, err
+	}
+	keys, err := listObjects(c, artifact.IBMCOS.Bucket, directoryPrefix(artifact.IBMCOS.Key), 1)
+	return len(keys) > 0, err
+}
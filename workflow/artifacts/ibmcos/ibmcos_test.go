@@ -0,0 +1,345 @@
+package ibmcos
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibm-cos-sdk-go/service/s3/s3manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// fakeCOS is IAM and the S3 API of Cloud Object Storage, for a bucket named my-bucket
+type fakeCOS struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+	// tokenRequests are the forms of the requests for tokens
+	tokenRequests []map[string]string
+	// failComplete fails the completion of multipart uploads with an error in the body of a successful response, as
+	// Cloud Object Storage does when it fails after it started to respond
+	failComplete bool
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func newFakeCOS(t *testing.T) (*fakeCOS, *httptest.Server) {
+	t.Helper()
+	f := &fakeCOS{objects: map[string][]byte{}, uploads: map[string]map[int][]byte{}}
+	ts := httptest.NewServer(f)
+	t.Cleanup(ts.Close)
+	return f, ts
+}
+
+func (f *fakeCOS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fail := func(status int, code string) {
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+	}
+	if r.URL.Path == "/identity/token" {
+		_ = r.ParseForm()
+		form := map[string]string{}
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+		f.tokenRequests = append(f.tokenRequests, form)
+		if form["apikey"] != "my-api-key" && form["cr_token"] != "my-cr-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorCode":"BXNIM0415E","errorMessage":"Provided API key could not be found."}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "my-token",
+			"refresh_token": "my-refresh-token",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+			"expiration":    time.Now().Add(time.Hour).Unix(),
+		})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer my-token" {
+		fail(http.StatusForbidden, "AccessDenied")
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/my-bucket") {
+		fail(http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/my-bucket"), "/")
+	query := r.URL.Query()
+	switch {
+	case key == "" && r.Method == http.MethodGet:
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, query.Get("prefix")) && k > query.Get("continuation-token") {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		maxKeys, _ := strconv.Atoi(query.Get("max-keys"))
+		truncated := len(keys) > maxKeys
+		if truncated {
+			keys = keys[:maxKeys]
+		}
+		_, _ = fmt.Fprintf(w, "<ListBucketResult><IsTruncated>%v</IsTruncated>", truncated)
+		for _, k := range keys {
+			_, _ = fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", k)
+		}
+		if truncated {
+			_, _ = fmt.Fprintf(w, "<NextContinuationToken>%s</NextContinuationToken>", keys[len(keys)-1])
+		}
+		_, _ = w.Write([]byte("</ListBucketResult>"))
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		id := strconv.Itoa(len(f.uploads) + 1)
+		f.uploads[id] = map[int][]byte{}
+		_, _ = fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		n, _ := strconv.Atoi(query.Get("partNumber"))
+		data, _ := io.ReadAll(r.Body)
+		f.uploads[query.Get("uploadId")][n] = data
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, n))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		if f.failComplete {
+			_, _ = w.Write([]byte("<Error><Code>InternalError</Code><Message>We encountered an internal error.</Message></Error>"))
+			return
+		}
+		var complete struct {
+			Parts []completedPart `xml:"Part"`
+		}
+		_ = xml.NewDecoder(r.Body).Decode(&complete)
+		var data []byte
+		for i, p := range complete.Parts {
+			if p.PartNumber != i+1 || p.ETag != fmt.Sprintf(`"etag-%d"`, i+1) {
+				fail(http.StatusBadRequest, "InvalidPart")
+				return
+			}
+			data = append(data, f.uploads[query.Get("uploadId")][p.PartNumber]...)
+		}
+		delete(f.uploads, query.Get("uploadId"))
+		f.objects[key] = data
+		_, _ = fmt.Fprintf(w, "<CompleteMultipartUploadResult><Bucket>my-bucket</Bucket><Key>%s</Key></CompleteMultipartUploadResult>", key)
+	case r.Method == http.MethodPut:
+		if r.ContentLength < 0 || r.Header.Get("Transfer-Encoding") != "" {
+			fail(http.StatusLengthRequired, "MissingContentLength")
+			return
+		}
+		f.objects[key], _ = io.ReadAll(r.Body)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			fail(http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(f.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		fail(http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	f, ts := newFakeCOS(t)
+	d := &ArtifactDriver{Endpoint: ts.URL, APIKey: "my-api-key", IAMEndpoint: ts.URL}
+	local := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "dir", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "file.txt"), []byte("hello"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "empty.txt"), nil, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "a.txt"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "sub", "b c.txt"), []byte("b"), 0o600))
+	file := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "out/file.txt"}}}
+	dir := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "out/dir"}}}
+	missing := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "missing"}}}
+
+	t.Run("File", func(t *testing.T) {
+		var progress int64
+		assert.True(t, d.SetProgress(func(n int64) { progress += n }))
+		defer d.SetProgress(nil)
+		require.NoError(t, d.Save(filepath.Join(local, "file.txt"), file))
+		assert.Equal(t, int64(5), progress)
+		loaded := filepath.Join(t.TempDir(), "file.txt")
+		require.NoError(t, d.Load(file, loaded))
+		data, err := os.ReadFile(loaded)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+	t.Run("EmptyFile", func(t *testing.T) {
+		require.NoError(t, d.Save(filepath.Join(local, "empty.txt"), &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "out/empty.txt"}}}))
+		assert.Equal(t, []byte{}, f.objects["out/empty.txt"])
+	})
+	t.Run("Directory", func(t *testing.T) {
+		require.NoError(t, d.Save(filepath.Join(local, "dir"), dir))
+		isDir, err := d.IsDirectory(dir)
+		require.NoError(t, err)
+		assert.True(t, isDir)
+		keys, err := d.ListObjects(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"out/dir/a.txt", "out/dir/sub/b c.txt"}, keys)
+
+		loaded := filepath.Join(t.TempDir(), "dir")
+		require.NoError(t, d.Load(dir, loaded))
+		data, err := os.ReadFile(filepath.Join(loaded, "sub", "b c.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "b", string(data))
+	})
+	t.Run("Exists", func(t *testing.T) {
+		for key, expected := range map[string]bool{"out/file.txt": true, "out/dir": true, "out/fi": false, "missing": false} {
+			exists, err := d.Exists(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: key}}})
+			require.NoError(t, err)
+			assert.Equal(t, expected, exists, key)
+		}
+	})
+	t.Run("OpenStream", func(t *testing.T) {
+		r, err := d.OpenStream(file)
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		_ = r.Close()
+		assert.Equal(t, "hello", string(data))
+		_, err = d.OpenStream(dir)
+		assert.True(t, argoerrors.IsCode(argoerrors.CodeNotImplemented, err))
+		_, err = d.OpenStream(missing)
+		assert.True(t, argoerrors.IsCode(argoerrors.CodeNotFound, err))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		err := d.Load(missing, filepath.Join(t.TempDir(), "missing"))
+		assert.True(t, argoerrors.IsCode(argoerrors.CodeNotFound, err))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, d.Delete(dir))
+		require.NoError(t, d.Delete(file))
+		require.NoError(t, d.Delete(missing))
+		var keys []string
+		for k := range f.objects {
+			keys = append(keys, k)
+		}
+		assert.Equal(t, []string{"out/empty.txt"}, keys)
+	})
+	assert.Len(t, f.tokenRequests, 1, "the token is reused until it is about to expire")
+}
+
+func TestSaveInParts(t *testing.T) {
+	f, ts := newFakeCOS(t)
+	d := &ArtifactDriver{Endpoint: ts.URL, APIKey: "my-api-key", IAMEndpoint: ts.URL, partSize: s3manager.MinUploadPartSize}
+	content := strings.Repeat("0123456789", int(s3manager.MinUploadPartSize/5)) + "tail"
+	local := filepath.Join(t.TempDir(), "large.bin")
+	require.NoError(t, os.WriteFile(local, []byte(content), 0o600))
+	var progress atomic.Int64
+	d.SetProgress(func(n int64) { progress.Add(n) })
+	require.NoError(t, d.Save(local, &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "large.bin"}}}))
+	assert.Equal(t, content, string(f.objects["large.bin"]))
+	assert.Empty(t, f.uploads)
+	assert.Equal(t, int64(len(content)), progress.Load(), "the parts are reported once, although they are also read to checksum them")
+	d.SetProgress(nil)
+
+	t.Run("CompleteFailed", func(t *testing.T) {
+		f.failComplete = true
+		err := d.Save(local, &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "failed.bin"}}})
+		require.ErrorContains(t, err, "failed to upload failed.bin")
+		require.ErrorContains(t, err, "InternalError")
+		assert.NotContains(t, f.objects, "failed.bin")
+		assert.Empty(t, f.uploads, "the upload is aborted")
+	})
+}
+
+func TestListObjects(t *testing.T) {
+	f, ts := newFakeCOS(t)
+	for i := range maxKeys + 5 {
+		f.objects[fmt.Sprintf("dir/%04d", i)] = nil
+	}
+	c, err := (&ArtifactDriver{Endpoint: ts.URL, APIKey: "my-api-key", IAMEndpoint: ts.URL}).newClient()
+	require.NoError(t, err)
+	keys, err := listObjects(c, "my-bucket", "dir/", 0)
+	require.NoError(t, err)
+	assert.Len(t, keys, maxKeys+5)
+	keys, err = listObjects(c, "my-bucket", "dir/", 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dir/0000", "dir/0001", "dir/0002"}, keys)
+}
+
+func TestCredentials(t *testing.T) {
+	t.Run("APIKey", func(t *testing.T) {
+		f, ts := newFakeCOS(t)
+		d := &ArtifactDriver{Endpoint: ts.URL, APIKey: "my-api-key", IAMEndpoint: ts.URL}
+		exists, err := d.Exists(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "missing"}}})
+		require.NoError(t, err)
+		assert.False(t, exists)
+		require.NotEmpty(t, f.tokenRequests)
+		assert.Equal(t, "urn:ibm:params:oauth:grant-type:apikey", f.tokenRequests[0]["grant_type"])
+		assert.Equal(t, "my-api-key", f.tokenRequests[0]["apikey"])
+	})
+	t.Run("TrustedProfile", func(t *testing.T) {
+		f, ts := newFakeCOS(t)
+		crTokenPath := filepath.Join(t.TempDir(), "vault-token")
+		require.NoError(t, os.WriteFile(crTokenPath, []byte("my-cr-token"), 0o600))
+		d := &ArtifactDriver{Endpoint: ts.URL, TrustedProfileID: "Profile-1234", ComputeResourceTokenPath: crTokenPath, IAMEndpoint: ts.URL}
+		exists, err := d.Exists(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "missing"}}})
+		require.NoError(t, err)
+		assert.False(t, exists)
+		require.NotEmpty(t, f.tokenRequests)
+		assert.Equal(t, "urn:ibm:params:oauth:grant-type:cr-token", f.tokenRequests[0]["grant_type"])
+		assert.Equal(t, "my-cr-token", f.tokenRequests[0]["cr_token"])
+		assert.Equal(t, "Profile-1234", f.tokenRequests[0]["profile_id"])
+	})
+	t.Run("Error", func(t *testing.T) {
+		_, ts := newFakeCOS(t)
+		d := &ArtifactDriver{Endpoint: ts.URL, APIKey: "wrong", IAMEndpoint: ts.URL}
+		_, err := d.Exists(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{IBMCOS: &wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket"}, Key: "out.txt"}}})
+		require.Error(t, err)
+	})
+}
+
+func TestValidateArtifact(t *testing.T) {
+	secret := &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "ibmcos"}, Key: "apiKey"}
+	config := wfv1.IBMCOSConfig{Endpoint: "s3.us-south.cloud-object-storage.appdomain.cloud", Bucket: "my-bucket"}
+	for name, tc := range map[string]struct {
+		art wfv1.IBMCOSArtifact
+		err string
+	}{
+		"Key":   {art: wfv1.IBMCOSArtifact{Key: "out.txt"}},
+		"NoKey": {art: wfv1.IBMCOSArtifact{}, err: "ibmcos.key is required"},
+		"NoEndpoint": {art: wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Bucket: "my-bucket", APIKeySecret: secret}, Key: "out.txt"},
+			err: "ibmcos.endpoint is required"},
+		"NoCredentials": {art: wfv1.IBMCOSArtifact{IBMCOSConfig: config, Key: "out.txt"},
+			err: "ibmcos must have either apiKeySecret or trustedProfileID"},
+		"APIKey":         {art: wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Endpoint: config.Endpoint, Bucket: config.Bucket, APIKeySecret: secret}, Key: "out.txt"}},
+		"TrustedProfile": {art: wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Endpoint: config.Endpoint, Bucket: config.Bucket, TrustedProfileID: "Profile-1234"}, Key: "out.txt"}},
+		"Both": {art: wfv1.IBMCOSArtifact{IBMCOSConfig: wfv1.IBMCOSConfig{Endpoint: config.Endpoint, Bucket: config.Bucket, APIKeySecret: secret, TrustedProfileID: "Profile-1234"}, Key: "out.txt"},
+			err: "ibmcos must have either apiKeySecret or trustedProfileID"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateArtifact("ibmcos", &tc.art)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -55,6 +55,18 @@ func (d driver) Save(path string, a *wfv1.Artifact) error {
 	return err
 }
 
+func (d driver) SaveStream(r io.Reader, a *wfv1.Artifact) error {
+	t := time.Now()
+	key, _ := a.GetKey()
+	err := common.SaveStream(d.ArtifactDriver, r, a)
+	log.WithField("artifactName", a.Name).
+		WithField("key", key).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info("Save artifact stream")
+	return err
+}
+
 func (d driver) ListObjects(a *wfv1.Artifact) ([]string, error) {
 	t := time.Now()
 	key, _ := a.GetKey()
@@ -67,6 +79,58 @@ func (d driver) ListObjects(a *wfv1.Artifact) ([]string, error) {
 	return list, err
 }
 
+func (d driver) Exists(a *wfv1.Artifact) (bool, error) {
+	t := time.Now()
+	key, _ := a.GetKey()
+	exists, err := common.Exists(d.ArtifactDriver, a)
+	log.WithField("artifactName", a.Name).
+		WithField("key", key).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info("Check if exists")
+	return exists, err
+}
+
+func (d driver) ETag(a *wfv1.Artifact) (string, error) {
+	t := time.Now()
+	key, _ := a.GetKey()
+	etag, err := common.ETag(d.ArtifactDriver, a)
+	log.WithField("artifactName", a.Name).
+		WithField("key", key).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info("Get entity tag")
+	return etag, err
+}
+
+func (d driver) DeletePrefix(a *wfv1.Artifact) error {
+	t := time.Now()
+	key, _ := a.GetKey()
+	err := common.DeletePrefix(d.ArtifactDriver, a)
+	log.WithField("artifactName", a.Name).
+		WithField("key", key).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info("Delete prefix")
+	return err
+}
+
+func (d driver) SetProgress(progress func(n int64)) bool {
+	return common.SetProgress(d.ArtifactDriver, progress)
+}
+
+func (d driver) OpenSeekableStream(a *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	t := time.Now()
+	key, _ := a.GetKey()
+	rs, err := common.OpenSeekableStream(d.ArtifactDriver, a)
+	log.WithField("artifactName", a.Name).
+		WithField("key", key).
+		WithField("duration", time.Since(t)).
+		WithError(err).
+		Info("Stream artifact")
+	return rs, err
+}
+
 func (d driver) IsDirectory(a *wfv1.Artifact) (bool, error) {
 	t := time.Now()
 	key, _ := a.GetKey()
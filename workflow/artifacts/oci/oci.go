@@ -0,0 +1,271 @@
+package oci
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	log "github.com/sirupsen/logrus"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+const (
+	// annotationTitle is the annotation of a layer with the name of its file or directory
+	annotationTitle = "org.opencontainers.image.title"
+	// annotationUnpack is the annotation of a layer that is a directory, packed as a tar.gz, as ORAS pushes them
+	annotationUnpack = "io.deis.oras.content.unpack"
+)
+
+// ArtifactDriver is the artifact driver for OCI artifacts, e.g. as pushed by ORAS, in a container registry. The files
+// and directories of an artifact are the layers of its manifest, named by their titles.
+type ArtifactDriver struct {
+	Username string
+	Password string
+	Insecure bool
+}
+
+var _ common.ArtifactDriver = &ArtifactDriver{}
+
+// ValidateArtifact validates an OCI artifact
+func ValidateArtifact(errPrefix string, art *wfv1.OCIArtifact) error {
+	if art.Reference == "" {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.reference is required", errPrefix)
+	}
+	if (art.UsernameSecret == nil) != (art.PasswordSecret == nil) {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s must have both usernameSecret and passwordSecret, or neither", errPrefix)
+	}
+	return nil
+}
+
+// CreateDriver constructs ArtifactDriver
+func CreateDriver(ctx context.Context, ri resource.Interface, art *wfv1.OCIArtifact) (*ArtifactDriver, error) {
+	driver := ArtifactDriver{Insecure: art.Insecure}
+	if art.UsernameSecret != nil {
+		username, err := ri.GetSecret(ctx, art.UsernameSecret.Name, art.UsernameSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		driver.Username = username
+	}
+	if art.PasswordSecret != nil {
+		password, err := ri.GetSecret(ctx, art.PasswordSecret.Name, art.PasswordSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		driver.Password = password
+	}
+	return &driver, nil
+}
+
+// file is a file or directory of an OCI artifact
+type file struct {
+	title     string
+	directory bool
+	digest    v1.Hash
+}
+
+// image returns the image of the artifact, and its files
+func (d *ArtifactDriver) image(a *wfv1.OCIArtifact) (v1.Image, []file, error) {
+	var opts []name.Option
+	if d.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	ref, err := name.ParseReference(a.Reference, opts...)
+	if err != nil {
+		return nil, nil, argoerrors.Errorf(argoerrors.CodeBadRequest, "reference %q is invalid: %v", a.Reference, err)
+	}
+	auth := authn.Anonymous
+	if d.Username != "" || d.Password != "" {
+		auth = authn.FromConfig(authn.AuthConfig{Username: d.Username, Password: d.Password})
+	}
+	img, err := remote.Image(ref, remote.WithAuth(auth))
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+			return nil, nil, argoerrors.Errorf(argoerrors.CodeNotFound, "%s does not exist", a.Reference)
+		}
+		return nil, nil, err
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, nil, err
+	}
+	var files []file
+	for _, l := range manifest.Layers {
+		title := l.Annotations[annotationTitle]
+		if title == "" {
+			// a layer of a container image, rather than a file
+			continue
+		}
+		if !filepath.IsLocal(title) {
+			return nil, nil, fmt.Errorf("%s has a file named %q, which is not a relative path", a.Reference, title)
+		}
+		files = append(files, file{title: title, directory: l.Annotations[annotationUnpack] == "true", digest: l.Digest})
+	}
+	if len(files) == 0 {
+		return nil, nil, argoerrors.Errorf(argoerrors.CodeNotFound, "%s has no files", a.Reference)
+	}
+	return img, files, nil
+}
+
+// Load pulls the artifact. If it is a single file or directory, that is loaded to the path. Otherwise, the path is a
+// directory of its files and directories.
+func (d *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, localPath string) error {
+	a := inputArtifact.OCI
+	log.WithFields(log.Fields{"reference": a.Reference, "path": localPath}).Info("Loading from OCI registry")
+	img, files, err := d.image(a)
+	if err != nil {
+		return err
+	}
+	if len(files) == 1 {
+		return loadFile(img, files[0], localPath)
+	}
+	for _, f := range files {
+		if err := loadFile(img, f, filepath.Join(localPath, filepath.FromSlash(f.title))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadFile(img v1.Image, f file, localPath string) error {
+	layer, err := img.LayerByDigest(f.digest)
+	if err != nil {
+		return err
+	}
+	// the blob of a layer is the file itself, or the tar.gz of the directory
+	src, err := layer.Compressed()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	if f.directory {
+		return untar(src, path.Clean(f.title), localPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o777); err != nil {
+		return err
+	}
+	dst, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// untar extracts the tar.gz of a directory to the path. The entries of the tarball are in the directory, as ORAS packs
+// them, and may not be outside of it.
+func untar(r io.Reader, dir, localPath string) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gzr.Close() }()
+	// Follow umask for the permission
+	if err := os.MkdirAll(localPath, 0o777); err != nil {
+		return err
+	}
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		// entries whose names are insecure are checked below, like the rest
+		if err != nil && !errors.Is(err, tar.ErrInsecurePath) {
+			return err
+		}
+		entry := path.Clean(header.Name)
+		if entry == dir {
+			continue
+		}
+		rel, ok := strings.CutPrefix(entry, dir+"/")
+		if !ok || !filepath.IsLocal(rel) {
+			return fmt.Errorf("%s is not in the directory %s", header.Name, dir)
+		}
+		target := filepath.Join(localPath, filepath.FromSlash(rel))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o777); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o777); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, header.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) || !filepath.IsLocal(filepath.Join(filepath.Dir(rel), header.Linkname)) {
+				return fmt.Errorf("%s links to %s, which is not in the directory %s", header.Name, header.Linkname, dir)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o777); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// OpenStream opens the artifact by loading it first
+func (d *ArtifactDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	return common.LoadToStream(a, d)
+}
+
+// Save is not supported, as OCI artifacts can only be input artifacts
+func (d *ArtifactDriver) Save(string, *wfv1.Artifact) error {
+	return argoerrors.New(argoerrors.CodeNotImplemented, "OCI artifacts can only be input artifacts")
+}
+
+func (d *ArtifactDriver) Delete(*wfv1.Artifact) error {
+	return common.ErrDeleteNotSupported
+}
+
+func (d *ArtifactDriver) ListObjects(*wfv1.Artifact) ([]string, error) {
+	return nil, argoerrors.New(argoerrors.CodeNotImplemented, "ListObjects currently unimplemented for OCI")
+}
+
+// IsDirectory returns whether the artifact is loaded as a directory, i.e. it is a directory or has several files
+func (d *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
+	_, files, err := d.image(artifact.OCI)
+	if err != nil {
+		return false, err
+	}
+	return len(files) > 1 || files[0].directory, nil
+}
+
+// Capabilities returns the optional operations that OCI supports, which are none
+func (d *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{}
+}
@@ -0,0 +1,183 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// testFile is a layer of an artifact that is pushed to the test registry
+type testFile struct {
+	title     string
+	directory bool
+	content   []byte
+}
+
+// newTestRegistry returns the host of a registry that requires the username and password
+func newTestRegistry(t *testing.T) string {
+	t.Helper()
+	handler := registry.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "my-user" || password != "my-password" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func push(t *testing.T, reference string, files ...testFile) {
+	t.Helper()
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	for _, f := range files {
+		annotations := map[string]string{annotationTitle: f.title}
+		mediaType := types.MediaType("application/vnd.oci.image.layer.v1.tar")
+		if f.directory {
+			annotations[annotationUnpack] = "true"
+			mediaType = types.OCILayer
+		}
+		var err error
+		img, err = mutate.Append(img, mutate.Addendum{Layer: static.NewLayer(f.content, mediaType), Annotations: annotations})
+		require.NoError(t, err)
+	}
+	ref, err := name.ParseReference(reference, name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img, remote.WithAuth(&authn.Basic{Username: "my-user", Password: "my-password"})))
+}
+
+// tarDirectory returns the tar.gz of the entries, as ORAS packs a directory
+func tarDirectory(t *testing.T, entries ...*tar.Header) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	gzw := gzip.NewWriter(&b)
+	tw := tar.NewWriter(gzw)
+	for _, h := range entries {
+		// the content of a file is its name, so that it can be told apart
+		content := []byte("content of " + h.Name)
+		if h.Typeflag == tar.TypeReg {
+			h.Size = int64(len(content))
+		}
+		require.NoError(t, tw.WriteHeader(h))
+		if h.Typeflag == tar.TypeReg {
+			_, err := tw.Write(content)
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return b.Bytes()
+}
+
+func TestValidateArtifact(t *testing.T) {
+	secret := &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-secret"}, Key: "password"}
+	require.NoError(t, ValidateArtifact("oci", &wfv1.OCIArtifact{Reference: "ghcr.io/my-org/my-data:v1"}))
+	require.NoError(t, ValidateArtifact("oci", &wfv1.OCIArtifact{Reference: "ghcr.io/my-org/my-data:v1", UsernameSecret: secret, PasswordSecret: secret}))
+	require.EqualError(t, ValidateArtifact("oci", &wfv1.OCIArtifact{}), "oci.reference is required")
+	require.EqualError(t, ValidateArtifact("oci", &wfv1.OCIArtifact{Reference: "ghcr.io/my-org/my-data:v1", PasswordSecret: secret}), "oci must have both usernameSecret and passwordSecret, or neither")
+}
+
+func TestLoad(t *testing.T) {
+	host := newTestRegistry(t)
+	driver := &ArtifactDriver{Username: "my-user", Password: "my-password", Insecure: true}
+	load := func(t *testing.T, reference string) (string, error) {
+		localPath := filepath.Join(t.TempDir(), "artifact")
+		return localPath, driver.Load(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{OCI: &wfv1.OCIArtifact{Reference: reference}}}, localPath)
+	}
+
+	t.Run("File", func(t *testing.T) {
+		push(t, host+"/file:v1", testFile{title: "model.bin", content: []byte("my-model")})
+		localPath, err := load(t, host+"/file:v1")
+		require.NoError(t, err)
+		content, err := os.ReadFile(localPath)
+		require.NoError(t, err)
+		assert.Equal(t, "my-model", string(content))
+		isDir, err := driver.IsDirectory(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{OCI: &wfv1.OCIArtifact{Reference: host + "/file:v1"}}})
+		require.NoError(t, err)
+		assert.False(t, isDir)
+	})
+	t.Run("Directory", func(t *testing.T) {
+		push(t, host+"/directory:v1", testFile{title: "tools", directory: true, content: tarDirectory(t,
+			&tar.Header{Typeflag: tar.TypeDir, Name: "tools/", Mode: 0o755},
+			&tar.Header{Typeflag: tar.TypeDir, Name: "tools/bin/", Mode: 0o755},
+			&tar.Header{Typeflag: tar.TypeReg, Name: "tools/bin/run.sh", Mode: 0o755},
+			&tar.Header{Typeflag: tar.TypeSymlink, Name: "tools/run.sh", Linkname: "bin/run.sh"},
+		)})
+		localPath, err := load(t, host+"/directory:v1")
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(localPath, "run.sh"))
+		require.NoError(t, err)
+		assert.Equal(t, "content of tools/bin/run.sh", string(content))
+		info, err := os.Stat(filepath.Join(localPath, "bin", "run.sh"))
+		require.NoError(t, err)
+		assert.NotZero(t, info.Mode().Perm()&0o100)
+	})
+	t.Run("Files", func(t *testing.T) {
+		push(t, host+"/files:v1",
+			testFile{title: "a.txt", content: []byte("a")},
+			testFile{title: "b", directory: true, content: tarDirectory(t, &tar.Header{Typeflag: tar.TypeReg, Name: "b/c.txt", Mode: 0o644})},
+		)
+		localPath, err := load(t, host+"/files:v1")
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(localPath, "a.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "a", string(content))
+		content, err = os.ReadFile(filepath.Join(localPath, "b", "c.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "content of b/c.txt", string(content))
+	})
+	t.Run("OutsideDirectory", func(t *testing.T) {
+		push(t, host+"/outside:v1", testFile{title: "tools", directory: true, content: tarDirectory(t,
+			&tar.Header{Typeflag: tar.TypeReg, Name: "tools/../../evil.sh", Mode: 0o755},
+		)})
+		_, err := load(t, host+"/outside:v1")
+		require.EqualError(t, err, "tools/../../evil.sh is not in the directory tools")
+	})
+	t.Run("SymlinkOutsideDirectory", func(t *testing.T) {
+		push(t, host+"/symlink:v1", testFile{title: "tools", directory: true, content: tarDirectory(t,
+			&tar.Header{Typeflag: tar.TypeSymlink, Name: "tools/passwd", Linkname: "/etc/passwd"},
+		)})
+		_, err := load(t, host+"/symlink:v1")
+		require.EqualError(t, err, "tools/passwd links to /etc/passwd, which is not in the directory tools")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := load(t, host+"/missing:v1")
+		require.Error(t, err)
+		assert.True(t, argoerrors.IsCode(argoerrors.CodeNotFound, err))
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		push(t, host+"/unauthorized:v1", testFile{title: "model.bin", content: []byte("my-model")})
+		localPath := filepath.Join(t.TempDir(), "artifact")
+		err := (&ArtifactDriver{Insecure: true}).Load(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{OCI: &wfv1.OCIArtifact{Reference: host + "/unauthorized:v1"}}}, localPath)
+		require.Error(t, err)
+		assert.NoFileExists(t, localPath)
+	})
+}
+
+func TestSave(t *testing.T) {
+	err := (&ArtifactDriver{}).Save("/tmp/my-file", &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{OCI: &wfv1.OCIArtifact{Reference: "ghcr.io/my-org/my-data:v1"}}})
+	require.EqualError(t, err, "OCI artifacts can only be input artifacts")
+}
@@ -1,11 +1,14 @@
 package oss
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"crypto/sha256"
 	"fmt"
 	"io"
 	"math"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -43,6 +46,8 @@ var (
 	ossTransientErrorCodes = []string{"RequestTimeout", "QuotaExceeded.Refresh", "Default", "ServiceUnavailable", "Throttling", "RequestTimeTooSkewed", "SocketException", "SocketTimeout", "ServiceBusy", "DomainNetWorkVisitedException", "ConnectionTimeout", "CachedTimeTooLarge", "InternalError"}
 	bucketLogFilePrefix    = "bucket-log-"
 	maxObjectSize          = int64(5 * 1024 * 1024 * 1024)
+	// maxMultipartObjectSize is the size of the largest object that can be uploaded, in 10,000 parts
+	maxMultipartObjectSize = 10000 * maxObjectSize
 )
 
 type ossCredentials struct {
@@ -190,7 +195,7 @@ func (ossDriver *ArtifactDriver) OpenStream(inputArtifact *wfv1.Artifact) (io.Re
 				stream = s
 				return true, nil
 			}
-			if !IsOssErrCode(err, "NoSuchKey") {
+			if !IsOssErrCode(origErr, "NoSuchKey") {
 				return !isTransientOSSErr(origErr), fmt.Errorf("failed to get file: %w", origErr)
 			}
 			isDir, err := IsOssDirectory(bucket, inputArtifact.OSS.Key)
@@ -198,11 +203,14 @@ func (ossDriver *ArtifactDriver) OpenStream(inputArtifact *wfv1.Artifact) (io.Re
 				return !isTransientOSSErr(err), fmt.Errorf("failed to test if %s/%s is a directory: %w", bucketName, inputArtifact.OSS.Key, err)
 			}
 			if !isDir {
-				return false, origErr
+				return false, errors.New(errors.CodeNotFound, origErr.Error())
 			}
-			// directory case:
-			// todo: make a .tgz file which can be streamed to user
-			return false, errors.New(errors.CodeNotImplemented, "Directory Stream capability currently unimplemented for OSS")
+			s, err = streamOssDirectory(bucket, inputArtifact.OSS.Key)
+			if err != nil {
+				return !isTransientOSSErr(err), fmt.Errorf("failed to stream directory %s/%s: %w", bucketName, inputArtifact.OSS.Key, err)
+			}
+			stream = s
+			return true, nil
 		})
 	return stream, err
 }
@@ -294,6 +302,33 @@ func (ossDriver *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
 	return err
 }
 
+// DeletePrefix deletes every object whose key starts with the key of the artifact, with batch deletes
+func (ossDriver *ArtifactDriver) DeletePrefix(artifact *wfv1.Artifact) error {
+	err := waitutil.Backoff(defaultRetry,
+		func() (bool, error) {
+			log.Infof("OSS Delete prefix: key: %s", artifact.OSS.Key)
+			osscli, err := ossDriver.newOSSClient()
+			if err != nil {
+				return !isTransientOSSErr(err), err
+			}
+			bucketName := artifact.OSS.Bucket
+			err = setBucketLogging(osscli, bucketName)
+			if err != nil {
+				return !isTransientOSSErr(err), err
+			}
+			bucket, err := osscli.Bucket(bucketName)
+			if err != nil {
+				return !isTransientOSSErr(err), err
+			}
+			err = deleteOssPrefix(bucket, artifact.OSS.Key)
+			if err != nil {
+				return !isTransientOSSErr(err), err
+			}
+			return true, nil
+		})
+	return err
+}
+
 func (ossDriver *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
 	var files []string
 	err := waitutil.Backoff(defaultRetry,
@@ -568,6 +603,15 @@ func GetOssDirectory(bucket *oss.Bucket, objectName, path string) error {
 
 // ListOssDirectory lists all the files which are the descendants of the specified objectKey, if a file has suffix '/', then it is an OSS directory
 func ListOssDirectory(bucket *oss.Bucket, objectKey string) (files []string, err error) {
+	objects, err := listOssObjects(bucket, objectKey)
+	for _, obj := range objects {
+		files = append(files, obj.Key)
+	}
+	return files, err
+}
+
+// listOssObjects lists all the objects which are the descendants of the specified objectKey
+func listOssObjects(bucket *oss.Bucket, objectKey string) (objects []oss.ObjectProperties, err error) {
 	if objectKey != "" {
 		if !strings.HasSuffix(objectKey, "/") {
 			objectKey += "/"
@@ -580,18 +624,90 @@ func ListOssDirectory(bucket *oss.Bucket, objectKey string) (files []string, err
 		lor, err := bucket.ListObjects(marker, pre)
 		if err != nil {
 			log.Warnf("oss list object(%s) error: %v", objectKey, err)
-			return files, err
-		}
-		for _, obj := range lor.Objects {
-			files = append(files, obj.Key)
+			return objects, err
 		}
+		objects = append(objects, lor.Objects...)
 
 		marker = oss.Marker(lor.NextMarker)
 		if !lor.IsTruncated {
 			break
 		}
 	}
-	return files, nil
+	return objects, nil
+}
+
+// deleteOssPrefix deletes the objects whose keys start with the prefix, a page of up to 1000 keys at a time, each with
+// a single DeleteObjects request
+func deleteOssPrefix(bucket *oss.Bucket, prefix string) error {
+	marker := oss.Marker("")
+	for {
+		lor, err := bucket.ListObjects(marker, oss.Prefix(prefix), oss.MaxKeys(1000))
+		if err != nil {
+			return err
+		}
+		var keys []string
+		for _, obj := range lor.Objects {
+			keys = append(keys, obj.Key)
+		}
+		if len(keys) > 0 {
+			if _, err := bucket.DeleteObjects(keys, oss.DeleteObjectsQuiet(true)); err != nil {
+				return err
+			}
+		}
+		marker = oss.Marker(lor.NextMarker)
+		if !lor.IsTruncated {
+			return nil
+		}
+	}
+}
+
+// streamOssDirectory streams an OSS "directory" as a tarball, which is laid out like the tarball of a directory that
+// is archived by the executor
+func streamOssDirectory(bucket *oss.Bucket, objectName string) (io.ReadCloser, error) {
+	objects, err := listOssObjects(bucket, objectName)
+	if err != nil {
+		return nil, err
+	}
+	r, w := io.Pipe()
+	go func() {
+		_ = w.CloseWithError(tarOssObjects(w, objectName, objects, func(key string) (io.ReadCloser, error) {
+			return bucket.GetObject(key)
+		}))
+	}()
+	return r, nil
+}
+
+// tarOssObjects writes the objects of the OSS "directory" as a tar.gz to w, getting their content with getObject
+func tarOssObjects(w io.Writer, objectName string, objects []oss.ObjectProperties, getObject func(key string) (io.ReadCloser, error)) error {
+	prefix := strings.TrimSuffix(objectName, "/")
+	baseName := path.Base(prefix)
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	for _, obj := range objects {
+		name := path.Join(baseName, strings.TrimPrefix(obj.Key, prefix+"/"))
+		if strings.HasSuffix(obj.Key, "/") {
+			if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: 0o755, ModTime: obj.LastModified}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Size: obj.Size, Mode: 0o644, ModTime: obj.LastModified}); err != nil {
+			return err
+		}
+		body, err := getObject(obj.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get object %s: %w", obj.Key, err)
+		}
+		_, err = io.Copy(tw, body)
+		_ = body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to archive object %s: %w", obj.Key, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
 }
 
 // IsDirectory tests if the key is acting like a OSS directory
@@ -612,3 +728,8 @@ func (ossDriver *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, err
 	}
 	return isDir, nil
 }
+
+// Capabilities returns the optional operations that OSS supports
+func (ossDriver *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{Delete: true, ListObjects: true, MaxObjectSize: maxMultipartObjectSize, PrefixDelete: true}
+}
@@ -1,11 +1,25 @@
 package oss
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 func TestIsTransientOSSErr(t *testing.T) {
@@ -22,3 +36,118 @@ func TestIsTransientOSSErr(t *testing.T) {
 
 	assert.False(t, isTransientOSSErr(nil))
 }
+
+func TestTarOssObjects(t *testing.T) {
+	contents := map[string]string{
+		"my-wf/reports/index.html":     "<html></html>",
+		"my-wf/reports/data/1.json":    `{"a": 1}`,
+		"my-wf/reports/data/empty.txt": "",
+	}
+	objects := []oss.ObjectProperties{
+		{Key: "my-wf/reports/index.html", Size: 13, LastModified: time.Now()},
+		{Key: "my-wf/reports/data/", LastModified: time.Now()},
+		{Key: "my-wf/reports/data/1.json", Size: 8, LastModified: time.Now()},
+		{Key: "my-wf/reports/data/empty.txt", LastModified: time.Now()},
+	}
+	buf := &bytes.Buffer{}
+	err := tarOssObjects(buf, "my-wf/reports/", objects, func(key string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(contents[key])), nil
+	})
+	require.NoError(t, err)
+
+	gzr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gzr)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(data)
+	}
+	assert.Equal(t, map[string]string{
+		"reports/index.html":     "<html></html>",
+		"reports/data/":          "",
+		"reports/data/1.json":    `{"a": 1}`,
+		"reports/data/empty.txt": "",
+	}, files)
+
+	t.Run("GetObjectError", func(t *testing.T) {
+		err := tarOssObjects(io.Discard, "my-wf/reports", objects, func(key string) (io.ReadCloser, error) {
+			return nil, oss.ServiceError{Code: "NoSuchKey"}
+		})
+		assert.ErrorContains(t, err, "failed to get object my-wf/reports/index.html")
+	})
+}
+
+// fakeOssServer lists the keys of my-bucket a page of max-keys at a time, and records the keys of each batch delete
+func fakeOssServer(t *testing.T, keys []string) (*httptest.Server, *[][]string) {
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/my-bucket/":
+			type contents struct{ Key string }
+			result := struct {
+				XMLName     xml.Name `xml:"ListBucketResult"`
+				Prefix      string
+				IsTruncated bool
+				NextMarker  string
+				Contents    []contents
+			}{Prefix: query.Get("prefix")}
+			maxKeys, err := strconv.Atoi(query.Get("max-keys"))
+			assert.NoError(t, err)
+			for _, key := range keys {
+				if !strings.HasPrefix(key, result.Prefix) || key <= query.Get("marker") {
+					continue
+				}
+				if len(result.Contents) == maxKeys {
+					result.IsTruncated = true
+					break
+				}
+				result.Contents = append(result.Contents, contents{key})
+				result.NextMarker = key
+			}
+			assert.NoError(t, xml.NewEncoder(w).Encode(result))
+		case r.Method == http.MethodPost && query.Has("delete"):
+			var req struct {
+				Quiet  bool
+				Object []struct{ Key string }
+			}
+			assert.NoError(t, xml.NewDecoder(r.Body).Decode(&req))
+			assert.True(t, req.Quiet)
+			var batch []string
+			for _, o := range req.Object {
+				batch = append(batch, o.Key)
+			}
+			batches = append(batches, batch)
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &batches
+}
+
+func TestDeletePrefix(t *testing.T) {
+	keys := []string{"argo-ephemeral/other-uid/kept.tgz"}
+	for i := range 1001 {
+		keys = append(keys, fmt.Sprintf("argo-ephemeral/my-uid/pod-%04d/intermediate.tgz", i))
+	}
+	server, batches := fakeOssServer(t, keys)
+	ossDriver := &ArtifactDriver{Endpoint: server.URL, AccessKey: "key", SecretKey: "secret"}
+	assert.True(t, ossDriver.Capabilities().PrefixDelete)
+
+	err := ossDriver.DeletePrefix(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{OSS: &wfv1.OSSArtifact{
+		OSSBucket: wfv1.OSSBucket{Bucket: "my-bucket"},
+		Key:       "argo-ephemeral/my-uid/",
+	}}})
+	require.NoError(t, err)
+	require.Len(t, *batches, 2, "the keys are deleted a page of 1000 at a time")
+	assert.Len(t, (*batches)[0], 1000)
+	assert.Equal(t, []string{"argo-ephemeral/my-uid/pod-1000/intermediate.tgz"}, (*batches)[1])
+}
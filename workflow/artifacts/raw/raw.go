@@ -49,3 +49,8 @@ func (a *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error)
 func (a *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
 	return false, errors.New(errors.CodeNotImplemented, "IsDirectory currently unimplemented for raw")
 }
+
+// Capabilities returns the optional operations that raw artifacts support, which are none
+func (a *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{}
+}
@@ -1,9 +1,15 @@
 package s3
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/argoproj/argo-workflows/v3/util/errors"
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 )
 
 // s3TransientErrorCodes is a list of S3 error codes that are transient (retryable)
@@ -20,6 +26,36 @@ var s3TransientErrorCodes = []string{
 	"ServiceUnavailable",
 }
 
+// s3ErrorKinds are the kinds of artifact driver error of S3 error codes
+// Reference: https://docs.aws.amazon.com/AmazonS3/latest/API/ErrorResponses.html
+var s3ErrorKinds = map[string]artifactscommon.ErrorKind{
+	"NoSuchKey":             artifactscommon.ErrorKindNotFound,
+	"NoSuchBucket":          artifactscommon.ErrorKindNotFound,
+	"AccessDenied":          artifactscommon.ErrorKindForbidden,
+	"InvalidAccessKeyId":    artifactscommon.ErrorKindForbidden,
+	"SignatureDoesNotMatch": artifactscommon.ErrorKindForbidden,
+	"EntityTooLarge":        artifactscommon.ErrorKindTooLarge,
+	"Throttling":            artifactscommon.ErrorKindThrottled,
+	"ThrottlingException":   artifactscommon.ErrorKindThrottled,
+	"RequestLimitExceeded":  artifactscommon.ErrorKindThrottled,
+	"RequestThrottled":      artifactscommon.ErrorKindThrottled,
+	"SlowDown":              artifactscommon.ErrorKindThrottled,
+	// Ceph returns QuotaExceeded when a bucket is over its quota, MinIO returns the others when it or a bucket is full
+	"QuotaExceeded":                  artifactscommon.ErrorKindQuotaExceeded,
+	"XMinioStorageFull":              artifactscommon.ErrorKindQuotaExceeded,
+	"XMinioAdminBucketQuotaExceeded": artifactscommon.ErrorKindQuotaExceeded,
+}
+
+// s3StatusErrorKinds are the kinds of artifact driver error of the HTTP status codes of S3 errors with codes that are
+// not in s3ErrorKinds, e.g. those of S3 compatible storage
+var s3StatusErrorKinds = map[int]artifactscommon.ErrorKind{
+	http.StatusNotFound:              artifactscommon.ErrorKindNotFound,
+	http.StatusForbidden:             artifactscommon.ErrorKindForbidden,
+	http.StatusRequestEntityTooLarge: artifactscommon.ErrorKindTooLarge,
+	http.StatusTooManyRequests:       artifactscommon.ErrorKindThrottled,
+	http.StatusInsufficientStorage:   artifactscommon.ErrorKindQuotaExceeded,
+}
+
 // isTransientS3Err checks if an minio.ErrorResponse error is transient (retryable)
 func isTransientS3Err(err error) bool {
 	if err == nil {
@@ -31,5 +67,31 @@ func isTransientS3Err(err error) bool {
 			return true
 		}
 	}
-	return errors.IsTransientErr(err)
+	return errorsutil.IsTransientErr(err)
+}
+
+// s3ErrorKind returns the kind of artifact driver error of the S3 error, or an empty string if it has no kind
+func s3ErrorKind(err error) artifactscommon.ErrorKind {
+	var resp minio.ErrorResponse
+	if errors.As(err, &resp) {
+		if kind, ok := s3ErrorKinds[resp.Code]; ok {
+			return kind
+		}
+		if kind, ok := s3StatusErrorKinds[resp.StatusCode]; ok {
+			return kind
+		}
+	}
+	if isTransientS3Err(err) {
+		return artifactscommon.ErrorKindTransient
+	}
+	return ""
+}
+
+// wrapS3Err wraps the S3 error with the message, in an artifact driver error if the S3 error has a kind
+func wrapS3Err(err error, message string) error {
+	wrapped := fmt.Errorf("%s: %w", message, err)
+	if kind := s3ErrorKind(err); kind != "" {
+		return artifactscommon.NewDriverError(kind, wrapped)
+	}
+	return wrapped
 }
@@ -2,10 +2,15 @@ package s3
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
 )
 
 func TestIsTransientOSSErr(t *testing.T) {
@@ -23,3 +28,20 @@ func TestIsTransientOSSErr(t *testing.T) {
 	requestErr := minio.ErrorResponse{Code: "RequestError"}
 	assert.True(t, isTransientS3Err(requestErr))
 }
+
+func TestS3ErrorKind(t *testing.T) {
+	assert.Equal(t, artifactscommon.ErrorKindNotFound, s3ErrorKind(minio.ErrorResponse{Code: "NoSuchKey"}))
+	assert.Equal(t, artifactscommon.ErrorKindForbidden, s3ErrorKind(minio.ErrorResponse{Code: "AccessDenied"}))
+	assert.Equal(t, artifactscommon.ErrorKindThrottled, s3ErrorKind(minio.ErrorResponse{Code: "SlowDown"}))
+	assert.Equal(t, artifactscommon.ErrorKindTransient, s3ErrorKind(minio.ErrorResponse{Code: "InternalError"}))
+	assert.Equal(t, artifactscommon.ErrorKindTooLarge, s3ErrorKind(minio.ErrorResponse{Code: "EntityTooLarge"}))
+	assert.Equal(t, artifactscommon.ErrorKindForbidden, s3ErrorKind(minio.ErrorResponse{Code: "Unknown", StatusCode: http.StatusForbidden}))
+	assert.Equal(t, artifactscommon.ErrorKindQuotaExceeded, s3ErrorKind(minio.ErrorResponse{Code: "XMinioAdminBucketQuotaExceeded", StatusCode: http.StatusBadRequest}))
+	assert.Equal(t, artifactscommon.ErrorKindQuotaExceeded, s3ErrorKind(minio.ErrorResponse{Code: "Unknown", StatusCode: http.StatusInsufficientStorage}))
+	assert.Equal(t, artifactscommon.ErrorKind(""), s3ErrorKind(errors.New("UnseenError")))
+
+	err := wrapS3Err(minio.ErrorResponse{Code: "AccessDenied", Message: "Access Denied."}, "failed to put file")
+	require.EqualError(t, err, "failed to put file: Access Denied.")
+	assert.Equal(t, artifactscommon.ErrorKindForbidden, artifactscommon.ErrorKindOf(err))
+	assert.True(t, argoerrs.IsCode(argoerrs.CodeForbidden, err))
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"os"
 	"path"
@@ -19,6 +20,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/signer"
 	"github.com/minio/minio-go/v7/pkg/sse"
 
 	"github.com/minio/minio-go/v7"
@@ -36,6 +38,16 @@ import (
 
 const nullIAMEndpoint = ""
 
+// requestPayerHeader is the header that accepts the charges of requests to a requester pays bucket
+const requestPayerHeader = "x-amz-request-payer"
+
+// maxObjectSize is the size of the largest object that S3 can store, 5 TiB
+const maxObjectSize = int64(5) << 40
+
+// streamPartSize is the size of the parts of streams, whose size is unknown, that are put with multipart uploads. Each
+// part is buffered in memory, and an upload has at most 10000 parts, so streams can be at most 640 GiB.
+const streamPartSize = uint64(64) << 20
+
 type S3Client interface {
 	// PutFile puts a single file to a bucket at the specified key
 	PutFile(bucket, key, path string) error
@@ -44,18 +56,30 @@ type S3Client interface {
 	// a separate key in the bucket.
 	PutDirectory(bucket, key, path string) error
 
+	// PutStream puts the content of a reader of unknown size to a bucket at the specified key
+	PutStream(bucket, key string, r io.Reader) error
+
 	// GetFile downloads a file to a local file path
 	GetFile(bucket, key, path string) error
 
 	// OpenFile opens a file for much lower disk and memory usage that GetFile
 	OpenFile(bucket, key string) (io.ReadCloser, error)
 
+	// SelectFile opens the records of a file that match an S3 Select query
+	SelectFile(bucket, key string, query *wfv1.S3Select) (io.ReadCloser, error)
+
 	// KeyExists checks if object exists (and if we have permission to access)
 	KeyExists(bucket, key string) (bool, error)
 
+	// ETag returns the entity tag of the object
+	ETag(bucket, key string) (string, error)
+
 	// Delete deletes the key from the bucket
 	Delete(bucket, key string) error
 
+	// DeletePrefix deletes every key that starts with the prefix from the bucket, in batches
+	DeletePrefix(bucket, keyPrefix string) error
+
 	// GetDirectory downloads a directory to a local file path
 	GetDirectory(bucket, key, path string) error
 
@@ -103,6 +127,13 @@ type S3ClientOpts struct {
 	UseSDKCreds     bool
 	EncryptOpts     EncryptOpts
 	SendContentMd5  bool
+	// StorageClass and ACL are applied to the objects that are put
+	StorageClass string
+	ACL          string
+	// Progress is called with the number of bytes of objects uploaded, as they are uploaded
+	Progress func(n int64)
+	// RequesterPays accepts the charges of the requests to requester pays buckets, including those of uploads
+	RequesterPays bool
 }
 
 type s3client struct {
@@ -129,9 +160,23 @@ type ArtifactDriver struct {
 	KmsEncryptionContext  string
 	EnableEncryption      bool
 	ServerSideCustomerKey string
+	StorageClass          string
+	ACL                   string
+	Progress              func(n int64)
+	RequesterPays         bool
 }
 
-var _ artifactscommon.ArtifactDriver = &ArtifactDriver{}
+var (
+	_ artifactscommon.ArtifactDriver = &ArtifactDriver{}
+	_ artifactscommon.RangeReader    = &ArtifactDriver{}
+	_ artifactscommon.StreamSaver    = &ArtifactDriver{}
+)
+
+// SetProgress sets the function called with the number of bytes uploaded by Save
+func (s3Driver *ArtifactDriver) SetProgress(progress func(n int64)) bool {
+	s3Driver.Progress = progress
+	return true
+}
 
 // newS3Client instantiates a new S3 client object.
 func (s3Driver *ArtifactDriver) newS3Client(ctx context.Context) (S3Client, error) {
@@ -152,6 +197,10 @@ func (s3Driver *ArtifactDriver) newS3Client(ctx context.Context) (S3Client, erro
 			ServerSideCustomerKey: s3Driver.ServerSideCustomerKey,
 		},
 		SendContentMd5: true,
+		StorageClass:   s3Driver.StorageClass,
+		ACL:            s3Driver.ACL,
+		Progress:       s3Driver.Progress,
+		RequesterPays:  s3Driver.RequesterPays,
 	}
 
 	if tr, err := GetDefaultTransport(opts); err == nil {
@@ -189,29 +238,55 @@ func (s3Driver *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string)
 // returns true if the download is completed or can't be retried (non-transient error)
 // returns false if it can be retried (transient error)
 func loadS3Artifact(s3cli S3Client, inputArtifact *wfv1.Artifact, path string) (bool, error) {
+	if inputArtifact.S3.Select != nil {
+		return selectS3Artifact(s3cli, inputArtifact, path)
+	}
 	origErr := s3cli.GetFile(inputArtifact.S3.Bucket, inputArtifact.S3.Key, path)
 	if origErr == nil {
 		return true, nil
 	}
 	if !IsS3ErrCode(origErr, "NoSuchKey") {
-		return !isTransientS3Err(origErr), fmt.Errorf("failed to get file: %v", origErr)
+		return !isTransientS3Err(origErr), wrapS3Err(origErr, "failed to get file")
 	}
 	// If we get here, the error was a NoSuchKey. The key might be an s3 "directory"
 	isDir, err := s3cli.IsDirectory(inputArtifact.S3.Bucket, inputArtifact.S3.Key)
 	if err != nil {
-		return !isTransientS3Err(err), fmt.Errorf("failed to test if %s is a directory: %v", inputArtifact.S3.Key, err)
+		return !isTransientS3Err(err), wrapS3Err(err, fmt.Sprintf("failed to test if %s is a directory", inputArtifact.S3.Key))
 	}
 	if !isDir {
 		// It's neither a file, nor a directory. Return the original NoSuchKey error
-		return true, argoerrs.New(argoerrs.CodeNotFound, origErr.Error())
+		return true, artifactscommon.NewDriverError(artifactscommon.ErrorKindNotFound, origErr)
 	}
 
 	if err = s3cli.GetDirectory(inputArtifact.S3.Bucket, inputArtifact.S3.Key, path); err != nil {
-		return !isTransientS3Err(err), fmt.Errorf("failed to get directory: %v", err)
+		return !isTransientS3Err(err), wrapS3Err(err, "failed to get directory")
 	}
 	return true, nil
 }
 
+// selectS3Artifact downloads the records of an artifact that match its S3 Select query
+// returns true if the download is completed or can't be retried (non-transient error)
+// returns false if it can be retried (transient error)
+func selectS3Artifact(s3cli S3Client, inputArtifact *wfv1.Artifact, path string) (bool, error) {
+	stream, err := s3cli.SelectFile(inputArtifact.S3.Bucket, inputArtifact.S3.Key, inputArtifact.S3.Select)
+	if err != nil {
+		if IsS3ErrCode(err, "NoSuchKey") {
+			return true, artifactscommon.NewDriverError(artifactscommon.ErrorKindNotFound, err)
+		}
+		return !isTransientS3Err(err), wrapS3Err(err, "failed to select from file")
+	}
+	defer func() { _ = stream.Close() }()
+	f, err := os.Create(path)
+	if err != nil {
+		return true, fmt.Errorf("failed to create %s: %v", path, err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := io.Copy(f, stream); err != nil {
+		return !isTransientS3Err(err), wrapS3Err(err, "failed to select from file")
+	}
+	return true, f.Close()
+}
+
 // OpenStream opens a stream reader for an artifact from S3 compliant storage
 func (s3Driver *ArtifactDriver) OpenStream(inputArtifact *wfv1.Artifact) (io.ReadCloser, error) {
 	log.Infof("S3 OpenStream: key: %s", inputArtifact.S3.Key)
@@ -225,27 +300,63 @@ func (s3Driver *ArtifactDriver) OpenStream(inputArtifact *wfv1.Artifact) (io.Rea
 }
 
 func streamS3Artifact(s3cli S3Client, inputArtifact *wfv1.Artifact) (io.ReadCloser, error) {
+	if inputArtifact.S3.Select != nil {
+		stream, err := s3cli.SelectFile(inputArtifact.S3.Bucket, inputArtifact.S3.Key, inputArtifact.S3.Select)
+		if err != nil {
+			if IsS3ErrCode(err, "NoSuchKey") {
+				return nil, artifactscommon.NewDriverError(artifactscommon.ErrorKindNotFound, err)
+			}
+			return nil, wrapS3Err(err, "failed to select from file")
+		}
+		return stream, nil
+	}
 	stream, origErr := s3cli.OpenFile(inputArtifact.S3.Bucket, inputArtifact.S3.Key)
 	if origErr == nil {
 		return stream, nil
 	}
 	if !IsS3ErrCode(origErr, "NoSuchKey") {
-		return nil, fmt.Errorf("failed to get file: %v", origErr)
+		return nil, wrapS3Err(origErr, "failed to get file")
 	}
 	// If we get here, the error was a NoSuchKey. The key might be an s3 "directory"
 	isDir, err := s3cli.IsDirectory(inputArtifact.S3.Bucket, inputArtifact.S3.Key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to test if %s is a directory: %v", inputArtifact.S3.Key, err)
+		return nil, wrapS3Err(err, fmt.Sprintf("failed to test if %s is a directory", inputArtifact.S3.Key))
 	}
 	if !isDir {
 		// It's neither a file, nor a directory. Return the original NoSuchKey error
-		return nil, argoerrs.New(argoerrs.CodeNotFound, origErr.Error())
+		return nil, artifactscommon.NewDriverError(artifactscommon.ErrorKindNotFound, origErr)
 	}
 	// directory case:
 	// todo: make a .tgz file which can be streamed to user
 	return nil, argoerrs.New(argoerrs.CodeNotImplemented, "Directory Stream capability currently unimplemented for S3")
 }
 
+// OpenSeekableStream opens the object of the artifact, for reading from any offset. The results of S3 Select queries
+// cannot be read from an offset.
+func (s3Driver *ArtifactDriver) OpenSeekableStream(inputArtifact *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	s3cli, err := s3Driver.newS3Client(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new S3 client: %v", err)
+	}
+	return seekableStreamS3Artifact(s3cli, inputArtifact)
+}
+
+func seekableStreamS3Artifact(s3cli S3Client, inputArtifact *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	if inputArtifact.S3.Select != nil {
+		return nil, argoerrs.New(argoerrs.CodeNotImplemented, "ranged reads of S3 Select results are not supported")
+	}
+	stream, err := streamS3Artifact(s3cli, inputArtifact)
+	if err != nil {
+		return nil, err
+	}
+	// minio objects are seekable, and read the object from the offset that they are seeked to
+	if s, ok := stream.(io.ReadSeekCloser); ok {
+		return s, nil
+	}
+	_ = stream.Close()
+	return nil, argoerrs.New(argoerrs.CodeNotImplemented, "ranged reads are not supported by the S3 client")
+}
+
 // Save saves an artifact to S3 compliant storage
 func (s3Driver *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -263,6 +374,20 @@ func (s3Driver *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact)
 	return err
 }
 
+// SaveStream saves the content of the reader to S3 compliant storage with a multipart upload. It is not retried, as
+// the content cannot be read again.
+func (s3Driver *ArtifactDriver) SaveStream(r io.Reader, outputArtifact *wfv1.Artifact) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log.Infof("S3 Save stream, key: %s", outputArtifact.S3.Key)
+	s3cli, err := s3Driver.newS3Client(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create new S3 client: %v", err)
+	}
+	return saveS3Stream(s3cli, r, outputArtifact)
+}
+
 // Delete deletes an artifact from an S3 compliant storage
 func (s3Driver *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -282,7 +407,7 @@ func (s3Driver *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
 
 		keys, err := s3cli.ListDirectory(artifact.S3.Bucket, artifact.S3.Key)
 		if err != nil {
-			return fmt.Errorf("unable to list files in %s: %s", artifact.S3.Key, err)
+			return fmt.Errorf("unable to list files in %s: %w", artifact.S3.Key, err)
 		}
 		for _, objKey := range keys {
 			err = s3cli.Delete(artifact.S3.Bucket, objKey)
@@ -292,8 +417,10 @@ func (s3Driver *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
 		}
 		return nil
 	})
-
-	return err
+	if err != nil {
+		return wrapS3Err(err, fmt.Sprintf("failed to delete %s", artifact.S3.Key))
+	}
+	return nil
 }
 
 // saveS3Artifact uploads artifacts to an S3 compliant storage
@@ -305,35 +432,54 @@ func saveS3Artifact(s3cli S3Client, path string, outputArtifact *wfv1.Artifact)
 		return true, fmt.Errorf("failed to test if %s is a directory: %v", path, err)
 	}
 
-	createBucketIfNotPresent := outputArtifact.S3.CreateBucketIfNotPresent
-	if createBucketIfNotPresent != nil {
-		log.WithField("bucket", outputArtifact.S3.Bucket).Info("creating bucket")
-		err := s3cli.MakeBucket(outputArtifact.S3.Bucket, minio.MakeBucketOptions{
-			Region:        outputArtifact.S3.Region,
-			ObjectLocking: outputArtifact.S3.CreateBucketIfNotPresent.ObjectLocking,
-		})
-		alreadyExists := bucketAlreadyExistsErr(err)
-		log.WithField("bucket", outputArtifact.S3.Bucket).
-			WithField("alreadyExists", alreadyExists).
-			WithError(err).
-			Info("create bucket failed")
-		if err != nil && !alreadyExists {
-			return !isTransientS3Err(err), fmt.Errorf("failed to create bucket %s: %v", outputArtifact.S3.Bucket, err)
-		}
+	if err := makeBucketIfNotPresent(s3cli, outputArtifact); err != nil {
+		return !isTransientS3Err(err), wrapS3Err(err, "failed to create bucket "+outputArtifact.S3.Bucket)
 	}
 
 	if isDir {
 		if err = s3cli.PutDirectory(outputArtifact.S3.Bucket, outputArtifact.S3.Key, path); err != nil {
-			return !isTransientS3Err(err), fmt.Errorf("failed to put directory: %v", err)
+			return !isTransientS3Err(err), wrapS3Err(err, "failed to put directory")
 		}
 	} else {
 		if err = s3cli.PutFile(outputArtifact.S3.Bucket, outputArtifact.S3.Key, path); err != nil {
-			return !isTransientS3Err(err), fmt.Errorf("failed to put file: %v", err)
+			return !isTransientS3Err(err), wrapS3Err(err, "failed to put file")
 		}
 	}
 	return true, nil
 }
 
+// saveS3Stream uploads the content of the reader to an S3 compliant storage
+func saveS3Stream(s3cli S3Client, r io.Reader, outputArtifact *wfv1.Artifact) error {
+	if err := makeBucketIfNotPresent(s3cli, outputArtifact); err != nil {
+		return wrapS3Err(err, "failed to create bucket "+outputArtifact.S3.Bucket)
+	}
+	if err := s3cli.PutStream(outputArtifact.S3.Bucket, outputArtifact.S3.Key, r); err != nil {
+		return wrapS3Err(err, "failed to put stream")
+	}
+	return nil
+}
+
+// makeBucketIfNotPresent creates the bucket of the artifact if it should be created, and it does not exist
+func makeBucketIfNotPresent(s3cli S3Client, outputArtifact *wfv1.Artifact) error {
+	if outputArtifact.S3.CreateBucketIfNotPresent == nil {
+		return nil
+	}
+	log.WithField("bucket", outputArtifact.S3.Bucket).Info("creating bucket")
+	err := s3cli.MakeBucket(outputArtifact.S3.Bucket, minio.MakeBucketOptions{
+		Region:        outputArtifact.S3.Region,
+		ObjectLocking: outputArtifact.S3.CreateBucketIfNotPresent.ObjectLocking,
+	})
+	alreadyExists := bucketAlreadyExistsErr(err)
+	log.WithField("bucket", outputArtifact.S3.Bucket).
+		WithField("alreadyExists", alreadyExists).
+		WithError(err).
+		Info("create bucket failed")
+	if err != nil && !alreadyExists {
+		return err
+	}
+	return nil
+}
+
 func bucketAlreadyExistsErr(err error) bool {
 	resp := &minio.ErrorResponse{}
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/ErrorResponses.html
@@ -368,22 +514,40 @@ func listObjects(s3cli S3Client, artifact *wfv1.Artifact) (bool, []string, error
 	var files []string
 	files, err := s3cli.ListDirectory(artifact.S3.Bucket, artifact.S3.Key)
 	if err != nil {
-		return !isTransientS3Err(err), files, fmt.Errorf("failed to list directory: %v", err)
+		return !isTransientS3Err(err), files, wrapS3Err(err, "failed to list directory")
 	}
 	log.Debugf("successfully listing S3 directory associated with bucket: %s and key %s: %v", artifact.S3.Bucket, artifact.S3.Key, files)
 
 	if len(files) == 0 {
 		directoryExists, err := s3cli.KeyExists(artifact.S3.Bucket, artifact.S3.Key)
 		if err != nil {
-			return !isTransientS3Err(err), files, fmt.Errorf("failed to check if key %s exists from bucket %s: %v", artifact.S3.Key, artifact.S3.Bucket, err)
+			return !isTransientS3Err(err), files, wrapS3Err(err, fmt.Sprintf("failed to check if key %s exists from bucket %s", artifact.S3.Key, artifact.S3.Bucket))
 		}
 		if !directoryExists {
-			return true, files, argoerrs.New(argoerrs.CodeNotFound, fmt.Sprintf("no key found of name %s", artifact.S3.Key))
+			return true, files, artifactscommon.NewDriverError(artifactscommon.ErrorKindNotFound, fmt.Errorf("no key found of name %s", artifact.S3.Key))
 		}
 	}
 	return true, files, nil
 }
 
+// Exists returns whether the artifact's key exists
+func (s3Driver *ArtifactDriver) Exists(artifact *wfv1.Artifact) (bool, error) {
+	s3cli, err := s3Driver.newS3Client(context.TODO())
+	if err != nil {
+		return false, err
+	}
+	return s3cli.KeyExists(artifact.S3.Bucket, artifact.S3.Key)
+}
+
+// ETag returns the entity tag of the artifact's key
+func (s3Driver *ArtifactDriver) ETag(artifact *wfv1.Artifact) (string, error) {
+	s3cli, err := s3Driver.newS3Client(context.TODO())
+	if err != nil {
+		return "", err
+	}
+	return s3cli.ETag(artifact.S3.Bucket, artifact.S3.Key)
+}
+
 func (s3Driver *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
 	s3cli, err := s3Driver.newS3Client(context.TODO())
 	if err != nil {
@@ -392,6 +556,30 @@ func (s3Driver *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, erro
 	return s3cli.IsDirectory(artifact.S3.Bucket, artifact.S3.Key)
 }
 
+// DeletePrefix deletes every object whose key starts with the key of the artifact, with batch deletes
+func (s3Driver *ArtifactDriver) DeletePrefix(artifact *wfv1.Artifact) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := retry.OnError(retry.DefaultBackoff, isTransientS3Err, func() error {
+		log.Infof("S3 Delete prefix: key: %s", artifact.S3.Key)
+		s3cli, err := s3Driver.newS3Client(ctx)
+		if err != nil {
+			return err
+		}
+		return s3cli.DeletePrefix(artifact.S3.Bucket, artifact.S3.Key)
+	})
+	if err != nil {
+		return wrapS3Err(err, fmt.Sprintf("failed to delete prefix %s", artifact.S3.Key))
+	}
+	return nil
+}
+
+// Capabilities returns the optional operations that S3 supports
+func (s3Driver *ArtifactDriver) Capabilities() artifactscommon.Capabilities {
+	return artifactscommon.Capabilities{Delete: true, ListObjects: true, RangedReads: true, StreamingSaves: true, MaxObjectSize: maxObjectSize, PrefixDelete: true}
+}
+
 // Get AWS credentials based on default order from aws SDK
 func GetAWSCredentials(opts S3ClientOpts) (*credentials.Credentials, error) {
 	ctx := context.Background()
@@ -478,6 +666,15 @@ func NewS3Client(ctx context.Context, opts S3ClientOpts) (S3Client, error) {
 		bucketLookupType = minio.BucketLookupAuto
 	}
 	minioOpts := &minio.Options{Creds: credentials, Secure: s3cli.Secure, Transport: opts.Transport, Region: s3cli.Region, BucketLookup: bucketLookupType}
+	if opts.RequesterPays {
+		transport := opts.Transport
+		if transport == nil {
+			if transport, err = minio.DefaultTransport(opts.Secure); err != nil {
+				return nil, err
+			}
+		}
+		minioOpts.Transport = &requestPayerTransport{RoundTripper: transport, creds: credentials}
+	}
 	minioClient, err = minio.New(s3cli.Endpoint, minioOpts)
 	if err != nil {
 		return nil, err
@@ -511,13 +708,104 @@ func (s *s3client) PutFile(bucket, key, path string) error {
 		return err
 	}
 
-	_, err = s.minioClient.FPutObject(s.ctx, bucket, key, path, minio.PutObjectOptions{SendContentMd5: s.SendContentMd5, ServerSideEncryption: encOpts})
+	_, err = s.minioClient.FPutObject(s.ctx, bucket, key, path, s.putObjectOptions(encOpts))
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// PutStream puts the content of the reader to a bucket at the specified key, with a multipart upload, as its size is
+// unknown
+func (s *s3client) PutStream(bucket, key string, r io.Reader) error {
+	log.WithFields(log.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info("Saving stream to s3")
+	encOpts, err := s.EncryptOpts.buildServerSideEnc(bucket, key)
+	if err != nil {
+		return err
+	}
+	opts := s.putObjectOptions(encOpts)
+	opts.PartSize = streamPartSize
+	// minio only detects the mime-type of files
+	opts.ContentType = mime.TypeByExtension(path.Ext(key))
+	_, err = s.minioClient.PutObject(s.ctx, bucket, key, r, -1, opts)
+	return err
+}
+
+// putObjectOptions returns the options to put objects with
+func (s *s3client) putObjectOptions(encOpts encrypt.ServerSide) minio.PutObjectOptions {
+	opts := minio.PutObjectOptions{SendContentMd5: s.SendContentMd5, ServerSideEncryption: encOpts, StorageClass: s.StorageClass}
+	// minio sends x-amz-* metadata as headers, rather than as user metadata
+	if s.ACL != "" {
+		opts.UserMetadata = map[string]string{"x-amz-acl": s.ACL}
+	}
+	if s.Progress != nil {
+		opts.Progress = progressReader(s.Progress)
+	}
+	if s.RequesterPays {
+		// uploads that are signed in chunks cannot be signed again with the header of requester pays buckets
+		opts.DisableContentSha256 = true
+	}
+	return opts
+}
+
+// getObjectOptions returns the options to get and stat objects with
+func (s *s3client) getObjectOptions(encOpts encrypt.ServerSide) minio.GetObjectOptions {
+	opts := minio.GetObjectOptions{ServerSideEncryption: encOpts}
+	if s.RequesterPays {
+		opts.Set(requestPayerHeader, "requester")
+	}
+	return opts
+}
+
+// listObjectsOptions returns the options to list objects with
+func (s *s3client) listObjectsOptions(prefix string, recursive bool) minio.ListObjectsOptions {
+	opts := minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: recursive,
+	}
+	if s.RequesterPays {
+		opts.Set(requestPayerHeader, "requester")
+	}
+	return opts
+}
+
+// requestPayerTransport sends the header that accepts the charges of requester pays buckets with the requests that
+// minio cannot send it with, such as those of uploads. The header must be signed, so the requests are signed again.
+type requestPayerTransport struct {
+	http.RoundTripper
+	creds *credentials.Credentials
+}
+
+func (t *requestPayerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	const algorithm = "AWS4-HMAC-SHA256 Credential="
+	authorization := req.Header.Get("Authorization")
+	// requests that are not signed with signature version 4 or are signed in chunks are sent as they are
+	if req.Header.Get(requestPayerHeader) != "" || !strings.HasPrefix(authorization, algorithm) || strings.HasPrefix(req.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	// the scope of the credential is <access key>/<date>/<region>/s3/aws4_request
+	credential, _, _ := strings.Cut(strings.TrimPrefix(authorization, algorithm), ",")
+	scope := strings.Split(credential, "/")
+	if len(scope) != 5 {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	value, err := t.creds.Get()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(requestPayerHeader, "requester")
+	return t.RoundTripper.RoundTrip(signer.SignV4(*req, value.AccessKeyID, value.SecretAccessKey, value.SessionToken, scope[2]))
+}
+
+// progressReader is read by minio with each chunk of an object as it is uploaded
+type progressReader func(n int64)
+
+func (f progressReader) Read(p []byte) (int, error) {
+	f(int64(len(p)))
+	return len(p), nil
+}
+
 func (s *s3client) BucketExists(bucketName string) (bool, error) {
 	log.WithField("bucket", bucketName).Info("Checking if bucket exists")
 	result, err := s.minioClient.BucketExists(s.ctx, bucketName)
@@ -590,7 +878,7 @@ func (s *s3client) GetFile(bucket, key, path string) error {
 		return err
 	}
 
-	err = s.minioClient.FGetObject(s.ctx, bucket, key, path, minio.GetObjectOptions{ServerSideEncryption: encOpts})
+	err = s.minioClient.FGetObject(s.ctx, bucket, key, path, s.getObjectOptions(encOpts))
 	if err != nil {
 		return err
 	}
@@ -605,7 +893,7 @@ func (s *s3client) OpenFile(bucket, key string) (io.ReadCloser, error) {
 	if err != nil {
 		return nil, err
 	}
-	f, err := s.minioClient.GetObject(s.ctx, bucket, key, minio.GetObjectOptions{ServerSideEncryption: encOpts})
+	f, err := s.minioClient.GetObject(s.ctx, bucket, key, s.getObjectOptions(encOpts))
 	if err != nil {
 		return nil, err
 	}
@@ -617,6 +905,22 @@ func (s *s3client) OpenFile(bucket, key string) (io.ReadCloser, error) {
 	return f, nil
 }
 
+// SelectFile opens the records of a file that match an S3 Select query for reading
+func (s *s3client) SelectFile(bucket, key string, query *wfv1.S3Select) (io.ReadCloser, error) {
+	log.WithFields(log.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info("Selecting from file from s3")
+
+	encOpts, err := s.EncryptOpts.buildServerSideEnc(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	opts := selectObjectOptions(query)
+	// only customer keys are needed to read objects, other encryption headers are rejected
+	if encOpts != nil && encOpts.Type() == encrypt.SSEC {
+		opts.ServerSideEncryption = encOpts
+	}
+	return s.minioClient.SelectObjectContent(s.ctx, bucket, key, opts)
+}
+
 // checks if object exists (and if we have permission to access)
 func (s *s3client) KeyExists(bucket, key string) (bool, error) {
 	log.WithFields(log.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info("Checking key exists from s3")
@@ -626,7 +930,7 @@ func (s *s3client) KeyExists(bucket, key string) (bool, error) {
 		return false, err
 	}
 
-	_, err = s.minioClient.StatObject(s.ctx, bucket, key, minio.StatObjectOptions{ServerSideEncryption: encOpts})
+	_, err = s.minioClient.StatObject(s.ctx, bucket, key, s.getObjectOptions(encOpts))
 	if err == nil {
 		return true, nil
 	}
@@ -637,11 +941,59 @@ func (s *s3client) KeyExists(bucket, key string) (bool, error) {
 	return false, err
 }
 
+func (s *s3client) ETag(bucket, key string) (string, error) {
+	encOpts, err := s.EncryptOpts.buildServerSideEnc(bucket, key)
+	if err != nil {
+		return "", err
+	}
+	info, err := s.minioClient.StatObject(s.ctx, bucket, key, s.getObjectOptions(encOpts))
+	if err != nil {
+		return "", err
+	}
+	return info.ETag, nil
+}
+
 func (s *s3client) Delete(bucket, key string) error {
 	log.WithFields(log.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": key}).Info("Deleting object from s3")
 	return s.minioClient.RemoveObject(s.ctx, bucket, key, minio.RemoveObjectOptions{})
 }
 
+// DeletePrefix lists the keys that start with the prefix, and deletes them with as few DeleteObjects requests as
+// possible, each of up to 1000 keys
+func (s *s3client) DeletePrefix(bucket, keyPrefix string) error {
+	log.WithFields(log.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": keyPrefix}).Info("Deleting prefix from s3")
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	objCh := make(chan minio.ObjectInfo)
+	listErrCh := make(chan error, 1)
+	go func() {
+		defer close(objCh)
+		for obj := range s.minioClient.ListObjects(ctx, bucket, s.listObjectsOptions(keyPrefix, true)) {
+			if obj.Err != nil {
+				listErrCh <- obj.Err
+				return
+			}
+			select {
+			case objCh <- obj:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for removeErr := range s.minioClient.RemoveObjects(ctx, bucket, objCh, minio.RemoveObjectsOptions{}) {
+		if removeErr.Err != nil {
+			return removeErr.Err
+		}
+	}
+	select {
+	case err := <-listErrCh:
+		return err
+	default:
+		return nil
+	}
+}
+
 // GetDirectory downloads a s3 directory to a local path
 func (s *s3client) GetDirectory(bucket, keyPrefix, path string) error {
 	log.WithFields(log.Fields{"endpoint": s.Endpoint, "bucket": bucket, "key": keyPrefix, "path": path}).Info("Getting directory from s3")
@@ -659,7 +1011,7 @@ func (s *s3client) GetDirectory(bucket, keyPrefix, path string) error {
 			return err
 		}
 
-		err = s.minioClient.FGetObject(s.ctx, bucket, objKey, localPath, minio.GetObjectOptions{ServerSideEncryption: encOpts})
+		err = s.minioClient.FGetObject(s.ctx, bucket, objKey, localPath, s.getObjectOptions(encOpts))
 		if err != nil {
 			return err
 		}
@@ -680,11 +1032,7 @@ func (s *s3client) IsDirectory(bucket, keyPrefix string) (bool, error) {
 		}
 	}
 
-	listOpts := minio.ListObjectsOptions{
-		Prefix:    keyPrefix,
-		Recursive: false,
-	}
-	objCh := s.minioClient.ListObjects(s.ctx, bucket, listOpts)
+	objCh := s.minioClient.ListObjects(s.ctx, bucket, s.listObjectsOptions(keyPrefix, false))
 	for obj := range objCh {
 		if obj.Err != nil {
 			return false, obj.Err
@@ -707,12 +1055,8 @@ func (s *s3client) ListDirectory(bucket, keyPrefix string) ([]string, error) {
 
 	doneCh := make(chan struct{})
 	defer close(doneCh)
-	listOpts := minio.ListObjectsOptions{
-		Prefix:    keyPrefix,
-		Recursive: true,
-	}
 	var out []string
-	objCh := s.minioClient.ListObjects(s.ctx, bucket, listOpts)
+	objCh := s.minioClient.ListObjects(s.ctx, bucket, s.listObjectsOptions(keyPrefix, true))
 	for obj := range objCh {
 		if obj.Err != nil {
 			return nil, obj.Err
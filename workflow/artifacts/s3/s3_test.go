@@ -3,10 +3,14 @@ package s3
 import (
 	"bytes"
 	"context"
+	"encoding/xml"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
@@ -52,6 +57,18 @@ func (s *mockS3Client) PutDirectory(bucket, key, path string) error {
 	return s.getMockedErr("PutDirectory")
 }
 
+// PutStream reads all of the reader, as a multipart upload would, and puts the key in the bucket
+func (s *mockS3Client) PutStream(bucket, key string, r io.Reader) error {
+	if err := s.getMockedErr("PutStream"); err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return err
+	}
+	s.files[bucket] = append(s.files[bucket], key)
+	return nil
+}
+
 // GetFile downloads a file to a local file path
 func (s *mockS3Client) GetFile(bucket, key, path string) error {
 	return s.getMockedErr("GetFile")
@@ -60,7 +77,22 @@ func (s *mockS3Client) GetFile(bucket, key, path string) error {
 func (s *mockS3Client) OpenFile(bucket, key string) (io.ReadCloser, error) {
 	err := s.getMockedErr("OpenFile")
 	if err == nil {
-		return io.NopCloser(&bytes.Buffer{}), nil
+		// minio objects are seekable
+		return nopSeekCloser{bytes.NewReader([]byte("my-data"))}, nil
+	}
+	return nil, err
+}
+
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+func (s *mockS3Client) SelectFile(bucket, key string, query *wfv1.S3Select) (io.ReadCloser, error) {
+	err := s.getMockedErr("SelectFile")
+	if err == nil {
+		return io.NopCloser(strings.NewReader(query.Expression)), nil
 	}
 	return nil, err
 }
@@ -77,11 +109,32 @@ func (s *mockS3Client) KeyExists(bucket, key string) (bool, error) {
 	return false, err
 }
 
+func (s *mockS3Client) ETag(bucket, key string) (string, error) {
+	if err := s.getMockedErr("ETag"); err != nil {
+		return "", err
+	}
+	for _, file := range s.files[bucket] {
+		if file == key {
+			return "etag-of-" + key, nil
+		}
+	}
+	return "", minio.ErrorResponse{Code: "NoSuchKey"}
+}
+
 // GetDirectory downloads a directory to a local file path
 func (s *mockS3Client) GetDirectory(bucket, key, path string) error {
 	return s.getMockedErr("GetDirectory")
 }
 
+// DeletePrefix deletes every key of the bucket that starts with the prefix
+func (s *mockS3Client) DeletePrefix(bucket, keyPrefix string) error {
+	if err := s.getMockedErr("DeletePrefix"); err != nil {
+		return err
+	}
+	s.files[bucket] = slices.DeleteFunc(s.files[bucket], func(file string) bool { return strings.HasPrefix(file, keyPrefix) })
+	return nil
+}
+
 // ListDirectory list the contents of a directory/bucket
 func (s *mockS3Client) ListDirectory(bucket, keyPrefix string) ([]string, error) {
 	dirs := make([]string, 0)
@@ -404,6 +457,134 @@ func TestLoadS3Artifact(t *testing.T) {
 	}
 }
 
+func TestSelectS3Artifact(t *testing.T) {
+	query := &wfv1.S3Select{
+		Expression: "SELECT * FROM S3Object s WHERE s.status = 'failed'",
+		Input:      wfv1.S3SelectInput{CSV: &wfv1.S3SelectCSV{FileHeaderInfo: "USE"}},
+	}
+	artifact := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{
+		S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"},
+		Key:      "/folder/data.csv",
+		Select:   query,
+	}}}
+	t.Run("Load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "data.csv")
+		done, err := loadS3Artifact(newMockS3Client(map[string][]string{}, map[string]error{
+			"GetFile": minio.ErrorResponse{Code: "AccessDenied"},
+		}), artifact, path)
+		require.NoError(t, err)
+		assert.True(t, done)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, query.Expression, string(data), "only the selected records are loaded")
+	})
+	t.Run("LoadNoSuchKey", func(t *testing.T) {
+		done, err := loadS3Artifact(newMockS3Client(map[string][]string{}, map[string]error{
+			"SelectFile": minio.ErrorResponse{Code: "NoSuchKey"},
+		}), artifact, filepath.Join(t.TempDir(), "data.csv"))
+		require.Error(t, err)
+		assert.True(t, done)
+		assert.True(t, argoerrs.IsCode(argoerrs.CodeNotFound, err))
+	})
+	t.Run("OpenStream", func(t *testing.T) {
+		stream, err := streamS3Artifact(newMockS3Client(map[string][]string{}, map[string]error{}), artifact)
+		require.NoError(t, err)
+		data, err := io.ReadAll(stream)
+		require.NoError(t, err)
+		assert.Equal(t, query.Expression, string(data))
+	})
+	t.Run("OpenSeekableStream", func(t *testing.T) {
+		_, err := seekableStreamS3Artifact(newMockS3Client(map[string][]string{}, map[string]error{}), artifact)
+		assert.True(t, argoerrs.IsCode(argoerrs.CodeNotImplemented, err), "the selected records cannot be read from an offset")
+	})
+}
+
+func TestSeekableStreamS3Artifact(t *testing.T) {
+	artifact := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: "my-key"}}}
+	t.Run("Seek", func(t *testing.T) {
+		stream, err := seekableStreamS3Artifact(newMockS3Client(map[string][]string{}, map[string]error{}), artifact)
+		require.NoError(t, err)
+		_, err = stream.Seek(3, io.SeekStart)
+		require.NoError(t, err)
+		data, err := io.ReadAll(stream)
+		require.NoError(t, err)
+		assert.Equal(t, "data", string(data))
+	})
+	t.Run("NoSuchKey", func(t *testing.T) {
+		_, err := seekableStreamS3Artifact(newMockS3Client(map[string][]string{}, map[string]error{
+			"OpenFile": minio.ErrorResponse{Code: "NoSuchKey"},
+		}), artifact)
+		assert.True(t, argoerrs.IsCode(argoerrs.CodeNotFound, err))
+	})
+}
+
+func TestSelectObjectOptions(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		opts := selectObjectOptions(&wfv1.S3Select{
+			Expression: "SELECT * FROM S3Object",
+			Input:      wfv1.S3SelectInput{CompressionType: "GZIP", CSV: &wfv1.S3SelectCSV{FileHeaderInfo: "USE", FieldDelimiter: ";"}},
+		})
+		assert.Equal(t, minio.QueryExpressionTypeSQL, opts.ExpressionType)
+		assert.Equal(t, minio.SelectCompressionGZIP, opts.InputSerialization.CompressionType)
+		require.NotNil(t, opts.InputSerialization.CSV)
+		assert.Equal(t, minio.CSVFileHeaderInfoUse, opts.InputSerialization.CSV.FileHeaderInfo)
+		require.NotNil(t, opts.OutputSerialization.CSV, "CSV is output as CSV by default")
+		assert.Equal(t, ";", opts.OutputSerialization.CSV.FieldDelimiter)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		opts := selectObjectOptions(&wfv1.S3Select{
+			Expression: "SELECT * FROM S3Object",
+			Input:      wfv1.S3SelectInput{JSON: &wfv1.S3SelectJSON{Type: "LINES"}},
+		})
+		assert.Equal(t, minio.SelectCompressionNONE, opts.InputSerialization.CompressionType)
+		require.NotNil(t, opts.InputSerialization.JSON)
+		assert.Equal(t, minio.JSONLinesType, opts.InputSerialization.JSON.Type)
+		assert.NotNil(t, opts.OutputSerialization.JSON, "JSON is output as JSON by default")
+		assert.Nil(t, opts.OutputSerialization.CSV)
+	})
+	t.Run("Parquet", func(t *testing.T) {
+		opts := selectObjectOptions(&wfv1.S3Select{
+			Expression: "SELECT * FROM S3Object",
+			Input:      wfv1.S3SelectInput{Parquet: &wfv1.S3SelectParquet{}},
+			Output:     &wfv1.S3SelectOutput{JSON: &wfv1.S3SelectJSON{}},
+		})
+		assert.NotNil(t, opts.InputSerialization.Parquet)
+		assert.NotNil(t, opts.OutputSerialization.JSON)
+		assert.Nil(t, opts.OutputSerialization.CSV)
+	})
+}
+
+func TestValidateSelect(t *testing.T) {
+	valid := func() *wfv1.S3Select {
+		return &wfv1.S3Select{
+			Expression: "SELECT * FROM S3Object",
+			Input:      wfv1.S3SelectInput{JSON: &wfv1.S3SelectJSON{Type: "DOCUMENT"}},
+		}
+	}
+	require.NoError(t, ValidateSelect("select", nil))
+	require.NoError(t, ValidateSelect("select", valid()))
+
+	query := valid()
+	query.Expression = ""
+	require.EqualError(t, ValidateSelect("select", query), "select.expression is required")
+
+	query = valid()
+	query.Input.CSV = &wfv1.S3SelectCSV{}
+	require.EqualError(t, ValidateSelect("select", query), "select.input must have exactly one of csv, json, or parquet")
+
+	query = valid()
+	query.Input.JSON.Type = ""
+	require.EqualError(t, ValidateSelect("select", query), "select.input.json.type must be one of DOCUMENT or LINES")
+
+	query = valid()
+	query.Input.CompressionType = "ZIP"
+	require.EqualError(t, ValidateSelect("select", query), "select.input.compressionType must be one of NONE, GZIP, or BZIP2")
+
+	query = valid()
+	query.Output = &wfv1.S3SelectOutput{CSV: &wfv1.S3SelectCSV{FileHeaderInfo: "USE"}}
+	require.EqualError(t, ValidateSelect("select", query), "select.output.csv.fileHeaderInfo is only valid for the input")
+}
+
 func TestSaveS3Artifact(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -538,6 +719,38 @@ func TestSaveS3Artifact(t *testing.T) {
 	}
 }
 
+func TestSaveS3Stream(t *testing.T) {
+	art := &wfv1.Artifact{
+		ArtifactLocation: wfv1.ArtifactLocation{
+			S3: &wfv1.S3Artifact{
+				S3Bucket: wfv1.S3Bucket{
+					Bucket:                   "my-bucket",
+					CreateBucketIfNotPresent: &wfv1.CreateS3BucketOptions{},
+				},
+				Key: "folder/hello-art.tgz",
+			},
+		},
+	}
+	t.Run("Success", func(t *testing.T) {
+		files := map[string][]string{"my-bucket": {}}
+		err := saveS3Stream(newMockS3Client(files, map[string]error{}), strings.NewReader("my-data"), art)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"folder/hello-art.tgz"}, files["my-bucket"])
+	})
+	t.Run("MakeBucketAccessDenied", func(t *testing.T) {
+		err := saveS3Stream(newMockS3Client(map[string][]string{}, map[string]error{
+			"MakeBucket": minio.ErrorResponse{Code: "AccessDenied"},
+		}), strings.NewReader("my-data"), art)
+		require.EqualError(t, err, "failed to create bucket my-bucket: Access Denied.")
+	})
+	t.Run("PutStreamError", func(t *testing.T) {
+		err := saveS3Stream(newMockS3Client(map[string][]string{}, map[string]error{
+			"PutStream": minio.ErrorResponse{Code: "InternalError"},
+		}), strings.NewReader("my-data"), art)
+		require.EqualError(t, err, "failed to put stream: We encountered an internal error, please try again.")
+	})
+}
+
 func TestListObjects(t *testing.T) {
 
 	tests := map[string]struct {
@@ -730,3 +943,154 @@ func TestDisallowedComboOptions(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestPutObjectOptions(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		s3cli := &s3client{S3ClientOpts: S3ClientOpts{SendContentMd5: true}}
+		opts := s3cli.putObjectOptions(nil)
+		assert.True(t, opts.SendContentMd5)
+		assert.Empty(t, opts.StorageClass)
+		assert.Empty(t, opts.UserMetadata)
+	})
+	t.Run("StorageClassAndACL", func(t *testing.T) {
+		s3cli := &s3client{S3ClientOpts: S3ClientOpts{StorageClass: "GLACIER_IR", ACL: "bucket-owner-full-control"}}
+		opts := s3cli.putObjectOptions(nil)
+		assert.Equal(t, "GLACIER_IR", opts.StorageClass)
+		assert.Equal(t, map[string]string{"x-amz-acl": "bucket-owner-full-control"}, opts.UserMetadata)
+		assert.Equal(t, "bucket-owner-full-control", opts.Header().Get("x-amz-acl"))
+	})
+	t.Run("Progress", func(t *testing.T) {
+		var uploaded int64
+		s3cli := &s3client{S3ClientOpts: S3ClientOpts{Progress: func(n int64) { uploaded += n }}}
+		opts := s3cli.putObjectOptions(nil)
+		require.NotNil(t, opts.Progress)
+		_, err := opts.Progress.Read(make([]byte, 10))
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), uploaded)
+	})
+}
+
+func TestRequesterPays(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		s3cli := &s3client{}
+		assert.Empty(t, s3cli.putObjectOptions(nil).Header().Get(requestPayerHeader))
+		opts := s3cli.getObjectOptions(nil)
+		assert.Empty(t, opts.Header().Get(requestPayerHeader))
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		s3cli := &s3client{S3ClientOpts: S3ClientOpts{ACL: "private", RequesterPays: true}}
+		putOpts := s3cli.putObjectOptions(nil)
+		assert.Empty(t, putOpts.Header().Get("x-amz-meta-"+requestPayerHeader), "the header is not saved as metadata of the object")
+		assert.Equal(t, "private", putOpts.Header().Get("x-amz-acl"))
+		getOpts := s3cli.getObjectOptions(nil)
+		assert.Equal(t, "requester", getOpts.Header().Get(requestPayerHeader))
+	})
+}
+
+func TestRequesterPaysUploads(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		request := r.Method + " " + r.URL.RawQuery
+		requests = append(requests, request)
+		assert.Equal(t, "requester", r.Header.Get(requestPayerHeader), request)
+		assert.Contains(t, r.Header.Get("Authorization"), requestPayerHeader, "the header is signed")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Query().Has("uploads"):
+			_, _ = w.Write([]byte(`<InitiateMultipartUploadResult><Bucket>my-bucket</Bucket><Key>my-key</Key><UploadId>my-upload</UploadId></InitiateMultipartUploadResult>`))
+		case r.Method == http.MethodPost && r.URL.Query().Has("uploadId"):
+			_, _ = w.Write([]byte(`<CompleteMultipartUploadResult><Bucket>my-bucket</Bucket><Key>my-key</Key><ETag>"my-etag"</ETag></CompleteMultipartUploadResult>`))
+		case r.Method == http.MethodPut:
+			w.Header().Set("ETag", `"my-etag"`)
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	t.Cleanup(server.Close)
+	s3cli, err := NewS3Client(context.Background(), S3ClientOpts{
+		Endpoint:      strings.TrimPrefix(server.URL, "http://"),
+		Region:        "us-east-1",
+		AccessKey:     "key",
+		SecretKey:     "secret",
+		RequesterPays: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("PutFile", func(t *testing.T) {
+		requests = nil
+		path := filepath.Join(t.TempDir(), "my-file")
+		require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+		require.NoError(t, s3cli.PutFile("my-bucket", "my-key", path))
+		assert.Equal(t, []string{"PUT "}, requests)
+	})
+	t.Run("Multipart", func(t *testing.T) {
+		requests = nil
+		require.NoError(t, s3cli.PutStream("my-bucket", "my-key", strings.NewReader("hello")))
+		assert.Equal(t, []string{"POST uploads=", "PUT partNumber=1&uploadId=my-upload", "POST uploadId=my-upload"}, requests)
+	})
+}
+
+// fakeS3Server lists the keys of my-bucket, and records the keys of each batch delete
+func fakeS3Server(t *testing.T, keys []string) (*httptest.Server, *[][]string) {
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+			type contents struct{ Key string }
+			result := struct {
+				XMLName  xml.Name `xml:"ListBucketResult"`
+				Name     string
+				Prefix   string
+				KeyCount int
+				Contents []contents
+			}{Name: "my-bucket", Prefix: r.URL.Query().Get("prefix")}
+			for _, key := range keys {
+				if strings.HasPrefix(key, result.Prefix) {
+					result.Contents = append(result.Contents, contents{key})
+				}
+			}
+			result.KeyCount = len(result.Contents)
+			assert.NoError(t, xml.NewEncoder(w).Encode(result))
+		case r.Method == http.MethodPost && r.URL.Query().Has("delete"):
+			var req struct {
+				Object []struct{ Key string }
+			}
+			assert.NoError(t, xml.NewDecoder(r.Body).Decode(&req))
+			var batch []string
+			for _, o := range req.Object {
+				batch = append(batch, o.Key)
+			}
+			batches = append(batches, batch)
+			_, _ = w.Write([]byte(`<DeleteResult></DeleteResult>`))
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &batches
+}
+
+func TestDeletePrefix(t *testing.T) {
+	keys := []string{"argo-ephemeral/other-uid/kept.tgz"}
+	for i := range 1001 {
+		keys = append(keys, fmt.Sprintf("argo-ephemeral/my-uid/pod-%d/intermediate.tgz", i))
+	}
+	server, batches := fakeS3Server(t, keys)
+	s3Driver := &ArtifactDriver{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		Region:    "us-east-1",
+		AccessKey: "key",
+		SecretKey: "secret",
+	}
+	assert.True(t, s3Driver.Capabilities().PrefixDelete)
+
+	err := s3Driver.DeletePrefix(&wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{
+		S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"},
+		Key:      "argo-ephemeral/my-uid/",
+	}}})
+	require.NoError(t, err)
+	require.Len(t, *batches, 2, "the keys are deleted in batches of 1000")
+	assert.Len(t, (*batches)[0], 1000)
+	assert.Equal(t, []string{"argo-ephemeral/my-uid/pod-1000/intermediate.tgz"}, (*batches)[1])
+	assert.NotContains(t, slices.Concat((*batches)...), "argo-ephemeral/other-uid/kept.tgz")
+}
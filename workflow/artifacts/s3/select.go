@@ -0,0 +1,111 @@
+package s3
+
+import (
+	"github.com/minio/minio-go/v7"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// ValidateSelect validates an S3 Select query
+func ValidateSelect(errPrefix string, query *wfv1.S3Select) error {
+	if query == nil {
+		return nil
+	}
+	if query.Expression == "" {
+		return errors.Errorf(errors.CodeBadRequest, "%s.expression is required", errPrefix)
+	}
+	switch minio.SelectCompressionType(query.Input.CompressionType) {
+	case "", minio.SelectCompressionNONE, minio.SelectCompressionGZIP, minio.SelectCompressionBZIP:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "%s.input.compressionType must be one of NONE, GZIP, or BZIP2", errPrefix)
+	}
+	formats := 0
+	if query.Input.CSV != nil {
+		formats++
+		switch minio.CSVFileHeaderInfo(query.Input.CSV.FileHeaderInfo) {
+		case "", minio.CSVFileHeaderInfoNone, minio.CSVFileHeaderInfoIgnore, minio.CSVFileHeaderInfoUse:
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "%s.input.csv.fileHeaderInfo must be one of NONE, IGNORE, or USE", errPrefix)
+		}
+	}
+	if query.Input.JSON != nil {
+		formats++
+		switch minio.JSONType(query.Input.JSON.Type) {
+		case minio.JSONDocumentType, minio.JSONLinesType:
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "%s.input.json.type must be one of DOCUMENT or LINES", errPrefix)
+		}
+	}
+	if query.Input.Parquet != nil {
+		formats++
+		if query.Input.CompressionType != "" && query.Input.CompressionType != string(minio.SelectCompressionNONE) {
+			return errors.Errorf(errors.CodeBadRequest, "%s.input.compressionType is not supported for Parquet objects", errPrefix)
+		}
+	}
+	if formats != 1 {
+		return errors.Errorf(errors.CodeBadRequest, "%s.input must have exactly one of csv, json, or parquet", errPrefix)
+	}
+	if output := query.Output; output != nil {
+		if output.CSV != nil && output.JSON != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.output may not have both csv and json", errPrefix)
+		}
+		if output.CSV != nil && output.CSV.FileHeaderInfo != "" {
+			return errors.Errorf(errors.CodeBadRequest, "%s.output.csv.fileHeaderInfo is only valid for the input", errPrefix)
+		}
+		if output.JSON != nil && output.JSON.Type != "" {
+			return errors.Errorf(errors.CodeBadRequest, "%s.output.json.type is only valid for the input", errPrefix)
+		}
+	}
+	return nil
+}
+
+// selectObjectOptions returns the options to query an object with S3 Select
+func selectObjectOptions(query *wfv1.S3Select) minio.SelectObjectOptions {
+	opts := minio.SelectObjectOptions{
+		Expression:     query.Expression,
+		ExpressionType: minio.QueryExpressionTypeSQL,
+	}
+	opts.InputSerialization.CompressionType = minio.SelectCompressionNONE
+	if query.Input.CompressionType != "" {
+		opts.InputSerialization.CompressionType = minio.SelectCompressionType(query.Input.CompressionType)
+	}
+	switch {
+	case query.Input.CSV != nil:
+		csv := &minio.CSVInputOptions{}
+		if query.Input.CSV.FileHeaderInfo != "" {
+			csv.SetFileHeaderInfo(minio.CSVFileHeaderInfo(query.Input.CSV.FileHeaderInfo))
+		}
+		if query.Input.CSV.FieldDelimiter != "" {
+			csv.SetFieldDelimiter(query.Input.CSV.FieldDelimiter)
+		}
+		opts.InputSerialization.CSV = csv
+	case query.Input.JSON != nil:
+		json := &minio.JSONInputOptions{}
+		json.SetType(minio.JSONType(query.Input.JSON.Type))
+		opts.InputSerialization.JSON = json
+	case query.Input.Parquet != nil:
+		opts.InputSerialization.Parquet = &minio.ParquetInputOptions{}
+	}
+
+	// records are output in the same format as they are input, except Parquet which can only be output as CSV or JSON
+	output := query.Output
+	if output == nil || (output.CSV == nil && output.JSON == nil) {
+		output = &wfv1.S3SelectOutput{CSV: query.Input.CSV}
+		if query.Input.JSON != nil {
+			output.JSON = &wfv1.S3SelectJSON{}
+		} else if output.CSV == nil {
+			output.CSV = &wfv1.S3SelectCSV{}
+		}
+	}
+	if output.JSON != nil {
+		opts.OutputSerialization.JSON = &minio.JSONOutputOptions{}
+	} else {
+		csv := &minio.CSVOutputOptions{}
+		if output.CSV.FieldDelimiter != "" {
+			csv.SetFieldDelimiter(output.CSV.FieldDelimiter)
+		}
+		opts.OutputSerialization.CSV = csv
+	}
+	return opts
+}
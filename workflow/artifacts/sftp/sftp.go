@@ -0,0 +1,516 @@
+package sftp
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+const (
+	defaultPort = 22
+	dialTimeout = 30 * time.Second
+)
+
+// ArtifactDriver is the artifact driver for an SFTP server
+type ArtifactDriver struct {
+	Address               string
+	Username              string
+	Password              string
+	PrivateKey            string
+	KnownHosts            string
+	InsecureIgnoreHostKey bool
+}
+
+var (
+	_ common.ArtifactDriver   = &ArtifactDriver{}
+	_ common.ExistenceChecker = &ArtifactDriver{}
+)
+
+// ValidateArtifact validates an SFTP artifact
+func ValidateArtifact(errPrefix string, art *wfv1.SFTPArtifact) error {
+	if art.Path == "" {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.path is required", errPrefix)
+	}
+	if art.Port < 0 || art.Port > 65535 {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.port must be between 1 and 65535", errPrefix)
+	}
+	if art.Host == "" {
+		// the rest of the location is from the artifact repository
+		return nil
+	}
+	if art.UsernameSecret == nil {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.usernameSecret is required", errPrefix)
+	}
+	if art.PasswordSecret == nil && art.PrivateKeySecret == nil {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "either %s.passwordSecret or %s.privateKeySecret is required", errPrefix, errPrefix)
+	}
+	if art.KnownHostsConfigMap == nil && !art.InsecureIgnoreHostKey {
+		return argoerrors.Errorf(argoerrors.CodeBadRequest, "%s.knownHostsConfigMap is required unless %s.insecureIgnoreHostKey is true", errPrefix, errPrefix)
+	}
+	return nil
+}
+
+// CreateDriver constructs ArtifactDriver
+func CreateDriver(ctx context.Context, ri resource.Interface, art *wfv1.SFTPArtifact) (*ArtifactDriver, error) {
+	port := int(art.Port)
+	if port == 0 {
+		port = defaultPort
+	}
+	driver := ArtifactDriver{
+		Address:               net.JoinHostPort(art.Host, strconv.Itoa(port)),
+		InsecureIgnoreHostKey: art.InsecureIgnoreHostKey,
+	}
+	var err error
+	if art.UsernameSecret != nil {
+		driver.Username, err = ri.GetSecret(ctx, art.UsernameSecret.Name, art.UsernameSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if art.PasswordSecret != nil {
+		driver.Password, err = ri.GetSecret(ctx, art.PasswordSecret.Name, art.PasswordSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if art.PrivateKeySecret != nil {
+		driver.PrivateKey, err = ri.GetSecret(ctx, art.PrivateKeySecret.Name, art.PrivateKeySecret.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if art.KnownHostsConfigMap != nil {
+		driver.KnownHosts, err = ri.GetConfigMapKey(ctx, art.KnownHostsConfigMap.Name, art.KnownHostsConfigMap.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &driver, nil
+}
+
+func (d *ArtifactDriver) clientConfig() (*ssh.ClientConfig, error) {
+	config := &ssh.ClientConfig{User: d.Username, Timeout: dialTimeout}
+	if d.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(d.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the private key: %w", err)
+		}
+		config.Auth = append(config.Auth, ssh.PublicKeys(signer))
+	}
+	if d.Password != "" {
+		config.Auth = append(config.Auth,
+			ssh.Password(d.Password),
+			// many servers only accept passwords by keyboard-interactive authentication
+			ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range answers {
+					answers[i] = d.Password
+				}
+				return answers, nil
+			}),
+		)
+	}
+	switch {
+	case d.InsecureIgnoreHostKey:
+		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	case d.KnownHosts != "":
+		callback, err := knownHostsCallback(d.KnownHosts)
+		if err != nil {
+			return nil, err
+		}
+		config.HostKeyCallback = callback
+	default:
+		return nil, fmt.Errorf("the known hosts of %s are required to verify its host key", d.Address)
+	}
+	return config, nil
+}
+
+// knownHostsCallback returns a callback that verifies host keys against the known hosts, which are in the format of
+// an OpenSSH known_hosts file
+func knownHostsCallback(knownHosts string) (ssh.HostKeyCallback, error) {
+	f, err := os.CreateTemp("", "known_hosts.")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	_, err = f.WriteString(knownHosts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	callback, err := knownhosts.New(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the known hosts: %w", err)
+	}
+	return callback, nil
+}
+
+// connection is an SFTP session to the server, which closes its SSH connection when it is closed
+type connection struct {
+	*sftp.Client
+	conn *ssh.Client
+}
+
+func (c *connection) Close() error {
+	err := c.Client.Close()
+	if closeErr := c.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// connect opens an SFTP session to the server
+func (d *ArtifactDriver) connect() (*connection, error) {
+	config, err := d.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := ssh.Dial("tcp", d.Address, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", d.Address, err)
+	}
+	c, err := sftp.NewClient(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to start the SFTP subsystem on %s: %w", d.Address, err)
+	}
+	return &connection{Client: c, conn: conn}, nil
+}
+
+// stat returns the file info of the artifact, or a not found error if it does not exist
+func stat(c *sftp.Client, p string) (fs.FileInfo, error) {
+	info, err := c.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, argoerrors.Errorf(argoerrors.CodeNotFound, "%s does not exist", p)
+	}
+	return info, err
+}
+
+// readDir lists the entries of the directory, following symbolic links
+func readDir(c *sftp.Client, remotePath string) ([]fs.FileInfo, error) {
+	entries, err := c.ReadDir(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	for i, e := range entries {
+		if e.Mode()&fs.ModeSymlink == 0 {
+			continue
+		}
+		if entries[i], err = c.Stat(path.Join(remotePath, e.Name())); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// Load downloads the file or directory of the artifact from the server
+func (d *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, localPath string) error {
+	c, err := d.connect()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+	return download(c.Client, inputArtifact.SFTP.Path, localPath)
+}
+
+// download downloads the file or directory at the remote path to the local path
+func download(c *sftp.Client, remotePath, localPath string) error {
+	info, err := stat(c, remotePath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return downloadDirectory(c, remotePath, localPath)
+	}
+	return downloadFile(c, remotePath, localPath)
+}
+
+func downloadDirectory(c *sftp.Client, remotePath, localPath string) error {
+	// Follow umask for the permission
+	if err := os.MkdirAll(localPath, 0o777); err != nil {
+		return err
+	}
+	entries, err := readDir(c, remotePath)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		remoteEntryPath := path.Join(remotePath, e.Name())
+		if e.IsDir() {
+			err = downloadDirectory(c, remoteEntryPath, filepath.Join(localPath, e.Name()))
+		} else {
+			err = downloadFile(c, remoteEntryPath, filepath.Join(localPath, e.Name()))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func downloadFile(c *sftp.Client, remotePath, localPath string) error {
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o777); err != nil {
+		return err
+	}
+	src, err := c.Open(remotePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	dst, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// OpenStream opens the file of the artifact, or streams its directory as a tarball
+func (d *ArtifactDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	c, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	remotePath := a.SFTP.Path
+	info, err := stat(c.Client, remotePath)
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		r, w := io.Pipe()
+		go func() {
+			defer func() { _ = c.Close() }()
+			_ = w.CloseWithError(tarDirectory(c.Client, remotePath, w))
+		}()
+		return r, nil
+	}
+	f, err := c.Open(remotePath)
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return &stream{File: f, c: c}, nil
+}
+
+// stream is a file on the server, which closes its connection when it is closed
+type stream struct {
+	*sftp.File
+	c *connection
+}
+
+func (s *stream) Close() error {
+	err := s.File.Close()
+	if closeErr := s.c.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// tarDirectory writes the directory as a tar.gz to w, laid out like the tarball of a directory that is archived by
+// the executor
+func tarDirectory(c *sftp.Client, remotePath string, w io.Writer) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	if err := tarEntries(c, tw, remotePath, path.Base(path.Clean(remotePath))); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+func tarEntries(c *sftp.Client, tw *tar.Writer, remotePath, name string) error {
+	if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: 0o755}); err != nil {
+		return err
+	}
+	entries, err := readDir(c, remotePath)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		remoteEntryPath := path.Join(remotePath, e.Name())
+		if e.IsDir() {
+			if err := tarEntries(c, tw, remoteEntryPath, path.Join(name, e.Name())); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: path.Join(name, e.Name()), Size: e.Size(), Mode: 0o644}); err != nil {
+			return err
+		}
+		f, err := c.Open(remoteEntryPath)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to archive %s: %w", remoteEntryPath, err)
+		}
+	}
+	return nil
+}
+
+// Save uploads the file or directory to the path of the artifact on the server
+func (d *ArtifactDriver) Save(localPath string, outputArtifact *wfv1.Artifact) error {
+	c, err := d.connect()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+	return upload(c.Client, localPath, outputArtifact.SFTP.Path)
+}
+
+// upload uploads the file or directory at the local path to the remote path, creating its parent directories
+func upload(c *sftp.Client, localPath, remotePath string) error {
+	isDir, err := file.IsDirectory(localPath)
+	if err != nil {
+		return err
+	}
+	if !isDir {
+		if err := c.MkdirAll(path.Dir(remotePath)); err != nil {
+			return err
+		}
+		return uploadFile(c, localPath, remotePath)
+	}
+	return filepath.WalkDir(localPath, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localPath, p)
+		if err != nil {
+			return err
+		}
+		remoteEntryPath := path.Join(remotePath, filepath.ToSlash(rel))
+		if e.IsDir() {
+			return c.MkdirAll(remoteEntryPath)
+		}
+		return uploadFile(c, p, remoteEntryPath)
+	})
+}
+
+func uploadFile(c *sftp.Client, localPath, remotePath string) error {
+	src, err := os.Open(filepath.Clean(localPath))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	dst, err := c.Create(remotePath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Delete removes the file or directory of the artifact from the server
+func (d *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
+	c, err := d.connect()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+	err = c.RemoveAll(artifact.SFTP.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// ListObjects lists the paths of the files of the artifact
+func (d *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
+	c, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.Close() }()
+	return listFiles(c.Client, artifact.SFTP.Path)
+}
+
+// listFiles lists the paths of the file, or of the files in the directory and its subdirectories
+func listFiles(c *sftp.Client, remotePath string) ([]string, error) {
+	info, err := c.Stat(remotePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{remotePath}, nil
+	}
+	entries, err := c.ReadDir(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		entryFiles, err := listFiles(c, path.Join(remotePath, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, entryFiles...)
+	}
+	return files, nil
+}
+
+func (d *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
+	c, err := d.connect()
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = c.Close() }()
+	info, err := stat(c.Client, artifact.SFTP.Path)
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// Capabilities returns the optional operations that SFTP supports
+func (d *ArtifactDriver) Capabilities() common.Capabilities {
+	return common.Capabilities{StreamsDirectories: true, Delete: true, ListObjects: true}
+}
+
+func (d *ArtifactDriver) Exists(artifact *wfv1.Artifact) (bool, error) {
+	c, err := d.connect()
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = c.Close() }()
+	_, err = c.Stat(artifact.SFTP.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,162 @@
+package sftp
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	apiv1 "k8s.io/api/core/v1"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// pipe is one end of the connection between the test client and server
+type pipe struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// newTestClient returns a client of an SFTP server of a local directory, which relative paths are resolved against
+func newTestClient(t *testing.T) (*sftp.Client, string) {
+	t.Helper()
+	root := t.TempDir()
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	s, err := sftp.NewServer(pipe{serverReader, serverWriter}, sftp.WithServerWorkingDirectory(root))
+	require.NoError(t, err)
+	go func() { _ = s.Serve() }()
+	c, err := sftp.NewClientPipe(clientReader, clientWriter)
+	require.NoError(t, err)
+	// the client waits for the server to close its end of the connection
+	t.Cleanup(func() {
+		_ = s.Close()
+		_ = c.Close()
+	})
+	return c, root
+}
+
+func TestUploadAndDownload(t *testing.T) {
+	c, _ := newTestClient(t)
+	local := t.TempDir()
+	// larger than the data of several packets
+	large := string(make([]byte, 100*1024))
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "dir", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "file.txt"), []byte("hello"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "a.txt"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "sub", "b.txt"), []byte(large), 0o600))
+
+	t.Run("File", func(t *testing.T) {
+		require.NoError(t, upload(c, filepath.Join(local, "file.txt"), "out/nested/file.txt"))
+		downloaded := filepath.Join(t.TempDir(), "file.txt")
+		require.NoError(t, download(c, "out/nested/file.txt", downloaded))
+		data, err := os.ReadFile(downloaded)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+	t.Run("Directory", func(t *testing.T) {
+		require.NoError(t, upload(c, filepath.Join(local, "dir"), "out/dir"))
+		files, err := listFiles(c, "out/dir")
+		require.NoError(t, err)
+		sort.Strings(files)
+		assert.Equal(t, []string{"out/dir/a.txt", "out/dir/sub/b.txt"}, files)
+
+		downloaded := filepath.Join(t.TempDir(), "dir")
+		require.NoError(t, download(c, "out/dir", downloaded))
+		data, err := os.ReadFile(filepath.Join(downloaded, "sub", "b.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, large, string(data))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		err := download(c, "missing", filepath.Join(t.TempDir(), "missing"))
+		assert.True(t, argoerrors.IsCode(argoerrors.CodeNotFound, err))
+	})
+	t.Run("RemoveAll", func(t *testing.T) {
+		require.NoError(t, c.RemoveAll("out"))
+		_, err := c.Stat("out")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func TestTarDirectory(t *testing.T) {
+	c, root := newTestClient(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dir", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir", "a.txt"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir", "sub", "b.txt"), []byte("b"), 0o600))
+
+	r, w := io.Pipe()
+	go func() { _ = w.CloseWithError(tarDirectory(c, "dir", w)) }()
+	gzr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gzr)
+	contents := map[string]string{}
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		contents[h.Name] = string(data)
+	}
+	assert.Equal(t, map[string]string{"dir/": "", "dir/a.txt": "a", "dir/sub/": "", "dir/sub/b.txt": "b"}, contents)
+}
+
+func TestValidateArtifact(t *testing.T) {
+	secret := &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "sftp"}, Key: "key"}
+	configMap := &apiv1.ConfigMapKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "sftp"}, Key: "known_hosts"}
+	for name, tc := range map[string]struct {
+		art wfv1.SFTPArtifact
+		err string
+	}{
+		"Key":           {art: wfv1.SFTPArtifact{Path: "out.txt"}},
+		"NoPath":        {art: wfv1.SFTPArtifact{}, err: "sftp.path is required"},
+		"NoUsername":    {art: wfv1.SFTPArtifact{SFTPConfig: wfv1.SFTPConfig{Host: "sftp"}, Path: "out.txt"}, err: "sftp.usernameSecret is required"},
+		"NoCredentials": {art: wfv1.SFTPArtifact{SFTPConfig: wfv1.SFTPConfig{Host: "sftp", UsernameSecret: secret}, Path: "out.txt"}, err: "either sftp.passwordSecret or sftp.privateKeySecret is required"},
+		"NoKnownHosts":  {art: wfv1.SFTPArtifact{SFTPConfig: wfv1.SFTPConfig{Host: "sftp", UsernameSecret: secret, PasswordSecret: secret}, Path: "out.txt"}, err: "sftp.knownHostsConfigMap is required unless sftp.insecureIgnoreHostKey is true"},
+		"KnownHosts":    {art: wfv1.SFTPArtifact{SFTPConfig: wfv1.SFTPConfig{Host: "sftp", UsernameSecret: secret, PrivateKeySecret: secret, KnownHostsConfigMap: configMap}, Path: "out.txt"}},
+		"Insecure":      {art: wfv1.SFTPArtifact{SFTPConfig: wfv1.SFTPConfig{Host: "sftp", UsernameSecret: secret, PasswordSecret: secret, InsecureIgnoreHostKey: true}, Path: "out.txt"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateArtifact("sftp", &tc.art)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestKnownHostsCallback(t *testing.T) {
+	newKey := func() ssh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		key, err := ssh.NewPublicKey(pub)
+		require.NoError(t, err)
+		return key
+	}
+	key := newKey()
+	callback, err := knownHostsCallback(knownhosts.Line([]string{"sftp.example.com"}, key) + "\n")
+	require.NoError(t, err)
+	addr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 22}
+
+	require.NoError(t, callback("sftp.example.com:22", addr, key))
+	require.Error(t, callback("sftp.example.com:22", addr, newKey()))
+	require.Error(t, callback("other.example.com:22", addr, key))
+}
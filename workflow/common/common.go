@@ -29,6 +29,18 @@ const (
 	AnnotationKeyNodeType = workflow.WorkflowFullName + "/node-type"
 	// AnnotationKeyNodeStartTime is the node's start timestamp.
 	AnnotationKeyNodeStartTime = workflow.WorkflowFullName + "/node-start-time"
+	// AnnotationKeyNodeTemplateName is the name of the template of the node, on node events
+	AnnotationKeyNodeTemplateName = workflow.WorkflowFullName + "/node-template-name"
+	// AnnotationKeyNodeRetryAttempt is the attempt, starting at 0, of a node that is retried, on node events
+	AnnotationKeyNodeRetryAttempt = workflow.WorkflowFullName + "/node-retry-attempt"
+	// AnnotationKeyDuration is the duration in seconds of a completed workflow or node, on its events
+	AnnotationKeyDuration = workflow.WorkflowFullName + "/duration"
+	// AnnotationKeyOwnerTeam is the team that owns the workflow, on workflow and node events
+	AnnotationKeyOwnerTeam = workflow.WorkflowFullName + "/owner-team"
+	// AnnotationKeyOwnerEmail is the email address of the owner of the workflow, on workflow and node events
+	AnnotationKeyOwnerEmail = workflow.WorkflowFullName + "/owner-email"
+	// AnnotationKeyOwnerSlackChannel is the Slack channel of the owner of the workflow, on workflow and node events
+	AnnotationKeyOwnerSlackChannel = workflow.WorkflowFullName + "/owner-slack-channel"
 
 	// AnnotationKeyRBACRule is a rule to match the claims
 	AnnotationKeyRBACRule           = workflow.WorkflowFullName + "/rbac-rule"
@@ -46,12 +58,25 @@ const (
 	// AnnotationKeyPodNameVersion stores the pod naming convention version
 	AnnotationKeyPodNameVersion = workflow.WorkflowFullName + "/pod-name-format"
 
+	// AnnotationKeyPodNameTemplate is the template of the names of the pods of the workflow, e.g.
+	// "{{workflow.name}}-{{template.name}}-{{node.hash}}", which takes precedence over the pod name version
+	AnnotationKeyPodNameTemplate = workflow.WorkflowFullName + "/pod-name-template"
+
 	// AnnotationKeyProgress is N/M progress for the node
 	AnnotationKeyProgress = workflow.WorkflowFullName + "/progress"
 
 	// AnnotationKeyArtifactGCStrategy is listed as an annotation on the Artifact GC Pod to identify
 	// the strategy whose artifacts are being deleted
 	AnnotationKeyArtifactGCStrategy = workflow.WorkflowFullName + "/artifact-gc-strategy"
+	// AnnotationKeyArtifactRepositories is the comma-separated artifact repositories that the pod loads or saves
+	// artifacts with, which the controller's circuit breaker counts the failures and successes of the pod towards
+	AnnotationKeyArtifactRepositories = workflow.WorkflowFullName + "/artifact-repositories"
+	// AnnotationKeyArtifactGCRequest is the comma-separated output artifacts of a completed workflow, as
+	// nodeID/artifactName, that have been requested to be deleted, e.g. by `argo artifact rm`
+	AnnotationKeyArtifactGCRequest = workflow.WorkflowFullName + "/artifact-gc-request"
+	// AnnotationKeySuspendAfter is the JSON list of nodes, by ID, name or display name, that the workflow is suspended
+	// after, once any of them has completed, e.g. by `argo suspend --after`
+	AnnotationKeySuspendAfter = workflow.WorkflowFullName + "/suspend-after"
 
 	// LabelParallelismLimit is a label applied on namespace objects to control the per namespace parallelism.
 	LabelParallelismLimit = workflow.WorkflowFullName + "/parallelism-limit"
@@ -60,6 +85,14 @@ const (
 	// the strategy for the pod, in case the pod is orphaned from its workflow
 	AnnotationKeyPodGCStrategy = workflow.WorkflowFullName + "/pod-gc-strategy"
 
+	// AnnotationKeyLivenessFailureTimeout is how long the liveness probe of the main container may fail for before
+	// the controller fails the node of the pod
+	AnnotationKeyLivenessFailureTimeout = workflow.WorkflowFullName + "/liveness-failure-timeout"
+
+	// AnnotationKeyPreemptionPolicy is the preemption policy of the template of the pod, so that the controller can
+	// signal the pod to save its checkpoint when its Kubernetes node is about to be preempted
+	AnnotationKeyPreemptionPolicy = workflow.WorkflowFullName + "/preemption-policy"
+
 	// LabelKeyControllerInstanceID is the label the controller will carry forward to workflows/pod labels
 	// for the purposes of workflow segregation
 	LabelKeyControllerInstanceID = workflow.WorkflowFullName + "/controller-instanceid"
@@ -72,6 +105,12 @@ const (
 	LabelKeyActorEmail             = workflow.WorkflowFullName + "/actor-email"
 	LabelKeyActorPreferredUsername = workflow.WorkflowFullName + "/actor-preferred-username"
 	LabelKeyAction                 = workflow.WorkflowFullName + "/action"
+	// LabelKeySubmissionID is the tracking ID of a workflow submitted durably, which may have been queued whilst the
+	// Kubernetes API was unavailable
+	LabelKeySubmissionID = workflow.WorkflowFullName + "/submission-id"
+	// LabelKeyAwaitingEvent is the label of workflows that have had a suspend node that is resumed by an event, so
+	// that events only list those workflows
+	LabelKeyAwaitingEvent = workflow.WorkflowFullName + "/awaiting-event"
 	// LabelKeyCompleted is the metadata label applied on workflows and workflow pods to indicates if resource is completed
 	// Workflows and pods with a completed=true label will be ignored by the controller.
 	// See also `LabelKeyWorkflowArchivingStatus`.
@@ -85,6 +124,13 @@ const (
 	LabelKeyWorkflowArchivingStatus = workflow.WorkflowFullName + "/workflow-archiving-status"
 	// LabelKeyWorkflow is the pod metadata label to indicate the associated workflow name
 	LabelKeyWorkflow = workflow.WorkflowFullName + "/workflow"
+	// LabelKeyJobNode is the label of the Job of a job or gang template, and of the service of a gang, which identifies its node
+	LabelKeyJobNode = workflow.WorkflowFullName + "/job-node"
+	// LabelKeyGang is the label of the pods of the gang of a gang template, which its headless service selects
+	LabelKeyGang = workflow.WorkflowFullName + "/gang"
+	// LabelKeyImagePrePull is the label of the pods of the DaemonSet that pre-pulls the images of a cron workflow,
+	// which the DaemonSet selects
+	LabelKeyImagePrePull = workflow.WorkflowFullName + "/image-pre-pull"
 	// LabelKeyComponent determines what component within a workflow, intentionally similar to app.kubernetes.io/component.
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
 	LabelKeyComponent = workflow.WorkflowFullName + "/component"
@@ -113,10 +159,22 @@ const (
 	// LabelKeyCronWorkflowBackfill is a label applied to the cron workflow when the workflow is created by backfill
 	LabelKeyCronWorkflowBackfill = workflow.WorkflowFullName + "/backfill"
 
+	// LabelKeyAPITokenServiceAccount is a label applied to the secrets that API tokens are bound to, naming the service
+	// account of the token
+	LabelKeyAPITokenServiceAccount = workflow.WorkflowFullName + "/api-token-service-account"
+	// AnnotationKeyAPITokenDescription describes what an API token is for
+	AnnotationKeyAPITokenDescription = workflow.WorkflowFullName + "/api-token-description"
+	// AnnotationKeyAPITokenExpirationTimestamp is when an API token expires
+	AnnotationKeyAPITokenExpirationTimestamp = workflow.WorkflowFullName + "/api-token-expiration-timestamp"
+
 	// ExecutorArtifactBaseDir is the base directory in the init container in which artifacts will be copied to.
 	// Each artifact will be named according to its input name (e.g: /argo/inputs/artifacts/CODE)
 	ExecutorArtifactBaseDir = "/argo/inputs/artifacts"
 
+	// ExecutorArtifactMemoryDir is the directory in the init container of the memory-backed volume that artifacts with
+	// stageInMemory are copied to, named according to their input name like in ExecutorArtifactBaseDir
+	ExecutorArtifactMemoryDir = "/argo/inputs/artifacts-memory"
+
 	// ExecutorMainFilesystemDir is a path made available to the init/wait containers such that they
 	// can access the same volume mounts used in the main container. This is used for the purposes
 	// of artifact loading (when there is overlapping paths between artifacts and volume mounts),
@@ -174,10 +232,31 @@ const (
 	EnvVarDefaultRequeueTime = "DEFAULT_REQUEUE_TIME"
 	// EnvVarPodStatusCaptureFinalizer is used to prevent pod garbage collected before argo captures its exit status
 	EnvVarPodStatusCaptureFinalizer = "ARGO_POD_STATUS_CAPTURE_FINALIZER"
+	// EnvVarArtifactCacheDir is the directory that input artifacts are cached in
+	EnvVarArtifactCacheDir = "ARGO_ARTIFACT_CACHE_DIR"
+	// EnvVarArtifactCacheMaxSize is the size (e.g. 10Gi) that the artifact cache directory is trimmed to after a download
+	EnvVarArtifactCacheMaxSize = "ARGO_ARTIFACT_CACHE_MAX_SIZE"
+	// EnvVarArtifactRetryMaxAttempts is the most times that a load or save of an artifact is attempted
+	EnvVarArtifactRetryMaxAttempts = "ARGO_ARTIFACT_RETRY_MAX_ATTEMPTS"
+	// EnvVarArtifactRetryMaxBackoff caps the time between two attempts of a load or save of an artifact
+	EnvVarArtifactRetryMaxBackoff = "ARGO_ARTIFACT_RETRY_MAX_BACKOFF"
+	// EnvVarGangName is the name of the gang of a pod of a gang template, which is the subdomain of the hostnames of
+	// its pods
+	EnvVarGangName = "ARGO_GANG_NAME"
+	// EnvVarGangSize is the number of pods of the gang of a pod of a gang template
+	EnvVarGangSize = "ARGO_GANG_SIZE"
+	// EnvVarGangRank is the rank of a pod of a gang template, from 0 to the size of its gang - 1
+	EnvVarGangRank = "ARGO_GANG_RANK"
+	// EnvVarGangLeader is the address of the leader of the gang of a pod of a gang template, which is its pod of rank 0
+	EnvVarGangLeader = "ARGO_GANG_LEADER"
 	// EnvAgentTaskWorkers is the number of task workers for the agent pod
 	EnvAgentTaskWorkers = "ARGO_AGENT_TASK_WORKERS"
 	// EnvAgentPatchRate is the rate that the Argo Agent will patch the Workflow TaskSet
 	EnvAgentPatchRate = "ARGO_AGENT_PATCH_RATE"
+	// EnvAgentDefaultTimeout is the timeout of the requests and calls of the Argo Agent whose templates do not set one
+	EnvAgentDefaultTimeout = "ARGO_AGENT_DEFAULT_TIMEOUT"
+	// EnvAgentMaxTimeout is the longest timeout of the requests and calls of the Argo Agent
+	EnvAgentMaxTimeout = "ARGO_AGENT_MAX_TIMEOUT"
 
 	// Finalizer to block deletion of the workflow if deletion of artifacts fail for some reason.
 	FinalizerArtifactGC = workflow.WorkflowFullName + "/artifact-gc"
@@ -203,6 +282,12 @@ const (
 	GlobalVarWorkflowCreationTimestamp = "workflow.creationTimestamp"
 	// GlobalVarWorkflowPriority is the workflow variable referencing the workflow's priority field
 	GlobalVarWorkflowPriority = "workflow.priority"
+	// GlobalVarWorkflowOwnerTeam is the workflow variable referencing the team of the workflow's owner
+	GlobalVarWorkflowOwnerTeam = "workflow.owner.team"
+	// GlobalVarWorkflowOwnerEmail is the workflow variable referencing the email address of the workflow's owner
+	GlobalVarWorkflowOwnerEmail = "workflow.owner.email"
+	// GlobalVarWorkflowOwnerSlackChannel is the workflow variable referencing the Slack channel of the workflow's owner
+	GlobalVarWorkflowOwnerSlackChannel = "workflow.owner.slackChannel"
 	// GlobalVarWorkflowFailures is a global variable of a JSON map referencing the workflow's failed nodes
 	GlobalVarWorkflowFailures = "workflow.failures"
 	// GlobalVarWorkflowDuration is the current duration of this workflow
@@ -252,6 +337,13 @@ const (
 	LocalVarRetriesLastDuration = "lastRetry.duration"
 	// LocalVarRetriesLastMessage is a variable that references information about the last retry's failure message
 	LocalVarRetriesLastMessage = "lastRetry.message"
+	// LocalVarRetriesLastExitCodeClass is a variable that references the class of the last retry's exit code, e.g. transient
+	LocalVarRetriesLastExitCodeClass = "lastRetry.exitCodeClass"
+	// LocalVarRetriesLastOutputs is the prefix of the variables that reference the last retry's result and output parameters
+	LocalVarRetriesLastOutputs = "lastRetry.outputs"
+	// LocalVarRetriesAttempts is a variable that references the exit code, its class, status, duration and message of
+	// each attempt of the retried node, oldest first
+	LocalVarRetriesAttempts = "attempts"
 
 	KubeConfigDefaultMountPath    = "/kube/config"
 	KubeConfigDefaultVolumeName   = "kubeconfig"
@@ -261,9 +353,34 @@ const (
 	EnvConfigMountPath            = "/argo/config"
 	EnvVarTemplateOffloaded       = "offloaded"
 
+	// ArtifactVolumeMountPath is the path that the volumes of filesystem artifacts are mounted under, by their name
+	ArtifactVolumeMountPath = "/argo/artifact-volumes"
+	// ArtifactVolumePrefix is the prefix of the names of the pod volumes of filesystem artifacts
+	ArtifactVolumePrefix = "artifacts-"
+	// ArtifactRepositoryUnavailableMessage is in the message of the error of a load or save of an artifact that still
+	// failed transiently once its retries were used up, which the controller counts towards opening its circuit
+	// breaker for the artifact repository
+	ArtifactRepositoryUnavailableMessage = "artifact repository unavailable"
+	// ArtifactRepositoryQuotaExceededMessage is in the message of the error of a save of an artifact that failed because
+	// the artifact repository is out of space, or over its quota, which the controller reports as a condition of the
+	// workflow
+	ArtifactRepositoryQuotaExceededMessage = "artifact repository quota exceeded"
+	// PreemptedMessage is the message of a node whose pod was stopped because its Kubernetes node was preempted, which
+	// the controller restarts from its checkpoint
+	PreemptedMessage = "pod was preempted, restarting from its checkpoint"
+
 	// CACertificatesVolumeMountName is the name of the secret that contains the CA certificates.
 	CACertificatesVolumeMountName = "argo-workflows-agent-ca-certificates"
 
+	// TrustedCAVolumeName is the name of the volume containing the trusted CA bundle from the controller's config
+	TrustedCAVolumeName = "argo-trusted-ca"
+	// TrustedCAMountPath is the directory that the trusted CA bundle is mounted into
+	TrustedCAMountPath = "/etc/argo/trusted-ca"
+	// TrustedCAFileName is the name of the trusted CA bundle file
+	TrustedCAFileName = "ca-bundle.crt"
+	// EnvVarSSLCertFile is the path of the CA certificates file, read by Go, OpenSSL, and other TLS libraries
+	EnvVarSSLCertFile = "SSL_CERT_FILE"
+
 	// VarRunArgoPath is the standard path for the shared volume
 	VarRunArgoPath = "/var/run/argo"
 
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -11,12 +12,21 @@ import (
 	kjson "sigs.k8s.io/json"
 	"sigs.k8s.io/yaml"
 
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
 	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	jsonpkg "github.com/argoproj/argo-workflows/v3/util/json"
+	"github.com/argoproj/argo-workflows/v3/util/suggest"
 )
 
-var yamlSeparator = regexp.MustCompile(`\n---`)
+var (
+	yamlSeparator = regexp.MustCompile(`\n---`)
+	// unknownFieldRegex matches the strict decoding error of an unknown field, e.g. `unknown field "spec.entrypoints"`
+	unknownFieldRegex = regexp.MustCompile(`unknown field "(.*)"$`)
+	// fieldIndexRegex matches the indexes of a list field in a path, e.g. `[0][1]` of `steps[0][1]`
+	fieldIndexRegex   = regexp.MustCompile(`(\[\d+\])+$`)
+	parallelStepsType = reflect.TypeOf(wfv1.ParallelSteps{})
+)
 
 type ParseResult struct {
 	Object metav1.Object
@@ -104,7 +114,9 @@ func toWorkflowTypeJSON(body []byte, kind string, strict bool) (metav1.Object, e
 			// fatal decoding error, not due to strictness
 			return v, err
 		}
-		strictErrs = append(strictErrs, strictJSONErrs...)
+		for _, strictErr := range strictJSONErrs {
+			strictErrs = append(strictErrs, toFieldError(v, strictErr))
+		}
 
 		if len(strictErrs) > 0 {
 			// return the successfully decoded object along with the strict errors
@@ -116,6 +128,98 @@ func toWorkflowTypeJSON(body []byte, kind string, strict bool) (metav1.Object, e
 	return v, jsonpkg.Unmarshal(body, v)
 }
 
+// toFieldError returns the strict decoding error of an unknown field of the object as a FieldError, suggesting the
+// field that was probably meant
+func toFieldError(obj interface{}, err error) error {
+	match := unknownFieldRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	path := match[1]
+	parent, name := "", path
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		parent, name = path[:i], path[i+1:]
+	}
+	suggestion := suggest.Closest(name, jsonFieldNames(reflect.TypeOf(obj), parent))
+	return argoerrors.NewFieldError(path, name, suggestion, err.Error())
+}
+
+// jsonFieldNames returns the JSON names of the fields of the struct at the path in the type, or nil if there is none
+func jsonFieldNames(t reflect.Type, path string) []string {
+	if path != "" {
+		for _, segment := range strings.Split(path, ".") {
+			indexes := fieldIndexRegex.FindString(segment)
+			t = jsonField(t, strings.TrimSuffix(segment, indexes))
+			for range strings.Count(indexes, "[") {
+				t = elem(t)
+				// parallel steps are a list in JSON
+				if t == parallelStepsType {
+					t = parallelStepsType.Field(0).Type
+				}
+				if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+					return nil
+				}
+				t = t.Elem()
+			}
+			if t == nil {
+				return nil
+			}
+		}
+	}
+	t = elem(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+		case name == "" && f.Anonymous:
+			names = append(names, jsonFieldNames(f.Type, "")...)
+		case name != "":
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jsonField returns the type of the field with the JSON name of the struct, or of the values of the map
+func jsonField(t reflect.Type, name string) reflect.Type {
+	t = elem(t)
+	if t == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Map {
+		return t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tagName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tagName == name {
+			return f.Type
+		}
+		if tagName == "" && f.Anonymous {
+			if ft := jsonField(f.Type, name); ft != nil {
+				return ft
+			}
+		}
+	}
+	return nil
+}
+
+// elem returns the type that the pointer type points to, or the type if it is not a pointer
+func elem(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
 // SplitWorkflowYAMLFile is a helper to split a body into multiple workflow objects
 func SplitWorkflowYAMLFile(body []byte, strict bool) ([]wfv1.Workflow, error) {
 	manifests := make([]wfv1.Workflow, 0)
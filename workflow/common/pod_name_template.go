@@ -0,0 +1,38 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Variables of pod name templates
+const (
+	PodNameVarWorkflowName = "{{workflow.name}}"
+	PodNameVarTemplateName = "{{template.name}}"
+	PodNameVarNodeID       = "{{node.id}}"
+	// PodNameVarNodeHash is the hash of the name of the node that v2 pod names end with
+	PodNameVarNodeHash = "{{node.hash}}"
+	// PodNameVarRetryAttempt is the number of the retry attempt of the node, starting at 0
+	PodNameVarRetryAttempt = "{{retry.attempt}}"
+)
+
+var podNameVars = []string{PodNameVarWorkflowName, PodNameVarTemplateName, PodNameVarNodeID, PodNameVarNodeHash, PodNameVarRetryAttempt}
+
+// ValidatePodNameTemplate returns an error if the pod name template uses an unknown variable, or would not give the
+// pods of a workflow unique names because it uses neither {{node.hash}} nor {{node.id}}
+func ValidatePodNameTemplate(podNameTemplate string) error {
+	if podNameTemplate == "" {
+		return nil
+	}
+	if !strings.Contains(podNameTemplate, PodNameVarNodeHash) && !strings.Contains(podNameTemplate, PodNameVarNodeID) {
+		return fmt.Errorf("pod name template %q must contain %s or %s", podNameTemplate, PodNameVarNodeHash, PodNameVarNodeID)
+	}
+	unresolved := podNameTemplate
+	for _, v := range podNameVars {
+		unresolved = strings.ReplaceAll(unresolved, v, "")
+	}
+	if strings.Contains(unresolved, "{{") {
+		return fmt.Errorf("pod name template %q has an unknown variable, the variables are %s", podNameTemplate, strings.Join(podNameVars, ", "))
+	}
+	return nil
+}
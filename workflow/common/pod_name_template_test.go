@@ -0,0 +1,16 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePodNameTemplate(t *testing.T) {
+	require.NoError(t, ValidatePodNameTemplate(""))
+	require.NoError(t, ValidatePodNameTemplate("{{workflow.name}}-{{template.name}}-{{retry.attempt}}-{{node.hash}}"))
+	require.NoError(t, ValidatePodNameTemplate("{{node.id}}"))
+	assert.EqualError(t, ValidatePodNameTemplate("{{workflow.name}}-{{template.name}}"), `pod name template "{{workflow.name}}-{{template.name}}" must contain {{node.hash}} or {{node.id}}`)
+	assert.ErrorContains(t, ValidatePodNameTemplate("{{workflow.namespace}}-{{node.hash}}"), "has an unknown variable")
+}
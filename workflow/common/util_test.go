@@ -1,6 +1,7 @@
 package common
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,7 +9,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
@@ -111,6 +114,55 @@ func TestParseObjects(t *testing.T) {
 	assert.Empty(t, ParseObjects(invalidObj, false))
 }
 
+func TestParseObjectsStrict(t *testing.T) {
+	res := ParseObjects([]byte(`apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: strict
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    contianer:
+      image: argoproj/argosay:v2
+    garbage: true
+`), true)
+	require.Len(t, res, 1)
+	strictErr, ok := runtime.AsStrictDecodingError(res[0].Err)
+	require.True(t, ok)
+	errs := strictErr.Errors()
+	require.Len(t, errs, 2)
+	assert.Equal(t, argoerrors.FieldError{Path: "spec.templates[0].contianer", Value: "contianer", Suggestion: "container", Message: `unknown field "spec.templates[0].contianer"`}, errs[0])
+	assert.Equal(t, `unknown field "spec.templates[0].garbage"`, errs[1].Error())
+
+	res = ParseObjects([]byte(`apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: strict
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: a
+        tempalte: b
+`), true)
+	require.Len(t, res, 1)
+	require.EqualError(t, res[0].Err, `json: unknown field "tempalte", did you mean 'template'?`)
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	wfType := reflect.TypeOf(&wfv1.Workflow{})
+	assert.Contains(t, jsonFieldNames(wfType, ""), "spec")
+	assert.Contains(t, jsonFieldNames(wfType, ""), "kind")
+	assert.Contains(t, jsonFieldNames(wfType, "spec"), "entrypoint")
+	assert.Contains(t, jsonFieldNames(wfType, "spec.templates[0]"), "container")
+	assert.Contains(t, jsonFieldNames(wfType, "spec.templates[0].steps[0][1]"), "template")
+	assert.Contains(t, jsonFieldNames(wfType, "spec.hooks.exit"), "expression")
+	assert.Nil(t, jsonFieldNames(wfType, "spec.garbage"))
+	assert.Nil(t, jsonFieldNames(wfType, "spec.entrypoint"))
+}
+
 func TestGetTemplateHolderString(t *testing.T) {
 	assert.Equal(t, "*v1alpha1.DAGTask invalid (https://argo-workflows.readthedocs.io/en/latest/templates/)", GetTemplateHolderString(&wfv1.DAGTask{}))
 	assert.Equal(t, "*v1alpha1.DAGTask inlined", GetTemplateHolderString(&wfv1.DAGTask{Inline: &wfv1.Template{}}))
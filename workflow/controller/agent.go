@@ -136,6 +136,10 @@ func (woc *wfOperationCtx) createAgentPod(ctx context.Context) (*apiv1.Pod, erro
 		{Name: common.EnvAgentPatchRate, Value: env.LookupEnvStringOr(common.EnvAgentPatchRate, GetRequeueTime().String())},
 		{Name: common.EnvVarPluginAddresses, Value: wfv1.MustMarshallJSON(addresses(pluginSidecars))},
 		{Name: common.EnvVarPluginNames, Value: wfv1.MustMarshallJSON(names(pluginSidecars))},
+		{Name: common.EnvAgentDefaultTimeout, Value: woc.controller.Config.AgentTimeouts.GetDefault().String()},
+	}
+	if maxTimeout := woc.controller.Config.AgentTimeouts.GetMax(); maxTimeout > 0 {
+		envVars = append(envVars, apiv1.EnvVar{Name: common.EnvAgentMaxTimeout, Value: maxTimeout.String()})
 	}
 
 	// If the default number of task workers is overridden, then pass it to the agent pod.
@@ -229,6 +233,7 @@ func (woc *wfOperationCtx) createAgentPod(ctx context.Context) (*apiv1.Pod, erro
 	woc.addSchedulingConstraints(pod, woc.execWf.Spec.DeepCopy(), tmpl, "")
 	woc.addMetadata(pod, tmpl)
 	woc.addDNSConfig(pod)
+	woc.addPodNetworkConfig(pod)
 
 	if woc.execWf.Spec.HasPodSpecPatch() {
 		patchedPodSpec, err := util.ApplyPodSpecPatch(pod.Spec, woc.execWf.Spec.PodSpecPatch)
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	gosync "sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// artifactCircuitBreaker counts the pods in a row that failed because an artifact repository was unavailable, by
+// repository, and stops pods that use a repository from being created whilst its circuit is open
+type artifactCircuitBreaker struct {
+	circuits map[string]*artifactCircuit
+	mutex    gosync.Mutex
+}
+
+type artifactCircuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// recordFailure counts a pod that failed because the repository was unavailable, opening the circuit if enough pods
+// in a row did
+func (b *artifactCircuitBreaker) recordFailure(repository string, cfg *config.ArtifactCircuitBreaker, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.circuits == nil {
+		b.circuits = map[string]*artifactCircuit{}
+	}
+	c, ok := b.circuits[repository]
+	if !ok {
+		c = &artifactCircuit{}
+		b.circuits[repository] = c
+	}
+	c.failures++
+	if c.failures >= cfg.GetFailureThreshold() && !now.Before(c.openUntil) {
+		c.openUntil = now.Add(cfg.GetOpenDuration())
+	}
+}
+
+// recordSuccess closes the circuit of the repository, as a pod used it successfully
+func (b *artifactCircuitBreaker) recordSuccess(repository string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.circuits, repository)
+}
+
+// openUntil returns when the circuit of the repository closes, or the zero time if it is closed
+func (b *artifactCircuitBreaker) openUntil(repository string, now time.Time) time.Time {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if c, ok := b.circuits[repository]; ok && now.Before(c.openUntil) {
+		return c.openUntil
+	}
+	return time.Time{}
+}
+
+// templateArtifactRepositories returns the repositories that the pod of the template loads or saves artifacts with,
+// once its archive location has been added
+func templateArtifactRepositories(tmpl *wfv1.Template) []string {
+	var repositories []string
+	add := func(l *wfv1.ArtifactLocation) {
+		// raw artifacts are not stored anywhere
+		if l == nil || l.Raw != nil {
+			return
+		}
+		if name := artifactRepositoryName(l, tmpl.ArchiveLocation); name != "" && !slices.Contains(repositories, name) {
+			repositories = append(repositories, name)
+		}
+	}
+	for _, art := range append(tmpl.Inputs.Artifacts, tmpl.Outputs.Artifacts...) {
+		if art.HasLocation() || art.Raw != nil {
+			add(&art.ArtifactLocation)
+		} else {
+			add(tmpl.ArchiveLocation)
+		}
+	}
+	if tmpl.ArchiveLocation != nil && tmpl.ArchiveLocation.IsArchiveLogs() {
+		add(tmpl.ArchiveLocation)
+	}
+	slices.Sort(repositories)
+	return repositories
+}
+
+// checkArtifactCircuits returns why the pod of the template must not be created, as the circuit of one of its
+// artifact repositories is open, and annotates the pod with its repositories otherwise, for the circuit breaker and
+// the pauses of repositories that are out of space
+func (woc *wfOperationCtx) checkArtifactCircuits(pod *apiv1.Pod, tmpl *wfv1.Template) string {
+	if woc.controller.Config.ArtifactOperations.GetCircuitBreaker() == nil && woc.controller.Config.ArtifactOperations.GetQuotaPause() == nil {
+		return ""
+	}
+	repositories := templateArtifactRepositories(tmpl)
+	if len(repositories) == 0 {
+		return ""
+	}
+	now := time.Now()
+	for _, repository := range repositories {
+		if until := woc.controller.artifactCircuits.openUntil(repository, now); !until.IsZero() {
+			return fmt.Sprintf("artifact repository %s is unavailable, so pods that use it fail until %s", repository, until.UTC().Format(time.RFC3339))
+		}
+	}
+	pod.Annotations[common.AnnotationKeyArtifactRepositories] = strings.Join(repositories, ",")
+	return ""
+}
+
+// recordArtifactCircuits counts the node of the pod, which has just completed, towards the circuits of the artifact
+// repositories of the pod
+func (woc *wfOperationCtx) recordArtifactCircuits(pod *apiv1.Pod, node *wfv1.NodeStatus) {
+	cfg := woc.controller.Config.ArtifactOperations.GetCircuitBreaker()
+	if cfg == nil || pod == nil || pod.Annotations[common.AnnotationKeyArtifactRepositories] == "" {
+		return
+	}
+	for _, repository := range strings.Split(pod.Annotations[common.AnnotationKeyArtifactRepositories], ",") {
+		switch {
+		case node.Succeeded():
+			woc.controller.artifactCircuits.recordSuccess(repository)
+		case strings.Contains(node.Message, common.ArtifactRepositoryUnavailableMessage):
+			woc.log.WithField("artifactRepository", repository).WithField("nodeID", node.ID).Warn("Pod failed as its artifact repository was unavailable")
+			woc.controller.artifactCircuits.recordFailure(repository, cfg, time.Now())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestArtifactCircuitBreaker(t *testing.T) {
+	cfg := &config.ArtifactCircuitBreaker{FailureThreshold: 2}
+	now := time.Now()
+	b := &artifactCircuitBreaker{}
+	assert.True(t, b.openUntil("s3://my-bucket", now).IsZero())
+	b.recordFailure("s3://my-bucket", cfg, now)
+	assert.True(t, b.openUntil("s3://my-bucket", now).IsZero(), "one failure does not open the circuit")
+	b.recordFailure("s3://my-bucket", cfg, now)
+	assert.Equal(t, now.Add(time.Minute), b.openUntil("s3://my-bucket", now))
+	assert.True(t, b.openUntil("gcs://my-bucket", now).IsZero(), "circuits are by repository")
+
+	later := now.Add(time.Minute)
+	assert.True(t, b.openUntil("s3://my-bucket", later).IsZero(), "the circuit closes")
+	b.recordFailure("s3://my-bucket", cfg, later)
+	assert.Equal(t, later.Add(time.Minute), b.openUntil("s3://my-bucket", later), "a single failure opens it again")
+
+	b.recordSuccess("s3://my-bucket")
+	assert.True(t, b.openUntil("s3://my-bucket", later).IsZero(), "a success closes the circuit")
+}
+
+var artifactCircuitWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-circuit
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+    outputs:
+      artifacts:
+      - name: out
+        path: /tmp/out
+`
+
+func TestArtifactCircuits(t *testing.T) {
+	operate := func(t *testing.T, open bool) *wfOperationCtx {
+		t.Helper()
+		wf := wfv1.MustUnmarshalWorkflow(artifactCircuitWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.Config.ArtifactOperations = &config.ArtifactOperationsConfig{CircuitBreaker: &config.ArtifactCircuitBreaker{FailureThreshold: 1}}
+		if open {
+			controller.artifactCircuits.recordFailure("s3://my-bucket", controller.Config.ArtifactOperations.CircuitBreaker, time.Now())
+		}
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(context.Background())
+		return woc
+	}
+
+	t.Run("Closed", func(t *testing.T) {
+		woc := operate(t, false)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		require.Len(t, pods.Items, 1)
+		assert.Equal(t, "s3://my-bucket", pods.Items[0].Annotations[common.AnnotationKeyArtifactRepositories])
+	})
+	t.Run("Open", func(t *testing.T) {
+		woc := operate(t, true)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		assert.Empty(t, pods.Items)
+		node := woc.wf.Status.Nodes.FindByDisplayName("artifact-circuit")
+		require.NotNil(t, node)
+		assert.Equal(t, wfv1.NodeFailed, node.Phase)
+		assert.Contains(t, node.Message, "artifact repository s3://my-bucket is unavailable")
+	})
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+// newArtifactDriver is a variable so that tests can fake the artifact drivers
+var newArtifactDriver artifact.NewDriverFunc = artifact.NewDriver
+
+// checkInputArtifactsExist returns why the pod of the template cannot load its input artifacts, if one of its
+// required input artifacts does not exist, so that the node fails before the pod is scheduled rather than once its
+// init container tries to load the artifact. Artifacts whose existence cannot be checked, because their driver
+// cannot check or the check fails, are assumed to exist, and left for the pod to load.
+func (woc *wfOperationCtx) checkInputArtifactsExist(ctx context.Context, tmpl *wfv1.Template) string {
+	for _, art := range tmpl.Inputs.Artifacts {
+		if art.Optional || !art.HasLocationOrKey() {
+			continue
+		}
+		exists, err := woc.inputArtifactExists(ctx, tmpl, &art)
+		if err != nil {
+			woc.log.WithError(err).WithField("artifactName", art.Name).Warn("Failed to check whether input artifact exists")
+			continue
+		}
+		if !exists {
+			key, _ := art.GetKey()
+			return fmt.Sprintf("input artifact %q does not exist (key %q)", art.Name, key)
+		}
+	}
+	return ""
+}
+
+// inputArtifactExists returns whether the artifact exists: a file by its key, or a directory by the files listed with
+// its key as their prefix
+func (woc *wfOperationCtx) inputArtifactExists(ctx context.Context, tmpl *wfv1.Template, art *wfv1.Artifact) (bool, error) {
+	driverArt := art.DeepCopy()
+	if err := driverArt.Relocate(tmpl.ArchiveLocation); err != nil {
+		return false, err
+	}
+	drv, err := newArtifactDriver(ctx, driverArt, artifactResources{woc.controller.kubeclientset, woc.wf.Namespace})
+	if err != nil {
+		return false, err
+	}
+	exists, err := artifactcommon.Exists(drv, driverArt)
+	if err != nil || exists {
+		return exists, err
+	}
+	if !drv.Capabilities().ListObjects {
+		// the driver may not be able to check whether files exist either
+		return true, nil
+	}
+	files, err := drv.ListObjects(driverArt)
+	if err != nil {
+		return false, err
+	}
+	return len(files) > 0, nil
+}
+
+// artifactResources reads the secrets and config maps of the artifacts of a workflow from its namespace
+type artifactResources struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func (r artifactResources) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeClient.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r artifactResources) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeClient.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
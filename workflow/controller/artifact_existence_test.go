@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+// existenceArtifactDriver has the files of its keys, and lists the files of directories if it can list objects
+type existenceArtifactDriver struct {
+	artifactcommon.ArtifactDriver
+	keys        []string
+	listObjects bool
+}
+
+func (d existenceArtifactDriver) Exists(a *wfv1.Artifact) (bool, error) {
+	return slices.Contains(d.keys, a.S3.Key), nil
+}
+
+func (d existenceArtifactDriver) ListObjects(a *wfv1.Artifact) ([]string, error) {
+	var files []string
+	for _, key := range d.keys {
+		if strings.HasPrefix(key, a.S3.Key+"/") {
+			files = append(files, key)
+		}
+	}
+	return files, nil
+}
+
+func (d existenceArtifactDriver) Capabilities() artifactcommon.Capabilities {
+	return artifactcommon.Capabilities{ListObjects: d.listObjects}
+}
+
+func fakeArtifactDrivers(t *testing.T, drv artifactcommon.ArtifactDriver) {
+	old := newArtifactDriver
+	newArtifactDriver = func(context.Context, *wfv1.Artifact, resource.Interface) (artifactcommon.ArtifactDriver, error) {
+		return drv, nil
+	}
+	t.Cleanup(func() { newArtifactDriver = old })
+}
+
+var checkInputArtifactsWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: check-input-artifacts
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    inputs:
+      artifacts:
+      - name: in
+        path: /tmp/in
+        s3:
+          key: missing.txt
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestCheckInputArtifactsExist(t *testing.T) {
+	fakeArtifactDrivers(t, existenceArtifactDriver{keys: []string{"file.txt", "dir/file.txt"}, listObjects: true})
+	wf := wfv1.MustUnmarshalWorkflow(checkInputArtifactsWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	ctx := context.Background()
+	check := func(key string, optional bool) string {
+		tmpl := &wfv1.Template{
+			Inputs:          wfv1.Inputs{Artifacts: wfv1.Artifacts{{Name: "in", Optional: optional, ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: key}}}}},
+			ArchiveLocation: &wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}}},
+		}
+		return woc.checkInputArtifactsExist(ctx, tmpl)
+	}
+	assert.Empty(t, check("file.txt", false))
+	assert.Empty(t, check("dir", false), "a directory exists if it has files")
+	assert.Empty(t, check("missing.txt", true), "optional artifacts are not checked")
+	assert.Equal(t, `input artifact "in" does not exist (key "missing.txt")`, check("missing.txt", false))
+
+	fakeArtifactDrivers(t, existenceArtifactDriver{})
+	assert.Empty(t, check("dir", false), "an artifact is assumed to exist if its driver cannot list objects")
+}
+
+func TestCheckInputArtifacts(t *testing.T) {
+	fakeArtifactDrivers(t, existenceArtifactDriver{listObjects: true})
+	wf := wfv1.MustUnmarshalWorkflow(checkInputArtifactsWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.Config.CheckInputArtifacts = true
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	node := woc.wf.Status.Nodes.FindByDisplayName("check-input-artifacts")
+	require.NotNil(t, node)
+	assert.Equal(t, wfv1.NodeFailed, node.Phase)
+	assert.Equal(t, `input artifact "in" does not exist (key "missing.txt")`, node.Message)
+	pods, err := controller.kubeclientset.CoreV1().Pods(wf.Namespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items, "no pod is created")
+}
@@ -59,6 +59,11 @@ func (woc *wfOperationCtx) garbageCollectArtifacts(ctx context.Context) error {
 		return nil
 	}
 
+	// ephemeral artifacts are deleted first, so that the strategies do not start up Pods to delete them
+	if err := woc.deleteEphemeralArtifacts(ctx); err != nil {
+		return err
+	}
+
 	// based on current state of Workflow, which Artifact GC Strategies can be processed now?
 	strategies := woc.artifactGCStrategiesReady()
 	for strategy := range strategies {
@@ -73,13 +78,26 @@ func (woc *wfOperationCtx) garbageCollectArtifacts(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	err = woc.retryFailedArtifactGC(ctx)
+	if err != nil {
+		return err
+	}
+	err = woc.garbageCollectExpiredArtifacts(ctx)
+	if err != nil {
+		return err
+	}
+	return woc.garbageCollectRequestedArtifacts(ctx)
 }
 
 func (woc *wfOperationCtx) HasArtifactGC() bool {
 	// ArtifactGC can be defined on the Workflow level or on the Artifact level
 	// It may be defined in the Workflow itself or in a WorkflowTemplate referenced by the Workflow
 
+	// ephemeral artifacts are deleted by the controller once the Workflow completes
+	if woc.execWf.Spec.ArtifactGC.IsEphemeral() {
+		return true
+	}
+
 	// woc.execWf.Spec.Templates includes templates described directly in the Workflow as well as templates
 	// in a WorkflowTemplate that the entire Workflow is based on
 	for _, template := range woc.execWf.Spec.Templates {
@@ -101,6 +119,22 @@ func (woc *wfOperationCtx) HasArtifactGC() bool {
 		}
 	}
 
+	// artifacts with a retention are deleted by the controller without a strategy
+	for _, template := range woc.execWf.Spec.Templates {
+		for _, artifact := range template.Outputs.Artifacts {
+			if woc.getArtifactRetention(&artifact) != nil {
+				return true
+			}
+		}
+	}
+	for _, template := range woc.wf.Status.StoredTemplates {
+		for _, artifact := range template.Outputs.Artifacts {
+			if woc.getArtifactRetention(&artifact) != nil {
+				return true
+			}
+		}
+	}
+
 	return false
 }
 
@@ -132,8 +166,6 @@ func (woc *wfOperationCtx) processArtifactGCStrategy(ctx context.Context, strate
 		woc.updated = true
 	}()
 
-	var err error
-
 	woc.log.Debugf("processing Artifact GC Strategy %s", strategy)
 
 	// Search for artifacts
@@ -143,6 +175,14 @@ func (woc *wfOperationCtx) processArtifactGCStrategy(ctx context.Context, strate
 		return nil
 	}
 
+	return woc.deleteArtifacts(ctx, strategy, artifactSearchResults, "")
+}
+
+// start up Pods to delete the artifacts; podNameSuffix distinguishes the Pods of a strategy that may be processed
+// more than once
+func (woc *wfOperationCtx) deleteArtifacts(ctx context.Context, strategy wfv1.ArtifactGCStrategy, artifactSearchResults wfv1.ArtifactSearchResults, podNameSuffix string) error {
+	var err error
+
 	// cache the templates by name so we can find them easily
 	templatesByName := make(map[string]*wfv1.Template)
 
@@ -167,6 +207,9 @@ func (woc *wfOperationCtx) processArtifactGCStrategy(ctx context.Context, strate
 		if err != nil {
 			return err
 		}
+		if podNameSuffix != "" {
+			podName += "-" + podNameSuffix
+		}
 		if _, found := podNames[podName]; !found {
 			podNames[podName] = podInfo
 		}
@@ -201,6 +244,10 @@ func (woc *wfOperationCtx) processArtifactGCStrategy(ctx context.Context, strate
 
 	// start up a separate Pod with a separate set of ArtifactGCTasks for it to use for each unique Service Account/metadata
 	for podName, templatesToArtList := range groupedByPod {
+		if woc.wf.Status.ArtifactGCStatus.IsArtifactGCPodRecouped(podName) {
+			woc.log.Debugf("Artifact GC Pod %s already completed", podName)
+			continue
+		}
 		tasks := make([]*wfv1.WorkflowArtifactGCTask, 0)
 
 		for templateName, artifacts := range templatesToArtList {
@@ -222,6 +269,7 @@ func (woc *wfOperationCtx) processArtifactGCStrategy(ctx context.Context, strate
 				return fmt.Errorf("can't find podInfo for podName %q", podName)
 			}
 
+			woc.ensureArtifactGCRBAC(ctx, podAccessInfo.serviceAccount)
 			_, err := woc.createArtifactGCPod(ctx, strategy, tasks, podAccessInfo, podName, templatesToArtList, templatesByName)
 			if err != nil {
 				return err
@@ -267,6 +315,10 @@ func (woc *wfOperationCtx) artGCPodName(strategy wfv1.ArtifactGCStrategy, podInf
 		abbreviatedName = "wfcomp"
 	case wfv1.ArtifactGCOnWorkflowDeletion:
 		abbreviatedName = "wfdel"
+	case wfv1.ArtifactGCOnRetentionExpiry:
+		abbreviatedName = "ret"
+	case wfv1.ArtifactGCOnRequest:
+		abbreviatedName = "req"
 	default:
 		return "", fmt.Errorf("ArtifactGCStrategy %q not valid", strategy)
 	}
@@ -411,6 +463,10 @@ func (woc *wfOperationCtx) createArtifactGCPod(ctx context.Context, strategy wfv
 		}
 		for i := range artifacts {
 			artifactLocations = append(artifactLocations, &artifacts[i].ArtifactLocation)
+			// replicas are deleted with the artifact
+			for j := range artifacts[i].ReplicateTo {
+				artifactLocations = append(artifactLocations, &artifacts[i].ReplicateTo[j])
+			}
 		}
 	}
 
@@ -465,6 +521,8 @@ func (woc *wfOperationCtx) createArtifactGCPod(ctx context.Context, strategy wfv
 		},
 	}
 
+	woc.addPodNetworkConfig(pod)
+
 	if podInfo.podSpecPatch != "" {
 		patchedPodSpec, err := util.ApplyPodSpecPatch(pod.Spec, podInfo.podSpecPatch)
 		if err != nil {
@@ -565,6 +623,10 @@ func (woc *wfOperationCtx) allArtifactsDeleted() bool {
 			if !a.Deleted && woc.execWf.GetArtifactGCStrategy(&a) != wfv1.ArtifactGCNever && woc.execWf.GetArtifactGCStrategy(&a) != wfv1.ArtifactGCStrategyUndefined {
 				return false
 			}
+			// artifacts with a retention are waited for, unless the workflow is being deleted, when they are kept
+			if !a.Deleted && woc.wf.DeletionTimestamp == nil && woc.getArtifactRetention(&a) != nil {
+				return false
+			}
 		}
 	}
 	return true
@@ -584,7 +646,7 @@ func (woc *wfOperationCtx) findArtifactsToGC(strategy wfv1.ArtifactGCStrategy) w
 			// artifact strategy is either based on overall Workflow ArtifactGC Strategy, or
 			// if it's specified on the individual artifact level that takes priority
 			artifactStrategy := woc.execWf.GetArtifactGCStrategy(&a)
-			if artifactStrategy == strategy && !a.Deleted {
+			if artifactStrategy == strategy && !a.Deleted && !woc.isEphemeralArtifact(&a) {
 				results = append(results, wfv1.ArtifactSearchResult{Artifact: a, NodeID: n.ID})
 			}
 		}
@@ -601,8 +663,9 @@ func (woc *wfOperationCtx) processCompletedArtifactGCPod(ctx context.Context, po
 	}
 	strategy := wfv1.ArtifactGCStrategy(strategyStr)
 
+	var errMsg string
 	if pod.Status.Phase == corev1.PodFailed {
-		errMsg := fmt.Sprintf("Artifact Garbage Collection failed for strategy %s, pod %s exited with non-zero exit code: check pod logs for more information", strategy, pod.Name)
+		errMsg = fmt.Sprintf("Artifact Garbage Collection failed for strategy %s, pod %s exited with non-zero exit code: check pod logs for more information", strategy, pod.Name)
 		woc.addArtGCCondition(errMsg)
 		woc.addArtGCEvent(errMsg)
 	}
@@ -615,10 +678,13 @@ func (woc *wfOperationCtx) processCompletedArtifactGCPod(ctx context.Context, po
 	}
 
 	for _, task := range taskList.Items {
-		allArtifactsSucceeded, err := woc.processCompletedWorkflowArtifactGCTask(&task, strategy)
+		allArtifactsSucceeded, err := woc.processCompletedWorkflowArtifactGCTask(ctx, &task, strategy)
 		if err != nil {
 			return err
 		}
+		if pod.Status.Phase == corev1.PodFailed {
+			woc.recordArtifactGCTaskUnfinished(ctx, &task, strategy, errMsg)
+		}
 		if allArtifactsSucceeded && pod.Status.Phase == corev1.PodSucceeded {
 			// now we can delete it, if it succeeded (otherwise we leave it up to be inspected)
 			woc.log.Debugf("deleting WorkflowArtifactGCTask: %s", task.Name)
@@ -634,7 +700,7 @@ func (woc *wfOperationCtx) processCompletedArtifactGCPod(ctx context.Context, po
 
 // process the Status in the WorkflowArtifactGCTask which was completed and reflect it in Workflow Status; then delete the Task CRD Object
 // return true if all artifacts succeeded, else false
-func (woc *wfOperationCtx) processCompletedWorkflowArtifactGCTask(artifactGCTask *wfv1.WorkflowArtifactGCTask, strategy wfv1.ArtifactGCStrategy) (bool, error) {
+func (woc *wfOperationCtx) processCompletedWorkflowArtifactGCTask(ctx context.Context, artifactGCTask *wfv1.WorkflowArtifactGCTask, strategy wfv1.ArtifactGCStrategy) (bool, error) {
 	woc.log.Debugf("processing WorkflowArtifactGCTask %s", artifactGCTask.Name)
 
 	foundGCFailure := false
@@ -659,6 +725,16 @@ func (woc *wfOperationCtx) processCompletedWorkflowArtifactGCTask(artifactGCTask
 			wfNode.Outputs.Artifacts[i].Deleted = artifactResult.Success
 			woc.wf.Status.Nodes.Set(nodeName, *wfNode)
 
+			if artifactResult.Success {
+				woc.clearArtifactGCFailure(nodeName, wfArtifact.Name)
+			} else {
+				msg := "artifact was not deleted"
+				if artifactResult.Error != nil {
+					msg = *artifactResult.Error
+				}
+				woc.recordArtifactGCFailure(ctx, nodeName, wfArtifact.Name, strategy, msg)
+			}
+
 			if artifactResult.Error != nil {
 				woc.addArtGCCondition(fmt.Sprintf("%s (artifactGCTask: %s)", *artifactResult.Error, artifactGCTask.Name))
 				// issue an Event if there was an error - just do this once to prevent flooding the system with Events
@@ -675,6 +751,20 @@ func (woc *wfOperationCtx) processCompletedWorkflowArtifactGCTask(artifactGCTask
 	return !foundGCFailure, nil
 }
 
+// recordArtifactGCTaskUnfinished records the failure to delete the artifacts of the WorkflowArtifactGCTask that its Pod
+// failed before reporting on
+func (woc *wfOperationCtx) recordArtifactGCTaskUnfinished(ctx context.Context, artifactGCTask *wfv1.WorkflowArtifactGCTask, strategy wfv1.ArtifactGCStrategy, msg string) {
+	for nodeName, nodeSpec := range artifactGCTask.Spec.ArtifactsByNode {
+		nodeResult := artifactGCTask.Status.ArtifactResultsByNode[nodeName]
+		for artifactName := range nodeSpec.Artifacts {
+			if _, found := nodeResult.ArtifactResults[artifactName]; found {
+				continue
+			}
+			woc.recordArtifactGCFailure(ctx, nodeName, artifactName, strategy, msg)
+		}
+	}
+}
+
 func (woc *wfOperationCtx) addArtGCCondition(msg string) {
 	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{
 		Type:    wfv1.ConditionTypeArtifactGCError,
@@ -689,7 +779,7 @@ func (woc *wfOperationCtx) addArtGCEvent(msg string) {
 
 func (woc *wfOperationCtx) getArtifactGCPodInfo(artifact *wfv1.Artifact) podInfo {
 	//  start with Workflow.ArtifactGC and override with Artifact.ArtifactGC
-	podInfo := podInfo{}
+	podInfo := podInfo{serviceAccount: woc.controller.Config.ArtifactGC.GetServiceAccountName()}
 	if woc.execWf.Spec.ArtifactGC != nil {
 		woc.updateArtifactGCPodInfo(&woc.execWf.Spec.ArtifactGC.ArtifactGC, &podInfo)
 		podInfo.podSpecPatch = woc.execWf.Spec.ArtifactGC.PodSpecPatch
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// ephemeralArtifactsDir is the directory of the artifact repository that the ephemeral artifacts of Workflows are
+// saved under, each Workflow's under a prefix of its own
+const ephemeralArtifactsDir = "argo-ephemeral"
+
+// ephemeralArtifactPrefix returns the prefix that the ephemeral artifacts of the Workflow are saved under, or an empty
+// string if its artifacts are not ephemeral or its artifact repository has no keys. The prefix keeps the directory of
+// the repository's key format that does not depend on the Workflow, e.g. one that its credentials are limited to.
+func (woc *wfOperationCtx) ephemeralArtifactPrefix() string {
+	if !woc.execWf.Spec.ArtifactGC.IsEphemeral() || woc.artifactRepository == nil || woc.wf.UID == "" {
+		return ""
+	}
+	key, err := woc.artifactRepository.ToArtifactLocation().GetKey()
+	if err != nil {
+		return ""
+	}
+	return path.Join(staticKeyDir(key), ephemeralArtifactsDir, string(woc.wf.UID)) + "/"
+}
+
+// staticKeyDir returns the directory of the key format that does not reference any variables, e.g. "my-team" of
+// "my-team/{{workflow.name}}/{{pod.name}}"
+func staticKeyDir(keyFormat string) string {
+	if i := strings.Index(keyFormat, "{{"); i >= 0 {
+		keyFormat = keyFormat[:i]
+	}
+	if i := strings.LastIndex(keyFormat, "/"); i >= 0 {
+		return keyFormat[:i]
+	}
+	return ""
+}
+
+// isEphemeralArtifact returns whether the artifact was saved under the prefix of the ephemeral artifacts of the
+// Workflow, so it is deleted with the prefix rather than on its own
+func (woc *wfOperationCtx) isEphemeralArtifact(a *wfv1.Artifact) bool {
+	prefix := woc.ephemeralArtifactPrefix()
+	if prefix == "" {
+		return false
+	}
+	key, err := a.GetKey()
+	return err == nil && strings.HasPrefix(key, prefix)
+}
+
+// findEphemeralArtifacts returns the output artifacts of the Workflow that were saved under the prefix of its
+// ephemeral artifacts, and have not been deleted
+func (woc *wfOperationCtx) findEphemeralArtifacts() wfv1.ArtifactSearchResults {
+	var results wfv1.ArtifactSearchResults
+	for _, n := range woc.wf.Status.Nodes {
+		if n.Type != wfv1.NodeTypePod {
+			continue
+		}
+		for _, a := range n.GetOutputs().GetArtifacts() {
+			if !a.Deleted && woc.isEphemeralArtifact(&a) {
+				results = append(results, wfv1.ArtifactSearchResult{Artifact: a, NodeID: n.ID})
+			}
+		}
+	}
+	return results
+}
+
+// deleteEphemeralArtifacts deletes the prefix of the ephemeral artifacts of a completed Workflow with a single delete,
+// rather than starting up Pods to delete its artifacts one at a time. Transient failures are retried, and otherwise
+// its artifacts are recorded as having failed to be deleted, so that they are retried one at a time like any other.
+func (woc *wfOperationCtx) deleteEphemeralArtifacts(ctx context.Context) error {
+	gcStatus := woc.wf.Status.ArtifactGCStatus
+	if gcStatus.EphemeralPrefixProcessed || (woc.wf.Labels[common.LabelKeyCompleted] != "true" && woc.wf.DeletionTimestamp == nil) {
+		return nil
+	}
+	prefix := woc.ephemeralArtifactPrefix()
+	if prefix == "" {
+		return nil
+	}
+	results := woc.findEphemeralArtifacts()
+	if len(results) > 0 {
+		err := woc.deleteArtifactPrefix(ctx, prefix)
+		if err != nil && artifactcommon.IsRetryable(err) {
+			woc.log.WithError(err).WithField("prefix", prefix).Warn("Failed to delete ephemeral artifacts, retrying")
+			woc.requeueAfter(time.Minute)
+			return nil
+		}
+		for _, r := range results {
+			if err != nil {
+				woc.recordArtifactGCFailure(ctx, r.NodeID, r.Name, wfv1.ArtifactGCOnWorkflowCompletion, err.Error())
+				continue
+			}
+			node, getErr := woc.wf.Status.Nodes.Get(r.NodeID)
+			if getErr != nil {
+				return getErr
+			}
+			for i, a := range node.Outputs.Artifacts {
+				if a.Name == r.Name {
+					node.Outputs.Artifacts[i].Deleted = true
+				}
+			}
+			woc.wf.Status.Nodes.Set(r.NodeID, *node)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Artifact Garbage Collection failed to delete the ephemeral artifacts under %s, deleting them one at a time instead: %v", prefix, err)
+			woc.addArtGCCondition(msg)
+			woc.addArtGCEvent(msg)
+		} else {
+			woc.log.WithField("prefix", prefix).WithField("numArtifacts", len(results)).Info("Deleted ephemeral artifacts")
+		}
+	}
+	gcStatus.EphemeralPrefixProcessed = true
+	woc.updated = true
+	return nil
+}
+
+// deleteArtifactPrefix deletes all the files under the prefix of the Workflow's artifact repository, if its driver can
+// delete a prefix at once. Deleting a key that ends with a slash only deletes that one object in most storage, not the
+// files under it.
+func (woc *wfOperationCtx) deleteArtifactPrefix(ctx context.Context, prefix string) error {
+	location := woc.artifactRepository.ToArtifactLocation()
+	if err := location.SetKey(prefix); err != nil {
+		return err
+	}
+	a := &wfv1.Artifact{Name: ephemeralArtifactsDir, ArtifactLocation: *location}
+	drv, err := newArtifactDriver(ctx, a, artifactResources{woc.controller.kubeclientset, woc.wf.Namespace})
+	if err != nil {
+		return err
+	}
+	if !drv.Capabilities().PrefixDelete {
+		return errors.New(errors.CodeNotImplemented, "prefix deletes are not supported by the artifact repository")
+	}
+	return artifactcommon.DeletePrefix(drv, a)
+}
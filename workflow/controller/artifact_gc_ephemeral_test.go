@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// deletingArtifactDriver records the prefixes that it deletes, and fails to delete them with its error
+type deletingArtifactDriver struct {
+	artifactcommon.ArtifactDriver
+	deleted      *[]string
+	err          error
+	prefixDelete bool
+}
+
+func (d deletingArtifactDriver) Delete(a *wfv1.Artifact) error {
+	return errors.New("only the key is deleted, not the files under it")
+}
+
+func (d deletingArtifactDriver) DeletePrefix(a *wfv1.Artifact) error {
+	*d.deleted = append(*d.deleted, a.S3.Key)
+	return d.err
+}
+
+func (d deletingArtifactDriver) Capabilities() artifactcommon.Capabilities {
+	return artifactcommon.Capabilities{Delete: true, PrefixDelete: d.prefixDelete}
+}
+
+var ephemeralArtifactsWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: ephemeral-artifacts
+  uid: my-uid
+spec:
+  entrypoint: main
+  artifactGC:
+    ephemeral: true
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+    outputs:
+      artifacts:
+      - name: intermediate
+        path: /tmp/intermediate
+`
+
+func newEphemeralArtifactsWoc(t *testing.T) *wfOperationCtx {
+	wf := wfv1.MustUnmarshalWorkflow(ephemeralArtifactsWf)
+	cancel, controller := newController(wf)
+	t.Cleanup(cancel)
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.artifactRepository = &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{
+		S3Bucket:  wfv1.S3Bucket{Bucket: "my-bucket"},
+		KeyFormat: "my-team/{{workflow.name}}/{{pod.name}}",
+	}}
+	return woc
+}
+
+func TestStaticKeyDir(t *testing.T) {
+	assert.Equal(t, "my-team", staticKeyDir("my-team/{{workflow.name}}/{{pod.name}}"))
+	assert.Equal(t, "my-team/artifacts", staticKeyDir("my-team/artifacts/{{workflow.name}}"))
+	assert.Empty(t, staticKeyDir("{{workflow.name}}/{{pod.name}}"))
+	assert.Equal(t, "fixed", staticKeyDir("fixed/key"))
+}
+
+func TestEphemeralArtifactPrefix(t *testing.T) {
+	woc := newEphemeralArtifactsWoc(t)
+	assert.Equal(t, "my-team/argo-ephemeral/my-uid/", woc.ephemeralArtifactPrefix())
+
+	t.Run("ArchiveLocation", func(t *testing.T) {
+		tmpl := woc.execWf.Spec.Templates[0].DeepCopy()
+		woc.addArchiveLocation(tmpl)
+		key, err := tmpl.ArchiveLocation.GetKey()
+		require.NoError(t, err)
+		assert.Equal(t, "my-team/argo-ephemeral/my-uid/{{pod.name}}", key)
+	})
+	t.Run("ArchiveLogs", func(t *testing.T) {
+		woc := newEphemeralArtifactsWoc(t)
+		woc.artifactRepository.ArchiveLogs = ptr.To(true)
+		tmpl := woc.execWf.Spec.Templates[0].DeepCopy()
+		woc.addArchiveLocation(tmpl)
+		key, err := tmpl.ArchiveLocation.GetKey()
+		require.NoError(t, err)
+		assert.Equal(t, "my-team/{{workflow.name}}/{{pod.name}}", key, "archived logs are not ephemeral")
+	})
+	t.Run("NotEphemeral", func(t *testing.T) {
+		woc := newEphemeralArtifactsWoc(t)
+		woc.execWf.Spec.ArtifactGC = nil
+		assert.Empty(t, woc.ephemeralArtifactPrefix())
+	})
+}
+
+func TestDeleteEphemeralArtifacts(t *testing.T) {
+	ctx := context.Background()
+	setUp := func(t *testing.T, err error) (*wfOperationCtx, *[]string) {
+		deleted := &[]string{}
+		fakeArtifactDrivers(t, deletingArtifactDriver{deleted: deleted, err: err, prefixDelete: true})
+		woc := newEphemeralArtifactsWoc(t)
+		woc.wf.Labels = map[string]string{common.LabelKeyCompleted: "true"}
+		woc.wf.Status.ArtifactGCStatus = &wfv1.ArtGCStatus{}
+		woc.wf.Status.Nodes = wfv1.Nodes{"pod-1": {ID: "pod-1", Type: wfv1.NodeTypePod, Outputs: &wfv1.Outputs{Artifacts: wfv1.Artifacts{
+			{Name: "intermediate", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-team/argo-ephemeral/my-uid/pod-1/intermediate.tgz"}}},
+			{Name: "kept", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "results/kept.tgz"}}},
+		}}}}
+		return woc, deleted
+	}
+	artifact := func(woc *wfOperationCtx, name string) *wfv1.Artifact {
+		return woc.wf.Status.Nodes["pod-1"].Outputs.GetArtifactByName(name)
+	}
+
+	t.Run("Deleted", func(t *testing.T) {
+		woc, deleted := setUp(t, nil)
+		require.NoError(t, woc.deleteEphemeralArtifacts(ctx))
+		assert.Equal(t, []string{"my-team/argo-ephemeral/my-uid/"}, *deleted, "the prefix is deleted as a whole")
+		assert.True(t, artifact(woc, "intermediate").Deleted)
+		assert.False(t, artifact(woc, "kept").Deleted)
+		assert.True(t, woc.wf.Status.ArtifactGCStatus.EphemeralPrefixProcessed)
+		assert.Empty(t, woc.findArtifactsToGC(wfv1.ArtifactGCOnWorkflowCompletion))
+
+		require.NoError(t, woc.deleteEphemeralArtifacts(ctx))
+		assert.Len(t, *deleted, 1, "the prefix is only deleted once")
+	})
+	t.Run("Running", func(t *testing.T) {
+		woc, deleted := setUp(t, nil)
+		woc.wf.Labels = nil
+		require.NoError(t, woc.deleteEphemeralArtifacts(ctx))
+		assert.Empty(t, *deleted)
+		assert.False(t, woc.wf.Status.ArtifactGCStatus.EphemeralPrefixProcessed)
+	})
+	t.Run("Transient", func(t *testing.T) {
+		woc, deleted := setUp(t, artifactcommon.NewDriverError(artifactcommon.ErrorKindThrottled, errors.New("slow down")))
+		require.NoError(t, woc.deleteEphemeralArtifacts(ctx))
+		assert.Len(t, *deleted, 1)
+		assert.False(t, artifact(woc, "intermediate").Deleted)
+		assert.False(t, woc.wf.Status.ArtifactGCStatus.EphemeralPrefixProcessed, "the prefix is deleted again")
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		woc, _ := setUp(t, artifactcommon.NewDriverError(artifactcommon.ErrorKindForbidden, errors.New("access denied")))
+		require.NoError(t, woc.deleteEphemeralArtifacts(ctx))
+		assert.False(t, artifact(woc, "intermediate").Deleted)
+		assert.True(t, woc.wf.Status.ArtifactGCStatus.EphemeralPrefixProcessed)
+		failure := woc.wf.Status.ArtifactGCStatus.Failures[wfv1.ArtifactGCFailureKey("pod-1", "intermediate")]
+		assert.Equal(t, "access denied", failure.Message, "the artifacts are retried one at a time")
+		assert.Equal(t, wfv1.ArtifactGCOnWorkflowCompletion, failure.Strategy)
+	})
+	t.Run("PrefixDeleteNotSupported", func(t *testing.T) {
+		woc, deleted := setUp(t, nil)
+		fakeArtifactDrivers(t, deletingArtifactDriver{deleted: deleted})
+		require.NoError(t, woc.deleteEphemeralArtifacts(ctx))
+		assert.Empty(t, *deleted)
+		assert.False(t, artifact(woc, "intermediate").Deleted)
+		assert.True(t, woc.wf.Status.ArtifactGCStatus.EphemeralPrefixProcessed)
+		failure := woc.wf.Status.ArtifactGCStatus.Failures[wfv1.ArtifactGCFailureKey("pod-1", "intermediate")]
+		assert.Contains(t, failure.Message, "prefix deletes are not supported", "the artifacts are deleted one at a time")
+	})
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+)
+
+// artifactGCRoleName is the name of the Role that the controller provisions for artifact GC pods
+const artifactGCRoleName = "argo-workflows-artifactgc"
+
+// artifactGCRules are the permissions that artifact GC pods need: to read their tasks, and to report their results
+var artifactGCRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{workflow.Group}, Resources: []string{workflow.WorkflowArtifactGCTaskPlural}, Verbs: []string{"list", "watch"}},
+	{APIGroups: []string{workflow.Group}, Resources: []string{workflow.WorkflowArtifactGCTaskPlural + "/status"}, Verbs: []string{"patch"}},
+}
+
+// ensureArtifactGCRBAC provisions or validates the RBAC of the service account of an artifact GC pod, as configured.
+// Failures are reported on the workflow, rather than stopping the pod being created, so that they are not silent.
+func (woc *wfOperationCtx) ensureArtifactGCRBAC(ctx context.Context, serviceAccount string) {
+	mode := woc.controller.Config.ArtifactGC.GetRBAC()
+	if mode == "" {
+		return
+	}
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	key := woc.wf.Namespace + "/" + serviceAccount
+	if _, done := woc.controller.artifactGCRBACDone.Load(key); done {
+		return
+	}
+	var err error
+	switch mode {
+	case config.ArtifactGCRBACProvision:
+		defaultServiceAccount := woc.controller.Config.ArtifactGC.GetServiceAccountName()
+		if defaultServiceAccount == "" {
+			defaultServiceAccount = "default"
+		}
+		if serviceAccount != defaultServiceAccount {
+			// only the default service account is provisioned, as the workflow owns any other
+			return
+		}
+		err = woc.provisionArtifactGCRBAC(ctx, serviceAccount)
+		if err != nil {
+			err = fmt.Errorf("failed to provision the RBAC of artifact GC service account %q: %w", serviceAccount, err)
+		}
+	case config.ArtifactGCRBACValidate:
+		err = woc.validateArtifactGCRBAC(ctx, serviceAccount)
+	default:
+		err = fmt.Errorf("unknown artifact GC RBAC mode %q", mode)
+	}
+	if err != nil {
+		woc.log.WithError(err).Warn("Artifact GC RBAC not ready")
+		woc.addArtGCCondition(err.Error())
+		woc.addArtGCEvent(err.Error())
+		return
+	}
+	woc.controller.artifactGCRBACDone.Store(key, true)
+}
+
+// provisionArtifactGCRBAC creates the service account, the Role, and a RoleBinding of the Role to the service account,
+// and updates the rules of the Role if they have changed
+func (woc *wfOperationCtx) provisionArtifactGCRBAC(ctx context.Context, serviceAccount string) error {
+	namespace := woc.wf.Namespace
+	kubeClient := woc.controller.kubeclientset
+	_, err := kubeClient.CoreV1().ServiceAccounts(namespace).Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceAccount},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierr.IsAlreadyExists(err) {
+		return err
+	}
+	roles := kubeClient.RbacV1().Roles(namespace)
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: artifactGCRoleName}, Rules: artifactGCRules}
+	_, err = roles.Create(ctx, role, metav1.CreateOptions{})
+	if apierr.IsAlreadyExists(err) {
+		existing, getErr := roles.Get(ctx, artifactGCRoleName, metav1.GetOptions{})
+		if getErr != nil {
+			return getErr
+		}
+		existing.Rules = artifactGCRules
+		_, err = roles.Update(ctx, existing, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	_, err = kubeClient.RbacV1().RoleBindings(namespace).Create(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: artifactGCRoleName + "-" + serviceAccount},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: artifactGCRoleName},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: serviceAccount, Namespace: namespace}},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierr.IsAlreadyExists(err) {
+		return err
+	}
+	woc.log.WithField("serviceAccount", serviceAccount).Info("Provisioned artifact GC RBAC")
+	return nil
+}
+
+// validateArtifactGCRBAC returns an error that names the first permission the service account does not have
+func (woc *wfOperationCtx) validateArtifactGCRBAC(ctx context.Context, serviceAccount string) error {
+	namespace := woc.wf.Namespace
+	for _, rule := range artifactGCRules {
+		resource, subresource, _ := strings.Cut(rule.Resources[0], "/")
+		for _, verb := range rule.Verbs {
+			review, err := woc.controller.kubeclientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					User:   fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount),
+					Groups: []string{"system:serviceaccounts", "system:serviceaccounts:" + namespace},
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace:   namespace,
+						Verb:        verb,
+						Group:       workflow.Group,
+						Resource:    resource,
+						Subresource: subresource,
+					},
+				},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to validate the RBAC of artifact GC service account %q: %w", serviceAccount, err)
+			}
+			if !review.Status.Allowed {
+				return fmt.Errorf("artifact GC service account %q is not allowed to %s %s, so artifacts cannot be deleted", serviceAccount, verb, rule.Resources[0])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func artifactGCErrorCondition(woc *wfOperationCtx) *wfv1.Condition {
+	for i, condition := range woc.wf.Status.Conditions {
+		if condition.Type == wfv1.ConditionTypeArtifactGCError {
+			return &woc.wf.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestProvisionArtifactGCRBAC(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artgcWorkflow)
+	// the service account of the controller's configuration is used unless the workflow specifies one
+	wf.Spec.ArtifactGC.ServiceAccountName = ""
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.Config.ArtifactGC = &config.ArtifactGCConfig{ServiceAccountName: "artifactgc", RBAC: config.ArtifactGCRBACProvision}
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	assert.Equal(t, "artifactgc", woc.getArtifactGCPodInfo(&wfv1.Artifact{}).serviceAccount)
+
+	woc.ensureArtifactGCRBAC(ctx, "my-sa")
+	_, err := controller.kubeclientset.CoreV1().ServiceAccounts(wf.Namespace).Get(ctx, "my-sa", metav1.GetOptions{})
+	require.Error(t, err, "service accounts that the workflow specifies are not provisioned")
+
+	woc.ensureArtifactGCRBAC(ctx, "artifactgc")
+	_, err = controller.kubeclientset.CoreV1().ServiceAccounts(wf.Namespace).Get(ctx, "artifactgc", metav1.GetOptions{})
+	require.NoError(t, err)
+	role, err := controller.kubeclientset.RbacV1().Roles(wf.Namespace).Get(ctx, artifactGCRoleName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, artifactGCRules, role.Rules)
+	binding, err := controller.kubeclientset.RbacV1().RoleBindings(wf.Namespace).Get(ctx, artifactGCRoleName+"-artifactgc", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "artifactgc", binding.Subjects[0].Name)
+	assert.Nil(t, artifactGCErrorCondition(woc))
+}
+
+func TestValidateArtifactGCRBAC(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artgcWorkflow)
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.Config.ArtifactGC = &config.ArtifactGCConfig{RBAC: config.ArtifactGCRBACValidate}
+	controller.kubeclientset.(*fake.Clientset).PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "system:serviceaccount:"+wf.Namespace+":allowed"
+		return true, review, nil
+	})
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.ensureArtifactGCRBAC(ctx, "allowed")
+	assert.Nil(t, artifactGCErrorCondition(woc))
+
+	woc.ensureArtifactGCRBAC(ctx, "")
+	condition := artifactGCErrorCondition(woc)
+	require.NotNil(t, condition)
+	assert.Equal(t, `artifact GC service account "default" is not allowed to list workflowartifactgctasks, so artifacts cannot be deleted`, condition.Message)
+}
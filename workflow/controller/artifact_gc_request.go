@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// garbageCollectRequestedArtifacts starts up Pods to delete the artifacts of a completed Workflow that have been
+// requested to be deleted, e.g. by `argo artifact rm`. Artifacts that fail to be deleted are retried like those of
+// any other strategy.
+func (woc *wfOperationCtx) garbageCollectRequestedArtifacts(ctx context.Context) error {
+	if woc.wf.Labels[common.LabelKeyCompleted] != "true" || woc.wf.DeletionTimestamp != nil {
+		return nil
+	}
+	requested := woc.findRequestedArtifacts()
+	if len(requested) == 0 {
+		return nil
+	}
+	running, err := woc.artifactGCPodsRunning(wfv1.ArtifactGCOnRequest)
+	if err != nil {
+		return err
+	}
+	if running {
+		woc.log.Debug("Waiting for Artifact GC Pods of requested artifacts to complete")
+		return nil
+	}
+	woc.log.WithField("numArtifacts", len(requested)).Info("Deleting artifacts that were requested to be deleted")
+	return woc.deleteArtifacts(ctx, wfv1.ArtifactGCOnRequest, requested, artifactSearchResultsHash(requested))
+}
+
+// findRequestedArtifacts returns the output artifacts of the annotation that have not been deleted yet
+func (woc *wfOperationCtx) findRequestedArtifacts() wfv1.ArtifactSearchResults {
+	var results wfv1.ArtifactSearchResults
+	for _, request := range strings.Split(woc.wf.Annotations[common.AnnotationKeyArtifactGCRequest], ",") {
+		nodeID, artifactName, ok := strings.Cut(strings.TrimSpace(request), "/")
+		if !ok {
+			continue
+		}
+		node, err := woc.wf.Status.Nodes.Get(nodeID)
+		if err != nil || node.Type != wfv1.NodeTypePod {
+			continue
+		}
+		a := node.GetOutputs().GetArtifactByName(artifactName)
+		if a == nil || a.Deleted {
+			continue
+		}
+		results = append(results, wfv1.ArtifactSearchResult{Artifact: *a, NodeID: nodeID})
+	}
+	return results
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiintstr "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/intstr"
+)
+
+// defaultArtifactGCRetry is how artifacts that failed to be deleted are retried, unless the Workflow says otherwise
+var defaultArtifactGCRetry = wfv1.ArtifactGCRetry{
+	Limit:   ptr.To(int32(5)),
+	Backoff: &wfv1.Backoff{Duration: "1m", Factor: ptr.To(apiintstr.FromInt32(2)), Cap: "1h"},
+}
+
+// getArtifactGCRetry returns how artifacts that failed to be deleted are retried
+func (woc *wfOperationCtx) getArtifactGCRetry() wfv1.ArtifactGCRetry {
+	retry := defaultArtifactGCRetry
+	if r := woc.execWf.Spec.ArtifactGC.GetRetry(); r != nil {
+		if r.Limit != nil {
+			retry.Limit = r.Limit
+		}
+		if r.Backoff != nil {
+			retry.Backoff = r.Backoff
+		}
+	}
+	return retry
+}
+
+// artifactGCBackoff returns how long to wait before retrying to delete an artifact that failed to be deleted the
+// number of times
+func artifactGCBackoff(backoff *wfv1.Backoff, attempts int32) (time.Duration, error) {
+	if backoff == nil || backoff.Duration == "" {
+		return 0, nil
+	}
+	timeToWait, err := wfv1.ParseStringToDuration(backoff.Duration)
+	if err != nil {
+		return 0, err
+	}
+	factor, err := intstr.Int32(backoff.Factor)
+	if err != nil {
+		return 0, err
+	}
+	if factor != nil && *factor > 0 && attempts > 1 {
+		timeToWait = time.Duration(float64(timeToWait) * math.Pow(float64(*factor), float64(attempts-1)))
+	}
+	if backoff.Cap != "" {
+		capDuration, err := wfv1.ParseStringToDuration(backoff.Cap)
+		if err != nil {
+			return 0, err
+		}
+		if timeToWait > capDuration {
+			timeToWait = capDuration
+		}
+	}
+	return timeToWait, nil
+}
+
+// recordArtifactGCFailure records the failure to delete an artifact, which is dead-lettered once it has failed to be
+// deleted more times than it may be retried
+func (woc *wfOperationCtx) recordArtifactGCFailure(ctx context.Context, nodeID, artifactName string, strategy wfv1.ArtifactGCStrategy, msg string) {
+	gcStatus := woc.wf.Status.ArtifactGCStatus
+	if gcStatus.Failures == nil {
+		gcStatus.Failures = make(map[string]wfv1.ArtifactGCFailure)
+	}
+	key := wfv1.ArtifactGCFailureKey(nodeID, artifactName)
+	failure := gcStatus.Failures[key]
+	failure.NodeID = nodeID
+	failure.ArtifactName = artifactName
+	failure.Strategy = strategy
+	failure.Message = msg
+	failure.Attempts++
+	failure.LastAttemptAt = metav1.Now()
+	failure.DeadLettered = failure.Attempts > *woc.getArtifactGCRetry().Limit
+	gcStatus.Failures[key] = failure
+	woc.updated = true
+
+	woc.controller.metrics.ArtifactGCFailed(ctx, woc.wf.Namespace, failure.DeadLettered)
+	if failure.DeadLettered {
+		deadLetterMsg := fmt.Sprintf("Artifact Garbage Collection gave up deleting artifact %s of node %s after %d attempts: %s", artifactName, nodeID, failure.Attempts, msg)
+		woc.log.Warn(deadLetterMsg)
+		woc.eventRecorder.Event(woc.wf, corev1.EventTypeWarning, "ArtifactGCDeadLettered", deadLetterMsg)
+	}
+}
+
+// clearArtifactGCFailure forgets the failures to delete an artifact, once it has been deleted
+func (woc *wfOperationCtx) clearArtifactGCFailure(nodeID, artifactName string) {
+	gcStatus := woc.wf.Status.ArtifactGCStatus
+	key := wfv1.ArtifactGCFailureKey(nodeID, artifactName)
+	if _, ok := gcStatus.Failures[key]; ok {
+		delete(gcStatus.Failures, key)
+		woc.updated = true
+	}
+}
+
+// retryFailedArtifactGC starts up Pods to retry deleting the artifacts that failed to be deleted once they have backed
+// off, and requeues the Workflow for when the next ones will have
+func (woc *wfOperationCtx) retryFailedArtifactGC(ctx context.Context) error {
+	if woc.wf.Status.ArtifactGCStatus == nil || len(woc.wf.Status.ArtifactGCStatus.Failures) == 0 {
+		return nil
+	}
+	retry := woc.getArtifactGCRetry()
+	now := time.Now()
+	var next time.Duration
+	toRetry := make(map[wfv1.ArtifactGCStrategy]wfv1.ArtifactSearchResults)
+	attempts := make(map[wfv1.ArtifactGCStrategy]int32)
+	failures := woc.wf.Status.ArtifactGCStatus.Failures
+	keys := maps.Keys(failures)
+	slices.Sort(keys)
+	for _, key := range keys {
+		failure := failures[key]
+		if failure.DeadLettered {
+			continue
+		}
+		node, err := woc.wf.Status.Nodes.Get(failure.NodeID)
+		if err != nil {
+			continue
+		}
+		a := node.GetOutputs().GetArtifactByName(failure.ArtifactName)
+		if a == nil || a.Deleted {
+			continue
+		}
+		backoff, err := artifactGCBackoff(retry.Backoff, failure.Attempts)
+		if err != nil {
+			return fmt.Errorf("invalid artifact GC retry backoff: %w", err)
+		}
+		if untilRetry := failure.LastAttemptAt.Add(backoff).Sub(now); untilRetry > 0 {
+			if next == 0 || untilRetry < next {
+				next = untilRetry
+			}
+			continue
+		}
+		toRetry[failure.Strategy] = append(toRetry[failure.Strategy], wfv1.ArtifactSearchResult{Artifact: *a, NodeID: failure.NodeID})
+		attempts[failure.Strategy] = max(attempts[failure.Strategy], failure.Attempts)
+	}
+	if next > 0 {
+		woc.requeueAfter(next)
+	}
+	for strategy, results := range toRetry {
+		running, err := woc.artifactGCPodsRunning(strategy)
+		if err != nil {
+			return err
+		}
+		if running {
+			woc.log.Debug("Waiting for Artifact GC Pods to complete before retrying failed artifacts")
+			continue
+		}
+		woc.log.WithField("strategy", strategy).WithField("numArtifacts", len(results)).Info("Retrying deleting artifacts that failed to be deleted")
+		// the Pods of each attempt are named differently, so that they are not mistaken for the completed Pods of
+		// the previous attempt
+		podNameSuffix := fmt.Sprintf("%s-%d", artifactSearchResultsHash(results), attempts[strategy])
+		if err := woc.deleteArtifacts(ctx, strategy, results, podNameSuffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -559,6 +562,7 @@ status:
 `
 
 func TestProcessCompletedWorkflowArtifactGCTask(t *testing.T) {
+	ctx := context.Background()
 	wf := wfv1.MustUnmarshalWorkflow(artgcWorkflow)
 	wfat := wfv1.MustUnmarshalWorkflowArtifactGCTask(artgcTask)
 	cancel, controller := newController(wf)
@@ -571,7 +575,7 @@ func TestProcessCompletedWorkflowArtifactGCTask(t *testing.T) {
 	// - Artifact.Deleted
 	// - Conditions
 
-	_, err := woc.processCompletedWorkflowArtifactGCTask(wfat, "OnWorkflowCompletion")
+	_, err := woc.processCompletedWorkflowArtifactGCTask(ctx, wfat, "OnWorkflowCompletion")
 	require.NoError(t, err)
 
 	for _, expectedArtifact := range []struct {
@@ -619,6 +623,60 @@ func TestProcessCompletedWorkflowArtifactGCTask(t *testing.T) {
 
 }
 
+func TestArtifactGCFailureRetry(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(artgcWorkflow)
+	wf.Spec.ArtifactGC = &wfv1.WorkflowLevelArtifactGC{Retry: &wfv1.ArtifactGCRetry{Limit: ptr.To(int32(1))}}
+	wfat := wfv1.MustUnmarshalWorkflowArtifactGCTask(artgcTask)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.wf.Status.ArtifactGCStatus = &wfv1.ArtGCStatus{}
+
+	_, err := woc.processCompletedWorkflowArtifactGCTask(ctx, wfat, "OnWorkflowCompletion")
+	require.NoError(t, err)
+	failures := woc.wf.Status.ArtifactGCStatus.Failures
+	require.Len(t, failures, 1)
+	failure := failures[wfv1.ArtifactGCFailureKey("two-artgc-8tcvt-802059674", "first-on-completion-2")]
+	assert.Equal(t, "first-on-completion-2", failure.ArtifactName)
+	assert.Equal(t, wfv1.ArtifactGCOnWorkflowCompletion, failure.Strategy)
+	assert.Equal(t, "something went wrong", failure.Message)
+	assert.Equal(t, int32(1), failure.Attempts)
+	assert.False(t, failure.DeadLettered)
+
+	// the retry fails too, which is more than the limit
+	_, err = woc.processCompletedWorkflowArtifactGCTask(ctx, wfat, "OnWorkflowCompletion")
+	require.NoError(t, err)
+	failure = woc.wf.Status.ArtifactGCStatus.Failures[wfv1.ArtifactGCFailureKey("two-artgc-8tcvt-802059674", "first-on-completion-2")]
+	assert.Equal(t, int32(2), failure.Attempts)
+	assert.True(t, failure.DeadLettered)
+
+	// the artifact is deleted after all
+	success := wfat.Status.ArtifactResultsByNode["two-artgc-8tcvt-802059674"]
+	success.ArtifactResults["first-on-completion-2"] = wfv1.ArtifactResult{Name: "first-on-completion-2", Success: true}
+	_, err = woc.processCompletedWorkflowArtifactGCTask(ctx, wfat, "OnWorkflowCompletion")
+	require.NoError(t, err)
+	assert.Empty(t, woc.wf.Status.ArtifactGCStatus.Failures)
+}
+
+func TestArtifactGCBackoff(t *testing.T) {
+	backoff := &wfv1.Backoff{Duration: "1m", Factor: ptr.To(intstr.FromInt32(2)), Cap: "5m"}
+	for attempts, expected := range map[int32]time.Duration{
+		1: time.Minute,
+		2: 2 * time.Minute,
+		3: 4 * time.Minute,
+		4: 5 * time.Minute,
+	} {
+		d, err := artifactGCBackoff(backoff, attempts)
+		require.NoError(t, err)
+		assert.Equal(t, expected, d, "attempts=%d", attempts)
+	}
+	d, err := artifactGCBackoff(nil, 3)
+	require.NoError(t, err)
+	assert.Zero(t, d)
+}
+
 func TestWorkflowHasArtifactGC(t *testing.T) {
 	tests := []struct {
 		name                      string
@@ -682,6 +740,26 @@ func TestWorkflowHasArtifactGC(t *testing.T) {
                         strategy: Never`,
 			expectedResult: false,
 		},
+		{
+			name:                      "ArtifactSpecGC_Retention",
+			workflowArtGCStrategySpec: "",
+			artifactGCStrategySpec: `
+                      artifactGC:
+                        retention:
+                          maxAge: 30d`,
+			expectedResult: true,
+		},
+		{
+			name: "WorkflowSpecGC_RetentionNever",
+			workflowArtGCStrategySpec: `
+              artifactGC:
+                retention:
+                  maxCount: 10`,
+			artifactGCStrategySpec: `
+                      artifactGC:
+                        strategy: Never`,
+			expectedResult: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -721,3 +799,126 @@ func TestWorkflowHasArtifactGC(t *testing.T) {
 	}
 
 }
+
+func TestFindExpiredArtifacts(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: my-wf
+  labels:
+    workflows.argoproj.io/completed: "true"
+spec:
+  entrypoint: main
+  artifactGC:
+    retention:
+      maxAge: 1d
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+status:
+  nodes:
+    my-wf:
+      id: my-wf
+      name: my-wf
+      type: Pod
+      templateName: main
+      finishedAt: "2024-01-01T00:00:00Z"
+      outputs:
+        artifacts:
+        - name: default
+          s3:
+            key: default
+        - name: longer
+          s3:
+            key: longer
+          artifactGC:
+            retention:
+              maxAge: 48h
+        - name: never
+          s3:
+            key: never
+          artifactGC:
+            strategy: Never
+        - name: deleted
+          s3:
+            key: deleted
+          deleted: true
+`)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	finishedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("NotExpired", func(t *testing.T) {
+		expired, next, err := woc.findExpiredArtifacts(finishedAt.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+		assert.Equal(t, 23*time.Hour, next)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		expired, next, err := woc.findExpiredArtifacts(finishedAt.Add(25 * time.Hour))
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, "default", expired[0].Name)
+		assert.Equal(t, "my-wf", expired[0].NodeID)
+		assert.Equal(t, 23*time.Hour, next)
+	})
+	t.Run("MaxCount", func(t *testing.T) {
+		woc.execWf.Spec.ArtifactGC.Retention = &wfv1.ArtifactRetention{MaxCount: ptr.To(int32(0))}
+		defer func() { woc.execWf.Spec.ArtifactGC.Retention = &wfv1.ArtifactRetention{MaxAge: "1d"} }()
+		expired, _, err := woc.findExpiredArtifacts(finishedAt)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, "default", expired[0].Name)
+	})
+}
+
+func TestFindRequestedArtifacts(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: my-wf
+  labels:
+    workflows.argoproj.io/completed: "true"
+  annotations:
+    workflows.argoproj.io/artifact-gc-request: my-wf/requested,my-wf/deleted,my-wf/missing,other-node/requested,invalid
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+status:
+  nodes:
+    my-wf:
+      id: my-wf
+      name: my-wf
+      type: Pod
+      templateName: main
+      outputs:
+        artifacts:
+        - name: requested
+          s3:
+            key: requested
+          artifactGC:
+            strategy: Never
+        - name: not-requested
+          s3:
+            key: not-requested
+        - name: deleted
+          s3:
+            key: deleted
+          deleted: true
+`)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+
+	requested := woc.findRequestedArtifacts()
+	require.Len(t, requested, 1)
+	assert.Equal(t, "requested", requested[0].Name)
+	assert.Equal(t, "my-wf", requested[0].NodeID)
+}
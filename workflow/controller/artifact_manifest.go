@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"cmp"
+	"slices"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// artifactManifest returns the output artifacts saved by the pods of the workflow, ordered by node and name.
+// The outputs of steps and DAGs are the artifacts of their children, so are not listed again.
+func (woc *wfOperationCtx) artifactManifest() []wfv1.ArtifactManifestEntry {
+	var manifest []wfv1.ArtifactManifestEntry
+	var defaultLocation *wfv1.ArtifactLocation
+	if woc.artifactRepository != nil {
+		defaultLocation = woc.artifactRepository.ToArtifactLocation()
+	}
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod || node.Outputs == nil {
+			continue
+		}
+		for _, art := range node.Outputs.Artifacts {
+			key, err := art.GetKey()
+			if err != nil || key == "" {
+				continue
+			}
+			manifest = append(manifest, wfv1.ArtifactManifestEntry{
+				NodeID:     node.ID,
+				Name:       art.Name,
+				Key:        key,
+				Repository: artifactRepositoryName(&art.ArtifactLocation, defaultLocation),
+				SizeBytes:  art.SizeBytes,
+				Checksum:   art.Checksum,
+			})
+		}
+	}
+	slices.SortFunc(manifest, func(a, b wfv1.ArtifactManifestEntry) int {
+		return cmp.Or(cmp.Compare(a.NodeID, b.NodeID), cmp.Compare(a.Name, b.Name))
+	})
+	return manifest
+}
+
+// artifactRepositoryName returns the name of the repository of an artifact location, e.g. s3://my-bucket. Artifacts
+// saved to the default repository only record their key, so locations without a bucket are in the bucket of the
+// default location, if it has the same driver.
+func artifactRepositoryName(l, defaultLocation *wfv1.ArtifactLocation) string {
+	driver, bucket := artifactDriverAndBucket(l)
+	if bucket == "" && defaultLocation != nil {
+		if defaultDriver, defaultBucket := artifactDriverAndBucket(defaultLocation); defaultDriver == driver {
+			bucket = defaultBucket
+		}
+	}
+	if bucket == "" {
+		return driver
+	}
+	return driver + "://" + bucket
+}
+
+// artifactDriverAndBucket returns the name of the driver of an artifact location, and its bucket, if it has one
+func artifactDriverAndBucket(l *wfv1.ArtifactLocation) (string, string) {
+	switch {
+	case l.S3 != nil:
+		return "s3", l.S3.Bucket
+	case l.GCS != nil:
+		return "gcs", l.GCS.Bucket
+	case l.OSS != nil:
+		return "oss", l.OSS.Bucket
+	case l.Azure != nil:
+		return "azure", l.Azure.Container
+	case l.B2 != nil:
+		return "b2", l.B2.Bucket
+	case l.IBMCOS != nil:
+		return "ibmcos", l.IBMCOS.Bucket
+	case l.Artifactory != nil:
+		return "artifactory", ""
+	case l.HDFS != nil:
+		return "hdfs", ""
+	case l.SFTP != nil:
+		return "sftp", ""
+	case l.Filesystem != nil:
+		return "filesystem", ""
+	case l.HTTP != nil:
+		return "http", ""
+	case l.Git != nil:
+		return "git", ""
+	case l.OCI != nil:
+		return "oci", ""
+	case l.Raw != nil:
+		return "raw", ""
+	}
+	return "", ""
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var artifactManifestWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-manifest
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+status:
+  phase: Running
+  nodes:
+    artifact-manifest:
+      id: artifact-manifest
+      name: artifact-manifest
+      type: Steps
+      outputs:
+        artifacts:
+        - name: out
+          s3:
+            key: artifact-manifest/out.tgz
+    artifact-manifest-2:
+      id: artifact-manifest-2
+      name: artifact-manifest[0].b
+      type: Pod
+      outputs:
+        artifacts:
+        - name: out
+          s3:
+            key: artifact-manifest/out.tgz
+          size: 3
+          checksum: sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+        - name: logs
+          s3:
+            bucket: logs-bucket
+            key: artifact-manifest/main.log
+        - name: optional
+          optional: true
+    artifact-manifest-1:
+      id: artifact-manifest-1
+      name: artifact-manifest[0].a
+      type: Pod
+      outputs:
+        artifacts:
+        - name: out
+          gcs:
+            bucket: my-gcs-bucket
+            key: a/out.tgz
+`
+
+func TestArtifactManifest(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artifactManifestWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.artifactRepository = &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}}}
+
+	assert.Equal(t, []wfv1.ArtifactManifestEntry{
+		{NodeID: "artifact-manifest-1", Name: "out", Key: "a/out.tgz", Repository: "gcs://my-gcs-bucket"},
+		{NodeID: "artifact-manifest-2", Name: "logs", Key: "artifact-manifest/main.log", Repository: "s3://logs-bucket"},
+		{NodeID: "artifact-manifest-2", Name: "out", Key: "artifact-manifest/out.tgz", Repository: "s3://my-bucket", SizeBytes: 3, Checksum: "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"},
+	}, woc.artifactManifest())
+
+	woc.markWorkflowPhase(context.Background(), wfv1.WorkflowSucceeded, "")
+	assert.Len(t, woc.wf.Status.ArtifactManifest, 3)
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	gosync "sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// artifactQuotaPauses are the artifact repositories that pods failed to save artifacts to because they were out of
+// space, and until when new workflows that save artifacts to them are postponed
+type artifactQuotaPauses struct {
+	pauses map[string]time.Time
+	mutex  gosync.Mutex
+}
+
+// pause postpones new workflows that save artifacts to the repository until the time
+func (p *artifactQuotaPauses) pause(repository string, until time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.pauses == nil {
+		p.pauses = map[string]time.Time{}
+	}
+	p.pauses[repository] = until
+}
+
+// clear stops postponing workflows that save artifacts to the repository, as a pod used it successfully
+func (p *artifactQuotaPauses) clear(repository string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.pauses, repository)
+}
+
+// pausedUntil returns until when workflows that save artifacts to the repository are postponed, or the zero time if
+// they are not
+func (p *artifactQuotaPauses) pausedUntil(repository string, now time.Time) time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if until, ok := p.pauses[repository]; ok && now.Before(until) {
+		return until
+	}
+	return time.Time{}
+}
+
+// outputArtifactRepositories returns the repositories that the templates of the workflow save artifacts to
+func (woc *wfOperationCtx) outputArtifactRepositories() []string {
+	var repositories []string
+	for _, tmpl := range woc.execWf.Spec.Templates {
+		archiveLocation := tmpl.ArchiveLocation
+		if !archiveLocation.HasLocation() {
+			archiveLocation = woc.artifactRepository.ToArtifactLocation()
+		}
+		for _, art := range tmpl.Outputs.Artifacts {
+			l := archiveLocation
+			if art.HasLocation() {
+				l = &art.ArtifactLocation
+			}
+			if l == nil {
+				continue
+			}
+			if name := artifactRepositoryName(l, archiveLocation); name != "" && !slices.Contains(repositories, name) {
+				repositories = append(repositories, name)
+			}
+		}
+	}
+	slices.Sort(repositories)
+	return repositories
+}
+
+// checkArtifactQuotas returns why the workflow, which has not started yet, is postponed, as it saves artifacts to an
+// artifact repository that is out of space, and requeues it
+func (woc *wfOperationCtx) checkArtifactQuotas() string {
+	if woc.controller.Config.ArtifactOperations.GetQuotaPause() == nil || len(woc.wf.Status.Nodes) > 0 || woc.GetShutdownStrategy().Enabled() {
+		return ""
+	}
+	now := time.Now()
+	for _, repository := range woc.outputArtifactRepositories() {
+		if until := woc.controller.artifactQuotas.pausedUntil(repository, now); !until.IsZero() {
+			// the pause is cleared as soon as a pod saves artifacts to the repository, so the workflow is checked again
+			// before it ends
+			woc.requeueAfter(min(until.Sub(now), time.Minute))
+			return fmt.Sprintf("Workflow processing has been postponed as artifact repository %s is out of space, until a pod saves artifacts to it or %s", repository, until.UTC().Format(time.RFC3339))
+		}
+	}
+	return ""
+}
+
+// recordArtifactQuota reports the node of the pod, which has just completed, if it failed because an artifact
+// repository was out of space, pausing the repositories of the pod if that is configured. A node that succeeded clears
+// the pauses of the repositories of its pod.
+func (woc *wfOperationCtx) recordArtifactQuota(ctx context.Context, pod *apiv1.Pod, node *wfv1.NodeStatus) {
+	var repositories []string
+	if pod != nil && pod.Annotations[common.AnnotationKeyArtifactRepositories] != "" {
+		repositories = strings.Split(pod.Annotations[common.AnnotationKeyArtifactRepositories], ",")
+	}
+	if node.Succeeded() {
+		for _, repository := range repositories {
+			woc.controller.artifactQuotas.clear(repository)
+		}
+		return
+	}
+	if !strings.Contains(node.Message, common.ArtifactRepositoryQuotaExceededMessage) {
+		return
+	}
+	woc.log.WithField("artifactRepositories", repositories).WithField("nodeID", node.ID).Warn("Pod failed to save artifacts as its artifact repository was out of space")
+	woc.controller.metrics.ArtifactQuotaExceeded(ctx, woc.wf.Namespace)
+	woc.markArtifactQuotaExceeded(node.Name)
+	if cfg := woc.controller.Config.ArtifactOperations.GetQuotaPause(); cfg != nil {
+		until := time.Now().Add(cfg.GetDuration())
+		for _, repository := range repositories {
+			woc.controller.artifactQuotas.pause(repository, until)
+		}
+	}
+}
+
+func (woc *wfOperationCtx) markArtifactQuotaExceeded(nodeName string) {
+	msg := fmt.Sprintf("node %q could not save artifacts as the artifact repository is out of space", nodeName)
+	for _, condition := range woc.wf.Status.Conditions {
+		if condition.Type == wfv1.ConditionTypeArtifactQuotaExceeded && strings.Contains(condition.Message, msg) {
+			return
+		}
+	}
+	woc.wf.Status.Conditions.UpsertConditionMessage(wfv1.Condition{
+		Type:    wfv1.ConditionTypeArtifactQuotaExceeded,
+		Status:  metav1.ConditionTrue,
+		Message: msg,
+	})
+	woc.updated = true
+}
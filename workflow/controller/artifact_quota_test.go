@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestArtifactQuotaPauses(t *testing.T) {
+	now := time.Now()
+	p := &artifactQuotaPauses{}
+	assert.True(t, p.pausedUntil("s3://my-bucket", now).IsZero())
+	p.pause("s3://my-bucket", now.Add(time.Minute))
+	assert.Equal(t, now.Add(time.Minute), p.pausedUntil("s3://my-bucket", now))
+	assert.True(t, p.pausedUntil("gcs://my-bucket", now).IsZero(), "pauses are by repository")
+	assert.True(t, p.pausedUntil("s3://my-bucket", now.Add(time.Minute)).IsZero(), "the pause ends")
+	p.clear("s3://my-bucket")
+	assert.True(t, p.pausedUntil("s3://my-bucket", now).IsZero(), "a success clears the pause")
+}
+
+var artifactQuotaWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-quota
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+    outputs:
+      artifacts:
+      - name: out
+        path: /tmp/out
+`
+
+func TestCheckArtifactQuotas(t *testing.T) {
+	operate := func(t *testing.T, paused bool) *wfOperationCtx {
+		t.Helper()
+		wf := wfv1.MustUnmarshalWorkflow(artifactQuotaWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+		controller.Config.ArtifactOperations = &config.ArtifactOperationsConfig{QuotaPause: &config.ArtifactQuotaPause{}}
+		if paused {
+			controller.artifactQuotas.pause("s3://my-bucket", time.Now().Add(time.Minute))
+		}
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(context.Background())
+		return woc
+	}
+
+	t.Run("NotPaused", func(t *testing.T) {
+		woc := operate(t, false)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		require.Len(t, pods.Items, 1)
+		assert.Equal(t, "s3://my-bucket", pods.Items[0].Annotations[common.AnnotationKeyArtifactRepositories])
+	})
+	t.Run("Paused", func(t *testing.T) {
+		woc := operate(t, true)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		assert.Empty(t, pods.Items)
+		assert.Equal(t, wfv1.WorkflowPending, woc.wf.Status.Phase)
+		assert.Contains(t, woc.wf.Status.Message, "artifact repository s3://my-bucket is out of space")
+	})
+}
+
+func TestRecordArtifactQuota(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artifactQuotaWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.Config.ArtifactOperations = &config.ArtifactOperationsConfig{QuotaPause: &config.ArtifactQuotaPause{Duration: &metav1.Duration{Duration: time.Hour}}}
+	woc := newWorkflowOperationCtx(wf, controller)
+	pod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{common.AnnotationKeyArtifactRepositories: "s3://my-bucket"}}}
+
+	node := &wfv1.NodeStatus{ID: "my-node", Name: "artifact-quota", Phase: wfv1.NodeFailed, Message: "failed to save outputs: " + common.ArtifactRepositoryQuotaExceededMessage + ": storage full"}
+	woc.recordArtifactQuota(context.Background(), pod, node)
+	woc.recordArtifactQuota(context.Background(), pod, node)
+	require.Len(t, woc.wf.Status.Conditions, 1)
+	assert.Equal(t, wfv1.ConditionTypeArtifactQuotaExceeded, woc.wf.Status.Conditions[0].Type)
+	assert.Equal(t, `node "artifact-quota" could not save artifacts as the artifact repository is out of space`, woc.wf.Status.Conditions[0].Message)
+	assert.False(t, controller.artifactQuotas.pausedUntil("s3://my-bucket", time.Now()).IsZero())
+
+	woc.recordArtifactQuota(context.Background(), pod, &wfv1.NodeStatus{ID: "other-node", Phase: wfv1.NodeSucceeded})
+	assert.True(t, controller.artifactQuotas.pausedUntil("s3://my-bucket", time.Now()).IsZero())
+}
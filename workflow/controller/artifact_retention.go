@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+)
+
+// getArtifactRetention returns the retention of the artifact: the artifact's own, otherwise the Workflow's,
+// otherwise the artifact repository's. Artifacts that are never to be deleted have no retention.
+func (woc *wfOperationCtx) getArtifactRetention(a *wfv1.Artifact) *wfv1.ArtifactRetention {
+	artifactStrategy := a.GetArtifactGC().GetStrategy()
+	wfStrategy := woc.execWf.Spec.GetArtifactGC().GetStrategy()
+	if artifactStrategy == wfv1.ArtifactGCNever || (artifactStrategy == wfv1.ArtifactGCStrategyUndefined && wfStrategy == wfv1.ArtifactGCNever) {
+		return nil
+	}
+	if r := a.GetArtifactGC().GetRetention(); r != nil {
+		return r
+	}
+	if r := woc.execWf.Spec.GetArtifactGC().GetRetention(); r != nil {
+		return r
+	}
+	if woc.artifactRepository != nil {
+		return woc.artifactRepository.Retention
+	}
+	return nil
+}
+
+// garbageCollectExpiredArtifacts starts up Pods to delete the artifacts of a completed Workflow whose retention has
+// expired. Workflows are periodically re-processed by the informer's re-sync, which catches newer Workflows
+// completing, and are requeued for when the next artifact becomes too old.
+func (woc *wfOperationCtx) garbageCollectExpiredArtifacts(ctx context.Context) error {
+	if woc.wf.Labels[common.LabelKeyCompleted] != "true" || woc.wf.DeletionTimestamp != nil {
+		return nil
+	}
+	running, err := woc.artifactGCPodsRunning(wfv1.ArtifactGCOnRetentionExpiry)
+	if err != nil {
+		return err
+	}
+	if running {
+		woc.log.Debug("Waiting for Artifact GC Pods of expired artifacts to complete")
+		return nil
+	}
+	expired, next, err := woc.findExpiredArtifacts(time.Now())
+	if err != nil {
+		return err
+	}
+	if next > 0 {
+		woc.requeueAfter(next)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	woc.log.WithField("numArtifacts", len(expired)).Info("Deleting artifacts whose retention expired")
+	return woc.deleteArtifacts(ctx, wfv1.ArtifactGCOnRetentionExpiry, expired, artifactSearchResultsHash(expired))
+}
+
+// findExpiredArtifacts returns the artifacts whose retention has expired, and how long until the next artifact
+// becomes too old, if any
+func (woc *wfOperationCtx) findExpiredArtifacts(now time.Time) (wfv1.ArtifactSearchResults, time.Duration, error) {
+	var results wfv1.ArtifactSearchResults
+	var next time.Duration
+	newerWorkflows := -1
+	for _, n := range woc.wf.Status.Nodes {
+		if n.Type != wfv1.NodeTypePod || n.FinishedAt.IsZero() {
+			continue
+		}
+		for _, a := range n.GetOutputs().GetArtifacts() {
+			retention := woc.getArtifactRetention(&a)
+			if a.Deleted || retention == nil {
+				continue
+			}
+			expired := false
+			maxAge, err := retention.GetMaxAge()
+			if err != nil {
+				return nil, 0, err
+			}
+			if maxAge > 0 {
+				untilExpiry := n.FinishedAt.Add(maxAge).Sub(now)
+				if untilExpiry <= 0 {
+					expired = true
+				} else if next == 0 || untilExpiry < next {
+					next = untilExpiry
+				}
+			}
+			if retention.MaxCount != nil {
+				if newerWorkflows < 0 {
+					newerWorkflows, err = woc.countNewerCompletedWorkflows()
+					if err != nil {
+						return nil, 0, err
+					}
+				}
+				// the artifacts are kept while this is one of the MaxCount most recent workflows
+				if int32(newerWorkflows) >= *retention.MaxCount {
+					expired = true
+				}
+			}
+			if expired {
+				results = append(results, wfv1.ArtifactSearchResult{Artifact: a, NodeID: n.ID})
+			}
+		}
+	}
+	return results, next, nil
+}
+
+// countNewerCompletedWorkflows returns the number of completed Workflows that were created from the same
+// WorkflowTemplate, ClusterWorkflowTemplate, or CronWorkflow as this one, after it
+func (woc *wfOperationCtx) countNewerCompletedWorkflows() (int, error) {
+	for labelName, indexName := range map[string]string{
+		common.LabelKeyWorkflowTemplate:        indexes.WorkflowTemplateIndex,
+		common.LabelKeyClusterWorkflowTemplate: indexes.ClusterWorkflowTemplateIndex,
+		common.LabelKeyCronWorkflow:            indexes.CronWorkflowIndex,
+	} {
+		labelValue, exists := woc.wf.Labels[labelName]
+		if !exists {
+			continue
+		}
+		objs, err := woc.controller.wfInformer.GetIndexer().ByIndex(indexName, indexes.MetaNamespaceLabelIndex(woc.wf.Namespace, labelValue))
+		if err != nil {
+			return 0, fmt.Errorf("failed to list workflows by index: %w", err)
+		}
+		count := 0
+		for _, obj := range objs {
+			un, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return 0, fmt.Errorf("failed convert object to unstructured")
+			}
+			if un.GetLabels()[common.LabelKeyCompleted] == "true" && un.GetCreationTimestamp().After(woc.wf.CreationTimestamp.Time) {
+				count++
+			}
+		}
+		return count, nil
+	}
+	return 0, nil
+}
+
+// artifactGCPodsRunning returns whether any Artifact GC Pods for the strategy have not completed yet
+func (woc *wfOperationCtx) artifactGCPodsRunning(strategy wfv1.ArtifactGCStrategy) (bool, error) {
+	pods, err := woc.controller.PodController.GetPodsByIndex(indexes.WorkflowIndex, woc.wf.GetNamespace()+"/"+woc.wf.GetName())
+	if err != nil {
+		return false, fmt.Errorf("failed to get pods from informer: %w", err)
+	}
+	for _, obj := range pods {
+		pod := obj.(*corev1.Pod)
+		if pod.Labels[common.LabelKeyComponent] != artifactGCComponent || pod.Annotations[common.AnnotationKeyArtifactGCStrategy] != string(strategy) {
+			continue
+		}
+		if !woc.wf.Status.ArtifactGCStatus.IsArtifactGCPodRecouped(pod.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// artifactSearchResultsHash returns a hash of the artifacts, so that each set of expired artifacts is deleted by
+// different Pods
+func artifactSearchResultsHash(results wfv1.ArtifactSearchResults) string {
+	keys := make([]string, len(results))
+	for i, result := range results {
+		keys[i] = result.NodeID + "/" + result.Name
+	}
+	sort.Strings(keys)
+	h := fnv.New32a()
+	for _, key := range keys {
+		_, _ = h.Write([]byte(key))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%v", h.Sum32())
+}
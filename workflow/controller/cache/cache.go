@@ -8,6 +8,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
@@ -51,25 +52,30 @@ type cacheFactory struct {
 	caches     map[string]MemoizationCache
 	kubeclient kubernetes.Interface
 	namespace  string
+	sqlRepo    sqldb.MemoizationCacheRepo
 }
 
 type Factory interface {
 	GetCache(ct CacheType, name string) MemoizationCache
 }
 
-func NewCacheFactory(ki kubernetes.Interface, ns string) Factory {
+// NewCacheFactory returns a factory of memoization caches. SQL caches are only available if the repository of the
+// memoization caches in the persistence database is not nil.
+func NewCacheFactory(ki kubernetes.Interface, ns string, sqlRepo sqldb.MemoizationCacheRepo) Factory {
 	return &cacheFactory{
 		make(map[string]MemoizationCache),
 		ki,
 		ns,
+		sqlRepo,
 	}
 }
 
 type CacheType string
 
 const (
-	// Only config maps are currently supported for caching
 	ConfigMapCache CacheType = "ConfigMapCache"
+	// SQLCache saves the entries of the cache in the persistence database
+	SQLCache CacheType = "SQLCache"
 )
 
 // Returns a cache if it exists and creates it otherwise
@@ -83,6 +89,13 @@ func (cf *cacheFactory) GetCache(ct CacheType, name string) MemoizationCache {
 		c := NewConfigMapCache(cf.namespace, cf.kubeclient, name)
 		cf.caches[idx] = c
 		return c
+	case SQLCache:
+		if cf.sqlRepo == nil {
+			return nil
+		}
+		c := NewSQLCache(cf.namespace, cf.sqlRepo, name)
+		cf.caches[idx] = c
+		return c
 	default:
 		return nil
 	}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// sqlCache is a memoization cache whose entries are saved in the persistence database, so that it is not limited to
+// the 1MB of a ConfigMap
+type sqlCache struct {
+	namespace string
+	name      string
+	repo      sqldb.MemoizationCacheRepo
+}
+
+func NewSQLCache(ns string, repo sqldb.MemoizationCacheRepo, n string) MemoizationCache {
+	return &sqlCache{
+		namespace: ns,
+		name:      n,
+		repo:      repo,
+	}
+}
+
+func (c *sqlCache) log(fields log.Fields) *log.Entry {
+	return log.WithFields(log.Fields{"namespace": c.namespace, "name": c.name}).WithFields(fields)
+}
+
+func (c *sqlCache) Load(ctx context.Context, key string) (*Entry, error) {
+	if !cacheKeyRegex.MatchString(key) {
+		return nil, fmt.Errorf("invalid cache key: %s", key)
+	}
+	record, err := c.repo.Get(c.namespace, c.name, key)
+	if err != nil {
+		c.log(log.Fields{"key": key}).WithError(err).Debug("Error loading database cache")
+		return nil, fmt.Errorf("could not load database cache: %w", err)
+	}
+	if record == nil {
+		c.log(log.Fields{"key": key}).Info("database cache miss: entry does not exist")
+		return nil, nil
+	}
+
+	var outputs *wfv1.Outputs
+	if err := json.Unmarshal([]byte(record.Outputs), &outputs); err != nil {
+		return nil, fmt.Errorf("malformed cache entry: could not unmarshal JSON; unable to parse: %w", err)
+	}
+	hitTime := time.Now()
+	if err := c.repo.Hit(c.namespace, c.name, key, hitTime); err != nil {
+		c.log(log.Fields{"key": key}).WithError(err).Debug("Error updating last hit timestamp on cache")
+		return nil, fmt.Errorf("error updating last hit timestamp on cache: %w", err)
+	}
+	c.log(log.Fields{"key": key}).Info("database cache loaded")
+	return &Entry{
+		NodeID:            record.NodeID,
+		Outputs:           outputs,
+		CreationTimestamp: metav1.Time{Time: record.CreatedAt},
+		LastHitTimestamp:  metav1.Time{Time: hitTime},
+	}, nil
+}
+
+func (c *sqlCache) Save(ctx context.Context, key string, nodeID string, value *wfv1.Outputs) error {
+	if !cacheKeyRegex.MatchString(key) {
+		return fmt.Errorf("invalid cache key: %s", key)
+	}
+	c.log(log.Fields{"key": key, "nodeID": nodeID}).Info("Saving database cache entry")
+	outputs, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("unable to marshal cache entry: %w", err)
+	}
+	creationTime := time.Now().UTC()
+	err = c.repo.Save(&sqldb.MemoizationCacheRecord{
+		Namespace: c.namespace,
+		CacheName: c.name,
+		Key:       key,
+		NodeID:    nodeID,
+		Outputs:   string(outputs),
+		CreatedAt: creationTime,
+		LastHitAt: creationTime,
+	})
+	if err != nil {
+		c.log(log.Fields{"key": key, "nodeID": nodeID}).WithError(err).Debug("Error saving to database cache")
+		return fmt.Errorf("error creating cache entry: %w", err)
+	}
+	return nil
+}
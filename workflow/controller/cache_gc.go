@@ -25,6 +25,11 @@ func init() {
 
 // syncAllCacheForGC syncs all cache for GC
 func (wfc *WorkflowController) syncAllCacheForGC(ctx context.Context) {
+	if wfc.memoizationCacheRepo != nil {
+		if err := wfc.memoizationCacheRepo.DeleteExpired(wfc.Config.Persistence.MemoizationCache.GetTTL()); err != nil {
+			log.WithError(err).Error("Unable to delete expired memoization cache entries from the database")
+		}
+	}
 	configMaps, err := wfc.configMapInformer.GetIndexer().ByIndex(indexes.ConfigMapLabelsIndex, common.LabelValueTypeConfigMapCache)
 	if err != nil {
 		log.WithError(err).Error("Failed to get configmaps from informer")
@@ -3,12 +3,14 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
@@ -104,3 +106,68 @@ func TestConfigMapCacheSave(t *testing.T) {
 	wfv1.MustUnmarshal([]byte(cm.Data["hi-there-world"]), &entry)
 	assert.Equal(t, entry.LastHitTimestamp.Time, entry.CreationTimestamp.Time)
 }
+
+// fakeMemoizationCacheRepo is a memoization cache repository that keeps its entries in memory
+type fakeMemoizationCacheRepo map[string]*sqldb.MemoizationCacheRecord
+
+func (r fakeMemoizationCacheRepo) Get(namespace, cacheName, key string) (*sqldb.MemoizationCacheRecord, error) {
+	return r[namespace+"/"+cacheName+"/"+key], nil
+}
+
+func (r fakeMemoizationCacheRepo) Hit(namespace, cacheName, key string, at time.Time) error {
+	if record := r[namespace+"/"+cacheName+"/"+key]; record != nil {
+		record.LastHitAt = at
+	}
+	return nil
+}
+
+func (r fakeMemoizationCacheRepo) Save(record *sqldb.MemoizationCacheRecord) error {
+	r[record.Namespace+"/"+record.CacheName+"/"+record.Key] = record
+	return nil
+}
+
+func (r fakeMemoizationCacheRepo) DeleteExpired(ttl time.Duration) error {
+	for k, record := range r {
+		if time.Since(record.LastHitAt) > ttl {
+			delete(r, k)
+		}
+	}
+	return nil
+}
+
+func TestSQLCache(t *testing.T) {
+	ctx := context.Background()
+	repo := fakeMemoizationCacheRepo{}
+	c := cache.NewCacheFactory(nil, "default", repo).GetCache(cache.SQLCache, "whalesay-cache")
+	require.NotNil(t, c)
+
+	entry, err := c.Load(ctx, "hi-there-world")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	outputs := &wfv1.Outputs{Parameters: []wfv1.Parameter{{Name: "hello", Value: wfv1.AnyStringPtr("foobar")}}}
+	require.NoError(t, c.Save(ctx, "hi-there-world", "my-node", outputs))
+	require.Contains(t, repo, "default/whalesay-cache/hi-there-world")
+
+	entry, err = c.Load(ctx, "hi-there-world")
+	require.NoError(t, err)
+	assert.True(t, entry.Hit())
+	assert.Equal(t, "my-node", entry.NodeID)
+	assert.Equal(t, outputs, entry.GetOutputs())
+	assert.False(t, entry.LastHitTimestamp.Before(&entry.CreationTimestamp))
+
+	_, err = c.Load(ctx, "hi there")
+	require.Error(t, err)
+}
+
+func TestSQLCacheNotConfigured(t *testing.T) {
+	assert.Nil(t, cache.NewCacheFactory(nil, "default", nil).GetCache(cache.SQLCache, "whalesay-cache"))
+}
+
+func TestMemoizationCacheType(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	assert.Equal(t, cache.ConfigMapCache, controller.memoizationCacheType())
+	controller.memoizationCacheRepo = fakeMemoizationCacheRepo{}
+	assert.Equal(t, cache.SQLCache, controller.memoizationCacheType())
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
@@ -12,9 +13,12 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3"
 	persist "github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/sqldb"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 )
 
@@ -24,10 +28,15 @@ func (wfc *WorkflowController) updateConfig(ctx context.Context) error {
 		return err
 	}
 	log.Info("Configuration:\n" + string(bytes))
+	if err := common.ValidatePodNameTemplate(wfc.Config.PodNameTemplate); err != nil {
+		return fmt.Errorf("invalid podNameTemplate: %w", err)
+	}
 	wfc.artifactRepositories = artifactrepositories.New(wfc.kubeclientset, wfc.namespace, &wfc.Config.ArtifactRepository)
 	wfc.offloadNodeStatusRepo = persist.ExplosiveOffloadNodeStatusRepo
 	wfc.wfArchive = persist.NullWorkflowArchive
 	wfc.archiveLabelSelector = labels.Everything()
+	wfc.memoizationCacheRepo = nil
+	wfc.statusSnapshotRepo = nil
 
 	persistence := wfc.Config.Persistence
 	if persistence != nil {
@@ -61,19 +70,29 @@ func (wfc *WorkflowController) updateConfig(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
-			wfc.wfArchive = persist.NewWorkflowArchive(wfc.session, persistence.GetClusterName(), wfc.managedNamespace, instanceIDService)
+			wfc.wfArchive = persist.NewWorkflowArchive(wfc.session, persistence.GetClusterName(), wfc.managedNamespace, instanceIDService, persistence.ArchiveOutputParameters)
 			log.Info("Workflow archiving is enabled")
 		} else {
 			log.Info("Workflow archiving is disabled")
 		}
+		if persistence.MemoizationCache != nil {
+			wfc.memoizationCacheRepo = persist.NewMemoizationCacheRepo(wfc.session, persistence.GetClusterName())
+			log.Info("Memoization caches are saved in the database")
+		}
+		if persistence.StatusHistory != nil {
+			wfc.statusSnapshotRepo = persist.NewWorkflowStatusSnapshotRepo(wfc.session, persistence.GetClusterName())
+			log.Info("Snapshots of the statuses of workflows are saved in the database")
+		}
 	} else {
 		log.Info("Persistence configuration disabled")
 	}
+	wfc.cacheFactory = controllercache.NewCacheFactory(wfc.kubeclientset, wfc.namespace, wfc.memoizationCacheRepo)
 
 	wfc.hydrator = hydrator.New(wfc.offloadNodeStatusRepo)
 	wfc.updateEstimatorFactory()
 	wfc.rateLimiter = wfc.newRateLimiter()
 	wfc.maxStackDepth = wfc.getMaxStackDepth()
+	wfc.templateCompileCache = newTemplateCompileCache(wfc.Config.TemplateCompileCacheSize)
 
 	log.WithField("executorImage", wfc.executorImage()).
 		WithField("executorImagePullPolicy", wfc.executorImagePullPolicy()).
@@ -94,7 +113,39 @@ func (wfc *WorkflowController) initDB(ctx context.Context) error {
 		return err
 	}
 
-	return persist.Migrate(ctx, wfc.session, persistence.GetClusterName(), tableName)
+	err = persist.Migrate(ctx, wfc.session, persistence.GetClusterName(), tableName)
+	if err != nil {
+		return err
+	}
+	if persistence.MemoizationCache != nil {
+		return persist.MigrateMemoizationCache(ctx, wfc.session)
+	}
+	return nil
+}
+
+// memoizationCacheType returns the type of the caches that the outputs of memoized nodes are saved in
+func (wfc *WorkflowController) memoizationCacheType() controllercache.CacheType {
+	if wfc.memoizationCacheRepo != nil {
+		return controllercache.SQLCache
+	}
+	return controllercache.ConfigMapCache
+}
+
+// memoizationMaxAge returns the maximum age of the cache entries that the template hits, which is the shorter of its
+// maxAge and the maximum age of the controller, or 0 if neither is set
+func (wfc *WorkflowController) memoizationMaxAge(memoize *wfv1.Memoize) (time.Duration, error) {
+	var maxAge time.Duration
+	if memoize.MaxAge != "" {
+		var err error
+		maxAge, err = time.ParseDuration(memoize.MaxAge)
+		if err != nil {
+			return 0, fmt.Errorf("invalid maxAge: %s", err)
+		}
+	}
+	if limit := wfc.Config.Memoization.GetMaxAge(); limit > 0 && (maxAge <= 0 || maxAge > limit) {
+		maxAge = limit
+	}
+	return maxAge, nil
 }
 
 func (wfc *WorkflowController) newRateLimiter() *rate.Limiter {
@@ -33,6 +33,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	apiwatch "k8s.io/client-go/tools/watch"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/lru"
 
 	"github.com/argoproj/argo-workflows/v3"
 	"github.com/argoproj/argo-workflows/v3/config"
@@ -60,6 +61,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/informer"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/pod"
 	"github.com/argoproj/argo-workflows/v3/workflow/cron"
+	"github.com/argoproj/argo-workflows/v3/workflow/defaults"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 	"github.com/argoproj/argo-workflows/v3/workflow/gccontroller"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
@@ -121,6 +123,7 @@ type WorkflowController struct {
 	nsInformer            cache.SharedIndexInformer
 	wftmplInformer        wfextvv1alpha1.WorkflowTemplateInformer
 	cwftmplInformer       wfextvv1alpha1.ClusterWorkflowTemplateInformer
+	wfDefaults            *defaults.Defaults
 	PodController         *pod.Controller // Currently public for woc to access, but would rather an accessor
 	configMapInformer     cache.SharedIndexInformer
 	wfQueue               workqueue.TypedRateLimitingInterface[string]
@@ -131,6 +134,8 @@ type WorkflowController struct {
 	offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo
 	hydrator              hydrator.Interface
 	wfArchive             sqldb.WorkflowArchive
+	memoizationCacheRepo  sqldb.MemoizationCacheRepo
+	statusSnapshotRepo    sqldb.WorkflowStatusSnapshotRepo
 	estimatorFactory      estimation.EstimatorFactory
 	syncManager           *sync.Manager
 	metrics               *metrics.Metrics
@@ -150,6 +155,19 @@ type WorkflowController struct {
 	executorPlugins          map[string]map[string]*spec.Plugin // namespace -> name -> plugin
 
 	recentCompletions recentCompletions
+
+	// artifactGCRBACDone records the namespace/service accounts whose artifact GC RBAC has been provisioned or validated
+	artifactGCRBACDone gosync.Map
+	// templateCompileCache records the workflows that referenced a workflow template and passed validation, or is nil
+	templateCompileCache *lru.Cache
+	// artifactCircuits is the circuit breaker of the artifact repositories of pods
+	artifactCircuits artifactCircuitBreaker
+	// artifactQuotas are the artifact repositories that are out of space, which postpone new workflows
+	artifactQuotas artifactQuotaPauses
+	// lastStatusSnapshots records when the last snapshot of the status of each running workflow, by its UID, was saved
+	lastStatusSnapshots gosync.Map
+	// resultDeliveries runs the deliveries of the results of completed workflows
+	resultDeliveries *resultDeliveries
 }
 
 const (
@@ -199,7 +217,7 @@ func NewWorkflowController(ctx context.Context, restConfig *rest.Config, kubecli
 		cliExecutorLogFormat:       executorLogFormat,
 		configController:           config.NewController(namespace, configMap, kubeclientset),
 		workflowKeyLock:            syncpkg.NewKeyLock(),
-		cacheFactory:               controllercache.NewCacheFactory(kubeclientset, namespace),
+		cacheFactory:               controllercache.NewCacheFactory(kubeclientset, namespace, nil),
 		eventRecorderManager:       events.NewEventRecorderManager(kubeclientset),
 		progressPatchTickDuration:  env.LookupEnvDurationOr(common.EnvVarProgressPatchTickDuration, 1*time.Minute),
 		progressFileTickDuration:   env.LookupEnvDurationOr(common.EnvVarProgressFileTickDuration, 3*time.Second),
@@ -210,6 +228,7 @@ func NewWorkflowController(ctx context.Context, restConfig *rest.Config, kubecli
 	}
 
 	wfc.UpdateConfig(ctx)
+	wfc.wfDefaults = wfc.newWorkflowDefaults()
 	wfc.maxStackDepth = wfc.getMaxStackDepth()
 	wfc.metrics, err = metrics.New(ctx,
 		`workflows-controller`,
@@ -245,7 +264,7 @@ func (wfc *WorkflowController) newThrottler() sync.Throttler {
 func (wfc *WorkflowController) runGCcontroller(ctx context.Context, workflowTTLWorkers int) {
 	defer runtimeutil.HandleCrashWithContext(ctx, runtimeutil.PanicHandlers...)
 
-	gcCtrl := gccontroller.NewController(ctx, wfc.wfclientset, wfc.wfInformer, wfc.metrics, wfc.Config.RetentionPolicy)
+	gcCtrl := gccontroller.NewController(ctx, wfc.wfclientset, wfc.wfInformer, wfc.metrics, wfc.Config.RetentionPolicy, wfc.gcPausedUntil)
 	err := gcCtrl.Run(ctx.Done(), workflowTTLWorkers)
 	if err != nil {
 		panic(err)
@@ -261,7 +280,7 @@ func (wfc *WorkflowController) runPodController(ctx context.Context, podGCWorker
 func (wfc *WorkflowController) runCronController(ctx context.Context, cronWorkflowWorkers int) {
 	defer runtimeutil.HandleCrashWithContext(ctx, runtimeutil.PanicHandlers...)
 
-	cronController := cron.NewCronController(ctx, wfc.wfclientset, wfc.dynamicInterface, wfc.namespace, wfc.GetManagedNamespace(), wfc.Config.InstanceID, wfc.metrics, wfc.eventRecorderManager, cronWorkflowWorkers, wfc.wftmplInformer, wfc.cwftmplInformer, wfc.Config.WorkflowDefaults)
+	cronController := cron.NewCronController(ctx, wfc.wfclientset, wfc.dynamicInterface, wfc.namespace, wfc.GetManagedNamespace(), wfc.Config.InstanceID, wfc.metrics, wfc.eventRecorderManager, cronWorkflowWorkers, wfc.wftmplInformer, wfc.cwftmplInformer, wfc.wfDefaults, wfc.kubeclientset, wfc.Config.ImagePrePull, wfc.executorImage())
 	cronController.Run(ctx)
 }
 
@@ -290,6 +309,8 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 
 	defer wfc.wfQueue.ShutDown()
 
+	wfc.resultDeliveries = newResultDeliveries(ctx)
+
 	log.WithField("version", argo.GetVersion().Version).
 		WithField("defaultRequeueTime", GetRequeueTime()).
 		Info("Starting Workflow Controller")
@@ -340,6 +361,7 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 	go wfc.artGCTaskInformer.Informer().Run(ctx.Done())
 	go wfc.taskResultInformer.Run(ctx.Done())
 	wfc.createClusterWorkflowTemplateInformer(ctx)
+	wfc.createWorkflowDefaultsInformers(ctx)
 	go wfc.runPodController(ctx, podCleanupWorkers)
 
 	// Wait for all involved caches to be synced, before processing items from the queue is started
@@ -374,7 +396,10 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 	if cacheGCPeriod != 0 {
 		go wait.JitterUntilWithContext(ctx, wfc.syncAllCacheForGC, cacheGCPeriod, 0.0, true)
 	}
+	go wait.JitterUntilWithContext(ctx, wfc.deleteExpiredStatusSnapshots, statusSnapshotGCPeriod, 0.0, true)
 	<-ctx.Done()
+	// record the deliveries that were abandoned
+	wfc.resultDeliveries.wait()
 }
 
 func (wfc *WorkflowController) RunPrometheusServer(ctx context.Context, isDummy bool) {
@@ -642,6 +667,9 @@ func (wfc *WorkflowController) archivedWorkflowGarbageCollector(ctx context.Cont
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			if !wfc.waitForGCPause(ctx) {
+				return
+			}
 			log.Info("Performing archived workflow GC")
 			err := wfc.wfArchive.DeleteExpiredWorkflows(time.Duration(ttl))
 			if err != nil {
@@ -651,6 +679,28 @@ func (wfc *WorkflowController) archivedWorkflowGarbageCollector(ctx context.Cont
 	}
 }
 
+// gcPausedUntil returns when the current pause of garbage collection ends, or the zero time if it is not paused
+func (wfc *WorkflowController) gcPausedUntil() time.Time {
+	until, err := wfc.Config.GetGCPausedUntil(time.Now())
+	if err != nil {
+		log.WithError(err).Error("Failed to check the garbage collection pause windows")
+	}
+	return until
+}
+
+// waitForGCPause waits until garbage collection is not paused, and returns false if the context is done first
+func (wfc *WorkflowController) waitForGCPause(ctx context.Context) bool {
+	for until := wfc.gcPausedUntil(); !until.IsZero(); until = wfc.gcPausedUntil() {
+		log.WithField("until", until).Info("Garbage collection is paused, waiting to perform archived workflow GC")
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(time.Until(until)):
+		}
+	}
+	return true
+}
+
 func (wfc *WorkflowController) runWorker(ctx context.Context) {
 	defer runtimeutil.HandleCrashWithContext(ctx, runtimeutil.PanicHandlers...)
 
@@ -992,9 +1042,14 @@ func (wfc *WorkflowController) addWorkflowInformerHandlers(ctx context.Context)
 	}
 	_, err = wfc.wfInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
 			wf, ok := obj.(*unstructured.Unstructured)
-			if ok { // maybe cache.DeletedFinalStateUnknown
+			if ok {
 				wfc.metrics.StopRealtimeMetricsForWfUID(string(wf.GetUID()))
+				// a workflow deleted whilst it was running has no snapshot on completion to forget it
+				wfc.lastStatusSnapshots.Delete(string(wf.GetUID()))
 			}
 		},
 	})
@@ -1144,10 +1199,15 @@ func (wfc *WorkflowController) updateEstimatorFactory() {
 
 // setWorkflowDefaults sets values in the workflow.Spec with defaults from the
 // workflowController. Values in the workflow will be given the upper hand over the defaults.
-// The defaults for the workflow controller are set in the workflow-controller config map
+// The defaults for the workflow controller are set in the workflow-controller config map,
+// and in ClusterWorkflowDefaults and NamespaceWorkflowDefaults
 func (wfc *WorkflowController) setWorkflowDefaults(wf *wfv1.Workflow) error {
-	if wfc.Config.WorkflowDefaults != nil {
-		err := util.MergeTo(wfc.Config.WorkflowDefaults, wf)
+	wfDefaults, err := wfc.getWorkflowDefaults(wf.Namespace)
+	if err != nil {
+		return err
+	}
+	if wfDefaults != nil {
+		err := util.MergeTo(wfDefaults, wf)
 		if err != nil {
 			return err
 		}
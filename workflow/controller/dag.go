@@ -4,19 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/expr/argoexpr"
 	"github.com/argoproj/argo-workflows/v3/util/template"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
-	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
 )
 
 // dagContext holds context information about this context's DAG
@@ -56,6 +60,33 @@ type dagContext struct {
 	// Because this resolved "depends" is computed using regex and regex is expensive, we cache the results so that they
 	// are only computed once per operation
 	dependsLogic map[string]string
+
+	// selected are the tasks that spec.taskSelector selects, with the tasks they depend on. The other tasks are
+	// skipped. It is nil if all of the tasks are executed.
+	selected map[string]bool
+
+	// decisions are the decisions of the tasks that were ready to run when the DAG was executed, by task name. They
+	// are made concurrently by decideDAGTasks, and only read afterwards.
+	decisions map[string]*dagTaskDecision
+}
+
+// dagTaskDecision is a task with its references to its dependencies resolved, and its expansion, as decided against
+// a read-only snapshot of the workflow. It is only used when the task is executed if its scope and the global
+// parameters are still those it was decided with.
+type dagTaskDecision struct {
+	scope        *wfScope
+	globalParams common.Parameters
+	task         *wfv1.DAGTask
+	err          error
+	// expandedTasks and expandErr are only set if the task was resolved and does not have a dagTemplateFrom, which
+	// must be parsed before the task is expanded
+	expandedTasks []wfv1.DAGTask
+	expandErr     error
+}
+
+// madeWith returns whether the decision was made with the scope and global parameters
+func (d *dagTaskDecision) madeWith(scope *wfScope, globalParams common.Parameters) bool {
+	return maps.Equal(d.globalParams, globalParams) && reflect.DeepEqual(d.scope.scope, scope.scope)
 }
 
 func (d *dagContext) GetTaskDependencies(taskName string) []string {
@@ -100,11 +131,44 @@ func (d *dagContext) resolveDependencies(taskName string) {
 	for dep := range dependencies {
 		dependencyTasks = append(dependencyTasks, dep)
 	}
+	sort.Strings(dependencyTasks) // execute tasks in a predictable order
 
 	d.dependencies[taskName] = dependencyTasks
 	d.dependsLogic[taskName] = resolvedDependsLogic
 }
 
+// selectTasks returns the tasks that the selector selects, with the tasks they depend on, or nil if it selects none of
+// the tasks, in which case all of them are executed
+func (d *dagContext) selectTasks(taskSelector string) (map[string]bool, error) {
+	if taskSelector == "" {
+		return nil, nil
+	}
+	selector, err := labels.Parse(taskSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task selector %q: %w", taskSelector, err)
+	}
+	selected := make(map[string]bool)
+	var selectTask func(taskName string)
+	selectTask = func(taskName string) {
+		if selected[taskName] {
+			return
+		}
+		selected[taskName] = true
+		for _, dep := range d.GetTaskDependencies(taskName) {
+			selectTask(dep)
+		}
+	}
+	for _, task := range d.tasks {
+		if selector.Matches(labels.Set(task.Labels)) {
+			selectTask(task.Name)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+	return selected, nil
+}
+
 // taskNodeName formulates the nodeName for a dag task
 func (d *dagContext) taskNodeName(taskName string) string {
 	return fmt.Sprintf("%s.%s", d.boundaryName, taskName)
@@ -253,6 +317,10 @@ func (woc *wfOperationCtx) executeDAG(ctx context.Context, nodeName string, tmpl
 		dependencies:   make(map[string][]string),
 		dependsLogic:   make(map[string]string),
 	}
+	dagCtx.selected, err = dagCtx.selectTasks(woc.execWf.Spec.TaskSelector)
+	if err != nil {
+		return nil, err
+	}
 
 	// Identify our target tasks. If user did not specify any, then we choose all tasks which have
 	// no dependants.
@@ -274,7 +342,9 @@ func (woc *wfOperationCtx) executeDAG(ctx context.Context, nodeName string, tmpl
 		}
 	}
 
-	// kick off execution of each target task asynchronously
+	// kick off execution of each target task, and of the tasks it depends on. The tasks that are ready to run are
+	// decided concurrently first, then executed one at a time, as executing a task updates the nodes of the workflow.
+	woc.decideDAGTasks(dagCtx, targetTasks)
 	onExitCompleted := true
 	for _, taskName := range targetTasks {
 		woc.executeDAGTask(ctx, dagCtx, taskName)
@@ -313,7 +383,9 @@ func (woc *wfOperationCtx) executeDAG(ctx context.Context, nodeName string, tmpl
 
 	// Check if we are still running any tasks in this dag and return early if we do
 	// We should wait for onExit nodes even if ShutdownStrategy is enabled.
-	dagPhase, err := dagCtx.assessDAGPhase(targetTasks, woc.wf.Status.Nodes, woc.GetShutdownStrategy().Enabled() && onExitCompleted)
+	// When draining, the running tasks are waited for too, and the tasks that would be started fail instead.
+	isShutdown := woc.GetShutdownStrategy().ShouldTerminate(false) && onExitCompleted
+	dagPhase, err := dagCtx.assessDAGPhase(targetTasks, woc.wf.Status.Nodes, isShutdown)
 	if err != nil {
 		return nil, err
 	}
@@ -374,7 +446,7 @@ func (woc *wfOperationCtx) executeDAG(ctx context.Context, nodeName string, tmpl
 		woc.wf.Status.Nodes.Set(node.ID, *node)
 	}
 	if node.MemoizationStatus != nil {
-		c := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, node.MemoizationStatus.CacheName)
+		c := woc.controller.cacheFactory.GetCache(woc.controller.memoizationCacheType(), node.MemoizationStatus.CacheName)
 		err := c.Save(ctx, node.MemoizationStatus.Key, node.ID, node.Outputs)
 		if err != nil {
 			woc.log.WithFields(log.Fields{"nodeID": node.ID}).WithError(err).Error("Failed to save node outputs to cache")
@@ -441,31 +513,34 @@ func (woc *wfOperationCtx) executeDAGTask(ctx context.Context, dagCtx *dagContex
 	}
 
 	if node != nil && node.Phase.Fulfilled() {
-		// Collect the completed task metrics
-		_, tmpl, _, tmplErr := dagCtx.tmplCtx.ResolveTemplate(task)
-		if tmplErr != nil {
-			woc.markNodeError(node.Name, tmplErr)
-			return
-		}
-		if err := woc.mergedTemplateDefaultsInto(tmpl); err != nil {
-			woc.markNodeError(node.Name, err)
-			return
-		}
-		if tmpl != nil && tmpl.Metrics != nil {
-			if prevNodeStatus, ok := woc.preExecutionNodePhases[node.ID]; ok && !prevNodeStatus.Fulfilled() {
-				localScope, realTimeScope := woc.prepareMetricScope(node)
-				woc.computeMetrics(ctx, tmpl.Metrics.Prometheus, localScope, realTimeScope, false)
+		// the DAG of a task with dagTemplateFrom has neither metrics nor synchronization
+		if task.DAGTemplateFrom == "" {
+			// Collect the completed task metrics
+			_, tmpl, _, tmplErr := dagCtx.tmplCtx.ResolveTemplate(task)
+			if tmplErr != nil {
+				woc.markNodeError(node.Name, tmplErr)
+				return
+			}
+			if err := woc.mergedTemplateDefaultsInto(tmpl); err != nil {
+				woc.markNodeError(node.Name, err)
+				return
+			}
+			if tmpl != nil && tmpl.Metrics != nil {
+				if prevNodeStatus, ok := woc.preExecutionNodePhases[node.ID]; ok && !prevNodeStatus.Fulfilled() {
+					localScope, realTimeScope := woc.prepareMetricScope(node)
+					woc.computeMetrics(ctx, tmpl.Metrics.Prometheus, localScope, realTimeScope, false)
+				}
 			}
-		}
 
-		processedTmpl, err := common.ProcessArgs(tmpl, &task.Arguments, woc.globalParams, map[string]string{}, true, woc.wf.Namespace, woc.controller.configMapInformer.GetIndexer())
-		if err != nil {
-			woc.markNodeError(node.Name, err)
-		}
+			processedTmpl, err := common.ProcessArgs(tmpl, &task.Arguments, woc.globalParams, map[string]string{}, true, woc.wf.Namespace, woc.controller.configMapInformer.GetIndexer())
+			if err != nil {
+				woc.markNodeError(node.Name, err)
+			}
 
-		// Release acquired lock completed task.
-		if processedTmpl != nil {
-			woc.controller.syncManager.Release(ctx, woc.wf, node.ID, processedTmpl.Synchronization)
+			// Release acquired lock completed task.
+			if processedTmpl != nil {
+				woc.controller.syncManager.Release(ctx, woc.wf, node.ID, processedTmpl.Synchronization)
+			}
 		}
 
 		scope, err := woc.buildLocalScopeFromTask(dagCtx, task)
@@ -549,18 +624,41 @@ func (woc *wfOperationCtx) executeDAGTask(ctx context.Context, dagCtx *dagContex
 		}
 	}
 
+	if dagCtx.selected != nil && !dagCtx.selected[taskName] {
+		woc.initializeNode(nodeName, wfv1.NodeTypeSkipped, dagTemplateScope, task, dagCtx.boundaryID, wfv1.NodeSkipped, &wfv1.NodeFlag{}, fmt.Sprintf("task selector '%s' did not select the task", woc.execWf.Spec.TaskSelector))
+		connectDependencies(nodeName)
+		return
+	}
+
 	// All our dependencies were satisfied and successful. It's our turn to run
 	// First resolve/substitute params/artifacts from our dependencies
-	newTask, err := woc.resolveDependencyReferences(dagCtx, task)
+	newTask, decision, err := woc.resolveDependencyReferences(dagCtx, task)
 	if err != nil {
 		woc.initializeNode(nodeName, wfv1.NodeTypeSkipped, dagTemplateScope, task, dagCtx.boundaryID, wfv1.NodeError, &wfv1.NodeFlag{}, err.Error())
 		connectDependencies(nodeName)
 		return
 	}
 
+	// The DAG of a task with dagTemplateFrom is only known now that the output of its dependencies has been resolved.
+	// It is parsed again each time the task is executed, so it does not need to be stored.
+	if newTask.DAGTemplateFrom != "" {
+		newTask.Inline, err = woc.dagTemplateFrom(dagCtx, newTask)
+		if err != nil {
+			woc.initializeNode(nodeName, wfv1.NodeTypeSkipped, dagTemplateScope, task, dagCtx.boundaryID, wfv1.NodeError, &wfv1.NodeFlag{}, err.Error())
+			connectDependencies(nodeName)
+			return
+		}
+		woc.dagTemplatesFrom[nodeName] = newTask.Inline
+	}
+
 	// Next, expand the DAG's withItems/withParams/withSequence (if any). If there was none, then
 	// expandedTasks will be a single element list of the same task
-	expandedTasks, err := expandTask(*newTask)
+	var expandedTasks []wfv1.DAGTask
+	if decision != nil && newTask.DAGTemplateFrom == "" {
+		expandedTasks, err = decision.expandedTasks, decision.expandErr
+	} else {
+		expandedTasks, err = expandTask(*newTask)
+	}
 	if err != nil {
 		woc.initializeNode(nodeName, wfv1.NodeTypeSkipped, dagTemplateScope, task, dagCtx.boundaryID, wfv1.NodeError, &wfv1.NodeFlag{}, err.Error())
 		connectDependencies(nodeName)
@@ -582,10 +680,25 @@ func (woc *wfOperationCtx) executeDAGTask(ctx context.Context, dagCtx *dagContex
 		}
 	}
 
+	// The expanded tasks that have started but not yet fulfilled count towards the maxFanout of the task. The rest wait
+	// for them, without a node, until fewer are running.
+	var running int64
+	if task.MaxFanout != nil {
+		for _, t := range expandedTasks {
+			if node := dagCtx.getTaskNode(t.Name); node != nil && !node.Fulfilled() {
+				running++
+			}
+		}
+	}
+
 	for _, t := range expandedTasks {
 		taskNodeName := dagCtx.taskNodeName(t.Name)
 		node = dagCtx.getTaskNode(t.Name)
-		if node == nil {
+		notStarted := node == nil
+		if notStarted {
+			if task.MaxFanout != nil && running >= *task.MaxFanout {
+				continue
+			}
 			woc.log.Infof("All of node %s dependencies %v completed", taskNodeName, taskDependencies)
 			// Add the child relationship from our dependency's outbound nodes to this node.
 			connectDependencies(taskNodeName)
@@ -623,6 +736,10 @@ func (woc *wfOperationCtx) executeDAGTask(ctx context.Context, dagCtx *dagContex
 		if node == nil {
 			return
 		}
+		// a task that has just been started counts towards the maxFanout of the tasks after it
+		if notStarted && !node.Fulfilled() {
+			running++
+		}
 		if node.Completed() {
 			scope, err := woc.buildLocalScopeFromTask(dagCtx, task)
 			if err != nil {
@@ -655,6 +772,12 @@ func (woc *wfOperationCtx) executeDAGTask(ctx context.Context, dagCtx *dagContex
 }
 
 func (woc *wfOperationCtx) buildLocalScopeFromTask(dagCtx *dagContext, task *wfv1.DAGTask) (*wfScope, error) {
+	return woc.buildTaskScope(dagCtx, task, true)
+}
+
+// buildTaskScope builds the local scope of a task. The templates of its ancestors that are task groups are only
+// resolved if resolveTemplates is true, as resolving a template may store it in the workflow.
+func (woc *wfOperationCtx) buildTaskScope(dagCtx *dagContext, task *wfv1.DAGTask, resolveTemplates bool) (*wfScope, error) {
 	// build up the scope
 	scope := createScope(dagCtx.tmpl)
 	woc.addOutputsToLocalScope("workflow", woc.wf.Status.Outputs, scope)
@@ -677,16 +800,18 @@ func (woc *wfOperationCtx) buildLocalScopeFromTask(dagCtx *dagContext, task *wfv
 					ancestorNodes = append(ancestorNodes, node)
 				}
 			}
-			_, _, templateStored, err := dagCtx.tmplCtx.ResolveTemplate(ancestorNode)
-			if err != nil {
-				return nil, errors.InternalWrapError(err)
-			}
-			// A new template was stored during resolution, persist it
-			if templateStored {
-				woc.updated = true
+			if resolveTemplates {
+				_, _, templateStored, err := dagCtx.tmplCtx.ResolveTemplate(ancestorNode)
+				if err != nil {
+					return nil, errors.InternalWrapError(err)
+				}
+				// A new template was stored during resolution, persist it
+				if templateStored {
+					woc.updated = true
+				}
 			}
 
-			err = woc.processAggregateNodeOutputs(scope, prefix, ancestorNodes)
+			err := woc.processAggregateNodeOutputs(scope, prefix, ancestorNodes)
 			if err != nil {
 				return nil, errors.InternalWrapError(err)
 			}
@@ -697,27 +822,60 @@ func (woc *wfOperationCtx) buildLocalScopeFromTask(dagCtx *dagContext, task *wfv
 	return scope, nil
 }
 
-// resolveDependencyReferences replaces any references to outputs of task dependencies, or artifacts in the inputs
+// dagTemplateFrom returns the DAG template that the JSON value of the resolved dagTemplateFrom of the task defines,
+// which is either a DAG template or just its tasks. It is validated like the templates of the workflow, and may only
+// run the templates of the DAG that the task is in by name.
+func (woc *wfOperationCtx) dagTemplateFrom(dagCtx *dagContext, task *wfv1.DAGTask) (*wfv1.Template, error) {
+	value := strings.TrimSpace(task.DAGTemplateFrom)
+	dag := &wfv1.DAGTemplate{}
+	var err error
+	if strings.HasPrefix(value, "[") {
+		err = json.Unmarshal([]byte(value), &dag.Tasks)
+	} else {
+		err = json.Unmarshal([]byte(value), dag)
+	}
+	if err != nil {
+		return nil, errors.Errorf(errors.CodeBadRequest, "dagTemplateFrom of task '%s' is not a DAG template: %v", task.Name, err)
+	}
+	tmpl := &wfv1.Template{Name: task.Name, DAG: dag}
+	if err := validate.ValidateDAGTemplateFrom(woc.execWf, dagCtx.tmplCtx, woc.globalParams, tmpl); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// resolveDependencyReferences replaces any references to outputs of task dependencies, or artifacts in the inputs.
+// The decision of the task is returned if it was used, see dagTaskDecision.
 // NOTE: by now, input parameters should have been substituted throughout the template
-func (woc *wfOperationCtx) resolveDependencyReferences(dagCtx *dagContext, task *wfv1.DAGTask) (*wfv1.DAGTask, error) {
+func (woc *wfOperationCtx) resolveDependencyReferences(dagCtx *dagContext, task *wfv1.DAGTask) (*wfv1.DAGTask, *dagTaskDecision, error) {
 	scope, err := woc.buildLocalScopeFromTask(dagCtx, task)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Perform replacement
 	// Replace woc.volumes
 	err = woc.substituteParamsInVolumes(scope.getParameters())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	if decision := dagCtx.decisions[task.Name]; decision != nil && decision.madeWith(scope, woc.globalParams) {
+		return decision.task, decision, decision.err
+	}
+	newTask, err := woc.resolveTaskReferences(scope, woc.globalParams, task)
+	return newTask, nil, err
+}
+
+// resolveTaskReferences replaces the references of a task to its scope and to the global parameters. It only reads
+// the workflow.
+func (woc *wfOperationCtx) resolveTaskReferences(scope *wfScope, globalParams common.Parameters, task *wfv1.DAGTask) (*wfv1.DAGTask, error) {
 	// Replace task's parameters
 	taskBytes, err := json.Marshal(task)
 	if err != nil {
 		return nil, errors.InternalWrapError(err)
 	}
-	newTaskStr, err := template.Replace(string(taskBytes), woc.globalParams.Merge(scope.getParameters()), true)
+	newTaskStr, err := template.Replace(string(taskBytes), globalParams.Merge(scope.getParameters()), true)
 	if err != nil {
 		return nil, err
 	}
@@ -767,6 +925,95 @@ func (woc *wfOperationCtx) resolveDependencyReferences(dagCtx *dagContext, task
 	return &newTask, nil
 }
 
+// decideDAGTasks decides the tasks of the DAG that are ready to run, with up to dagTaskWorkers at a time, see
+// dagTaskDecision. Each decision only reads the workflow, and is stored by the name of its task, so the decisions do
+// not depend on the order that they are made in. The nodes of the tasks are then created as the DAG is executed, in
+// the same order as when the tasks are decided one at a time.
+func (woc *wfOperationCtx) decideDAGTasks(dagCtx *dagContext, targetTasks []string) {
+	if dagTaskWorkers <= 1 {
+		return
+	}
+	tasks := dagCtx.readyTasks(targetTasks)
+	if len(tasks) < 2 {
+		return
+	}
+	globalParams := maps.Clone(woc.globalParams)
+	decisions := make([]*dagTaskDecision, len(tasks))
+	parallelTaskNum := make(chan string, dagTaskWorkers)
+	var wg sync.WaitGroup
+
+	for i, task := range tasks {
+		parallelTaskNum <- task.Name
+		wg.Add(1)
+		go func(i int, task *wfv1.DAGTask) {
+			defer wg.Done()
+			decisions[i] = woc.decideDAGTask(dagCtx, globalParams, task)
+			<-parallelTaskNum
+		}(i, task)
+	}
+
+	wg.Wait()
+
+	dagCtx.decisions = make(map[string]*dagTaskDecision, len(tasks))
+	for i, task := range tasks {
+		if decisions[i] != nil {
+			dagCtx.decisions[task.Name] = decisions[i]
+		}
+	}
+}
+
+// decideDAGTask decides a task against the workflow, which it only reads. It returns nil if the scope of the task
+// cannot be built, which is then reported when the task is executed.
+func (woc *wfOperationCtx) decideDAGTask(dagCtx *dagContext, globalParams common.Parameters, task *wfv1.DAGTask) *dagTaskDecision {
+	scope, err := woc.buildTaskScope(dagCtx, task, false)
+	if err != nil {
+		return nil
+	}
+	decision := &dagTaskDecision{scope: scope, globalParams: globalParams}
+	decision.task, decision.err = woc.resolveTaskReferences(scope, globalParams, task)
+	if decision.err == nil && decision.task.DAGTemplateFrom == "" {
+		decision.expandedTasks, decision.expandErr = expandTask(*decision.task)
+	}
+	return decision
+}
+
+// readyTasks returns the tasks that are, or that the target tasks depend on, that are selected and have not
+// started, and whose dependencies and their ancestors are fulfilled, in the order of the tasks of the DAG.
+func (d *dagContext) readyTasks(targetTasks []string) []*wfv1.DAGTask {
+	fulfilled := make(map[string]bool)
+	ready := make(map[string]bool)
+	var visit func(taskName string) bool
+	visit = func(taskName string) bool {
+		if ok, visited := fulfilled[taskName]; visited {
+			return ok
+		}
+		fulfilled[taskName] = false
+		ancestorsFulfilled := true
+		for _, dep := range d.GetTaskDependencies(taskName) {
+			if !visit(dep) {
+				ancestorsFulfilled = false
+			}
+		}
+		node, err := d.wf.Status.Nodes.Get(d.taskNodeID(taskName))
+		if err != nil {
+			ready[taskName] = ancestorsFulfilled && (d.selected == nil || d.selected[taskName])
+			return false
+		}
+		fulfilled[taskName] = ancestorsFulfilled && node.Fulfilled() && common.CheckAllHooksFullfilled(node, d.wf.Status.Nodes)
+		return fulfilled[taskName]
+	}
+	for _, taskName := range targetTasks {
+		visit(taskName)
+	}
+	var tasks []*wfv1.DAGTask
+	for _, task := range d.tasks {
+		if ready[task.Name] {
+			tasks = append(tasks, &task)
+		}
+	}
+	return tasks
+}
+
 // findLeafTaskNames finds the names of all tasks whom no other nodes depend on.
 // This list of tasks is used as the default list of targets when dag.targets is omitted.
 func (d *dagContext) findLeafTaskNames(tasks []wfv1.DAGTask) []string {
@@ -3720,3 +3720,324 @@ func TestDagWftmplHookWithRetry(t *testing.T) {
 	finishNode := woc.wf.Status.Nodes.FindByDisplayName("finish")
 	assert.Equal(t, wfv1.NodeOmitted, finishNode.Phase)
 }
+
+var dagMaxFanout = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: dag-max-fanout
+  namespace: default
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: fanout
+            template: echo
+            maxFanout: 2
+            withItems: [a, b, c, d, e]
+    - name: echo
+      container:
+        image: alpine
+        command: [echo]
+`
+
+func TestDAGMaxFanout(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(dagMaxFanout)
+	woc := newWoc(*wf)
+	ctx := context.Background()
+	woc.operate(ctx)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 2)
+
+	// each time the running tasks succeed, the next ones are started
+	for _, expected := range []int{4, 5} {
+		makePodsPhase(ctx, woc, v1.PodSucceeded)
+		woc.operate(ctx)
+		pods, err = listPods(woc)
+		require.NoError(t, err)
+		assert.Len(t, pods.Items, expected)
+		assert.Equal(t, wfv1.NodeRunning, woc.wf.Status.Nodes.FindByDisplayName("fanout").Phase)
+	}
+
+	makePodsPhase(ctx, woc, v1.PodSucceeded)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.NodeSucceeded, woc.wf.Status.Nodes.FindByDisplayName("fanout").Phase)
+	assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+}
+
+var dagTemplateFromWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: dag-template-from
+  namespace: default
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: tasks
+        value: '%s'
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: dynamic
+            dagTemplateFrom: "{{workflow.parameters.tasks}}"
+    - name: echo
+      container:
+        image: alpine
+        command: [echo]
+`
+
+func TestDAGTemplateFrom(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Tasks", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(dagTemplateFromWf, `[{"name": "a", "template": "echo"}, {"name": "b", "template": "echo", "depends": "a"}]`))
+		woc := newWoc(*wf)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.NodeTypeDAG, woc.wf.Status.Nodes.FindByDisplayName("dynamic").Type)
+		assert.NotNil(t, woc.wf.Status.Nodes.FindByDisplayName("a"))
+		assert.Nil(t, woc.wf.Status.Nodes.FindByDisplayName("b"))
+
+		makePodsPhase(ctx, woc, v1.PodSucceeded)
+		woc.operate(ctx)
+		assert.NotNil(t, woc.wf.Status.Nodes.FindByDisplayName("b"))
+
+		makePodsPhase(ctx, woc, v1.PodSucceeded)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.NodeSucceeded, woc.wf.Status.Nodes.FindByDisplayName("dynamic").Phase)
+		assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+	})
+	t.Run("DAGTemplate", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(dagTemplateFromWf, `{"tasks": [{"name": "a", "template": "echo"}, {"name": "b", "template": "echo"}]}`))
+		woc := newWoc(*wf)
+		woc.operate(ctx)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		assert.Len(t, pods.Items, 2)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(dagTemplateFromWf, `[{"name": "a", "template": "echo", "depends": "missing"}]`))
+		woc := newWoc(*wf)
+		woc.operate(ctx)
+		node := woc.wf.Status.Nodes.FindByDisplayName("dynamic")
+		require.NotNil(t, node)
+		assert.Equal(t, wfv1.NodeError, node.Phase)
+		assert.Contains(t, node.Message, "dependency 'missing' not defined")
+		assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+	})
+	t.Run("Inline", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(dagTemplateFromWf, `[{"name": "a", "inline": {"container": {"image": "evil", "command": ["sh"]}}}]`))
+		woc := newWoc(*wf)
+		woc.operate(ctx)
+		node := woc.wf.Status.Nodes.FindByDisplayName("dynamic")
+		require.NotNil(t, node)
+		assert.Equal(t, wfv1.NodeError, node.Phase)
+		assert.Contains(t, node.Message, "inline, templateRef, and dagTemplateFrom are not allowed")
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		assert.Empty(t, pods.Items)
+	})
+	t.Run("MissingTemplate", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(dagTemplateFromWf, `[{"name": "a", "template": "missing"}]`))
+		woc := newWoc(*wf)
+		woc.operate(ctx)
+		node := woc.wf.Status.Nodes.FindByDisplayName("dynamic")
+		require.NotNil(t, node)
+		assert.Equal(t, wfv1.NodeError, node.Phase)
+		assert.Contains(t, node.Message, "template 'missing' not found")
+	})
+}
+
+var dagTaskSelectorWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: dag-task-selector
+  namespace: default
+spec:
+  entrypoint: main
+  taskSelector: %s
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: a
+            template: echo
+          - name: b
+            template: echo
+            depends: a
+            labels:
+              canary: "true"
+          - name: c
+            template: echo
+            depends: a
+          - name: d
+            template: echo
+            depends: b && c
+    - name: echo
+      container:
+        image: alpine
+        command: [echo]
+`
+
+func TestDAGTaskSelector(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Selected", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(dagTaskSelectorWf, "canary=true"))
+		woc := newWoc(*wf)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.NodePending, woc.wf.Status.Nodes.FindByDisplayName("a").Phase)
+
+		makePodsPhase(ctx, woc, v1.PodSucceeded)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.NodePending, woc.wf.Status.Nodes.FindByDisplayName("b").Phase)
+		c := woc.wf.Status.Nodes.FindByDisplayName("c")
+		require.NotNil(t, c)
+		assert.Equal(t, wfv1.NodeSkipped, c.Phase)
+		assert.Equal(t, "task selector 'canary=true' did not select the task", c.Message)
+
+		makePodsPhase(ctx, woc, v1.PodSucceeded)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.NodeSkipped, woc.wf.Status.Nodes.FindByDisplayName("d").Phase)
+		assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		assert.Len(t, pods.Items, 2)
+	})
+	t.Run("NoneSelected", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(dagTaskSelectorWf, "team=ml"))
+		woc := newWoc(*wf)
+		woc.operate(ctx)
+		makePodsPhase(ctx, woc, v1.PodSucceeded)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.NodePending, woc.wf.Status.Nodes.FindByDisplayName("b").Phase)
+		assert.Equal(t, wfv1.NodePending, woc.wf.Status.Nodes.FindByDisplayName("c").Phase)
+	})
+}
+
+var dagTaskDecisionsWf = `
+metadata:
+  name: dag-task-decisions
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: message
+        value: hello
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: a
+            template: echo
+            arguments:
+              parameters:
+                - name: message
+                  value: "{{workflow.parameters.message}}"
+%s
+          - name: z
+            template: echo
+            depends: %s
+            arguments:
+              parameters:
+                - name: message
+                  value: "{{tasks.b0.status}}"
+    - name: echo
+      inputs:
+        parameters:
+          - name: message
+      container:
+        image: alpine
+        command: [echo, "{{inputs.parameters.message}}"]
+`
+
+// newDAGTaskDecisionsWf returns a workflow whose tasks b0 to b(n-1) are ready to run once a has succeeded. The first
+// of them expand to the most tasks, so they tend to be decided last.
+func newDAGTaskDecisionsWf(n int) *wfv1.Workflow {
+	var tasks, depends []string
+	for i := 0; i < n; i++ {
+		tasks = append(tasks, fmt.Sprintf(`          - name: b%d
+            template: echo
+            depends: a
+            withSequence:
+              count: "%d"
+            arguments:
+              parameters:
+                - name: message
+                  value: "{{workflow.parameters.message}} {{tasks.a.status}} {{item}}"`, i, 10*(n-i)))
+		depends = append(depends, fmt.Sprintf("b%d", i))
+	}
+	return wfv1.MustUnmarshalWorkflow(fmt.Sprintf(dagTaskDecisionsWf, strings.Join(tasks, "\n"), strings.Join(depends, " && ")))
+}
+
+// operateDAGTaskDecisions runs the workflow until the tasks after a have started, and returns its nodes without the
+// times that they started at
+func operateDAGTaskDecisions(t *testing.T, workers int) wfv1.Nodes {
+	t.Helper()
+	defer func(workers int) { dagTaskWorkers = workers }(dagTaskWorkers)
+	dagTaskWorkers = workers
+
+	ctx := context.Background()
+	woc := newWoc(*newDAGTaskDecisionsWf(8))
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, v1.PodSucceeded)
+	woc.operate(ctx)
+	nodes := woc.wf.Status.Nodes.DeepCopy()
+	for id, node := range nodes {
+		node.StartedAt = metav1.Time{}
+		node.FinishedAt = metav1.Time{}
+		nodes[id] = node
+	}
+	return nodes
+}
+
+func TestDAGTaskDecisions(t *testing.T) {
+	want := operateDAGTaskDecisions(t, 1)
+	b0 := want.FindByDisplayName("b0(79:79)")
+	require.NotNil(t, b0)
+	assert.Equal(t, "hello Succeeded 79", b0.Inputs.Parameters[0].Value.String())
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, want, operateDAGTaskDecisions(t, 16), "the nodes must not depend on the order that the tasks are decided in")
+	}
+}
+
+func TestDAGTaskDecisionsStale(t *testing.T) {
+	defer func(workers int) { dagTaskWorkers = workers }(dagTaskWorkers)
+	dagTaskWorkers = 16
+
+	ctx := context.Background()
+	woc := newWoc(*newDAGTaskDecisionsWf(2))
+	woc.operate(ctx)
+	a := woc.wf.Status.Nodes.FindByDisplayName("a")
+	require.NotNil(t, a)
+	a.Phase = wfv1.NodeSucceeded
+	woc.wf.Status.Nodes.Set(a.ID, *a)
+
+	tmpl := woc.wf.Spec.Templates[0]
+	dagCtx := &dagContext{
+		boundaryName: woc.wf.Name,
+		boundaryID:   woc.wf.Name,
+		tasks:        tmpl.DAG.Tasks,
+		tmpl:         &tmpl,
+		wf:           woc.wf,
+		dependencies: make(map[string][]string),
+		dependsLogic: make(map[string]string),
+	}
+	woc.decideDAGTasks(dagCtx, []string{"z"})
+	assert.Len(t, dagCtx.decisions, 2)
+
+	task, decision, err := woc.resolveDependencyReferences(dagCtx, dagCtx.GetTask("b0"))
+	require.NoError(t, err)
+	assert.NotNil(t, decision)
+	assert.Equal(t, "hello Succeeded {{item}}", task.Arguments.Parameters[0].Value.String())
+
+	woc.globalParams["workflow.parameters.message"] = "goodbye"
+	task, decision, err = woc.resolveDependencyReferences(dagCtx, dagCtx.GetTask("b1"))
+	require.NoError(t, err)
+	assert.Nil(t, decision, "a decision must not be used once the global parameters have changed")
+	assert.Equal(t, "goodbye Succeeded {{item}}", task.Arguments.Parameters[0].Value.String())
+}
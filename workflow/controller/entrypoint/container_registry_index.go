@@ -2,12 +2,17 @@ package entrypoint
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
 )
 
 type containerRegistryIndex struct {
@@ -29,7 +34,7 @@ func (i *containerRegistryIndex) Lookup(ctx context.Context, image string, optio
 	}
 	img, err := remote.Image(ref, remote.WithAuthFromKeychain(kc))
 	if err != nil {
-		return nil, err
+		return nil, registryErr(err)
 	}
 	f, err := img.ConfigFile()
 	if err != nil {
@@ -41,6 +46,16 @@ func (i *containerRegistryIndex) Lookup(ctx context.Context, image string, optio
 	}, nil
 }
 
+// registryErr marks errors of a registry that is unavailable or rate limiting as transient, so that the pod is created
+// once the registry is back, rather than failing the step
+func registryErr(err error) error {
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) && (transportErr.StatusCode >= http.StatusInternalServerError || transportErr.StatusCode == http.StatusTooManyRequests) {
+		return errorsutil.NewErrTransient(err.Error())
+	}
+	return err
+}
+
 func imagePullSecretNames(secrets []v1.LocalObjectReference) []string {
 	var v []string
 	for _, s := range secrets {
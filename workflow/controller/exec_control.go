@@ -38,10 +38,11 @@ func (woc *wfOperationCtx) applyExecutionControl(pod *apiv1.Pod, wfNodesLock *sy
 	case apiv1.PodPending, apiv1.PodRunning:
 		// Check if we are currently shutting down
 		if woc.GetShutdownStrategy().Enabled() {
-			// Only delete pods that are not part of an onExit handler if we are "Stopping" or all pods if we are "Terminating"
+			// Only delete pods that are not part of an onExit handler if we are "Stopping" or all pods if we are "Terminating",
+			// and none if we are "Draining"
 			_, onExitPod := pod.Labels[common.LabelKeyOnExit]
 
-			if !woc.GetShutdownStrategy().ShouldExecute(onExitPod) {
+			if woc.GetShutdownStrategy().ShouldTerminate(onExitPod) {
 				woc.log.WithField("podName", pod.Name).
 					WithField("shutdownStrategy", woc.GetShutdownStrategy()).
 					Info("Terminating pod as part of workflow shutdown")
@@ -65,9 +66,17 @@ func (woc *wfOperationCtx) applyExecutionControl(pod *apiv1.Pod, wfNodesLock *sy
 				return
 			}
 		}
+		woc.checkPreemption(pod, node, wfNodesLock)
+		if message := woc.checkLiveness(pod); message != "" {
+			woc.log.WithField("podName", pod.Name).
+				Info("Terminating pod which has failed its liveness probe")
+			woc.controller.PodController.TerminateContainers(pod.Namespace, pod.Name)
+			woc.handleExecutionControlError(nodeID, wfNodesLock, message)
+			return
+		}
 	}
 	if woc.GetShutdownStrategy().Enabled() {
-		if _, onExitPod := pod.Labels[common.LabelKeyOnExit]; !woc.GetShutdownStrategy().ShouldExecute(onExitPod) {
+		if _, onExitPod := pod.Labels[common.LabelKeyOnExit]; woc.GetShutdownStrategy().ShouldTerminate(onExitPod) {
 			woc.log.WithField("podName", pod.Name).
 				Info("Terminating on-exit pod")
 			woc.controller.PodController.TerminateContainers(pod.Namespace, pod.Name)
@@ -75,6 +84,43 @@ func (woc *wfOperationCtx) applyExecutionControl(pod *apiv1.Pod, wfNodesLock *sy
 	}
 }
 
+// checkLiveness returns why the pod has failed the liveness probe of its template, or requeues the workflow to check
+// again if the probe is failing but has not failed for long enough yet
+func (woc *wfOperationCtx) checkLiveness(pod *apiv1.Pod) string {
+	failureTimeout, ok := pod.Annotations[common.AnnotationKeyLivenessFailureTimeout]
+	if !ok || pod.Status.Phase != apiv1.PodRunning {
+		return ""
+	}
+	timeout, err := time.ParseDuration(failureTimeout)
+	if err != nil {
+		woc.log.WithField("podName", pod.Name).WithError(err).Warn("Invalid liveness failure timeout")
+		return ""
+	}
+	failing := false
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == common.MainContainerName {
+			failing = !s.Ready && s.State.Running != nil
+		}
+	}
+	if !failing {
+		return ""
+	}
+	failingSince := pod.Status.StartTime
+	for _, c := range pod.Status.Conditions {
+		if c.Type == apiv1.ContainersReady && c.Status == apiv1.ConditionFalse {
+			failingSince = &c.LastTransitionTime
+		}
+	}
+	if failingSince == nil {
+		return ""
+	}
+	if d := time.Since(failingSince.Time); d < timeout {
+		woc.requeueAfter(timeout - d)
+		return ""
+	}
+	return fmt.Sprintf("liveness probe failed for %s", timeout)
+}
+
 // handleExecutionControlError marks a node as failed with an error message
 func (woc *wfOperationCtx) handleExecutionControlError(nodeID string, wfNodesLock *sync.RWMutex, errorMsg string) {
 	wfNodesLock.Lock()
@@ -114,7 +160,7 @@ func (woc *wfOperationCtx) killDaemonedChildren(nodeID string) {
 		if !childNode.IsDaemoned() {
 			continue
 		}
-		podName := util.GeneratePodName(woc.wf.Name, childNode.Name, util.GetTemplateFromNode(childNode), childNode.ID, util.GetWorkflowPodNameVersion(woc.wf))
+		podName := util.GenerateWorkflowPodName(woc.wf, childNode.Name, util.GetTemplateFromNode(childNode), childNode.ID)
 		woc.controller.PodController.TerminateContainers(woc.wf.Namespace, podName)
 		childNode.Phase = wfv1.NodeSucceeded
 		childNode.Daemoned = nil
@@ -3,11 +3,15 @@ package controller
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 func TestKillDaemonChildrenUnmarkPod(t *testing.T) {
@@ -167,3 +171,42 @@ func TestHandleExecutionControlErrorMarksChildNodes(t *testing.T) {
 	assert.Equal(t, v1alpha1.NodeFailed, woc.wf.Status.Nodes[step1NodeName].Phase)
 	assert.Equal(t, v1alpha1.NodeFailed, woc.wf.Status.Nodes[step2NodeName].Phase)
 }
+
+func TestCheckLiveness(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	woc := newWorkflowOperationCtx(&v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "wf", Namespace: "argo"}}, controller)
+
+	newPod := func(failureTimeout string, ready bool, unreadyFor time.Duration) *apiv1.Pod {
+		pod := &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Annotations: map[string]string{}},
+			Status: apiv1.PodStatus{
+				Phase:     apiv1.PodRunning,
+				StartTime: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour))),
+				ContainerStatuses: []apiv1.ContainerStatus{{
+					Name:  common.MainContainerName,
+					Ready: ready,
+					State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+				}},
+				Conditions: []apiv1.PodCondition{{
+					Type:               apiv1.ContainersReady,
+					Status:             apiv1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-unreadyFor)),
+				}},
+			},
+		}
+		if failureTimeout != "" {
+			pod.Annotations[common.AnnotationKeyLivenessFailureTimeout] = failureTimeout
+		}
+		return pod
+	}
+
+	assert.Empty(t, woc.checkLiveness(newPod("", false, time.Hour)))
+	assert.Empty(t, woc.checkLiveness(newPod("10m", true, 0)))
+	assert.Empty(t, woc.checkLiveness(newPod("10m", false, time.Minute)))
+	assert.Equal(t, "liveness probe failed for 10m0s", woc.checkLiveness(newPod("10m", false, 11*time.Minute)))
+
+	pending := newPod("10m", false, 11*time.Minute)
+	pending.Status.Phase = apiv1.PodPending
+	assert.Empty(t, woc.checkLiveness(pending))
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// maxGangNameLength leaves room in the hostnames of the pods of a gang, which are its name and their rank, for ranks
+// of up to five digits
+const maxGangNameLength = 57
+
+var invalidGangNameRegex = regexp.MustCompile(`[^a-z0-9-]`)
+
+// jobName returns the name of the Job and headless service of the gang of a node, which is its ID, changed to be a
+// valid service name if it is not one. The Jobs of job and gang templates are labeled with it, so that the Job of a
+// node can be found.
+func jobName(nodeID string) string {
+	name := invalidGangNameRegex.ReplaceAllString(strings.ToLower(nodeID), "-")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "gang-" + name
+	}
+	if len(name) > maxGangNameLength {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(nodeID))
+		suffix := fmt.Sprintf("-%v", h.Sum32())
+		name = strings.TrimRight(name[:maxGangNameLength-len(suffix)], "-") + suffix
+	}
+	return name
+}
+
+// executeGang executes a gang template as a Job template of an indexed Job of the pods of the gang, once it has
+// created the headless service that gives the pods their hostnames
+func (woc *wfOperationCtx) executeGang(ctx context.Context, nodeName string, templateScope string, tmpl *wfv1.Template, orgTmpl wfv1.TemplateReferenceHolder, opts *executeTemplateOpts) (*wfv1.NodeStatus, error) {
+	node, err := woc.wf.GetNodeByName(nodeName)
+	if err != nil {
+		node = woc.initializeExecutableNode(nodeName, wfv1.NodeTypePod, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending, opts.nodeFlag)
+	} else if !node.Pending() {
+		return node, nil
+	}
+	name := jobName(node.ID)
+	if err := woc.createGangService(ctx, name); err != nil {
+		return woc.requeueIfTransientErr(err, nodeName)
+	}
+	tmpl = tmpl.DeepCopy()
+	tmpl.JobTemplate = gangJobTemplate(name, tmpl.Gang)
+	return woc.executeJob(ctx, nodeName, templateScope, tmpl, orgTmpl, opts)
+}
+
+// createGangService creates the headless service of a gang, which is owned by the workflow, and labeled like its Job.
+// Its addresses are published before the pods are ready, so that they can reach each other whilst they start.
+func (woc *wfOperationCtx) createGangService(ctx context.Context, name string) error {
+	svc := apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{common.LabelKeyWorkflow: woc.wf.Name, common.LabelKeyJobNode: name},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(woc.wf, wfv1.SchemeGroupVersion.WithKind(workflow.WorkflowKind)),
+			},
+		},
+		Spec: apiv1.ServiceSpec{
+			ClusterIP:                apiv1.ClusterIPNone,
+			Selector:                 map[string]string{common.LabelKeyGang: name},
+			PublishNotReadyAddresses: true,
+		},
+	}
+	_, err := woc.controller.kubeclientset.CoreV1().Services(woc.wf.Namespace).Create(ctx, &svc, metav1.CreateOptions{})
+	if err != nil && !apierr.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// gangJobTemplate returns the template of the indexed Job of the pods of a gang. A pod that fails fails the Job,
+// which stops the other pods.
+func gangJobTemplate(name string, gang *wfv1.GangTemplate) *batchv1.JobTemplateSpec {
+	podTemplate := gang.Template.DeepCopy()
+	if podTemplate.Labels == nil {
+		podTemplate.Labels = map[string]string{}
+	}
+	podTemplate.Labels[common.LabelKeyGang] = name
+	// the hostnames of the pods of an indexed Job are its name and their index, in the subdomain of the service
+	podTemplate.Spec.Subdomain = name
+	podTemplate.Spec.RestartPolicy = apiv1.RestartPolicyNever
+	env := []apiv1.EnvVar{
+		{Name: common.EnvVarGangName, Value: name},
+		{Name: common.EnvVarGangSize, Value: strconv.Itoa(int(gang.Pods))},
+		{Name: common.EnvVarGangRank, ValueFrom: &apiv1.EnvVarSource{FieldRef: &apiv1.ObjectFieldSelector{
+			FieldPath: fmt.Sprintf("metadata.annotations['%s']", batchv1.JobCompletionIndexAnnotation),
+		}}},
+		{Name: common.EnvVarGangLeader, Value: fmt.Sprintf("%s-0.%s", name, name)},
+	}
+	for i := range podTemplate.Spec.InitContainers {
+		podTemplate.Spec.InitContainers[i].Env = append(podTemplate.Spec.InitContainers[i].Env, env...)
+	}
+	for i := range podTemplate.Spec.Containers {
+		podTemplate.Spec.Containers[i].Env = append(podTemplate.Spec.Containers[i].Env, env...)
+	}
+	jobTemplate := &batchv1.JobTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: batchv1.JobSpec{
+			Completions:    ptr.To(gang.Pods),
+			Parallelism:    ptr.To(gang.Pods),
+			CompletionMode: ptr.To(batchv1.IndexedCompletion),
+			BackoffLimit:   ptr.To(int32(0)),
+			Template:       *podTemplate,
+		},
+	}
+	if gang.LeaderCompletes {
+		jobTemplate.Spec.SuccessPolicy = &batchv1.SuccessPolicy{Rules: []batchv1.SuccessPolicyRule{{SucceededIndexes: ptr.To("0")}}}
+	}
+	return jobTemplate
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestJobName(t *testing.T) {
+	assert.Equal(t, "my-wf-1234567890", jobName("my-wf-1234567890"))
+	assert.Equal(t, "my-wf-v1-2-1234567890", jobName("my-wf.v1.2-1234567890"))
+	assert.Equal(t, "gang-1-wf-1234567890", jobName("1-wf-1234567890"))
+	long := jobName(strings.Repeat("a", 100) + "-1234567890")
+	assert.Len(t, long, maxGangNameLength)
+	assert.NotEqual(t, long, jobName(strings.Repeat("a", 100)+"-1234567891"), "names that are shortened are still unique")
+}
+
+var gangWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: gang
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    gang:
+      size: 4
+      leaderCompletes: true
+      template:
+        spec:
+          containers:
+          - name: worker
+            image: argoproj/argosay:v2
+`
+
+func TestGangTemplate(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(gangWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(context.Background())
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+
+	svc, err := controller.kubeclientset.CoreV1().Services("my-ns").Get(context.Background(), "gang", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, apiv1.ClusterIPNone, svc.Spec.ClusterIP)
+	assert.Equal(t, map[string]string{common.LabelKeyGang: "gang"}, svc.Spec.Selector)
+	require.Len(t, svc.OwnerReferences, 1)
+	assert.Equal(t, "gang", svc.OwnerReferences[0].Name)
+
+	pod, err := getPod(woc, "gang")
+	require.NoError(t, err)
+	tmpl, err := getPodTemplate(pod)
+	require.NoError(t, err)
+	job := tmpl.JobTemplate
+	require.NotNil(t, job)
+	assert.Equal(t, "gang", job.Name)
+	assert.Equal(t, int32(4), *job.Spec.Completions)
+	assert.Equal(t, int32(4), *job.Spec.Parallelism)
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	require.NotNil(t, job.Spec.SuccessPolicy)
+	assert.Equal(t, "0", *job.Spec.SuccessPolicy.Rules[0].SucceededIndexes)
+	podSpec := job.Spec.Template.Spec
+	assert.Equal(t, "gang", podSpec.Subdomain)
+	assert.Equal(t, apiv1.RestartPolicyNever, podSpec.RestartPolicy)
+	assert.Equal(t, "gang", job.Spec.Template.Labels[common.LabelKeyGang])
+	env := map[string]apiv1.EnvVar{}
+	for _, e := range podSpec.Containers[0].Env {
+		env[e.Name] = e
+	}
+	assert.Equal(t, "4", env[common.EnvVarGangSize].Value)
+	assert.Equal(t, "gang-0.gang", env[common.EnvVarGangLeader].Value)
+	require.NotNil(t, env[common.EnvVarGangRank].ValueFrom)
+	assert.Equal(t, "metadata.annotations['batch.kubernetes.io/job-completion-index']", env[common.EnvVarGangRank].ValueFrom.FieldRef.FieldPath)
+}
+
+func TestGangShutdown(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(gangWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	node := woc.wf.Status.Nodes.FindByDisplayName("gang")
+	require.NotNil(t, node)
+
+	svc, err := controller.kubeclientset.CoreV1().Services("my-ns").Get(ctx, "gang", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "gang", svc.Labels[common.LabelKeyJobNode])
+	// the indexed Job that the pod of the node created
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name:   "gang",
+		Labels: map[string]string{common.LabelKeyWorkflow: "gang", common.LabelKeyJobNode: "gang"},
+	}}
+	_, err = controller.kubeclientset.BatchV1().Jobs("my-ns").Create(ctx, job, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	woc.execWf.Spec.Shutdown = wfv1.ShutdownStrategyTerminate
+	woc.markNodePhase(node.Name, wfv1.NodeFailed)
+	woc.deleteJobsOfStoppedNodes(ctx)
+	_, err = controller.kubeclientset.BatchV1().Jobs("my-ns").Get(ctx, "gang", metav1.GetOptions{})
+	assert.True(t, apierr.IsNotFound(err), "the Job of a stopped gang is deleted")
+	_, err = controller.kubeclientset.CoreV1().Services("my-ns").Get(ctx, "gang", metav1.GetOptions{})
+	assert.True(t, apierr.IsNotFound(err), "the service of a stopped gang is deleted")
+}
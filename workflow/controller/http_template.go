@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"fmt"
+
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
@@ -8,6 +10,10 @@ func (woc *wfOperationCtx) executeHTTPTemplate(nodeName string, templateScope st
 	node, err := woc.wf.GetNodeByName(nodeName)
 	if err != nil {
 		node = woc.initializeExecutableNode(nodeName, wfv1.NodeTypeHTTP, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending, opts.nodeFlag)
+		if !woc.GetShutdownStrategy().ShouldExecute(opts.onExitTemplate) {
+			// Do not start nodes if we are shutting down
+			return woc.markNodePhase(nodeName, wfv1.NodeFailed, fmt.Sprintf("workflow shutdown with strategy: %s", woc.GetShutdownStrategy()))
+		}
 	}
 	if !node.Fulfilled() {
 		woc.taskSet[node.ID] = *tmpl
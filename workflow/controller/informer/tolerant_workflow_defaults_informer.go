@@ -0,0 +1,58 @@
+package informer
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	extwfv1 "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/listers/workflow/v1alpha1"
+)
+
+type tolerantClusterWorkflowDefaultsInformer struct {
+	delegate informers.GenericInformer
+}
+
+// a drop-in replacement for `extwfv1.ClusterWorkflowDefaultsInformer` that ignores malformed resources
+func NewTolerantClusterWorkflowDefaultsInformer(dynamicInterface dynamic.Interface, defaultResync time.Duration) extwfv1.ClusterWorkflowDefaultsInformer {
+	return &tolerantClusterWorkflowDefaultsInformer{delegate: newWorkflowDefaultsGenericInformer(dynamicInterface, defaultResync, "", workflow.ClusterWorkflowDefaultsPlural)}
+}
+
+func (t *tolerantClusterWorkflowDefaultsInformer) Informer() cache.SharedIndexInformer {
+	return t.delegate.Informer()
+}
+
+func (t *tolerantClusterWorkflowDefaultsInformer) Lister() v1alpha1.ClusterWorkflowDefaultsLister {
+	return &tolerantClusterWorkflowDefaultsLister{delegate: t.delegate.Lister()}
+}
+
+type tolerantNamespaceWorkflowDefaultsInformer struct {
+	delegate informers.GenericInformer
+}
+
+// a drop-in replacement for `extwfv1.NamespaceWorkflowDefaultsInformer` that ignores malformed resources
+func NewTolerantNamespaceWorkflowDefaultsInformer(dynamicInterface dynamic.Interface, defaultResync time.Duration, namespace string) extwfv1.NamespaceWorkflowDefaultsInformer {
+	return &tolerantNamespaceWorkflowDefaultsInformer{delegate: newWorkflowDefaultsGenericInformer(dynamicInterface, defaultResync, namespace, workflow.NamespaceWorkflowDefaultsPlural)}
+}
+
+func (t *tolerantNamespaceWorkflowDefaultsInformer) Informer() cache.SharedIndexInformer {
+	return t.delegate.Informer()
+}
+
+func (t *tolerantNamespaceWorkflowDefaultsInformer) Lister() v1alpha1.NamespaceWorkflowDefaultsLister {
+	return &tolerantNamespaceWorkflowDefaultsLister{delegate: t.delegate.Lister()}
+}
+
+func newWorkflowDefaultsGenericInformer(dynamicInterface dynamic.Interface, defaultResync time.Duration, namespace, resource string) informers.GenericInformer {
+	return dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicInterface, defaultResync, namespace, func(options *metav1.ListOptions) {
+		// `ResourceVersion=0` does not honor the `limit` in API calls, which results in making significant List calls
+		// without `limit`. For details, see https://github.com/argoproj/argo-workflows/pull/11343
+		options.ResourceVersion = ""
+	}).ForResource(schema.GroupVersionResource{Group: workflow.Group, Version: workflow.Version, Resource: resource})
+}
@@ -0,0 +1,76 @@
+package informer
+
+import (
+	v1Label "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/listers/workflow/v1alpha1"
+)
+
+// Unlike the listers of templates, the listers of workflow defaults leave out the malformed ones, as they must not be
+// merged into any workflow.
+
+type tolerantClusterWorkflowDefaultsLister struct {
+	delegate cache.GenericLister
+}
+
+var _ v1alpha1.ClusterWorkflowDefaultsLister = &tolerantClusterWorkflowDefaultsLister{}
+
+func (t *tolerantClusterWorkflowDefaultsLister) List(selector v1Label.Selector) ([]*wfv1.ClusterWorkflowDefaults, error) {
+	list, err := t.delegate.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return objectsToClusterWorkflowDefaults(list), nil
+}
+
+func (t *tolerantClusterWorkflowDefaultsLister) Get(name string) (*wfv1.ClusterWorkflowDefaults, error) {
+	object, err := t.delegate.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	v := &wfv1.ClusterWorkflowDefaults{}
+	return v, interfaceToWorkflowDefaults(object, v)
+}
+
+type tolerantNamespaceWorkflowDefaultsLister struct {
+	delegate cache.GenericLister
+}
+
+var _ v1alpha1.NamespaceWorkflowDefaultsLister = &tolerantNamespaceWorkflowDefaultsLister{}
+
+func (t *tolerantNamespaceWorkflowDefaultsLister) List(selector v1Label.Selector) ([]*wfv1.NamespaceWorkflowDefaults, error) {
+	list, err := t.delegate.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return objectsToNamespaceWorkflowDefaults(list), nil
+}
+
+func (t *tolerantNamespaceWorkflowDefaultsLister) NamespaceWorkflowDefaultses(namespace string) v1alpha1.NamespaceWorkflowDefaultsNamespaceLister {
+	return &tolerantNamespaceWorkflowDefaultsNamespaceLister{delegate: t.delegate.ByNamespace(namespace)}
+}
+
+type tolerantNamespaceWorkflowDefaultsNamespaceLister struct {
+	delegate cache.GenericNamespaceLister
+}
+
+var _ v1alpha1.NamespaceWorkflowDefaultsNamespaceLister = &tolerantNamespaceWorkflowDefaultsNamespaceLister{}
+
+func (t *tolerantNamespaceWorkflowDefaultsNamespaceLister) List(selector v1Label.Selector) ([]*wfv1.NamespaceWorkflowDefaults, error) {
+	list, err := t.delegate.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return objectsToNamespaceWorkflowDefaults(list), nil
+}
+
+func (t *tolerantNamespaceWorkflowDefaultsNamespaceLister) Get(name string) (*wfv1.NamespaceWorkflowDefaults, error) {
+	object, err := t.delegate.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	v := &wfv1.NamespaceWorkflowDefaults{}
+	return v, interfaceToWorkflowDefaults(object, v)
+}
@@ -0,0 +1,47 @@
+package informer
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func objectsToClusterWorkflowDefaults(list []runtime.Object) []*wfv1.ClusterWorkflowDefaults {
+	var ret []*wfv1.ClusterWorkflowDefaults
+	for _, object := range list {
+		v := &wfv1.ClusterWorkflowDefaults{}
+		if interfaceToWorkflowDefaults(object, v) == nil {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func objectsToNamespaceWorkflowDefaults(list []runtime.Object) []*wfv1.NamespaceWorkflowDefaults {
+	var ret []*wfv1.NamespaceWorkflowDefaults
+	for _, object := range list {
+		v := &wfv1.NamespaceWorkflowDefaults{}
+		if interfaceToWorkflowDefaults(object, v) == nil {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// interfaceToWorkflowDefaults converts the object into v, which is either a ClusterWorkflowDefaults or a
+// NamespaceWorkflowDefaults
+func interfaceToWorkflowDefaults(object interface{}, v interface{}) error {
+	un, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("malformed workflow defaults: expected \"*unstructured.Unstructured\", got \"%s\"", reflect.TypeOf(object).String())
+	}
+	if err := util.FromUnstructuredObj(un, v); err != nil {
+		return fmt.Errorf("malformed workflow defaults %q: %w", un.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// executeJob executes a Job template as a resource template that creates the Job, and whose pod waits for the Job to
+// complete or fail
+func (woc *wfOperationCtx) executeJob(ctx context.Context, nodeName string, templateScope string, tmpl *wfv1.Template, orgTmpl wfv1.TemplateReferenceHolder, opts *executeTemplateOpts) (*wfv1.NodeStatus, error) {
+	tmpl = tmpl.DeepCopy()
+	manifest, err := woc.jobManifest(woc.wf.NodeID(nodeName), tmpl.JobTemplate)
+	if err != nil {
+		return woc.initializeExecutableNode(nodeName, wfv1.NodeTypePod, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodeError, opts.nodeFlag, err.Error()), err
+	}
+	tmpl.Resource = &wfv1.ResourceTemplate{Action: "create", Manifest: manifest, SetOwnerReference: true}
+	return woc.executeResource(ctx, nodeName, templateScope, tmpl, orgTmpl, opts)
+}
+
+// jobManifest returns the manifest of the Job of the template, which is named after the workflow unless the template
+// names it, and labeled with the workflow and its node
+func (woc *wfOperationCtx) jobManifest(nodeID string, jobTemplate *batchv1.JobTemplateSpec) (string, error) {
+	job := batchv1.Job{
+		TypeMeta:   metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+		ObjectMeta: *jobTemplate.ObjectMeta.DeepCopy(),
+		Spec:       *jobTemplate.Spec.DeepCopy(),
+	}
+	if job.Name == "" && job.GenerateName == "" {
+		job.GenerateName = woc.wf.Name + "-"
+	}
+	if job.Labels == nil {
+		job.Labels = map[string]string{}
+	}
+	job.Labels[common.LabelKeyWorkflow] = woc.wf.Name
+	job.Labels[common.LabelKeyJobNode] = jobName(nodeID)
+	data, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// deleteJobsOfStoppedNodes deletes the running Jobs of the job and gang nodes that have failed whilst the workflow is
+// shutting down or has exceeded its deadline, as stopping the pod that waits for a Job does not stop the Job. They are
+// deleted in the foreground, so that their pods are deleted before them. The headless services of stopped gangs are
+// deleted too.
+func (woc *wfOperationCtx) deleteJobsOfStoppedNodes(ctx context.Context) {
+	deadlineExceeded := woc.workflowDeadline != nil && time.Now().UTC().After(*woc.workflowDeadline)
+	if !woc.GetShutdownStrategy().Enabled() && !deadlineExceeded {
+		return
+	}
+	stopped := make(map[string]bool)
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypePod && node.FailedOrError() {
+			stopped[jobName(node.ID)] = true
+		}
+	}
+	if len(stopped) == 0 {
+		return
+	}
+	listOptions := metav1.ListOptions{LabelSelector: common.LabelKeyWorkflow + "=" + woc.wf.Name + "," + common.LabelKeyJobNode}
+	jobs, err := woc.controller.kubeclientset.BatchV1().Jobs(woc.wf.Namespace).List(ctx, listOptions)
+	if err != nil {
+		woc.log.WithError(err).Warn("Failed to list the Jobs of the workflow")
+		return
+	}
+	propagation := metav1.DeletePropagationForeground
+	for _, job := range jobs.Items {
+		if !stopped[job.Labels[common.LabelKeyJobNode]] || job.DeletionTimestamp != nil || jobFinished(&job) {
+			continue
+		}
+		woc.log.WithField("job", job.Name).Info("Deleting the Job of a stopped node")
+		err := woc.controller.kubeclientset.BatchV1().Jobs(woc.wf.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil && !apierr.IsNotFound(err) {
+			woc.log.WithError(err).WithField("job", job.Name).Warn("Failed to delete the Job of a stopped node")
+		}
+	}
+	services, err := woc.controller.kubeclientset.CoreV1().Services(woc.wf.Namespace).List(ctx, listOptions)
+	if err != nil {
+		woc.log.WithError(err).Warn("Failed to list the gang services of the workflow")
+		return
+	}
+	for _, svc := range services.Items {
+		if !stopped[svc.Labels[common.LabelKeyJobNode]] || svc.DeletionTimestamp != nil {
+			continue
+		}
+		woc.log.WithField("service", svc.Name).Info("Deleting the service of a stopped gang")
+		err := woc.controller.kubeclientset.CoreV1().Services(woc.wf.Namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			woc.log.WithError(err).WithField("service", svc.Name).Warn("Failed to delete the service of a stopped gang")
+		}
+	}
+}
+
+// jobFinished returns whether the Job has completed or failed
+func jobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == apiv1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
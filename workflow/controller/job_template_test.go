@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var jobTemplateWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: job-template
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    jobTemplate:
+      spec:
+        completions: 3
+        parallelism: 3
+        completionMode: Indexed
+        template:
+          spec:
+            restartPolicy: Never
+            containers:
+            - name: main
+              image: argoproj/argosay:v2
+`
+
+func TestJobTemplate(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(jobTemplateWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(context.Background())
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+
+	pod, err := getPod(woc, "job-template")
+	require.NoError(t, err)
+	for _, c := range pod.Spec.Containers {
+		if c.Name == common.MainContainerName {
+			assert.Equal(t, append(append(append([]string{"/var/run/argo/argoexec", "emissary"}, woc.getExecutorLogOpts()...),
+				"--", "argoexec", "resource", "create"), woc.getExecutorLogOpts()...), c.Command)
+		}
+	}
+	tmpl, err := getPodTemplate(pod)
+	require.NoError(t, err)
+	require.NotNil(t, tmpl.JobTemplate, "the executor waits for the Job")
+	job := batchv1.Job{}
+	require.NoError(t, yaml.Unmarshal([]byte(tmpl.Resource.Manifest), &job))
+	assert.Equal(t, "Job", job.Kind)
+	assert.Equal(t, "job-template-", job.GenerateName)
+	assert.Equal(t, "job-template", job.Labels[common.LabelKeyWorkflow])
+	require.Len(t, job.OwnerReferences, 1)
+	assert.Equal(t, "job-template", job.OwnerReferences[0].Name)
+	assert.Equal(t, int32(3), *job.Spec.Completions)
+	assert.Equal(t, batchv1.IndexedCompletion, *job.Spec.CompletionMode)
+}
+
+func TestDeleteJobsOfStoppedNodes(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(jobTemplateWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	node := woc.wf.Status.Nodes.FindByDisplayName("job-template")
+	require.NotNil(t, node)
+
+	// the Job that the pod of the node created
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name:   "job-template-abcde",
+		Labels: map[string]string{common.LabelKeyWorkflow: "job-template", common.LabelKeyJobNode: jobName(node.ID)},
+	}}
+	_, err := controller.kubeclientset.BatchV1().Jobs(woc.wf.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	woc.deleteJobsOfStoppedNodes(ctx)
+	_, err = controller.kubeclientset.BatchV1().Jobs(woc.wf.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+	require.NoError(t, err, "the Job of a running node is kept")
+
+	woc.execWf.Spec.Shutdown = wfv1.ShutdownStrategyTerminate
+	woc.markNodePhase(node.Name, wfv1.NodeFailed)
+	woc.deleteJobsOfStoppedNodes(ctx)
+	_, err = controller.kubeclientset.BatchV1().Jobs(woc.wf.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+	assert.True(t, apierr.IsNotFound(err), "the Job of a stopped node is deleted")
+}
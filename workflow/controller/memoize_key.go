@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// inputsHashKeyPrefix is the prefix of the memoization keys that are derived from the inputs of a template
+const inputsHashKeyPrefix = "inputs-"
+
+// maxMemoizationKeyLength is the length of the longest key that a config map can store
+const maxMemoizationKeyLength = validation.DNS1123SubdomainMaxLength
+
+// inputsHashKey returns the memoization key of a template without a key, derived from a hash of its name, its resolved
+// body, e.g. its container or script with the inputs substituted, and its resolved inputs: the values of its
+// parameters, and the checksums of its artifacts. Artifacts without a checksum, e.g. those saved by an executor that
+// does not compute one, are hashed by their raw data or location instead, so that they only hit the cache when the same
+// artifact is passed again.
+func inputsHashKey(tmpl *wfv1.Template) (string, error) {
+	type hashedInput struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	// the inputs are hashed separately, as the locations of artifacts change from run to run, and the memoization and
+	// metadata of the template do not change what it does
+	body := tmpl.DeepCopy()
+	body.Inputs = wfv1.Inputs{}
+	body.Memoize = nil
+	body.Metadata = wfv1.Metadata{}
+	inputs := struct {
+		Template   string         `json:"template"`
+		Body       *wfv1.Template `json:"body"`
+		Parameters []hashedInput  `json:"parameters,omitempty"`
+		Artifacts  []hashedInput  `json:"artifacts,omitempty"`
+	}{Template: tmpl.Name, Body: body}
+	for _, p := range tmpl.Inputs.Parameters {
+		inputs.Parameters = append(inputs.Parameters, hashedInput{Name: p.Name, Value: p.GetValue()})
+	}
+	for _, a := range tmpl.Inputs.Artifacts {
+		value, err := artifactHashValue(a)
+		if err != nil {
+			return "", err
+		}
+		inputs.Artifacts = append(inputs.Artifacts, hashedInput{Name: a.Name, Value: value})
+	}
+	sort.Slice(inputs.Parameters, func(i, j int) bool { return inputs.Parameters[i].Name < inputs.Parameters[j].Name })
+	sort.Slice(inputs.Artifacts, func(i, j int) bool { return inputs.Artifacts[i].Name < inputs.Artifacts[j].Name })
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return inputsHashKeyPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// artifactHashValue returns the value of an input artifact that is hashed into the memoization key
+func artifactHashValue(a wfv1.Artifact) (string, error) {
+	switch {
+	case a.Checksum != "":
+		return a.Checksum, nil
+	case a.Raw != nil:
+		return a.Raw.Data, nil
+	}
+	data, err := json.Marshal(a.ArtifactLocation)
+	return string(data), err
+}
+
+// scopedMemoizationKey returns the key of the cache entry of the template's memoization key. The keys of templates in the
+// scope of their workflow are prefixed by the kind and name of the workflow, e.g. "workflowtemplate-nightly-", so that
+// they only hit the entries saved by runs of the same workflow. Keys that would be too long for a config map, because
+// of a long workflow name, end with a hash of the whole key instead.
+func (woc *wfOperationCtx) scopedMemoizationKey(memoize *wfv1.Memoize) string {
+	if memoize.GetScope() != wfv1.MemoizeScopeWorkflow {
+		return memoize.Key
+	}
+	key := woc.memoizationWorkflowScope() + "-" + memoize.Key
+	if len(key) > maxMemoizationKeyLength {
+		sum := sha256.Sum256([]byte(key))
+		hash := hex.EncodeToString(sum[:])
+		key = key[:maxMemoizationKeyLength-len(hash)-1] + "-" + hash
+	}
+	return key
+}
+
+// memoizationWorkflowScope returns the kind and name of the workflow that the Workflow is a run of: its
+// WorkflowTemplate or ClusterWorkflowTemplate, else its CronWorkflow, else the Workflow itself
+func (woc *wfOperationCtx) memoizationWorkflowScope() string {
+	labels := woc.wf.Labels
+	var scope string
+	switch {
+	case labels[common.LabelKeyClusterWorkflowTemplate] != "":
+		scope = "clusterworkflowtemplate-" + labels[common.LabelKeyClusterWorkflowTemplate]
+	case labels[common.LabelKeyWorkflowTemplate] != "":
+		scope = "workflowtemplate-" + labels[common.LabelKeyWorkflowTemplate]
+	case woc.wf.Spec.WorkflowTemplateRef != nil && woc.wf.Spec.WorkflowTemplateRef.ClusterScope: // not-woc-misuse
+		scope = "clusterworkflowtemplate-" + woc.wf.Spec.WorkflowTemplateRef.Name // not-woc-misuse
+	case woc.wf.Spec.WorkflowTemplateRef != nil: // not-woc-misuse
+		scope = "workflowtemplate-" + woc.wf.Spec.WorkflowTemplateRef.Name // not-woc-misuse
+	case labels[common.LabelKeyCronWorkflow] != "":
+		scope = "cronworkflow-" + labels[common.LabelKeyCronWorkflow]
+	default:
+		scope = "workflow-" + woc.wf.Name
+	}
+	// names may contain dots, which are not allowed in cache keys
+	return strings.ReplaceAll(scope, ".", "-")
+}
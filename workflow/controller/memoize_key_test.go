@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestInputsHashKey(t *testing.T) {
+	tmpl := func(message string, artifacts ...wfv1.Artifact) *wfv1.Template {
+		return &wfv1.Template{
+			Name: "train",
+			Inputs: wfv1.Inputs{
+				Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr(message)}, {Name: "epochs", Value: wfv1.AnyStringPtr("10")}},
+				Artifacts:  artifacts,
+			},
+		}
+	}
+	dataset := func(key, checksum string) wfv1.Artifact {
+		return wfv1.Artifact{
+			Name:             "dataset",
+			Checksum:         checksum,
+			ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: key}},
+		}
+	}
+	key := func(t *testing.T, tmpl *wfv1.Template) string {
+		key, err := inputsHashKey(tmpl)
+		require.NoError(t, err)
+		return key
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		k := key(t, tmpl("hello"))
+		assert.True(t, strings.HasPrefix(k, inputsHashKeyPrefix))
+		assert.Regexp(t, "^[a-zA-Z0-9][-a-zA-Z0-9]*$", k)
+	})
+	t.Run("Parameters", func(t *testing.T) {
+		assert.Equal(t, key(t, tmpl("hello")), key(t, tmpl("hello")))
+		assert.NotEqual(t, key(t, tmpl("hello")), key(t, tmpl("goodbye")))
+	})
+	t.Run("ParameterOrder", func(t *testing.T) {
+		reordered := tmpl("hello")
+		reordered.Inputs.Parameters[0], reordered.Inputs.Parameters[1] = reordered.Inputs.Parameters[1], reordered.Inputs.Parameters[0]
+		assert.Equal(t, key(t, tmpl("hello")), key(t, reordered))
+	})
+	t.Run("TemplateName", func(t *testing.T) {
+		other := tmpl("hello")
+		other.Name = "evaluate"
+		assert.NotEqual(t, key(t, tmpl("hello")), key(t, other))
+	})
+	t.Run("Source", func(t *testing.T) {
+		script := func(source string) *wfv1.Template {
+			tmpl := tmpl("hello")
+			tmpl.Name = "main"
+			tmpl.Script = &wfv1.ScriptTemplate{Container: apiv1.Container{Image: "python:3.12"}, Source: source}
+			return tmpl
+		}
+		assert.Equal(t, key(t, script(`print("hello")`)), key(t, script(`print("hello")`)))
+		assert.NotEqual(t, key(t, script(`print("hello")`)), key(t, script(`print("goodbye")`)), "same name, different source")
+		otherImage := script(`print("hello")`)
+		otherImage.Script.Image = "python:3.13"
+		assert.NotEqual(t, key(t, script(`print("hello")`)), key(t, otherImage), "same name, different image")
+		otherCommand := script(`print("hello")`)
+		otherCommand.Script.Command = []string{"python3", "-u"}
+		assert.NotEqual(t, key(t, script(`print("hello")`)), key(t, otherCommand), "same name, different command")
+	})
+	t.Run("Memoize", func(t *testing.T) {
+		memoized := tmpl("hello")
+		memoized.Memoize = &wfv1.Memoize{Cache: &wfv1.Cache{ConfigMap: &apiv1.ConfigMapKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "train-cache"}}}}
+		assert.Equal(t, key(t, tmpl("hello")), key(t, memoized))
+	})
+	t.Run("ArtifactChecksum", func(t *testing.T) {
+		assert.Equal(t, key(t, tmpl("hello", dataset("wf-1/dataset.tgz", "sha256:abc"))), key(t, tmpl("hello", dataset("wf-2/dataset.tgz", "sha256:abc"))))
+		assert.NotEqual(t, key(t, tmpl("hello", dataset("wf-1/dataset.tgz", "sha256:abc"))), key(t, tmpl("hello", dataset("wf-1/dataset.tgz", "sha256:def"))))
+	})
+	t.Run("ArtifactLocation", func(t *testing.T) {
+		assert.Equal(t, key(t, tmpl("hello", dataset("wf-1/dataset", ""))), key(t, tmpl("hello", dataset("wf-1/dataset", ""))))
+		assert.NotEqual(t, key(t, tmpl("hello", dataset("wf-1/dataset", ""))), key(t, tmpl("hello", dataset("wf-2/dataset", ""))))
+	})
+	t.Run("RawArtifact", func(t *testing.T) {
+		raw := func(data string) wfv1.Artifact {
+			return wfv1.Artifact{Name: "dataset", ArtifactLocation: wfv1.ArtifactLocation{Raw: &wfv1.RawArtifact{Data: data}}}
+		}
+		assert.NotEqual(t, key(t, tmpl("hello", raw("a"))), key(t, tmpl("hello", raw("b"))))
+	})
+}
+
+var workflowCachedWithoutKey = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: memoized-workflow-test
+  namespace: default
+spec:
+  entrypoint: whalesay
+  arguments:
+    parameters:
+    - name: message
+      value: hi-there-world
+  templates:
+  - name: whalesay
+    inputs:
+      parameters:
+      - name: message
+    memoize:
+      cache:
+        configMap:
+          name: whalesay-cache
+    container:
+      image: docker/whalesay:latest
+      command: [cowsay, "{{inputs.parameters.message}}"]
+`
+
+func TestMemoizeWithoutKey(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(workflowCachedWithoutKey)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	expected, err := inputsHashKey(&wfv1.Template{
+		Name:   "whalesay",
+		Inputs: wfv1.Inputs{Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("hi-there-world")}}},
+		Container: &apiv1.Container{
+			Image:   "docker/whalesay:latest",
+			Command: []string{"cowsay", "hi-there-world"},
+		},
+	})
+	require.NoError(t, err)
+	node := woc.wf.Status.Nodes.FindByDisplayName("memoized-workflow-test")
+	require.NotNil(t, node)
+	require.NotNil(t, node.MemoizationStatus)
+	assert.False(t, node.MemoizationStatus.Hit)
+	assert.Equal(t, expected, node.MemoizationStatus.Key)
+	assert.Equal(t, "whalesay-cache", node.MemoizationStatus.CacheName)
+}
+
+func TestScopedMemoizationKey(t *testing.T) {
+	key := func(wf *wfv1.Workflow, scope wfv1.MemoizeScope) string {
+		woc := &wfOperationCtx{wf: wf}
+		return woc.scopedMemoizationKey(&wfv1.Memoize{Key: "my-key", Scope: scope})
+	}
+	wf := func(labels map[string]string, ref *wfv1.WorkflowTemplateRef) *wfv1.Workflow {
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly.abc12", Labels: labels},
+			Spec:       wfv1.WorkflowSpec{WorkflowTemplateRef: ref},
+		}
+	}
+
+	t.Run("Namespace", func(t *testing.T) {
+		assert.Equal(t, "my-key", key(wf(nil, nil), ""))
+		assert.Equal(t, "my-key", key(wf(nil, nil), wfv1.MemoizeScopeNamespace))
+	})
+	t.Run("WorkflowTemplate", func(t *testing.T) {
+		assert.Equal(t, "workflowtemplate-nightly-my-key", key(wf(nil, &wfv1.WorkflowTemplateRef{Name: "nightly"}), wfv1.MemoizeScopeWorkflow))
+		assert.Equal(t, "workflowtemplate-nightly-my-key", key(wf(map[string]string{"workflows.argoproj.io/workflow-template": "nightly"}, nil), wfv1.MemoizeScopeWorkflow))
+	})
+	t.Run("ClusterWorkflowTemplate", func(t *testing.T) {
+		assert.Equal(t, "clusterworkflowtemplate-nightly-my-key", key(wf(nil, &wfv1.WorkflowTemplateRef{Name: "nightly", ClusterScope: true}), wfv1.MemoizeScopeWorkflow))
+	})
+	t.Run("CronWorkflow", func(t *testing.T) {
+		assert.Equal(t, "cronworkflow-nightly-my-key", key(wf(map[string]string{"workflows.argoproj.io/cron-workflow": "nightly"}, nil), wfv1.MemoizeScopeWorkflow))
+	})
+	t.Run("Workflow", func(t *testing.T) {
+		assert.Equal(t, "workflow-nightly-abc12-my-key", key(wf(nil, nil), wfv1.MemoizeScopeWorkflow))
+	})
+	t.Run("LongName", func(t *testing.T) {
+		name := strings.Repeat("n", 253)
+		long := key(wf(nil, &wfv1.WorkflowTemplateRef{Name: name, ClusterScope: true}), wfv1.MemoizeScopeWorkflow)
+		assert.Len(t, long, 253, "keys are no longer than config maps allow")
+		assert.True(t, strings.HasPrefix(long, "clusterworkflowtemplate-nnn"))
+		assert.Regexp(t, "^[a-zA-Z0-9][-a-zA-Z0-9]*$", long)
+		other := key(wf(nil, &wfv1.WorkflowTemplateRef{Name: name[1:] + "m", ClusterScope: true}), wfv1.MemoizeScopeWorkflow)
+		assert.NotEqual(t, long, other, "keys that only differ after where they are cut off are still different")
+	})
+}
+
+func TestMemoizationMaxAge(t *testing.T) {
+	maxAge := func(t *testing.T, limit time.Duration, tmplMaxAge string) time.Duration {
+		controller := &WorkflowController{}
+		if limit > 0 {
+			controller.Config.Memoization = &config.MemoizationConfig{MaxAge: config.TTL(limit)}
+		}
+		d, err := controller.memoizationMaxAge(&wfv1.Memoize{MaxAge: tmplMaxAge})
+		require.NoError(t, err)
+		return d
+	}
+
+	assert.Equal(t, time.Duration(0), maxAge(t, 0, ""))
+	assert.Equal(t, time.Hour, maxAge(t, 0, "1h"))
+	assert.Equal(t, 24*time.Hour, maxAge(t, 24*time.Hour, ""))
+	assert.Equal(t, time.Hour, maxAge(t, 24*time.Hour, "1h"))
+	assert.Equal(t, 24*time.Hour, maxAge(t, 24*time.Hour, "48h"))
+
+	_, err := (&WorkflowController{}).memoizationMaxAge(&wfv1.Memoize{MaxAge: "forever"})
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"os"
 	"reflect"
@@ -25,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	apiintstr "k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/cache"
@@ -32,6 +34,7 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
@@ -54,7 +57,6 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/template"
 	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
-	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/estimation"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
@@ -95,6 +97,8 @@ type wfOperationCtx struct {
 	// activePods tracks the number of active (Running/Pending) pods for controlling
 	// parallelism
 	activePods int64
+	// podCreations are the pods to create once the workflow has been executed, when pods are created concurrently
+	podCreations []podCreation
 	// workflowDeadline is the deadline which the workflow is expected to complete before we
 	// terminate the workflow.
 	workflowDeadline *time.Time
@@ -110,6 +114,10 @@ type wfOperationCtx struct {
 
 	taskSet map[string]wfv1.Template
 
+	// dagTemplatesFrom are the DAG templates of the tasks with dagTemplateFrom that have been executed, by the names of
+	// their nodes. They are not stored, so the tasks of these DAGs resolve their boundary templates from here
+	dagTemplatesFrom map[string]*wfv1.Template
+
 	// currentStackDepth tracks the depth of the "stack", increased with every nested call to executeTemplate and decreased
 	// when such calls return. This is used to prevent infinite recursion
 	currentStackDepth int
@@ -131,6 +139,18 @@ var (
 // for before requeuing the workflow onto the workqueue.
 var (
 	maxOperationTime = envutil.LookupEnvDurationOr("MAX_OPERATION_TIME", 30*time.Second)
+	// podAssessmentWorkers is the maximum number of pods of a workflow that are assessed concurrently
+	podAssessmentWorkers = max(envutil.LookupEnvIntOr("POD_ASSESSMENT_WORKERS", 500), 1)
+	// podCreationWorkers is the maximum number of pods of a workflow that are created concurrently. Pods are created
+	// as their nodes are executed if this is 1, and once the workflow has been executed otherwise.
+	podCreationWorkers = max(envutil.LookupEnvIntOr("POD_CREATION_WORKERS", 1), 1)
+	// dagTaskWorkers is the maximum number of tasks of a DAG that are decided concurrently. The tasks are decided as
+	// they are executed if this is 1.
+	dagTaskWorkers = max(envutil.LookupEnvIntOr("DAG_TASK_WORKERS", 8), 1)
+	// suspendConfigMapRefreshPeriod is how often the output parameters of suspended nodes that are read from
+	// ConfigMaps are read again, so that changes to the ConfigMaps take effect when the nodes are resumed. They are
+	// only read when the nodes start if it is zero.
+	suspendConfigMapRefreshPeriod = envutil.LookupEnvDurationOr("SUSPEND_CONFIG_MAP_REFRESH_PERIOD", 30*time.Second)
 )
 
 // failedNodeStatus is a subset of NodeStatus that is only used to Marshal certain fields into a JSON of failed nodes
@@ -166,6 +186,7 @@ func newWorkflowOperationCtx(wf *wfv1.Workflow, wfc *WorkflowController) *wfOper
 		eventRecorder:          wfc.eventRecorderManager.Get(wf.Namespace),
 		preExecutionNodePhases: make(map[string]wfv1.NodePhase),
 		taskSet:                make(map[string]wfv1.Template),
+		dagTemplatesFrom:       make(map[string]*wfv1.Template),
 		currentStackDepth:      0,
 	}
 
@@ -190,6 +211,8 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 	defer func() {
 		woc.persistUpdates(ctx)
 	}()
+	// pods that are created concurrently must exist before the updates are persisted
+	defer woc.createPendingPods(ctx)
 	defer func() {
 		if r := recover(); r != nil {
 			woc.log.WithFields(log.Fields{"stack": string(debug.Stack()), "r": r}).Errorf("Recovered from panic")
@@ -248,6 +271,26 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		return
 	}
 
+	if msg := woc.checkSchedulingWindow(); msg != "" {
+		woc.log.Info("Workflow processing has been postponed until its next scheduling window")
+		phase := woc.wf.Status.Phase
+		if phase == wfv1.WorkflowUnknown {
+			phase = wfv1.WorkflowPending
+		}
+		woc.markWorkflowPhase(ctx, phase, msg)
+		return
+	}
+
+	if msg := woc.checkArtifactQuotas(); msg != "" {
+		woc.log.Warn("Workflow processing has been postponed as an artifact repository is out of space")
+		phase := woc.wf.Status.Phase
+		if phase == wfv1.WorkflowUnknown {
+			phase = wfv1.WorkflowPending
+		}
+		woc.markWorkflowPhase(ctx, phase, msg)
+		return
+	}
+
 	// Workflow Level Synchronization lock
 	if woc.execWf.Spec.Synchronization != nil {
 		acquired, wfUpdate, msg, failedLockName, err := woc.controller.syncManager.TryAcquire(ctx, woc.wf, "", woc.execWf.Spec.Synchronization)
@@ -289,7 +332,7 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		}
 
 		woc.markWorkflowRunning(ctx)
-		setWfPodNamesAnnotation(woc.wf)
+		setWfPodNamesAnnotation(woc.wf, woc.controller.Config.PodNameTemplate)
 
 		woc.workflowDeadline = woc.getWorkflowDeadline()
 
@@ -301,6 +344,8 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 
 		woc.wf.Status.EstimatedDuration = woc.estimateWorkflowDuration()
 	} else {
+		woc.checkPendingTimeout()
+		woc.checkResourceBudget()
 		woc.workflowDeadline = woc.getWorkflowDeadline()
 		err, podReconciliationCompleted := woc.podReconciliation(ctx)
 		if err == nil {
@@ -308,6 +353,7 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 			// However, pending and suspended nodes do not have created pods, and taskset nodes use the agent pod.
 			// Apply execution control to these nodes now since pod reconciliation does not take effect on them.
 			woc.failNodesWithoutCreatedPodsAfterDeadlineOrShutdown()
+			woc.deleteJobsOfStoppedNodes(ctx)
 		}
 
 		if err != nil {
@@ -324,6 +370,7 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		}
 	}
 
+	woc.checkSuspendAfter()
 	if woc.ShouldSuspend() {
 		woc.log.Info("workflow suspended")
 		return
@@ -408,7 +455,7 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 					Message:      node.Message,
 					TemplateName: wfutil.GetTemplateFromNode(node),
 					Phase:        string(node.Phase),
-					PodName:      wfutil.GeneratePodName(woc.wf.Name, node.Name, wfutil.GetTemplateFromNode(node), node.ID, wfutil.GetPodNameVersion()),
+					PodName:      wfutil.GenerateWorkflowPodName(woc.wf, node.Name, wfutil.GetTemplateFromNode(node), node.ID),
 					FinishedAt:   node.FinishedAt,
 				})
 		}
@@ -425,8 +472,8 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 	if err != nil {
 		woc.markNodeError(node.Name, err)
 	}
-	// Reconcile TaskSet and Agent for HTTP/Plugin templates when is not shutdown
-	if !woc.execWf.Spec.Shutdown.Enabled() {
+	// Reconcile TaskSet and Agent for HTTP/Plugin templates when is not shutdown, or is draining
+	if !woc.execWf.Spec.Shutdown.ShouldTerminate(false) {
 		woc.taskSetReconciliation(ctx)
 	}
 
@@ -483,6 +530,10 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 	var workflowMessage string
 	if node.FailedOrError() && woc.GetShutdownStrategy().Enabled() {
 		workflowMessage = fmt.Sprintf("Stopped with strategy '%s'", woc.GetShutdownStrategy())
+	} else if condition := woc.getPendingTimeoutCondition(); node.FailedOrError() && condition != nil {
+		workflowMessage = condition.Message
+	} else if condition := woc.getResourceBudgetExceededCondition(); node.FailedOrError() && condition != nil {
+		workflowMessage = condition.Message
 	} else {
 		workflowMessage = node.Message
 	}
@@ -570,17 +621,9 @@ func (woc *wfOperationCtx) updateWorkflowMetadata() error {
 
 		env := env.GetFuncMap(template.EnvMap(woc.globalParams))
 		for n, f := range md.LabelsFrom {
-			program, err := expr.Compile(f.Expression, expr.Env(env))
-			if err != nil {
-				return fmt.Errorf("Failed to compile function for expression %q: %w", f.Expression, err)
-			}
-			r, err := expr.Run(program, env)
+			v, err := evaluateMetadataExpression(env, "label", n, f.Expression)
 			if err != nil {
-				return fmt.Errorf("failed to evaluate label %q expression %q: %w", n, f.Expression, err)
-			}
-			v, ok := r.(string)
-			if !ok {
-				return fmt.Errorf("failed to evaluate label %q expression %q evaluted to %T but must be a string", n, f.Expression, r)
+				return err
 			}
 			if errs := validation.IsValidLabelValue(v); errs != nil {
 				return errors.Errorf(errors.CodeBadRequest, "invalid label value %q for label %q and expression %q: %s", v, n, f.Expression, strings.Join(errs, ";"))
@@ -589,6 +632,15 @@ func (woc *wfOperationCtx) updateWorkflowMetadata() error {
 			woc.globalParams["workflow.labels."+n] = v
 			updatedParams["workflow.labels."+n] = v
 		}
+		for n, f := range md.AnnotationsFrom {
+			v, err := evaluateMetadataExpression(env, "annotation", n, f.Expression)
+			if err != nil {
+				return err
+			}
+			woc.wf.Annotations[n] = v
+			woc.globalParams["workflow.annotations."+n] = v
+			updatedParams["workflow.annotations."+n] = v
+		}
 		woc.updated = true
 
 		// Now we need to do any substitution that involves these labels
@@ -601,18 +653,168 @@ func (woc *wfOperationCtx) updateWorkflowMetadata() error {
 	return nil
 }
 
-func (woc *wfOperationCtx) getWorkflowDeadline() *time.Time {
-	if woc.execWf.Spec.ActiveDeadlineSeconds == nil {
-		return nil
+// evaluateMetadataExpression evaluates the expression of a label or annotation, which must evaluate to a string
+func evaluateMetadataExpression(env map[string]interface{}, kind, name, expression string) (string, error) {
+	program, err := expr.Compile(expression, expr.Env(env))
+	if err != nil {
+		return "", fmt.Errorf("Failed to compile function for expression %q: %w", expression, err)
+	}
+	r, err := expr.Run(program, env)
+	if err != nil {
+		return "", fmt.Errorf("failed to evaluate %s %q expression %q: %w", kind, name, expression, err)
+	}
+	v, ok := r.(string)
+	if !ok {
+		return "", fmt.Errorf("failed to evaluate %s %q expression %q evaluted to %T but must be a string", kind, name, expression, r)
 	}
+	return v, nil
+}
+
+func (woc *wfOperationCtx) getWorkflowDeadline() *time.Time {
 	if woc.wf.Status.StartedAt.IsZero() {
 		return nil
 	}
 	startedAt := woc.wf.Status.StartedAt.Truncate(time.Second)
+	// a workflow with a pod that was pending for too long, or that exceeded its resource budget, is failed as if it had
+	// exceeded its deadline
+	if woc.getPendingTimeoutCondition() != nil || woc.resourceBudgetTerminated() {
+		deadline := startedAt.UTC()
+		return &deadline
+	}
+	if woc.execWf.Spec.ActiveDeadlineSeconds == nil {
+		return nil
+	}
 	deadline := startedAt.Add(time.Duration(*woc.execWf.Spec.ActiveDeadlineSeconds) * time.Second).UTC()
 	return &deadline
 }
 
+// checkPendingTimeout records the PendingTimeout condition if a pod of the workflow has been pending for longer than
+// the pending timeout of the workflow, otherwise it requeues the workflow for when the next pod would time out
+func (woc *wfOperationCtx) checkPendingTimeout() {
+	if woc.execWf.Spec.PendingTimeout == nil || woc.getPendingTimeoutCondition() != nil {
+		return
+	}
+	timeout := woc.execWf.Spec.PendingTimeout.Duration
+	nodes := woc.wf.Status.Nodes
+	var requeueTime *time.Time
+	for _, node := range nodes {
+		if node.Type != wfv1.NodeTypePod || node.Phase != wfv1.NodePending || node.IsPartOfExitHandler(nodes) {
+			continue
+		}
+		// nodes waiting for a lock are not waiting for their pod
+		if node.SynchronizationStatus != nil && node.SynchronizationStatus.Waiting != "" {
+			continue
+		}
+		deadline := node.StartedAt.Add(timeout)
+		if time.Now().Before(deadline) {
+			if requeueTime == nil || deadline.Before(*requeueTime) {
+				requeueTime = &deadline
+			}
+			continue
+		}
+		message := fmt.Sprintf("Pod of step %s was pending for longer than %v", node.DisplayName, timeout)
+		if node.Message != "" {
+			message += ": " + node.Message
+		}
+		woc.log.WithField("nodeName", node.Name).Info(message)
+		woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypePendingTimeout, Status: metav1.ConditionTrue, Message: message})
+		woc.updated = true
+		woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowPendingTimedOut", message)
+		return
+	}
+	if requeueTime != nil {
+		woc.requeueAfter(time.Until(*requeueTime))
+	}
+}
+
+// checkResourceBudget records the ResourceBudgetExceeded condition if the resources duration of the completed pods of
+// the workflow exceeds its resource budget, and suspends the workflow if that is the action of the budget. The budget
+// is only checked until it is first exceeded, so a suspended workflow can be resumed.
+func (woc *wfOperationCtx) checkResourceBudget() {
+	budget := woc.execWf.Spec.ResourceBudget
+	if budget == nil || woc.getResourceBudgetExceededCondition() != nil {
+		return
+	}
+	used := wfv1.ResourcesDuration{}
+	for _, node := range woc.wf.Status.Nodes {
+		// only pods use resources, the durations of other nodes are the sums of their children
+		if node.Type == wfv1.NodeTypePod {
+			used = used.Add(node.ResourcesDuration)
+		}
+	}
+	name, exceeded := budget.Exceeded(used)
+	if !exceeded {
+		return
+	}
+	message := fmt.Sprintf("Workflow used %v of %s, which exceeds its resource budget of %v", used[name], name, budget.Limits[name])
+	woc.log.WithField("action", budget.GetAction()).Info(message)
+	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypeResourceBudgetExceeded, Status: metav1.ConditionTrue, Message: message})
+	woc.updated = true
+	woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowResourceBudgetExceeded", message)
+	if budget.GetAction() == wfv1.ResourceBudgetActionSuspend {
+		woc.wf.Spec.Suspend = ptr.To(true) // not-woc-misuse
+		woc.execWf.Spec.Suspend = ptr.To(true)
+	}
+}
+
+// checkSuspendAfter suspends the workflow once a node of the suspend-after annotation has completed. The nodes are
+// checked after the pods are reconciled, so that the workflow is suspended before any node that follows a completed pod
+// is started. The nodes that have completed are removed from the annotation, so that the workflow can be resumed.
+func (woc *wfOperationCtx) checkSuspendAfter() {
+	nodes, err := wfutil.SuspendAfterNodes(woc.wf)
+	if err != nil {
+		woc.log.WithError(err).Warn("Ignoring the suspend-after annotation")
+		return
+	}
+	var waiting, completed []string
+	for _, name := range nodes {
+		node := woc.wf.Status.Nodes.Find(func(node wfv1.NodeStatus) bool {
+			return node.ID == name || node.Name == name || node.DisplayName == name
+		})
+		if node != nil && node.Fulfilled() {
+			completed = append(completed, name)
+		} else {
+			waiting = append(waiting, name)
+		}
+	}
+	if len(completed) == 0 {
+		return
+	}
+	message := fmt.Sprintf("Workflow suspended after %s", strings.Join(completed, ", "))
+	woc.log.Info(message)
+	if err := wfutil.SetSuspendAfterNodes(woc.wf, waiting); err != nil {
+		woc.log.WithError(err).Warn("Failed to update the suspend-after annotation")
+		return
+	}
+	woc.wf.Spec.Suspend = ptr.To(true) // not-woc-misuse
+	woc.execWf.Spec.Suspend = ptr.To(true)
+	woc.updated = true
+	woc.eventRecorder.Event(woc.wf, apiv1.EventTypeNormal, "WorkflowSuspended", message)
+}
+
+func (woc *wfOperationCtx) getResourceBudgetExceededCondition() *wfv1.Condition {
+	for i, condition := range woc.wf.Status.Conditions {
+		if condition.Type == wfv1.ConditionTypeResourceBudgetExceeded && condition.Status == metav1.ConditionTrue {
+			return &woc.wf.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// resourceBudgetTerminated returns whether the workflow exceeded a resource budget whose action is to terminate it
+func (woc *wfOperationCtx) resourceBudgetTerminated() bool {
+	return woc.getResourceBudgetExceededCondition() != nil && woc.execWf.Spec.ResourceBudget.GetAction() == wfv1.ResourceBudgetActionTerminate
+}
+
+func (woc *wfOperationCtx) getPendingTimeoutCondition() *wfv1.Condition {
+	for i, condition := range woc.wf.Status.Conditions {
+		if condition.Type == wfv1.ConditionTypePendingTimeout && condition.Status == metav1.ConditionTrue {
+			return &woc.wf.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 // setGlobalParameters sets the globalParam map with global parameters
 func (woc *wfOperationCtx) setGlobalParameters(executionParameters wfv1.Arguments) error {
 	woc.globalParams[common.GlobalVarWorkflowName] = woc.wf.Name
@@ -631,6 +833,10 @@ func (woc *wfOperationCtx) setGlobalParameters(executionParameters wfv1.Argument
 	if woc.execWf.Spec.Priority != nil {
 		woc.globalParams[common.GlobalVarWorkflowPriority] = strconv.Itoa(int(*woc.execWf.Spec.Priority))
 	}
+	owner := ptr.Deref(woc.execWf.Spec.Owner, wfv1.WorkflowOwner{})
+	woc.globalParams[common.GlobalVarWorkflowOwnerTeam] = owner.Team
+	woc.globalParams[common.GlobalVarWorkflowOwnerEmail] = owner.Email
+	woc.globalParams[common.GlobalVarWorkflowOwnerSlackChannel] = owner.SlackChannel
 	for char := range strftime.FormatChars {
 		cTimeVar := fmt.Sprintf("%s.%s", common.GlobalVarWorkflowCreationTimestamp, string(char))
 		woc.globalParams[cTimeVar] = strftime.Format("%"+string(char), woc.wf.CreationTimestamp.Time)
@@ -683,7 +889,15 @@ func (woc *wfOperationCtx) setGlobalParameters(executionParameters wfv1.Argument
 		woc.globalParams[common.GlobalVarWorkflowAnnotationsJSON] = string(workflowAnnotations)
 	}
 	for k, v := range woc.wf.Annotations {
-		woc.globalParams["workflow.annotations."+k] = v
+		// if the Annotation will get overridden by an AnnotationsFrom expression later, don't set it now
+		if md != nil {
+			_, existsAnnotationsFrom := md.AnnotationsFrom[k]
+			if !existsAnnotationsFrom {
+				woc.globalParams["workflow.annotations."+k] = v
+			}
+		} else {
+			woc.globalParams["workflow.annotations."+k] = v
+		}
 	}
 	if workflowLabels, err := json.Marshal(woc.wf.Labels); err == nil {
 		woc.globalParams[common.GlobalVarWorkflowLabels] = string(workflowLabels)
@@ -710,7 +924,11 @@ func (woc *wfOperationCtx) setGlobalParameters(executionParameters wfv1.Argument
 			}
 		}
 		for n, v := range md.Annotations {
-			woc.globalParams["workflow.annotations."+n] = v
+			// if the Annotation will get overridden by an AnnotationsFrom expression later, don't set it now
+			_, existsAnnotationsFrom := md.AnnotationsFrom[n]
+			if !existsAnnotationsFrom {
+				woc.globalParams["workflow.annotations."+n] = v
+			}
 		}
 	}
 
@@ -792,6 +1010,7 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 	}
 
 	woc.log.WithFields(log.Fields{"resourceVersion": woc.wf.ResourceVersion, "phase": woc.wf.Status.Phase}).Info("Workflow update successful")
+	woc.saveStatusSnapshot()
 
 	switch os.Getenv("INFORMER_WRITE_BACK") {
 	// By default we write back (as per v2.11), this does not reduce errors, but does reduce
@@ -811,6 +1030,9 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 		if err := woc.deleteTaskResults(ctx); err != nil {
 			woc.log.WithError(err).Warn("failed to delete task-results")
 		}
+		if !woc.orig.Status.Fulfilled() {
+			woc.deliverResult()
+		}
 	}
 	// If Finalizer exists, requeue to make sure Finalizer can be removed.
 	if woc.wf.Status.Fulfilled() && len(wf.GetFinalizers()) > 0 {
@@ -953,6 +1175,33 @@ func (woc *wfOperationCtx) requeue() {
 	woc.controller.wfQueue.AddRateLimited(key)
 }
 
+// backoffJitter returns the amount to take off the backoff after the node failed, which is at most the percentage of
+// the backoff. It is random between nodes, so that their retries are spread out, but it is the same for each
+// reconciliation of a node, so that it does not change the deadline of the backoff.
+func backoffJitter(nodeID string, percentage int32, backoff time.Duration) time.Duration {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(nodeID))
+	fraction := float64(h.Sum32()) / math.MaxUint32
+	return time.Duration(float64(backoff) * float64(min(percentage, 100)) / 100 * fraction)
+}
+
+// applyMaxAttemptDuration limits the execution deadline of the next attempt of a retryStrategy to its
+// maxAttemptDuration, so that an attempt that hangs fails and can be retried
+func applyMaxAttemptDuration(backoff *wfv1.Backoff, opts *executeTemplateOpts) error {
+	if backoff == nil || backoff.MaxAttemptDuration == "" {
+		return nil
+	}
+	maxAttemptDuration, err := wfv1.ParseStringToDuration(backoff.MaxAttemptDuration)
+	if err != nil {
+		return err
+	}
+	attemptDeadline := time.Now().Add(maxAttemptDuration)
+	if opts.executionDeadline.IsZero() || attemptDeadline.Before(opts.executionDeadline) {
+		opts.executionDeadline = attemptDeadline
+	}
+	return nil
+}
+
 // processNodeRetries updates the retry node state based on the child node state and the retry strategy and returns the node.
 func (woc *wfOperationCtx) processNodeRetries(node *wfv1.NodeStatus, retryStrategy wfv1.RetryStrategy, opts *executeTemplateOpts) (*wfv1.NodeStatus, bool, error) {
 	if node.Phase.Fulfilled() {
@@ -1001,6 +1250,15 @@ func (woc *wfOperationCtx) processNodeRetries(node *wfv1.NodeStatus, retryStrate
 		return woc.markNodePhase(node.Name, lastChildNode.Phase, message), true, nil
 	}
 
+	if lastChildNode.Message == common.PreemptedMessage {
+		if preemptions := woc.countPreemptions(childNodeIds); preemptions > opts.checkpoint.GetMaxRestarts() {
+			woc.log.WithField("node", node.Name).Infoln("No more restarts left. Failing...")
+			return woc.markNodePhase(node.Name, lastChildNode.Phase, fmt.Sprintf("No more restarts left after being preempted %d times", preemptions)), true, nil
+		}
+		woc.log.WithField("node", node.Name).Info("Node was preempted, restarting it from its checkpoint")
+		return node, true, nil
+	}
+
 	if retryStrategy.Backoff != nil {
 		maxDurationDeadline := time.Time{}
 		// Process max duration limit
@@ -1049,6 +1307,13 @@ func (woc *wfOperationCtx) processNodeRetries(node *wfv1.NodeStatus, retryStrate
 				timeToWait = capDuration
 			}
 		}
+		jitter, err := intstr.Int32(retryStrategy.Backoff.Jitter)
+		if err != nil {
+			return nil, false, err
+		}
+		if jitter != nil && *jitter > 0 {
+			timeToWait -= backoffJitter(lastChildNode.ID, *jitter, timeToWait)
+		}
 		waitingDeadline := lastChildNode.FinishedAt.Add(timeToWait)
 
 		// If the waiting deadline is after the max duration deadline, then it's futile to wait until then. Stop early
@@ -1106,13 +1371,14 @@ func (woc *wfOperationCtx) processNodeRetries(node *wfv1.NodeStatus, retryStrate
 	if err != nil {
 		return nil, false, err
 	}
-	if retryStrategy.Limit != nil && limit != nil && int32(len(childNodeIds)) > *limit {
+	// restarts of nodes that were preempted are not retries
+	if retryStrategy.Limit != nil && limit != nil && int32(len(childNodeIds))-woc.countPreemptions(childNodeIds) > *limit {
 		woc.log.Infoln("No more retries left. Failing...")
 		return woc.markNodePhase(node.Name, lastChildNode.Phase, "No more retries left"), true, nil
 	}
 
 	if retryStrategy.Expression != "" && len(childNodeIds) > 0 {
-		localScope := buildRetryStrategyLocalScope(node, woc.wf.Status.Nodes)
+		localScope := buildRetryStrategyLocalScope(node, woc.wf.Status.Nodes, woc.controller.Config)
 		scope := env.GetFuncMap(localScope)
 		shouldContinue, err := argoexpr.EvalBool(retryStrategy.Expression, scope)
 		if err != nil {
@@ -1143,11 +1409,14 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) (error, bool)
 	wfNodesLock := &sync.RWMutex{}
 	podRunningCondition := wfv1.Condition{Type: wfv1.ConditionTypePodRunning, Status: metav1.ConditionFalse}
 	taskResultIncomplete := false
-	performAssessment := func(pod *apiv1.Pod) {
+	assessments := make([]*podAssessment, len(podList))
+	performAssessment := func(i int, pod *apiv1.Pod) {
 		if pod == nil {
 			return
 		}
 		if woc.isAgentPod(pod) {
+			wfNodesLock.Lock()
+			defer wfNodesLock.Unlock()
 			woc.updateAgentPodStatus(pod)
 			return
 		}
@@ -1156,58 +1425,89 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) (error, bool)
 		seenPods[nodeID] = pod
 		seenPodLock.Unlock()
 
-		wfNodesLock.Lock()
-		defer wfNodesLock.Unlock()
+		wfNodesLock.RLock()
+		defer wfNodesLock.RUnlock()
 		node, err := woc.wf.Status.Nodes.Get(nodeID)
 		if err == nil {
-			if newState := woc.assessNodeStatus(ctx, pod, node); newState != nil {
-				// update if a pod deletion timestamp exists on a completed workflow, ensures this pod is always looked at
-				// in the pod cleanup process
-				if pod.DeletionTimestamp != nil && newState.Fulfilled() {
-					woc.updated = true
-				}
-				// Check whether its taskresult is in an incompleted state.
-				if newState.Succeeded() && woc.wf.Status.IsTaskResultIncomplete(node.ID) {
-					woc.log.WithFields(log.Fields{"nodeID": newState.ID}).Debug("Taskresult of the node not yet completed")
-					taskResultIncomplete = true
-					return
-				}
-				woc.addOutputsToGlobalScope(newState.Outputs)
-				if newState.MemoizationStatus != nil {
-					if newState.Succeeded() {
-						c := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, newState.MemoizationStatus.CacheName)
-						err := c.Save(ctx, newState.MemoizationStatus.Key, newState.ID, newState.Outputs)
-						if err != nil {
-							woc.log.WithFields(log.Fields{"nodeID": newState.ID}).WithError(err).Error("Failed to save node outputs to cache")
-							newState.Phase = wfv1.NodeError
-							newState.Message = err.Error()
-						}
-					}
-				}
-				if newState.Phase == wfv1.NodeRunning {
-					podRunningCondition.Status = metav1.ConditionTrue
-				}
-				woc.wf.Status.Nodes.Set(nodeID, *newState)
-				woc.updated = true
-				// warning!  when the node completes, the daemoned flag will be unset, so we must check the old node
-				if !node.IsDaemoned() && !node.Completed() && newState.Completed() {
-					if woc.shouldPrintPodSpec(newState) {
-						printPodSpecLog(pod, woc.wf.Name)
-					}
+			assessments[i] = woc.assessPod(ctx, pod, node)
+		}
+	}
+	mergeAssessment := func(a *podAssessment) {
+		a.applyEffects()
+		newState, node, pod := a.node, a.old, a.pod
+		if newState == nil {
+			return
+		}
+		// update if a pod deletion timestamp exists on a completed workflow, ensures this pod is always looked at
+		// in the pod cleanup process
+		if pod.DeletionTimestamp != nil && newState.Fulfilled() {
+			woc.updated = true
+		}
+		// Check whether its taskresult is in an incompleted state.
+		if newState.Succeeded() && woc.wf.Status.IsTaskResultIncomplete(node.ID) {
+			woc.log.WithFields(log.Fields{"nodeID": newState.ID}).Debug("Taskresult of the node not yet completed")
+			taskResultIncomplete = true
+			return
+		}
+		woc.addOutputsToGlobalScope(newState.Outputs)
+		if newState.MemoizationStatus != nil {
+			if newState.Succeeded() {
+				c := woc.controller.cacheFactory.GetCache(woc.controller.memoizationCacheType(), newState.MemoizationStatus.CacheName)
+				err := c.Save(ctx, newState.MemoizationStatus.Key, newState.ID, newState.Outputs)
+				if err != nil {
+					woc.log.WithFields(log.Fields{"nodeID": newState.ID}).WithError(err).Error("Failed to save node outputs to cache")
+					newState.Phase = wfv1.NodeError
+					newState.Message = err.Error()
 				}
 			}
 		}
+		if newState.Phase == wfv1.NodeRunning {
+			podRunningCondition.Status = metav1.ConditionTrue
+		}
+		woc.wf.Status.Nodes.Set(node.ID, *newState)
+		woc.updated = true
+		if !node.Fulfilled() && newState.Fulfilled() {
+			woc.recordArtifactCircuits(pod, newState)
+			woc.recordArtifactQuota(ctx, pod, newState)
+		}
+		// warning!  when the node completes, the daemoned flag will be unset, so we must check the old node
+		if !node.IsDaemoned() && !node.Completed() && newState.Completed() {
+			if woc.shouldPrintPodSpec(newState) {
+				printPodSpecLog(pod, woc.wf.Name)
+			}
+		}
 	}
 
-	parallelPodNum := make(chan string, 500)
+	// The pods are assessed concurrently by a bounded number of workers, each only reading the workflow. The
+	// assessments are then merged in the order of their nodes' IDs, so the resulting status does not depend on the order
+	// that the assessments complete in.
+	parallelPodNum := make(chan string, podAssessmentWorkers)
 	var wg sync.WaitGroup
 
+	for i, pod := range podList {
+		parallelPodNum <- pod.Name
+		wg.Add(1)
+		go func(i int, pod *apiv1.Pod) {
+			defer wg.Done()
+			performAssessment(i, pod)
+			<-parallelPodNum
+		}(i, pod)
+	}
+
+	wg.Wait()
+
+	assessed := slices.DeleteFunc(assessments, func(a *podAssessment) bool { return a == nil })
+	slices.SortFunc(assessed, func(a, b *podAssessment) int { return strings.Compare(a.old.ID, b.old.ID) })
+	for _, a := range assessed {
+		mergeAssessment(a)
+	}
+
+	// execution control is applied once the assessments are merged, so that the nodes that it fails are not overwritten
 	for _, pod := range podList {
 		parallelPodNum <- pod.Name
 		wg.Add(1)
 		go func(pod *apiv1.Pod) {
 			defer wg.Done()
-			performAssessment(pod)
 			woc.applyExecutionControl(pod, wfNodesLock)
 			<-parallelPodNum
 		}(pod)
@@ -1314,10 +1614,10 @@ func (woc *wfOperationCtx) failNodesWithoutCreatedPodsAfterDeadlineOrShutdown()
 		if node.Fulfilled() {
 			continue
 		}
-		// Only fail nodes that are not part of exit handler if we are "Stopping" or all pods if we are "Terminating"
+		// Only fail nodes that are not part of exit handler if we are "Stopping" or "Draining" or all pods if we are "Terminating"
 		if woc.GetShutdownStrategy().Enabled() && !woc.GetShutdownStrategy().ShouldExecute(node.IsPartOfExitHandler(nodes)) {
-			// fail suspended nodes or taskset nodes when shutting down
-			if node.IsActiveSuspendNode() || node.IsTaskSetNode() {
+			// fail suspended nodes or taskset nodes when shutting down, but let running taskset nodes finish when draining
+			if node.IsActiveSuspendNode() || (node.IsTaskSetNode() && woc.GetShutdownStrategy().ShouldTerminate(node.IsPartOfExitHandler(nodes))) {
 				message := fmt.Sprintf("Stopped with strategy '%s'", woc.GetShutdownStrategy())
 				woc.markNodePhase(node.Name, wfv1.NodeFailed, message)
 				continue
@@ -1368,14 +1668,46 @@ func printPodSpecLog(pod *apiv1.Pod, wfName string) {
 	}
 }
 
+// podAssessment is the assessment of a pod against its node. The changes that it makes to the rest of the workflow are
+// deferred until the assessments of all the pods are merged, so that pods can be assessed concurrently.
+type podAssessment struct {
+	pod *apiv1.Pod
+	old *wfv1.NodeStatus
+	// node is the new status of the node, or nil if it is unchanged
+	node *wfv1.NodeStatus
+	// effects are the changes to the rest of the workflow, applied in order
+	effects []func()
+}
+
+func (a *podAssessment) addEffect(f func()) {
+	a.effects = append(a.effects, f)
+}
+
+func (a *podAssessment) applyEffects() {
+	for _, f := range a.effects {
+		f()
+	}
+	a.effects = nil
+}
+
 // assessNodeStatus compares the current state of a pod with its corresponding node
 // and returns the new node status if something changed
 func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod, old *wfv1.NodeStatus) *wfv1.NodeStatus {
+	a := woc.assessPod(ctx, pod, old)
+	a.applyEffects()
+	return a.node
+}
+
+// assessPod compares the current state of a pod with its corresponding node without changing the workflow, so it
+// can be called concurrently for different pods whilst the nodes are read locked
+func (woc *wfOperationCtx) assessPod(ctx context.Context, pod *apiv1.Pod, old *wfv1.NodeStatus) *podAssessment {
+	a := &podAssessment{pod: pod, old: old}
 	new := old.DeepCopy()
-	tmpl, err := woc.GetNodeTemplate(old)
+	tmpl, err := woc.resolveNodeTemplate(old)
 	if err != nil {
 		woc.log.Error(err)
-		return nil
+		a.addEffect(func() { woc.markNodeError(old.Name, err) })
+		return a
 	}
 	switch pod.Status.Phase {
 	case apiv1.PodPending:
@@ -1411,7 +1743,7 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 				// pod is running and template is marked daemon. check if everything is ready
 				for _, ctrStatus := range pod.Status.ContainerStatuses {
 					if !ctrStatus.Ready {
-						return nil
+						return a
 					}
 				}
 				// proceed to mark node as running and daemoned
@@ -1431,6 +1763,11 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 		new.Phase = wfv1.NodeError
 		new.Message = fmt.Sprintf("Unexpected pod phase for %s: %s", pod.Name, pod.Status.Phase)
 	}
+	if new.Phase.Fulfilled() && tmpl != nil && tmpl.PreemptionPolicy == wfv1.PreemptionPolicyCheckpoint && isPreempted(pod) {
+		// however the pod completed, the node is restarted from its checkpoint
+		new.Phase = wfv1.NodeFailed
+		new.Message = common.PreemptedMessage
+	}
 	if old.Phase != new.Phase {
 		woc.controller.metrics.ChangePodPhase(ctx, string(new.Phase), pod.Namespace)
 	}
@@ -1448,20 +1785,20 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 			message := fmt.Sprintf("%s: %s (exit code %d): %s", c.Name, c.State.Terminated.Reason, exitCode, c.State.Terminated.Message)
 			switch exitCode {
 			case 0:
-				woc.markNodePhase(ctrNodeName, wfv1.NodeSucceeded)
+				a.addEffect(func() { woc.markNodePhase(ctrNodeName, wfv1.NodeSucceeded) })
 			case 64:
 				// special emissary exit code indicating the emissary errors, rather than the sub-process failure,
 				// (unless the sub-process coincidentally exits with code 64 of course)
-				woc.markNodePhase(ctrNodeName, wfv1.NodeError, message)
+				a.addEffect(func() { woc.markNodePhase(ctrNodeName, wfv1.NodeError, message) })
 			default:
-				woc.markNodePhase(ctrNodeName, wfv1.NodeFailed, message)
+				a.addEffect(func() { woc.markNodePhase(ctrNodeName, wfv1.NodeFailed, message) })
 			}
 		case pod.Status.Phase == apiv1.PodFailed:
-			woc.markNodePhase(ctrNodeName, wfv1.NodeFailed, `Pod Failed whilst container running`)
+			a.addEffect(func() { woc.markNodePhase(ctrNodeName, wfv1.NodeFailed, `Pod Failed whilst container running`) })
 		case c.State.Waiting != nil:
-			woc.markNodePhase(ctrNodeName, wfv1.NodePending)
+			a.addEffect(func() { woc.markNodePhase(ctrNodeName, wfv1.NodePending) })
 		case c.State.Running != nil:
-			woc.markNodePhase(ctrNodeName, wfv1.NodeRunning)
+			a.addEffect(func() { woc.markNodePhase(ctrNodeName, wfv1.NodeRunning) })
 		}
 	}
 
@@ -1472,6 +1809,15 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 
 	new.HostNodeName = pod.Spec.NodeName
 
+	if new.SchedulingLatency == nil {
+		if latency := getSchedulingLatency(pod, new.StartedAt.Time); latency != nil {
+			new.SchedulingLatency = latency
+			woc.controller.metrics.PodSchedulingLatency(ctx, "scheduling", latency.Scheduling.Duration, pod.Namespace)
+			woc.controller.metrics.PodSchedulingLatency(ctx, "initializing", latency.Initializing.Duration, pod.Namespace)
+			woc.controller.metrics.PodSchedulingLatency(ctx, "starting", latency.Starting.Duration, pod.Namespace)
+		}
+	}
+
 	if !new.Progress.IsValid() {
 		new.Progress = wfv1.ProgressDefault
 	}
@@ -1502,7 +1848,7 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 				nodeID := woc.nodeID(pod)
 				woc.log.WithFields(log.Fields{"nodeID": nodeID, "exitCode": c.State.Terminated.ExitCode, "reason": c.State.Terminated.Reason}).
 					Warn("marking its taskResult as completed since wait container did not exit normally")
-				woc.wf.Status.MarkTaskResultComplete(nodeID)
+				a.addEffect(func() { woc.wf.Status.MarkTaskResultComplete(nodeID) })
 			}
 		}
 	}
@@ -1512,7 +1858,7 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 		nodeID := woc.nodeID(pod)
 		woc.log.WithFields(log.Fields{"nodeID": nodeID}).
 			Warn("marking its taskResult as completed since wait container has been cleaned up.")
-		woc.wf.Status.MarkTaskResultComplete(nodeID)
+		a.addEffect(func() { woc.wf.Status.MarkTaskResultComplete(nodeID) })
 	}
 
 	// if we are transitioning from Pending to a different state (except Fail or Error), clear out unchanged message
@@ -1525,6 +1871,16 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 		new.ResourcesDuration = resource.DurationForPod(pod)
 	}
 
+	if new.Fulfilled() && (new.Provenance == nil || new.Provenance.ImageIDs == nil) {
+		if imageIDs, envVarNames := getPodProvenance(pod); len(imageIDs) > 0 {
+			if new.Provenance == nil {
+				new.Provenance = &wfv1.NodeProvenance{}
+			}
+			new.Provenance.ImageIDs = imageIDs
+			new.Provenance.EnvVarNames = envVarNames
+		}
+	}
+
 	if !reflect.DeepEqual(old, new) {
 		woc.log.WithField("nodeID", old.ID).
 			WithField("old.phase", old.Phase).
@@ -1534,11 +1890,12 @@ func (woc *wfOperationCtx) assessNodeStatus(ctx context.Context, pod *apiv1.Pod,
 			WithField("old.progress", old.Progress).
 			WithField("new.progress", new.Progress).
 			Debug("node changed")
-		return new
+		a.node = new
+		return a
 	}
 	woc.log.WithField("nodeID", old.ID).
 		Debug("node unchanged")
-	return nil
+	return a
 }
 
 func getExitCode(pod *apiv1.Pod) *int32 {
@@ -1574,6 +1931,88 @@ func (woc *wfOperationCtx) cleanUpPod(pod *apiv1.Pod, tmpl wfv1.Template) {
 	}
 }
 
+// getPodProvenance returns the IDs of the images that the containers of the pod ran, and the sorted names of their
+// environment variables. The wait container and the variables that the controller sets are excluded, as these are the
+// same for every pod of a version.
+func getPodProvenance(pod *apiv1.Pod) (map[string]string, []string) {
+	imageIDs := make(map[string]string)
+	for _, c := range pod.Status.ContainerStatuses {
+		if c.Name != common.WaitContainerName && c.ImageID != "" {
+			imageIDs[c.Name] = c.ImageID
+		}
+	}
+	var envVarNames []string
+	for _, c := range pod.Spec.Containers {
+		if c.Name == common.WaitContainerName {
+			continue
+		}
+		for _, e := range c.Env {
+			if !strings.HasPrefix(e.Name, "ARGO_") && !slices.Contains(envVarNames, e.Name) {
+				envVarNames = append(envVarNames, e.Name)
+			}
+		}
+	}
+	sort.Strings(envVarNames)
+	return imageIDs, envVarNames
+}
+
+// getSchedulingLatency returns how long the pod waited before its containers started, from its node being created at
+// the time, or nil if none of its containers have started yet. The times are measured by different clocks, to the
+// second, so they are kept in order rather than giving negative durations.
+func getSchedulingLatency(pod *apiv1.Pod, createdAt time.Time) *wfv1.NodeSchedulingLatency {
+	var started time.Time
+	for _, c := range pod.Status.ContainerStatuses {
+		if c.Name == common.WaitContainerName {
+			continue
+		}
+		var t time.Time
+		switch {
+		case c.State.Running != nil:
+			t = c.State.Running.StartedAt.Time
+		case c.State.Terminated != nil:
+			t = c.State.Terminated.StartedAt.Time
+		}
+		if !t.IsZero() && (started.IsZero() || t.Before(started)) {
+			started = t
+		}
+	}
+	if started.IsZero() {
+		return nil
+	}
+	if createdAt.IsZero() {
+		createdAt = pod.CreationTimestamp.Time
+	}
+	inOrder := func(t, earliest time.Time) time.Time {
+		if t.IsZero() || t.Before(earliest) {
+			return earliest
+		}
+		if t.After(started) {
+			return started
+		}
+		return t
+	}
+	createdAt = inOrder(createdAt, createdAt)
+	var scheduled, initialized time.Time
+	for _, c := range pod.Status.Conditions {
+		if c.Status != apiv1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case apiv1.PodScheduled:
+			scheduled = c.LastTransitionTime.Time
+		case apiv1.PodInitialized:
+			initialized = c.LastTransitionTime.Time
+		}
+	}
+	scheduled = inOrder(scheduled, createdAt)
+	initialized = inOrder(initialized, scheduled)
+	return &wfv1.NodeSchedulingLatency{
+		Scheduling:   &metav1.Duration{Duration: scheduled.Sub(createdAt)},
+		Initializing: &metav1.Duration{Duration: initialized.Sub(scheduled)},
+		Starting:     &metav1.Duration{Duration: started.Sub(initialized)},
+	}
+}
+
 func getLatestFinishedAt(pod *apiv1.Pod) metav1.Time {
 	var latest metav1.Time
 	for _, ctr := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
@@ -1586,13 +2025,30 @@ func getLatestFinishedAt(pod *apiv1.Pod) metav1.Time {
 	return latest
 }
 
+// podInitializingReason is the reason that containers wait for whilst the init containers of their pod run
+const podInitializingReason = "PodInitializing"
+
+// imagePullWaitingReasons are the reasons that a container waits for when its image cannot be pulled, e.g. because
+// the registry is unavailable
+var imagePullWaitingReasons = map[string]bool{
+	"ErrImagePull":        true,
+	"ImagePullBackOff":    true,
+	"ErrImageNeverPull":   true,
+	"InvalidImageName":    true,
+	"RegistryUnavailable": true,
+}
+
 func getPendingReason(pod *apiv1.Pod) string {
+	// containers wait for the init containers, so an init container that is waiting itself, e.g. because its image
+	// cannot be pulled, is why the pod is pending
+	for _, ctrStatus := range pod.Status.InitContainerStatuses {
+		if w := ctrStatus.State.Waiting; w != nil && w.Reason != "" && w.Reason != podInitializingReason {
+			return getWaitingReason(w)
+		}
+	}
 	for _, ctrStatus := range pod.Status.ContainerStatuses {
 		if ctrStatus.State.Waiting != nil {
-			if ctrStatus.State.Waiting.Message != "" {
-				return fmt.Sprintf("%s: %s", ctrStatus.State.Waiting.Reason, ctrStatus.State.Waiting.Message)
-			}
-			return ctrStatus.State.Waiting.Reason
+			return getWaitingReason(ctrStatus.State.Waiting)
 		}
 	}
 	// Example:
@@ -1613,6 +2069,13 @@ func getPendingReason(pod *apiv1.Pod) string {
 	return ""
 }
 
+func getWaitingReason(w *apiv1.ContainerStateWaiting) string {
+	if w.Message != "" {
+		return fmt.Sprintf("%s: %s", w.Reason, w.Message)
+	}
+	return w.Reason
+}
+
 // inferFailedReason returns metadata about a Failed pod to be used in its NodeStatus
 // Returns a tuple of the new phase and message
 func (woc *wfOperationCtx) inferFailedReason(pod *apiv1.Pod, tmpl *wfv1.Template) (wfv1.NodePhase, string) {
@@ -1650,6 +2113,9 @@ func (woc *wfOperationCtx) inferFailedReason(pod *apiv1.Pod, tmpl *wfv1.Template
 		// https://github.com/virtual-kubelet/virtual-kubelet/blob/7f2a02291530d2df14905702e6d51500dd57640a/node/sync.go#L195-L208
 
 		if ctr.State.Waiting != nil {
+			if imagePullWaitingReasons[ctr.State.Waiting.Reason] {
+				return wfv1.NodeError, fmt.Sprintf("Pod failed before %s container starts, as its image could not be pulled: %s", ctr.Name, getWaitingReason(ctr.State.Waiting))
+			}
 			return wfv1.NodeError, fmt.Sprintf("Pod failed before %s container starts due to %s: %s", ctr.Name, ctr.State.Waiting.Reason, ctr.State.Waiting.Message)
 		}
 		t := ctr.State.Terminated
@@ -1670,7 +2136,8 @@ func (woc *wfOperationCtx) inferFailedReason(pod *apiv1.Pod, tmpl *wfv1.Template
 
 		switch {
 		case ctr.Name == common.InitContainerName:
-			return wfv1.NodeError, msg
+			// the init container loads the input artifacts, so this is not a failure of the step itself
+			return wfv1.NodeError, "Failed to load input artifacts: " + msg
 		case tmpl.IsMainContainerName(ctr.Name):
 			return wfv1.NodeFailed, msg
 		case ctr.Name == common.WaitContainerName:
@@ -1882,7 +2349,7 @@ func getRetryNodeChildrenIds(node *wfv1.NodeStatus, nodes wfv1.Nodes) []string {
 	return childrenIds
 }
 
-func buildRetryStrategyLocalScope(node *wfv1.NodeStatus, nodes wfv1.Nodes) map[string]interface{} {
+func buildRetryStrategyLocalScope(node *wfv1.NodeStatus, nodes wfv1.Nodes, cfg config.Config) map[string]interface{} {
 	localScope := make(map[string]interface{})
 
 	// `retries` variable
@@ -1893,18 +2360,51 @@ func buildRetryStrategyLocalScope(node *wfv1.NodeStatus, nodes wfv1.Nodes) map[s
 	}
 	localScope[common.LocalVarRetries] = strconv.Itoa(len(childNodeIds) - 1)
 
-	exitCode := "-1"
-	if lastChildNode.Outputs != nil && lastChildNode.Outputs.ExitCode != nil {
-		exitCode = *lastChildNode.Outputs.ExitCode
+	attempts := make([]map[string]interface{}, 0, len(childNodeIds))
+	for _, childNodeID := range childNodeIds {
+		child, err := nodes.Get(childNodeID)
+		if err != nil {
+			continue
+		}
+		attempts = append(attempts, retryAttemptScope(child, cfg))
+	}
+	localScope[common.LocalVarRetriesAttempts] = attempts
+
+	lastRetry := attempts[len(attempts)-1]
+	localScope[common.LocalVarRetriesLastExitCode] = lastRetry["exitCode"]
+	localScope[common.LocalVarRetriesLastExitCodeClass] = lastRetry["exitCodeClass"]
+	localScope[common.LocalVarRetriesLastStatus] = lastRetry["status"]
+	localScope[common.LocalVarRetriesLastDuration] = lastRetry["duration"]
+	localScope[common.LocalVarRetriesLastMessage] = lastRetry["message"]
+	if outputs := lastChildNode.Outputs; outputs != nil {
+		if outputs.Result != nil {
+			localScope[common.LocalVarRetriesLastOutputs+".result"] = *outputs.Result
+		}
+		for _, param := range outputs.Parameters {
+			if param.Value != nil {
+				localScope[common.LocalVarRetriesLastOutputs+".parameters."+param.Name] = param.Value.String()
+			}
+		}
 	}
-	localScope[common.LocalVarRetriesLastExitCode] = exitCode
-	localScope[common.LocalVarRetriesLastStatus] = string(lastChildNode.Phase)
-	localScope[common.LocalVarRetriesLastDuration] = fmt.Sprint(lastChildNode.GetDuration().Seconds())
-	localScope[common.LocalVarRetriesLastMessage] = lastChildNode.Message
 
 	return localScope
 }
 
+// retryAttemptScope returns the variables of an attempt of a retried node, which are strings for backwards compatibility
+func retryAttemptScope(attempt *wfv1.NodeStatus, cfg config.Config) map[string]interface{} {
+	exitCode := "-1"
+	if attempt.Outputs != nil && attempt.Outputs.ExitCode != nil {
+		exitCode = *attempt.Outputs.ExitCode
+	}
+	return map[string]interface{}{
+		"exitCode":      exitCode,
+		"exitCodeClass": cfg.GetExitCodeClass(exitCode),
+		"status":        string(attempt.Phase),
+		"duration":      fmt.Sprint(attempt.GetDuration().Seconds()),
+		"message":       attempt.Message,
+	}
+}
+
 type executeTemplateOpts struct {
 	// boundaryID is an ID for node grouping
 	boundaryID string
@@ -1915,6 +2415,10 @@ type executeTemplateOpts struct {
 	executionDeadline time.Time
 	// nodeFlag tracks node information such as hook or retry
 	nodeFlag *wfv1.NodeFlag
+	// patched signifies that the template was patched by a patched retry
+	patched bool
+	// checkpoint is the checkpoint of a template that is restarted when it is preempted
+	checkpoint *wfv1.Checkpoint
 }
 
 // executeTemplate executes the template with the given arguments and returns the created NodeStatus
@@ -2057,13 +2561,22 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 	// Check memoization cache if the node is about to be created, or was created in the past but is only now allowed to run due to acquiring a lock
 	if processedTmpl.Memoize != nil {
 		if node == nil || unlockedNode {
-			memoizationCache := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, processedTmpl.Memoize.Cache.ConfigMap.Name)
+			memoizationCache := woc.controller.cacheFactory.GetCache(woc.controller.memoizationCacheType(), processedTmpl.Memoize.Cache.ConfigMap.Name)
 			if memoizationCache == nil {
 				err := fmt.Errorf("cache could not be found or created")
 				woc.log.WithFields(log.Fields{"cacheName": processedTmpl.Memoize.Cache.ConfigMap.Name}).WithError(err)
 				return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, err), err
 			}
 
+			if processedTmpl.Memoize.Key == "" {
+				key, err := inputsHashKey(processedTmpl)
+				if err != nil {
+					return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, err), err
+				}
+				processedTmpl.Memoize.Key = key
+			}
+			processedTmpl.Memoize.Key = woc.scopedMemoizationKey(processedTmpl.Memoize)
+
 			entry, err := memoizationCache.Load(ctx, processedTmpl.Memoize.Key)
 			if err != nil {
 				return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, err), err
@@ -2071,12 +2584,11 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 
 			hit := entry.Hit()
 			var outputs *wfv1.Outputs
-			if processedTmpl.Memoize.MaxAge != "" {
-				maxAge, err := time.ParseDuration(processedTmpl.Memoize.MaxAge)
-				if err != nil {
-					err := fmt.Errorf("invalid maxAge: %s", err)
-					return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, err), err
-				}
+			maxAge, err := woc.controller.memoizationMaxAge(processedTmpl.Memoize)
+			if err != nil {
+				return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, opts.nodeFlag, err), err
+			}
+			if maxAge > 0 {
 				maxAgeOutputs, ok := entry.GetOutputsWithMaxAge(maxAge)
 				if !ok {
 					// The outputs are expired, so this cache entry is not hit
@@ -2147,6 +2659,7 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 			opts.nodeFlag = &wfv1.NodeFlag{}
 		}
 		opts.nodeFlag.Retried = true
+		opts.checkpoint = processedTmpl.Checkpoint
 		processedRetryParentNode, continueExecution, err := woc.processNodeRetries(retryParentNode, *woc.retryStrategy(processedTmpl), opts)
 		if err != nil {
 			return woc.markNodeError(retryNodeName, err), err
@@ -2155,6 +2668,9 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 			return retryParentNode, nil
 		}
 		retryParentNode = processedRetryParentNode
+		if err := applyMaxAttemptDuration(woc.retryStrategy(processedTmpl).Backoff, opts); err != nil {
+			return woc.markNodeError(retryNodeName, err), err
+		}
 		childNodeIDs, lastChildNode := getChildNodeIdsAndLastRetriedNode(retryParentNode, woc.wf.Status.Nodes)
 
 		// The retry node might have completed by now.
@@ -2239,6 +2755,18 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 		}
 	}
 
+	// A script that is re-run by a patched retry runs the patched script instead of its template's
+	if processedTmpl.GetType() == wfv1.TemplateTypeScript {
+		invocationNodeName := nodeName
+		if retryNodeName != "" {
+			invocationNodeName = retryNodeName
+		}
+		if patch, ok := woc.wf.Status.NodePatches[woc.wf.NodeID(invocationNodeName)]; ok {
+			processedTmpl = patch.Apply(processedTmpl)
+			opts.patched = true
+		}
+	}
+
 	switch processedTmpl.GetType() {
 	case wfv1.TemplateTypeContainer:
 		node, err = woc.executeContainer(ctx, nodeName, templateScope, processedTmpl, orgTmpl, opts)
@@ -2250,6 +2778,10 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 		node, err = woc.executeScript(ctx, nodeName, templateScope, processedTmpl, orgTmpl, opts)
 	case wfv1.TemplateTypeResource:
 		node, err = woc.executeResource(ctx, nodeName, templateScope, processedTmpl, orgTmpl, opts)
+	case wfv1.TemplateTypeJob:
+		node, err = woc.executeJob(ctx, nodeName, templateScope, processedTmpl, orgTmpl, opts)
+	case wfv1.TemplateTypeGang:
+		node, err = woc.executeGang(ctx, nodeName, templateScope, processedTmpl, orgTmpl, opts)
 	case wfv1.TemplateTypeDAG:
 		node, err = woc.executeDAG(ctx, nodeName, newTmplCtx, templateScope, processedTmpl, orgTmpl, opts)
 	case wfv1.TemplateTypeSuspend:
@@ -2438,11 +2970,11 @@ func (woc *wfOperationCtx) markWorkflowPhase(ctx context.Context, phase wfv1.Wor
 		if woc.controller.Config.WorkflowEvents.IsEnabled() {
 			switch phase {
 			case wfv1.WorkflowRunning:
-				woc.eventRecorder.Event(woc.wf, apiv1.EventTypeNormal, "WorkflowRunning", "Workflow Running")
+				woc.recordWorkflowPhaseEvent(apiv1.EventTypeNormal, "WorkflowRunning", "Workflow Running")
 			case wfv1.WorkflowSucceeded:
-				woc.eventRecorder.Event(woc.wf, apiv1.EventTypeNormal, "WorkflowSucceeded", "Workflow completed")
+				woc.recordWorkflowPhaseEvent(apiv1.EventTypeNormal, "WorkflowSucceeded", "Workflow completed")
 			case wfv1.WorkflowFailed, wfv1.WorkflowError:
-				woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowFailed", message)
+				woc.recordWorkflowPhaseEvent(apiv1.EventTypeWarning, "WorkflowFailed", message)
 			}
 		}
 	}
@@ -2480,6 +3012,7 @@ func (woc *wfOperationCtx) markWorkflowPhase(ctx context.Context, phase wfv1.Wor
 		}
 		woc.wf.Labels[common.LabelKeyCompleted] = "true"
 		woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Status: metav1.ConditionTrue, Type: wfv1.ConditionTypeCompleted})
+		woc.wf.Status.ArtifactManifest = woc.artifactManifest()
 		err := woc.deletePDBResource(ctx)
 		if err != nil {
 			woc.wf.Status.Phase = wfv1.WorkflowError
@@ -2545,18 +3078,22 @@ func (woc *wfOperationCtx) childrenFulfilled(node *wfv1.NodeStatus) bool {
 }
 
 func (woc *wfOperationCtx) GetNodeTemplate(node *wfv1.NodeStatus) (*wfv1.Template, error) {
+	tmpl, err := woc.resolveNodeTemplate(node)
+	if err != nil {
+		woc.markNodeError(node.Name, err)
+	}
+	return tmpl, err
+}
+
+// resolveNodeTemplate returns the template of the node without changing the workflow, so it can be called whilst pods
+// are assessed concurrently
+func (woc *wfOperationCtx) resolveNodeTemplate(node *wfv1.NodeStatus) (*wfv1.Template, error) {
 	if node.TemplateRef != nil {
 		tmplCtx, err := woc.createTemplateContext(node.GetTemplateScope())
 		if err != nil {
-			woc.markNodeError(node.Name, err)
 			return nil, err
 		}
-		tmpl, err := tmplCtx.GetTemplateFromRef(node.TemplateRef)
-		if err != nil {
-			woc.markNodeError(node.Name, err)
-			return tmpl, err
-		}
-		return tmpl, nil
+		return tmplCtx.GetTemplateFromRef(node.TemplateRef)
 	}
 	return woc.wf.GetTemplateByName(node.TemplateName), nil
 }
@@ -2764,14 +3301,23 @@ func (woc *wfOperationCtx) recordNodePhaseEvent(node *wfv1.NodeStatus) {
 	case wfv1.NodeSucceeded, wfv1.NodeRunning:
 		eventType = apiv1.EventTypeNormal
 	}
+	reason := fmt.Sprintf("WorkflowNode%s", node.Phase)
 	eventConfig := woc.controller.Config.NodeEvents
-	annotations := map[string]string{
-		common.AnnotationKeyNodeType: string(node.Type),
-		common.AnnotationKeyNodeName: node.Name,
-		common.AnnotationKeyNodeID:   node.ID,
-		// For retried/resubmitted workflows, the only main differentiation is the start time of nodes.
-		// We include this annotation here so that we could avoid combining events for those nodes.
-		common.AnnotationKeyNodeStartTime: strconv.FormatInt(node.StartedAt.UnixNano(), 10),
+	annotations := woc.workflowEventAnnotations()
+	annotations[common.AnnotationKeyNodeType] = string(node.Type)
+	annotations[common.AnnotationKeyNodeName] = node.Name
+	annotations[common.AnnotationKeyNodeID] = node.ID
+	// For retried/resubmitted workflows, the only main differentiation is the start time of nodes.
+	// We include this annotation here so that we could avoid combining events for those nodes.
+	annotations[common.AnnotationKeyNodeStartTime] = strconv.FormatInt(node.StartedAt.UnixNano(), 10)
+	if templateName := wfutil.GetTemplateFromNode(*node); templateName != "" {
+		annotations[common.AnnotationKeyNodeTemplateName] = templateName
+	}
+	if attempt, ok := retryAttempt(node); ok {
+		annotations[common.AnnotationKeyNodeRetryAttempt] = strconv.Itoa(attempt)
+	}
+	if node.Fulfilled() && !node.StartedAt.IsZero() && !node.FinishedAt.IsZero() {
+		annotations[common.AnnotationKeyDuration] = fmt.Sprintf("%f", node.FinishedAt.Sub(node.StartedAt.Time).Seconds())
 	}
 	var involvedObject runtime.Object = woc.wf
 	if eventConfig.SendAsPod {
@@ -2788,12 +3334,60 @@ func (woc *wfOperationCtx) recordNodePhaseEvent(node *wfv1.NodeStatus) {
 	woc.eventRecorder.AnnotatedEventf(
 		involvedObject,
 		annotations,
-		eventType,
-		fmt.Sprintf("WorkflowNode%s", node.Phase),
+		eventConfig.GetEventType(reason, eventType),
+		reason,
 		message,
 	)
 }
 
+var retryAttemptRegex = regexp.MustCompile(`\((\d+)\)$`)
+
+// retryAttempt returns the attempt of the node, if it is a child of a retry node
+func retryAttempt(node *wfv1.NodeStatus) (int, bool) {
+	if node.NodeFlag == nil || !node.NodeFlag.Retried {
+		return 0, false
+	}
+	match := retryAttemptRegex.FindStringSubmatch(node.Name)
+	if match == nil {
+		return 0, false
+	}
+	attempt, err := strconv.Atoi(match[1])
+	return attempt, err == nil
+}
+
+// workflowEventAnnotations returns the annotations of the events of the workflow and its nodes, which identify what
+// the workflow was created from
+func (woc *wfOperationCtx) workflowEventAnnotations() map[string]string {
+	annotations := map[string]string{}
+	for _, key := range []string{common.LabelKeyWorkflowTemplate, common.LabelKeyClusterWorkflowTemplate, common.LabelKeyCronWorkflow} {
+		if value, ok := woc.wf.Labels[key]; ok {
+			annotations[key] = value
+		}
+	}
+	// notifications are routed to the owner by these
+	if owner := woc.execWf.Spec.Owner; owner != nil {
+		for key, value := range map[string]string{
+			common.AnnotationKeyOwnerTeam:         owner.Team,
+			common.AnnotationKeyOwnerEmail:        owner.Email,
+			common.AnnotationKeyOwnerSlackChannel: owner.SlackChannel,
+		} {
+			if value != "" {
+				annotations[key] = value
+			}
+		}
+	}
+	return annotations
+}
+
+// recordWorkflowPhaseEvent emits the event of the workflow changing to the phase
+func (woc *wfOperationCtx) recordWorkflowPhaseEvent(eventType, reason, message string) {
+	annotations := woc.workflowEventAnnotations()
+	if woc.wf.Status.Phase.Completed() && !woc.wf.Status.StartedAt.IsZero() {
+		annotations[common.AnnotationKeyDuration] = fmt.Sprintf("%f", time.Since(woc.wf.Status.StartedAt.Time).Seconds())
+	}
+	woc.eventRecorder.AnnotatedEventf(woc.wf, annotations, woc.controller.Config.WorkflowEvents.GetEventType(reason, eventType), reason, "%s", message)
+}
+
 // recordNodePhaseChangeEvents creates WorkflowNode Kubernetes events for each node
 // that has changes logged during this execution of the operator loop.
 func (woc *wfOperationCtx) recordNodePhaseChangeEvents(old wfv1.Nodes, new wfv1.Nodes) {
@@ -3185,6 +3779,13 @@ func (woc *wfOperationCtx) executeScript(ctx context.Context, nodeName string, t
 		return node, err
 	}
 
+	if opts.patched && !node.Patched {
+		woc.log.WithField("nodeName", nodeName).Info("Running patched script")
+		node.Patched = true
+		woc.wf.Status.Nodes.Set(node.ID, *node)
+		woc.updated = true
+	}
+
 	mainCtr := tmpl.Script.Container
 	if len(tmpl.Script.Source) == 0 {
 		woc.log.Warn("'script.source' is empty, suggest change template into 'container'")
@@ -3208,6 +3809,10 @@ func (woc *wfOperationCtx) requeueIfTransientErr(err error, nodeName string) (*w
 		woc.requeue()
 		return woc.markNodePending(nodeName, err), nil
 	}
+	if closed, ok := err.(schedulingWindowClosedError); ok {
+		woc.requeueAfter(time.Until(closed.opensAt))
+		return woc.markNodePending(nodeName, err), nil
+	}
 	return nil, err
 }
 
@@ -3566,9 +4171,21 @@ func (woc *wfOperationCtx) executeSuspend(nodeName string, templateScope string,
 	if err != nil {
 		node = woc.initializeExecutableNode(nodeName, wfv1.NodeTypeSuspend, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending, opts.nodeFlag)
 		woc.resolveInputFieldsForSuspendNode(node)
+		woc.resolveConfigMapOutputsForSuspendNode(node)
+	} else if suspendConfigMapRefreshPeriod > 0 {
+		woc.resolveConfigMapOutputsForSuspendNode(node)
 	}
 	woc.log.Infof("node %s suspended", nodeName)
 
+	// label the workflow, so that events only list the workflows that may have a node that they resume
+	if tmpl.Suspend.GetEventSelector() != "" && woc.wf.Labels[common.LabelKeyAwaitingEvent] != "true" {
+		if woc.wf.Labels == nil {
+			woc.wf.Labels = make(map[string]string)
+		}
+		woc.wf.Labels[common.LabelKeyAwaitingEvent] = "true"
+		woc.updated = true
+	}
+
 	// If there is either an active workflow deadline, or if this node is suspended with a duration, then the workflow
 	// will need to be requeued after a certain amount of time
 	var requeueTime *time.Time
@@ -3604,6 +4221,14 @@ func (woc *wfOperationCtx) executeSuspend(nodeName string, templateScope string,
 		}
 	}
 
+	// output parameters read from ConfigMaps are read again until the node is resumed
+	if suspendConfigMapRefreshPeriod > 0 && hasConfigMapOutputs(node) {
+		refreshTime := time.Now().Add(suspendConfigMapRefreshPeriod)
+		if requeueTime == nil || refreshTime.Before(*requeueTime) {
+			requeueTime = &refreshTime
+		}
+	}
+
 	if requeueTime != nil {
 		woc.requeueAfter(time.Until(*requeueTime))
 	}
@@ -3639,12 +4264,58 @@ func (woc *wfOperationCtx) resolveInputFieldsForSuspendNode(node *wfv1.NodeStatu
 	}
 }
 
+// resolveConfigMapOutputsForSuspendNode sets the output parameters of the node that are read from ConfigMaps to the
+// values that the ConfigMaps have now. Parameters that cannot be read keep their values, or their defaults if they
+// have never been read, as the ConfigMaps may be fixed before the node is resumed.
+func (woc *wfOperationCtx) resolveConfigMapOutputsForSuspendNode(node *wfv1.NodeStatus) {
+	if !hasConfigMapOutputs(node) {
+		return
+	}
+	updated := false
+	for i, param := range node.Outputs.Parameters {
+		if param.ValueFrom == nil || param.ValueFrom.ConfigMapKeyRef == nil {
+			continue
+		}
+		ref := param.ValueFrom.ConfigMapKeyRef
+		value, err := common.GetConfigMapValue(woc.controller.configMapInformer.GetIndexer(), woc.wf.Namespace, ref.Name, ref.Key)
+		if err != nil {
+			woc.log.WithError(err).WithField("nodeID", node.ID).WithField("parameter", param.Name).Warn("Failed to read output parameter of suspended node from ConfigMap")
+			if param.Value == nil && param.ValueFrom.Default != nil {
+				node.Outputs.Parameters[i].Value = param.ValueFrom.Default
+				updated = true
+			}
+			continue
+		}
+		if param.Value == nil || param.Value.String() != value {
+			node.Outputs.Parameters[i].Value = wfv1.AnyStringPtr(value)
+			updated = true
+		}
+	}
+	if updated {
+		woc.wf.Status.Nodes.Set(node.ID, *node)
+		woc.updated = true
+	}
+}
+
+// hasConfigMapOutputs returns whether any of the output parameters of the node are read from ConfigMaps
+func hasConfigMapOutputs(node *wfv1.NodeStatus) bool {
+	if node.Outputs == nil {
+		return false
+	}
+	for _, param := range node.Outputs.Parameters {
+		if param.ValueFrom != nil && param.ValueFrom.ConfigMapKeyRef != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func addRawOutputFields(node *wfv1.NodeStatus, tmpl *wfv1.Template) *wfv1.NodeStatus {
 	if tmpl.GetType() != wfv1.TemplateTypeSuspend || node.Type != wfv1.NodeTypeSuspend {
 		panic("addRawOutputFields should only be used for nodes and templates of type suspend")
 	}
 	for _, param := range tmpl.Outputs.Parameters {
-		if param.ValueFrom.Supplied != nil {
+		if param.ValueFrom != nil && (param.ValueFrom.Supplied != nil || param.ValueFrom.ConfigMapKeyRef != nil) {
 			if node.Outputs == nil {
 				node.Outputs = &wfv1.Outputs{Parameters: []wfv1.Parameter{}}
 			}
@@ -4039,6 +4710,10 @@ func (woc *wfOperationCtx) retryStrategy(tmpl *wfv1.Template) *wfv1.RetryStrateg
 	if tmpl != nil && tmpl.RetryStrategy != nil {
 		return tmpl.RetryStrategy
 	}
+	if woc.execWf.Spec.RetryStrategy == nil && tmpl != nil && tmpl.PreemptionPolicy == wfv1.PreemptionPolicyCheckpoint {
+		// a node that can be preempted is restarted by a retry node, which does not retry it otherwise
+		return &wfv1.RetryStrategy{Limit: ptr.To(apiintstr.FromInt32(0))}
+	}
 	return woc.execWf.Spec.RetryStrategy
 }
 
@@ -4070,20 +4745,41 @@ func (woc *wfOperationCtx) setExecWorkflow(ctx context.Context) error {
 		wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(woc.controller.wfclientset.ArgoprojV1alpha1().WorkflowTemplates(woc.wf.Namespace))
 		cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(woc.controller.wfclientset.ArgoprojV1alpha1().ClusterWorkflowTemplates())
 
-		// Validate the execution wfSpec
-		err := waitutil.Backoff(retry.DefaultRetry,
-			func() (bool, error) {
-				validationErr := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, woc.wf, woc.controller.Config.WorkflowDefaults, validateOpts)
-				if validationErr != nil {
-					return !errorsutil.IsTransientErr(validationErr), validationErr
-				}
-				return true, nil
-			})
+		wfDefaults, err := woc.controller.getWorkflowDefaults(woc.wf.Namespace)
 		if err != nil {
-			msg := fmt.Sprintf("invalid spec: %s", err.Error())
-			woc.markWorkflowFailed(ctx, msg)
+			woc.markWorkflowError(ctx, err)
 			return err
 		}
+
+		// Validate the execution wfSpec, unless the same workflow was validated against the same versions of its
+		// workflow template and the templates that it references
+		compileKey, cacheable := woc.templateCompileKey(wfDefaults)
+		validated := cacheable && woc.isTemplateCompileCached(compileKey)
+		if !validated {
+			resolved := resolvedTemplates{}
+			if cacheable {
+				wftmplGetter = recordingWorkflowTemplateGetter{wftmplGetter, resolved}
+				cwftmplGetter = recordingClusterWorkflowTemplateGetter{cwftmplGetter, resolved}
+			}
+			err = waitutil.Backoff(retry.DefaultRetry,
+				func() (bool, error) {
+					validationErr := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, woc.wf, wfDefaults, validateOpts)
+					if validationErr != nil {
+						return !errorsutil.IsTransientErr(validationErr), validationErr
+					}
+					return true, nil
+				})
+			if err != nil {
+				msg := fmt.Sprintf("invalid spec: %s", err.Error())
+				woc.markWorkflowFailed(ctx, msg)
+				return err
+			}
+			if cacheable {
+				woc.controller.templateCompileCache.Add(compileKey, resolved)
+			}
+		} else {
+			woc.log.Debug("Workflow template validation cache hit")
+		}
 	}
 	err := woc.setGlobalParameters(woc.execWf.Spec.Arguments)
 	if err != nil {
@@ -4137,7 +4833,10 @@ func (woc *wfOperationCtx) needsStoredWfSpecUpdate() bool {
 }
 
 func (woc *wfOperationCtx) setStoredWfSpec(ctx context.Context) error {
-	wfDefault := woc.controller.Config.WorkflowDefaults
+	wfDefault, err := woc.controller.getWorkflowDefaults(woc.wf.Namespace)
+	if err != nil {
+		return err
+	}
 	if wfDefault == nil {
 		wfDefault = &wfv1.Workflow{}
 	}
@@ -4230,11 +4929,10 @@ func (woc *wfOperationCtx) substituteGlobalVariables(params common.Parameters) e
 	return nil
 }
 
-// getPodName gets the appropriate pod name for a workflow based on the
-// POD_NAMES environment variable
+// getPodName gets the appropriate pod name for a workflow based on its
+// pod name template, or the POD_NAMES environment variable
 func (woc *wfOperationCtx) getPodName(nodeName, templateName string) string {
-	version := wfutil.GetWorkflowPodNameVersion(woc.wf)
-	return wfutil.GeneratePodName(woc.wf.Name, nodeName, templateName, woc.wf.NodeID(nodeName), version)
+	return wfutil.GenerateWorkflowPodName(woc.wf, nodeName, templateName, woc.wf.NodeID(nodeName))
 }
 
 func (woc *wfOperationCtx) getServiceAccountTokenName(ctx context.Context, name string) (string, error) {
@@ -4249,8 +4947,9 @@ func (woc *wfOperationCtx) getServiceAccountTokenName(ctx context.Context, name
 }
 
 // setWfPodNamesAnnotation sets an annotation on a workflow with the pod naming
-// convention version
-func setWfPodNamesAnnotation(wf *wfv1.Workflow) {
+// convention version, and the pod name template of the controller unless the
+// workflow has its own
+func setWfPodNamesAnnotation(wf *wfv1.Workflow, podNameTemplate string) {
 	podNameVersion := wfutil.GetPodNameVersion()
 
 	if wf.Annotations == nil {
@@ -4258,6 +4957,9 @@ func setWfPodNamesAnnotation(wf *wfv1.Workflow) {
 	}
 
 	wf.Annotations[common.AnnotationKeyPodNameVersion] = podNameVersion.String()
+	if _, ok := wf.Annotations[common.AnnotationKeyPodNameTemplate]; !ok && podNameTemplate != "" {
+		wf.Annotations[common.AnnotationKeyPodNameTemplate] = podNameTemplate
+	}
 }
 
 // getChildNodeIdsAndLastRetriedNode returns child node ids and last retried node, which are marked as `NodeStatus.NodeFlag.Retried=true`.
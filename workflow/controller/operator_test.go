@@ -23,8 +23,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	batchfake "k8s.io/client-go/kubernetes/typed/batch/v1/fake"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	corefake "k8s.io/client-go/kubernetes/typed/core/v1/fake"
 	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
@@ -33,6 +35,8 @@ import (
 	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/expr/argoexpr"
+	"github.com/argoproj/argo-workflows/v3/util/expr/env"
 	intstrutil "github.com/argoproj/argo-workflows/v3/util/intstr"
 	"github.com/argoproj/argo-workflows/v3/util/strftime"
 	"github.com/argoproj/argo-workflows/v3/util/template"
@@ -383,6 +387,25 @@ func TestGlobalParams(t *testing.T) {
 	assert.Contains(t, woc.globalParams, "workflow.labels.workflows.argoproj.io/phase")
 }
 
+func TestWorkflowOwner(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	wf.Spec.Owner = &wfv1.WorkflowOwner{Team: "ml-platform", SlackChannel: "#ml-platform-alerts"}
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, "ml-platform", woc.globalParams["workflow.owner.team"])
+	assert.Equal(t, "#ml-platform-alerts", woc.globalParams["workflow.owner.slackChannel"])
+	assert.Contains(t, woc.globalParams, "workflow.owner.email")
+
+	annotations := woc.workflowEventAnnotations()
+	assert.Equal(t, "ml-platform", annotations[common.AnnotationKeyOwnerTeam])
+	assert.Equal(t, "#ml-platform-alerts", annotations[common.AnnotationKeyOwnerSlackChannel])
+	assert.NotContains(t, annotations, common.AnnotationKeyOwnerEmail)
+}
+
 // TestSidecarWithVolume verifies ia sidecar can have a volumeMount reference to both existing or volumeClaimTemplate volumes
 func TestSidecarWithVolume(t *testing.T) {
 	wf := wfv1.MustUnmarshalWorkflow(sidecarWithVol)
@@ -890,6 +913,76 @@ func TestProcessNodeRetriesWithExponentialBackoff(t *testing.T) {
 	require.Equal(wfv1.NodeSucceeded, n.Phase)
 }
 
+func TestBackoffJitter(t *testing.T) {
+	backoff := 10 * time.Minute
+	a := backoffJitter("my-wf-1", 20, backoff)
+	b := backoffJitter("my-wf-2", 20, backoff)
+	assert.NotEqual(t, a, b, "the jitter is different for different nodes")
+	assert.Equal(t, a, backoffJitter("my-wf-1", 20, backoff), "the jitter is the same for each reconciliation of a node")
+	for _, jitter := range []time.Duration{a, b} {
+		assert.GreaterOrEqual(t, jitter, time.Duration(0))
+		assert.LessOrEqual(t, jitter, 2*time.Minute)
+	}
+	assert.LessOrEqual(t, backoffJitter("my-wf-1", 200, backoff), backoff, "the jitter is at most the backoff")
+	assert.Equal(t, time.Duration(0), backoffJitter("my-wf-1", 0, backoff))
+}
+
+func TestProcessNodeRetriesWithJitter(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	woc := newWorkflowOperationCtx(wf, controller)
+
+	nodeName := "test-node"
+	node := woc.initializeNode(nodeName, wfv1.NodeTypeRetry, "", &wfv1.WorkflowStep{}, "", wfv1.NodeRunning, &wfv1.NodeFlag{})
+	retries := wfv1.RetryStrategy{
+		Limit:       intstrutil.ParsePtr("3"),
+		RetryPolicy: wfv1.RetryPolicyAlways,
+		Backoff: &wfv1.Backoff{
+			Duration: "10m",
+			Jitter:   intstrutil.ParsePtr("50"),
+		},
+	}
+	woc.wf.Status.Nodes[node.ID] = *node
+	child := woc.initializeNode(nodeName+"(0)", wfv1.NodeTypePod, "", &wfv1.WorkflowStep{}, "", wfv1.NodeFailed, &wfv1.NodeFlag{Retried: true})
+	woc.addChildNode(nodeName, nodeName+"(0)")
+
+	n, err := woc.wf.GetNodeByName(nodeName)
+	require.NoError(t, err)
+	n, _, err = woc.processNodeRetries(n, retries, &executeTemplateOpts{})
+	require.NoError(t, err)
+	require.Equal(t, wfv1.NodeRunning, n.Phase)
+
+	// the backoff is between 5 and 10 minutes, depending on the node
+	expected := 10*time.Minute - backoffJitter(child.ID, 50, 10*time.Minute)
+	backoff, err := parseRetryMessage(n.Message)
+	require.NoError(t, err)
+	assert.InDelta(t, expected.Seconds(), backoff, 1)
+	assert.GreaterOrEqual(t, backoff, 300)
+}
+
+func TestApplyMaxAttemptDuration(t *testing.T) {
+	t.Run("NotSet", func(t *testing.T) {
+		opts := &executeTemplateOpts{}
+		require.NoError(t, applyMaxAttemptDuration(&wfv1.Backoff{Duration: "1m"}, opts))
+		assert.True(t, opts.executionDeadline.IsZero())
+	})
+	t.Run("Set", func(t *testing.T) {
+		opts := &executeTemplateOpts{}
+		require.NoError(t, applyMaxAttemptDuration(&wfv1.Backoff{MaxAttemptDuration: "10m"}, opts))
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), opts.executionDeadline, time.Minute)
+	})
+	t.Run("MaxDurationIsSooner", func(t *testing.T) {
+		maxDurationDeadline := time.Now().Add(time.Minute)
+		opts := &executeTemplateOpts{executionDeadline: maxDurationDeadline}
+		require.NoError(t, applyMaxAttemptDuration(&wfv1.Backoff{MaxAttemptDuration: "10m"}, opts))
+		assert.Equal(t, maxDurationDeadline, opts.executionDeadline)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		require.Error(t, applyMaxAttemptDuration(&wfv1.Backoff{MaxAttemptDuration: "later"}, &executeTemplateOpts{}))
+	})
+}
+
 // TestProcessNodeRetries tests retrying with Expression
 func TestProcessNodeRetriesWithExpression(t *testing.T) {
 	cancel, controller := newController()
@@ -1882,6 +1975,125 @@ func TestAssessNodeStatus(t *testing.T) {
 	}
 }
 
+func TestAssessNodeStatusProvenance(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	pod := &apiv1.Pod{
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{Name: common.WaitContainerName, Env: []apiv1.EnvVar{{Name: "WAIT_ONLY"}}},
+				{Name: common.MainContainerName, Env: []apiv1.EnvVar{{Name: "USER"}, {Name: common.EnvVarContainerName}, {Name: "HOME"}}},
+				{Name: "sidecar", Env: []apiv1.EnvVar{{Name: "HOME"}}},
+			},
+		},
+		Status: apiv1.PodStatus{
+			Phase: apiv1.PodSucceeded,
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{Name: common.WaitContainerName, ImageID: "quay.io/argoproj/argoexec@sha256:1"},
+				{Name: common.MainContainerName, ImageID: "docker.io/library/python@sha256:2"},
+				{Name: "sidecar", ImageID: "docker.io/library/nginx@sha256:3"},
+			},
+		},
+	}
+	cancel, controller := newController()
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+
+	t.Run("Running", func(t *testing.T) {
+		running := pod.DeepCopy()
+		running.Status.Phase = apiv1.PodRunning
+		got := woc.assessNodeStatus(context.TODO(), running, &wfv1.NodeStatus{TemplateName: "whalesay"})
+		assert.Nil(t, got.Provenance)
+	})
+	t.Run("Completed", func(t *testing.T) {
+		node := &wfv1.NodeStatus{TemplateName: "whalesay", Provenance: &wfv1.NodeProvenance{ExecutorVersion: "v3.7.0"}}
+		got := woc.assessNodeStatus(context.TODO(), pod, node)
+		require.NotNil(t, got.Provenance)
+		assert.Equal(t, map[string]string{
+			common.MainContainerName: "docker.io/library/python@sha256:2",
+			"sidecar":                "docker.io/library/nginx@sha256:3",
+		}, got.Provenance.ImageIDs)
+		assert.Equal(t, []string{"HOME", "USER"}, got.Provenance.EnvVarNames)
+		assert.Equal(t, "v3.7.0", got.Provenance.ExecutorVersion)
+	})
+}
+
+func TestGetSchedulingLatency(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) metav1.Time {
+		return metav1.NewTime(created.Add(time.Duration(seconds) * time.Second))
+	}
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(1)},
+		Status: apiv1.PodStatus{
+			Conditions: []apiv1.PodCondition{
+				{Type: apiv1.PodScheduled, Status: apiv1.ConditionTrue, LastTransitionTime: at(10)},
+				{Type: apiv1.PodInitialized, Status: apiv1.ConditionTrue, LastTransitionTime: at(25)},
+			},
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{Name: common.WaitContainerName, State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{StartedAt: at(26)}}},
+				{Name: common.MainContainerName, State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{StartedAt: at(40)}}},
+			},
+		},
+	}
+	seconds := func(d *metav1.Duration) int { return int(d.Seconds()) }
+
+	t.Run("NotStarted", func(t *testing.T) {
+		waiting := pod.DeepCopy()
+		waiting.Status.ContainerStatuses[1].State = apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+		assert.Nil(t, getSchedulingLatency(waiting, created))
+	})
+	t.Run("Started", func(t *testing.T) {
+		latency := getSchedulingLatency(pod, created)
+		require.NotNil(t, latency)
+		assert.Equal(t, 10, seconds(latency.Scheduling))
+		assert.Equal(t, 15, seconds(latency.Initializing))
+		assert.Equal(t, 15, seconds(latency.Starting))
+		assert.Equal(t, 40*time.Second, latency.Total())
+	})
+	t.Run("Terminated", func(t *testing.T) {
+		terminated := pod.DeepCopy()
+		terminated.Status.ContainerStatuses[1].State = apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{StartedAt: at(40), FinishedAt: at(50)}}
+		latency := getSchedulingLatency(terminated, created)
+		require.NotNil(t, latency)
+		assert.Equal(t, 15, seconds(latency.Starting))
+	})
+	t.Run("PodCreationTimestamp", func(t *testing.T) {
+		latency := getSchedulingLatency(pod, time.Time{})
+		require.NotNil(t, latency)
+		assert.Equal(t, 9, seconds(latency.Scheduling))
+	})
+	t.Run("ClockSkew", func(t *testing.T) {
+		latency := getSchedulingLatency(pod, created.Add(time.Minute))
+		require.NotNil(t, latency)
+		assert.Equal(t, 0, seconds(latency.Scheduling))
+		assert.Equal(t, 0, seconds(latency.Initializing))
+		assert.Equal(t, 0, seconds(latency.Starting))
+	})
+}
+
+func TestAssessNodeStatusSchedulingLatency(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	cancel, controller := newController()
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	started := metav1.Now()
+	pod := &apiv1.Pod{
+		Status: apiv1.PodStatus{
+			Phase: apiv1.PodRunning,
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{Name: common.MainContainerName, State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{StartedAt: started}}},
+			},
+		},
+	}
+	node := &wfv1.NodeStatus{TemplateName: "whalesay", StartedAt: metav1.NewTime(started.Add(-time.Minute))}
+	got := woc.assessNodeStatus(context.TODO(), pod, node)
+	require.NotNil(t, got.SchedulingLatency)
+	assert.Equal(t, time.Minute, got.SchedulingLatency.Total())
+
+	// the latency is only recorded once, so the node is unchanged afterwards
+	assert.Nil(t, woc.assessNodeStatus(context.TODO(), pod, got))
+}
+
 func getPodTemplate(pod *apiv1.Pod) (*wfv1.Template, error) {
 	tmpl := &wfv1.Template{}
 	for _, c := range pod.Spec.InitContainers {
@@ -2516,6 +2728,69 @@ func TestSuspendInputsResolution(t *testing.T) {
 	assert.Equal(t, "value2", node.Inputs.Parameters[1].Value.String())
 }
 
+var suspendTemplateConfigMapOutputs = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: suspend-template
+  namespace: default
+spec:
+  entrypoint: suspend
+  templates:
+  - name: suspend
+    suspend: {}
+    outputs:
+      parameters:
+      - name: version
+        valueFrom:
+          configMapKeyRef:
+            name: release
+            key: version
+      - name: region
+        valueFrom:
+          default: us-east-1
+          configMapKeyRef:
+            name: release
+            key: region
+`
+
+func TestSuspendConfigMapOutputs(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	cm := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "release",
+			Namespace: "default",
+			Labels:    map[string]string{common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapParameter},
+		},
+		Data: map[string]string{"version": "v1"},
+	}
+	require.NoError(t, controller.configMapInformer.GetIndexer().Add(cm))
+
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(suspendTemplateConfigMapOutputs)
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	node := woc.wf.Status.Nodes.FindByDisplayName("suspend-template")
+	require.NotNil(t, node)
+	assert.Equal(t, wfv1.NodeRunning, node.Phase)
+	require.Len(t, node.Outputs.Parameters, 2)
+	assert.Equal(t, "v1", node.Outputs.Parameters[0].Value.String())
+	assert.Equal(t, "us-east-1", node.Outputs.Parameters[1].Value.String())
+
+	// the ConfigMap is fixed whilst the node is suspended
+	cm = cm.DeepCopy()
+	cm.Data = map[string]string{"version": "v2", "region": "eu-west-1"}
+	require.NoError(t, controller.configMapInformer.GetIndexer().Update(cm))
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	node = woc.wf.Status.Nodes.FindByDisplayName("suspend-template")
+	require.NotNil(t, node)
+	assert.Equal(t, wfv1.NodeRunning, node.Phase)
+	assert.Equal(t, "v2", node.Outputs.Parameters[0].Value.String())
+	assert.Equal(t, "eu-west-1", node.Outputs.Parameters[1].Value.String())
+}
+
 var sequence = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -2952,6 +3227,24 @@ func TestSuspendResumeAfterTemplate(t *testing.T) {
 	assert.Len(t, pods.Items, 1)
 }
 
+func TestSuspendEventTemplateLabel(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	wfcset := controller.wfclientset.ArgoprojV1alpha1().Workflows("")
+
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(suspendResumeAfterTemplate)
+	wf.Spec.Templates[1].Suspend = &wfv1.SuspendTemplate{Event: &wfv1.SuspendEvent{Selector: "payload.approved"}}
+	wf, err := wfcset.Create(ctx, wf, metav1.CreateOptions{})
+	require.NoError(t, err)
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	wf, err = wfcset.Get(ctx, wf.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, util.IsWorkflowSuspended(wf))
+	assert.Equal(t, "true", wf.Labels[common.LabelKeyAwaitingEvent])
+}
+
 func TestSuspendResumeAfterTemplateNoWait(t *testing.T) {
 	cancel, controller := newController()
 	defer cancel()
@@ -4303,6 +4596,60 @@ spec:
 	}
 }
 
+func TestEventAnnotations(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: event-annotations
+  labels:
+    workflows.argoproj.io/workflow-template: my-wftmpl
+spec:
+  entrypoint: whalesay
+  templates:
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+`)
+	ctx := context.Background()
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.Config.NodeEvents = config.NodeEvents{EventTypes: map[string]string{"WorkflowNodeSucceeded": apiv1.EventTypeWarning}}
+	woc := newWorkflowOperationCtx(wf, controller)
+	createRunningPods(ctx, woc)
+	woc.operate(ctx)
+	// the node finishes when its containers do
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded, func(pod *apiv1.Pod, _ *wfOperationCtx) {
+		pod.Status.ContainerStatuses = []apiv1.ContainerStatus{{
+			Name:  common.MainContainerName,
+			State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{FinishedAt: metav1.Now()}},
+		}}
+	})
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	c := controller.eventRecorderManager.(*testEventRecorderManager).eventRecorder.Events
+	events := map[string]string{}
+	for i := 0; i < 4; i++ {
+		event := <-c
+		events[strings.Fields(event)[1]] = event
+	}
+	for _, reason := range []string{"WorkflowRunning", "WorkflowNodeRunning", "WorkflowNodeSucceeded", "WorkflowSucceeded"} {
+		assert.Contains(t, events[reason], "workflows.argoproj.io/workflow-template:my-wftmpl", reason)
+	}
+	assert.Contains(t, events["WorkflowNodeRunning"], "workflows.argoproj.io/node-template-name:whalesay")
+	assert.NotContains(t, events["WorkflowNodeRunning"], "workflows.argoproj.io/duration:")
+	assert.True(t, strings.HasPrefix(events["WorkflowNodeSucceeded"], "Warning WorkflowNodeSucceeded"), "the type of the event is overridden")
+	assert.Contains(t, events["WorkflowNodeSucceeded"], "workflows.argoproj.io/duration:")
+	assert.Contains(t, events["WorkflowSucceeded"], "workflows.argoproj.io/duration:")
+}
+
+func TestRetryAttempt(t *testing.T) {
+	_, ok := retryAttempt(&wfv1.NodeStatus{Name: "my-wf(0)"})
+	assert.False(t, ok, "nodes that are not retried have no attempt")
+	attempt, ok := retryAttempt(&wfv1.NodeStatus{Name: "my-wf[0].step(2)", NodeFlag: &wfv1.NodeFlag{Retried: true}})
+	assert.True(t, ok)
+	assert.Equal(t, 2, attempt)
+}
+
 func getEventsWithoutAnnotations(controller *WorkflowController, num int) []string {
 	c := controller.eventRecorderManager.(*testEventRecorderManager).eventRecorder.Events
 	events := make([]string, num)
@@ -6933,6 +7280,63 @@ func TestFailSuspendedAndPendingNodesAfterShutdown(t *testing.T) {
 	})
 }
 
+var drainWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: drain
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: a
+        template: whalesay
+      - name: b
+        template: whalesay
+        dependencies: [a]
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+`
+
+func TestDrainShutdownStrategy(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(drainWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodRunning)
+
+	// the running task is left to finish
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.wf.Spec.Shutdown = wfv1.ShutdownStrategyDrain
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	a, err := woc.wf.GetNodeByName("drain.a")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeRunning, a.Phase)
+
+	// the next task is not started
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+	a, err = woc.wf.GetNodeByName("drain.a")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeSucceeded, a.Phase)
+	b, err := woc.wf.GetNodeByName("drain.b")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeFailed, b.Phase)
+	assert.Equal(t, "workflow shutdown with strategy: Drain", b.Message)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+}
+
 func Test_processItem(t *testing.T) {
 	task := wfv1.DAGTask{
 		WithParam: `[{"number": 2, "string": "foo", "list": [0, "1"], "json": {"number": 2, "string": "foo", "list": [0, "1"]}}]`,
@@ -7171,6 +7575,54 @@ func TestPodFailureWithContainerWaitingState(t *testing.T) {
 	assert.Equal(t, "Pod failed before main container starts due to ContainerCreating: Container is creating", msg)
 }
 
+func TestPodFailureWithImagePullFailure(t *testing.T) {
+	pod := &apiv1.Pod{Status: apiv1.PodStatus{
+		Phase: apiv1.PodFailed,
+		InitContainerStatuses: []apiv1.ContainerStatus{{
+			Name:  common.InitContainerName,
+			State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "registry unavailable"}},
+		}},
+	}}
+	nodeStatus, msg := newWoc().inferFailedReason(pod, nil)
+	assert.Equal(t, wfv1.NodeError, nodeStatus)
+	assert.Equal(t, "Pod failed before init container starts, as its image could not be pulled: ErrImagePull: registry unavailable", msg)
+}
+
+func TestPodFailureWithInitContainerFailure(t *testing.T) {
+	pod := &apiv1.Pod{Status: apiv1.PodStatus{
+		Phase: apiv1.PodFailed,
+		InitContainerStatuses: []apiv1.ContainerStatus{{
+			Name:  common.InitContainerName,
+			State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, Message: "artifact my-art failed to load"}},
+		}},
+	}}
+	nodeStatus, msg := newWoc().inferFailedReason(pod, nil)
+	assert.Equal(t, wfv1.NodeError, nodeStatus)
+	assert.Equal(t, "Failed to load input artifacts: init: Error (exit code 1): artifact my-art failed to load", msg)
+}
+
+func TestGetPendingReason(t *testing.T) {
+	initializing := apiv1.ContainerStatus{Name: "main", State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "PodInitializing"}}}
+	t.Run("Initializing", func(t *testing.T) {
+		pod := &apiv1.Pod{Status: apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{initializing}}}
+		assert.Equal(t, "PodInitializing", getPendingReason(pod))
+	})
+	t.Run("InitContainerImagePullBackOff", func(t *testing.T) {
+		pod := &apiv1.Pod{Status: apiv1.PodStatus{
+			InitContainerStatuses: []apiv1.ContainerStatus{{
+				Name:  common.InitContainerName,
+				State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: `Back-off pulling image "argoexec"`}},
+			}},
+			ContainerStatuses: []apiv1.ContainerStatus{initializing},
+		}}
+		assert.Equal(t, `ImagePullBackOff: Back-off pulling image "argoexec"`, getPendingReason(pod))
+	})
+	t.Run("Unschedulable", func(t *testing.T) {
+		pod := &apiv1.Pod{Status: apiv1.PodStatus{Conditions: []apiv1.PodCondition{{Reason: apiv1.PodReasonUnschedulable, Message: "0/3 nodes are available"}}}}
+		assert.Equal(t, "Unschedulable: 0/3 nodes are available", getPendingReason(pod))
+	})
+}
+
 var podWithWaitContainerOOM = `
 apiVersion: v1
 kind: Pod
@@ -8523,6 +8975,102 @@ func TestSubstituteGlobalVariablesLabelsAnnotations(t *testing.T) {
 	}
 }
 
+var workflowMetadataPropagateToPods = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: workflow-metadata
+  namespace: argo
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: team
+        value: platform
+  workflowMetadata:
+    labels:
+      app: my-app
+    labelsFrom:
+      team:
+        expression: workflow.parameters.team
+    annotationsFrom:
+      example.com/owner:
+        expression: "'team-' + workflow.parameters.team"
+    propagateToPods: true
+  templates:
+    - name: main
+      metadata:
+        labels:
+          app: my-pod
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestWorkflowMetadataAnnotationsFromAndPropagateToPods(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(workflowMetadataPropagateToPods)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	assert.Equal(t, "platform", woc.wf.Labels["team"])
+	assert.Equal(t, "team-platform", woc.wf.Annotations["example.com/owner"])
+
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	pod := pods.Items[0]
+	assert.Equal(t, "platform", pod.Labels["team"])
+	assert.Equal(t, "my-pod", pod.Labels["app"], "the template's labels are not overridden")
+	assert.Equal(t, "team-platform", pod.Annotations["example.com/owner"])
+}
+
+var patchedScript = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: patched-script
+  namespace: default
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    script:
+      image: python:alpine3.6
+      command: [python]
+      source: print('broken')
+status:
+  nodePatches:
+    patched-script:
+      image: python:3.12
+      source: print('patched')
+`
+
+func TestPatchedScript(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(patchedScript)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	node := woc.wf.Status.Nodes.FindByName("patched-script")
+	require.NotNil(t, node)
+	assert.True(t, node.Patched)
+
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	tmpl, err := getPodTemplate(&pods.Items[0])
+	require.NoError(t, err)
+	assert.Equal(t, "python:3.12", tmpl.Script.Image)
+	assert.Equal(t, "print('patched')", tmpl.Script.Source)
+	assert.Equal(t, "print('broken')", woc.execWf.Spec.Templates[0].Script.Source, "the workflow's template is not patched")
+}
+
 var wfPending = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -9099,14 +9647,40 @@ func TestBuildRetryStrategyLocalScope(t *testing.T) {
 	retryNode, err := wf.GetNodeByName("retry-script-9z9pv[1].retry")
 	require.NoError(t, err)
 
-	localScope := buildRetryStrategyLocalScope(retryNode, wf.Status.Nodes)
+	localScope := buildRetryStrategyLocalScope(retryNode, wf.Status.Nodes, config.Config{ExitCodeClasses: map[string][]int{"permanent": {2}}})
 
-	assert.Len(t, localScope, 5)
+	assert.Len(t, localScope, 7)
 	assert.Equal(t, "1", localScope[common.LocalVarRetries])
 	assert.Equal(t, "1", localScope[common.LocalVarRetriesLastExitCode])
+	assert.Empty(t, localScope[common.LocalVarRetriesLastExitCodeClass])
 	assert.Equal(t, string(wfv1.NodeFailed), localScope[common.LocalVarRetriesLastStatus])
 	assert.Equal(t, "6", localScope[common.LocalVarRetriesLastDuration])
 	assert.Equal(t, "Error (exit code 1)", localScope[common.LocalVarRetriesLastMessage])
+
+	attempts := localScope[common.LocalVarRetriesAttempts].([]map[string]interface{})
+	require.Len(t, attempts, 2)
+	assert.Equal(t, "2", attempts[0]["exitCode"])
+	assert.Equal(t, "permanent", attempts[0]["exitCodeClass"])
+	assert.Equal(t, "1", attempts[1]["exitCode"])
+}
+
+func TestBuildRetryStrategyLocalScopeOutputs(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(operatorRetryExpression)
+	retryNode, err := wf.GetNodeByName("retry-script-9z9pv[1].retry")
+	require.NoError(t, err)
+	lastRetry, err := wf.GetNodeByName("retry-script-9z9pv[1].retry(1)")
+	require.NoError(t, err)
+	lastRetry.Outputs.Result = ptr.To("quota exceeded")
+	lastRetry.Outputs.Parameters = []wfv1.Parameter{{Name: "reason", Value: wfv1.AnyStringPtr("quota")}}
+	wf.Status.Nodes.Set(lastRetry.ID, *lastRetry)
+
+	localScope := buildRetryStrategyLocalScope(retryNode, wf.Status.Nodes, config.Config{})
+	assert.Equal(t, "quota exceeded", localScope["lastRetry.outputs.result"])
+	assert.Equal(t, "quota", localScope["lastRetry.outputs.parameters.reason"])
+
+	shouldContinue, err := argoexpr.EvalBool(`lastRetry.outputs.parameters.reason != "quota" && len(filter(attempts, {#.exitCodeClass == "permanent"})) == 0`, env.GetFuncMap(localScope))
+	require.NoError(t, err)
+	assert.False(t, shouldContinue)
 }
 
 const operatorRetryExpressionError = `
@@ -11680,3 +12254,323 @@ func TestWithItemsOptionalArtDAGWF(t *testing.T) {
 	require.NotNil(t, node)
 	assert.Equal(t, wfv1.NodePending, node.Phase)
 }
+
+func TestConcurrentPodCreation(t *testing.T) {
+	defer func(workers int) { podCreationWorkers = workers }(podCreationWorkers)
+	podCreationWorkers = 4
+
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: wide-dag
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: fan-out
+            template: pod
+            withSequence:
+              count: "10"
+    - name: pod
+      container:
+        image: my-image
+`)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	assert.Empty(t, woc.podCreations)
+	pods, err := controller.kubeclientset.CoreV1().Pods("my-ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 10, "the pods are created by the time operate returns")
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypePod {
+			assert.Equal(t, wfv1.NodePending, node.Phase)
+		}
+	}
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+}
+
+// panickingPodsClientset is a clientset that panics when a pod is created
+type panickingPodsClientset struct{ kubernetes.Interface }
+
+func (c panickingPodsClientset) CoreV1() typedcorev1.CoreV1Interface {
+	return panickingPodsCoreV1{c.Interface.CoreV1()}
+}
+
+type panickingPodsCoreV1 struct{ typedcorev1.CoreV1Interface }
+
+func (c panickingPodsCoreV1) Pods(namespace string) typedcorev1.PodInterface {
+	return panickingPods{c.CoreV1Interface.Pods(namespace)}
+}
+
+type panickingPods struct{ typedcorev1.PodInterface }
+
+func (c panickingPods) Create(context.Context, *apiv1.Pod, metav1.CreateOptions) (*apiv1.Pod, error) {
+	panic("boom")
+}
+
+func TestConcurrentPodCreationPanic(t *testing.T) {
+	defer func(workers int) { podCreationWorkers = workers }(podCreationWorkers)
+	podCreationWorkers = 4
+
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: my-image
+`)
+	cancel, controller := newController(wf)
+	defer cancel()
+	clientset := controller.kubeclientset
+	controller.kubeclientset = panickingPodsClientset{clientset}
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	pods, err := clientset.CoreV1().Pods("my-ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items)
+	node := woc.wf.Status.Nodes.FindByDisplayName("my-wf")
+	require.NotNil(t, node)
+	assert.Equal(t, wfv1.NodeError, node.Phase)
+	assert.Contains(t, node.Message, "panic whilst creating pod my-wf: boom")
+}
+
+// slowPodsClientset is a clientset whose API server takes a millisecond to create a pod
+type slowPodsClientset struct{ kubernetes.Interface }
+
+func (c slowPodsClientset) CoreV1() typedcorev1.CoreV1Interface {
+	return slowPodsCoreV1{c.Interface.CoreV1()}
+}
+
+type slowPodsCoreV1 struct{ typedcorev1.CoreV1Interface }
+
+func (c slowPodsCoreV1) Pods(namespace string) typedcorev1.PodInterface {
+	return slowPods{c.CoreV1Interface.Pods(namespace)}
+}
+
+type slowPods struct{ typedcorev1.PodInterface }
+
+func (c slowPods) Create(ctx context.Context, pod *apiv1.Pod, opts metav1.CreateOptions) (*apiv1.Pod, error) {
+	time.Sleep(time.Millisecond)
+	return c.PodInterface.Create(ctx, pod, opts)
+}
+
+// BenchmarkCreatePendingPods creates the pods of a wide DAG, e.g.
+// go test ./workflow/controller -run '^$' -bench BenchmarkCreatePendingPods
+func BenchmarkCreatePendingPods(b *testing.B) {
+	for _, workers := range []int{1, 16} {
+		b.Run(fmt.Sprintf("Workers%d", workers), func(b *testing.B) {
+			defer func(workers int) { podCreationWorkers = workers }(podCreationWorkers)
+			podCreationWorkers = workers
+			woc := newWoc()
+			woc.controller.kubeclientset = slowPodsClientset{woc.controller.kubeclientset}
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 100; j++ {
+					name := fmt.Sprintf("pod-%d-%d", i, j)
+					woc.podCreations = append(woc.podCreations, podCreation{nodeName: name, pod: &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}})
+				}
+				woc.createPendingPods(ctx)
+			}
+		})
+	}
+}
+
+func TestMarkOutputsTruncated(t *testing.T) {
+	woc := newWoc()
+	woc.markOutputsTruncated("my-wf[0].a", []string{"result", "parameters.my-param"})
+	woc.markOutputsTruncated("my-wf[0].a", []string{"result", "parameters.my-param"})
+	woc.markOutputsTruncated("my-wf[0].b", []string{"logs.main"})
+	assert.True(t, woc.updated)
+	require.Len(t, woc.wf.Status.Conditions, 1)
+	condition := woc.wf.Status.Conditions[0]
+	assert.Equal(t, wfv1.ConditionTypeOutputsTruncated, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, `node "my-wf[0].a" truncated result, parameters.my-param, node "my-wf[0].b" truncated logs.main`, condition.Message)
+}
+
+var pendingTimeoutWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: pending-timeout
+spec:
+  entrypoint: main
+  pendingTimeout: 10m
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestPendingTimeout(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(pendingTimeoutWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodPending)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	assert.Nil(t, woc.getPendingTimeoutCondition(), "the pod has not been pending for long enough")
+
+	node := woc.wf.Status.Nodes.FindByDisplayName("pending-timeout")
+	require.NotNil(t, node)
+	node.StartedAt = metav1.NewTime(time.Now().Add(-time.Hour))
+	woc.wf.Status.Nodes.Set(node.ID, *node)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	condition := woc.getPendingTimeoutCondition()
+	require.NotNil(t, condition)
+	assert.Contains(t, condition.Message, "Pod of step pending-timeout was pending for longer than 10m0s")
+	assert.Equal(t, wfv1.NodeFailed, woc.wf.Status.Nodes.FindByDisplayName("pending-timeout").Phase)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+	assert.Equal(t, condition.Message, woc.wf.Status.Message)
+}
+
+var resourceBudgetWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: resource-budget
+spec:
+  entrypoint: main
+  resourceBudget:
+    limits:
+      cpu: 3600
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestResourceBudget(t *testing.T) {
+	// exceedBudget makes the pod of the workflow use two hours of CPU
+	exceedBudget := func(woc *wfOperationCtx) {
+		node := woc.wf.Status.Nodes.FindByDisplayName("resource-budget")
+		require.NotNil(t, node)
+		node.ResourcesDuration = wfv1.ResourcesDuration{apiv1.ResourceCPU: wfv1.NewResourceDuration(2 * time.Hour)}
+		woc.wf.Status.Nodes.Set(node.ID, *node)
+	}
+	ctx := context.Background()
+
+	t.Run("Terminate", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(resourceBudgetWf)
+		cancel, controller := newController(wf)
+		defer cancel()
+
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		makePodsPhase(ctx, woc, apiv1.PodPending)
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		assert.Nil(t, woc.getResourceBudgetExceededCondition(), "the pod has not used its budget yet")
+
+		exceedBudget(woc)
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		condition := woc.getResourceBudgetExceededCondition()
+		require.NotNil(t, condition)
+		assert.Equal(t, "Workflow used 2h0m0s of cpu, which exceeds its resource budget of 1h0m0s", condition.Message)
+		assert.Equal(t, wfv1.NodeFailed, woc.wf.Status.Nodes.FindByDisplayName("resource-budget").Phase)
+		assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+		assert.Equal(t, condition.Message, woc.wf.Status.Message)
+	})
+
+	t.Run("Suspend", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(resourceBudgetWf)
+		wf.Spec.ResourceBudget.Action = wfv1.ResourceBudgetActionSuspend
+		cancel, controller := newController(wf)
+		defer cancel()
+
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		makePodsPhase(ctx, woc, apiv1.PodPending)
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		exceedBudget(woc)
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		require.NotNil(t, woc.getResourceBudgetExceededCondition())
+		assert.True(t, util.IsWorkflowSuspended(woc.wf))
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+
+		// once it is resumed, the workflow is not suspended again
+		woc.wf.Spec.Suspend = nil
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		assert.False(t, util.IsWorkflowSuspended(woc.wf))
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	})
+}
+
+var suspendAfterWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: suspend-after
+  annotations:
+    workflows.argoproj.io/suspend-after: '["a"]'
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: a
+        template: run
+    - - name: b
+        template: run
+  - name: run
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestSuspendAfter(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(suspendAfterWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	assert.False(t, woc.ShouldSuspend(), "a has not completed yet")
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.True(t, woc.ShouldSuspend())
+	assert.NotContains(t, woc.wf.Annotations, common.AnnotationKeySuspendAfter)
+	assert.Nil(t, woc.wf.Status.Nodes.FindByDisplayName("b"), "b is not started once the workflow is suspended")
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+
+	woc.wf.Spec.Suspend = nil
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.False(t, woc.ShouldSuspend())
+	pods, err = listPods(woc)
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 2)
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"fmt"
+	"path"
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// isPreempted returns whether the Kubernetes node of the pod is being, or was, preempted, e.g. by the scheduler, the
+// taint manager, or a graceful shutdown of the node when its spot instance is reclaimed
+func isPreempted(pod *apiv1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == apiv1.DisruptionTarget && c.Status == apiv1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPreemption signals the containers of a pod that is about to be preempted, once, so that the main container can
+// write its checkpoint and the wait container saves it before the pod is deleted. A pod that is already being deleted
+// has been signalled by the kubelet.
+func (woc *wfOperationCtx) checkPreemption(pod *apiv1.Pod, node *wfv1.NodeStatus, wfNodesLock *sync.RWMutex) {
+	if pod.Annotations[common.AnnotationKeyPreemptionPolicy] != string(wfv1.PreemptionPolicyCheckpoint) || !isPreempted(pod) {
+		return
+	}
+	if pod.DeletionTimestamp != nil || node.Message == common.PreemptedMessage {
+		return
+	}
+	woc.log.WithField("podName", pod.Name).Info("Signalling pod which is being preempted to save its checkpoint")
+	woc.controller.PodController.TerminateContainers(pod.Namespace, pod.Name)
+	wfNodesLock.Lock()
+	defer wfNodesLock.Unlock()
+	woc.markNodePhase(node.Name, node.Phase, common.PreemptedMessage)
+}
+
+// addCheckpoint sets up the checkpoint of a template with the "checkpoint" preemption policy: each checkpoint artifact
+// is saved to a key of the node that is the same for each of its restarts, and is loaded as an optional input artifact
+func (woc *wfOperationCtx) addCheckpoint(pod *apiv1.Pod, tmpl *wfv1.Template, nodeID string) error {
+	if tmpl.PreemptionPolicy != wfv1.PreemptionPolicyCheckpoint || tmpl.Checkpoint == nil {
+		return nil
+	}
+	pod.Annotations[common.AnnotationKeyPreemptionPolicy] = string(tmpl.PreemptionPolicy)
+	checkpointID := nodeID
+	if retryNode := woc.retryParentNode(nodeID); retryNode != nil {
+		checkpointID = retryNode.ID
+	}
+	for i := range tmpl.Checkpoint.Artifacts {
+		art := &tmpl.Checkpoint.Artifacts[i]
+		art.Optional = true
+		if !art.HasLocation() {
+			location := tmpl.ArchiveLocation
+			if !location.HasLocation() {
+				location = woc.artifactRepository.ToArtifactLocation()
+			}
+			art.ArtifactLocation = *location.DeepCopy()
+			art.ArtifactLocation.ArchiveLogs = nil
+			if err := art.SetKey(path.Join(woc.wf.Name, "checkpoints", checkpointID, art.Name+".tgz")); err != nil {
+				return fmt.Errorf("checkpoint artifact %s: %w", art.Name, err)
+			}
+		}
+		tmpl.Inputs.Artifacts = append(tmpl.Inputs.Artifacts, *art.DeepCopy())
+	}
+	return nil
+}
+
+// retryParentNode returns the retry node that the node is an attempt of, if it is one
+func (woc *wfOperationCtx) retryParentNode(nodeID string) *wfv1.NodeStatus {
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypeRetry && node.HasChild(nodeID) {
+			return &node
+		}
+	}
+	return nil
+}
+
+// countPreemptions returns how many of the nodes were preempted, which are restarts rather than retries
+func (woc *wfOperationCtx) countPreemptions(nodeIDs []string) int32 {
+	preemptions := int32(0)
+	for _, id := range nodeIDs {
+		if node, err := woc.wf.Status.Nodes.Get(id); err == nil && node.Message == common.PreemptedMessage {
+			preemptions++
+		}
+	}
+	return preemptions
+}
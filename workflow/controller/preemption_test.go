@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var checkpointWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: checkpoint
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    preemptionPolicy: checkpoint
+    checkpoint:
+      artifacts:
+      - name: state
+        path: /tmp/state
+    container:
+      image: argoproj/argosay:v2
+`
+
+func withPreemption(pod *apiv1.Pod, _ *wfOperationCtx) {
+	pod.Status.Conditions = append(pod.Status.Conditions, apiv1.PodCondition{Type: apiv1.DisruptionTarget, Status: apiv1.ConditionTrue, Reason: "TerminationByKubelet"})
+}
+
+func TestIsPreempted(t *testing.T) {
+	pod := &apiv1.Pod{}
+	assert.False(t, isPreempted(pod))
+	withPreemption(pod, nil)
+	assert.True(t, isPreempted(pod))
+}
+
+func TestAddCheckpoint(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(checkpointWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(context.Background())
+
+	retryNode, err := woc.wf.GetNodeByName("checkpoint")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeTypeRetry, retryNode.Type, "a node that can be preempted is restarted by a retry node")
+
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	pod := pods.Items[0]
+	assert.Equal(t, "checkpoint", pod.Annotations[common.AnnotationKeyPreemptionPolicy])
+	tmpl, err := getPodTemplate(&pod)
+	require.NoError(t, err)
+	require.Len(t, tmpl.Inputs.Artifacts, 1)
+	input := tmpl.Inputs.Artifacts[0]
+	assert.Equal(t, "state", input.Name)
+	assert.True(t, input.Optional)
+	require.NotNil(t, input.S3)
+	assert.Equal(t, "checkpoint/checkpoints/"+retryNode.ID+"/state.tgz", input.S3.Key)
+	assert.Equal(t, input.S3.Key, tmpl.Checkpoint.Artifacts[0].S3.Key)
+}
+
+func TestPreemptedNodeRestarts(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(checkpointWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	makePodsPhase(ctx, woc, apiv1.PodFailed, withPreemption)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	preempted, err := woc.wf.GetNodeByName("checkpoint(0)")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeFailed, preempted.Phase)
+	assert.Equal(t, common.PreemptedMessage, preempted.Message)
+	restarted, err := woc.wf.GetNodeByName("checkpoint(1)")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodePending, restarted.Phase)
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+
+	makePodsPhase(ctx, woc, apiv1.PodFailed)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase, "failures that are not preemptions are not retried")
+}
+
+func TestPreemptedNodeRestartsAreLimited(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(checkpointWf)
+	wf.Spec.Templates[0].Checkpoint.MaxRestarts = ptr.To(int32(1))
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	makePodsPhase(ctx, woc, apiv1.PodFailed, withPreemption)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	restarted, err := woc.wf.GetNodeByName("checkpoint(1)")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodePending, restarted.Phase)
+
+	makePodsPhase(ctx, woc, apiv1.PodFailed, withPreemption)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase, "a node is not restarted more than maxRestarts times")
+	_, err = woc.wf.GetNodeByName("checkpoint(2)")
+	require.Error(t, err)
+	retryNode, err := woc.wf.GetNodeByName("checkpoint")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodeFailed, retryNode.Phase)
+	assert.Equal(t, "No more restarts left after being preempted 2 times", retryNode.Message)
+}
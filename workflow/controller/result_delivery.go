@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	envutil "github.com/argoproj/argo-workflows/v3/util/env"
+	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
+	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+	artifacthttp "github.com/argoproj/argo-workflows/v3/workflow/artifacts/http"
+)
+
+// resultDeliveryTimeout is the timeout of each request that delivers the result of a workflow
+const resultDeliveryTimeout = 30 * time.Second
+
+// resultDeliveryWorkers is the maximum number of results of workflows that are delivered concurrently
+var resultDeliveryWorkers = max(envutil.LookupEnvIntOr("RESULT_DELIVERY_WORKERS", 16), 1)
+
+// resultDeliveries runs the deliveries of the results of workflows in the background, for as long as the controller
+// runs
+type resultDeliveries struct {
+	// ctx is cancelled when the controller stops, which abandons the deliveries that are still running
+	ctx     context.Context
+	workers chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newResultDeliveries(ctx context.Context) *resultDeliveries {
+	return &resultDeliveries{ctx: ctx, workers: make(chan struct{}, resultDeliveryWorkers)}
+}
+
+// run runs the delivery in the background, once fewer than resultDeliveryWorkers deliveries are running
+func (d *resultDeliveries) run(deliver func(ctx context.Context)) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		select {
+		case d.workers <- struct{}{}:
+			defer func() { <-d.workers }()
+		case <-d.ctx.Done():
+		}
+		deliver(d.ctx)
+	}()
+}
+
+// wait waits for the deliveries to finish, which they do promptly once the controller has stopped
+func (d *resultDeliveries) wait() {
+	d.wg.Wait()
+}
+
+// resultDelivery is the JSON that the result of a completed workflow is delivered as
+type resultDelivery struct {
+	Name       string             `json:"name"`
+	Namespace  string             `json:"namespace"`
+	UID        string             `json:"uid"`
+	Phase      wfv1.WorkflowPhase `json:"phase"`
+	Message    string             `json:"message,omitempty"`
+	StartedAt  metav1.Time        `json:"startedAt"`
+	FinishedAt metav1.Time        `json:"finishedAt"`
+	// Outputs are the outputs of the entrypoint of the workflow
+	Outputs *wfv1.Outputs `json:"outputs,omitempty"`
+	// GlobalOutputs are the outputs that the templates of the workflow exported globally
+	GlobalOutputs *wfv1.Outputs `json:"globalOutputs,omitempty"`
+}
+
+func (woc *wfOperationCtx) resultDelivery() resultDelivery {
+	result := resultDelivery{
+		Name:          woc.wf.Name,
+		Namespace:     woc.wf.Namespace,
+		UID:           string(woc.wf.UID),
+		Phase:         woc.wf.Status.Phase,
+		Message:       woc.wf.Status.Message,
+		StartedAt:     woc.wf.Status.StartedAt,
+		FinishedAt:    woc.wf.Status.FinishedAt,
+		GlobalOutputs: woc.wf.Status.Outputs,
+	}
+	if node, err := woc.wf.Status.Nodes.Get(woc.wf.Name); err == nil {
+		result.Outputs = node.Outputs
+	}
+	return result
+}
+
+// deliverResult delivers the phase and outputs of a workflow that has just completed, if its spec says to. Failed
+// deliveries are retried in the background, so that a slow or unavailable receiver does not hold up other workflows,
+// and the outcome is recorded as an event of the workflow. A delivery that is still running when the controller stops
+// is abandoned, which is recorded as a failure. Results are only delivered to the URLs that the controller's config
+// allows.
+func (woc *wfOperationCtx) deliverResult() {
+	delivery := woc.execWf.Spec.ResultDelivery
+	if delivery == nil || delivery.HTTP == nil {
+		return
+	}
+	allowed := woc.controller.Config.ResultDelivery
+	if !allowed.IsURLAllowed(delivery.HTTP.URL) {
+		woc.log.WithField("url", delivery.HTTP.URL).Warn("Not delivering the result of the workflow to a URL that the controller's config does not allow")
+		woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowResultDeliveryFailed", fmt.Sprintf("The result of the workflow may not be delivered to %s, which is not allowed by the resultDelivery config of the controller", delivery.HTTP.URL))
+		return
+	}
+	body, err := json.Marshal(woc.resultDelivery())
+	if err != nil {
+		woc.log.WithError(err).Error("Failed to marshal the result of the workflow")
+		return
+	}
+	wf := woc.wf.DeepCopy()
+	ri := artifactResources{woc.controller.kubeclientset, woc.wf.Namespace}
+	woc.controller.resultDeliveries.run(func(ctx context.Context) {
+		err := postResult(ctx, delivery.HTTP, body, ri, allowed)
+		switch {
+		case ctx.Err() != nil:
+			woc.log.WithField("url", delivery.HTTP.URL).Warn("Abandoned the delivery of the result of the workflow as the controller stopped")
+			woc.eventRecorder.Event(wf, apiv1.EventTypeWarning, "WorkflowResultDeliveryFailed", fmt.Sprintf("Abandoned the delivery of the result of the workflow to %s as the controller stopped", delivery.HTTP.URL))
+		case err != nil:
+			woc.log.WithError(err).WithField("url", delivery.HTTP.URL).Warn("Failed to deliver the result of the workflow")
+			woc.eventRecorder.Event(wf, apiv1.EventTypeWarning, "WorkflowResultDeliveryFailed", fmt.Sprintf("Failed to deliver the result of the workflow to %s: %v", delivery.HTTP.URL, err))
+		default:
+			woc.log.WithField("url", delivery.HTTP.URL).Info("Delivered the result of the workflow")
+			woc.eventRecorder.Event(wf, apiv1.EventTypeNormal, "WorkflowResultDelivered", fmt.Sprintf("Delivered the result of the workflow to %s", delivery.HTTP.URL))
+		}
+	})
+}
+
+// postResult POSTs the result to the URL of the delivery, retrying requests that fail with a network error, or a 429
+// or 5xx status code. Redirects are only followed to allowed URLs, and the delivery fails with the status code of
+// those to other URLs. It stops retrying once the context is done.
+func postResult(ctx context.Context, delivery *wfv1.HTTPResultDelivery, body []byte, ri artifactResources, allowed *config.ResultDelivery) error {
+	// the HTTP artifact driver authenticates the same way as the delivery
+	httpDriver, err := artifact.NewHTTPDriver(ctx, &wfv1.HTTPArtifact{URL: delivery.URL, Auth: delivery.Auth}, ri)
+	if err != nil {
+		return err
+	}
+	client := *httpDriver.Client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !allowed.IsURLAllowed(req.URL.String()) {
+			log.WithField("url", req.URL.String()).Warn("Not following the redirect of a result delivery to a URL that is not allowed")
+			return http.ErrUseLastResponse
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	backoff, err := artifacthttp.RetryBackoff(delivery.Retry)
+	if err != nil {
+		return err
+	}
+	return waitutil.BackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		ctx, cancel := context.WithTimeout(ctx, resultDeliveryTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+		if err != nil {
+			return true, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for _, h := range delivery.Headers {
+			req.Header.Add(h.Name, h.Value)
+		}
+		if httpDriver.Username != "" && httpDriver.Password != "" {
+			req.SetBasicAuth(httpDriver.Username, httpDriver.Password)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return false, err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		switch {
+		case resp.StatusCode < 300:
+			return true, nil
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			return false, fmt.Errorf("%s: %s", delivery.URL, resp.Status)
+		default:
+			return true, fmt.Errorf("%s: %s", delivery.URL, resp.Status)
+		}
+	})
+}
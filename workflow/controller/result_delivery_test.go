@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestResultDelivery(t *testing.T) {
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns", UID: "my-uid"},
+		Status: wfv1.WorkflowStatus{
+			Phase:   wfv1.WorkflowSucceeded,
+			Outputs: &wfv1.Outputs{Parameters: []wfv1.Parameter{{Name: "global", Value: wfv1.AnyStringPtr("1")}}},
+			Nodes: wfv1.Nodes{
+				"my-wf": {ID: "my-wf", Name: "my-wf", Outputs: &wfv1.Outputs{
+					Parameters: []wfv1.Parameter{{Name: "accuracy", Value: wfv1.AnyStringPtr("0.9")}},
+					Artifacts:  []wfv1.Artifact{{Name: "model", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-wf/model.tgz"}}}},
+				}},
+			},
+		},
+	}
+	woc := &wfOperationCtx{wf: wf}
+
+	result := woc.resultDelivery()
+	assert.Equal(t, "my-wf", result.Name)
+	assert.Equal(t, "my-ns", result.Namespace)
+	assert.Equal(t, "my-uid", result.UID)
+	assert.Equal(t, wfv1.WorkflowSucceeded, result.Phase)
+	require.NotNil(t, result.Outputs)
+	assert.Equal(t, "0.9", result.Outputs.Parameters[0].Value.String())
+	assert.Equal(t, "my-wf/model.tgz", result.Outputs.Artifacts[0].S3.Key)
+	assert.Equal(t, wf.Status.Outputs, result.GlobalOutputs)
+}
+
+func TestPostResult(t *testing.T) {
+	retry := &wfv1.HTTPArtifactRetry{Backoff: &wfv1.Backoff{Duration: "10ms"}}
+	serve := func(t *testing.T, statusCodes ...int) (*httptest.Server, *int32) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "my-token", r.Header.Get("X-Token"))
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"phase":"Succeeded"}`, string(body))
+			i := atomic.AddInt32(&requests, 1) - 1
+			if int(i) < len(statusCodes) {
+				w.WriteHeader(statusCodes[i])
+			}
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+	post := func(url string, allowedURLPrefixes ...string) error {
+		body, err := json.Marshal(map[string]string{"phase": "Succeeded"})
+		require.NoError(t, err)
+		delivery := &wfv1.HTTPResultDelivery{URL: url, Headers: []wfv1.Header{{Name: "X-Token", Value: "my-token"}}, Retry: retry}
+		allowed := &config.ResultDelivery{AllowedURLPrefixes: append(allowedURLPrefixes, url)}
+		return postResult(context.Background(), delivery, body, artifactResources{}, allowed)
+	}
+	redirect := func(t *testing.T, to string) *httptest.Server {
+		server := httptest.NewServer(http.RedirectHandler(to, http.StatusTemporaryRedirect))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("Delivered", func(t *testing.T) {
+		server, requests := serve(t)
+		require.NoError(t, post(server.URL))
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	})
+	t.Run("Retried", func(t *testing.T) {
+		server, requests := serve(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		require.NoError(t, post(server.URL))
+		assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		server, requests := serve(t, http.StatusBadRequest)
+		require.ErrorContains(t, post(server.URL), "400 Bad Request")
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	})
+	t.Run("Redirected", func(t *testing.T) {
+		server, requests := serve(t)
+		require.NoError(t, post(redirect(t, server.URL).URL, server.URL))
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	})
+	t.Run("RedirectNotAllowed", func(t *testing.T) {
+		server, requests := serve(t)
+		require.ErrorContains(t, post(redirect(t, server.URL).URL), "307 Temporary Redirect")
+		assert.Equal(t, int32(0), atomic.LoadInt32(requests))
+	})
+}
+
+func TestDeliverResultAbandoned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  resultDelivery:
+    http:
+      retry:
+        backoff:
+          duration: 1h
+status:
+  phase: Failed
+`)
+	wf.Spec.ResultDelivery.HTTP.URL = server.URL
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.Config.ResultDelivery = &config.ResultDelivery{AllowedURLPrefixes: []string{server.URL}}
+	ctx, stop := context.WithCancel(context.Background())
+	controller.resultDeliveries = newResultDeliveries(ctx)
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.deliverResult()
+	stop()
+	controller.resultDeliveries.wait()
+
+	events := controller.eventRecorderManager.(*testEventRecorderManager).eventRecorder.Events
+	require.Len(t, events, 1)
+	assert.Equal(t, "Warning WorkflowResultDeliveryFailed Abandoned the delivery of the result of the workflow to "+server.URL+" as the controller stopped", <-events)
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// mergeNodeSelectors merges node selectors, from the least to the most specific, whose labels take precedence
+func mergeNodeSelectors(nodeSelectors ...map[string]string) map[string]string {
+	var merged map[string]string
+	for _, nodeSelector := range nodeSelectors {
+		for k, v := range nodeSelector {
+			if merged == nil {
+				merged = make(map[string]string)
+			}
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// mergeTolerations returns the tolerations of all of the lists, without duplicates
+func mergeTolerations(tolerations ...[]apiv1.Toleration) []apiv1.Toleration {
+	var merged []apiv1.Toleration
+	for _, list := range tolerations {
+		for _, t := range list {
+			duplicate := false
+			for _, m := range merged {
+				if m.MatchToleration(&t) && equalTolerationSeconds(m.TolerationSeconds, t.TolerationSeconds) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				merged = append(merged, t)
+			}
+		}
+	}
+	return merged
+}
+
+func equalTolerationSeconds(a, b *int64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// mergeAffinities returns an affinity that requires what each of the affinities requires, and prefers what any of them
+// prefers
+func mergeAffinities(affinities ...*apiv1.Affinity) *apiv1.Affinity {
+	var merged *apiv1.Affinity
+	for _, a := range affinities {
+		if a == nil {
+			continue
+		}
+		if merged == nil {
+			merged = a.DeepCopy()
+			continue
+		}
+		if a.NodeAffinity != nil {
+			if merged.NodeAffinity == nil {
+				merged.NodeAffinity = &apiv1.NodeAffinity{}
+			}
+			merged.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = mergeRequiredNodeSelectors(merged.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+			merged.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(merged.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, a.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+		}
+		// the terms of pod affinities and anti-affinities are all required, or all preferred, so they are appended
+		if a.PodAffinity != nil {
+			if merged.PodAffinity == nil {
+				merged.PodAffinity = &apiv1.PodAffinity{}
+			}
+			merged.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(merged.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, a.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+			merged.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(merged.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution, a.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+		}
+		if a.PodAntiAffinity != nil {
+			if merged.PodAntiAffinity == nil {
+				merged.PodAntiAffinity = &apiv1.PodAntiAffinity{}
+			}
+			merged.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(merged.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+			merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+		}
+	}
+	return merged
+}
+
+// mergeRequiredNodeSelectors returns a node selector that selects the nodes that both of the node selectors select.
+// A node selector selects the nodes that any of its terms selects, so each term of one is combined with each term of
+// the other.
+func mergeRequiredNodeSelectors(a, b *apiv1.NodeSelector) *apiv1.NodeSelector {
+	if b == nil || len(b.NodeSelectorTerms) == 0 {
+		return a
+	}
+	if a == nil || len(a.NodeSelectorTerms) == 0 {
+		return b.DeepCopy()
+	}
+	merged := &apiv1.NodeSelector{}
+	for _, ta := range a.NodeSelectorTerms {
+		for _, tb := range b.NodeSelectorTerms {
+			merged.NodeSelectorTerms = append(merged.NodeSelectorTerms, apiv1.NodeSelectorTerm{
+				MatchExpressions: append(append([]apiv1.NodeSelectorRequirement{}, ta.MatchExpressions...), tb.MatchExpressions...),
+				MatchFields:      append(append([]apiv1.NodeSelectorRequirement{}, ta.MatchFields...), tb.MatchFields...),
+			})
+		}
+	}
+	return merged
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func nodeSelectorTerm(key string, values ...string) apiv1.NodeSelectorTerm {
+	return apiv1.NodeSelectorTerm{MatchExpressions: []apiv1.NodeSelectorRequirement{{Key: key, Operator: apiv1.NodeSelectorOpIn, Values: values}}}
+}
+
+func TestMergeNodeSelectors(t *testing.T) {
+	assert.Nil(t, mergeNodeSelectors(nil, map[string]string{}))
+	assert.Equal(t, map[string]string{"a": "2", "b": "1"}, mergeNodeSelectors(map[string]string{"a": "1", "b": "1"}, nil, map[string]string{"a": "2"}))
+}
+
+func TestMergeTolerations(t *testing.T) {
+	seconds := int64(60)
+	merged := mergeTolerations(
+		[]apiv1.Toleration{{Key: "a", Operator: apiv1.TolerationOpExists}},
+		[]apiv1.Toleration{{Key: "a", Operator: apiv1.TolerationOpExists}, {Key: "a", Operator: apiv1.TolerationOpExists, TolerationSeconds: &seconds}, {Key: "b", Operator: apiv1.TolerationOpExists}},
+	)
+	require.Len(t, merged, 3)
+	assert.Equal(t, "a", merged[0].Key)
+	assert.Equal(t, &seconds, merged[1].TolerationSeconds)
+	assert.Equal(t, "b", merged[2].Key)
+}
+
+func TestMergeAffinities(t *testing.T) {
+	assert.Nil(t, mergeAffinities(nil, nil))
+
+	wf := &apiv1.Affinity{
+		NodeAffinity: &apiv1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
+			NodeSelectorTerms: []apiv1.NodeSelectorTerm{nodeSelectorTerm("zone", "a"), nodeSelectorTerm("zone", "b")},
+		}},
+	}
+	tmpl := &apiv1.Affinity{
+		NodeAffinity: &apiv1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
+			NodeSelectorTerms: []apiv1.NodeSelectorTerm{nodeSelectorTerm("gpu", "true")},
+		}},
+		PodAntiAffinity: &apiv1.PodAntiAffinity{PreferredDuringSchedulingIgnoredDuringExecution: []apiv1.WeightedPodAffinityTerm{{Weight: 1}}},
+	}
+	merged := mergeAffinities(wf, nil, tmpl)
+	require.NotNil(t, merged)
+
+	// a node must be in one of the zones, and have a GPU
+	terms := merged.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 2)
+	assert.Equal(t, []apiv1.NodeSelectorRequirement{nodeSelectorTerm("zone", "a").MatchExpressions[0], nodeSelectorTerm("gpu", "true").MatchExpressions[0]}, terms[0].MatchExpressions)
+	assert.Equal(t, []apiv1.NodeSelectorRequirement{nodeSelectorTerm("zone", "b").MatchExpressions[0], nodeSelectorTerm("gpu", "true").MatchExpressions[0]}, terms[1].MatchExpressions)
+	assert.Len(t, merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+
+	// the affinity of the workflow is not modified
+	assert.Len(t, wf.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, 2)
+	assert.Nil(t, wf.PodAntiAffinity)
+}
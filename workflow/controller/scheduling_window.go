@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// schedulingWindowClosedError is why a pod was not created, as it is not in a scheduling window of its workflow
+type schedulingWindowClosedError struct {
+	opensAt time.Time
+}
+
+func (e schedulingWindowClosedError) Error() string {
+	return fmt.Sprintf("Waiting for the next scheduling window of the workflow, which starts at %s", e.opensAt.UTC().Format(time.RFC3339))
+}
+
+// nextSchedulingWindow returns when the next of the windows starts, or the zero time if the time is in one of them
+func nextSchedulingWindow(w *wfv1.SchedulingWindow, now time.Time) (time.Time, error) {
+	var next time.Time
+	for _, schedule := range w.Schedules {
+		spec := schedule
+		if w.Timezone != "" {
+			spec = "CRON_TZ=" + w.Timezone + " " + spec
+		}
+		s, err := cron.ParseStandard(spec)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid schedule %q of scheduling window: %w", schedule, err)
+		}
+		// the time is in a window if one started less than its duration before it
+		if start := s.Next(now.Add(-w.Duration.Duration)); !start.IsZero() && !start.After(now) {
+			return time.Time{}, nil
+		}
+		if start := s.Next(now); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next, nil
+}
+
+// schedulingWindowOpensAt returns when the next scheduling window of the workflow starts, or the zero time if the
+// workflow has none, is in one of them, or is being stopped or terminated
+func (woc *wfOperationCtx) schedulingWindowOpensAt() time.Time {
+	w := woc.execWf.Spec.SchedulingWindow
+	if w == nil || woc.GetShutdownStrategy().Enabled() {
+		return time.Time{}
+	}
+	opensAt, err := nextSchedulingWindow(w, time.Now())
+	if err != nil {
+		// the schedules were validated, so this is not expected, and the window is ignored rather than blocking the
+		// workflow forever
+		woc.log.WithError(err).Warn("Failed to get the next scheduling window")
+		return time.Time{}
+	}
+	return opensAt
+}
+
+// checkSchedulingWindow returns why the workflow, which has not started yet, is postponed, as it is not in one of its
+// scheduling windows, and requeues it for when the next one starts
+func (woc *wfOperationCtx) checkSchedulingWindow() string {
+	if len(woc.wf.Status.Nodes) > 0 {
+		return ""
+	}
+	opensAt := woc.schedulingWindowOpensAt()
+	if opensAt.IsZero() {
+		return ""
+	}
+	woc.requeueAfter(time.Until(opensAt))
+	return fmt.Sprintf("Workflow processing has been postponed until its next scheduling window, which starts at %s", opensAt.UTC().Format(time.RFC3339))
+}
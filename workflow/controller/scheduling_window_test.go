@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestNextSchedulingWindow(t *testing.T) {
+	offPeak := &wfv1.SchedulingWindow{Schedules: []string{"0 22 * * *"}, Duration: metav1.Duration{Duration: 8 * time.Hour}}
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return v
+	}
+	for _, tt := range []struct {
+		name string
+		w    *wfv1.SchedulingWindow
+		now  string
+		want string
+	}{
+		{"InWindow", offPeak, "2026-10-16T23:00:00Z", ""},
+		{"InWindowAfterMidnight", offPeak, "2026-10-16T05:59:00Z", ""},
+		{"StartOfWindow", offPeak, "2026-10-16T22:00:00Z", ""},
+		{"EndOfWindow", offPeak, "2026-10-16T06:00:00Z", "2026-10-16T22:00:00Z"},
+		{"OutsideWindow", offPeak, "2026-10-16T12:00:00Z", "2026-10-16T22:00:00Z"},
+		{"EarliestSchedule", &wfv1.SchedulingWindow{Schedules: []string{"0 22 * * *", "0 13 * * *"}, Duration: metav1.Duration{Duration: time.Hour}}, "2026-10-16T12:00:00Z", "2026-10-16T13:00:00Z"},
+		{"TimezoneInWindow", &wfv1.SchedulingWindow{Schedules: []string{"0 22 * * *"}, Duration: metav1.Duration{Duration: 8 * time.Hour}, Timezone: "America/Los_Angeles"}, "2026-10-16T12:00:00Z", ""},
+		{"TimezoneOutsideWindow", &wfv1.SchedulingWindow{Schedules: []string{"0 22 * * *"}, Duration: metav1.Duration{Duration: 8 * time.Hour}, Timezone: "America/Los_Angeles"}, "2026-10-16T14:00:00Z", "2026-10-17T05:00:00Z"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := nextSchedulingWindow(tt.w, at(tt.now))
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.True(t, next.IsZero(), "expected to be in a window, but the next one starts at %v", next)
+			} else {
+				assert.True(t, at(tt.want).Equal(next), "expected the next window to start at %s, but it starts at %v", tt.want, next)
+			}
+		})
+	}
+	_, err := nextSchedulingWindow(&wfv1.SchedulingWindow{Schedules: []string{"0 25 * * *"}, Duration: metav1.Duration{Duration: time.Hour}}, time.Now())
+	require.Error(t, err)
+}
+
+var schedulingWindowWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: scheduling-window
+spec:
+  entrypoint: main
+  schedulingWindow:
+    schedules: ["* * * * *"]
+    duration: 1m
+  templates:
+  - name: main
+    steps:
+    - - name: a
+        template: argosay
+    - - name: b
+        template: argosay
+  - name: argosay
+    container:
+      image: argoproj/argosay:v2
+`
+
+// closedSchedulingWindow is a window that is only open for a minute on the 29th of February
+var closedSchedulingWindow = &wfv1.SchedulingWindow{Schedules: []string{"0 0 29 2 *"}, Duration: metav1.Duration{Duration: time.Minute}}
+
+func TestSchedulingWindowPostponesWorkflow(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(schedulingWindowWf)
+	wf.Spec.SchedulingWindow = closedSchedulingWindow
+	cancel, controller := newController(wf)
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(context.Background())
+
+	assert.Equal(t, wfv1.WorkflowPending, woc.wf.Status.Phase)
+	assert.Contains(t, woc.wf.Status.Message, "Workflow processing has been postponed until its next scheduling window")
+	assert.Empty(t, woc.wf.Status.Nodes)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items)
+}
+
+func TestSchedulingWindowPostponesPods(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(schedulingWindowWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+
+	// the window closes whilst the first step runs
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc.wf.Spec.SchedulingWindow = closedSchedulingWindow
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	b, err := woc.wf.GetNodeByName("scheduling-window[1].b")
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.NodePending, b.Phase)
+	assert.Contains(t, b.Message, "Waiting for the next scheduling window of the workflow")
+	pods, err = listPods(woc)
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 1, "the pod of the second step is not created outside of the window")
+}
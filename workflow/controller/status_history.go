@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	"github.com/argoproj/argo-workflows/v3/util/env"
+)
+
+var statusSnapshotGCPeriod = env.LookupEnvDurationOr("STATUS_SNAPSHOT_GC_PERIOD", time.Hour)
+
+// saveStatusSnapshot saves a snapshot of the status of the workflow, if the status history is enabled and the interval
+// has passed since its last snapshot, or it has just completed. The updates of completed workflows, e.g. to remove
+// finalizers, are not saved, as their nodes no longer change.
+func (woc *wfOperationCtx) saveStatusSnapshot() {
+	repo := woc.controller.statusSnapshotRepo
+	if repo == nil {
+		return
+	}
+	uid := string(woc.wf.UID)
+	now := time.Now()
+	fulfilled := woc.wf.Status.Fulfilled()
+	if fulfilled && woc.orig.Status.Fulfilled() {
+		woc.controller.lastStatusSnapshots.Delete(uid)
+		return
+	}
+	if last, ok := woc.controller.lastStatusSnapshots.Load(uid); ok && !fulfilled && now.Sub(last.(time.Time)) < woc.controller.Config.Persistence.StatusHistory.GetInterval() {
+		return
+	}
+	nodes, err := json.Marshal(woc.wf.Status.Nodes)
+	if err != nil {
+		woc.log.WithError(err).Warn("Failed to marshal the nodes of the status snapshot")
+		return
+	}
+	err = repo.Save(&sqldb.WorkflowStatusSnapshotRecord{
+		UID:       uid,
+		Namespace: woc.wf.Namespace,
+		Name:      woc.wf.Name,
+		Phase:     string(woc.wf.Status.Phase),
+		Message:   woc.wf.Status.Message,
+		Nodes:     string(nodes),
+		CreatedAt: now,
+	})
+	if err != nil {
+		woc.log.WithError(err).Warn("Failed to save a snapshot of the workflow status")
+		return
+	}
+	if fulfilled {
+		woc.controller.lastStatusSnapshots.Delete(uid)
+	} else {
+		woc.controller.lastStatusSnapshots.Store(uid, now)
+	}
+}
+
+// deleteExpiredStatusSnapshots deletes the snapshots of the statuses of workflows that are older than their TTL
+func (wfc *WorkflowController) deleteExpiredStatusSnapshots(_ context.Context) {
+	if wfc.statusSnapshotRepo == nil {
+		return
+	}
+	if err := wfc.statusSnapshotRepo.DeleteExpired(wfc.Config.Persistence.StatusHistory.GetTTL()); err != nil {
+		log.WithError(err).Error("Unable to delete expired workflow status snapshots from the database")
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+type fakeWorkflowStatusSnapshotRepo struct {
+	saved []sqldb.WorkflowStatusSnapshotRecord
+}
+
+func (r *fakeWorkflowStatusSnapshotRepo) Save(record *sqldb.WorkflowStatusSnapshotRecord) error {
+	r.saved = append(r.saved, *record)
+	return nil
+}
+
+func (r *fakeWorkflowStatusSnapshotRepo) Get(string, string, string, time.Time) (*sqldb.WorkflowStatusSnapshotRecord, error) {
+	return nil, nil
+}
+
+func (r *fakeWorkflowStatusSnapshotRepo) DeleteExpired(time.Duration) error { return nil }
+
+func TestSaveStatusSnapshot(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	repo := &fakeWorkflowStatusSnapshotRepo{}
+	controller.statusSnapshotRepo = repo
+	controller.Config.Persistence = &config.PersistConfig{StatusHistory: &config.StatusHistoryConfig{Interval: config.TTL(time.Hour)}}
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	require.Len(t, repo.saved, 1)
+	snapshot := repo.saved[0]
+	assert.Equal(t, wf.Name, snapshot.Name)
+	assert.Equal(t, string(wfv1.WorkflowRunning), snapshot.Phase)
+	assert.Contains(t, snapshot.Nodes, `"phase":"Pending"`)
+
+	makePodsPhase(ctx, woc, apiv1.PodRunning)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Len(t, repo.saved, 1, "the status is not saved again within the interval")
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	require.Len(t, repo.saved, 2, "the status is saved when the workflow completes")
+	assert.Equal(t, string(wfv1.WorkflowSucceeded), repo.saved[1].Phase)
+	_, ok := controller.lastStatusSnapshots.Load(string(wf.UID))
+	assert.False(t, ok)
+
+	// e.g. an update to remove a finalizer
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.updated = true
+	woc.operate(ctx)
+	assert.Len(t, repo.saved, 2, "the status of a completed workflow is only saved once")
+}
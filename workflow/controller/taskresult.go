@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -118,6 +120,19 @@ func (woc *wfOperationCtx) taskResultReconciliation() {
 		if result.Progress.IsValid() {
 			newNode.Progress = result.Progress
 		}
+		if result.ArtifactProgress != nil {
+			newNode.ArtifactProgress = result.ArtifactProgress.DeepCopy()
+		}
+		if result.Provenance != nil {
+			newNode.Provenance = result.Provenance.DeepCopy()
+			if old.Provenance != nil { // preserve the images and environment recorded from the pod
+				newNode.Provenance.ImageIDs = old.Provenance.ImageIDs
+				newNode.Provenance.EnvVarNames = old.Provenance.EnvVarNames
+			}
+		}
+		if len(result.TruncatedOutputs) > 0 {
+			woc.markOutputsTruncated(old.Name, result.TruncatedOutputs)
+		}
 		if !reflect.DeepEqual(old, newNode) {
 			woc.log.
 				WithField("nodeID", nodeID).
@@ -127,3 +142,19 @@ func (woc *wfOperationCtx) taskResultReconciliation() {
 		}
 	}
 }
+
+// markOutputsTruncated adds the outputs of the node that were truncated to the OutputsTruncated condition of the workflow
+func (woc *wfOperationCtx) markOutputsTruncated(nodeName string, outputs []string) {
+	msg := fmt.Sprintf("node %q truncated %s", nodeName, strings.Join(outputs, ", "))
+	for _, condition := range woc.wf.Status.Conditions {
+		if condition.Type == wfv1.ConditionTypeOutputsTruncated && strings.Contains(condition.Message, msg) {
+			return
+		}
+	}
+	woc.wf.Status.Conditions.UpsertConditionMessage(wfv1.Condition{
+		Type:    wfv1.ConditionTypeOutputsTruncated,
+		Status:  metav1.ConditionTrue,
+		Message: msg,
+	})
+	woc.updated = true
+}
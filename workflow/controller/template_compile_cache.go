@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/lru"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+func newTemplateCompileCache(size int) *lru.Cache {
+	if size <= 0 {
+		return nil
+	}
+	return lru.New(size)
+}
+
+// resolvedTemplate is a workflow template, or cluster workflow template, that validation resolved
+type resolvedTemplate struct {
+	ClusterScope bool
+	Name         string
+}
+
+// resolvedTemplates are the versions, of UID and resource version, of the templates that the validation of a
+// workflow resolved, which are what the cache entry of the validation is valid for
+type resolvedTemplates map[resolvedTemplate]string
+
+func templateVersion(tmpl metav1.Object) string {
+	return string(tmpl.GetUID()) + "/" + tmpl.GetResourceVersion()
+}
+
+// recordingWorkflowTemplateGetter records the versions of the workflow templates that it gets
+type recordingWorkflowTemplateGetter struct {
+	templateresolution.WorkflowTemplateNamespacedGetter
+	resolved resolvedTemplates
+}
+
+func (g recordingWorkflowTemplateGetter) Get(name string) (*wfv1.WorkflowTemplate, error) {
+	tmpl, err := g.WorkflowTemplateNamespacedGetter.Get(name)
+	if err == nil {
+		g.resolved[resolvedTemplate{Name: name}] = templateVersion(tmpl)
+	}
+	return tmpl, err
+}
+
+// recordingClusterWorkflowTemplateGetter records the versions of the cluster workflow templates that it gets
+type recordingClusterWorkflowTemplateGetter struct {
+	templateresolution.ClusterWorkflowTemplateGetter
+	resolved resolvedTemplates
+}
+
+func (g recordingClusterWorkflowTemplateGetter) Get(name string) (*wfv1.ClusterWorkflowTemplate, error) {
+	tmpl, err := g.ClusterWorkflowTemplateGetter.Get(name)
+	if err == nil {
+		g.resolved[resolvedTemplate{ClusterScope: true, Name: name}] = templateVersion(tmpl)
+	}
+	return tmpl, err
+}
+
+// getTemplate returns the workflow template, or cluster workflow template, from the informers
+func (woc *wfOperationCtx) getTemplate(ref resolvedTemplate) (metav1.Object, bool) {
+	var tmpl metav1.Object
+	var err error
+	if ref.ClusterScope {
+		if woc.controller.cwftmplInformer == nil {
+			return nil, false
+		}
+		tmpl, err = woc.controller.cwftmplInformer.Lister().Get(ref.Name)
+	} else {
+		tmpl, err = woc.controller.wftmplInformer.Lister().WorkflowTemplates(woc.wf.Namespace).Get(ref.Name)
+	}
+	return tmpl, err == nil
+}
+
+// isTemplateCompileCached returns whether the workflow was validated with the key, against the current versions of
+// every template that its validation resolved, including those that its workflow template references
+func (woc *wfOperationCtx) isTemplateCompileCached(key string) bool {
+	value, ok := woc.controller.templateCompileCache.Get(key)
+	if !ok {
+		return false
+	}
+	for ref, version := range value.(resolvedTemplates) {
+		if tmpl, ok := woc.getTemplate(ref); !ok || templateVersion(tmpl) != version {
+			woc.controller.templateCompileCache.Remove(key)
+			return false
+		}
+	}
+	return true
+}
+
+// templateCompileKey returns the key that the validation of the workflow is cached by, or false if it cannot be
+// cached, e.g. because the workflow does not reference a workflow template. The key is of the version of the
+// template, and of everything of the workflow that validation depends on, so that workflows that only differ in
+// their names share it. The templates that the workflow template references are checked by isTemplateCompileCached.
+func (woc *wfOperationCtx) templateCompileKey(wfDefaults *wfv1.Workflow) (string, bool) {
+	ref := woc.wf.Spec.WorkflowTemplateRef // not-woc-misuse
+	if woc.controller.templateCompileCache == nil || ref == nil {
+		return "", false
+	}
+	tmpl, ok := woc.getTemplate(resolvedTemplate{ClusterScope: ref.ClusterScope, Name: ref.Name})
+	if !ok {
+		return "", false
+	}
+	data, err := json.Marshal(struct {
+		NameLength  int
+		Labels      []string
+		Annotations []string
+		Spec        wfv1.WorkflowSpec
+		Defaults    *wfv1.Workflow
+	}{
+		NameLength:  len(woc.wf.Name),
+		Labels:      slices.Sorted(maps.Keys(woc.wf.Labels)),
+		Annotations: slices.Sorted(maps.Keys(woc.wf.Annotations)),
+		Spec:        woc.wf.Spec, // not-woc-misuse
+		Defaults:    wfDefaults,
+	})
+	if err != nil {
+		return "", false
+	}
+	digest := sha256.Sum256(data)
+	return templateVersion(tmpl) + "/" + hex.EncodeToString(digest[:]), true
+}
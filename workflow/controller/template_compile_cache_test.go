@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+)
+
+func TestTemplateCompileCache(t *testing.T) {
+	cancel, controller := newController(wfv1.MustUnmarshalWorkflowTemplate(wfTmpl))
+	defer cancel()
+	controller.templateCompileCache = newTemplateCompileCache(10)
+	// validation gets the workflow template from the API, rather than the informer
+	gets := 0
+	controller.wfclientset.(*fakewfclientset.Clientset).PrependReactor("get", "workflowtemplates", func(k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+
+	ctx := context.Background()
+	operate := func(name, message string) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		wf.Name = name
+		wf.Spec.Arguments.Parameters[0].Value = wfv1.AnyStringPtr(message)
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	}
+	operate("my-wf-1", "test")
+	validated := gets
+	assert.Positive(t, validated)
+	operate("my-wf-2", "test")
+	assert.Equal(t, validated, gets, "the same workflow is not validated again")
+	operate("my-wf-3", "changed")
+	assert.Greater(t, gets, validated, "a workflow with a different spec is validated")
+}
+
+const wfTmplWithTmplRef = `
+apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: workflow-template-outer
+  namespace: default
+spec:
+  templates:
+  - name: main
+    inputs:
+      parameters:
+      - name: message
+    steps:
+    - - name: whalesay
+        templateRef:
+          name: workflow-template-whalesay-template
+          template: whalesay-template
+        arguments:
+          parameters:
+          - name: message
+            value: "{{inputs.parameters.message}}"
+`
+
+func TestTemplateCompileCacheReferencedTemplate(t *testing.T) {
+	inner := wfv1.MustUnmarshalWorkflowTemplate(wfTmpl)
+	cancel, controller := newController(wfv1.MustUnmarshalWorkflowTemplate(wfTmplWithTmplRef), inner)
+	defer cancel()
+	controller.templateCompileCache = newTemplateCompileCache(10)
+	gets := map[string]int{}
+	controller.wfclientset.(*fakewfclientset.Clientset).PrependReactor("get", "workflowtemplates", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gets[action.(k8stesting.GetAction).GetName()]++
+		return false, nil, nil
+	})
+
+	ctx := context.Background()
+	operate := func(name string) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		wf.Name = name
+		wf.Spec.Entrypoint = "main"
+		wf.Spec.WorkflowTemplateRef.Name = "workflow-template-outer"
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	}
+	operate("my-wf-1")
+	validated := gets["workflow-template-whalesay-template"]
+	assert.Positive(t, validated, "validation resolves the referenced template")
+	operate("my-wf-2")
+	assert.Equal(t, validated, gets["workflow-template-whalesay-template"], "the same workflow is not validated again")
+
+	// the informer sees a new version of the referenced template, but not of the workflow template
+	inner.ResourceVersion = "2"
+	assert.NoError(t, controller.wftmplInformer.Informer().GetIndexer().Update(inner))
+	operate("my-wf-3")
+	assert.Greater(t, gets["workflow-template-whalesay-template"], validated, "a change to a referenced template is validated")
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/defaults"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func (wfc *WorkflowController) newWorkflowDefaults() *defaults.Defaults {
+	return defaults.New(func() *wfv1.Workflow { return wfc.Config.WorkflowDefaults })
+}
+
+// createWorkflowDefaultsInformers watches the ClusterWorkflowDefaults and NamespaceWorkflowDefaults, if their CRDs
+// are installed and the controller has RBAC access to them
+func (wfc *WorkflowController) createWorkflowDefaultsInformers(ctx context.Context) {
+	wfc.wfDefaults.Run(ctx, wfc.kubeclientset, wfc.dynamicInterface, wfc.namespace, wfc.GetManagedNamespace(), cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			wfc.validateWorkflowDefaults(obj)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			oldUn, ok := old.(*unstructured.Unstructured)
+			if un, ok2 := obj.(*unstructured.Unstructured); ok && ok2 && oldUn.GetResourceVersion() == un.GetResourceVersion() {
+				return
+			}
+			wfc.validateWorkflowDefaults(obj)
+		},
+	})
+}
+
+// validateWorkflowDefaults issues a warning Event for WorkflowDefaults that are invalid, which are ignored
+func (wfc *WorkflowController) validateWorkflowDefaults(obj interface{}) {
+	un, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	_, err := unstructuredToWorkflowDefaults(un)
+	if err == nil {
+		return
+	}
+	log.WithField("kind", un.GetKind()).WithField("name", un.GetName()).WithError(err).Warn("Invalid workflow defaults are ignored")
+	wfc.eventRecorderManager.Get(un.GetNamespace()).Event(un, apiv1.EventTypeWarning, "InvalidWorkflowDefaults", err.Error())
+}
+
+// unstructuredToWorkflowDefaults returns the WorkflowDefaults as a Workflow, checking that they can be merged into
+// one
+func unstructuredToWorkflowDefaults(un *unstructured.Unstructured) (*wfv1.Workflow, error) {
+	// ClusterWorkflowDefaults and NamespaceWorkflowDefaults only differ by their scope
+	var v wfv1.NamespaceWorkflowDefaults
+	if err := util.FromUnstructuredObj(un, &v); err != nil {
+		return nil, fmt.Errorf("malformed workflow defaults: %w", err)
+	}
+	return defaults.ToWorkflow(&v.Spec)
+}
+
+// getWorkflowDefaults returns the defaults of the Workflows in the namespace. These are the NamespaceWorkflowDefaults
+// of the namespace, then the ClusterWorkflowDefaults, then the workflowDefaults of the controller ConfigMap, from the
+// highest precedence to the lowest.
+func (wfc *WorkflowController) getWorkflowDefaults(namespace string) (*wfv1.Workflow, error) {
+	return wfc.wfDefaults.GetWorkflowDefaults(namespace)
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestGetWorkflowDefaults(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		cancel, controller := newController()
+		defer cancel()
+		wfDefaults, err := controller.getWorkflowDefaults("my-ns")
+		require.NoError(t, err)
+		assert.Nil(t, wfDefaults)
+	})
+	t.Run("ConfigMap", func(t *testing.T) {
+		cancel, controller := newController()
+		defer cancel()
+		controller.Config.WorkflowDefaults = &wfv1.Workflow{Spec: wfv1.WorkflowSpec{ServiceAccountName: "configmap"}}
+		wfDefaults, err := controller.getWorkflowDefaults("my-ns")
+		require.NoError(t, err)
+		assert.Equal(t, "configmap", wfDefaults.Spec.ServiceAccountName)
+	})
+}
+
+func TestValidateWorkflowDefaults(t *testing.T) {
+	newWorkflowDefaults := func(parallelism interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "NamespaceWorkflowDefaults",
+			"metadata":   map[string]interface{}{"name": "my-defaults", "namespace": "my-ns"},
+			"spec":       map[string]interface{}{"workflowSpec": map[string]interface{}{"parallelism": parallelism}},
+		}}
+	}
+	cancel, controller := newController()
+	defer cancel()
+	recorder := controller.eventRecorderManager.(*testEventRecorderManager).eventRecorder
+
+	controller.validateWorkflowDefaults(newWorkflowDefaults(int64(1)))
+	assert.Empty(t, recorder.Events)
+
+	controller.validateWorkflowDefaults(newWorkflowDefaults("not-a-number"))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning InvalidWorkflowDefaults")
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"runtime/debug"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
@@ -156,6 +161,11 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 				return nil, err
 			}
 		}
+		addEnvSets(&c, tmpl.EnvSets)
+		if tmpl.Liveness != nil && c.Name == common.MainContainerName {
+			// the controller interprets the probe, so it must not restart the container
+			c.ReadinessProbe = tmpl.Liveness.Probe.DeepCopy()
+		}
 
 		mainCtrs[i] = c
 	}
@@ -190,7 +200,7 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 
 	pod := &apiv1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      util.GeneratePodName(woc.wf.Name, nodeName, tmpl.Name, nodeID, util.GetWorkflowPodNameVersion(woc.wf)),
+			Name:      util.GenerateWorkflowPodName(woc.wf, nodeName, tmpl.Name, nodeID),
 			Namespace: woc.wf.Namespace,
 			Labels: map[string]string{
 				common.LabelKeyWorkflow:  woc.wf.Name, // Allows filtering by pods related to specific workflow
@@ -233,6 +243,22 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 
 	woc.addArchiveLocation(tmpl)
 
+	if err := woc.addCheckpoint(pod, tmpl, nodeID); err != nil {
+		return nil, err
+	}
+
+	if message := woc.checkArtifactCircuits(pod, tmpl); message != "" {
+		woc.markNodePhase(nodeName, wfv1.NodeFailed, message)
+		return nil, nil
+	}
+
+	if woc.controller.Config.CheckInputArtifacts {
+		if message := woc.checkInputArtifactsExist(ctx, tmpl); message != "" {
+			woc.markNodePhase(nodeName, wfv1.NodeFailed, message)
+			return nil, nil
+		}
+	}
+
 	err = woc.setupServiceAccount(ctx, pod, tmpl)
 	if err != nil {
 		return nil, err
@@ -263,6 +289,10 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 	}
 	pod.Annotations[common.AnnotationKeyDefaultContainer] = defaultContainer
 
+	if tmpl.Liveness != nil {
+		pod.Annotations[common.AnnotationKeyLivenessFailureTimeout] = tmpl.Liveness.FailureTimeout
+	}
+
 	if podGC := woc.execWf.Spec.PodGC; podGC != nil {
 		pod.Annotations[common.AnnotationKeyPodGCStrategy] = fmt.Sprintf("%s/%s", podGC.GetStrategy(), woc.getPodGCDelay(podGC))
 	}
@@ -357,6 +387,8 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.Spec.Containers[i] = c
 	}
 
+	woc.addPodNetworkConfig(pod)
+
 	// Perform one last variable substitution here. Some variables come from the from workflow
 	// configmap (e.g. archive location) or volumes attribute, and were not substituted
 	// in executeTemplate.
@@ -527,10 +559,42 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.Spec.ActiveDeadlineSeconds = &newActiveDeadlineSeconds
 	}
 
+	// the pods of exit handlers run whenever the workflow completes, so that they always clean up after it
+	if !opts.onExitPod {
+		if opensAt := woc.schedulingWindowOpensAt(); !opensAt.IsZero() {
+			return nil, schedulingWindowClosedError{opensAt}
+		}
+	}
+
 	if !woc.controller.rateLimiter.Allow() {
 		return nil, ErrResourceRateLimitReached
 	}
 
+	if podCreationWorkers > 1 {
+		// the pod is created concurrently with the other pods once the workflow has been executed
+		if !slices.ContainsFunc(woc.podCreations, func(c podCreation) bool { return c.nodeName == nodeName }) {
+			woc.podCreations = append(woc.podCreations, podCreation{nodeName: nodeName, pod: pod})
+			woc.activePods++
+		}
+		return pod, nil
+	}
+
+	created, err := woc.createPod(ctx, nodeName, pod)
+	if err != nil {
+		return nil, err
+	}
+	woc.activePods++
+	return created, nil
+}
+
+// podCreation is a pod to create once the workflow has been executed
+type podCreation struct {
+	nodeName string
+	pod      *apiv1.Pod
+}
+
+// createPod creates the pod of the node, or gets it if it already exists
+func (woc *wfOperationCtx) createPod(ctx context.Context, nodeName string, pod *apiv1.Pod) (*apiv1.Pod, error) {
 	woc.log.Debugf("Creating Pod: %s (%s)", nodeName, pod.Name)
 
 	created, err := woc.controller.kubeclientset.CoreV1().Pods(woc.wf.ObjectMeta.Namespace).Create(ctx, pod, metav1.CreateOptions{})
@@ -540,7 +604,8 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 			// controller fails to persist the workflow after creating the pod.
 			woc.log.Infof("Failed pod %s (%s) creation: already exists", nodeName, pod.Name)
 			// get a reference to the currently existing Pod since the created pod returned before was nil.
-			if existing, err = woc.controller.kubeclientset.CoreV1().Pods(woc.wf.ObjectMeta.Namespace).Get(ctx, pod.Name, metav1.GetOptions{}); err == nil {
+			existing, err := woc.controller.kubeclientset.CoreV1().Pods(woc.wf.ObjectMeta.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if err == nil {
 				return existing, nil
 			}
 		}
@@ -551,10 +616,54 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		return nil, errors.InternalWrapError(err)
 	}
 	woc.log.Infof("Created pod: %s (%s)", nodeName, created.Name)
-	woc.activePods++
 	return created, nil
 }
 
+// createPendingPods creates the pods that were scheduled whilst the workflow was executed, with at most
+// podCreationWorkers at a time. The results are applied in the order that the pods were scheduled, so the resulting
+// status does not depend on the order that the creations complete in.
+func (woc *wfOperationCtx) createPendingPods(ctx context.Context) {
+	if len(woc.podCreations) == 0 {
+		return
+	}
+	errs := make([]error, len(woc.podCreations))
+	workers := make(chan struct{}, podCreationWorkers)
+	var wg sync.WaitGroup
+	for i, c := range woc.podCreations {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-workers }()
+			// this runs after operate has recovered from any panic, so a panic errors the node instead
+			defer func() {
+				if r := recover(); r != nil {
+					woc.log.WithFields(log.Fields{"stack": string(debug.Stack()), "r": r}).Errorf("Recovered from panic whilst creating pod %s", c.pod.Name)
+					woc.controller.metrics.OperationPanic(ctx)
+					errs[i] = fmt.Errorf("panic whilst creating pod %s: %v", c.pod.Name, r)
+				}
+			}()
+			_, errs[i] = woc.createPod(ctx, c.nodeName, c.pod)
+		}()
+	}
+	wg.Wait()
+	for i, c := range woc.podCreations {
+		err := errs[i]
+		if err == nil {
+			continue
+		}
+		woc.activePods--
+		if errorsutil.IsTransientErr(err) {
+			// the node is still pending, so the pod is created when the workflow is next operated on
+			woc.requeue()
+			woc.markNodePending(c.nodeName, err)
+			continue
+		}
+		woc.markNodeError(c.nodeName, err)
+	}
+	woc.podCreations = nil
+}
+
 func (woc *wfOperationCtx) podExists(nodeID string) (existing *apiv1.Pod, exists bool, err error) {
 	objs, err := woc.controller.PodController.GetPodsByIndex(indexes.NodeIDIndex, woc.wf.Namespace+"/"+nodeID)
 	if err != nil {
@@ -664,6 +773,14 @@ func (woc *wfOperationCtx) createEnvVars() []apiv1.EnvVar {
 			apiv1.EnvVar{Name: common.EnvVarInstanceID, Value: v},
 		)
 	}
+	if budget := woc.controller.Config.ArtifactOperations.GetRetry(); budget != nil {
+		if budget.MaxAttempts > 0 {
+			execEnvVars = append(execEnvVars, apiv1.EnvVar{Name: common.EnvVarArtifactRetryMaxAttempts, Value: strconv.Itoa(budget.MaxAttempts)})
+		}
+		if budget.MaxBackoff != nil {
+			execEnvVars = append(execEnvVars, apiv1.EnvVar{Name: common.EnvVarArtifactRetryMaxBackoff, Value: budget.MaxBackoff.Duration.String()})
+		}
+	}
 	if woc.controller.Config.Executor != nil {
 		execEnvVars = append(execEnvVars, woc.controller.Config.Executor.Env...)
 	}
@@ -747,6 +864,24 @@ func (woc *wfOperationCtx) newExecContainer(name string, tmpl *wfv1.Template) *a
 
 // addMetadata applies metadata specified in the template
 func (woc *wfOperationCtx) addMetadata(pod *apiv1.Pod, tmpl *wfv1.Template) {
+	if md := woc.execWf.Spec.WorkflowMetadata; md != nil && md.PropagateToPods {
+		// add the workflow's labels and annotations from its workflow metadata, as evaluated on the workflow,
+		// without overriding the pod's own
+		for _, k := range append(slices.Collect(maps.Keys(md.Labels)), slices.Collect(maps.Keys(md.LabelsFrom))...) {
+			if _, exists := pod.Labels[k]; !exists {
+				if v, ok := woc.wf.Labels[k]; ok {
+					pod.Labels[k] = v
+				}
+			}
+		}
+		for _, k := range append(slices.Collect(maps.Keys(md.Annotations)), slices.Collect(maps.Keys(md.AnnotationsFrom))...) {
+			if _, exists := pod.Annotations[k]; !exists {
+				if v, ok := woc.wf.Annotations[k]; ok {
+					pod.Annotations[k] = v
+				}
+			}
+		}
+	}
 	if woc.execWf.Spec.PodMetadata != nil {
 		// add workflow-level pod annotations and labels
 		for k, v := range woc.execWf.Spec.PodMetadata.Annotations {
@@ -776,6 +911,89 @@ func (woc *wfOperationCtx) addDNSConfig(pod *apiv1.Pod) {
 	}
 }
 
+// addPodNetworkConfig applies the controller's default DNS config, proxy, and trusted CA bundle to the pod.
+// The Workflow's DNS config and the containers' own environment variables take precedence.
+func (woc *wfOperationCtx) addPodNetworkConfig(pod *apiv1.Pod) {
+	c := woc.controller.Config.PodNetwork
+	if c == nil {
+		return
+	}
+	if pod.Spec.DNSConfig == nil && c.DNSConfig != nil {
+		pod.Spec.DNSConfig = c.DNSConfig.DeepCopy()
+	}
+	env := c.ProxyEnv()
+	if c.TrustedCABundle != nil {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, apiv1.Volume{
+			Name: common.TrustedCAVolumeName,
+			VolumeSource: apiv1.VolumeSource{
+				ConfigMap: &apiv1.ConfigMapVolumeSource{
+					LocalObjectReference: c.TrustedCABundle.LocalObjectReference,
+					Items:                []apiv1.KeyToPath{{Key: c.TrustedCABundle.Key, Path: common.TrustedCAFileName}},
+					Optional:             c.TrustedCABundle.Optional,
+				},
+			},
+		})
+		env = append(env, apiv1.EnvVar{Name: common.EnvVarSSLCertFile, Value: common.TrustedCAMountPath + "/" + common.TrustedCAFileName})
+	}
+	addNetworkConfig := func(ctr *apiv1.Container) {
+		for _, x := range env {
+			if !hasEnvVar(ctr.Env, x.Name) {
+				ctr.Env = append(ctr.Env, x)
+			}
+		}
+		if c.TrustedCABundle != nil {
+			ctr.VolumeMounts = append(ctr.VolumeMounts, apiv1.VolumeMount{Name: common.TrustedCAVolumeName, MountPath: common.TrustedCAMountPath, ReadOnly: true})
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		addNetworkConfig(&pod.Spec.InitContainers[i])
+	}
+	for i := range pod.Spec.Containers {
+		addNetworkConfig(&pod.Spec.Containers[i])
+	}
+}
+
+// addEnvSets merges the template's env sets into the container. Later sets take precedence over earlier ones, and
+// the container's own environment takes precedence over all sets.
+func addEnvSets(c *apiv1.Container, sets []wfv1.EnvSet) {
+	var envFrom []apiv1.EnvFromSource
+	var names []string
+	values := make(map[string]string)
+	for _, set := range sets {
+		if set.ConfigMapRef != nil || set.SecretRef != nil {
+			envFrom = append(envFrom, apiv1.EnvFromSource{Prefix: set.Prefix, ConfigMapRef: set.ConfigMapRef.DeepCopy(), SecretRef: set.SecretRef.DeepCopy()})
+		}
+		for _, name := range slices.Sorted(maps.Keys(set.Values)) {
+			if _, ok := values[name]; !ok {
+				names = append(names, name)
+			}
+			values[name] = set.Values[name]
+		}
+	}
+	// Kubernetes gives precedence to the last `envFrom` source, and to `env` over `envFrom`
+	if len(envFrom) > 0 {
+		c.EnvFrom = append(envFrom, c.EnvFrom...)
+	}
+	var env []apiv1.EnvVar
+	for _, name := range names {
+		if !hasEnvVar(c.Env, name) {
+			env = append(env, apiv1.EnvVar{Name: name, Value: values[name]})
+		}
+	}
+	if len(env) > 0 {
+		c.Env = append(env, c.Env...)
+	}
+}
+
+func hasEnvVar(env []apiv1.EnvVar, name string) bool {
+	for _, x := range env {
+		if x.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // addSchedulingConstraints applies any node selectors or affinity rules to the pod, either set in the workflow or the template
 func (woc *wfOperationCtx) addSchedulingConstraints(pod *apiv1.Pod, wfSpec *wfv1.WorkflowSpec, tmpl *wfv1.Template, nodeName string) {
 	// Get boundaryNode Template (if specified)
@@ -783,29 +1001,40 @@ func (woc *wfOperationCtx) addSchedulingConstraints(pod *apiv1.Pod, wfSpec *wfv1
 	if err != nil {
 		woc.log.Warnf("couldn't get boundaryTemplate through nodeName %s", nodeName)
 	}
-	// Set nodeSelector (if specified)
-	if len(tmpl.NodeSelector) > 0 {
-		pod.Spec.NodeSelector = tmpl.NodeSelector
-	} else if boundaryTemplate != nil && len(boundaryTemplate.NodeSelector) > 0 {
-		pod.Spec.NodeSelector = boundaryTemplate.NodeSelector
-	} else if len(wfSpec.NodeSelector) > 0 {
-		pod.Spec.NodeSelector = wfSpec.NodeSelector
-	}
-	// Set affinity (if specified)
-	if tmpl.Affinity != nil {
-		pod.Spec.Affinity = tmpl.Affinity
-	} else if boundaryTemplate != nil && boundaryTemplate.Affinity != nil {
-		pod.Spec.Affinity = boundaryTemplate.Affinity
-	} else if wfSpec.Affinity != nil {
-		pod.Spec.Affinity = wfSpec.Affinity
-	}
-	// Set tolerations (if specified)
-	if len(tmpl.Tolerations) > 0 {
-		pod.Spec.Tolerations = tmpl.Tolerations
-	} else if boundaryTemplate != nil && len(boundaryTemplate.Tolerations) > 0 {
-		pod.Spec.Tolerations = boundaryTemplate.Tolerations
-	} else if len(wfSpec.Tolerations) > 0 {
-		pod.Spec.Tolerations = wfSpec.Tolerations
+	if wfSpec.MergeStrategy == wfv1.SchedulingMergeStrategyMerge {
+		// the constraints of the workflow, the boundary template, and the template all apply
+		boundary := boundaryTemplate
+		if boundary == nil {
+			boundary = &wfv1.Template{}
+		}
+		pod.Spec.NodeSelector = mergeNodeSelectors(wfSpec.NodeSelector, boundary.NodeSelector, tmpl.NodeSelector)
+		pod.Spec.Affinity = mergeAffinities(wfSpec.Affinity, boundary.Affinity, tmpl.Affinity)
+		pod.Spec.Tolerations = mergeTolerations(wfSpec.Tolerations, boundary.Tolerations, tmpl.Tolerations)
+	} else {
+		// Set nodeSelector (if specified)
+		if len(tmpl.NodeSelector) > 0 {
+			pod.Spec.NodeSelector = tmpl.NodeSelector
+		} else if boundaryTemplate != nil && len(boundaryTemplate.NodeSelector) > 0 {
+			pod.Spec.NodeSelector = boundaryTemplate.NodeSelector
+		} else if len(wfSpec.NodeSelector) > 0 {
+			pod.Spec.NodeSelector = wfSpec.NodeSelector
+		}
+		// Set affinity (if specified)
+		if tmpl.Affinity != nil {
+			pod.Spec.Affinity = tmpl.Affinity
+		} else if boundaryTemplate != nil && boundaryTemplate.Affinity != nil {
+			pod.Spec.Affinity = boundaryTemplate.Affinity
+		} else if wfSpec.Affinity != nil {
+			pod.Spec.Affinity = wfSpec.Affinity
+		}
+		// Set tolerations (if specified)
+		if len(tmpl.Tolerations) > 0 {
+			pod.Spec.Tolerations = tmpl.Tolerations
+		} else if boundaryTemplate != nil && len(boundaryTemplate.Tolerations) > 0 {
+			pod.Spec.Tolerations = boundaryTemplate.Tolerations
+		} else if len(wfSpec.Tolerations) > 0 {
+			pod.Spec.Tolerations = wfSpec.Tolerations
+		}
 	}
 
 	// Set scheduler name (if specified)
@@ -819,6 +1048,8 @@ func (woc *wfOperationCtx) addSchedulingConstraints(pod *apiv1.Pod, wfSpec *wfv1
 		pod.Spec.PriorityClassName = tmpl.PriorityClassName
 	} else if wfSpec.PodPriorityClassName != "" {
 		pod.Spec.PriorityClassName = wfSpec.PodPriorityClassName
+	} else {
+		pod.Spec.PriorityClassName = woc.controller.Config.GetPodPriorityClassName(wfSpec.Priority)
 	}
 
 	// set hostaliases
@@ -853,6 +1084,9 @@ func (woc *wfOperationCtx) GetTemplateByBoundaryID(boundaryID string) (*wfv1.Tem
 	if err != nil {
 		return nil, false, err
 	}
+	if tmpl, ok := woc.dagTemplatesFrom[boundaryNode.Name]; ok {
+		return tmpl.DeepCopy(), false, nil
+	}
 	tmplCtx, err := woc.createTemplateContext(boundaryNode.GetTemplateScope())
 	if err != nil {
 		return nil, false, err
@@ -992,6 +1226,23 @@ func (woc *wfOperationCtx) addInputArtifactsVolumes(pod *apiv1.Pod, tmpl *wfv1.T
 	}
 	pod.Spec.Volumes = append(pod.Spec.Volumes, artVol)
 
+	// artifacts with stageInMemory are loaded into a tmpfs instead, which is limited in size as it uses the memory of
+	// the pod
+	var memVol *apiv1.Volume
+	if slices.ContainsFunc(tmpl.Inputs.Artifacts, func(art wfv1.Artifact) bool { return art.StageInMemory }) {
+		limit := woc.controller.Config.ArtifactOperations.GetMemoryStagingLimit()
+		memVol = &apiv1.Volume{
+			Name: "input-artifacts-memory",
+			VolumeSource: apiv1.VolumeSource{
+				EmptyDir: &apiv1.EmptyDirVolumeSource{
+					Medium:    apiv1.StorageMediumMemory,
+					SizeLimit: &limit,
+				},
+			},
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, *memVol)
+	}
+
 	for i, initCtr := range pod.Spec.InitContainers {
 		if initCtr.Name == common.InitContainerName {
 			volMount := apiv1.VolumeMount{
@@ -999,6 +1250,12 @@ func (woc *wfOperationCtx) addInputArtifactsVolumes(pod *apiv1.Pod, tmpl *wfv1.T
 				MountPath: common.ExecutorArtifactBaseDir,
 			}
 			initCtr.VolumeMounts = append(initCtr.VolumeMounts, volMount)
+			if memVol != nil {
+				initCtr.VolumeMounts = append(initCtr.VolumeMounts, apiv1.VolumeMount{
+					Name:      memVol.Name,
+					MountPath: common.ExecutorArtifactMemoryDir,
+				})
+			}
 
 			// We also add the user supplied mount paths to the init container,
 			// in case the executor needs to load artifacts to this volume
@@ -1044,6 +1301,9 @@ func (woc *wfOperationCtx) addInputArtifactsVolumes(pod *apiv1.Pod, tmpl *wfv1.T
 				MountPath: art.Path,
 				SubPath:   art.Name,
 			}
+			if art.StageInMemory {
+				volMount.Name = memVol.Name
+			}
 			c.VolumeMounts = append(c.VolumeMounts, volMount)
 		}
 		pod.Spec.Containers[i] = c
@@ -1107,6 +1367,12 @@ func (woc *wfOperationCtx) addArchiveLocation(tmpl *wfv1.Template) {
 	}
 	tmpl.ArchiveLocation = woc.artifactRepository.ToArtifactLocation()
 	tmpl.ArchiveLocation.ArchiveLogs = &archiveLogs
+	// logs are not ephemeral, so pods that archive them keep the default key
+	if prefix := woc.ephemeralArtifactPrefix(); prefix != "" && !archiveLogs {
+		if err := tmpl.ArchiveLocation.SetKey(prefix + "{{pod.name}}"); err != nil {
+			woc.log.WithError(err).Warn("Artifact repository does not support ephemeral artifacts")
+		}
+	}
 }
 
 // IsArchiveLogs determines if container should archive logs
@@ -1258,16 +1524,28 @@ func createSecretVolumesAndMounts(tmpl *wfv1.Template) ([]apiv1.Volume, []apiv1.
 
 	for volMountName, val := range allVolumesMap {
 		secretVolumes = append(secretVolumes, val)
-		secretVolMounts = append(secretVolMounts, apiv1.VolumeMount{
-			Name:      volMountName,
-			MountPath: common.SecretVolMountPath + "/" + val.Name,
-			ReadOnly:  true,
-		})
+		secretVolMounts = append(secretVolMounts, artifactVolumeMount(volMountName, val))
 	}
 
 	return secretVolumes, secretVolMounts
 }
 
+// artifactVolumeMount returns the mount of a volume of artifact locations: secrets are mounted read-only, and the
+// volumes of filesystem artifacts read-write, at the path that the driver expects
+func artifactVolumeMount(volMountName string, val apiv1.Volume) apiv1.VolumeMount {
+	if val.Secret == nil {
+		return apiv1.VolumeMount{
+			Name:      volMountName,
+			MountPath: common.ArtifactVolumeMountPath + "/" + strings.TrimPrefix(val.Name, common.ArtifactVolumePrefix),
+		}
+	}
+	return apiv1.VolumeMount{
+		Name:      volMountName,
+		MountPath: common.SecretVolMountPath + "/" + val.Name,
+		ReadOnly:  true,
+	}
+}
+
 func createArchiveLocationSecret(tmpl *wfv1.Template, volMap map[string]apiv1.Volume, uniqueKeyMap map[string]bool) {
 	if tmpl.ArchiveLocation == nil {
 		return
@@ -1289,11 +1567,7 @@ func createSecretVolumesAndMountsFromArtifactLocations(artifactLocations []*wfv1
 
 	for volMountName, val := range allVolumesMap {
 		secretVolumes = append(secretVolumes, val)
-		secretVolMounts = append(secretVolMounts, apiv1.VolumeMount{
-			Name:      volMountName,
-			MountPath: common.SecretVolMountPath + "/" + val.Name,
-			ReadOnly:  true,
-		})
+		secretVolMounts = append(secretVolMounts, artifactVolumeMount(volMountName, val))
 	}
 
 	return secretVolumes, secretVolMounts
@@ -1332,6 +1606,7 @@ func createSecretVolumesFromArtifactLocations(volMap map[string]apiv1.Volume, ar
 			createSecretVal(volMap, artifactLocation.OSS.SecretKeySecret, keyMap)
 		} else if artifactLocation.GCS != nil {
 			createSecretVal(volMap, artifactLocation.GCS.ServiceAccountKeySecret, keyMap)
+			createSecretVal(volMap, artifactLocation.GCS.ExternalAccountSecret, keyMap)
 		} else if artifactLocation.HTTP != nil && artifactLocation.HTTP.Auth != nil {
 			createSecretVal(volMap, artifactLocation.HTTP.Auth.BasicAuth.UsernameSecret, keyMap)
 			createSecretVal(volMap, artifactLocation.HTTP.Auth.BasicAuth.PasswordSecret, keyMap)
@@ -1342,6 +1617,22 @@ func createSecretVolumesFromArtifactLocations(volMap map[string]apiv1.Volume, ar
 			createSecretVal(volMap, artifactLocation.HTTP.Auth.OAuth2.TokenURLSecret, keyMap)
 		} else if artifactLocation.Azure != nil {
 			createSecretVal(volMap, artifactLocation.Azure.AccountKeySecret, keyMap)
+		} else if artifactLocation.B2 != nil {
+			createSecretVal(volMap, artifactLocation.B2.ApplicationKeyIDSecret, keyMap)
+			createSecretVal(volMap, artifactLocation.B2.ApplicationKeySecret, keyMap)
+		} else if artifactLocation.IBMCOS != nil {
+			createSecretVal(volMap, artifactLocation.IBMCOS.APIKeySecret, keyMap)
+		} else if artifactLocation.OCI != nil {
+			createSecretVal(volMap, artifactLocation.OCI.UsernameSecret, keyMap)
+			createSecretVal(volMap, artifactLocation.OCI.PasswordSecret, keyMap)
+		} else if artifactLocation.SFTP != nil {
+			createSecretVal(volMap, artifactLocation.SFTP.UsernameSecret, keyMap)
+			createSecretVal(volMap, artifactLocation.SFTP.PasswordSecret, keyMap)
+			createSecretVal(volMap, artifactLocation.SFTP.PrivateKeySecret, keyMap)
+		} else if artifactLocation.Filesystem != nil && artifactLocation.Filesystem.Volume.Name != "" {
+			volume := *artifactLocation.Filesystem.Volume.DeepCopy()
+			volume.Name = common.ArtifactVolumePrefix + volume.Name
+			volMap[volume.Name] = volume
 		}
 	}
 }
@@ -15,6 +15,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
 	"github.com/argoproj/argo-workflows/v3/config"
@@ -183,6 +184,49 @@ func TestScriptTemplateWithoutVolumeOptionalArtifact(t *testing.T) {
 	assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts, customVolumeMountForInit)
 }
 
+var scriptTemplateWithInputArtifactStagedInMemory = `
+name: script-with-input-artifact
+inputs:
+  artifacts:
+  - name: config
+    path: /config
+    stageInMemory: true
+    http:
+        url: https://raw.githubusercontent.com/argoproj/argo-workflows/stable/manifests/install.yaml
+  - name: manifest
+    path: /manifest
+    http:
+        url: https://raw.githubusercontent.com/argoproj/argo-workflows/stable/manifests/install.yaml
+script:
+  image: alpine:latest
+  command: [sh]
+  source: |
+    ls -al
+`
+
+// TestInputArtifactStagedInMemory verifies that artifacts with stageInMemory are mounted from a tmpfs
+func TestInputArtifactStagedInMemory(t *testing.T) {
+	tmpl := unmarshalTemplate(scriptTemplateWithInputArtifactStagedInMemory)
+	woc := newWoc()
+	limit := resource.MustParse("1Mi")
+	woc.controller.Config.ArtifactOperations = &config.ArtifactOperationsConfig{MemoryStagingLimit: &limit}
+	mainCtr := tmpl.Script.Container
+	mainCtr.Args = append(mainCtr.Args, common.ExecutorScriptSourcePath)
+	ctx := context.Background()
+	pod, err := woc.createWorkflowPod(ctx, tmpl.Name, []apiv1.Container{mainCtr}, tmpl, &createWorkflowPodOpts{})
+	require.NoError(t, err)
+	assert.Contains(t, pod.Spec.Volumes, apiv1.Volume{
+		Name: "input-artifacts-memory",
+		VolumeSource: apiv1.VolumeSource{
+			EmptyDir: &apiv1.EmptyDirVolumeSource{Medium: apiv1.StorageMediumMemory, SizeLimit: &limit},
+		},
+	})
+	assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts, apiv1.VolumeMount{Name: "input-artifacts-memory", MountPath: common.ExecutorArtifactMemoryDir})
+	// Note: pod.Spec.Containers[0] is wait
+	assert.Contains(t, pod.Spec.Containers[1].VolumeMounts, apiv1.VolumeMount{Name: "input-artifacts-memory", MountPath: "/config", SubPath: "config"})
+	assert.Contains(t, pod.Spec.Containers[1].VolumeMounts, apiv1.VolumeMount{Name: "input-artifacts", MountPath: "/manifest", SubPath: "manifest"})
+}
+
 // TestWFLevelServiceAccount verifies the ability to carry forward the service account name
 // for the pod from workflow.spec.serviceAccountName.
 func TestWFLevelServiceAccount(t *testing.T) {
@@ -436,6 +480,30 @@ func TestTolerations(t *testing.T) {
 	assert.Equal(t, "nvidia.com/gpu", pod.Spec.Tolerations[0].Key)
 }
 
+// TestMergeSchedulingConstraints verifies that the scheduling constraints of the workflow and template are merged
+func TestMergeSchedulingConstraints(t *testing.T) {
+	woc := newWoc()
+	woc.execWf.Spec.MergeStrategy = wfv1.SchedulingMergeStrategyMerge
+	woc.execWf.Spec.NodeSelector = map[string]string{"pool": "default", "zone": "a"}
+	woc.execWf.Spec.Tolerations = []apiv1.Toleration{{Key: "dedicated", Operator: "Exists"}}
+	woc.execWf.Spec.Templates[0].NodeSelector = map[string]string{"pool": "gpu"}
+	woc.execWf.Spec.Templates[0].Tolerations = []apiv1.Toleration{{Key: "nvidia.com/gpu", Operator: "Exists"}}
+	tmplCtx, err := woc.createTemplateContext(wfv1.ResourceScopeLocal, "")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = woc.executeContainer(ctx, woc.execWf.Spec.Entrypoint, tmplCtx.GetTemplateScope(), &woc.execWf.Spec.Templates[0], &wfv1.WorkflowStep{}, &executeTemplateOpts{})
+	require.NoError(t, err)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	pod := pods.Items[0]
+	assert.Equal(t, map[string]string{"pool": "gpu", "zone": "a"}, pod.Spec.NodeSelector)
+	require.Len(t, pod.Spec.Tolerations, 2)
+	assert.Equal(t, "dedicated", pod.Spec.Tolerations[0].Key)
+	assert.Equal(t, "nvidia.com/gpu", pod.Spec.Tolerations[1].Key)
+}
+
 // TestMetadata verifies ability to carry forward annotations and labels
 func TestMetadata(t *testing.T) {
 	woc := newWoc()
@@ -880,6 +948,40 @@ func TestPriorityClass(t *testing.T) {
 	assert.Equal(t, "foo", pod.Spec.PriorityClassName)
 }
 
+// TestPriorityClassFromWorkflowPriority verifies that the priority of the workflow is mapped to a priorityClassName,
+// unless the workflow or template has one
+func TestPriorityClassFromWorkflowPriority(t *testing.T) {
+	for name, tt := range map[string]struct {
+		priority             *int32
+		podPriorityClassName string
+		expected             string
+	}{
+		"High":     {priority: ptr.To(int32(10)), expected: "high"},
+		"Default":  {expected: "default"},
+		"Unmapped": {priority: ptr.To(int32(-10))},
+		"Workflow": {priority: ptr.To(int32(10)), podPriorityClassName: "foo", expected: "foo"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			woc := newWoc()
+			woc.controller.Config.PodPriorityClasses = []config.PodPriorityClass{
+				{Min: ptr.To(int32(10)), PriorityClassName: "high"},
+				{Min: ptr.To(int32(0)), PriorityClassName: "default"},
+			}
+			woc.execWf.Spec.Priority = tt.priority
+			woc.execWf.Spec.PodPriorityClassName = tt.podPriorityClassName
+			tmplCtx, err := woc.createTemplateContext(wfv1.ResourceScopeLocal, "")
+			require.NoError(t, err)
+			_, err = woc.executeContainer(ctx, woc.execWf.Spec.Entrypoint, tmplCtx.GetTemplateScope(), &woc.execWf.Spec.Templates[0], &wfv1.WorkflowStep{}, &executeTemplateOpts{})
+			require.NoError(t, err)
+			pods, err := listPods(woc)
+			require.NoError(t, err)
+			require.Len(t, pods.Items, 1)
+			assert.Equal(t, tt.expected, pods.Items[0].Spec.PriorityClassName)
+		})
+	}
+}
+
 // TestSchedulerName verifies the ability to carry forward schedulerName.
 func TestSchedulerName(t *testing.T) {
 	ctx := context.Background()
@@ -1153,6 +1255,104 @@ func TestTmplLevelSecurityContext(t *testing.T) {
 	assert.Equal(t, runAsUser, *pod.Spec.SecurityContext.RunAsUser)
 }
 
+func TestPodNetworkConfig(t *testing.T) {
+	podNetwork := &config.PodNetworkConfig{
+		DNSConfig:  &apiv1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}},
+		HTTPProxy:  "http://proxy:3128",
+		HTTPSProxy: "http://proxy:3128",
+		NoProxy:    ".svc,.cluster.local",
+		TrustedCABundle: &apiv1.ConfigMapKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: "my-ca"},
+			Key:                  "ca.crt",
+		},
+	}
+	createPod := func(t *testing.T, woc *wfOperationCtx) *apiv1.Pod {
+		t.Helper()
+		woc.controller.Config.PodNetwork = podNetwork
+		tmplCtx, err := woc.createTemplateContext(wfv1.ResourceScopeLocal, "")
+		require.NoError(t, err)
+		_, err = woc.executeContainer(context.Background(), woc.execWf.Spec.Entrypoint, tmplCtx.GetTemplateScope(), &woc.execWf.Spec.Templates[0], &wfv1.WorkflowStep{}, &executeTemplateOpts{})
+		require.NoError(t, err)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		require.Len(t, pods.Items, 1)
+		return &pods.Items[0]
+	}
+	t.Run("Defaults", func(t *testing.T) {
+		pod := createPod(t, newWoc())
+		assert.Equal(t, podNetwork.DNSConfig, pod.Spec.DNSConfig)
+		assert.Contains(t, pod.Spec.Volumes, apiv1.Volume{
+			Name: common.TrustedCAVolumeName,
+			VolumeSource: apiv1.VolumeSource{ConfigMap: &apiv1.ConfigMapVolumeSource{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: "my-ca"},
+				Items:                []apiv1.KeyToPath{{Key: "ca.crt", Path: common.TrustedCAFileName}},
+			}},
+		})
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			assert.Contains(t, c.Env, apiv1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy:3128"}, c.Name)
+			assert.Contains(t, c.Env, apiv1.EnvVar{Name: "https_proxy", Value: "http://proxy:3128"}, c.Name)
+			assert.Contains(t, c.Env, apiv1.EnvVar{Name: "NO_PROXY", Value: ".svc,.cluster.local"}, c.Name)
+			assert.Contains(t, c.Env, apiv1.EnvVar{Name: common.EnvVarSSLCertFile, Value: "/etc/argo/trusted-ca/ca-bundle.crt"}, c.Name)
+			assert.Contains(t, c.VolumeMounts, apiv1.VolumeMount{Name: common.TrustedCAVolumeName, MountPath: common.TrustedCAMountPath, ReadOnly: true}, c.Name)
+		}
+	})
+	t.Run("Overridden", func(t *testing.T) {
+		woc := newWoc()
+		woc.execWf.Spec.DNSConfig = &apiv1.PodDNSConfig{Nameservers: []string{"8.8.8.8"}}
+		woc.execWf.Spec.Templates[0].Container.Env = []apiv1.EnvVar{{Name: "HTTP_PROXY", Value: "http://other:8080"}}
+		pod := createPod(t, woc)
+		assert.Equal(t, []string{"8.8.8.8"}, pod.Spec.DNSConfig.Nameservers)
+		main := pod.Spec.Containers[1]
+		assert.Equal(t, common.MainContainerName, main.Name)
+		assert.Contains(t, main.Env, apiv1.EnvVar{Name: "HTTP_PROXY", Value: "http://other:8080"})
+		assert.NotContains(t, main.Env, apiv1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy:3128"})
+	})
+}
+
+func TestEnvSets(t *testing.T) {
+	woc := newWoc()
+	tmpl := &woc.execWf.Spec.Templates[0]
+	tmpl.Container.Env = []apiv1.EnvVar{{Name: "OWN", Value: "container"}}
+	tmpl.EnvSets = []wfv1.EnvSet{
+		{ConfigMapRef: &apiv1.ConfigMapEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-config"}}, Prefix: "CONFIG_"},
+		{Values: map[string]string{"REGION": "eu-west-1", "OWN": "env-set"}},
+		{SecretRef: &apiv1.SecretEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-secret"}}, Values: map[string]string{"REGION": "us-east-1"}},
+	}
+	tmplCtx, err := woc.createTemplateContext(wfv1.ResourceScopeLocal, "")
+	require.NoError(t, err)
+	_, err = woc.executeContainer(context.Background(), woc.execWf.Spec.Entrypoint, tmplCtx.GetTemplateScope(), tmpl, &wfv1.WorkflowStep{}, &executeTemplateOpts{})
+	require.NoError(t, err)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	main := pods.Items[0].Spec.Containers[1]
+	assert.Equal(t, []apiv1.EnvFromSource{
+		{Prefix: "CONFIG_", ConfigMapRef: &apiv1.ConfigMapEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-config"}}},
+		{SecretRef: &apiv1.SecretEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-secret"}}},
+	}, main.EnvFrom)
+	assert.Contains(t, main.Env, apiv1.EnvVar{Name: "REGION", Value: "us-east-1"}, "later sets take precedence")
+	assert.Contains(t, main.Env, apiv1.EnvVar{Name: "OWN", Value: "container"}, "the container takes precedence")
+	assert.NotContains(t, main.Env, apiv1.EnvVar{Name: "OWN", Value: "env-set"})
+}
+
+func TestLiveness(t *testing.T) {
+	woc := newWoc()
+	tmpl := &woc.execWf.Spec.Templates[0]
+	probe := apiv1.Probe{ProbeHandler: apiv1.ProbeHandler{HTTPGet: &apiv1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)}}, PeriodSeconds: 30}
+	tmpl.Liveness = &wfv1.Liveness{Probe: probe, FailureTimeout: "10m"}
+	tmplCtx, err := woc.createTemplateContext(wfv1.ResourceScopeLocal, "")
+	require.NoError(t, err)
+	_, err = woc.executeContainer(context.Background(), woc.execWf.Spec.Entrypoint, tmplCtx.GetTemplateScope(), tmpl, &wfv1.WorkflowStep{}, &executeTemplateOpts{})
+	require.NoError(t, err)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	pod := pods.Items[0]
+	assert.Equal(t, "10m", pod.Annotations[common.AnnotationKeyLivenessFailureTimeout])
+	assert.Equal(t, &probe, pod.Spec.Containers[1].ReadinessProbe)
+	assert.Nil(t, pod.Spec.Containers[1].LivenessProbe, "the controller interprets the probe, so the container is not restarted")
+}
+
 func Test_createSecretVolumesFromArtifactLocations_SSECUsed(t *testing.T) {
 	ctx := context.Background()
 
@@ -1332,6 +1532,62 @@ func TestCreateSecretVolumesFromArtifactLocationsSessionToken(t *testing.T) {
 	}
 }
 
+func TestCreateVolumesFromFilesystemArtifactLocations(t *testing.T) {
+	ctx := context.Background()
+
+	cancel, controller := newControllerWithComplexDefaults()
+	defer cancel()
+
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	wf.Spec.Templates[0].Outputs = wfv1.Outputs{
+		Artifacts: []wfv1.Artifact{{Name: "foo", Path: "/tmp/file"}},
+	}
+	woc := newWorkflowOperationCtx(wf, controller)
+	setArtifactRepository(woc.controller,
+		&wfv1.ArtifactRepository{
+			Filesystem: &wfv1.FilesystemArtifactRepository{
+				FilesystemConfig: wfv1.FilesystemConfig{
+					Volume: apiv1.Volume{
+						Name: "shared",
+						VolumeSource: apiv1.VolumeSource{
+							PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: "artifacts"},
+						},
+					},
+				},
+			},
+		},
+	)
+
+	wantedVolume := apiv1.Volume{
+		Name: "artifacts-shared",
+		VolumeSource: apiv1.VolumeSource{
+			PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: "artifacts"},
+		},
+	}
+	wantedVolumeMount := apiv1.VolumeMount{
+		Name:      "artifacts-shared",
+		MountPath: path.Join(common.ArtifactVolumeMountPath, "shared"),
+	}
+
+	err := woc.setExecWorkflow(ctx)
+	require.NoError(t, err)
+	woc.operate(ctx)
+
+	mainCtr := woc.execWf.Spec.Templates[0].Container
+	for i := 1; i < 5; i++ {
+		pod, _ := woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*mainCtr}, &wf.Spec.Templates[0], &createWorkflowPodOpts{})
+		if pod != nil {
+			assert.Contains(t, pod.Spec.Volumes, wantedVolume)
+			for _, c := range pod.Spec.Containers {
+				if c.Name == common.WaitContainerName {
+					assert.Contains(t, c.VolumeMounts, wantedVolumeMount)
+				}
+			}
+			break
+		}
+	}
+}
+
 var helloWorldWfWithPatch = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -18,6 +18,7 @@ const (
 	ActionUpdate    ActionType = "Update"
 	ActionSuspend   ActionType = "Suspend"
 	ActionStop      ActionType = "Stop"
+	ActionDrain     ActionType = "Drain"
 	ActionTerminate ActionType = "Terminate"
 	ActionResume    ActionType = "Resume"
 	ActionNone      ActionType = ""
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	gosync "sync"
 	"time"
 
 	"github.com/argoproj/pkg/sync"
@@ -21,9 +22,11 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
@@ -31,6 +34,7 @@ import (
 	wfctx "github.com/argoproj/argo-workflows/v3/util/context"
 	"github.com/argoproj/argo-workflows/v3/util/env"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/defaults"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
@@ -48,12 +52,17 @@ type Controller struct {
 	cronWfInformer       informers.GenericInformer
 	wftmplInformer       wfextvv1alpha1.WorkflowTemplateInformer
 	cwftmplInformer      wfextvv1alpha1.ClusterWorkflowTemplateInformer
-	wfDefaults           *v1alpha1.Workflow
+	wfDefaults           defaults.Getter
 	cronWfQueue          workqueue.TypedRateLimitingInterface[string]
 	dynamicInterface     dynamic.Interface
 	metrics              *metrics.Metrics
 	eventRecorderManager events.EventRecorderManager
 	cronWorkflowWorkers  int
+	kubeclientset        kubernetes.Interface
+	imagePrePull         *config.ImagePrePull
+	executorImage        string
+	// imagePrePulls is the images of the DaemonSets that pre-pull images, by the key of their cron workflow
+	imagePrePulls gosync.Map
 }
 
 const (
@@ -74,7 +83,8 @@ func init() {
 }
 
 func NewCronController(ctx context.Context, wfclientset versioned.Interface, dynamicInterface dynamic.Interface, namespace string, managedNamespace string, instanceID string, metrics *metrics.Metrics,
-	eventRecorderManager events.EventRecorderManager, cronWorkflowWorkers int, wftmplInformer wfextvv1alpha1.WorkflowTemplateInformer, cwftmplInformer wfextvv1alpha1.ClusterWorkflowTemplateInformer, wfDefaults *v1alpha1.Workflow) *Controller {
+	eventRecorderManager events.EventRecorderManager, cronWorkflowWorkers int, wftmplInformer wfextvv1alpha1.WorkflowTemplateInformer, cwftmplInformer wfextvv1alpha1.ClusterWorkflowTemplateInformer, wfDefaults defaults.Getter,
+	kubeclientset kubernetes.Interface, imagePrePull *config.ImagePrePull, executorImage string) *Controller {
 	return &Controller{
 		wfClientset:          wfclientset,
 		namespace:            namespace,
@@ -90,6 +100,9 @@ func NewCronController(ctx context.Context, wfclientset versioned.Interface, dyn
 		wftmplInformer:       wftmplInformer,
 		cwftmplInformer:      cwftmplInformer,
 		cronWorkflowWorkers:  cronWorkflowWorkers,
+		kubeclientset:        kubeclientset,
+		imagePrePull:         imagePrePull,
+		executorImage:        executorImage,
 	}
 }
 
@@ -117,6 +130,12 @@ func (cc *Controller) Run(ctx context.Context) {
 
 	cc.wfLister = util.NewWorkflowLister(wfInformer)
 
+	if cc.imagePrePull != nil {
+		if err := cc.loadImagePrePulls(ctx); err != nil {
+			log.WithError(err).Error("Failed to list the DaemonSets that pre-pull images")
+		}
+	}
+
 	cc.cron.Start()
 	defer cc.cron.Stop()
 
@@ -269,6 +288,7 @@ func (cc *Controller) syncAll(ctx context.Context) {
 	groupedWorkflows := groupWorkflows(workflows)
 
 	cronWorkflows := cc.cronWfInformer.Informer().GetStore().List()
+	synced := make(map[string]bool, len(cronWorkflows))
 	for _, obj := range cronWorkflows {
 		un, ok := obj.(*unstructured.Unstructured)
 		if !ok {
@@ -282,12 +302,16 @@ func (cc *Controller) syncAll(ctx context.Context) {
 			continue
 		}
 
+		synced[cronWf.Namespace+"/"+cronWf.Name] = true
 		err = cc.syncCronWorkflow(ctx, cronWf, groupedWorkflows[cronWf.UID])
 		if err != nil {
 			log.WithError(err).Error("Unable to sync CronWorkflow")
 			continue
 		}
 	}
+	if cc.imagePrePull != nil {
+		cc.deleteStaleImagePrePulls(ctx, synced)
+	}
 }
 
 func (cc *Controller) syncCronWorkflow(ctx context.Context, cronWf *v1alpha1.CronWorkflow, workflows []v1alpha1.Workflow) error {
@@ -304,6 +328,12 @@ func (cc *Controller) syncCronWorkflow(ctx context.Context, cronWf *v1alpha1.Cro
 	if err != nil {
 		return err
 	}
+	if cc.imagePrePull != nil {
+		err = cc.syncImagePrePull(ctx, cronWf)
+		if err != nil {
+			return fmt.Errorf("failed to pre-pull images: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,224 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// prePullResources are the resources of each container of the pods that pre-pull images, which only pull the images
+// and run argoexec version
+var prePullResources = apiv1.ResourceRequirements{
+	Requests: apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.MustParse("10m"),
+		apiv1.ResourceMemory: resource.MustParse("32Mi"),
+	},
+	Limits: apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.MustParse("100m"),
+		apiv1.ResourceMemory: resource.MustParse("64Mi"),
+	},
+}
+
+// imagePrePullName returns the name of the DaemonSet that pre-pulls the images of the cron workflow
+func imagePrePullName(cronWfName string) string {
+	return cronWfName + "-pre-pull"
+}
+
+// prePullImages returns the images of the templates of the cron workflow that set prePull, in order
+func prePullImages(cronWf *v1alpha1.CronWorkflow) []string {
+	var images []string
+	for _, tmpl := range cronWf.Spec.WorkflowSpec.Templates {
+		if !tmpl.PrePull {
+			continue
+		}
+		switch {
+		case tmpl.Container != nil:
+			images = append(images, tmpl.Container.Image)
+		case tmpl.Script != nil:
+			images = append(images, tmpl.Script.Image)
+		case tmpl.ContainerSet != nil:
+			for _, c := range tmpl.ContainerSet.Containers {
+				images = append(images, c.Image)
+			}
+		}
+	}
+	images = slices.DeleteFunc(images, func(image string) bool { return image == "" || strings.Contains(image, "{{") })
+	slices.Sort(images)
+	return slices.Compact(images)
+}
+
+// nextScheduledTime returns the next time after now that the cron workflow is scheduled to run at
+func nextScheduledTime(ctx context.Context, cronWf *v1alpha1.CronWorkflow, now time.Time) (time.Time, error) {
+	var next time.Time
+	for _, schedule := range cronWf.Spec.GetSchedulesWithTimezone(ctx) {
+		s, err := cron.ParseStandard(schedule)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t := s.Next(now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next, nil
+}
+
+// isImagePrePullDue returns whether the images of the cron workflow are to be pre-pulled now, as its next run is
+// within the lead time
+func isImagePrePullDue(ctx context.Context, cronWf *v1alpha1.CronWorkflow, leadTime time.Duration, now time.Time) (bool, error) {
+	if cronWf.Spec.Suspend || cronWf.Status.Phase == v1alpha1.StoppedPhase || cronWf.DeletionTimestamp != nil {
+		return false, nil
+	}
+	next, err := nextScheduledTime(ctx, cronWf, now)
+	if err != nil {
+		return false, err
+	}
+	return !next.IsZero() && next.Sub(now) <= leadTime, nil
+}
+
+// loadImagePrePulls records the DaemonSets that pre-pull images that exist, so that those that are no longer due are
+// deleted after the controller restarts
+func (cc *Controller) loadImagePrePulls(ctx context.Context) error {
+	list, err := cc.kubeclientset.AppsV1().DaemonSets(cc.managedNamespace).List(ctx, metav1.ListOptions{LabelSelector: common.LabelKeyImagePrePull})
+	if err != nil {
+		return err
+	}
+	for _, ds := range list.Items {
+		var images []string
+		for i, c := range ds.Spec.Template.Spec.InitContainers {
+			if i > 0 {
+				images = append(images, c.Image)
+			}
+		}
+		cc.imagePrePulls.Store(ds.Namespace+"/"+ds.Labels[common.LabelKeyCronWorkflow], strings.Join(images, ","))
+	}
+	return nil
+}
+
+// syncImagePrePull creates the DaemonSet that pre-pulls the images of the cron workflow when its next run is due,
+// and deletes it once it is not
+func (cc *Controller) syncImagePrePull(ctx context.Context, cronWf *v1alpha1.CronWorkflow) error {
+	key := cronWf.Namespace + "/" + cronWf.Name
+	images := prePullImages(cronWf)
+	due := false
+	if len(images) > 0 {
+		var err error
+		due, err = isImagePrePullDue(ctx, cronWf, cc.imagePrePull.GetLeadTime(), time.Now())
+		if err != nil {
+			return err
+		}
+	}
+	current, exists := cc.imagePrePulls.Load(key)
+	daemonSets := cc.kubeclientset.AppsV1().DaemonSets(cronWf.Namespace)
+	logCtx := log.WithFields(log.Fields{"namespace": cronWf.Namespace, "cronWorkflow": cronWf.Name})
+	if !due {
+		if !exists {
+			return nil
+		}
+		err := daemonSets.Delete(ctx, imagePrePullName(cronWf.Name), metav1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+		cc.imagePrePulls.Delete(key)
+		logCtx.Info("Deleted the DaemonSet that pre-pulled the images of the cron workflow")
+		return nil
+	}
+	value := strings.Join(images, ",")
+	if exists && current == value {
+		return nil
+	}
+	ds := cc.imagePrePullDaemonSet(cronWf, images)
+	_, err := daemonSets.Create(ctx, ds, metav1.CreateOptions{})
+	if apierr.IsAlreadyExists(err) {
+		_, err = daemonSets.Update(ctx, ds, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	cc.imagePrePulls.Store(key, value)
+	logCtx.WithField("images", images).Info("Pre-pulling the images of the cron workflow before its next run")
+	return nil
+}
+
+// deleteStaleImagePrePulls deletes the DaemonSets that pre-pull the images of cron workflows that were not synced, as
+// they were deleted or completed
+func (cc *Controller) deleteStaleImagePrePulls(ctx context.Context, synced map[string]bool) {
+	cc.imagePrePulls.Range(func(k, _ any) bool {
+		key := k.(string)
+		if synced[key] {
+			return true
+		}
+		namespace, name, _ := strings.Cut(key, "/")
+		err := cc.kubeclientset.AppsV1().DaemonSets(namespace).Delete(ctx, imagePrePullName(name), metav1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			log.WithError(err).WithField("cronWorkflow", key).Error("Failed to delete the DaemonSet that pre-pulled images")
+			return true
+		}
+		cc.imagePrePulls.Delete(key)
+		return true
+	})
+}
+
+// imagePrePullDaemonSet returns the DaemonSet that pre-pulls the images onto the nodes that the pods of the cron
+// workflow may run on. Its first init container copies argoexec into a shared volume, and each of the others pulls
+// one of the images and runs argoexec version from it. A pause container then keeps the pod running until the
+// DaemonSet is deleted.
+func (cc *Controller) imagePrePullDaemonSet(cronWf *v1alpha1.CronWorkflow, images []string) *appsv1.DaemonSet {
+	name := imagePrePullName(cronWf.Name)
+	labels := map[string]string{common.LabelKeyImagePrePull: name, common.LabelKeyCronWorkflow: cronWf.Name}
+	volumeMounts := []apiv1.VolumeMount{{Name: "var-run-argo", MountPath: common.VarRunArgoPath}}
+	initContainers := []apiv1.Container{{
+		Name:         "init",
+		Image:        cc.executorImage,
+		Command:      []string{"argoexec", "pre-pull"},
+		Resources:    prePullResources,
+		VolumeMounts: volumeMounts,
+	}}
+	for i, image := range images {
+		initContainers = append(initContainers, apiv1.Container{
+			Name:            fmt.Sprintf("pre-pull-%d", i),
+			Image:           image,
+			ImagePullPolicy: apiv1.PullIfNotPresent,
+			Command:         []string{common.VarRunArgoPath + "/argoexec", "version"},
+			Resources:       prePullResources,
+			VolumeMounts:    volumeMounts,
+		})
+	}
+	spec := cronWf.Spec.WorkflowSpec
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronWf, v1alpha1.SchemeGroupVersion.WithKind("CronWorkflow")),
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{common.LabelKeyImagePrePull: name}},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: apiv1.PodSpec{
+					InitContainers:    initContainers,
+					Containers:        []apiv1.Container{{Name: "pause", Image: cc.imagePrePull.GetPauseImage(), Resources: prePullResources}},
+					Volumes:           []apiv1.Volume{{Name: "var-run-argo", VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}}}},
+					NodeSelector:      spec.NodeSelector,
+					Tolerations:       spec.Tolerations,
+					ImagePullSecrets:  spec.ImagePullSecrets,
+					PriorityClassName: cc.imagePrePull.PriorityClassName,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,145 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var prePullCronWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: nightly
+  namespace: argo
+  uid: 2e4b8d5e-8d4c-4bd6-9f5b-2c1fdc6a2a5a
+spec:
+  schedules:
+    - "0 2 * * *"
+  workflowSpec:
+    entrypoint: main
+    nodeSelector:
+      pool: batch
+    templates:
+    - name: main
+      steps:
+      - - name: train
+          template: train
+        - name: report
+          template: report
+    - name: train
+      prePull: true
+      container:
+        image: my-registry/trainer:v1
+    - name: report
+      prePull: true
+      script:
+        image: python:3.12
+        source: print("done")
+    - name: notify
+      container:
+        image: curlimages/curl:latest
+`
+
+func TestPrePullImages(t *testing.T) {
+	cronWf := v1alpha1.MustUnmarshalCronWorkflow(prePullCronWf)
+	assert.Equal(t, []string{"my-registry/trainer:v1", "python:3.12"}, prePullImages(cronWf))
+
+	cronWf.Spec.WorkflowSpec.Templates[1].PrePull = false
+	cronWf.Spec.WorkflowSpec.Templates[2].Script.Image = "{{workflow.parameters.image}}"
+	assert.Empty(t, prePullImages(cronWf))
+}
+
+func TestIsImagePrePullDue(t *testing.T) {
+	ctx := context.Background()
+	cronWf := v1alpha1.MustUnmarshalCronWorkflow(prePullCronWf)
+	leadTime := 10 * time.Minute
+	for _, tt := range []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"WithinLeadTime", time.Date(2026, 10, 16, 1, 55, 0, 0, time.Local), true},
+		{"BeforeLeadTime", time.Date(2026, 10, 16, 1, 45, 0, 0, time.Local), false},
+		{"AfterRun", time.Date(2026, 10, 16, 2, 5, 0, 0, time.Local), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			due, err := isImagePrePullDue(ctx, cronWf, leadTime, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, due)
+		})
+	}
+	t.Run("Suspended", func(t *testing.T) {
+		suspended := cronWf.DeepCopy()
+		suspended.Spec.Suspend = true
+		due, err := isImagePrePullDue(ctx, suspended, leadTime, time.Date(2026, 10, 16, 1, 55, 0, 0, time.Local))
+		require.NoError(t, err)
+		assert.False(t, due)
+	})
+}
+
+func TestSyncImagePrePull(t *testing.T) {
+	ctx := context.Background()
+	cronWf := v1alpha1.MustUnmarshalCronWorkflow(prePullCronWf)
+	// schedule the cron workflow to run within the lead time
+	next := time.Now().Add(5 * time.Minute)
+	cronWf.Spec.Schedules = []string{next.Format("4 15 * * *")}
+	kubeclientset := fake.NewSimpleClientset()
+	cc := &Controller{
+		kubeclientset: kubeclientset,
+		imagePrePull:  &config.ImagePrePull{PriorityClassName: "low"},
+		executorImage: "quay.io/argoproj/argoexec:latest",
+	}
+
+	require.NoError(t, cc.syncImagePrePull(ctx, cronWf))
+	ds, err := kubeclientset.AppsV1().DaemonSets("argo").Get(ctx, "nightly-pre-pull", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "nightly-pre-pull", ds.Labels[common.LabelKeyImagePrePull])
+	assert.Equal(t, "nightly", ds.Labels[common.LabelKeyCronWorkflow])
+	require.Len(t, ds.OwnerReferences, 1)
+	assert.Equal(t, cronWf.UID, ds.OwnerReferences[0].UID)
+	podSpec := ds.Spec.Template.Spec
+	require.Len(t, podSpec.InitContainers, 3)
+	assert.Equal(t, "quay.io/argoproj/argoexec:latest", podSpec.InitContainers[0].Image)
+	assert.Equal(t, []string{"argoexec", "pre-pull"}, podSpec.InitContainers[0].Command)
+	assert.Equal(t, "my-registry/trainer:v1", podSpec.InitContainers[1].Image)
+	assert.Equal(t, "python:3.12", podSpec.InitContainers[2].Image)
+	assert.Equal(t, []string{common.VarRunArgoPath + "/argoexec", "version"}, podSpec.InitContainers[2].Command)
+	assert.Equal(t, "registry.k8s.io/pause:3.10", podSpec.Containers[0].Image)
+	assert.Equal(t, map[string]string{"pool": "batch"}, podSpec.NodeSelector)
+	assert.Equal(t, "low", podSpec.PriorityClassName)
+
+	t.Run("ImagesChanged", func(t *testing.T) {
+		cronWf.Spec.WorkflowSpec.Templates[3].PrePull = true
+		require.NoError(t, cc.syncImagePrePull(ctx, cronWf))
+		ds, err := kubeclientset.AppsV1().DaemonSets("argo").Get(ctx, "nightly-pre-pull", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Len(t, ds.Spec.Template.Spec.InitContainers, 4)
+	})
+	t.Run("Suspended", func(t *testing.T) {
+		cronWf.Spec.Suspend = true
+		require.NoError(t, cc.syncImagePrePull(ctx, cronWf))
+		list, err := kubeclientset.AppsV1().DaemonSets("argo").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+	})
+	t.Run("Reloaded", func(t *testing.T) {
+		cronWf.Spec.Suspend = false
+		require.NoError(t, cc.syncImagePrePull(ctx, cronWf))
+		restarted := &Controller{kubeclientset: kubeclientset, imagePrePull: cc.imagePrePull}
+		require.NoError(t, restarted.loadImagePrePulls(ctx))
+		restarted.deleteStaleImagePrePulls(ctx, map[string]bool{})
+		list, err := kubeclientset.AppsV1().DaemonSets("argo").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, list.Items, "the DaemonSet of a cron workflow that was not synced is deleted")
+	})
+}
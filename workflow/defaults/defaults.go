@@ -0,0 +1,180 @@
+// Package defaults layers the defaults of Workflows. These are the NamespaceWorkflowDefaults of the Workflow's
+// namespace, then the ClusterWorkflowDefaults, then the workflowDefaults of the controller ConfigMap, from the highest
+// precedence to the lowest.
+package defaults
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wfextvv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/workflow/v1alpha1"
+	authutil "github.com/argoproj/argo-workflows/v3/util/auth"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/informer"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+const resyncPeriod = 20 * time.Minute
+
+// Getter returns the defaults of the Workflows in a namespace, or nil if there are none
+type Getter interface {
+	GetWorkflowDefaults(namespace string) (*wfv1.Workflow, error)
+}
+
+// Defaults layers the ClusterWorkflowDefaults and NamespaceWorkflowDefaults, once they are watched, over the
+// workflowDefaults of the controller ConfigMap
+type Defaults struct {
+	config func() *wfv1.Workflow
+	// ClusterInformer and NamespaceInformer are nil until Run is called, or if the resources cannot be watched
+	ClusterInformer   wfextvv1alpha1.ClusterWorkflowDefaultsInformer
+	NamespaceInformer wfextvv1alpha1.NamespaceWorkflowDefaultsInformer
+}
+
+var _ Getter = &Defaults{}
+
+// New returns the defaults, where config returns the current workflowDefaults of the controller ConfigMap
+func New(config func() *wfv1.Workflow) *Defaults {
+	return &Defaults{config: config}
+}
+
+// Static returns defaults that are only the workflowDefaults of the controller ConfigMap
+func Static(wfDefaults *wfv1.Workflow) *Defaults {
+	return New(func() *wfv1.Workflow { return wfDefaults })
+}
+
+// Run watches the ClusterWorkflowDefaults and NamespaceWorkflowDefaults, if their CRDs are installed and there is RBAC
+// access to them in the namespace, and waits for their caches to sync. The NamespaceWorkflowDefaults are only watched
+// in the managed namespace, if there is one. The handler, if any, is notified of the unstructured resources.
+func (d *Defaults) Run(ctx context.Context, kubeclientset kubernetes.Interface, dynamicInterface dynamic.Interface, namespace, managedNamespace string, handler cache.ResourceEventHandler) {
+	resources, err := kubeclientset.Discovery().ServerResourcesForGroupVersion(workflow.APIVersion)
+	if err != nil {
+		log.WithError(err).Warn("Failed to discover the WorkflowDefaults CRDs")
+		return
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name != workflow.ClusterWorkflowDefaultsPlural && resource.Name != workflow.NamespaceWorkflowDefaultsPlural {
+			continue
+		}
+		if !canWatch(ctx, kubeclientset, resource.Name, namespace) {
+			log.Warnf("No RBAC access for %s", resource.Name)
+			continue
+		}
+		var sharedInformer cache.SharedIndexInformer
+		if resource.Name == workflow.ClusterWorkflowDefaultsPlural {
+			d.ClusterInformer = informer.NewTolerantClusterWorkflowDefaultsInformer(dynamicInterface, resyncPeriod)
+			sharedInformer = d.ClusterInformer.Informer()
+		} else {
+			d.NamespaceInformer = informer.NewTolerantNamespaceWorkflowDefaultsInformer(dynamicInterface, resyncPeriod, managedNamespace)
+			sharedInformer = d.NamespaceInformer.Informer()
+		}
+		if handler != nil {
+			if _, err := sharedInformer.AddEventHandler(handler); err != nil {
+				log.Fatal(err)
+			}
+		}
+		go sharedInformer.Run(ctx.Done())
+		if !cache.WaitForCacheSync(ctx.Done(), sharedInformer.HasSynced) {
+			log.Fatalf("Timed out waiting for %s cache to sync", resource.Name)
+		}
+	}
+}
+
+func canWatch(ctx context.Context, kubeclientset kubernetes.Interface, resource, namespace string) bool {
+	for _, verb := range []string{"list", "watch"} {
+		allowed, err := authutil.CanIArgo(ctx, kubeclientset, verb, resource, namespace, "")
+		if err != nil || !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// GetWorkflowDefaults returns the defaults of the Workflows in the namespace. Defaults that cannot be merged into a
+// Workflow are ignored.
+func (d *Defaults) GetWorkflowDefaults(namespace string) (*wfv1.Workflow, error) {
+	var specs []*wfv1.WorkflowDefaultsSpec
+	if d.NamespaceInformer != nil && namespace != "" {
+		list, err := d.NamespaceInformer.Lister().NamespaceWorkflowDefaultses(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, sortSpecs(list, func(v *wfv1.NamespaceWorkflowDefaults) (string, *wfv1.WorkflowDefaultsSpec) {
+			return v.Name, &v.Spec
+		})...)
+	}
+	if d.ClusterInformer != nil {
+		list, err := d.ClusterInformer.Lister().List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, sortSpecs(list, func(v *wfv1.ClusterWorkflowDefaults) (string, *wfv1.WorkflowDefaultsSpec) {
+			return v.Name, &v.Spec
+		})...)
+	}
+	var layers []*wfv1.Workflow
+	for _, spec := range specs {
+		wf, err := ToWorkflow(spec)
+		if err != nil {
+			continue
+		}
+		layers = append(layers, wf)
+	}
+	if config := d.config(); config != nil {
+		layers = append(layers, config.DeepCopy())
+	}
+	return merge(layers)
+}
+
+// ToWorkflow returns the defaults as a Workflow, checking that they can be merged into one
+func ToWorkflow(spec *wfv1.WorkflowDefaultsSpec) (*wfv1.Workflow, error) {
+	wf := spec.ToWorkflow()
+	if err := util.MergeTo(wf.DeepCopy(), &wfv1.Workflow{}); err != nil {
+		return nil, fmt.Errorf("workflow defaults cannot be merged into a workflow: %w", err)
+	}
+	return wf, nil
+}
+
+// merge merges the defaults, which are ordered from the highest precedence to the lowest
+func merge(layers []*wfv1.Workflow) (*wfv1.Workflow, error) {
+	if len(layers) == 0 {
+		return nil, nil
+	}
+	merged := layers[0].DeepCopy()
+	for _, layer := range layers[1:] {
+		if err := util.MergeTo(layer, merged); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// sortSpecs returns the specs from the highest precedence to the lowest, i.e. by descending priority, then by name
+func sortSpecs[T any](list []T, get func(T) (string, *wfv1.WorkflowDefaultsSpec)) []*wfv1.WorkflowDefaultsSpec {
+	list = slices.Clone(list)
+	slices.SortFunc(list, func(a, b T) int {
+		nameA, specA := get(a)
+		nameB, specB := get(b)
+		if specA.Priority != specB.Priority {
+			if specA.Priority > specB.Priority {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(nameA, nameB)
+	})
+	specs := make([]*wfv1.WorkflowDefaultsSpec, len(list))
+	for i, v := range list {
+		_, specs[i] = get(v)
+	}
+	return specs
+}
@@ -0,0 +1,103 @@
+package defaults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/utils/ptr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/informer"
+)
+
+func newWorkflowDefaults(kind, namespace, name string, priority int64, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec": map[string]interface{}{
+			"priority":     priority,
+			"labels":       map[string]interface{}{"defaults": name},
+			"workflowSpec": spec,
+		},
+	}}
+}
+
+func newDefaults(t *testing.T, config *wfv1.Workflow, cluster []*unstructured.Unstructured, namespaced []*unstructured.Unstructured) *Defaults {
+	dynamicInterface := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	d := Static(config)
+	d.ClusterInformer = informer.NewTolerantClusterWorkflowDefaultsInformer(dynamicInterface, 0)
+	for _, obj := range cluster {
+		require.NoError(t, d.ClusterInformer.Informer().GetIndexer().Add(obj))
+	}
+	d.NamespaceInformer = informer.NewTolerantNamespaceWorkflowDefaultsInformer(dynamicInterface, 0, "")
+	for _, obj := range namespaced {
+		require.NoError(t, d.NamespaceInformer.Informer().GetIndexer().Add(obj))
+	}
+	return d
+}
+
+func TestGetWorkflowDefaults(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		wfDefaults, err := Static(nil).GetWorkflowDefaults("my-ns")
+		require.NoError(t, err)
+		assert.Nil(t, wfDefaults)
+	})
+	t.Run("Static", func(t *testing.T) {
+		wfDefaults, err := Static(&wfv1.Workflow{Spec: wfv1.WorkflowSpec{ServiceAccountName: "configmap"}}).GetWorkflowDefaults("my-ns")
+		require.NoError(t, err)
+		assert.Equal(t, "configmap", wfDefaults.Spec.ServiceAccountName)
+	})
+	t.Run("Precedence", func(t *testing.T) {
+		d := newDefaults(t,
+			&wfv1.Workflow{Spec: wfv1.WorkflowSpec{
+				ServiceAccountName: "configmap",
+				Parallelism:        ptr.To(int64(1)),
+				HostNetwork:        ptr.To(true),
+			}},
+			[]*unstructured.Unstructured{
+				newWorkflowDefaults("ClusterWorkflowDefaults", "", "cluster", 0, map[string]interface{}{"serviceAccountName": "cluster", "parallelism": int64(2)}),
+			},
+			[]*unstructured.Unstructured{
+				newWorkflowDefaults("NamespaceWorkflowDefaults", "my-ns", "b-low", 0, map[string]interface{}{"serviceAccountName": "b-low"}),
+				newWorkflowDefaults("NamespaceWorkflowDefaults", "my-ns", "a-low", 0, map[string]interface{}{"serviceAccountName": "a-low"}),
+				newWorkflowDefaults("NamespaceWorkflowDefaults", "my-ns", "z-high", 10, map[string]interface{}{"entrypoint": "main"}),
+				newWorkflowDefaults("NamespaceWorkflowDefaults", "other-ns", "other", 100, map[string]interface{}{"serviceAccountName": "other"}),
+			},
+		)
+
+		wfDefaults, err := d.GetWorkflowDefaults("my-ns")
+		require.NoError(t, err)
+		assert.Equal(t, "main", wfDefaults.Spec.Entrypoint)
+		assert.Equal(t, "a-low", wfDefaults.Spec.ServiceAccountName)
+		assert.Equal(t, ptr.To(int64(2)), wfDefaults.Spec.Parallelism)
+		assert.Equal(t, ptr.To(true), wfDefaults.Spec.HostNetwork)
+		assert.Equal(t, "z-high", wfDefaults.Labels["defaults"])
+
+		wfDefaults, err = d.GetWorkflowDefaults("no-defaults-ns")
+		require.NoError(t, err)
+		assert.Equal(t, "cluster", wfDefaults.Spec.ServiceAccountName)
+		assert.Equal(t, "cluster", wfDefaults.Labels["defaults"])
+
+		wfDefaults, err = d.GetWorkflowDefaults("")
+		require.NoError(t, err)
+		assert.Equal(t, "cluster", wfDefaults.Spec.ServiceAccountName)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		d := newDefaults(t, nil,
+			[]*unstructured.Unstructured{
+				newWorkflowDefaults("ClusterWorkflowDefaults", "", "invalid", 10, map[string]interface{}{"parallelism": "not-a-number"}),
+				newWorkflowDefaults("ClusterWorkflowDefaults", "", "valid", 0, map[string]interface{}{"serviceAccountName": "valid"}),
+			},
+			nil,
+		)
+		wfDefaults, err := d.GetWorkflowDefaults("my-ns")
+		require.NoError(t, err)
+		assert.Equal(t, "valid", wfDefaults.Spec.ServiceAccountName)
+		assert.Nil(t, wfDefaults.Spec.Parallelism)
+	})
+}
@@ -246,6 +246,14 @@ func (ae *AgentExecutor) executeHTTPTemplate(ctx context.Context, tmpl wfv1.Temp
 		return 0, nil
 	}
 
+	// the timeout covers reading the body of the response too
+	var timeout time.Duration
+	if tmpl.HTTP.TimeoutSeconds != nil {
+		timeout = time.Duration(*tmpl.HTTP.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, agentTimeout(timeout))
+	defer cancel()
+
 	response, err := ae.executeHTTPTemplateRequest(ctx, tmpl.HTTP)
 	if err != nil {
 		return 0, err
@@ -325,13 +333,7 @@ func (ae *AgentExecutor) executeHTTPTemplateRequest(ctx context.Context, httpTem
 		return nil, err
 	}
 
-	if httpTemplate.TimeoutSeconds != nil {
-		ctx, cancel := context.WithTimeout(ctx, time.Duration(*httpTemplate.TimeoutSeconds)*time.Second)
-		defer cancel()
-		request = request.WithContext(ctx)
-	} else {
-		request = request.WithContext(ctx)
-	}
+	request = request.WithContext(ctx)
 
 	for _, header := range httpTemplate.Headers {
 		value := header.Value
@@ -368,6 +370,10 @@ func (ae *AgentExecutor) executePluginTemplate(ctx context.Context, tmpl wfv1.Te
 		},
 		Template: &tmpl,
 	}
+	// a timeout that is not a duration, e.g. an unresolved variable, is ignored, as it is by the controller
+	timeout, _ := time.ParseDuration(tmpl.Timeout)
+	ctx, cancel := context.WithTimeout(ctx, agentTimeout(timeout))
+	defer cancel()
 	reply := &executorplugins.ExecuteTemplateReply{}
 	for _, plug := range ae.plugins {
 		if err := plug.ExecuteTemplate(ctx, args, reply); err != nil {
@@ -383,6 +389,18 @@ func (ae *AgentExecutor) executePluginTemplate(ctx context.Context, tmpl wfv1.Te
 	return 0, fmt.Errorf("no plugin executed the template")
 }
 
+// agentTimeout returns the timeout of a request or call of a template, which is the timeout of the template if it sets
+// one, otherwise the default timeout of the agent, limited to the maximum timeout of the agent
+func agentTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		timeout = env.LookupEnvDurationOr(common.EnvAgentDefaultTimeout, 30*time.Second)
+	}
+	if maxTimeout := env.LookupEnvDurationOr(common.EnvAgentMaxTimeout, 0); maxTimeout > 0 && timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	return timeout
+}
+
 func IsWorkflowCompleted(wts *wfv1.WorkflowTaskSet) bool {
 	return wts.Labels[common.LabelKeyCompleted] == "true"
 }
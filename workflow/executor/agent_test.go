@@ -8,10 +8,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	executorplugins "github.com/argoproj/argo-workflows/v3/pkg/plugins/executor"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 func TestUnsupportedTemplateTaskWorker(t *testing.T) {
@@ -94,3 +96,46 @@ func (a alwaysSucceededPlugin) ExecuteTemplate(_ context.Context, _ executorplug
 	reply.Requeue = &metav1.Duration{Duration: a.requeue}
 	return nil
 }
+
+func TestAgentTimeout(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, 30*time.Second, agentTimeout(0))
+		t.Setenv(common.EnvAgentDefaultTimeout, "1m")
+		assert.Equal(t, time.Minute, agentTimeout(0))
+	})
+	t.Run("Template", func(t *testing.T) {
+		t.Setenv(common.EnvAgentDefaultTimeout, "1m")
+		assert.Equal(t, 5*time.Minute, agentTimeout(5*time.Minute))
+	})
+	t.Run("Max", func(t *testing.T) {
+		t.Setenv(common.EnvAgentMaxTimeout, "2m")
+		assert.Equal(t, 30*time.Second, agentTimeout(0))
+		assert.Equal(t, time.Minute, agentTimeout(time.Minute))
+		assert.Equal(t, 2*time.Minute, agentTimeout(time.Hour))
+	})
+}
+
+func TestAgentPluginTimeout(t *testing.T) {
+	t.Setenv(common.EnvAgentMaxTimeout, "10ms")
+	ae := &AgentExecutor{
+		consideredTasks: &sync.Map{},
+		plugins:         []executorplugins.TemplateExecutor{&blockingPlugin{}},
+	}
+	tmpl := v1alpha1.Template{
+		Timeout: "1h",
+		Plugin: &v1alpha1.Plugin{
+			Object: v1alpha1.Object{Value: json.RawMessage(`{"key": "value"}`)},
+		},
+	}
+	result, _, err := ae.processTask(context.Background(), tmpl)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.NodeFailed, result.Phase)
+	assert.Contains(t, result.Message, context.DeadlineExceeded.Error())
+}
+
+type blockingPlugin struct{}
+
+func (blockingPlugin) ExecuteTemplate(ctx context.Context, _ executorplugins.ExecuteTemplateArgs, _ *executorplugins.ExecuteTemplateReply) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
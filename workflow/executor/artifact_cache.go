@@ -0,0 +1,207 @@
+package executor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// artifactCacheTempPrefix is the prefix of the directories that artifacts are downloaded to before they are cached
+const artifactCacheTempPrefix = "download-"
+
+// loadArtifact loads the artifact to the path. If there is an artifact cache directory shared between the pods of a
+// node (e.g. a hostPath volume), artifacts are loaded via the cache, so that an artifact that several steps on the
+// node use is only downloaded once.
+func loadArtifact(drv artifactcommon.ArtifactDriver, art *wfv1.Artifact, dest string) error {
+	cacheDir := os.Getenv(common.EnvVarArtifactCacheDir)
+	if cacheDir == "" {
+		return limitLoadBandwidth(drv, art).Load(art, dest)
+	}
+	cacheKey, err := artifactCacheKey(drv, art)
+	if err != nil {
+		return err
+	}
+	if cacheKey == "" {
+		return limitLoadBandwidth(drv, art).Load(art, dest)
+	}
+	logger := log.WithField("artifactName", art.Name).WithField("cacheKey", cacheKey)
+	cached := filepath.Join(cacheDir, cacheKey)
+	_, err = os.Stat(cached)
+	switch {
+	case os.IsNotExist(err):
+		logger.Info("Artifact not in cache, downloading")
+		if err := cacheArtifact(drv, art, cacheDir, cached); err != nil {
+			return err
+		}
+		trimArtifactCache(cacheDir, cached)
+	case err != nil:
+		return err
+	default:
+		logger.Info("Loading artifact from cache")
+		// the modification time records when the entry was last used, so that the least recently used are trimmed first
+		now := time.Now()
+		_ = os.Chtimes(cached, now, now)
+	}
+	if err := copyPath(cached, dest); err != nil {
+		// another pod may have trimmed the entry whilst it was copied
+		logger.WithError(err).Warn("Failed to load artifact from cache, downloading it instead")
+		if err := os.RemoveAll(dest); err != nil {
+			return err
+		}
+		return limitLoadBandwidth(drv, art).Load(art, dest)
+	}
+	return nil
+}
+
+// artifactCacheKey returns the name of the cache entry of the artifact, or an empty string if it is not cached.
+// Entries are shared between the pods on a node, which may have other credentials, so an artifact is only cached if
+// the pod can read its entity tag from the repository with its own credentials. Content-addressable artifacts are
+// cached by their location, as their key changes whenever their content does, and the same key in another repository
+// (endpoint, bucket, or container) may hold other content. Other artifacts are cached by their location and their
+// entity tag, so that an artifact that has been overwritten is downloaded again. Artifacts without an entity tag, such
+// as directories, are not cached, and neither are encrypted artifacts, as their decrypted content would be available
+// to pods without their key.
+func artifactCacheKey(drv artifactcommon.ArtifactDriver, art *wfv1.Artifact) (string, error) {
+	if art.Encryption != nil {
+		return "", nil
+	}
+	location, err := json.Marshal(art.ArtifactLocation)
+	if err != nil {
+		return "", err
+	}
+	etag, err := artifactcommon.ETag(drv, art)
+	if err != nil {
+		log.WithError(err).WithField("artifactName", art.Name).Debug("Not caching artifact, as its entity tag could not be read")
+		return "", nil
+	}
+	if etag == "" {
+		return "", nil
+	}
+	if art.ContentAddressable {
+		sum := sha256.Sum256(location)
+		return hex.EncodeToString(sum[:]), nil
+	}
+	h := sha256.New()
+	_, _ = h.Write(location)
+	_, _ = fmt.Fprintf(h, "\x00%s", etag)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cacheArtifact downloads the artifact to a temporary directory in the cache, and then moves it to the entry, so that
+// other pods never see a partial download
+func cacheArtifact(drv artifactcommon.ArtifactDriver, art *wfv1.Artifact, cacheDir, cached string) error {
+	tmp, err := os.MkdirTemp(cacheDir, artifactCacheTempPrefix)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	if err := limitLoadBandwidth(drv, art).Load(art, filepath.Join(tmp, "artifact")); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(tmp, "artifact"), cached); err != nil {
+		// another pod may have cached the artifact concurrently, in which case its copy is used
+		if _, statErr := os.Stat(cached); statErr != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trimArtifactCache removes the least recently used entries of the cache until it is no larger than its maximum size,
+// if it has one. The entry that has just been cached is kept, even if it alone is larger.
+func trimArtifactCache(cacheDir, keep string) {
+	v := os.Getenv(common.EnvVarArtifactCacheMaxSize)
+	if v == "" {
+		return
+	}
+	maxSize, err := resource.ParseQuantity(v)
+	if err != nil {
+		log.WithError(err).Warnf("Invalid %s, not trimming the artifact cache", common.EnvVarArtifactCacheMaxSize)
+		return
+	}
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		log.WithError(err).Warn("Failed to read the artifact cache")
+		return
+	}
+	type cacheEntry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var cacheEntries []cacheEntry
+	var size int64
+	for _, e := range entries {
+		p := filepath.Join(cacheDir, e.Name())
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		entry := cacheEntry{p, file.Size(p), info.ModTime()}
+		size += entry.size
+		// downloads in progress are not entries yet
+		if p != keep && !strings.HasPrefix(e.Name(), artifactCacheTempPrefix) {
+			cacheEntries = append(cacheEntries, entry)
+		}
+	}
+	sort.Slice(cacheEntries, func(i, j int) bool {
+		return cacheEntries[i].modTime.Before(cacheEntries[j].modTime)
+	})
+	for _, entry := range cacheEntries {
+		if size <= maxSize.Value() {
+			return
+		}
+		log.WithField("path", entry.path).WithField("size", entry.size).Info("Removing least recently used artifact from cache")
+		if err := os.RemoveAll(entry.path); err != nil {
+			log.WithError(err).WithField("path", entry.path).Warn("Failed to remove artifact from cache")
+			continue
+		}
+		size -= entry.size
+	}
+}
+
+// copyPath copies the file or directory src to dest
+func copyPath(src, dest string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+		in, err := os.Open(filepath.Clean(p))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = in.Close() }()
+		out, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			_ = out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
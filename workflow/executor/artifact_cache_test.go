@@ -0,0 +1,195 @@
+package executor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// countingArtifactDriver loads a file with the given content, counting the number of loads
+type countingArtifactDriver struct {
+	artifactcommon.ArtifactDriver
+	content string
+	loads   int
+}
+
+func (d *countingArtifactDriver) Load(_ *wfv1.Artifact, path string) error {
+	d.loads++
+	return os.WriteFile(path, []byte(d.content), 0o600)
+}
+
+func TestLoadArtifact(t *testing.T) {
+	art := &wfv1.Artifact{
+		Name:               "my-art",
+		ContentAddressable: true,
+		ArtifactLocation:   wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "sha256/my-digest/out.tgz"}},
+	}
+	t.Run("NoCacheDir", func(t *testing.T) {
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		dest := filepath.Join(t.TempDir(), "dest")
+		require.NoError(t, loadArtifact(drv, art, dest))
+		assert.Equal(t, 1, drv.loads)
+	})
+	t.Run("CacheDir", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		for i := 0; i < 2; i++ {
+			dest := filepath.Join(t.TempDir(), "dest")
+			require.NoError(t, loadArtifact(drv, art, dest))
+			data, err := os.ReadFile(dest)
+			require.NoError(t, err)
+			assert.Equal(t, "foo", string(data))
+		}
+		assert.Equal(t, 1, drv.loads, "second load is from the cache")
+	})
+	t.Run("OtherRepository", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		require.NoError(t, loadArtifact(drv, art, filepath.Join(t.TempDir(), "dest")))
+		other := art.DeepCopy()
+		other.S3.Bucket = "other-bucket"
+		require.NoError(t, loadArtifact(drv, other, filepath.Join(t.TempDir(), "dest")))
+		assert.Equal(t, 2, drv.loads, "the same key in another bucket is not the same artifact")
+	})
+	t.Run("NoETag", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &countingArtifactDriver{content: "foo"}
+		for i := 0; i < 2; i++ {
+			require.NoError(t, loadArtifact(drv, art, filepath.Join(t.TempDir(), "dest")))
+		}
+		assert.Equal(t, 2, drv.loads, "the access of the pod to the artifact cannot be checked")
+	})
+	t.Run("AccessDenied", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		require.NoError(t, loadArtifact(drv, art, filepath.Join(t.TempDir(), "dest")))
+		denied := &deniedArtifactDriver{}
+		err := loadArtifact(denied, art, filepath.Join(t.TempDir(), "dest"))
+		require.EqualError(t, err, "access denied", "a pod without access is not served the cached artifact")
+	})
+	t.Run("NotContentAddressable", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &countingArtifactDriver{content: "foo"}
+		notContentAddressable := art.DeepCopy()
+		notContentAddressable.ContentAddressable = false
+		for i := 0; i < 2; i++ {
+			require.NoError(t, loadArtifact(drv, notContentAddressable, filepath.Join(t.TempDir(), "dest")))
+		}
+		assert.Equal(t, 2, drv.loads)
+	})
+}
+
+// etagArtifactDriver is a countingArtifactDriver whose artifacts have an entity tag
+type etagArtifactDriver struct {
+	countingArtifactDriver
+	etag string
+}
+
+func (d *etagArtifactDriver) ETag(*wfv1.Artifact) (string, error) {
+	return d.etag, nil
+}
+
+// deniedArtifactDriver is a driver whose credentials cannot read the artifact
+type deniedArtifactDriver struct {
+	artifactcommon.ArtifactDriver
+}
+
+func (d *deniedArtifactDriver) Load(*wfv1.Artifact, string) error {
+	return errors.New("access denied")
+}
+
+func (d *deniedArtifactDriver) ETag(*wfv1.Artifact) (string, error) {
+	return "", errors.New("access denied")
+}
+
+func TestLoadArtifactByETag(t *testing.T) {
+	art := &wfv1.Artifact{
+		Name:             "my-art",
+		ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-key"}},
+	}
+	load := func(t *testing.T, drv artifactcommon.ArtifactDriver, art *wfv1.Artifact) string {
+		t.Helper()
+		dest := filepath.Join(t.TempDir(), "dest")
+		require.NoError(t, loadArtifact(drv, art, dest))
+		data, err := os.ReadFile(dest)
+		require.NoError(t, err)
+		return string(data)
+	}
+	t.Run("Cached", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		assert.Equal(t, "foo", load(t, drv, art))
+		assert.Equal(t, "foo", load(t, drv, art))
+		assert.Equal(t, 1, drv.loads, "second load is from the cache")
+	})
+	t.Run("Changed", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		assert.Equal(t, "foo", load(t, drv, art))
+		drv.content, drv.etag = "bar", "etag-2"
+		assert.Equal(t, "bar", load(t, drv, art), "an overwritten artifact is downloaded again")
+		assert.Equal(t, 2, drv.loads)
+	})
+	t.Run("OtherLocation", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		load(t, drv, art)
+		other := art.DeepCopy()
+		other.S3.Key = "other-key"
+		load(t, drv, other)
+		assert.Equal(t, 2, drv.loads, "entity tags are only unique for the same location")
+	})
+	t.Run("Encrypted", func(t *testing.T) {
+		t.Setenv(common.EnvVarArtifactCacheDir, t.TempDir())
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		encrypted := art.DeepCopy()
+		encrypted.Encryption = &wfv1.ArtifactEncryption{}
+		load(t, drv, encrypted)
+		load(t, drv, encrypted)
+		assert.Equal(t, 2, drv.loads, "decrypted content is not cached")
+	})
+	t.Run("Trimmed", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		t.Setenv(common.EnvVarArtifactCacheDir, cacheDir)
+		t.Setenv(common.EnvVarArtifactCacheMaxSize, "5")
+		drv := &etagArtifactDriver{countingArtifactDriver{content: "foo"}, "etag-1"}
+		load(t, drv, art)
+		other := art.DeepCopy()
+		other.S3.Key = "other-key"
+		load(t, drv, other)
+		entries, err := os.ReadDir(cacheDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1, "the least recently used entry is removed")
+		key, err := artifactCacheKey(drv, other)
+		require.NoError(t, err)
+		assert.Equal(t, key, entries[0].Name())
+	})
+}
+
+func TestTrimArtifactCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv(common.EnvVarArtifactCacheMaxSize, "10")
+	now := time.Now()
+	for i, name := range []string{"old", "new", "download-123", "kept"} {
+		p := filepath.Join(cacheDir, name)
+		require.NoError(t, os.WriteFile(p, []byte("12345"), 0o600))
+		modTime := now.Add(time.Duration(i-10) * time.Minute)
+		require.NoError(t, os.Chtimes(p, modTime, modTime))
+	}
+	trimArtifactCache(cacheDir, filepath.Join(cacheDir, "kept"))
+	_, err := os.Stat(filepath.Join(cacheDir, "old"))
+	assert.True(t, os.IsNotExist(err), "the least recently used entry is removed")
+	_, err = os.Stat(filepath.Join(cacheDir, "new"))
+	assert.True(t, os.IsNotExist(err), "entries are removed until the cache is small enough")
+	assert.FileExists(t, filepath.Join(cacheDir, "download-123"), "downloads in progress are kept")
+	assert.FileExists(t, filepath.Join(cacheDir, "kept"), "the entry that has just been cached is kept")
+}
@@ -0,0 +1,130 @@
+package executor
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+// minBandwidthBurst is the smallest number of bytes that are transferred at once when the bandwidth is limited, so that
+// low limits are not waited for in tiny steps
+const minBandwidthBurst = 32 << 10
+
+// withArtifactTimeout runs the transfer of the artifact, failing if it takes longer than the timeout of the artifact.
+// Drivers cannot be cancelled, so a transfer that times out is abandoned, and ends when the pod does.
+func withArtifactTimeout(art *wfv1.Artifact, transfer string, f func() error) error {
+	if art.Timeout == nil {
+		return f()
+	}
+	done := make(chan error, 1)
+	go func() { done <- f() }()
+	timer := time.NewTimer(art.Timeout.Duration)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return argoerrs.Errorf(argoerrs.CodeTimeout, "timed out after %v to %s artifact %s", art.Timeout.Duration, transfer, art.Name)
+	}
+}
+
+// bandwidthLimiter returns the limiter of the bandwidth of the artifact, or nil if it is not limited
+func bandwidthLimiter(art *wfv1.Artifact) *rate.Limiter {
+	if art.MaxBandwidth == nil || art.MaxBandwidth.Value() <= 0 {
+		return nil
+	}
+	limit := art.MaxBandwidth.Value()
+	return rate.NewLimiter(rate.Limit(limit), int(max(limit, minBandwidthBurst)))
+}
+
+// waitForBandwidth waits until the limiter allows the bytes to be transferred
+func waitForBandwidth(limiter *rate.Limiter, n int64) {
+	for n > 0 {
+		step := min(n, int64(limiter.Burst()))
+		// the context is never cancelled, and steps are never larger than the burst, so waiting cannot fail
+		_ = limiter.WaitN(context.Background(), int(step))
+		n -= step
+	}
+}
+
+// throttleProgress returns the progress function of a save, which waits for the bandwidth of the artifact to allow the
+// bytes that have been uploaded, so that the driver that reports them uploads no faster than its limit
+func throttleProgress(limiter *rate.Limiter, progress func(n int64)) func(n int64) {
+	if limiter == nil {
+		return progress
+	}
+	return func(n int64) {
+		progress(n)
+		waitForBandwidth(limiter, n)
+	}
+}
+
+// throttledReader reads no faster than the limiter allows
+type throttledReader struct {
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > t.limiter.Burst() {
+		p = p[:t.limiter.Burst()]
+	}
+	n, err := t.r.Read(p)
+	waitForBandwidth(t.limiter, int64(n))
+	return n, err
+}
+
+// throttleReader returns the reader limited to the bandwidth of the limiter, if there is one
+func throttleReader(limiter *rate.Limiter, r io.Reader) io.Reader {
+	if limiter == nil {
+		return r
+	}
+	return &throttledReader{r, limiter}
+}
+
+// bandwidthLimitedDriver loads files through a stream that is limited to the bandwidth, as drivers do not report the
+// progress of loads
+type bandwidthLimitedDriver struct {
+	artifactcommon.ArtifactDriver
+	limiter *rate.Limiter
+}
+
+// limitLoadBandwidth returns the driver to load the artifact with, which is limited to the bandwidth of the artifact
+func limitLoadBandwidth(drv artifactcommon.ArtifactDriver, art *wfv1.Artifact) artifactcommon.ArtifactDriver {
+	limiter := bandwidthLimiter(art)
+	if limiter == nil {
+		return drv
+	}
+	return &bandwidthLimitedDriver{drv, limiter}
+}
+
+func (d *bandwidthLimitedDriver) Load(art *wfv1.Artifact, path string) error {
+	isDir, err := d.IsDirectory(art)
+	if err != nil || isDir {
+		log.WithField("artifactName", art.Name).Warn("The bandwidth of loading the artifact cannot be limited, as it may be a directory")
+		return d.ArtifactDriver.Load(art, path)
+	}
+	stream, err := d.OpenStream(art)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	if _, err := io.Copy(f, throttleReader(d.limiter, stream)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestWithArtifactTimeout(t *testing.T) {
+	t.Run("NoTimeout", func(t *testing.T) {
+		err := withArtifactTimeout(&wfv1.Artifact{Name: "foo"}, "save", func() error { return errors.New("failed") })
+		require.EqualError(t, err, "failed")
+	})
+	t.Run("Completed", func(t *testing.T) {
+		art := &wfv1.Artifact{Name: "foo", Timeout: &metav1.Duration{Duration: time.Minute}}
+		require.NoError(t, withArtifactTimeout(art, "save", func() error { return nil }))
+	})
+	t.Run("TimedOut", func(t *testing.T) {
+		art := &wfv1.Artifact{Name: "foo", Timeout: &metav1.Duration{Duration: 10 * time.Millisecond}}
+		release := make(chan struct{})
+		defer close(release)
+		err := withArtifactTimeout(art, "load", func() error {
+			<-release
+			return nil
+		})
+		require.Error(t, err)
+		assert.True(t, argoerrs.IsCode(argoerrs.CodeTimeout, err))
+		assert.Contains(t, err.Error(), "timed out after 10ms to load artifact foo")
+	})
+}
+
+func TestBandwidthLimiter(t *testing.T) {
+	assert.Nil(t, bandwidthLimiter(&wfv1.Artifact{}))
+	limiter := bandwidthLimiter(&wfv1.Artifact{MaxBandwidth: resource.NewQuantity(1024, resource.BinarySI)})
+	require.NotNil(t, limiter)
+	assert.Equal(t, minBandwidthBurst, limiter.Burst(), "small limits burst at least the minimum")
+	limiter = bandwidthLimiter(&wfv1.Artifact{MaxBandwidth: resource.NewQuantity(1<<20, resource.BinarySI)})
+	require.NotNil(t, limiter)
+	assert.Equal(t, 1<<20, limiter.Burst())
+}
+
+func TestThrottleReader(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 3<<19)
+	r := bytes.NewReader(data)
+	assert.Equal(t, r, throttleReader(nil, r))
+
+	limiter := bandwidthLimiter(&wfv1.Artifact{MaxBandwidth: resource.NewQuantity(1<<20, resource.BinarySI)})
+	start := time.Now()
+	out, err := io.ReadAll(throttleReader(limiter, r))
+	require.NoError(t, err)
+	assert.Equal(t, data, out)
+	// the first MiB is the burst, the remaining half MiB is limited to 1MiB/s
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
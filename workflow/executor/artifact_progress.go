@@ -0,0 +1,144 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// artifactProgress tracks the progress of packing and uploading the output artifact that is currently being saved.
+// All methods are safe to call on a nil tracker, which tracks nothing.
+type artifactProgress struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	started  time.Time
+	progress wfv1.ArtifactProgress
+	// changed is whether the progress has changed since it was last reported
+	changed bool
+}
+
+func newArtifactProgress() *artifactProgress {
+	return &artifactProgress{now: time.Now}
+}
+
+// start starts tracking a phase of saving an artifact, with the total number of bytes if it is known
+func (p *artifactProgress) start(artifact string, phase wfv1.ArtifactProgressPhase, total int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = p.now()
+	p.progress = wfv1.ArtifactProgress{Artifact: artifact, Phase: phase, BytesTotal: total}
+	p.changed = true
+}
+
+// add adds to the number of bytes done
+func (p *artifactProgress) add(n int64) {
+	if p == nil || n <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progress.BytesDone += n
+	// uploads may be larger than the staged file, e.g. if they are encrypted or retried
+	if p.progress.BytesTotal > 0 && p.progress.BytesDone > p.progress.BytesTotal {
+		p.progress.BytesDone = p.progress.BytesTotal
+	}
+	p.changed = true
+}
+
+// complete marks the current phase as done, for drivers that cannot report their progress
+func (p *artifactProgress) complete() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.progress.BytesDone != p.progress.BytesTotal {
+		p.progress.BytesDone = p.progress.BytesTotal
+		p.changed = true
+	}
+}
+
+// get returns the progress, with the ETA estimated from the rate so far, and whether it changed since the last get
+func (p *artifactProgress) get() (*wfv1.ArtifactProgress, bool) {
+	if p == nil {
+		return nil, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.progress.Artifact == "" {
+		return nil, false
+	}
+	changed := p.changed
+	p.changed = false
+	progress := p.progress.DeepCopy()
+	done, total := progress.BytesDone, progress.BytesTotal
+	now := p.now()
+	if elapsed := now.Sub(p.started); done > 0 && total > 0 && elapsed > 0 {
+		remaining := time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+		progress.ETA = &metav1.Time{Time: now.Add(remaining).Truncate(time.Second)}
+	}
+	return progress, changed
+}
+
+// monitorArtifactProgress reports the progress of saving the output artifacts into the node status every
+// `annotationPatchTickDuration`, until the returned function is called, which reports the final progress
+func (we *WorkflowExecutor) monitorArtifactProgress(ctx context.Context) func() {
+	if we.annotationPatchTickDuration <= 0 {
+		return func() {}
+	}
+	report := func() {
+		if progress, changed := we.artifactProgress.get(); changed {
+			if err := we.reportResult(ctx, wfv1.NodeResult{ArtifactProgress: progress}); err != nil {
+				log.WithError(err).Info("failed to report artifact progress")
+			}
+		}
+	}
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(we.annotationPatchTickDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				report()
+				return
+			case <-ticker.C:
+				report()
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		wg.Wait()
+	}
+}
+
+// largestFileSize returns the size of the largest file in the path that can be read
+func largestFileSize(p string) int64 {
+	var size int64
+	_ = filepath.Walk(p, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size = max(size, info.Size())
+		}
+		return nil
+	})
+	return size
+}
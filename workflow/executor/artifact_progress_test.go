@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestArtifactProgress(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var p *artifactProgress
+		p.start("my-art", wfv1.ArtifactProgressPacking, 100)
+		p.add(10)
+		p.complete()
+		progress, changed := p.get()
+		assert.Nil(t, progress)
+		assert.False(t, changed)
+	})
+	t.Run("NotStarted", func(t *testing.T) {
+		progress, changed := newArtifactProgress().get()
+		assert.Nil(t, progress)
+		assert.False(t, changed)
+	})
+	t.Run("ETA", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		p := newArtifactProgress()
+		p.now = func() time.Time { return now }
+		p.start("my-art", wfv1.ArtifactProgressUploading, 100)
+		now = now.Add(10 * time.Second)
+		p.add(25)
+
+		progress, changed := p.get()
+		require.NotNil(t, progress)
+		assert.True(t, changed)
+		assert.Equal(t, "my-art", progress.Artifact)
+		assert.Equal(t, wfv1.ArtifactProgressUploading, progress.Phase)
+		assert.Equal(t, int64(25), progress.BytesDone)
+		assert.Equal(t, int64(100), progress.BytesTotal)
+		require.NotNil(t, progress.ETA)
+		assert.Equal(t, now.Add(30*time.Second), progress.ETA.Time)
+
+		_, changed = p.get()
+		assert.False(t, changed, "unchanged since the last get")
+	})
+	t.Run("UnknownTotal", func(t *testing.T) {
+		p := newArtifactProgress()
+		p.start("my-art", wfv1.ArtifactProgressPacking, 0)
+		p.add(25)
+		progress, _ := p.get()
+		require.NotNil(t, progress)
+		assert.Equal(t, int64(25), progress.BytesDone)
+		assert.Nil(t, progress.ETA)
+	})
+	t.Run("Complete", func(t *testing.T) {
+		p := newArtifactProgress()
+		p.start("my-art", wfv1.ArtifactProgressUploading, 100)
+		p.add(150)
+		progress, _ := p.get()
+		require.NotNil(t, progress)
+		assert.Equal(t, int64(100), progress.BytesDone, "bytes done are capped at the total")
+		p.start("my-other-art", wfv1.ArtifactProgressUploading, 100)
+		p.complete()
+		progress, _ = p.get()
+		require.NotNil(t, progress)
+		assert.Equal(t, int64(100), progress.BytesDone)
+	})
+}
@@ -0,0 +1,55 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/util/env"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	executorretry "github.com/argoproj/argo-workflows/v3/workflow/executor/retry"
+)
+
+// retryArtifactOperation retries the transient failures of the load or save with the backoff of the executor, limited
+// by the retry budget of the controller's configuration. If it still fails transiently once the attempts are used up,
+// the error says that the artifact repository is unavailable, for the controller to count. An error because the
+// artifact repository is out of space is not retried, and says so, for the controller to report.
+func retryArtifactOperation(f func() error) error {
+	backoff := executorretry.ExecutorRetry
+	attempts := env.LookupEnvIntOr(common.EnvVarArtifactRetryMaxAttempts, backoff.Steps)
+	// the cap of a wait.Backoff ends the retries once it is reached, so the delays are capped here instead
+	maxBackoff := env.LookupEnvDurationOr(common.EnvVarArtifactRetryMaxBackoff, 0)
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if artifactcommon.ErrorKindOf(err) == artifactcommon.ErrorKindQuotaExceeded {
+			return fmt.Errorf("%s: %w", common.ArtifactRepositoryQuotaExceededMessage, err)
+		}
+		if !isRetryableArtifactErr(err) {
+			return err
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("%s: %w", common.ArtifactRepositoryUnavailableMessage, err)
+		}
+		delay := backoff.Step()
+		if maxBackoff > 0 {
+			delay = min(delay, maxBackoff)
+		}
+		time.Sleep(delay)
+	}
+}
+
+// isRetryableArtifactErr is artifactcommon.IsRetryable, except that DNS errors are retried however the client of the
+// artifact repository wraps them, e.g. in a *url.Error. An unresolvable host is then retried like any other transient
+// network error, whereas the controller still fails fast on unresolvable hosts, e.g. of image registries.
+func isRetryableArtifactErr(err error) bool {
+	var dnsErr *net.DNSError
+	if artifactcommon.ErrorKindOf(err) == "" && errors.As(err, &dnsErr) {
+		return true
+	}
+	return artifactcommon.IsRetryable(err)
+}
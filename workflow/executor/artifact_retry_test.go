@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestRetryArtifactOperation(t *testing.T) {
+	t.Setenv(common.EnvVarArtifactRetryMaxAttempts, "3")
+	t.Setenv(common.EnvVarArtifactRetryMaxBackoff, "1ms")
+
+	t.Run("Succeeds", func(t *testing.T) {
+		attempts := 0
+		err := retryArtifactOperation(func() error {
+			attempts++
+			if attempts < 2 {
+				return errorsutil.NewErrTransient("connection refused")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+	t.Run("WrappedDNSError", func(t *testing.T) {
+		attempts := 0
+		err := retryArtifactOperation(func() error {
+			attempts++
+			if attempts < 2 {
+				return &url.Error{Op: "Get", URL: "https://my-bucket.s3.amazonaws.com", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "my-bucket.s3.amazonaws.com"}}}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+	t.Run("BudgetUsedUp", func(t *testing.T) {
+		attempts := 0
+		err := retryArtifactOperation(func() error {
+			attempts++
+			return errorsutil.NewErrTransient("connection refused")
+		})
+		require.Error(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Contains(t, err.Error(), common.ArtifactRepositoryUnavailableMessage)
+	})
+	t.Run("NotTransient", func(t *testing.T) {
+		attempts := 0
+		err := retryArtifactOperation(func() error {
+			attempts++
+			return errors.New("access denied")
+		})
+		require.EqualError(t, err, "access denied")
+		assert.Equal(t, 1, attempts)
+	})
+	t.Run("Throttled", func(t *testing.T) {
+		attempts := 0
+		err := retryArtifactOperation(func() error {
+			attempts++
+			if attempts < 2 {
+				return artifactcommon.NewDriverError(artifactcommon.ErrorKindThrottled, errors.New("slow down"))
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		attempts := 0
+		err := retryArtifactOperation(func() error {
+			attempts++
+			// the message would otherwise match a transient network error
+			return artifactcommon.NewDriverError(artifactcommon.ErrorKindForbidden, errors.New("connection reset by peer"))
+		})
+		require.EqualError(t, err, "connection reset by peer")
+		assert.Equal(t, 1, attempts)
+	})
+	t.Run("QuotaExceeded", func(t *testing.T) {
+		attempts := 0
+		err := retryArtifactOperation(func() error {
+			attempts++
+			return artifactcommon.NewDriverError(artifactcommon.ErrorKindQuotaExceeded, errors.New("storage full"))
+		})
+		require.EqualError(t, err, common.ArtifactRepositoryQuotaExceededMessage+": storage full")
+		assert.Equal(t, 1, attempts)
+	})
+}
@@ -0,0 +1,163 @@
+package executor
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+)
+
+// contentAddressableKeyPrefix is the prefix of the keys that content-addressable artifacts are saved to
+const contentAddressableKeyPrefix = "sha256"
+
+// contentAddressableKey returns the key that a content-addressable artifact staged at localArtPath is saved to
+func contentAddressableKey(fileName, localArtPath string) (string, error) {
+	digest, err := contentDigest(localArtPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute content digest: %w", err)
+	}
+	return path.Join(contentAddressableKeyPrefix, digest, fileName), nil
+}
+
+// contentDigest returns the SHA-256 digest of the content of a staged artifact.
+// Tarballs are digested by the names, modes and contents of their entries, rather than their bytes, as the
+// modification times in the headers would otherwise change the digest of unchanged content. Directories are digested
+// as entries too, so that artifacts that only differ by an empty directory or by permissions are told apart. The size
+// of each entry is digested before its content, so that the content of one entry cannot be mistaken for the name of
+// the next.
+func contentDigest(localArtPath string) (string, error) {
+	h := sha256.New()
+	isDir, err := file.IsDirectory(localArtPath)
+	if err != nil {
+		return "", err
+	}
+	if isDir {
+		err = filepath.Walk(localArtPath, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(localArtPath, p)
+			if err != nil || rel == "." {
+				return err
+			}
+			if info.IsDir() {
+				_, _ = fmt.Fprintf(h, "%s\x00%o\x00", filepath.ToSlash(rel), uint32(info.Mode()))
+				return nil
+			}
+			return digestEntry(h, filepath.ToSlash(rel), p)
+		})
+		return hex.EncodeToString(h.Sum(nil)), err
+	}
+	isTar, err := isTarball(localArtPath)
+	if err != nil {
+		return "", err
+	}
+	if isTar {
+		err = digestTarball(h, localArtPath)
+	} else {
+		err = digestEntry(h, "", localArtPath)
+	}
+	return hex.EncodeToString(h.Sum(nil)), err
+}
+
+func digestEntry(h io.Writer, name, p string) error {
+	f, err := os.Open(filepath.Clean(p))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(h, "%s\x00%o\x00%d\x00", name, uint32(info.Mode()), info.Size())
+	_, err = io.CopyN(h, f, info.Size())
+	return err
+}
+
+func digestTarball(h io.Writer, p string) error {
+	f, err := os.Open(filepath.Clean(p))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(h, "%s\x00%c\x00%o\x00%s\x00%d\x00", header.Name, header.Typeflag, header.Mode, header.Linkname, header.Size)
+		if _, err := io.CopyN(h, tr, header.Size); err != nil {
+			return err
+		}
+	}
+}
+
+// checksumPrefix is the prefix of the checksums of saved artifacts, naming their algorithm
+const checksumPrefix = "sha256:"
+
+// setArtifactChecksum sets the size and checksum of the bytes of an artifact staged at localArtPath. Directories are
+// saved file by file, so only their size is set.
+func setArtifactChecksum(art *wfv1.Artifact, localArtPath string) error {
+	isDir, err := file.IsDirectory(localArtPath)
+	if err != nil {
+		return err
+	}
+	if isDir {
+		art.SizeBytes = file.Size(localArtPath)
+		return nil
+	}
+	f, err := os.Open(filepath.Clean(localArtPath))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	r := newChecksumReader(f)
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return err
+	}
+	r.setOn(art)
+	return nil
+}
+
+// checksumReader counts and digests the bytes that are read through it
+type checksumReader struct {
+	io.Reader
+	h    hash.Hash
+	size int64
+}
+
+func newChecksumReader(r io.Reader) *checksumReader {
+	h := sha256.New()
+	return &checksumReader{Reader: io.TeeReader(r, h), h: h}
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// setOn sets the size and checksum of the bytes that were read on the artifact
+func (r *checksumReader) setOn(art *wfv1.Artifact) {
+	art.SizeBytes = r.size
+	art.Checksum = checksumPrefix + hex.EncodeToString(r.h.Sum(nil))
+}
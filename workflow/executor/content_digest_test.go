@@ -0,0 +1,162 @@
+package executor
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func writeTarball(t *testing.T, p string, modTime time.Time, mode int64, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(p)
+	require.NoError(t, err)
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		content := files[name]
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: mode, Size: int64(len(content)), ModTime: modTime, Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	require.NoError(t, f.Close())
+}
+
+func TestContentDigest(t *testing.T) {
+	dir := t.TempDir()
+	t.Run("Tarball", func(t *testing.T) {
+		a := filepath.Join(dir, "a.tgz")
+		b := filepath.Join(dir, "b.tgz")
+		c := filepath.Join(dir, "c.tgz")
+		writeTarball(t, a, time.Unix(0, 0), 0o644, map[string]string{"foo": "bar"})
+		writeTarball(t, b, time.Now(), 0o644, map[string]string{"foo": "bar"})
+		writeTarball(t, c, time.Unix(0, 0), 0o644, map[string]string{"foo": "baz"})
+		digestA, err := contentDigest(a)
+		require.NoError(t, err)
+		digestB, err := contentDigest(b)
+		require.NoError(t, err)
+		digestC, err := contentDigest(c)
+		require.NoError(t, err)
+		assert.Equal(t, digestA, digestB, "modification times do not change the digest")
+		assert.NotEqual(t, digestA, digestC, "content changes the digest")
+	})
+	t.Run("TarballMode", func(t *testing.T) {
+		script := filepath.Join(dir, "script.tgz")
+		executable := filepath.Join(dir, "executable.tgz")
+		writeTarball(t, script, time.Unix(0, 0), 0o644, map[string]string{"run.sh": "echo hello"})
+		writeTarball(t, executable, time.Unix(0, 0), 0o755, map[string]string{"run.sh": "echo hello"})
+		keyScript, err := contentAddressableKey("out.tgz", script)
+		require.NoError(t, err)
+		keyExecutable, err := contentAddressableKey("out.tgz", executable)
+		require.NoError(t, err)
+		assert.NotEqual(t, keyScript, keyExecutable, "modes change the key")
+	})
+	t.Run("DirectoryMode", func(t *testing.T) {
+		p := filepath.Join(dir, "mode")
+		require.NoError(t, os.MkdirAll(p, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(p, "run.sh"), []byte("echo hello"), 0o600))
+		digest, err := contentDigest(p)
+		require.NoError(t, err)
+		require.NoError(t, os.Chmod(filepath.Join(p, "run.sh"), 0o700))
+		changed, err := contentDigest(p)
+		require.NoError(t, err)
+		assert.NotEqual(t, digest, changed, "modes change the digest")
+	})
+	t.Run("EmptyDirectory", func(t *testing.T) {
+		p := filepath.Join(dir, "empty")
+		require.NoError(t, os.MkdirAll(p, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(p, "foo"), []byte("bar"), 0o600))
+		digest, err := contentDigest(p)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(p, "sub"), 0o700))
+		changed, err := contentDigest(p)
+		require.NoError(t, err)
+		assert.NotEqual(t, digest, changed, "empty directories change the digest")
+	})
+	t.Run("File", func(t *testing.T) {
+		p := filepath.Join(dir, "file")
+		require.NoError(t, os.WriteFile(p, []byte("foo"), 0o600))
+		digest, err := contentDigest(p)
+		require.NoError(t, err)
+		assert.Len(t, digest, 64)
+	})
+	t.Run("Directory", func(t *testing.T) {
+		p := filepath.Join(dir, "dir")
+		require.NoError(t, os.MkdirAll(p, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(p, "foo"), []byte("bar"), 0o600))
+		digest, err := contentDigest(p)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(p, "foo"), []byte("baz"), 0o600))
+		changed, err := contentDigest(p)
+		require.NoError(t, err)
+		assert.NotEqual(t, digest, changed)
+	})
+	t.Run("Key", func(t *testing.T) {
+		p := filepath.Join(dir, "key")
+		require.NoError(t, os.WriteFile(p, []byte("foo"), 0o600))
+		key, err := contentAddressableKey("out.tgz", p)
+		require.NoError(t, err)
+		assert.Regexp(t, `^sha256/[0-9a-f]{64}/out\.tgz$`, key)
+	})
+	t.Run("Collision", func(t *testing.T) {
+		// without the sizes, the content of "a" would be digested the same as the name of "b"
+		two := filepath.Join(dir, "two")
+		require.NoError(t, os.MkdirAll(two, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(two, "a"), nil, 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(two, "b"), nil, 0o600))
+		one := filepath.Join(dir, "one")
+		require.NoError(t, os.MkdirAll(one, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(one, "a"), []byte("b\x00"), 0o600))
+		digestTwo, err := contentDigest(two)
+		require.NoError(t, err)
+		digestOne, err := contentDigest(one)
+		require.NoError(t, err)
+		assert.NotEqual(t, digestTwo, digestOne)
+
+		twoTgz := filepath.Join(dir, "two.tgz")
+		oneTgz := filepath.Join(dir, "one.tgz")
+		writeTarball(t, twoTgz, time.Unix(0, 0), 0o644, map[string]string{"a": "", "b": ""})
+		writeTarball(t, oneTgz, time.Unix(0, 0), 0o644, map[string]string{"a": "b\x000\x00644\x00\x000\x00"})
+		digestTwo, err = contentDigest(twoTgz)
+		require.NoError(t, err)
+		digestOne, err = contentDigest(oneTgz)
+		require.NoError(t, err)
+		assert.NotEqual(t, digestTwo, digestOne)
+	})
+}
+
+func TestSetArtifactChecksum(t *testing.T) {
+	dir := t.TempDir()
+	t.Run("File", func(t *testing.T) {
+		p := filepath.Join(dir, "file")
+		require.NoError(t, os.WriteFile(p, []byte("foo"), 0o600))
+		art := &wfv1.Artifact{}
+		require.NoError(t, setArtifactChecksum(art, p))
+		assert.Equal(t, int64(3), art.SizeBytes)
+		assert.Equal(t, "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", art.Checksum)
+	})
+	t.Run("Directory", func(t *testing.T) {
+		p := filepath.Join(dir, "dir")
+		require.NoError(t, os.MkdirAll(p, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(p, "foo"), []byte("bar"), 0o600))
+		art := &wfv1.Artifact{}
+		require.NoError(t, setArtifactChecksum(art, p))
+		assert.Equal(t, int64(3), art.SizeBytes)
+		assert.Empty(t, art.Checksum)
+	})
+}
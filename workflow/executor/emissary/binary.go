@@ -9,7 +9,8 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
-func copyBinary() error {
+// CopyBinary copies argoexec into the volume that is shared with the other containers of the pod, so that they can run it
+func CopyBinary() error {
 	name, err := exec.LookPath("argoexec")
 	if err != nil {
 		return err
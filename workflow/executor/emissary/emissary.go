@@ -40,6 +40,10 @@ If the container is named `main` it also copies base-layer artifacts to the shar
 * `/var/run/argo/outputs/parameters/${path}` All output parameters are copied here, e.g. `/tmp/message` is moved to `/var/run/argo/outputs/parameters/tmp/message`.
 * `/var/run/argo/outputs/artifacts/${path}.tgz` All output artifacts are copied here, e.g. `/tmp/message` is moved to /var/run/argo/outputs/artifacts/tmp/message.tgz`.
 
+A sidecar instead copies the output artifacts whose `container` is its name to its own directory:
+
+* `/var/run/argo/ctr/${containerName}/outputs/artifacts/${path}.tgz`
+
 The wait container can create one file itself, used for terminating the sub-process:
 
 * `/var/run/argo/ctr/${containerName}/signal` The emissary binary listens to changes in this file, and signals the sub-process with the value found in this file.
@@ -52,7 +56,7 @@ func New() (executor.ContainerRuntimeExecutor, error) {
 
 func (e *emissary) Init(t wfv1.Template) error {
 	osspecific.AllowGrantingAccessToEveryone()
-	if err := copyBinary(); err != nil {
+	if err := CopyBinary(); err != nil {
 		return err
 	}
 	if err := e.writeTemplate(t); err != nil {
@@ -74,11 +78,14 @@ func (e emissary) GetFileContents(_ string, sourcePath string) (string, error) {
 	return string(data), err
 }
 
-func (e emissary) CopyFile(_ string, sourcePath string, destPath string, _ int) error {
+func (e emissary) CopyFile(containerName string, sourcePath string, destPath string, _ int) error {
 	// this implementation is very different, because we expect the emissary binary has already compressed the file
 	// so no compression can or needs to be implemented here
 	// TODO - warn the user we ignored compression?
 	sourceFile := filepath.Join(common.VarRunArgoPath, "outputs", "artifacts", strings.TrimSuffix(sourcePath, "/")+".tgz")
+	if containerName != common.MainContainerName {
+		sourceFile = filepath.Join(common.VarRunArgoPath, "ctr", containerName, "outputs", "artifacts", strings.TrimSuffix(sourcePath, "/")+".tgz")
+	}
 	log.Infof("%s -> %s", sourceFile, destPath)
 	src, err := os.Open(filepath.Clean(sourceFile))
 	if err != nil {
@@ -14,9 +14,12 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/argoproj/argo-workflows/v3/util/file"
@@ -31,6 +34,7 @@ import (
 	"k8s.io/client-go/rest"
 	retryutil "k8s.io/client-go/util/retry"
 
+	"github.com/argoproj/argo-workflows/v3"
 	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	argoprojv1 "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
@@ -76,6 +80,12 @@ type WorkflowExecutor struct {
 
 	// current progress which is synced every `annotationPatchTickDuration` to the pods annotations.
 	progress wfv1.Progress
+	// progress of saving the output artifacts, which is also synced every `annotationPatchTickDuration`
+	artifactProgress *artifactProgress
+	// runtime context of the main containers, which is recorded once they complete
+	provenance *wfv1.NodeProvenance
+	// outputs that were truncated because they were larger than the output limits of the template
+	truncatedOutputs []string
 
 	annotationPatchTickDuration  time.Duration
 	readProgressFileTickDuration time.Duration
@@ -187,7 +197,9 @@ func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 		// Determine the file path of where to load the artifact
 		var artPath string
 		mnt := common.FindOverlappingVolume(&we.Template, art.Path)
-		if mnt == nil {
+		if mnt == nil && art.StageInMemory {
+			artPath = path.Join(common.ExecutorArtifactMemoryDir, art.Name)
+		} else if mnt == nil {
 			artPath = path.Join(common.ExecutorArtifactBaseDir, art.Name)
 		} else {
 			// If we get here, it means the input artifact path overlaps with a user-specified
@@ -208,13 +220,22 @@ func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 		if err := os.MkdirAll(tempArtDir, 0o700); err != nil {
 			return fmt.Errorf("failed to create artifact temporary parent directory %s: %w", tempArtDir, err)
 		}
-		err = artDriver.Load(driverArt, tempArtPath)
+		err = withArtifactTimeout(driverArt, "load", func() error {
+			// transient failures, e.g. of DNS, are retried here rather than failing the step, which would use up its retries
+			return retryArtifactOperation(func() error {
+				// a failed attempt may have left a partial download behind
+				if err := os.RemoveAll(tempArtPath); err != nil {
+					return err
+				}
+				return loadArtifact(artDriver, driverArt, tempArtPath)
+			})
+		})
 		if err != nil {
 			if art.Optional && argoerrs.IsCode(argoerrs.CodeNotFound, err) {
 				log.Infof("Skipping optional input artifact that was not found: %s", art.Name)
 				continue
 			}
-			return fmt.Errorf("artifact %s failed to load: %w", art.Name, err)
+			return fmt.Errorf("artifact %s failed to load: %w", art.Name, memoryStagingError(&art, err))
 		}
 
 		isTar := false
@@ -248,7 +269,7 @@ func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 			err = os.Rename(tempArtPath, artPath)
 		}
 		if err != nil {
-			return err
+			return memoryStagingError(&art, err)
 		}
 
 		log.Infof("Successfully download file: %s", artPath)
@@ -262,6 +283,15 @@ func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 	return nil
 }
 
+// memoryStagingError explains that an artifact staged in memory did not fit, as the error of running out of space in
+// a tmpfs says nothing about its limit
+func memoryStagingError(art *wfv1.Artifact, err error) error {
+	if art.StageInMemory && errors.Is(err, syscall.ENOSPC) {
+		return fmt.Errorf("input artifacts staged in memory are larger than the memory staging limit of the controller: %w", err)
+	}
+	return err
+}
+
 // StageFiles will create any files required by script/resource templates
 func (we *WorkflowExecutor) StageFiles() error {
 	var filePath string
@@ -304,10 +334,19 @@ func (we *WorkflowExecutor) SaveArtifacts(ctx context.Context) (wfv1.Artifacts,
 	if err != nil {
 		return artifacts, argoerrs.InternalWrapError(err)
 	}
+	we.artifactProgress = newArtifactProgress()
+	stopMonitoringArtifactProgress := we.monitorArtifactProgress(ctx)
+	defer stopMonitoringArtifactProgress()
+
+	we.stopArtifactSidecars(ctx)
 
 	aggregateError := ""
 	for _, art := range we.Template.Outputs.Artifacts {
-		saved, err := we.saveArtifact(ctx, common.MainContainerName, &art)
+		containerName := common.MainContainerName
+		if art.Container != "" {
+			containerName = art.Container
+		}
+		saved, err := we.saveArtifact(ctx, containerName, &art)
 
 		if err != nil {
 			aggregateError += err.Error() + "; "
@@ -324,6 +363,45 @@ func (we *WorkflowExecutor) SaveArtifacts(ctx context.Context) (wfv1.Artifacts,
 
 }
 
+// SaveCheckpoint uploads the checkpoint artifacts of a template that can be preempted, to the keys that they are loaded
+// from when the node is restarted. A checkpoint artifact that the main container did not write is not saved.
+func (we *WorkflowExecutor) SaveCheckpoint(ctx context.Context) error {
+	if we.Template.Checkpoint == nil || len(we.Template.Checkpoint.Artifacts) == 0 {
+		return nil
+	}
+	// the main container is signalled when the wait container is, so it may still be writing its checkpoint
+	if err := we.RuntimeExecutor.Wait(ctx, we.Template.GetMainContainerNames()); err != nil {
+		return fmt.Errorf("failed to wait for main container to write its checkpoint: %w", err)
+	}
+	log.Info("Saving checkpoint artifacts")
+	if err := os.MkdirAll(tempOutArtDir, os.ModePerm); err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	we.artifactProgress = newArtifactProgress()
+	for _, art := range we.Template.Checkpoint.Artifacts {
+		art.Optional = true
+		if _, err := we.saveArtifact(ctx, common.MainContainerName, &art); err != nil {
+			return fmt.Errorf("failed to save checkpoint artifact %s: %w", art.Name, err)
+		}
+	}
+	return nil
+}
+
+// stopArtifactSidecars stops the sidecars that have output artifacts, as they save their artifacts when they exit
+func (we *WorkflowExecutor) stopArtifactSidecars(ctx context.Context) {
+	var containerNames []string
+	for _, art := range we.Template.Outputs.Artifacts {
+		if art.Container != "" && !slices.Contains(containerNames, art.Container) {
+			containerNames = append(containerNames, art.Container)
+		}
+	}
+	if len(containerNames) == 0 {
+		return
+	}
+	log.WithField("containerNames", containerNames).Info("Stopping sidecars to save their output artifacts")
+	we.killContainers(ctx, containerNames)
+}
+
 // save artifact
 // return whether artifact was in fact saved, and if there was an error
 func (we *WorkflowExecutor) saveArtifact(ctx context.Context, containerName string, art *wfv1.Artifact) (bool, error) {
@@ -332,6 +410,9 @@ func (we *WorkflowExecutor) saveArtifact(ctx context.Context, containerName stri
 	if err != nil {
 		return false, err
 	}
+	if streamed, err := we.saveArtifactStream(ctx, art); streamed || err != nil {
+		return streamed, err
+	}
 	fileName, localArtPath, err := we.stageArchiveFile(containerName, art)
 	if err != nil {
 		if art.Optional && argoerrs.IsCode(argoerrs.CodeNotFound, err) {
@@ -354,36 +435,130 @@ func (we *WorkflowExecutor) saveArtifact(ctx context.Context, containerName stri
 
 // fileBase is probably path.Base(filePath), but can be something else
 func (we *WorkflowExecutor) saveArtifactFromFile(ctx context.Context, art *wfv1.Artifact, fileName, localArtPath string) error {
-	if !art.HasKey() {
-		key, err := we.Template.ArchiveLocation.GetKey()
+	if err := we.setArtifactKey(art, fileName, localArtPath); err != nil {
+		return err
+	}
+	driverArt, err := we.newDriverArt(art)
+	if err != nil {
+		return err
+	}
+	artDriver, err := we.InitDriver(ctx, driverArt)
+	if err != nil {
+		return err
+	}
+	exists := false
+	if art.ContentAddressable {
+		exists, err = artifactcommon.Exists(artDriver, driverArt)
 		if err != nil {
 			return err
 		}
-		artLocation, err := we.Template.ArchiveLocation.Get()
+	}
+	if exists {
+		log.WithField("artifactName", art.Name).Info("Content-addressable artifact already exists, skipping upload")
+	} else {
+		err = we.saveWithProgress(artDriver, localArtPath, driverArt)
 		if err != nil {
 			return err
 		}
-		if err = art.SetType(artLocation); err != nil {
-			return err
-		}
-		if err := art.SetKey(path.Join(key, fileName)); err != nil {
-			return err
-		}
 	}
-	driverArt, err := we.newDriverArt(art)
+	if err = setArtifactChecksum(art, localArtPath); err != nil {
+		return fmt.Errorf("failed to compute checksum of artifact %s: %w", art.Name, err)
+	}
+	err = we.replicateArtifact(ctx, art, driverArt, localArtPath, artifact.NewDriver)
 	if err != nil {
 		return err
 	}
-	artDriver, err := we.InitDriver(ctx, driverArt)
+	we.maybeDeleteLocalArtPath(localArtPath)
+	log.Infof("Successfully saved file: %s", localArtPath)
+	return nil
+}
+
+// setArtifactKey sets the location of an artifact that has no key to the file in the archive location of the template
+func (we *WorkflowExecutor) setArtifactKey(art *wfv1.Artifact, fileName, localArtPath string) error {
+	// artifacts pushed to a git repository have no key
+	if art.HasKey() || art.Git.HasLocation() {
+		return nil
+	}
+	key, err := we.Template.ArchiveLocation.GetKey()
 	if err != nil {
 		return err
 	}
-	err = artDriver.Save(localArtPath, driverArt)
+	artLocation, err := we.Template.ArchiveLocation.Get()
 	if err != nil {
 		return err
 	}
-	we.maybeDeleteLocalArtPath(localArtPath)
-	log.Infof("Successfully saved file: %s", localArtPath)
+	if err = art.SetType(artLocation); err != nil {
+		return err
+	}
+	key = path.Join(key, fileName)
+	if art.ContentAddressable {
+		key, err = contentAddressableKey(fileName, localArtPath)
+		if err != nil {
+			return err
+		}
+		// the content may be shared with other workflows, so must not be garbage collected
+		art.ArtifactGC = &wfv1.ArtifactGC{Strategy: wfv1.ArtifactGCNever}
+	}
+	return art.SetKey(key)
+}
+
+// replicateArtifact copies a saved artifact to each of the locations in its replicateTo, recording the key
+// each replica was saved with
+func (we *WorkflowExecutor) replicateArtifact(ctx context.Context, art, driverArt *wfv1.Artifact, localArtPath string, newDriver artifact.NewDriverFunc) error {
+	if len(art.ReplicateTo) == 0 {
+		return nil
+	}
+	key, err := driverArt.GetKey()
+	if err != nil {
+		return err
+	}
+	replicas := make([]wfv1.ArtifactLocation, len(art.ReplicateTo))
+	for i, location := range art.ReplicateTo {
+		replica := driverArt.DeepCopy()
+		replica.ArtifactLocation = *location.DeepCopy()
+		replica.ReplicateTo = nil
+		if !replica.HasKey() {
+			if err := replica.SetKey(key); err != nil {
+				return err
+			}
+		}
+		artDriver, err := newDriver(ctx, replica, we)
+		if err != nil {
+			return err
+		}
+		if err := we.saveWithProgress(artDriver, localArtPath, replica); err != nil {
+			return fmt.Errorf("failed to replicate artifact %s to replicateTo[%d]: %w", art.Name, i, err)
+		}
+		replicas[i] = replica.ArtifactLocation
+		log.WithField("artifactName", art.Name).WithField("replica", i).Info("Successfully replicated artifact")
+	}
+	art.ReplicateTo = replicas
+	return nil
+}
+
+// saveWithProgress saves the artifact, tracking the progress of the upload. Artifacts with files that are larger than
+// the artifact repository can store fail before they are uploaded.
+func (we *WorkflowExecutor) saveWithProgress(artDriver artifactcommon.ArtifactDriver, localArtPath string, art *wfv1.Artifact) error {
+	if maxSize := artDriver.Capabilities().MaxObjectSize; maxSize > 0 {
+		if size := largestFileSize(localArtPath); size > maxSize {
+			return argoerrs.Errorf(argoerrs.CodeBadRequest, "artifact %s has a file of %d bytes, which is larger than the %d bytes that its artifact repository can store", art.Name, size, maxSize)
+		}
+	}
+	limiter := bandwidthLimiter(art)
+	if !artifactcommon.SetProgress(artDriver, throttleProgress(limiter, we.artifactProgress.add)) && limiter != nil {
+		log.WithField("artifactName", art.Name).Warn("The bandwidth of saving the artifact cannot be limited, as its driver does not report its progress")
+	}
+	err := withArtifactTimeout(art, "save", func() error {
+		return retryArtifactOperation(func() error {
+			// each attempt uploads the artifact from the start
+			we.artifactProgress.start(art.Name, wfv1.ArtifactProgressUploading, file.Size(localArtPath))
+			return artDriver.Save(localArtPath, art)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	we.artifactProgress.complete()
 	return nil
 }
 
@@ -415,16 +590,9 @@ func (we *WorkflowExecutor) stageArchiveFile(containerName string, art *wfv1.Art
 			Tar: &wfv1.TarStrategy{},
 		}
 	}
-	compressionLevel := gzip.NoCompression
-	if strategy.Tar != nil {
-		if l := strategy.Tar.CompressionLevel; l != nil {
-			compressionLevel = int(*l)
-		} else {
-			compressionLevel = gzip.DefaultCompression
-		}
-	}
+	compressionLevel := tarCompressionLevel(strategy)
 
-	if !we.isBaseImagePath(art.Path) {
+	if containerName == common.MainContainerName && !we.isBaseImagePath(art.Path) {
 		// If we get here, we are uploading an artifact from a mirrored volume mount which the wait
 		// sidecar has direct access to. We can upload directly from the shared volume mount,
 		// instead of copying it from the container.
@@ -447,7 +615,8 @@ func (we *WorkflowExecutor) stageArchiveFile(containerName string, art *wfv1.Art
 			}
 			zw := zip.NewWriter(f)
 			defer zw.Close()
-			err = archive.ZipToWriter(mountedArtPath, zw)
+			we.artifactProgress.start(art.Name, wfv1.ArtifactProgressPacking, file.Size(mountedArtPath))
+			err = archive.ZipToWriterWithProgress(mountedArtPath, zw, we.artifactProgress.add)
 			if err != nil {
 				return "", "", err
 			}
@@ -461,7 +630,8 @@ func (we *WorkflowExecutor) stageArchiveFile(containerName string, art *wfv1.Art
 			return "", "", argoerrs.InternalWrapError(err)
 		}
 		w := bufio.NewWriter(f)
-		err = archive.TarGzToWriter(mountedArtPath, compressionLevel, w)
+		we.artifactProgress.start(art.Name, wfv1.ArtifactProgressPacking, file.Size(mountedArtPath))
+		err = archive.TarGzToWriterWithProgress(mountedArtPath, compressionLevel, w, we.artifactProgress.add)
 		if err != nil {
 			return "", "", err
 		}
@@ -521,7 +691,8 @@ func (we *WorkflowExecutor) stageArchiveFile(containerName string, art *wfv1.Art
 		}
 		zw := zip.NewWriter(f)
 		defer zw.Close()
-		err = archive.ZipToWriter(unarchivedArtPath, zw)
+		we.artifactProgress.start(art.Name, wfv1.ArtifactProgressPacking, file.Size(unarchivedArtPath))
+		err = archive.ZipToWriterWithProgress(unarchivedArtPath, zw, we.artifactProgress.add)
 		if err != nil {
 			return "", "", err
 		}
@@ -531,6 +702,17 @@ func (we *WorkflowExecutor) stageArchiveFile(containerName string, art *wfv1.Art
 	return fileName, localArtPath, nil
 }
 
+// tarCompressionLevel returns the gzip compression level of the tarballs of artifacts with the archive strategy
+func tarCompressionLevel(strategy *wfv1.ArchiveStrategy) int {
+	if strategy.Tar == nil {
+		return gzip.NoCompression
+	}
+	if l := strategy.Tar.CompressionLevel; l != nil {
+		return int(*l)
+	}
+	return gzip.DefaultCompression
+}
+
 // isBaseImagePath checks if the given artifact path resides in the base image layer of the container
 // versus a shared volume mount between the wait and main container
 func (we *WorkflowExecutor) isBaseImagePath(path string) bool {
@@ -601,8 +783,15 @@ func (we *WorkflowExecutor) SaveParameters(ctx context.Context) error {
 		}
 
 		// Trims off a single newline for user convenience
-		output = wfv1.AnyStringPtr(strings.TrimSuffix(output.String(), "\n"))
-		we.Template.Outputs.Parameters[i].Value = output
+		value := strings.TrimSuffix(output.String(), "\n")
+		if limits := we.Template.OutputLimits; limits != nil {
+			var err error
+			value, err = we.limitOutput("parameters."+param.Name, value, limits.MaxParameterBytes)
+			if err != nil {
+				return err
+			}
+		}
+		we.Template.Outputs.Parameters[i].Value = wfv1.AnyStringPtr(value)
 		log.Infof("Successfully saved output parameter: %s", param.Name)
 	}
 	return nil
@@ -643,6 +832,11 @@ func (we *WorkflowExecutor) saveContainerLogs(ctx context.Context, tempLogsDir,
 	if err != nil {
 		return nil, err
 	}
+	if limits := we.Template.OutputLimits; limits != nil {
+		if err := we.limitFile("logs."+containerName, filePath, limits.MaxLogBytes); err != nil {
+			return nil, err
+		}
+	}
 
 	art := &wfv1.Artifact{Name: containerName + "-logs"}
 	err = we.saveArtifactFromFile(ctx, art, fileName, filePath)
@@ -788,6 +982,12 @@ func (we *WorkflowExecutor) CaptureScriptResult(ctx context.Context) error {
 	if outputLen > 0 && out[outputLen-1] == '\n' {
 		out = out[0 : outputLen-1]
 	}
+	if limits := we.Template.OutputLimits; limits != nil {
+		out, err = we.limitOutput("result", out, limits.MaxResultBytes)
+		if err != nil {
+			return err
+		}
+	}
 
 	const maxAnnotationSize int = 256 * (1 << 10) // 256 kB
 	// A character in a string is a byte
@@ -845,7 +1045,7 @@ func (we *WorkflowExecutor) InitializeOutput(ctx context.Context) {
 func (we *WorkflowExecutor) ReportOutputs(ctx context.Context, artifacts []wfv1.Artifact) error {
 	outputs := we.Template.Outputs.DeepCopy()
 	outputs.Artifacts = artifacts
-	return we.reportResult(ctx, wfv1.NodeResult{Outputs: outputs})
+	return we.reportResult(ctx, wfv1.NodeResult{Outputs: outputs, Provenance: we.provenance, TruncatedOutputs: we.truncatedOutputs})
 }
 
 // ReportOutputsLogs updates the WorkflowTaskResult log fields
@@ -855,7 +1055,7 @@ func (we *WorkflowExecutor) ReportOutputsLogs(ctx context.Context) error {
 	logArtifacts := we.SaveLogs(ctx)
 	artifacts = append(artifacts, logArtifacts...)
 	outputs.Artifacts = artifacts
-	return we.reportResult(ctx, wfv1.NodeResult{Outputs: &outputs})
+	return we.reportResult(ctx, wfv1.NodeResult{Outputs: &outputs, Provenance: we.provenance, TruncatedOutputs: we.truncatedOutputs})
 }
 
 func (we *WorkflowExecutor) reportResult(ctx context.Context, result wfv1.NodeResult) error {
@@ -1133,11 +1333,13 @@ func (we *WorkflowExecutor) Wait(ctx context.Context) error {
 
 	go we.monitorDeadline(ctx, containerNames)
 
+	startedAt := time.Now()
 	err := retryutil.OnError(executorretry.ExecutorRetry, errorsutil.IsTransientErr, func() error {
 		return we.RuntimeExecutor.Wait(ctx, containerNames)
 	})
 
 	log.WithError(err).Info("Main container completed")
+	we.recordProvenance(startedAt)
 
 	if err != nil && err != context.Canceled {
 		return fmt.Errorf("failed to wait for main container to complete: %w", err)
@@ -1145,6 +1347,21 @@ func (we *WorkflowExecutor) Wait(ctx context.Context) error {
 	return nil
 }
 
+// recordProvenance records the runtime context of the main containers, which ran from startedAt until now. The images
+// and environment of the containers are recorded by the controller, as the executor cannot see these.
+func (we *WorkflowExecutor) recordProvenance(startedAt time.Time) {
+	finishedAt := time.Now()
+	we.provenance = &wfv1.NodeProvenance{
+		OS:              runtime.GOOS,
+		Arch:            runtime.GOARCH,
+		ExecutorVersion: argo.GetVersion().Version,
+		StartedAt:       &metav1.Time{Time: startedAt},
+		FinishedAt:      &metav1.Time{Time: finishedAt},
+		// both times have monotonic clock readings, which Sub uses
+		Duration: &metav1.Duration{Duration: finishedAt.Sub(startedAt)},
+	}
+}
+
 // monitorProgress monitors for self-reported progress in the progressFile and patches the pod annotations with the parsed progress.
 //
 // The function reads the last line of the `progressFile` every `readFileTickDuration`.
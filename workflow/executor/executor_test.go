@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -20,6 +21,8 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	argofake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/executor/mocks"
 )
@@ -585,3 +588,78 @@ func TestReportOutputs(t *testing.T) {
 	})
 
 }
+
+// savingArtifactDriver records the content of the files that it saves, by key
+type savingArtifactDriver struct {
+	artifactcommon.ArtifactDriver
+	saved   map[string]string
+	saveErr error
+}
+
+func (d *savingArtifactDriver) Save(path string, a *wfv1.Artifact) error {
+	if d.saveErr != nil {
+		return d.saveErr
+	}
+	key, err := a.GetKey()
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	d.saved[key] = string(content)
+	return nil
+}
+
+func (d *savingArtifactDriver) Capabilities() artifactcommon.Capabilities {
+	return artifactcommon.Capabilities{}
+}
+
+func TestReplicateArtifact(t *testing.T) {
+	ctx := context.Background()
+	localArtPath := filepath.Join(t.TempDir(), "my-art.tgz")
+	require.NoError(t, os.WriteFile(localArtPath, []byte("foo"), 0o600))
+	driverArt := &wfv1.Artifact{Name: "my-art", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-wf/my-art.tgz"}}}
+	newArt := func() *wfv1.Artifact {
+		art := driverArt.DeepCopy()
+		art.ReplicateTo = []wfv1.ArtifactLocation{
+			{Azure: &wfv1.AzureArtifact{AzureBlobContainer: wfv1.AzureBlobContainer{Container: "my-container"}}},
+			{GCS: &wfv1.GCSArtifact{GCSBucket: wfv1.GCSBucket{Bucket: "my-bucket"}, Key: "replicas/my-art.tgz"}},
+		}
+		return art
+	}
+	drivers := func(azure, gcs *savingArtifactDriver) func(context.Context, *wfv1.Artifact, resource.Interface) (artifactcommon.ArtifactDriver, error) {
+		return func(_ context.Context, art *wfv1.Artifact, _ resource.Interface) (artifactcommon.ArtifactDriver, error) {
+			assert.Empty(t, art.ReplicateTo, "replicas are not replicated")
+			if art.Azure != nil {
+				return azure, nil
+			}
+			return gcs, nil
+		}
+	}
+	we := &WorkflowExecutor{}
+
+	t.Run("Replicated", func(t *testing.T) {
+		art := newArt()
+		azure := &savingArtifactDriver{saved: map[string]string{}}
+		gcs := &savingArtifactDriver{saved: map[string]string{}}
+		require.NoError(t, we.replicateArtifact(ctx, art, driverArt, localArtPath, drivers(azure, gcs)))
+		assert.Equal(t, map[string]string{"my-wf/my-art.tgz": "foo"}, azure.saved, "a replica without a key uses the key of the artifact")
+		assert.Equal(t, map[string]string{"replicas/my-art.tgz": "foo"}, gcs.saved)
+		require.Len(t, art.ReplicateTo, 2)
+		assert.Equal(t, "my-wf/my-art.tgz", art.ReplicateTo[0].Azure.Blob)
+		assert.Equal(t, "replicas/my-art.tgz", art.ReplicateTo[1].GCS.Key)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		art := newArt()
+		azure := &savingArtifactDriver{saved: map[string]string{}}
+		gcs := &savingArtifactDriver{saveErr: artifactcommon.NewDriverError(artifactcommon.ErrorKindForbidden, fmt.Errorf("access denied"))}
+		err := we.replicateArtifact(ctx, art, driverArt, localArtPath, drivers(azure, gcs))
+		require.ErrorContains(t, err, "failed to replicate artifact my-art to replicateTo[1]")
+		assert.Empty(t, art.ReplicateTo[0].Azure.Blob, "the keys of replicas are only recorded once they are all saved")
+	})
+	t.Run("NoReplicas", func(t *testing.T) {
+		require.NoError(t, we.replicateArtifact(ctx, driverArt.DeepCopy(), driverArt, localArtPath, nil))
+	})
+}
@@ -0,0 +1,55 @@
+package osspecific
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cgroupRoot is where the cgroup of the container is mounted
+var cgroupRoot = "/sys/fs/cgroup"
+
+// OOMKills returns the number of processes of the container that the kernel has killed because its memory cgroup ran
+// out of memory, and whether this is known. Both cgroup v2 and v1 are supported.
+func OOMKills() (int, bool) {
+	for _, path := range []string{"memory.events", "memory/memory.oom_control"} {
+		if n, ok := readCgroupStat(filepath.Join(cgroupRoot, path), "oom_kill"); ok {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// MemoryLimit returns the memory limit of the container in bytes, and whether it has one
+func MemoryLimit() (int64, bool) {
+	for _, path := range []string{"memory.max", "memory/memory.limit_in_bytes"} {
+		data, err := os.ReadFile(filepath.Join(cgroupRoot, path))
+		if err != nil {
+			continue
+		}
+		// cgroup v2 reports "max" for no limit, and v1 a very large number
+		limit, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		return limit, err == nil && limit < 1<<62
+	}
+	return 0, false
+}
+
+// readCgroupStat reads the value of the key from a file of "key value" lines
+func readCgroupStat(path, key string) (int64, bool) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return 0, false
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == key {
+			n, err := strconv.ParseInt(fields[1], 10, 64)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
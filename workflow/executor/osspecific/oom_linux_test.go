@@ -0,0 +1,53 @@
+package osspecific
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOOMKills(t *testing.T) {
+	withCgroup := func(t *testing.T, files map[string]string) {
+		root := t.TempDir()
+		for name, data := range files {
+			path := filepath.Join(root, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+		}
+		cgroupRoot = root
+		t.Cleanup(func() { cgroupRoot = "/sys/fs/cgroup" })
+	}
+	t.Run("V2", func(t *testing.T) {
+		withCgroup(t, map[string]string{
+			"memory.events": "low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\noom_group_kill 0\n",
+			"memory.max":    "268435456\n",
+		})
+		kills, ok := OOMKills()
+		assert.True(t, ok)
+		assert.Equal(t, 1, kills)
+		limit, ok := MemoryLimit()
+		assert.True(t, ok)
+		assert.Equal(t, int64(268435456), limit)
+	})
+	t.Run("V2NoLimit", func(t *testing.T) {
+		withCgroup(t, map[string]string{"memory.max": "max\n"})
+		_, ok := OOMKills()
+		assert.False(t, ok)
+		_, ok = MemoryLimit()
+		assert.False(t, ok)
+	})
+	t.Run("V1", func(t *testing.T) {
+		withCgroup(t, map[string]string{
+			"memory/memory.oom_control":    "oom_kill_disable 0\nunder_oom 0\noom_kill 2\n",
+			"memory/memory.limit_in_bytes": "9223372036854771712\n",
+		})
+		kills, ok := OOMKills()
+		assert.True(t, ok)
+		assert.Equal(t, 2, kills)
+		_, ok = MemoryLimit()
+		assert.False(t, ok)
+	})
+}
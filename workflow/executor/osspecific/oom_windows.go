@@ -0,0 +1,10 @@
+package osspecific
+
+func OOMKills() (int, bool) {
+	// There are no cgroups in Windows.
+	return 0, false
+}
+
+func MemoryLimit() (int64, bool) {
+	return 0, false
+}
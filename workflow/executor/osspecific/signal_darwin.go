@@ -53,7 +53,7 @@ func Wait(process *os.Process) error {
 			if s.Exited() {
 				return errors.NewExitErr(s.ExitStatus())
 			} else if s.Signaled() {
-				return errors.NewExitErr(128 + int(s.Signal()))
+				return errors.NewSignaledErr(s.Signal())
 			}
 		}
 		time.Sleep(time.Second)
@@ -1 +0,0 @@
-signal_darwin.go
\ No newline at end of file
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// truncationMarker marks where an output of the size was truncated
+func truncationMarker(size int64) string {
+	return fmt.Sprintf("[truncated from %d bytes]", size)
+}
+
+// truncate returns the output truncated to the limit, with a marker on its own line where it was truncated. The output
+// is truncated at the start of a UTF-8 character, so that it remains valid. Limits that are smaller than the marker
+// keep just the marker.
+func truncate(output string, limit int64, policy wfv1.OutputTruncationPolicy) string {
+	marker := truncationMarker(int64(len(output)))
+	keep := max(int(limit)-len(marker)-1, 0)
+	if policy == wfv1.OutputTruncationKeepFirst {
+		end := keep
+		for end > 0 && !utf8.RuneStart(output[end]) {
+			end--
+		}
+		return output[:end] + "\n" + marker
+	}
+	start := len(output) - keep
+	for start < len(output) && !utf8.RuneStart(output[start]) {
+		start++
+	}
+	return marker + "\n" + output[start:]
+}
+
+// checkOutputLimit returns whether the output of the size, which is named by name, e.g. `parameters.my-param`, must be
+// truncated to the limit, and records that it was. An error is returned if the policy is to fail instead.
+func (we *WorkflowExecutor) checkOutputLimit(name string, size int64, limit *int64) (bool, error) {
+	if limit == nil || size <= *limit {
+		return false, nil
+	}
+	if we.Template.OutputLimits.GetPolicy() == wfv1.OutputTruncationFail {
+		return false, argoerrs.Errorf(argoerrs.CodeBadRequest, "output %s is %d bytes, which is larger than its limit of %d bytes", name, size, *limit)
+	}
+	log.WithFields(log.Fields{"name": name, "size": size, "limit": *limit}).Warn("Truncating output that is larger than its limit")
+	we.truncatedOutputs = append(we.truncatedOutputs, name)
+	return true, nil
+}
+
+// limitOutput returns the output truncated to the limit according to the policy of the template
+func (we *WorkflowExecutor) limitOutput(name, output string, limit *int64) (string, error) {
+	truncated, err := we.checkOutputLimit(name, int64(len(output)), limit)
+	if err != nil || !truncated {
+		return output, err
+	}
+	return truncate(output, *limit, we.Template.OutputLimits.GetPolicy()), nil
+}
+
+// limitFile truncates the file to the limit according to the policy of the template, like limitOutput, but without
+// reading all of it into memory, as it may be a large log
+func (we *WorkflowExecutor) limitFile(name, path string, limit *int64) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	truncated, err := we.checkOutputLimit(name, info.Size(), limit)
+	if err != nil || !truncated {
+		return err
+	}
+	src, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	defer func() { _ = src.Close() }()
+	tmpPath := path + ".truncated"
+	dst, err := os.Create(tmpPath)
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	defer func() { _ = dst.Close() }()
+	marker := truncationMarker(info.Size())
+	keep := max(*limit-int64(len(marker))-1, 0)
+	if we.Template.OutputLimits.GetPolicy() == wfv1.OutputTruncationKeepFirst {
+		_, err = io.Copy(dst, io.LimitReader(src, keep))
+		if err == nil {
+			_, err = io.WriteString(dst, "\n"+marker)
+		}
+	} else {
+		_, err = io.WriteString(dst, marker+"\n")
+		if err == nil {
+			_, err = io.Copy(dst, io.NewSectionReader(src, info.Size()-keep, keep))
+		}
+	}
+	if err == nil {
+		err = dst.Close()
+	}
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	return os.Rename(tmpPath, path)
+}
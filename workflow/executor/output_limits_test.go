@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/executor/mocks"
+)
+
+func TestTruncate(t *testing.T) {
+	output := strings.Repeat("a", 50) + "é" + strings.Repeat("b", 49)
+	first := truncate(output, 78, wfv1.OutputTruncationKeepFirst)
+	assert.Equal(t, strings.Repeat("a", 50)+"\n[truncated from 101 bytes]", first)
+	last := truncate(output, 77, wfv1.OutputTruncationKeepLast)
+	assert.Equal(t, "[truncated from 101 bytes]\n"+strings.Repeat("b", 49), last)
+	assert.Equal(t, "[truncated from 101 bytes]\n", truncate(output, 10, wfv1.OutputTruncationKeepLast))
+}
+
+func TestSaveParametersOutputLimits(t *testing.T) {
+	newExecutor := func(policy wfv1.OutputTruncationPolicy) *WorkflowExecutor {
+		mockRuntimeExecutor := mocks.ContainerRuntimeExecutor{}
+		mockRuntimeExecutor.On("GetFileContents", fakeContainerName, "/path").Return(strings.Repeat("x", 100)+"\n", nil)
+		return &WorkflowExecutor{
+			PodName: fakePodName,
+			Template: wfv1.Template{
+				Outputs:      wfv1.Outputs{Parameters: []wfv1.Parameter{{Name: "my-out", ValueFrom: &wfv1.ValueFrom{Path: "/path"}}}},
+				OutputLimits: &wfv1.OutputLimits{MaxParameterBytes: ptr.To(int64(50)), Policy: policy},
+			},
+			ClientSet:       fake.NewSimpleClientset(),
+			Namespace:       fakeNamespace,
+			RuntimeExecutor: &mockRuntimeExecutor,
+		}
+	}
+	ctx := context.Background()
+
+	t.Run("Truncate", func(t *testing.T) {
+		we := newExecutor("")
+		require.NoError(t, we.SaveParameters(ctx))
+		value := we.Template.Outputs.Parameters[0].Value.String()
+		assert.Len(t, value, 50)
+		assert.True(t, strings.HasPrefix(value, "[truncated from 100 bytes]\n"))
+		assert.Equal(t, []string{"parameters.my-out"}, we.truncatedOutputs)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		we := newExecutor(wfv1.OutputTruncationFail)
+		err := we.SaveParameters(ctx)
+		require.ErrorContains(t, err, "output parameters.my-out is 100 bytes, which is larger than its limit of 50 bytes")
+		assert.Empty(t, we.truncatedOutputs)
+	})
+}
+
+func TestLimitFile(t *testing.T) {
+	for _, tt := range []struct {
+		policy  wfv1.OutputTruncationPolicy
+		content string
+	}{
+		{wfv1.OutputTruncationKeepFirst, "0123456789\n[truncated from 100 bytes]"},
+		{wfv1.OutputTruncationKeepLast, "[truncated from 100 bytes]\n0123456789"},
+	} {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "main.log")
+			require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("0123456789", 10)), 0o600))
+			we := &WorkflowExecutor{Template: wfv1.Template{OutputLimits: &wfv1.OutputLimits{Policy: tt.policy}}}
+
+			require.NoError(t, we.limitFile("logs.main", path, ptr.To(int64(37))))
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, string(data))
+			assert.Equal(t, []string{"logs.main"}, we.truncatedOutputs)
+
+			require.NoError(t, we.limitFile("logs.main", path, nil))
+			assert.Len(t, we.truncatedOutputs, 1)
+		})
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/itchyny/gojq"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -189,7 +191,7 @@ func (g gjsonLabels) Get(label string) string {
 
 // WaitResource waits for a specific resource to satisfy either the success or failure condition
 func (we *WorkflowExecutor) WaitResource(ctx context.Context, resourceNamespace, resourceName, selfLink string) error {
-	if we.Template.Resource.SuccessCondition == "" && we.Template.Resource.FailureCondition == "" {
+	if we.Template.Resource.SuccessCondition == "" && we.Template.Resource.FailureCondition == "" && we.Template.JobTemplate == nil {
 		return nil
 	}
 	var successReqs labels.Requirements
@@ -262,9 +264,30 @@ func (we *WorkflowExecutor) checkResourceState(ctx context.Context, selfLink str
 	if !gjson.Valid(jsonString) {
 		return false, errors.Errorf(errors.CodeNotFound, "Encountered invalid JSON response when checking resource status. Will not be retried: %q", jsonString)
 	}
+	if we.Template.JobTemplate != nil {
+		return matchJobConditions(jsonBytes)
+	}
 	return matchConditions(jsonBytes, successReqs, failReqs)
 }
 
+// matchJobConditions checks whether the returned JSON bytes of the Job of a Job template have the condition of a Job
+// that completed or failed
+func matchJobConditions(jsonBytes []byte) (bool, error) {
+	for _, condition := range gjson.GetBytes(jsonBytes, "status.conditions").Array() {
+		if condition.Get("status").String() != string(apiv1.ConditionTrue) {
+			continue
+		}
+		switch batchv1.JobConditionType(condition.Get("type").String()) {
+		case batchv1.JobComplete:
+			log.Info("Job completed")
+			return false, nil
+		case batchv1.JobFailed:
+			return false, errors.Errorf(errors.CodeBadRequest, "Job failed: %s: %s", condition.Get("reason").String(), condition.Get("message").String())
+		}
+	}
+	return true, errors.Errorf(errors.CodeNotFound, "The Job has neither completed nor failed. Retrying...")
+}
+
 // matchConditions checks whether the returned JSON bytes match success or failure conditions.
 func matchConditions(jsonBytes []byte, successReqs labels.Requirements, failReqs labels.Requirements) (bool, error) {
 	ls := gjsonLabels{json: jsonBytes}
@@ -156,6 +156,20 @@ func TestResourceConditionsMatching(t *testing.T) {
 	assert.True(t, finished)
 }
 
+func TestJobConditionsMatching(t *testing.T) {
+	retry, err := matchJobConditions([]byte(`{"status":{"active":3,"conditions":[{"type":"Suspended","status":"False"}]}}`))
+	require.EqualError(t, err, "The Job has neither completed nor failed. Retrying...")
+	assert.True(t, retry)
+
+	retry, err = matchJobConditions([]byte(`{"status":{"succeeded":3,"conditions":[{"type":"SuccessCriteriaMet","status":"True"},{"type":"Complete","status":"True"}]}}`))
+	require.NoError(t, err)
+	assert.False(t, retry)
+
+	retry, err = matchJobConditions([]byte(`{"status":{"failed":7,"conditions":[{"type":"Failed","status":"True","reason":"BackoffLimitExceeded","message":"Job has reached the specified backoff limit"}]}}`))
+	require.EqualError(t, err, "Job failed: BackoffLimitExceeded: Job has reached the specified backoff limit")
+	assert.False(t, retry)
+}
+
 // TestInferSelfLink tests whether the inferred self link for k8s objects are correct.
 func TestInferSelfLink(t *testing.T) {
 	obj := unstructured.Unstructured{}
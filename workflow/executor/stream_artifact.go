@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/archive"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// saveArtifactStream saves an artifact that is archived as a tarball by streaming the tarball to the artifact driver
+// as it is written, rather than staging it on local disk first, which doubles the disk space that large directories
+// use. It returns whether the artifact was streamed. Artifacts that cannot be streamed, e.g. because their driver does
+// not support streaming saves or their content addressed key needs the tarball, are left to be staged. Artifacts that
+// fail to stream are staged too, as streams cannot be retried.
+func (we *WorkflowExecutor) saveArtifactStream(ctx context.Context, art *wfv1.Artifact) (bool, error) {
+	if art.Container != "" || we.isBaseImagePath(art.Path) || (art.Archive != nil && art.Archive.Tar == nil) || art.ContentAddressable || len(art.ReplicateTo) > 0 {
+		return false, nil
+	}
+	mountedArtPath := filepath.Join(common.ExecutorMainFilesystemDir, art.Path)
+	if !file.Exists(mountedArtPath) {
+		return false, nil
+	}
+	streamArt := art.DeepCopy()
+	if err := we.setArtifactKey(streamArt, fmt.Sprintf("%s.tgz", art.Name), ""); err != nil {
+		return false, err
+	}
+	driverArt, err := we.newDriverArt(streamArt)
+	if err != nil {
+		return false, err
+	}
+	artDriver, err := we.InitDriver(ctx, driverArt)
+	if err != nil {
+		return false, err
+	}
+	capabilities := artDriver.Capabilities()
+	size := file.Size(mountedArtPath)
+	if !capabilities.StreamingSaves || (capabilities.MaxObjectSize > 0 && size > capabilities.MaxObjectSize) {
+		return false, nil
+	}
+	log.WithFields(log.Fields{"artifactName": art.Name, "path": mountedArtPath}).Info("Streaming artifact")
+	strategy := art.Archive
+	if strategy == nil {
+		strategy = &wfv1.ArchiveStrategy{Tar: &wfv1.TarStrategy{}}
+	}
+	// the progress is of the files that are archived, as the size of the tarball is not known until it is complete
+	we.artifactProgress.start(art.Name, wfv1.ArtifactProgressUploading, size)
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(archive.TarGzToWriterWithProgress(mountedArtPath, tarCompressionLevel(strategy), pw, we.artifactProgress.add))
+	}()
+	checksum := newChecksumReader(pr)
+	err = withArtifactTimeout(driverArt, "save", func() error {
+		return artifactcommon.SaveStream(artDriver, throttleReader(bandwidthLimiter(driverArt), checksum), driverArt)
+	})
+	// unblocks the archiving if the driver stopped reading the stream
+	_ = pr.CloseWithError(io.ErrClosedPipe)
+	if argoerrs.IsCode(argoerrs.CodeTimeout, err) {
+		return false, err
+	}
+	if err != nil {
+		log.WithError(err).WithField("artifactName", art.Name).Warn("Failed to stream artifact, staging it instead")
+		return false, nil
+	}
+	we.artifactProgress.complete()
+	checksum.setOn(streamArt)
+	*art = *streamArt
+	log.WithField("artifactName", art.Name).Info("Successfully streamed artifact")
+	return true, nil
+}
@@ -37,10 +37,13 @@ type Controller struct {
 	orderedQueueLock sync.Mutex
 	orderedQueue     map[wfv1.WorkflowPhase]*gcHeap
 	retentionPolicy  *config.RetentionPolicy
+	// pausedUntil returns when the current pause of garbage collection ends, or the zero time if it is not paused
+	pausedUntil func() time.Time
 }
 
-// NewController returns a new workflow ttl controller
-func NewController(ctx context.Context, wfClientset wfclientset.Interface, wfInformer cache.SharedIndexInformer, metrics *metrics.Metrics, retentionPolicy *config.RetentionPolicy) *Controller {
+// NewController returns a new workflow ttl controller. Workflows are not deleted whilst pausedUntil returns a time in
+// the future, and are instead deleted once it has passed.
+func NewController(ctx context.Context, wfClientset wfclientset.Interface, wfInformer cache.SharedIndexInformer, metrics *metrics.Metrics, retentionPolicy *config.RetentionPolicy, pausedUntil func() time.Time) *Controller {
 	orderedQueue := map[wfv1.WorkflowPhase]*gcHeap{
 		wfv1.WorkflowFailed:    NewHeap(),
 		wfv1.WorkflowError:     NewHeap(),
@@ -54,6 +57,7 @@ func NewController(ctx context.Context, wfClientset wfclientset.Interface, wfInf
 		metrics:         metrics,
 		orderedQueue:    orderedQueue,
 		retentionPolicy: retentionPolicy,
+		pausedUntil:     pausedUntil,
 	}
 
 	_, err := wfInformer.AddEventHandler(cache.FilteringResourceEventHandler{
@@ -209,6 +213,15 @@ func (c *Controller) deleteWorkflow(ctx context.Context, key string) error {
 	// It should be impossible for a workflow to have been queue without a valid key.
 	namespace, name, _ := cache.SplitMetaNamespaceKey(key)
 
+	// Defer the deletion until garbage collection is resumed, so that backups are consistent.
+	if c.pausedUntil != nil {
+		if until := c.pausedUntil(); until.After(c.clock.Now()) {
+			log.Infof("Garbage collection is paused until %v, deferring the deletion of workflow '%s'", until, key)
+			c.workqueue.AddAfter(key, until.Sub(c.clock.Now()))
+			return nil
+		}
+	}
+
 	// Double check that this workflow is still completed. If it were retried, it may be running again (c.f. https://github.com/argoproj/argo-workflows/issues/12636)
 	obj, exists, err := c.wfInformer.GetStore().GetByKey(key)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/cache"
@@ -424,6 +425,27 @@ func TestTTLStrategySucceeded(t *testing.T) {
 	assert.Equal(t, 1, controller.workqueue.Len())
 }
 
+func TestDeleteWorkflowPaused(t *testing.T) {
+	controller := newTTLController(t)
+	pausedUntil := controller.clock.Now().Add(time.Hour)
+	controller.pausedUntil = func() time.Time { return pausedUntil }
+
+	wf := wfv1.MustUnmarshalWorkflow([]byte(succeededWf))
+	ctx := context.Background()
+	_, err := controller.wfclientset.ArgoprojV1alpha1().Workflows("default").Create(ctx, wf, metav1.CreateOptions{})
+	require.NoError(t, err)
+	key, _ := cache.MetaNamespaceKeyFunc(wf)
+
+	require.NoError(t, controller.deleteWorkflow(ctx, key))
+	_, err = controller.wfclientset.ArgoprojV1alpha1().Workflows("default").Get(ctx, wf.Name, metav1.GetOptions{})
+	require.NoError(t, err, "the workflow is not deleted whilst garbage collection is paused")
+
+	pausedUntil = time.Time{}
+	require.NoError(t, controller.deleteWorkflow(ctx, key))
+	_, err = controller.wfclientset.ArgoprojV1alpha1().Workflows("default").Get(ctx, wf.Name, metav1.GetOptions{})
+	assert.True(t, apierr.IsNotFound(err))
+}
+
 func TestTTLStrategyFailed(t *testing.T) {
 	var err error
 	var un *unstructured.Unstructured
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+func addArtifactGCFailuresCounter(_ context.Context, m *Metrics) error {
+	return m.CreateBuiltinInstrument(telemetry.InstrumentArtifactGcFailures)
+}
+
+// ArtifactGCFailed records a failed attempt to delete an artifact, and whether it is no longer retried
+func (m *Metrics) ArtifactGCFailed(ctx context.Context, namespace string, deadLettered bool) {
+	m.AddInt(ctx, telemetry.InstrumentArtifactGcFailures.Name(), 1, telemetry.InstAttribs{
+		{Name: telemetry.AttribWorkflowNamespace, Value: namespace},
+		{Name: telemetry.AttribArtifactGCDeadLettered, Value: deadLettered},
+	})
+}
@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+func addArtifactQuotaExceededCounter(_ context.Context, m *Metrics) error {
+	return m.CreateBuiltinInstrument(telemetry.InstrumentArtifactQuotaExceeded)
+}
+
+// ArtifactQuotaExceeded records a pod that failed to save artifacts because an artifact repository was out of space
+func (m *Metrics) ArtifactQuotaExceeded(ctx context.Context, namespace string) {
+	m.AddInt(ctx, telemetry.InstrumentArtifactQuotaExceeded.Name(), 1, telemetry.InstAttribs{
+		{Name: telemetry.AttribWorkflowNamespace, Value: namespace},
+	})
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+
+	envutil "github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+// EnvVarExecutorMetricsPort is the port that the executor serves its metrics to Prometheus on, if set
+const EnvVarExecutorMetricsPort = "ARGO_EXECUTOR_METRICS_PORT"
+
+// NewExecutor creates the metrics of an executor, which are the metrics of its artifact drivers
+func NewExecutor(ctx context.Context, serviceName, prometheusName string, config *telemetry.Config, extraOpts ...metricsdk.Option) (*Metrics, error) {
+	m, err := telemetry.NewMetrics(ctx, serviceName, prometheusName, config, extraOpts...)
+	if err != nil {
+		return nil, err
+	}
+	err = m.Populate(ctx,
+		telemetry.AddVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	metrics := &Metrics{
+		Metrics:           m,
+		realtimeWorkflows: make(map[string][]realtimeTracker),
+	}
+	err = metrics.populate(ctx,
+		addArtifactMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// RunExecutor starts the executor's metrics, if they are enabled. They are served to Prometheus if
+// ARGO_EXECUTOR_METRICS_PORT is set, and exported via OTLP if the OpenTelemetry environment variables are set.
+// The returned function exports any remaining metrics and stops serving them. As executor pods are short-lived,
+// it must be called before exiting.
+func RunExecutor(ctx context.Context) func() {
+	port := envutil.LookupEnvIntOr(EnvVarExecutorMetricsPort, 0)
+	_, otlpEnabled := os.LookupEnv(`OTEL_EXPORTER_OTLP_ENDPOINT`)
+	_, otlpMetricsEnabled := os.LookupEnv(`OTEL_EXPORTER_OTLP_METRICS_ENDPOINT`)
+	if port <= 0 && !otlpEnabled && !otlpMetricsEnabled {
+		return func() {}
+	}
+	config := &telemetry.Config{
+		Enabled:     port > 0,
+		Port:        port,
+		Temporality: metricsdk.DefaultTemporalitySelector,
+	}
+	m, err := NewExecutor(ctx, `argo-executor`, `argo_workflows`, config)
+	if err != nil {
+		log.WithError(err).Warn("Failed to start executor metrics")
+		return func() {}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	go m.RunPrometheusServer(ctx, false)
+	return func() {
+		cancel()
+		provider, ok := otel.GetMeterProvider().(*metricsdk.MeterProvider)
+		if !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			log.WithError(err).Warn("Failed to export executor metrics")
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+func addPodSchedulingLatencyHistogram(_ context.Context, m *Metrics) error {
+	return m.CreateBuiltinInstrument(telemetry.InstrumentPodSchedulingLatency)
+}
+
+// PodSchedulingLatency records how long a stage of starting a pod took, e.g. "scheduling"
+func (m *Metrics) PodSchedulingLatency(ctx context.Context, stage string, latency time.Duration, namespace string) {
+	m.Record(ctx, telemetry.InstrumentPodSchedulingLatency.Name(), latency.Seconds(), telemetry.InstAttribs{
+		{Name: telemetry.AttribPodSchedulingStage, Value: stage},
+		{Name: telemetry.AttribPodNamespace, Value: namespace},
+	})
+}
@@ -43,6 +43,7 @@ func New(ctx context.Context, serviceName, prometheusName string, config *teleme
 		addPodPhaseCounter,
 		addPodMissingCounter,
 		addPodPendingCounter,
+		addPodSchedulingLatencyHistogram,
 		addWorkflowPhaseGauge,
 		addCronWfTriggerCounter,
 		addCronWfPolicyCounter,
@@ -55,6 +56,9 @@ func New(ctx context.Context, serviceName, prometheusName string, config *teleme
 		addK8sRequests,
 		addWorkflowConditionGauge,
 		addWorkQueueMetrics,
+		addArtifactMetrics,
+		addArtifactGCFailuresCounter,
+		addArtifactQuotaExceededCounter,
 	)
 	if err != nil {
 		return nil, err
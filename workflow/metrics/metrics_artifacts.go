@@ -0,0 +1,214 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+func addArtifactMetrics(_ context.Context, m *Metrics) error {
+	for _, inst := range []telemetry.BuiltinInstrument{
+		telemetry.InstrumentArtifactBytes,
+		telemetry.InstrumentArtifactErrors,
+		telemetry.InstrumentArtifactOperationDuration,
+	} {
+		if err := m.CreateBuiltinInstrument(inst); err != nil {
+			return err
+		}
+	}
+	// Register this metrics with the global, as artifact drivers are created without access to the metrics
+	artifactMetrics = m
+	return nil
+}
+
+// This is a messy global as artifact drivers are created throughout the code base
+var artifactMetrics *Metrics
+
+// artifactDriver records the transfers, durations, and errors of the operations of an artifact driver
+type artifactDriver struct {
+	common.ArtifactDriver
+}
+
+// NewArtifactDriver wraps the artifact driver to record its operations into the metrics, once they are created
+func NewArtifactDriver(d common.ArtifactDriver) common.ArtifactDriver {
+	return &artifactDriver{d}
+}
+
+func artifactAttribs(a *wfv1.Artifact, operation string) telemetry.InstAttribs {
+	driver, bucket := "", ""
+	switch {
+	case a.S3 != nil:
+		driver, bucket = "s3", a.S3.Bucket
+	case a.GCS != nil:
+		driver, bucket = "gcs", a.GCS.Bucket
+	case a.OSS != nil:
+		driver, bucket = "oss", a.OSS.Bucket
+	case a.Azure != nil:
+		driver, bucket = "azure", a.Azure.Container
+	case a.B2 != nil:
+		driver, bucket = "b2", a.B2.Bucket
+	case a.IBMCOS != nil:
+		driver, bucket = "ibmcos", a.IBMCOS.Bucket
+	case a.Artifactory != nil:
+		driver = "artifactory"
+	case a.HDFS != nil:
+		driver = "hdfs"
+	case a.SFTP != nil:
+		driver = "sftp"
+	case a.Filesystem != nil:
+		driver = "filesystem"
+	case a.HTTP != nil:
+		driver = "http"
+	case a.Git != nil:
+		driver = "git"
+	case a.OCI != nil:
+		driver = "oci"
+	case a.Raw != nil:
+		driver = "raw"
+	}
+	return telemetry.InstAttribs{
+		{Name: telemetry.AttribArtifactDriver, Value: driver},
+		{Name: telemetry.AttribArtifactBucket, Value: bucket},
+		{Name: telemetry.AttribArtifactOperation, Value: operation},
+	}
+}
+
+// recordArtifactOperation records the duration of an operation, and whether it failed
+func recordArtifactOperation(a *wfv1.Artifact, operation string, started time.Time, err error) {
+	m := artifactMetrics
+	if m == nil {
+		return
+	}
+	attribs := artifactAttribs(a, operation)
+	m.Record(m.Ctx, telemetry.InstrumentArtifactOperationDuration.Name(), time.Since(started).Seconds(), attribs)
+	if err != nil {
+		m.AddInt(m.Ctx, telemetry.InstrumentArtifactErrors.Name(), 1, attribs)
+	}
+}
+
+// recordArtifactBytes records the bytes transferred by an operation
+func recordArtifactBytes(a *wfv1.Artifact, operation string, n int64) {
+	m := artifactMetrics
+	if m == nil || n <= 0 {
+		return
+	}
+	m.AddInt(m.Ctx, telemetry.InstrumentArtifactBytes.Name(), n, artifactAttribs(a, operation))
+}
+
+func (d *artifactDriver) Load(a *wfv1.Artifact, path string) error {
+	t := time.Now()
+	err := d.ArtifactDriver.Load(a, path)
+	recordArtifactOperation(a, "load", t, err)
+	if err == nil && artifactMetrics != nil {
+		recordArtifactBytes(a, "load", file.Size(path))
+	}
+	return err
+}
+
+func (d *artifactDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	t := time.Now()
+	rc, err := d.ArtifactDriver.OpenStream(a)
+	recordArtifactOperation(a, "open_stream", t, err)
+	if err != nil {
+		return rc, err
+	}
+	return &countingReadCloser{ReadCloser: rc, artifact: a}, nil
+}
+
+func (d *artifactDriver) Save(path string, a *wfv1.Artifact) error {
+	t := time.Now()
+	err := d.ArtifactDriver.Save(path, a)
+	recordArtifactOperation(a, "save", t, err)
+	if err == nil && artifactMetrics != nil {
+		recordArtifactBytes(a, "save", file.Size(path))
+	}
+	return err
+}
+
+func (d *artifactDriver) Delete(a *wfv1.Artifact) error {
+	t := time.Now()
+	err := d.ArtifactDriver.Delete(a)
+	recordArtifactOperation(a, "delete", t, err)
+	return err
+}
+
+func (d *artifactDriver) ListObjects(a *wfv1.Artifact) ([]string, error) {
+	t := time.Now()
+	list, err := d.ArtifactDriver.ListObjects(a)
+	recordArtifactOperation(a, "list_objects", t, err)
+	return list, err
+}
+
+func (d *artifactDriver) IsDirectory(a *wfv1.Artifact) (bool, error) {
+	t := time.Now()
+	isDir, err := d.ArtifactDriver.IsDirectory(a)
+	recordArtifactOperation(a, "is_directory", t, err)
+	return isDir, err
+}
+
+func (d *artifactDriver) Exists(a *wfv1.Artifact) (bool, error) {
+	t := time.Now()
+	exists, err := common.Exists(d.ArtifactDriver, a)
+	recordArtifactOperation(a, "exists", t, err)
+	return exists, err
+}
+
+func (d *artifactDriver) ETag(a *wfv1.Artifact) (string, error) {
+	t := time.Now()
+	etag, err := common.ETag(d.ArtifactDriver, a)
+	recordArtifactOperation(a, "etag", t, err)
+	return etag, err
+}
+
+func (d *artifactDriver) DeletePrefix(a *wfv1.Artifact) error {
+	t := time.Now()
+	err := common.DeletePrefix(d.ArtifactDriver, a)
+	recordArtifactOperation(a, "delete_prefix", t, err)
+	return err
+}
+
+func (d *artifactDriver) SaveStream(r io.Reader, a *wfv1.Artifact) error {
+	t := time.Now()
+	cr := &countingReadCloser{ReadCloser: io.NopCloser(r), artifact: a}
+	err := common.SaveStream(d.ArtifactDriver, cr, a)
+	recordArtifactOperation(a, "save", t, err)
+	if err == nil && artifactMetrics != nil {
+		recordArtifactBytes(a, "save", cr.n)
+	}
+	return err
+}
+
+func (d *artifactDriver) SetProgress(progress func(n int64)) bool {
+	return common.SetProgress(d.ArtifactDriver, progress)
+}
+
+func (d *artifactDriver) OpenSeekableStream(a *wfv1.Artifact) (io.ReadSeekCloser, error) {
+	t := time.Now()
+	rs, err := common.OpenSeekableStream(d.ArtifactDriver, a)
+	recordArtifactOperation(a, "open_stream", t, err)
+	return rs, err
+}
+
+// countingReadCloser records the bytes read from a stream when it is closed
+type countingReadCloser struct {
+	io.ReadCloser
+	artifact *wfv1.Artifact
+	n        int64
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *countingReadCloser) Close() error {
+	recordArtifactBytes(r.artifact, "open_stream", r.n)
+	r.n = 0
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+// fakeArtifactDriver loads and streams "foo", and fails to delete
+type fakeArtifactDriver struct {
+	common.ArtifactDriver
+}
+
+func (fakeArtifactDriver) Load(_ *wfv1.Artifact, path string) error {
+	return os.WriteFile(path, []byte("foo"), 0o600)
+}
+
+func (fakeArtifactDriver) OpenStream(_ *wfv1.Artifact) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("foo")), nil
+}
+
+func (fakeArtifactDriver) Save(_ string, _ *wfv1.Artifact) error {
+	return nil
+}
+
+func (fakeArtifactDriver) Delete(_ *wfv1.Artifact) error {
+	return fmt.Errorf("failed to delete")
+}
+
+func TestArtifactDriverMetrics(t *testing.T) {
+	_, te, err := CreateDefaultTestMetrics()
+	require.NoError(t, err)
+	drv := NewArtifactDriver(fakeArtifactDriver{})
+	art := &wfv1.Artifact{
+		Name:             "my-art",
+		ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}, Key: "my-key"}},
+	}
+	attribs := func(operation string) *attribute.Set {
+		set := attribute.NewSet(
+			attribute.String(telemetry.AttribArtifactDriver, "s3"),
+			attribute.String(telemetry.AttribArtifactBucket, "my-bucket"),
+			attribute.String(telemetry.AttribArtifactOperation, operation),
+		)
+		return &set
+	}
+
+	t.Run("Load", func(t *testing.T) {
+		require.NoError(t, drv.Load(art, filepath.Join(t.TempDir(), "my-art")))
+		val, err := te.GetInt64CounterValue(telemetry.InstrumentArtifactBytes.Name(), attribs("load"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), val)
+		hist, err := te.GetFloat64HistogramData(telemetry.InstrumentArtifactOperationDuration.Name(), attribs("load"))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), hist.Count)
+	})
+	t.Run("OpenStream", func(t *testing.T) {
+		rc, err := drv.OpenStream(art)
+		require.NoError(t, err)
+		_, err = io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		val, err := te.GetInt64CounterValue(telemetry.InstrumentArtifactBytes.Name(), attribs("open_stream"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), val)
+	})
+	t.Run("Save", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "my-art")
+		require.NoError(t, os.WriteFile(path, []byte("foobar"), 0o600))
+		require.NoError(t, drv.Save(path, art))
+		val, err := te.GetInt64CounterValue(telemetry.InstrumentArtifactBytes.Name(), attribs("save"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), val)
+	})
+	t.Run("Error", func(t *testing.T) {
+		require.Error(t, drv.Delete(art))
+		val, err := te.GetInt64CounterValue(telemetry.InstrumentArtifactErrors.Name(), attribs("delete"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), val)
+	})
+}
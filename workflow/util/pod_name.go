@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"hash/fnv"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
@@ -62,11 +64,44 @@ func GeneratePodName(workflowName, nodeName, templateName, nodeID string, versio
 	}
 	prefix = ensurePodNamePrefixLength(prefix)
 
-	h := fnv.New32a()
-	_, _ = h.Write([]byte(nodeName))
+	return fmt.Sprintf("%s-%v", prefix, fnvHash(nodeName))
+
+}
 
-	return fmt.Sprintf("%s-%v", prefix, h.Sum32())
+var (
+	retryAttemptRegex         = regexp.MustCompile(`\((\d+)\)$`)
+	invalidPodNameRegex       = regexp.MustCompile(`[^a-z0-9-]`)
+	invalidPodNamePrefixRegex = regexp.MustCompile(`^[^a-z0-9]+`)
+	invalidPodNameSuffixRegex = regexp.MustCompile(`[^a-z0-9]+$`)
+)
+
+// GeneratePodNameFromTemplate returns the pod name that the template gives a node. Characters that cannot be in a pod
+// name are replaced with dashes, and a name that is too long is truncated and ends with a hash of the whole name, so
+// that it is still unique.
+func GeneratePodNameFromTemplate(podNameTemplate, workflowName, nodeName, templateName, nodeID string) string {
+	retryAttempt := "0"
+	if m := retryAttemptRegex.FindStringSubmatch(nodeName); m != nil {
+		retryAttempt = m[1]
+	}
+	name := strings.NewReplacer(
+		common.PodNameVarWorkflowName, workflowName,
+		common.PodNameVarTemplateName, templateName,
+		common.PodNameVarNodeID, nodeID,
+		common.PodNameVarNodeHash, fmt.Sprint(fnvHash(nodeName)),
+		common.PodNameVarRetryAttempt, retryAttempt,
+	).Replace(podNameTemplate)
+	name = invalidPodNameRegex.ReplaceAllString(strings.ToLower(name), "-")
+	name = invalidPodNamePrefixRegex.ReplaceAllString(name, "")
+	if len(name) > maxK8sResourceNameLength {
+		name = fmt.Sprintf("%s-%v", ensurePodNamePrefixLength(name), fnvHash(name))
+	}
+	return invalidPodNameSuffixRegex.ReplaceAllString(name, "")
+}
 
+func fnvHash(s string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum32()
 }
 
 func ensurePodNamePrefixLength(prefix string) string {
@@ -94,3 +129,18 @@ func GetWorkflowPodNameVersion(wf *v1alpha1.Workflow) PodNameVersion {
 		return DefaultPodNameVersion
 	}
 }
+
+// GetWorkflowPodNameTemplate gets the pod name template from the annotation of a given workflow, or "" if its pods are
+// named by the pod name version
+func GetWorkflowPodNameTemplate(wf *v1alpha1.Workflow) string {
+	return wf.GetAnnotations()[common.AnnotationKeyPodNameTemplate]
+}
+
+// GenerateWorkflowPodName returns the name of the pod of a node of a given workflow, using the pod name template of the
+// workflow if it has one, and its pod name version otherwise
+func GenerateWorkflowPodName(wf *v1alpha1.Workflow, nodeName, templateName, nodeID string) string {
+	if podNameTemplate := GetWorkflowPodNameTemplate(wf); podNameTemplate != "" && common.ValidatePodNameTemplate(podNameTemplate) == nil {
+		return GeneratePodNameFromTemplate(podNameTemplate, wf.Name, nodeName, templateName, nodeID)
+	}
+	return GeneratePodName(wf.Name, nodeName, templateName, nodeID, GetWorkflowPodNameVersion(wf))
+}
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestPodNameTemplate(t *testing.T) {
+	nodeName := "my-wf.train(1)"
+	hash := fnvHash(nodeName)
+
+	name := GeneratePodNameFromTemplate("{{workflow.name}}-{{template.name}}-{{retry.attempt}}-{{node.hash}}", "my-wf", nodeName, "train", "my-wf-1")
+	assert.Equal(t, fmt.Sprintf("my-wf-train-1-%v", hash), name)
+
+	name = GeneratePodNameFromTemplate("{{node.id}}", "my-wf", "my-wf.train(0:a)", "train", "my-wf-1")
+	assert.Equal(t, "my-wf-1", name)
+
+	// invalid characters are replaced, and the name must start and end with a letter or digit
+	name = GeneratePodNameFromTemplate("_{{template.name}}.{{node.hash}}_", "my-wf", nodeName, "My_Template", "my-wf-1")
+	assert.Equal(t, fmt.Sprintf("my-template-%v", hash), name)
+
+	// long names are truncated, and end with a hash so that they are still unique
+	long := strings.Repeat("a", maxK8sResourceNameLength)
+	name = GeneratePodNameFromTemplate("{{template.name}}-{{node.hash}}", "my-wf", nodeName, long, "my-wf-1")
+	assert.LessOrEqual(t, len(name), maxK8sResourceNameLength)
+	assert.NotEqual(t, name, GeneratePodNameFromTemplate("{{template.name}}-{{node.hash}}", "my-wf", "my-wf.train(2)", long, "my-wf-1"))
+}
+
+func TestGenerateWorkflowPodName(t *testing.T) {
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}}
+	assert.Equal(t, GeneratePodName("my-wf", "my-wf.train", "train", "my-wf-1", GetPodNameVersion()), GenerateWorkflowPodName(wf, "my-wf.train", "train", "my-wf-1"))
+
+	wf.Annotations = map[string]string{common.AnnotationKeyPodNameTemplate: "{{workflow.name}}-{{retry.attempt}}-{{node.hash}}"}
+	assert.Equal(t, fmt.Sprintf("my-wf-0-%v", fnvHash("my-wf.train")), GenerateWorkflowPodName(wf, "my-wf.train", "train", "my-wf-1"))
+}
@@ -228,6 +228,7 @@ func PopulateSubmitOpts(command *cobra.Command, submitOpts *wfv1.SubmitOpts, par
 	command.Flags().StringVar(&submitOpts.ServiceAccount, "serviceaccount", "", "run all pods in the workflow using specified serviceaccount")
 	command.Flags().StringVarP(parameterFile, "parameter-file", "f", "", "pass a file containing all input parameters")
 	command.Flags().StringVarP(&submitOpts.Labels, "labels", "l", "", "Comma separated labels to apply to the workflow. Will override previous values.")
+	command.Flags().StringVar(&submitOpts.TaskSelector, "task-selector", "", "execute only the DAG tasks with these labels, and the tasks they depend on, and skip the rest, e.g. --task-selector canary=true")
 
 	if includeDryRun {
 		command.Flags().BoolVar(&submitOpts.DryRun, "dry-run", false, "modify the workflow on the client-side without creating it")
@@ -256,6 +257,12 @@ func ApplySubmitOpts(wf *wfv1.Workflow, opts *wfv1.SubmitOpts) error {
 	if opts.Priority != nil {
 		wf.Spec.Priority = opts.Priority
 	}
+	if opts.TaskSelector != "" {
+		if _, err := labels.Parse(opts.TaskSelector); err != nil {
+			return fmt.Errorf("invalid task selector %q: %w", opts.TaskSelector, err)
+		}
+		wf.Spec.TaskSelector = opts.TaskSelector
+	}
 
 	wfLabels := wf.GetLabels()
 	if wfLabels == nil {
@@ -386,6 +393,103 @@ func SuspendWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, workf
 	return err
 }
 
+// SuspendWorkflowAfter suspends a workflow once the node, by ID, name or display name, has completed, by adding it to
+// the suspend-after annotation of the workflow. The node must have started, or be a step or task of a template of the
+// workflow, so that a mistyped name is not waited for forever. Retries conflict errors
+func SuspendWorkflowAfter(ctx context.Context, wfIf v1alpha1.WorkflowInterface, workflowName, nodeName string) error {
+	err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+		wf, err := wfIf.Get(ctx, workflowName, metav1.GetOptions{})
+		if err != nil {
+			return !errorsutil.IsTransientErr(err), err
+		}
+		if IsWorkflowCompleted(wf) {
+			return false, errSuspendedCompletedWorkflow
+		}
+		if !hasNodeOrStep(wf, nodeName) {
+			return true, errors.Errorf(errors.CodeNotFound, "workflow %s has no node, step or task named %q", workflowName, nodeName)
+		}
+		nodes, err := SuspendAfterNodes(wf)
+		if err != nil {
+			return true, err
+		}
+		if slices.Contains(nodes, nodeName) {
+			return true, nil
+		}
+		if err := SetSuspendAfterNodes(wf, append(nodes, nodeName)); err != nil {
+			return true, err
+		}
+		creator.LabelActor(ctx, wf, creator.ActionSuspend)
+		_, err = wfIf.Update(ctx, wf, metav1.UpdateOptions{})
+		if apierr.IsConflict(err) {
+			return false, nil
+		}
+		return !errorsutil.IsTransientErr(err), err
+	})
+	return err
+}
+
+// hasNodeOrStep returns whether the workflow has a node with the ID, name or display name, or a step or task with the
+// name, which is the display name of its node once it has started
+func hasNodeOrStep(wf *wfv1.Workflow, name string) bool {
+	if wf.Status.Nodes.Find(func(node wfv1.NodeStatus) bool {
+		return node.ID == name || node.Name == name || node.DisplayName == name
+	}) != nil {
+		return true
+	}
+	templates := wf.Spec.Templates
+	if wf.Status.StoredWorkflowSpec != nil {
+		templates = append(slices.Clip(templates), wf.Status.StoredWorkflowSpec.Templates...)
+	}
+	for _, tmpl := range templates {
+		for _, parallelSteps := range tmpl.Steps {
+			for _, step := range parallelSteps.Steps {
+				if step.Name == name {
+					return true
+				}
+			}
+		}
+		if tmpl.DAG != nil {
+			for _, task := range tmpl.DAG.Tasks {
+				if task.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// SuspendAfterNodes returns the nodes of the suspend-after annotation of the workflow, which is a JSON list so that
+// node names may contain any character
+func SuspendAfterNodes(wf *wfv1.Workflow) ([]string, error) {
+	value := wf.Annotations[common.AnnotationKeySuspendAfter]
+	if value == "" {
+		return nil, nil
+	}
+	var nodes []string
+	if err := json.Unmarshal([]byte(value), &nodes); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation, which must be a JSON list of node names: %w", common.AnnotationKeySuspendAfter, err)
+	}
+	return nodes, nil
+}
+
+// SetSuspendAfterNodes sets the suspend-after annotation of the workflow to the nodes, or removes it if there are none
+func SetSuspendAfterNodes(wf *wfv1.Workflow, nodes []string) error {
+	if len(nodes) == 0 {
+		delete(wf.Annotations, common.AnnotationKeySuspendAfter)
+		return nil
+	}
+	value, err := json.Marshal(nodes)
+	if err != nil {
+		return err
+	}
+	if wf.Annotations == nil {
+		wf.Annotations = map[string]string{}
+	}
+	wf.Annotations[common.AnnotationKeySuspendAfter] = string(value)
+	return nil
+}
+
 func OverrideOutputParametersWithDefault(outputs *wfv1.Outputs) error {
 	if outputs == nil {
 		return nil
@@ -399,6 +503,13 @@ func OverrideOutputParametersWithDefault(outputs *wfv1.Outputs) error {
 				return fmt.Errorf("raw output parameter '%s' has not been set and does not have a default value", param.Name)
 			}
 		}
+		if param.ValueFrom != nil && param.ValueFrom.ConfigMapKeyRef != nil && param.Value == nil {
+			if param.ValueFrom.Default != nil {
+				outputs.Parameters[i].Value = param.ValueFrom.Default
+			} else {
+				return fmt.Errorf("output parameter '%s' could not be read from ConfigMap '%s' and does not have a default value", param.Name, param.ValueFrom.ConfigMapKeyRef.Name)
+			}
+		}
 	}
 	return nil
 }
@@ -794,8 +905,7 @@ func isDescendantNodeSucceeded(wf *wfv1.Workflow, node wfv1.NodeStatus, nodeIDsT
 
 func deletePodNodeDuringRetryWorkflow(wf *wfv1.Workflow, node wfv1.NodeStatus, deletedPods map[string]bool, podsToDelete []string) (map[string]bool, []string) {
 	templateName := GetTemplateFromNode(node)
-	version := GetWorkflowPodNameVersion(wf)
-	podName := GeneratePodName(wf.Name, node.Name, templateName, node.ID, version)
+	podName := GenerateWorkflowPodName(wf, node.Name, templateName, node.ID)
 	if _, ok := deletedPods[podName]; !ok {
 		deletedPods[podName] = true
 		podsToDelete = append(podsToDelete, podName)
@@ -1305,6 +1415,70 @@ func FormulateRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, restartSucce
 	return newWf, podsToDelete, nil
 }
 
+// FormulatePatchedRetryWorkflow retries a workflow, re-running the single failed script node selected by the node
+// field selector with the patched script. The node is marked as patched in the workflow's status.
+func FormulatePatchedRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, nodeFieldSelector string, parameters []string, patch wfv1.NodePatch) (*wfv1.Workflow, []string, error) {
+	if patch.IsEmpty() {
+		return nil, nil, errors.Errorf(errors.CodeBadRequest, "A patched retry must patch the image or source of the script")
+	}
+	node, err := getNodeToPatch(wf, nodeFieldSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+	newWf, podsToDelete, err := FormulateRetryWorkflow(ctx, wf, true, nodeFieldSelector, parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+	if newWf.Status.NodePatches == nil {
+		newWf.Status.NodePatches = make(map[string]wfv1.NodePatch)
+	}
+	newWf.Status.NodePatches[node.ID] = patch
+	return newWf, podsToDelete, nil
+}
+
+// getNodeToPatch returns the node of the script invocation to patch. The invocation of a pod that was retried by a
+// retry strategy is its retry node.
+func getNodeToPatch(wf *wfv1.Workflow, nodeFieldSelector string) (*wfv1.NodeStatus, error) {
+	nodes := wf.Status.Nodes
+	if len(nodeFieldSelector) == 0 {
+		return nil, errors.Errorf(errors.CodeBadRequest, "A patched retry must select the node to patch with nodeFieldSelector")
+	}
+	selector, err := fields.ParseSelector(nodeFieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	var toPatch *wfv1.NodeStatus
+	selected := make(map[string]bool)
+	for _, node := range nodes {
+		if (node.Type != wfv1.NodeTypePod && node.Type != wfv1.NodeTypeRetry) || !SelectorMatchesNode(selector, node) {
+			continue
+		}
+		if node.NodeFlag != nil && node.NodeFlag.Retried && node.Type == wfv1.NodeTypePod {
+			if parent := nodes.Find(func(n wfv1.NodeStatus) bool { return n.Type == wfv1.NodeTypeRetry && n.HasChild(node.ID) }); parent != nil {
+				node = *parent
+			}
+		}
+		selected[node.ID] = true
+		toPatch = &node
+	}
+	if len(selected) != 1 {
+		return nil, errors.Errorf(errors.CodeBadRequest, "A patched retry must select exactly one pod node, but %d were selected", len(selected))
+	}
+	if !toPatch.FailedOrError() {
+		return nil, errors.Errorf(errors.CodeBadRequest, "Cannot patch node %s in phase %s", toPatch.Name, toPatch.Phase)
+	}
+	// only the scripts of script templates are patched when the node is re-run
+	scope, resourceName := toPatch.GetTemplateScope()
+	tmpl := wf.GetStoredTemplate(scope, resourceName, toPatch)
+	if tmpl == nil {
+		tmpl = wf.GetTemplateByName(toPatch.TemplateName)
+	}
+	if tmpl == nil || tmpl.GetType() != wfv1.TemplateTypeScript {
+		return nil, errors.Errorf(errors.CodeBadRequest, "Cannot patch node %s, as only the nodes of script templates can be patched", toPatch.Name)
+	}
+	return toPatch, nil
+}
+
 func resetNode(node wfv1.NodeStatus) wfv1.NodeStatus {
 	// The previously supplied parameters needed to be reset. Otherwise, `argo node reset` would not work as expected.
 	if node.Type == wfv1.NodeTypeSuspend {
@@ -1385,6 +1559,12 @@ func StopWorkflow(ctx context.Context, wfClient v1alpha1.WorkflowInterface, hydr
 	return patchShutdownStrategy(ctx, wfClient, name, wfv1.ShutdownStrategyStop)
 }
 
+// DrainWorkflow drains a workflow by setting its spec.shutdown to ShutdownStrategyDrain, so that no more nodes are
+// started but the running ones and the onExit handlers finish
+func DrainWorkflow(ctx context.Context, wfClient v1alpha1.WorkflowInterface, name string) error {
+	return patchShutdownStrategy(ctx, wfClient, name, wfv1.ShutdownStrategyDrain)
+}
+
 type AlreadyShutdownError struct {
 	workflowName string
 	namespace    string
@@ -1407,6 +1587,8 @@ func patchShutdownStrategy(ctx context.Context, wfClient v1alpha1.WorkflowInterf
 		action = creator.ActionTerminate
 	case wfv1.ShutdownStrategyStop:
 		action = creator.ActionStop
+	case wfv1.ShutdownStrategyDrain:
+		action = creator.ActionDrain
 	default:
 		action = creator.ActionNone
 	}
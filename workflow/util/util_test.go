@@ -333,6 +333,42 @@ func TestStopWorkflowByNodeName(t *testing.T) {
 	require.EqualError(t, err, "cannot shutdown a completed workflow: workflow: \"succeeded-wf\", namespace: \"\"")
 }
 
+func TestSuspendWorkflowAfter(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	ctx := context.Background()
+	_, err := wfIf.Create(ctx, wfv1.MustUnmarshalWorkflow(suspendedWf), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, SuspendWorkflowAfter(ctx, wfIf, "suspend", "approve"))
+	require.NoError(t, SuspendWorkflowAfter(ctx, wfIf, "suspend", "suspend-template-xjsg2"))
+	require.NoError(t, SuspendWorkflowAfter(ctx, wfIf, "suspend", "approve"))
+	err = SuspendWorkflowAfter(ctx, wfIf, "suspend", "train")
+	require.EqualError(t, err, `workflow suspend has no node, step or task named "train"`)
+	wf, err := wfIf.Get(ctx, "suspend", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `["approve", "suspend-template-xjsg2"]`, wf.Annotations[common.AnnotationKeySuspendAfter])
+	assert.Nil(t, wf.Spec.Suspend, "the workflow is not suspended until the node has completed")
+}
+
+func TestSuspendAfterNodes(t *testing.T) {
+	wf := &wfv1.Workflow{}
+	nodes, err := SuspendAfterNodes(wf)
+	require.NoError(t, err)
+	assert.Empty(t, nodes)
+
+	require.NoError(t, SetSuspendAfterNodes(wf, []string{"train(0:a,b)", "approve"}))
+	nodes, err = SuspendAfterNodes(wf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"train(0:a,b)", "approve"}, nodes, "node names may contain commas")
+
+	require.NoError(t, SetSuspendAfterNodes(wf, nil))
+	assert.NotContains(t, wf.Annotations, common.AnnotationKeySuspendAfter)
+
+	wf.Annotations[common.AnnotationKeySuspendAfter] = "train,approve"
+	_, err = SuspendAfterNodes(wf)
+	require.ErrorContains(t, err, "invalid workflows.argoproj.io/suspend-after annotation")
+}
+
 // Regression test for #6478
 func TestAddParamToGlobalScopeValueNil(t *testing.T) {
 	paramValue := wfv1.AnyString("test")
@@ -585,6 +621,12 @@ func TestApplySubmitOpts(t *testing.T) {
 		assert.Equal(t, "1", wf.GetLabels()["a"])
 		assert.Equal(t, "0", wf.GetLabels()["b"])
 	})
+	t.Run("TaskSelector", func(t *testing.T) {
+		wf := &wfv1.Workflow{}
+		require.NoError(t, ApplySubmitOpts(wf, &wfv1.SubmitOpts{TaskSelector: "canary=true"}))
+		assert.Equal(t, "canary=true", wf.Spec.TaskSelector)
+		require.Error(t, ApplySubmitOpts(wf, &wfv1.SubmitOpts{TaskSelector: "canary in true"}))
+	})
 	t.Run("InvalidParameters", func(t *testing.T) {
 		require.Error(t, ApplySubmitOpts(&wfv1.Workflow{}, &wfv1.SubmitOpts{Parameters: []string{"a"}}))
 	})
@@ -1319,6 +1361,66 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 	})
 }
 
+func TestFormulatePatchedRetryWorkflow(t *testing.T) {
+	ctx := context.Background()
+	newWorkflow := func() *wfv1.Workflow {
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "my-wf",
+				Labels: map[string]string{},
+			},
+			Spec: wfv1.WorkflowSpec{Templates: []wfv1.Template{
+				{Name: "a", Container: &v1.Container{Image: "my-image"}},
+				{Name: "b", Script: &wfv1.ScriptTemplate{Container: v1.Container{Image: "python:3.11"}, Source: "print('b')"}},
+			}},
+			Status: wfv1.WorkflowStatus{
+				Phase: wfv1.WorkflowFailed,
+				Nodes: map[string]wfv1.NodeStatus{
+					"my-wf": {ID: "my-wf", Name: "my-wf", Phase: wfv1.NodeFailed, Type: wfv1.NodeTypeDAG, Children: []string{"1", "2"}},
+					"1":     {ID: "1", Name: "my-wf.a", TemplateName: "a", Phase: wfv1.NodeSucceeded, Type: wfv1.NodeTypePod, BoundaryID: "my-wf"},
+					"2":     {ID: "2", Name: "my-wf.b", TemplateName: "b", Phase: wfv1.NodeFailed, Type: wfv1.NodeTypeRetry, BoundaryID: "my-wf", Children: []string{"3"}},
+					"3":     {ID: "3", Name: "my-wf.b(0)", TemplateName: "b", Phase: wfv1.NodeFailed, Type: wfv1.NodeTypePod, BoundaryID: "my-wf", NodeFlag: &wfv1.NodeFlag{Retried: true}},
+				},
+			},
+		}
+	}
+	patch := wfv1.NodePatch{Image: "python:3.12", Source: "print('patched')"}
+	t.Run("RetriedPod", func(t *testing.T) {
+		wf, podsToDelete, err := FormulatePatchedRetryWorkflow(ctx, newWorkflow(), "id=3", nil, patch)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]wfv1.NodePatch{"2": patch}, wf.Status.NodePatches)
+		assert.NotContains(t, wf.Status.Nodes, "3")
+		assert.Len(t, podsToDelete, 1)
+	})
+	t.Run("RetryNodeAndPod", func(t *testing.T) {
+		wf, _, err := FormulatePatchedRetryWorkflow(ctx, newWorkflow(), "templateName=b", nil, patch)
+		require.NoError(t, err)
+		assert.Contains(t, wf.Status.NodePatches, "2")
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, _, err := FormulatePatchedRetryWorkflow(ctx, newWorkflow(), "id=3", nil, wfv1.NodePatch{})
+		require.EqualError(t, err, "A patched retry must patch the image or source of the script")
+	})
+	t.Run("NoSelector", func(t *testing.T) {
+		_, _, err := FormulatePatchedRetryWorkflow(ctx, newWorkflow(), "", nil, patch)
+		require.EqualError(t, err, "A patched retry must select the node to patch with nodeFieldSelector")
+	})
+	t.Run("MultipleNodes", func(t *testing.T) {
+		_, _, err := FormulatePatchedRetryWorkflow(ctx, newWorkflow(), "phase!=Running", nil, patch)
+		require.EqualError(t, err, "A patched retry must select exactly one pod node, but 2 were selected")
+	})
+	t.Run("Succeeded", func(t *testing.T) {
+		_, _, err := FormulatePatchedRetryWorkflow(ctx, newWorkflow(), "id=1", nil, patch)
+		require.EqualError(t, err, "Cannot patch node my-wf.a in phase Succeeded")
+	})
+	t.Run("NotScript", func(t *testing.T) {
+		wf := newWorkflow()
+		wf.Spec.Templates[1] = wfv1.Template{Name: "b", Container: &v1.Container{Image: "my-image"}}
+		_, _, err := FormulatePatchedRetryWorkflow(ctx, wf, "id=3", nil, patch)
+		require.EqualError(t, err, "Cannot patch node my-wf.b, as only the nodes of script templates can be patched")
+	})
+}
+
 func TestFromUnstructuredObj(t *testing.T) {
 	un := &unstructured.Unstructured{}
 	wfv1.MustUnmarshal([]byte(`apiVersion: argoproj.io/v1alpha1
@@ -4,17 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"golang.org/x/exp/maps"
 
+	"github.com/expr-lang/expr"
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	apivalidation "k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 
@@ -23,8 +30,15 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/intstr"
 	"github.com/argoproj/argo-workflows/v3/util/sorting"
+	"github.com/argoproj/argo-workflows/v3/util/suggest"
 	"github.com/argoproj/argo-workflows/v3/util/template"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/b2"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/filesystem"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/hdfs"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/ibmcos"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/oci"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/s3"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/sftp"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
@@ -70,6 +84,9 @@ func newTemplateValidationCtx(wf *wfv1.Workflow, opts ValidateOpts) *templateVal
 	globalParams[common.GlobalVarWorkflowMainEntrypoint] = placeholderGenerator.NextPlaceholder()
 	globalParams[common.GlobalVarWorkflowServiceAccountName] = placeholderGenerator.NextPlaceholder()
 	globalParams[common.GlobalVarWorkflowUID] = placeholderGenerator.NextPlaceholder()
+	globalParams[common.GlobalVarWorkflowOwnerTeam] = placeholderGenerator.NextPlaceholder()
+	globalParams[common.GlobalVarWorkflowOwnerEmail] = placeholderGenerator.NextPlaceholder()
+	globalParams[common.GlobalVarWorkflowOwnerSlackChannel] = placeholderGenerator.NextPlaceholder()
 	return &templateValidationCtx{
 		ValidateOpts: opts,
 		globalParams: globalParams,
@@ -209,15 +226,15 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	annotationSources := [][]string{maps.Keys(wf.Annotations)}
 	labelSources := [][]string{maps.Keys(wf.Labels)}
 	if wf.Spec.WorkflowMetadata != nil {
-		annotationSources = append(annotationSources, maps.Keys(wf.Spec.WorkflowMetadata.Annotations))
+		annotationSources = append(annotationSources, maps.Keys(wf.Spec.WorkflowMetadata.Annotations), maps.Keys(wf.Spec.WorkflowMetadata.AnnotationsFrom))
 		labelSources = append(labelSources, maps.Keys(wf.Spec.WorkflowMetadata.Labels), maps.Keys(wf.Spec.WorkflowMetadata.LabelsFrom))
 	}
 	if wfDefaults != nil && wfDefaults.Spec.WorkflowMetadata != nil {
-		annotationSources = append(annotationSources, maps.Keys(wfDefaults.Spec.WorkflowMetadata.Annotations))
+		annotationSources = append(annotationSources, maps.Keys(wfDefaults.Spec.WorkflowMetadata.Annotations), maps.Keys(wfDefaults.Spec.WorkflowMetadata.AnnotationsFrom))
 		labelSources = append(labelSources, maps.Keys(wfDefaults.Spec.WorkflowMetadata.Labels), maps.Keys(wfDefaults.Spec.WorkflowMetadata.LabelsFrom))
 	}
 	if wf.Spec.WorkflowTemplateRef != nil && wfSpecHolder.GetWorkflowSpec().WorkflowMetadata != nil {
-		annotationSources = append(annotationSources, maps.Keys(wfSpecHolder.GetWorkflowSpec().WorkflowMetadata.Annotations))
+		annotationSources = append(annotationSources, maps.Keys(wfSpecHolder.GetWorkflowSpec().WorkflowMetadata.Annotations), maps.Keys(wfSpecHolder.GetWorkflowSpec().WorkflowMetadata.AnnotationsFrom))
 		labelSources = append(labelSources, maps.Keys(wfSpecHolder.GetWorkflowSpec().WorkflowMetadata.Labels), maps.Keys(wfSpecHolder.GetWorkflowSpec().WorkflowMetadata.LabelsFrom))
 	}
 	mergedAnnotations := getUniqueKeys(annotationSources...)
@@ -256,7 +273,7 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 		}
 		_, err = ctx.validateTemplateHolder(tmpl, tmplCtx, args, opts.WorkflowTemplateValidation)
 		if err != nil {
-			return err
+			return withFieldPath(err, "spec.entrypoint")
 		}
 	}
 
@@ -280,7 +297,7 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 		ctx.globalParams[common.GlobalVarWorkflowFailures] = placeholderGenerator.NextPlaceholder()
 		_, err = ctx.validateTemplateHolder(tmplHolder, tmplCtx, &wf.Spec.Arguments, opts.WorkflowTemplateValidation)
 		if err != nil {
-			return err
+			return withFieldPath(err, "spec.onExit")
 		}
 	}
 	err = validateHooks(wf.Spec.Hooks, "hooks")
@@ -294,6 +311,42 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	if _, err := wf.Spec.PodGC.GetLabelSelector(); err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "podGC.labelSelector invalid: %v", err)
 	}
+	if err := validateArtifactRetention("artifactGC", wf.Spec.GetArtifactGC().GetRetention()); err != nil {
+		return err
+	}
+	if err := validateWorkflowMetadata(wf.Spec.WorkflowMetadata); err != nil {
+		return err
+	}
+	if wf.Spec.PendingTimeout != nil && wf.Spec.PendingTimeout.Duration <= 0 {
+		return errors.Errorf(errors.CodeBadRequest, "spec.pendingTimeout must be greater than zero")
+	}
+	if err := validateResourceBudget(wf.Spec.ResourceBudget); err != nil {
+		return err
+	}
+	if err := validateSchedulingWindow(wf.Spec.SchedulingWindow); err != nil {
+		return err
+	}
+	if err := validateResultDelivery(wf.Spec.ResultDelivery); err != nil {
+		return err
+	}
+	switch wf.Spec.MergeStrategy {
+	case "", wfv1.SchedulingMergeStrategyReplace, wfv1.SchedulingMergeStrategyMerge:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "spec.mergeStrategy '%s' must be Replace or Merge", wf.Spec.MergeStrategy)
+	}
+	if wf.Spec.TaskSelector != "" {
+		if _, err := labels.Parse(wf.Spec.TaskSelector); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "spec.taskSelector '%s' is invalid: %v", wf.Spec.TaskSelector, err)
+		}
+	}
+	if err := common.ValidatePodNameTemplate(wf.Annotations[common.AnnotationKeyPodNameTemplate]); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "metadata.annotations.%s is invalid: %v", common.AnnotationKeyPodNameTemplate, err)
+	}
+	if owner := wf.Spec.Owner; owner != nil && owner.Email != "" {
+		if _, err := mail.ParseAddress(owner.Email); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "spec.owner.email '%s' is not a valid email address: %v", owner.Email, err)
+		}
+	}
 
 	// Check if all templates can be resolved.
 	// If the Workflow is using a WorkflowTemplateRef, then the templates of the referred WorkflowTemplate will be validated.
@@ -301,7 +354,7 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 		for _, template := range wfSpecHolder.GetWorkflowSpec().Templates {
 			_, err := ctx.validateTemplateHolder(&wfv1.WorkflowStep{TemplateRef: wf.Spec.WorkflowTemplateRef.ToTemplateRef(template.Name)}, tmplCtx, &FakeArguments{}, opts.WorkflowTemplateValidation)
 			if err != nil {
-				return errors.Errorf(errors.CodeBadRequest, "templates.%s %s", template.Name, err.Error())
+				return prefixFieldError(err, "spec.workflowTemplateRef", "templates.%s", template.Name)
 			}
 		}
 		return nil
@@ -310,7 +363,7 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	for _, template := range wf.Spec.Templates {
 		_, err := ctx.validateTemplateHolder(&wfv1.WorkflowStep{Template: template.Name}, tmplCtx, &FakeArguments{}, opts.WorkflowTemplateValidation)
 		if err != nil {
-			return errors.Errorf(errors.CodeBadRequest, "templates.%s %s", template.Name, err.Error())
+			return prefixFieldError(err, "", "templates.%s", template.Name)
 		}
 	}
 	return nil
@@ -512,6 +565,9 @@ func (ctx *templateValidationCtx) validateTemplate(tmpl *wfv1.Template, tmplCtx
 		if err != nil {
 			return err
 		}
+		if newTmpl.ArchiveLocation.OCI != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.oci is only valid for input artifacts", errPrefix)
+		}
 	}
 	if newTmpl.Metrics != nil {
 		for _, metric := range newTmpl.Metrics.Prometheus {
@@ -565,17 +621,22 @@ func (ctx *templateValidationCtx) validateTemplateHolder(tmplHolder wfv1.Templat
 		_, err := tmplCtx.GetTemplateByName(tmplName)
 		if err != nil {
 			if argoerr, ok := err.(errors.ArgoError); ok && argoerr.Code() == errors.CodeNotFound {
-				return nil, errors.Errorf(errors.CodeBadRequest, "template name '%s' undefined", tmplName)
+				suggestion := suggest.Closest(tmplName, templateNames(tmplCtx.GetCurrentTemplateBase()))
+				return nil, errors.NewFieldErrorf("", tmplName, suggestion, "template name '%s' undefined", tmplName)
 			}
 			return nil, err
 		}
 	}
 
-	tmplCtx, resolvedTmpl, _, err := tmplCtx.ResolveTemplate(tmplHolder)
+	resolvedCtx, resolvedTmpl, _, err := tmplCtx.ResolveTemplate(tmplHolder)
 	if err != nil {
 		if argoerr, ok := err.(errors.ArgoError); ok && argoerr.Code() == errors.CodeNotFound {
 			if tmplRef != nil {
-				return nil, errors.Errorf(errors.CodeBadRequest, "template reference %s.%s not found", tmplRef.Name, tmplRef.Template)
+				var suggestion string
+				if tmplHolder, err := tmplCtx.GetTemplateGetterFromRef(tmplRef); err == nil {
+					suggestion = suggest.Closest(tmplRef.Template, templateNames(tmplHolder))
+				}
+				return nil, errors.NewFieldErrorf("", tmplRef.Template, suggestion, "template reference %s.%s not found", tmplRef.Name, tmplRef.Template)
 			}
 			// this error should not occur.
 			return nil, errors.InternalWrapError(err)
@@ -591,26 +652,98 @@ func (ctx *templateValidationCtx) validateTemplateHolder(tmplHolder wfv1.Templat
 		default:
 			return nil, fmt.Errorf("%s is not a valid RetryPolicy", resolvedTmpl.RetryStrategy.RetryPolicy)
 		}
+		if err := validateRetryBackoff(resolvedTmpl.RetryStrategy.Backoff); err != nil {
+			return nil, err
+		}
+		// the deadline of an attempt is the deadline of its pod
+		if backoff := resolvedTmpl.RetryStrategy.Backoff; backoff != nil && backoff.MaxAttemptDuration != "" && !resolvedTmpl.IsPodType() {
+			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.retryStrategy.backoff.maxAttemptDuration is only supported by templates that run a pod, not by %s templates", resolvedTmpl.Name, resolvedTmpl.GetType())
+		}
+	}
+
+	return resolvedTmpl, ctx.validateTemplate(resolvedTmpl, resolvedCtx, args, workflowTemplateValidation)
+}
+
+// templateNames returns the names of the templates of the holder, to suggest one for a reference to a template that
+// is not found
+func templateNames(tmplHolder wfv1.TemplateHolder) []string {
+	var templates []wfv1.Template
+	switch v := tmplHolder.(type) {
+	case *wfv1.Workflow:
+		templates = v.Spec.Templates
+	case wfv1.WorkflowSpecHolder:
+		templates = v.GetWorkflowSpec().Templates
+	}
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// templatePath returns the path of the template in the Workflow being validated, e.g. `spec.templates[0]`, or the
+// empty string if it is not one of its templates, e.g. it is from a referenced WorkflowTemplate
+func (ctx *templateValidationCtx) templatePath(tmplCtx *templateresolution.Context, tmpl *wfv1.Template) string {
+	if ctx.wf == nil || tmplCtx.GetCurrentTemplateBase() != ctx.wf {
+		return ""
+	}
+	for i, t := range ctx.wf.Spec.Templates {
+		if t.Name == tmpl.Name {
+			return fmt.Sprintf("spec.templates[%d]", i)
+		}
 	}
+	return ""
+}
 
-	return resolvedTmpl, ctx.validateTemplate(resolvedTmpl, tmplCtx, args, workflowTemplateValidation)
+// templateReferencePath returns the path of the field of the holder that references its template
+func templateReferencePath(holderPath string, tmplHolder wfv1.TemplateReferenceHolder) string {
+	switch {
+	case holderPath == "":
+		return ""
+	case tmplHolder.GetTemplateRef() != nil:
+		return holderPath + ".templateRef.template"
+	default:
+		return holderPath + ".template"
+	}
+}
+
+// withFieldPath sets the path of a FieldError that does not have one yet, which is the error of the field at the path
+func withFieldPath(err error, path string) error {
+	fieldErr, ok := err.(errors.FieldError)
+	if !ok || fieldErr.Path != "" {
+		return err
+	}
+	fieldErr.Path = path
+	return fieldErr
+}
+
+// prefixFieldError prefixes the message of the error like the other errors of the field, keeping a FieldError a
+// FieldError, with the path if it does not have one yet
+func prefixFieldError(err error, path string, format string, args ...interface{}) error {
+	prefix := fmt.Sprintf(format, args...)
+	fieldErr, ok := withFieldPath(err, path).(errors.FieldError)
+	if !ok {
+		return errors.Errorf(errors.CodeBadRequest, "%s %s", prefix, err.Error())
+	}
+	fieldErr.Message = prefix + " " + fieldErr.Message
+	return fieldErr
 }
 
 // validateTemplateType validates that only one template type is defined
 func validateTemplateType(tmpl *wfv1.Template) error {
 	numTypes := 0
-	for _, tmplType := range []interface{}{tmpl.Container, tmpl.ContainerSet, tmpl.Steps, tmpl.Script, tmpl.Resource, tmpl.DAG, tmpl.Suspend, tmpl.Data, tmpl.HTTP, tmpl.Plugin} {
+	for _, tmplType := range []interface{}{tmpl.Container, tmpl.ContainerSet, tmpl.Steps, tmpl.Script, tmpl.Resource, tmpl.DAG, tmpl.Suspend, tmpl.Data, tmpl.HTTP, tmpl.Plugin, tmpl.JobTemplate, tmpl.Gang} {
 		if !reflect.ValueOf(tmplType).IsNil() {
 			numTypes++
 		}
 	}
 	switch numTypes {
 	case 0:
-		return errors.Errorf(errors.CodeBadRequest, "templates.%s template type unspecified. choose one of: container, containerSet, steps, script, resource, dag, suspend, template, template ref", tmpl.Name)
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s template type unspecified. choose one of: container, containerSet, steps, script, resource, jobTemplate, gang, dag, suspend, template, template ref", tmpl.Name)
 	case 1:
 		// Do nothing
 	default:
-		return errors.Errorf(errors.CodeBadRequest, "templates.%s multiple template types specified. choose one of: container, containerSet, steps, script, resource, dag, suspend, template, template ref", tmpl.Name)
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s multiple template types specified. choose one of: container, containerSet, steps, script, resource, jobTemplate, gang, dag, suspend, template, template ref", tmpl.Name)
 	}
 	return nil
 }
@@ -649,20 +782,50 @@ func validateInputs(tmpl *wfv1.Template) (map[string]interface{}, error) {
 		if art.From != "" {
 			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.from not valid in inputs", tmpl.Name, artRef)
 		}
+		if art.Container != "" {
+			return nil, errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.container not valid in inputs", tmpl.Name, artRef)
+		}
 		errPrefix := fmt.Sprintf("templates.%s.%s", tmpl.Name, artRef)
 		err = validateArtifactLocation(errPrefix, art.ArtifactLocation)
 		if err != nil {
 			return nil, err
 		}
+		err = validateArtifactEncryption(errPrefix, art.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		err = validateArtifactTransferLimits(errPrefix, &art)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return scope, nil
 }
 
 func validateArtifactLocation(errPrefix string, art wfv1.ArtifactLocation) error {
+	if err := validateArtifactKey(errPrefix, art); err != nil {
+		return err
+	}
 	if art.Git != nil {
 		if art.Git.Repo == "" {
 			return errors.Errorf(errors.CodeBadRequest, "%s.git.repo is required", errPrefix)
 		}
+		if art.Git.Filter != "" && !gitFilterRegex.MatchString(art.Git.Filter) {
+			return errors.Errorf(errors.CodeBadRequest, "%s.git.filter '%s' must be one of 'blob:none', 'blob:limit=<size>' or 'tree:<depth>'", errPrefix, art.Git.Filter)
+		}
+		for _, p := range art.Git.SparsePaths {
+			if !filepath.IsLocal(p) {
+				return errors.Errorf(errors.CodeBadRequest, "%s.git.sparsePaths '%s' must be a path relative to the root of the repository", errPrefix, p)
+			}
+		}
+	}
+	if art.HTTP != nil {
+		if art.HTTP.SHA256 != "" && !sha256Regex.MatchString(art.HTTP.SHA256) {
+			return errors.Errorf(errors.CodeBadRequest, "%s.http.sha256 '%s' must be a hex-encoded SHA256 checksum", errPrefix, art.HTTP.SHA256)
+		}
+		if err := validateHTTPArtifactRetry(fmt.Sprintf("%s.http.retry", errPrefix), art.HTTP.Retry); err != nil {
+			return err
+		}
 	}
 	if art.HDFS != nil {
 		err := hdfs.ValidateArtifact(fmt.Sprintf("%s.hdfs", errPrefix), art.HDFS)
@@ -670,10 +833,136 @@ func validateArtifactLocation(errPrefix string, art wfv1.ArtifactLocation) error
 			return err
 		}
 	}
+	if art.SFTP != nil {
+		err := sftp.ValidateArtifact(fmt.Sprintf("%s.sftp", errPrefix), art.SFTP)
+		if err != nil {
+			return err
+		}
+	}
+	if art.Filesystem != nil {
+		err := filesystem.ValidateArtifact(fmt.Sprintf("%s.filesystem", errPrefix), art.Filesystem)
+		if err != nil {
+			return err
+		}
+	}
+	if art.B2 != nil {
+		err := b2.ValidateArtifact(fmt.Sprintf("%s.b2", errPrefix), art.B2)
+		if err != nil {
+			return err
+		}
+	}
+	if art.IBMCOS != nil {
+		err := ibmcos.ValidateArtifact(fmt.Sprintf("%s.ibmcos", errPrefix), art.IBMCOS)
+		if err != nil {
+			return err
+		}
+	}
+	if art.OCI != nil {
+		err := oci.ValidateArtifact(fmt.Sprintf("%s.oci", errPrefix), art.OCI)
+		if err != nil {
+			return err
+		}
+	}
+	if art.GCS != nil && art.GCS.ServiceAccountKeySecret != nil && art.GCS.ExternalAccountSecret != nil {
+		return errors.Errorf(errors.CodeBadRequest, "%s.gcs may not have both serviceAccountKeySecret and externalAccountSecret", errPrefix)
+	}
+	if art.S3 != nil {
+		err := s3.ValidateSelect(fmt.Sprintf("%s.s3.select", errPrefix), art.S3.Select)
+		if err != nil {
+			return err
+		}
+	}
 	// TODO: validate other artifact locations
 	return nil
 }
 
+// validateArtifactKey checks that the expressions in the key of an artifact compile, e.g.
+// "{{=sprig.trunc(8, workflow.uid)}}/outputs", as they are otherwise only evaluated once the artifact is loaded or saved
+func validateArtifactKey(errPrefix string, art wfv1.ArtifactLocation) error {
+	key, err := art.GetKey()
+	if err != nil {
+		return nil
+	}
+	if err := template.ValidateExpressions(key); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "%s key: %s", errPrefix, err.Error())
+	}
+	return nil
+}
+
+// validateRetryBackoff validates the jitter and maxAttemptDuration of the backoff of a retryStrategy, unless they are
+// variables
+func validateRetryBackoff(backoff *wfv1.Backoff) error {
+	if backoff == nil {
+		return nil
+	}
+	if !intstr.IsValidIntOrArgoVariable(backoff.Jitter) {
+		return errors.Errorf(errors.CodeBadRequest, "retryStrategy.backoff.jitter '%s' must be an integer or a variable", backoff.Jitter.String())
+	}
+	if jitter, err := intstr.Int32(backoff.Jitter); err == nil && jitter != nil && (*jitter < 0 || *jitter > 100) {
+		return errors.Errorf(errors.CodeBadRequest, "retryStrategy.backoff.jitter must be a percentage between 0 and 100")
+	}
+	if backoff.MaxAttemptDuration != "" && !strings.HasPrefix(backoff.MaxAttemptDuration, "{{") {
+		if _, err := wfv1.ParseStringToDuration(backoff.MaxAttemptDuration); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "retryStrategy.backoff.maxAttemptDuration %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func validateHTTPArtifactRetry(errPrefix string, retry *wfv1.HTTPArtifactRetry) error {
+	if retry == nil {
+		return nil
+	}
+	if retry.Limit != nil && *retry.Limit < 0 {
+		return errors.Errorf(errors.CodeBadRequest, "%s.limit must not be negative", errPrefix)
+	}
+	if retry.Backoff == nil {
+		return nil
+	}
+	for field, value := range map[string]string{"duration": retry.Backoff.Duration, "cap": retry.Backoff.Cap} {
+		if value == "" {
+			continue
+		}
+		if _, err := wfv1.ParseStringToDuration(value); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.backoff.%s %s", errPrefix, field, err.Error())
+		}
+	}
+	if _, err := intstr.Int32(retry.Backoff.Factor); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "%s.backoff.factor %s", errPrefix, err.Error())
+	}
+	return nil
+}
+
+func validateArtifactEncryption(errPrefix string, encryption *wfv1.ArtifactEncryption) error {
+	if encryption != nil && (encryption.KeySecret == nil || encryption.KeySecret.Name == "" || encryption.KeySecret.Key == "") {
+		return errors.Errorf(errors.CodeBadRequest, "%s.encryption.keySecret name and key are required", errPrefix)
+	}
+	return nil
+}
+
+func validateArtifactTransferLimits(errPrefix string, art *wfv1.Artifact) error {
+	if art.Timeout != nil && art.Timeout.Duration <= 0 {
+		return errors.Errorf(errors.CodeBadRequest, "%s.timeout must be positive", errPrefix)
+	}
+	if art.MaxBandwidth != nil && art.MaxBandwidth.Sign() <= 0 {
+		return errors.Errorf(errors.CodeBadRequest, "%s.maxBandwidth must be positive", errPrefix)
+	}
+	return nil
+}
+
+func validateArtifactRetention(errPrefix string, retention *wfv1.ArtifactRetention) error {
+	if retention == nil {
+		return nil
+	}
+	if _, err := retention.GetMaxAge(); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "%s.retention.maxAge %s", errPrefix, err.Error())
+	}
+	if retention.MaxCount != nil && *retention.MaxCount < 0 {
+		return errors.Errorf(errors.CodeBadRequest, "%s.retention.maxCount must not be negative", errPrefix)
+	}
+	return nil
+}
+
 // resolveAllVariables is a helper to ensure all {{variables}} are resolvable from current scope
 func resolveAllVariables(scope map[string]interface{}, globalParams map[string]string, tmplStr string, workflowTemplateValidation bool) error {
 	_, allowAllItemRefs := scope[anyItemMagicValue] // 'item.*' is a magic placeholder value set by addItemsToScope
@@ -725,10 +1014,279 @@ func checkValidWorkflowVariablePrefix(tag string) bool {
 	return false
 }
 
+// validateResourceBudget validates the resource budget of a workflow
+func validateResourceBudget(budget *wfv1.ResourceBudget) error {
+	if budget == nil {
+		return nil
+	}
+	if len(budget.Limits) == 0 {
+		return errors.Errorf(errors.CodeBadRequest, "spec.resourceBudget.limits is required")
+	}
+	for name, limit := range budget.Limits {
+		if limit <= 0 {
+			return errors.Errorf(errors.CodeBadRequest, "spec.resourceBudget.limits.%s must be greater than zero", name)
+		}
+	}
+	switch budget.Action {
+	case "", wfv1.ResourceBudgetActionTerminate, wfv1.ResourceBudgetActionSuspend:
+		return nil
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "spec.resourceBudget.action '%s' must be Terminate or Suspend", budget.Action)
+	}
+}
+
+// validateSchedulingWindow validates the windows that the pods of a workflow may start in
+func validateSchedulingWindow(w *wfv1.SchedulingWindow) error {
+	if w == nil {
+		return nil
+	}
+	if len(w.Schedules) == 0 {
+		return errors.Errorf(errors.CodeBadRequest, "spec.schedulingWindow.schedules is required")
+	}
+	if w.Duration.Duration <= 0 {
+		return errors.Errorf(errors.CodeBadRequest, "spec.schedulingWindow.duration must be greater than zero")
+	}
+	if w.Timezone != "" {
+		if _, err := time.LoadLocation(w.Timezone); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "spec.schedulingWindow.timezone '%s' is invalid: %v", w.Timezone, err)
+		}
+	}
+	for _, schedule := range w.Schedules {
+		if _, err := cron.ParseStandard(schedule); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "spec.schedulingWindow.schedules '%s' is malformed: %v", schedule, err)
+		}
+	}
+	return nil
+}
+
+// validateResultDelivery validates how the result of a workflow is delivered
+func validateResultDelivery(delivery *wfv1.ResultDelivery) error {
+	if delivery == nil {
+		return nil
+	}
+	if delivery.HTTP == nil {
+		return errors.Errorf(errors.CodeBadRequest, "spec.resultDelivery.http is required")
+	}
+	if delivery.HTTP.URL == "" {
+		return errors.Errorf(errors.CodeBadRequest, "spec.resultDelivery.http.url is required")
+	}
+	if u, err := url.Parse(delivery.HTTP.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.Errorf(errors.CodeBadRequest, "spec.resultDelivery.http.url '%s' must be an http or https URL", delivery.HTTP.URL)
+	}
+	return nil
+}
+
+// validateWorkflowMetadata validates the keys of the labels and annotations, and the label values and expressions
+// that can be checked before the workflow's parameters are known
+func validateWorkflowMetadata(md *wfv1.WorkflowMetadata) error {
+	if md == nil {
+		return nil
+	}
+	for _, field := range []struct {
+		name string
+		keys []string
+	}{
+		{"labels", maps.Keys(md.Labels)},
+		{"labelsFrom", maps.Keys(md.LabelsFrom)},
+		{"annotations", maps.Keys(md.Annotations)},
+		{"annotationsFrom", maps.Keys(md.AnnotationsFrom)},
+	} {
+		for _, k := range field.keys {
+			if errs := apivalidation.IsQualifiedName(k); len(errs) > 0 {
+				return errors.Errorf(errors.CodeBadRequest, "workflowMetadata.%s key %q is invalid: %s", field.name, k, strings.Join(errs, ";"))
+			}
+		}
+	}
+	for k, v := range md.Labels {
+		// values with variables are validated once they are resolved
+		if strings.Contains(v, "{{") {
+			continue
+		}
+		if errs := apivalidation.IsValidLabelValue(v); len(errs) > 0 {
+			return errors.Errorf(errors.CodeBadRequest, "workflowMetadata.labels: invalid label value %q for label %q: %s", v, k, strings.Join(errs, ";"))
+		}
+	}
+	for _, field := range []struct {
+		name string
+		from map[string]wfv1.LabelValueFrom
+	}{
+		{"labelsFrom", md.LabelsFrom},
+		{"annotationsFrom", md.AnnotationsFrom},
+	} {
+		for k, f := range field.from {
+			if f.Expression == "" {
+				return errors.Errorf(errors.CodeBadRequest, "workflowMetadata.%s.%s.expression is required", field.name, k)
+			}
+			if strings.Contains(f.Expression, "{{") {
+				continue
+			}
+			if _, err := expr.Compile(f.Expression); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "workflowMetadata.%s.%s.expression is invalid: %s", field.name, k, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
 func validateNonLeaf(tmpl *wfv1.Template) error {
 	if tmpl.ActiveDeadlineSeconds != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.activeDeadlineSeconds is only valid for leaf templates", tmpl.Name)
 	}
+	if len(tmpl.EnvSets) > 0 {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.envSets is only valid for container, script, and container set templates", tmpl.Name)
+	}
+	if tmpl.Liveness != nil {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.liveness is only valid for container and script templates", tmpl.Name)
+	}
+	if tmpl.WaitForSidecars != "" {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.waitForSidecars is only valid for container, script, and container set templates", tmpl.Name)
+	}
+	if tmpl.PreemptionPolicy != "" || tmpl.Checkpoint != nil {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.preemptionPolicy and checkpoint are only valid for container and script templates", tmpl.Name)
+	}
+	if tmpl.PrePull {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.prePull is only valid for container, script, and container set templates", tmpl.Name)
+	}
+	return nil
+}
+
+func validateEnvSets(tmpl *wfv1.Template) error {
+	if len(tmpl.EnvSets) == 0 {
+		return nil
+	}
+	switch tmpl.GetType() {
+	case wfv1.TemplateTypeContainer, wfv1.TemplateTypeScript, wfv1.TemplateTypeContainerSet:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.envSets is only valid for container, script, and container set templates", tmpl.Name)
+	}
+	for i, set := range tmpl.EnvSets {
+		if set.ConfigMapRef != nil && set.SecretRef != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.envSets[%d] may not have both configMapRef and secretRef", tmpl.Name, i)
+		}
+		if set.ConfigMapRef == nil && set.SecretRef == nil && len(set.Values) == 0 {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.envSets[%d] must have a configMapRef, secretRef, or values", tmpl.Name, i)
+		}
+		if set.Prefix != "" && set.ConfigMapRef == nil && set.SecretRef == nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.envSets[%d].prefix is only valid with a configMapRef or secretRef", tmpl.Name, i)
+		}
+		for name := range set.Values {
+			if errs := apivalidation.IsEnvVarName(name); len(errs) > 0 {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.envSets[%d].values name '%s' is invalid: %s", tmpl.Name, i, name, strings.Join(errs, "; "))
+			}
+		}
+	}
+	return nil
+}
+
+func validateLiveness(tmpl *wfv1.Template) error {
+	if tmpl.Liveness == nil {
+		return nil
+	}
+	hasReadinessProbe := false
+	switch tmpl.GetType() {
+	case wfv1.TemplateTypeContainer:
+		hasReadinessProbe = tmpl.Container.ReadinessProbe != nil
+	case wfv1.TemplateTypeScript:
+		hasReadinessProbe = tmpl.Script.ReadinessProbe != nil
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.liveness is only valid for container and script templates", tmpl.Name)
+	}
+	if hasReadinessProbe {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.liveness may not be used with a readinessProbe, which it is run as", tmpl.Name)
+	}
+	handler := tmpl.Liveness.Probe.ProbeHandler
+	if handler.Exec == nil && handler.HTTPGet == nil && handler.TCPSocket == nil && handler.GRPC == nil {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.liveness.probe must have an exec, httpGet, tcpSocket, or grpc handler", tmpl.Name)
+	}
+	if tmpl.Liveness.FailureTimeout == "" {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.liveness.failureTimeout is required", tmpl.Name)
+	}
+	if !strings.Contains(tmpl.Liveness.FailureTimeout, "{{") {
+		if d, err := time.ParseDuration(tmpl.Liveness.FailureTimeout); err != nil || d <= 0 {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.liveness.failureTimeout '%s' must be a positive duration, e.g. '10m'", tmpl.Name, tmpl.Liveness.FailureTimeout)
+		}
+	}
+	return nil
+}
+
+func validateWaitForSidecars(tmpl *wfv1.Template) error {
+	if tmpl.WaitForSidecars == "" {
+		return nil
+	}
+	switch tmpl.GetType() {
+	case wfv1.TemplateTypeContainer, wfv1.TemplateTypeScript, wfv1.TemplateTypeContainerSet:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.waitForSidecars is only valid for container, script, and container set templates", tmpl.Name)
+	}
+	if tmpl.WaitForSidecars != wfv1.WaitForSidecarsReadinessProbe {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.waitForSidecars '%s' must be readinessProbe", tmpl.Name, tmpl.WaitForSidecars)
+	}
+	for _, sidecar := range tmpl.Sidecars {
+		// the main containers run the probes themselves, so they can only run those that connect to the sidecar
+		if probe := sidecar.ReadinessProbe; probe != nil && probe.HTTPGet == nil && probe.TCPSocket == nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.sidecars.%s.readinessProbe must have an httpGet or tcpSocket handler to be waited for", tmpl.Name, sidecar.Name)
+		}
+	}
+	return nil
+}
+
+func validateCheckpoint(tmpl *wfv1.Template) error {
+	if tmpl.PreemptionPolicy == "" && tmpl.Checkpoint == nil {
+		return nil
+	}
+	switch tmpl.GetType() {
+	case wfv1.TemplateTypeContainer, wfv1.TemplateTypeScript:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.preemptionPolicy and checkpoint are only valid for container and script templates", tmpl.Name)
+	}
+	if tmpl.PreemptionPolicy != wfv1.PreemptionPolicyCheckpoint {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.preemptionPolicy '%s' must be checkpoint", tmpl.Name, tmpl.PreemptionPolicy)
+	}
+	if tmpl.Checkpoint == nil || len(tmpl.Checkpoint.Artifacts) == 0 {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.checkpoint.artifacts is required by the checkpoint preemption policy", tmpl.Name)
+	}
+	if tmpl.Checkpoint.MaxRestarts != nil && *tmpl.Checkpoint.MaxRestarts < 0 {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.checkpoint.maxRestarts must not be negative", tmpl.Name)
+	}
+	// the checkpoint artifacts are loaded as input artifacts, so their names must not clash
+	names := map[string]bool{}
+	for _, art := range tmpl.Inputs.Artifacts {
+		names[art.Name] = true
+	}
+	for _, art := range tmpl.Checkpoint.Artifacts {
+		if art.Name == "" || art.Path == "" {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.checkpoint.artifacts must have a name and a path", tmpl.Name)
+		}
+		if names[art.Name] {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.checkpoint.artifacts.%s has the name of another input or checkpoint artifact", tmpl.Name, art.Name)
+		}
+		names[art.Name] = true
+	}
+	return nil
+}
+
+func validateOutputLimits(tmpl *wfv1.Template) error {
+	limits := tmpl.OutputLimits
+	if limits == nil {
+		return nil
+	}
+	for _, limit := range []struct {
+		name  string
+		value *int64
+	}{
+		{"maxResultBytes", limits.MaxResultBytes},
+		{"maxParameterBytes", limits.MaxParameterBytes},
+		{"maxLogBytes", limits.MaxLogBytes},
+	} {
+		if limit.value != nil && *limit.value <= 0 {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputLimits.%s must be positive", tmpl.Name, limit.name)
+		}
+	}
+	switch limits.Policy {
+	case "", wfv1.OutputTruncationKeepLast, wfv1.OutputTruncationKeepFirst, wfv1.OutputTruncationFail:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputLimits.policy '%s' must be KeepLast, KeepFirst, or Fail", tmpl.Name, limits.Policy)
+	}
 	return nil
 }
 
@@ -741,6 +1299,21 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s: %s", tmpl.Name, err.Error())
 	}
+	if err := validateEnvSets(tmpl); err != nil {
+		return err
+	}
+	if err := validateLiveness(tmpl); err != nil {
+		return err
+	}
+	if err := validateWaitForSidecars(tmpl); err != nil {
+		return err
+	}
+	if err := validateCheckpoint(tmpl); err != nil {
+		return err
+	}
+	if err := validateOutputLimits(tmpl); err != nil {
+		return err
+	}
 	if tmpl.Container != nil {
 		// Ensure there are no collisions with volume mountPaths and artifact load paths
 		mountPaths := make(map[string]string)
@@ -783,6 +1356,14 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 		}
 
 	}
+	if tmpl.Suspend != nil && tmpl.Suspend.Event != nil {
+		if tmpl.Suspend.Event.Selector == "" {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.suspend.event.selector may not be empty", tmpl.Name)
+		}
+		if _, err := expr.Compile(tmpl.Suspend.Event.Selector); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.suspend.event.selector is invalid: %s", tmpl.Name, err.Error())
+		}
+	}
 	if tmpl.Resource != nil {
 		if !placeholderGenerator.IsPlaceholder(tmpl.Resource.Action) {
 			switch tmpl.Resource.Action {
@@ -826,6 +1407,27 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 			}
 		}
 	}
+	if tmpl.JobTemplate != nil {
+		podSpec := tmpl.JobTemplate.Spec.Template.Spec
+		if len(podSpec.Containers) == 0 {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.jobTemplate.spec.template.spec.containers may not be empty", tmpl.Name)
+		}
+		if podSpec.RestartPolicy != apiv1.RestartPolicyNever && podSpec.RestartPolicy != apiv1.RestartPolicyOnFailure {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.jobTemplate.spec.template.spec.restartPolicy must be Never or OnFailure", tmpl.Name)
+		}
+	}
+	if tmpl.Gang != nil {
+		if tmpl.Gang.Pods < 1 {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.gang.size must be at least 1", tmpl.Name)
+		}
+		podSpec := tmpl.Gang.Template.Spec
+		if len(podSpec.Containers) == 0 {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.gang.template.spec.containers may not be empty", tmpl.Name)
+		}
+		if podSpec.RestartPolicy != "" && podSpec.RestartPolicy != apiv1.RestartPolicyNever {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.gang.template.spec.restartPolicy must be Never, as the pods of a gang fail together", tmpl.Name)
+		}
+	}
 	if tmpl.Script != nil {
 		if tmpl.Script.Image == "" {
 			switch baseTemplate := tmplCtx.GetCurrentTemplateBase().(type) {
@@ -929,6 +1531,9 @@ func validateArgumentsValues(prefix string, arguments wfv1.Arguments, allowEmpty
 		if art.From != "" && art.FromExpression != "" {
 			return errors.Errorf(errors.CodeBadRequest, "%s%s shouldn't have both `from` and `fromExpression` in Artifact", prefix, art.Name)
 		}
+		if err := validateArtifactKey(prefix+art.Name, art.ArtifactLocation); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -940,8 +1545,9 @@ func (ctx *templateValidationCtx) validateSteps(scope map[string]interface{}, tm
 	}
 	stepNames := make(map[string]bool)
 	resolvedTemplates := make(map[string]*wfv1.Template)
+	tmplPath := ctx.templatePath(tmplCtx, tmpl)
 	for i, stepGroup := range tmpl.Steps {
-		for _, step := range stepGroup.Steps {
+		for j, step := range stepGroup.Steps {
 			if step.Name == "" {
 				return errors.Errorf(errors.CodeBadRequest, "templates.%s.steps[%d].name is required", tmpl.Name, i)
 			}
@@ -963,9 +1569,13 @@ func (ctx *templateValidationCtx) validateSteps(scope map[string]interface{}, tm
 			if err != nil {
 				return err
 			}
+			stepPath := ""
+			if tmplPath != "" {
+				stepPath = fmt.Sprintf("%s.steps[%d][%d]", tmplPath, i, j)
+			}
 			resolvedTmpl, err := ctx.validateTemplateHolder(&step, tmplCtx, &FakeArguments{}, workflowTemplateValidation)
 			if err != nil {
-				return errors.Errorf(errors.CodeBadRequest, "templates.%s.steps[%d].%s %s", tmpl.Name, i, step.Name, err.Error())
+				return prefixFieldError(err, templateReferencePath(stepPath, &step), "templates.%s.steps[%d].%s", tmpl.Name, i, step.Name)
 			}
 
 			if step.HasExitHook() {
@@ -1006,7 +1616,7 @@ func (ctx *templateValidationCtx) validateSteps(scope map[string]interface{}, tm
 			// Validate the template again with actual arguments.
 			_, err = ctx.validateTemplateHolder(&step, tmplCtx, &step.Arguments, workflowTemplateValidation)
 			if err != nil {
-				return errors.Errorf(errors.CodeBadRequest, "templates.%s.steps[%d].%s %s", tmpl.Name, i, step.Name, err.Error())
+				return prefixFieldError(err, templateReferencePath(stepPath, &step), "templates.%s.steps[%d].%s", tmpl.Name, i, step.Name)
 			}
 		}
 	}
@@ -1137,6 +1747,14 @@ func validateOutputs(scope map[string]interface{}, globalParams map[string]strin
 
 	for _, art := range tmpl.Outputs.Artifacts {
 		artRef := fmt.Sprintf("outputs.artifacts.%s", art.Name)
+		if art.Container != "" {
+			if art.Path == "" {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.path is required when container is specified", tmpl.Name, artRef)
+			}
+			if !slices.ContainsFunc(tmpl.Sidecars, func(c wfv1.UserContainer) bool { return c.Name == art.Container }) {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.container '%s' must be the name of a sidecar", tmpl.Name, artRef, art.Container)
+			}
+		}
 		if tmpl.IsLeaf() {
 			err = art.CleanPath()
 			if err != nil {
@@ -1153,6 +1771,53 @@ func validateOutputs(scope map[string]interface{}, globalParams map[string]strin
 				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.globalName: %s", tmpl.Name, artRef, errs[0])
 			}
 		}
+		if art.StageInMemory {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.stageInMemory is only valid for input artifacts", tmpl.Name, artRef)
+		}
+		if art.S3 != nil && art.S3.Select != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.s3.select is only valid for input artifacts", tmpl.Name, artRef)
+		}
+		if art.OCI != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.oci is only valid for input artifacts", tmpl.Name, artRef)
+		}
+		err = validateArtifactKey(fmt.Sprintf("templates.%s.%s", tmpl.Name, artRef), art.ArtifactLocation)
+		if err != nil {
+			return err
+		}
+		if art.Git != nil {
+			if art.Git.Branch == "" {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.git.branch is required", tmpl.Name, artRef)
+			}
+			if art.Archive == nil || art.Archive.None == nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.archive must be none for git artifacts", tmpl.Name, artRef)
+			}
+			if len(art.ReplicateTo) > 0 {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.replicateTo is not supported for git artifacts", tmpl.Name, artRef)
+			}
+		}
+		if art.HTTP != nil && art.HTTP.Method != "" && !slices.Contains([]string{"PUT", "POST", "PATCH"}, art.HTTP.Method) {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.http.method '%s' must be one of PUT, POST or PATCH", tmpl.Name, artRef, art.HTTP.Method)
+		}
+		err = validateArtifactEncryption(fmt.Sprintf("templates.%s.%s", tmpl.Name, artRef), art.Encryption)
+		if err != nil {
+			return err
+		}
+		err = validateArtifactTransferLimits(fmt.Sprintf("templates.%s.%s", tmpl.Name, artRef), &art)
+		if err != nil {
+			return err
+		}
+		err = validateArtifactRetention(fmt.Sprintf("templates.%s.%s.artifactGC", tmpl.Name, artRef), art.GetArtifactGC().GetRetention())
+		if err != nil {
+			return err
+		}
+		for i, location := range art.ReplicateTo {
+			if _, err := location.Get(); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.replicateTo[%d] must specify an artifact location", tmpl.Name, artRef, i)
+			}
+			if location.Raw != nil || location.Git != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.replicateTo[%d] must be a location that artifacts can be saved to", tmpl.Name, artRef, i)
+			}
+		}
 	}
 	for _, param := range tmpl.Outputs.Parameters {
 		paramRef := fmt.Sprintf("templates.%s.outputs.parameters.%s", tmpl.Name, param.Name)
@@ -1162,12 +1827,15 @@ func validateOutputs(scope map[string]interface{}, globalParams map[string]strin
 		}
 		if param.ValueFrom != nil {
 			tmplType := tmpl.GetType()
+			if param.ValueFrom.ConfigMapKeyRef != nil && tmplType != wfv1.TemplateTypeSuspend {
+				return errors.Errorf(errors.CodeBadRequest, "%s.configMapKeyRef can only be specified for %s templates", paramRef, wfv1.TemplateTypeSuspend)
+			}
 			switch tmplType {
 			case wfv1.TemplateTypeContainer, wfv1.TemplateTypeContainerSet, wfv1.TemplateTypeScript:
 				if param.ValueFrom.Path == "" {
 					return errors.Errorf(errors.CodeBadRequest, "%s.path must be specified for %s templates", paramRef, tmplType)
 				}
-			case wfv1.TemplateTypeResource:
+			case wfv1.TemplateTypeResource, wfv1.TemplateTypeJob, wfv1.TemplateTypeGang:
 				if param.ValueFrom.JQFilter == "" && param.ValueFrom.JSONPath == "" {
 					return errors.Errorf(errors.CodeBadRequest, "%s .jqFilter or jsonPath must be specified for %s templates", paramRef, tmplType)
 				}
@@ -1210,12 +1878,15 @@ func validateOutputParameter(paramRef string, param *wfv1.Parameter) error {
 	if param.ValueFrom.Supplied != nil {
 		paramTypes++
 	}
+	if param.ValueFrom.ConfigMapKeyRef != nil {
+		paramTypes++
+	}
 	switch paramTypes {
 	case 0:
-		return errors.New(errors.CodeBadRequest, "valueFrom type unspecified. choose one of: path, jqFilter, jsonPath, parameter, raw, expression")
+		return errors.New(errors.CodeBadRequest, "valueFrom type unspecified. choose one of: path, jqFilter, jsonPath, parameter, raw, expression, configMapKeyRef")
 	case 1:
 	default:
-		return errors.New(errors.CodeBadRequest, "multiple valueFrom types specified. choose one of: path, jqFilter, jsonPath, parameter, raw")
+		return errors.New(errors.CodeBadRequest, "multiple valueFrom types specified. choose one of: path, jqFilter, jsonPath, parameter, raw, expression, configMapKeyRef")
 	}
 	return nil
 }
@@ -1340,9 +2011,16 @@ func (ctx *templateValidationCtx) validateDAG(scope map[string]interface{}, tmpl
 	}
 
 	resolvedTemplates := make(map[string]*wfv1.Template)
+	tmplPath := ctx.templatePath(tmplCtx, tmpl)
+	taskPath := func(i int) string {
+		if tmplPath == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s.dag.tasks[%d]", tmplPath, i)
+	}
 
 	// Verify dependencies for all tasks can be resolved as well as template names
-	for _, task := range tmpl.DAG.Tasks {
+	for i, task := range tmpl.DAG.Tasks {
 
 		if (usingDepends || len(task.Dependencies) > 0) && '0' <= task.Name[0] && task.Name[0] <= '9' {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s name cannot begin with a digit when using either 'depends' or 'dependencies'", tmpl.Name, task.Name)
@@ -1356,10 +2034,35 @@ func (ctx *templateValidationCtx) validateDAG(scope map[string]interface{}, tmpl
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s cannot use 'continueOn' when using 'depends'. Instead use 'dep-task.Failed'/'dep-task.Errored'", tmpl.Name)
 		}
 
-		resolvedTmpl, err := ctx.validateTemplateHolder(&task, tmplCtx, &FakeArguments{}, workflowTemplateValidation)
+		if task.MaxFanout != nil {
+			if !task.ShouldExpand() {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s.maxFanout can only be specified with withItems, withParam, or withSequence", tmpl.Name, task.Name)
+			}
+			if *task.MaxFanout < 1 {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s.maxFanout must be greater than 0", tmpl.Name, task.Name)
+			}
+		}
 
-		if err != nil {
-			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
+		for k, v := range task.Labels {
+			if errs := apivalidation.IsQualifiedName(k); len(errs) > 0 {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s.labels key %q is invalid: %s", tmpl.Name, task.Name, k, strings.Join(errs, ";"))
+			}
+			if errs := apivalidation.IsValidLabelValue(v); len(errs) > 0 {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s.labels.%s value %q is invalid: %s", tmpl.Name, task.Name, k, v, strings.Join(errs, ";"))
+			}
+		}
+
+		var resolvedTmpl *wfv1.Template
+		if task.DAGTemplateFrom != "" {
+			// the DAG of the task is validated once it is known
+			if err := validateDAGTemplateFrom(&task); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
+			}
+		} else {
+			resolvedTmpl, err = ctx.validateTemplateHolder(&task, tmplCtx, &FakeArguments{}, workflowTemplateValidation)
+			if err != nil {
+				return prefixFieldError(err, templateReferencePath(taskPath(i), &task), "templates.%s.tasks.%s", tmpl.Name, task.Name)
+			}
 		}
 
 		resolvedTemplates[task.Name] = resolvedTmpl
@@ -1398,7 +2101,7 @@ func (ctx *templateValidationCtx) validateDAG(scope map[string]interface{}, tmpl
 		return err
 	}
 
-	for _, task := range tmpl.DAG.Tasks {
+	for j, task := range tmpl.DAG.Tasks {
 		resolvedTmpl := resolvedTemplates[task.Name]
 		// add all tasks outputs to scope so that a nested DAGs can have outputs
 		prefix := fmt.Sprintf("tasks.%s", task.Name)
@@ -1448,16 +2151,92 @@ func (ctx *templateValidationCtx) validateDAG(scope map[string]interface{}, tmpl
 		if err != nil {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
 		}
+		if task.DAGTemplateFrom != "" {
+			continue
+		}
 		// Validate the template again with actual arguments.
 		_, err = ctx.validateTemplateHolder(&task, tmplCtx, &task.Arguments, workflowTemplateValidation)
 		if err != nil {
-			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
+			return prefixFieldError(err, templateReferencePath(taskPath(j), &task), "templates.%s.tasks.%s", tmpl.Name, task.Name)
 		}
 	}
 
 	return nil
 }
 
+func validateDAGTemplateFrom(task *wfv1.DAGTask) error {
+	if task.Template != "" || task.TemplateRef != nil || task.Inline != nil {
+		return fmt.Errorf("dagTemplateFrom cannot be specified with template, templateRef, or inline")
+	}
+	if task.ShouldExpand() {
+		return fmt.Errorf("dagTemplateFrom cannot be specified with withItems, withParam, or withSequence")
+	}
+	if len(task.Arguments.Parameters) > 0 || len(task.Arguments.Artifacts) > 0 {
+		return fmt.Errorf("dagTemplateFrom cannot be specified with arguments, as the tasks of its DAG have their own")
+	}
+	return nil
+}
+
+// ValidateDAGTasks validates the tasks of a DAG template that is only known at runtime, i.e. the DAG of a task with
+// dagTemplateFrom: their names, that their dependencies exist and have no cycles, and that each runs a template
+func ValidateDAGTasks(tmpl *wfv1.Template) error {
+	if tmpl.DAG == nil || len(tmpl.DAG.Tasks) == 0 {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s must have at least one task", tmpl.Name)
+	}
+	if err := validateWorkflowFieldNames(tmpl.DAG.Tasks); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks%s", tmpl.Name, err.Error())
+	}
+	nameToTask := make(map[string]wfv1.DAGTask)
+	for _, task := range tmpl.DAG.Tasks {
+		nameToTask[task.Name] = task
+	}
+	dagValidationCtx := &dagValidationContext{
+		tasks:        nameToTask,
+		dependencies: make(map[string]map[string]common.DependencyType),
+	}
+	for _, task := range tmpl.DAG.Tasks {
+		if task.Template == "" && task.TemplateRef == nil && task.Inline == nil && task.DAGTemplateFrom == "" {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s must specify a template, templateRef, inline, or dagTemplateFrom", tmpl.Name, task.Name)
+		}
+		if err := common.ValidateTaskResults(&task); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
+		}
+		for depName := range dagValidationCtx.GetTaskDependenciesWithDependencyTypes(task.Name) {
+			if _, ok := nameToTask[depName]; !ok {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s dependency '%s' not defined", tmpl.Name, task.Name, depName)
+			}
+		}
+	}
+	if err := verifyNoCycles(tmpl, dagValidationCtx); err != nil {
+		return err
+	}
+	return validateDAGTargets(tmpl, nameToTask)
+}
+
+// ValidateDAGTemplateFrom fully validates the DAG template of a task with dagTemplateFrom in the context of the
+// template of the task, with the global parameters of the running workflow. As the DAG comes from the output of a
+// task, its tasks may only run the templates of that context by name: inline templates, templateRef, and nested
+// dagTemplateFrom would let a task run templates that the workflow was never validated with, bypassing the
+// templateReferencing restrictions of the controller.
+func ValidateDAGTemplateFrom(wf *wfv1.Workflow, tmplCtx *templateresolution.Context, globalParams map[string]string, tmpl *wfv1.Template) error {
+	if err := ValidateDAGTasks(tmpl); err != nil {
+		return err
+	}
+	for _, task := range tmpl.DAG.Tasks {
+		if task.Inline != nil || task.TemplateRef != nil || task.DAGTemplateFrom != "" {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s must run a template of the workflow by name: inline, templateRef, and dagTemplateFrom are not allowed in a dagTemplateFrom", tmpl.Name, task.Name)
+		}
+		if _, err := tmplCtx.GetTemplateByName(task.Template); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s template '%s' not found", tmpl.Name, task.Name, task.Template)
+		}
+	}
+	ctx := newTemplateValidationCtx(wf, ValidateOpts{})
+	for name, value := range globalParams {
+		ctx.globalParams[name] = value
+	}
+	return ctx.validateTemplate(tmpl, tmplCtx, &wfv1.Arguments{}, false)
+}
+
 func validateDAGTaskArgumentDependency(arguments wfv1.Arguments, ancestry []string) error {
 	ancestryMap := make(map[string]struct{}, len(ancestry))
 	for _, a := range ancestry {
@@ -1574,6 +2353,10 @@ var (
 	paramRegex               = regexp.MustCompile(`{{[-a-zA-Z0-9]+(\.[-a-zA-Z0-9_]+)*}}`)
 	paramOrArtifactNameRegex = regexp.MustCompile(`^[-a-zA-Z0-9_]+[-a-zA-Z0-9_]*$`)
 	workflowFieldNameRegex   = regexp.MustCompile("^" + workflowFieldNameFmt + "$")
+	// gitFilterRegex matches the object filters of a git partial clone
+	gitFilterRegex = regexp.MustCompile(`^(blob:none|blob:limit=[0-9]+[kmg]?|tree:[0-9]+)$`)
+	// sha256Regex matches a hex-encoded SHA256 checksum
+	sha256Regex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
 )
 
 func isParameter(p string) bool {
@@ -1,9 +1,12 @@
 package validate
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 var dagCycle = `
@@ -1120,3 +1123,87 @@ func TestFailDAGArgParamValueFromPathInTask(t *testing.T) {
 	err := validate(failDagArgParamValueFromPathInTask)
 	require.ErrorContains(t, err, "valueFrom only allows: default, configMapKeyRef and supplied")
 }
+
+var dagMaxFanout = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+spec:
+  entrypoint: root
+  templates:
+    - name: template
+      container:
+        image: alpine
+    - name: root
+      dag:
+        tasks:
+          - name: task
+            template: template
+            maxFanout: %s
+            %s
+`
+
+func TestDAGMaxFanout(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(dagMaxFanout, "2", "withSequence: {count: '5'}"))
+		require.NoError(t, err)
+	})
+	t.Run("NotExpanded", func(t *testing.T) {
+		err := validate(fmt.Sprintf(dagMaxFanout, "2", ""))
+		require.ErrorContains(t, err, "templates.root.tasks.task.maxFanout can only be specified with withItems, withParam, or withSequence")
+	})
+	t.Run("Zero", func(t *testing.T) {
+		err := validate(fmt.Sprintf(dagMaxFanout, "0", "withItems: [a, b]"))
+		require.ErrorContains(t, err, "templates.root.tasks.task.maxFanout must be greater than 0")
+	})
+}
+
+var dagTemplateFrom = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+spec:
+  entrypoint: root
+  templates:
+    - name: template
+      container:
+        image: alpine
+    - name: root
+      dag:
+        tasks:
+          - name: plan
+            template: template
+          - name: run
+            depends: plan
+            dagTemplateFrom: "%s"
+            %s
+`
+
+func TestDAGTemplateFrom(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(dagTemplateFrom, "{{tasks.plan.outputs.result}}", ""))
+		require.NoError(t, err)
+	})
+	t.Run("UnresolvedReference", func(t *testing.T) {
+		err := validate(fmt.Sprintf(dagTemplateFrom, "{{tasks.missing.outputs.result}}", ""))
+		require.ErrorContains(t, err, "failed to resolve {{tasks.missing.outputs.result}}")
+	})
+	t.Run("WithTemplate", func(t *testing.T) {
+		err := validate(fmt.Sprintf(dagTemplateFrom, "{{tasks.plan.outputs.result}}", "template: template"))
+		require.ErrorContains(t, err, "templates.root.tasks.run dagTemplateFrom cannot be specified with template, templateRef, or inline")
+	})
+	t.Run("WithItems", func(t *testing.T) {
+		err := validate(fmt.Sprintf(dagTemplateFrom, "{{tasks.plan.outputs.result}}", "withItems: [a, b]"))
+		require.ErrorContains(t, err, "templates.root.tasks.run dagTemplateFrom cannot be specified with withItems, withParam, or withSequence")
+	})
+}
+
+func TestValidateDAGTasks(t *testing.T) {
+	tmpl := func(tasks ...wfv1.DAGTask) *wfv1.Template {
+		return &wfv1.Template{Name: "dynamic", DAG: &wfv1.DAGTemplate{Tasks: tasks}}
+	}
+	require.NoError(t, ValidateDAGTasks(tmpl(wfv1.DAGTask{Name: "a", Template: "echo"}, wfv1.DAGTask{Name: "b", Template: "echo", Depends: "a.Succeeded"})))
+	require.ErrorContains(t, ValidateDAGTasks(tmpl()), "templates.dynamic must have at least one task")
+	require.ErrorContains(t, ValidateDAGTasks(tmpl(wfv1.DAGTask{Name: "a"})), "templates.dynamic.tasks.a must specify a template")
+	require.ErrorContains(t, ValidateDAGTasks(tmpl(wfv1.DAGTask{Name: "a", Template: "echo"}, wfv1.DAGTask{Name: "a", Template: "echo"})), "is not unique")
+	require.ErrorContains(t, ValidateDAGTasks(tmpl(wfv1.DAGTask{Name: "a", Template: "echo", Dependencies: []string{"b"}})), "dependency 'b' not defined")
+	require.ErrorContains(t, ValidateDAGTasks(tmpl(wfv1.DAGTask{Name: "a", Template: "echo", Depends: "b"}, wfv1.DAGTask{Name: "b", Template: "echo", Depends: "a"})), "dependency cycle detected")
+}
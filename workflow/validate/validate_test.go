@@ -11,6 +11,7 @@ import (
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
@@ -961,6 +962,25 @@ spec:
           path: /abc
 `
 
+var outputValueFromConfigMapKeyRef = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: output-param-
+spec:
+  entrypoint: approve
+  templates:
+  - name: approve
+    suspend: {}
+    outputs:
+      parameters:
+      - name: outparam
+        valueFrom:
+          configMapKeyRef:
+            name: my-config
+            key: my-key
+`
+
 func TestInvalidOutputParam(t *testing.T) {
 	err := validate(invalidOutputParamNames)
 	require.ErrorContains(t, err, invalidErr)
@@ -976,6 +996,15 @@ func TestInvalidOutputParam(t *testing.T) {
 
 	err = validate(invalidOutputIncompatibleValueFromParam)
 	require.ErrorContains(t, err, ".parameter or expression must be specified for Steps templates")
+
+	err = validate(outputValueFromConfigMapKeyRef)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(outputValueFromConfigMapKeyRef, "          configMapKeyRef:", "          supplied: {}\n          configMapKeyRef:", 1))
+	require.ErrorContains(t, err, "multiple valueFrom")
+
+	err = validate(strings.Replace(outputValueFromConfigMapKeyRef, "    suspend: {}", "    container:\n      image: docker/whalesay:latest", 1))
+	require.ErrorContains(t, err, ".configMapKeyRef can only be specified for Suspend templates")
 }
 
 var multipleTemplateTypes = `
@@ -1005,6 +1034,66 @@ func TestMultipleTemplateTypes(t *testing.T) {
 	require.ErrorContains(t, err, "multiple template types specified")
 }
 
+var jobTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: job-template-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    jobTemplate:
+      spec:
+        completions: 3
+        completionMode: Indexed
+        template:
+          spec:
+            restartPolicy: Never
+            containers:
+            - name: main
+              image: argoproj/argosay:v2
+    outputs:
+      parameters:
+      - name: succeeded
+        valueFrom:
+          jsonPath: '{.status.succeeded}'
+`
+
+func TestJobTemplate(t *testing.T) {
+	require.NoError(t, validate(jobTemplate))
+	err := validate(strings.Replace(jobTemplate, "restartPolicy: Never", "restartPolicy: Always", 1))
+	require.ErrorContains(t, err, "templates.main.jobTemplate.spec.template.spec.restartPolicy must be Never or OnFailure")
+	err = validate(strings.Replace(jobTemplate, "jsonPath: '{.status.succeeded}'", "path: /tmp/succeeded", 1))
+	require.ErrorContains(t, err, ".jqFilter or jsonPath must be specified for Job templates")
+}
+
+var gangTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: gang-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    gang:
+      size: 4
+      template:
+        spec:
+          containers:
+          - name: worker
+            image: argoproj/argosay:v2
+`
+
+func TestGangTemplate(t *testing.T) {
+	require.NoError(t, validate(gangTemplate))
+	err := validate(strings.Replace(gangTemplate, "size: 4", "size: 0", 1))
+	require.ErrorContains(t, err, "templates.main.gang.size must be at least 1")
+	err = validate(strings.Replace(gangTemplate, "        spec:\n", "        spec:\n          restartPolicy: OnFailure\n", 1))
+	require.ErrorContains(t, err, "templates.main.gang.template.spec.restartPolicy must be Never")
+}
+
 var exitHandlerWorkflowStatusOnExit = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -1609,6 +1698,33 @@ func TestUndefinedTemplateRef(t *testing.T) {
 	require.ErrorContains(t, err, "not found")
 }
 
+var misspelledStepTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: misspelled-step-template-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+      - - name: hello
+          template: whalsay
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+`
+
+func TestMisspelledTemplateFieldError(t *testing.T) {
+	err := validate(misspelledStepTemplate)
+	require.EqualError(t, err, "templates.main.steps[0].hello template name 'whalsay' undefined, did you mean 'whalesay'?")
+	var fieldErr errors.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "spec.templates[0].steps[0][0].template", fieldErr.Path)
+	assert.Equal(t, "whalsay", fieldErr.Value)
+	assert.Equal(t, "whalesay", fieldErr.Suggestion)
+}
+
 var validResourceWorkflow = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -3354,3 +3470,894 @@ func TestShouldCheckValidationToSpacedParameters(t *testing.T) {
 	// Do not allow leading or trailing spaces in parameters
 	require.ErrorContains(t, err, "failed to resolve {{  workflow.thisdoesnotexist  }}")
 }
+
+var invalidSuspendEventSelector = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: suspend-event
+spec:
+  entrypoint: approve
+  templates:
+    - name: approve
+      suspend:
+        event:
+          selector: payload.approved ==
+`
+
+func TestInvalidSuspendEventSelector(t *testing.T) {
+	err := validate(invalidSuspendEventSelector)
+	require.ErrorContains(t, err, "templates.approve.suspend.event.selector is invalid")
+
+	err = validate(strings.Replace(invalidSuspendEventSelector, "payload.approved ==", `""`, 1))
+	require.ErrorContains(t, err, "templates.approve.suspend.event.selector may not be empty")
+
+	err = validate(strings.Replace(invalidSuspendEventSelector, "payload.approved ==", "payload.approved == true", 1))
+	require.NoError(t, err)
+}
+
+var invalidReplicateTo = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: replicate-to
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+      outputs:
+        artifacts:
+          - name: out
+            path: /tmp/out
+            replicateTo:
+              - raw:
+                  data: foo
+`
+
+func TestInvalidReplicateTo(t *testing.T) {
+	err := validate(invalidReplicateTo)
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.out.replicateTo[0] must be a location that artifacts can be saved to")
+
+	err = validate(strings.Replace(invalidReplicateTo, "raw:\n                  data: foo", "{}", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.out.replicateTo[0] must specify an artifact location")
+
+	err = validate(strings.Replace(invalidReplicateTo, "raw:\n                  data: foo", "azure:\n                  container: my-container\n                  endpoint: https://myaccount.blob.core.windows.net", 1))
+	require.NoError(t, err)
+}
+
+var artifactTransferLimits = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-transfer-limits
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+      inputs:
+        artifacts:
+          - name: in
+            path: /tmp/in
+            timeout: 10m
+            maxBandwidth: 50Mi
+            http:
+              url: https://example.com/in
+      outputs:
+        artifacts:
+          - name: out
+            path: /tmp/out
+            timeout: 10m
+            maxBandwidth: 50Mi
+`
+
+func TestArtifactTransferLimits(t *testing.T) {
+	err := validate(artifactTransferLimits)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(artifactTransferLimits, "timeout: 10m", "timeout: 0s", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.in.timeout must be positive")
+
+	err = validate(strings.TrimSuffix(artifactTransferLimits, "50Mi\n") + "\"0\"\n")
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.out.maxBandwidth must be positive")
+
+	err = validate(strings.Replace(artifactTransferLimits, "maxBandwidth: 50Mi\n            http", "maxBandwidth: \"-1\"\n            http", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.in.maxBandwidth must be positive")
+}
+
+var sidecarArtifact = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: sidecar-artifact
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+      sidecars:
+        - name: log-shipper
+          image: argoproj/argosay:v2
+      outputs:
+        artifacts:
+          - name: logs
+            path: /var/log/app
+            container: log-shipper
+`
+
+func TestSidecarArtifact(t *testing.T) {
+	err := validate(sidecarArtifact)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(sidecarArtifact, "container: log-shipper", "container: main", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.logs.container 'main' must be the name of a sidecar")
+
+	err = validate(strings.Replace(sidecarArtifact, "path: /var/log/app\n            ", "", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.logs.path is required when container is specified")
+
+	err = validate(strings.Replace(sidecarArtifact, "outputs:", "inputs:", 1) + "            http:\n              url: https://example.com/logs\n")
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.logs.container not valid in inputs")
+}
+
+var invalidArtifactRetention = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-retention
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+      outputs:
+        artifacts:
+          - name: out
+            path: /tmp/out
+            artifactGC:
+              retention:
+                maxAge: 30days
+`
+
+func TestInvalidArtifactRetention(t *testing.T) {
+	err := validate(invalidArtifactRetention)
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.out.artifactGC.retention.maxAge")
+
+	err = validate(strings.Replace(invalidArtifactRetention, "maxAge: 30days", "maxCount: -1", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.out.artifactGC.retention.maxCount must not be negative")
+
+	err = validate(strings.Replace(invalidArtifactRetention, "maxAge: 30days", "maxAge: 30d\n                maxCount: 10", 1))
+	require.NoError(t, err)
+}
+
+var envSets = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: env-sets-
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: region
+        value: eu-west-1
+  templates:
+    - name: main
+      inputs:
+        parameters:
+          - name: region
+      envSets:
+        - configMapRef:
+            name: my-config
+          prefix: CONFIG_
+        - values:
+            REGION: "{{=sprig.upper(inputs.parameters.region)}}"
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestEnvSets(t *testing.T) {
+	err := validate(envSets)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(envSets, "REGION:", "1REGION:", 1))
+	require.ErrorContains(t, err, "templates.main.envSets[1].values name '1REGION' is invalid")
+
+	err = validate(strings.Replace(envSets, "          prefix: CONFIG_\n", "          prefix: CONFIG_\n          secretRef:\n            name: my-secret\n", 1))
+	require.ErrorContains(t, err, "templates.main.envSets[0] may not have both configMapRef and secretRef")
+
+	err = validate(strings.Replace(envSets, "        - values:", "        - prefix: VALUES_\n          values:", 1))
+	require.ErrorContains(t, err, "templates.main.envSets[1].prefix is only valid with a configMapRef or secretRef")
+}
+
+var workflowMetadata = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: workflow-metadata-
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: team
+        value: platform
+  workflowMetadata:
+    labels:
+      app: my-app
+    labelsFrom:
+      team:
+        expression: workflow.parameters.team
+    annotationsFrom:
+      example.com/team:
+        expression: workflow.parameters.team
+    propagateToPods: true
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestWorkflowMetadata(t *testing.T) {
+	err := validate(workflowMetadata)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(workflowMetadata, "app: my-app", "app: my app", 1))
+	require.ErrorContains(t, err, "workflowMetadata.labels: invalid label value \"my app\" for label \"app\"")
+
+	err = validate(strings.Replace(workflowMetadata, "example.com/team:", "example.com/my team:", 1))
+	require.ErrorContains(t, err, "workflowMetadata.annotationsFrom key \"example.com/my team\" is invalid")
+
+	err = validate(strings.Replace(workflowMetadata, "expression: workflow.parameters.team\n    propagateToPods", "expression: workflow.parameters.team +\n    propagateToPods", 1))
+	require.ErrorContains(t, err, "workflowMetadata.annotationsFrom.example.com/team.expression is invalid")
+
+	err = validate(strings.Replace(workflowMetadata, "expression: workflow.parameters.team\n    annotationsFrom", "expression: \"\"\n    annotationsFrom", 1))
+	require.ErrorContains(t, err, "workflowMetadata.labelsFrom.team.expression is required")
+}
+
+var s3SelectArtifact = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: s3-select-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      inputs:
+        artifacts:
+          - name: data
+            path: /tmp/data.csv
+            s3:
+              key: data.csv
+              select:
+                expression: SELECT * FROM S3Object
+                input:
+                  csv: {}
+      outputs:
+        artifacts:
+          - name: result
+            path: /tmp/result.csv
+            s3:
+              key: result.csv
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestS3Select(t *testing.T) {
+	err := validate(s3SelectArtifact)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(s3SelectArtifact, "                  csv: {}\n", "                  csv: {}\n                  json:\n                    type: LINES\n", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.data.s3.select.input must have exactly one of csv, json, or parquet")
+
+	err = validate(strings.Replace(s3SelectArtifact, "              key: result.csv\n", "              key: result.csv\n              select:\n                expression: SELECT * FROM S3Object\n", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.result.s3.select is only valid for input artifacts")
+}
+
+var gitSparseArtifact = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: git-sparse-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      inputs:
+        artifacts:
+          - name: src
+            path: /src
+            git:
+              repo: https://github.com/argoproj/argo-workflows.git
+              filter: blob:none
+              sparsePaths:
+                - docs
+                - examples/cron
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestGitSparseCheckout(t *testing.T) {
+	err := validate(gitSparseArtifact)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(gitSparseArtifact, "filter: blob:none", "filter: blob:limit=1m", 1))
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(gitSparseArtifact, "filter: blob:none", "filter: garbage", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.src.git.filter 'garbage' must be one of")
+
+	err = validate(strings.Replace(gitSparseArtifact, "- examples/cron", "- ../examples", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.src.git.sparsePaths '../examples' must be a path relative to the root of the repository")
+
+	err = validate(strings.Replace(gitSparseArtifact, "- examples/cron", "- /examples", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.src.git.sparsePaths '/examples' must be a path relative to the root of the repository")
+}
+
+var httpArtifactWithRetry = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: http-retry-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      inputs:
+        artifacts:
+          - name: kubectl
+            path: /bin/kubectl
+            http:
+              url: https://storage.googleapis.com/kubernetes-release/release/v1.8.0/bin/linux/amd64/kubectl
+              sha256: 0cd84d4fbcb0d34cf5a3b3d0a1e8df3dc2d7b3b4a3b09e7a6d8a06fa1d4d1a29
+              retry:
+                limit: 5
+                backoff:
+                  duration: 2s
+                  factor: 2
+                  cap: 30s
+      container:
+        image: debian:9.4
+`
+
+func TestHTTPArtifactRetry(t *testing.T) {
+	err := validate(httpArtifactWithRetry)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(httpArtifactWithRetry, "sha256: 0cd84d", "sha256: xyz", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.kubectl.http.sha256 'xyz4fbcb0d34cf5a3b3d0a1e8df3dc2d7b3b4a3b09e7a6d8a06fa1d4d1a29' must be a hex-encoded SHA256 checksum")
+
+	err = validate(strings.Replace(httpArtifactWithRetry, "limit: 5", "limit: -1", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.kubectl.http.retry.limit must not be negative")
+
+	err = validate(strings.Replace(httpArtifactWithRetry, "duration: 2s", "duration: soon", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.kubectl.http.retry.backoff.duration unable to parse soon as a duration")
+}
+
+var httpOutputArtifactForm = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: http-form-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      outputs:
+        artifacts:
+          - name: report
+            path: /tmp/report.html
+            archive:
+              none: {}
+            http:
+              url: https://artifacts.example.com/upload
+              method: POST
+              form:
+                fileField: report
+                fields:
+                  workflow: "{{workflow.name}}"
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestHTTPOutputArtifactForm(t *testing.T) {
+	err := validate(httpOutputArtifactForm)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(httpOutputArtifactForm, "method: POST", "method: GET", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.report.http.method 'GET' must be one of PUT, POST or PATCH")
+}
+
+var gitOutputArtifact = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: git-output-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      outputs:
+        artifacts:
+          - name: manifests
+            path: /tmp/manifests
+            archive:
+              none: {}
+            git:
+              repo: https://github.com/argoproj/argo-workflows.git
+              branch: main
+              commitMessage: "Update manifests from {{workflow.name}}"
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestGitOutputArtifact(t *testing.T) {
+	err := validate(gitOutputArtifact)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(gitOutputArtifact, "              branch: main\n", "", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.manifests.git.branch is required")
+
+	err = validate(strings.Replace(gitOutputArtifact, "            archive:\n              none: {}\n", "", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.manifests.archive must be none for git artifacts")
+}
+
+var liveness = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: liveness-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      retryStrategy:
+        limit: 2
+      liveness:
+        probe:
+          httpGet:
+            path: /healthz
+            port: 8080
+          periodSeconds: 30
+        failureTimeout: 10m
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestLiveness(t *testing.T) {
+	err := validate(liveness)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(liveness, "failureTimeout: 10m", "failureTimeout: 10", 1))
+	require.ErrorContains(t, err, "templates.main.liveness.failureTimeout '10' must be a positive duration")
+
+	err = validate(strings.Replace(liveness, "        failureTimeout: 10m\n", "", 1))
+	require.ErrorContains(t, err, "templates.main.liveness.failureTimeout is required")
+
+	err = validate(strings.Replace(liveness, "          httpGet:\n            path: /healthz\n            port: 8080\n", "", 1))
+	require.ErrorContains(t, err, "templates.main.liveness.probe must have an exec, httpGet, tcpSocket, or grpc handler")
+
+	err = validate(strings.Replace(liveness, "        image: argoproj/argosay:v2\n", "        image: argoproj/argosay:v2\n        readinessProbe:\n          exec:\n            command: [cat, /tmp/ready]\n", 1))
+	require.ErrorContains(t, err, "templates.main.liveness may not be used with a readinessProbe")
+}
+
+var waitForSidecars = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: wait-for-sidecars-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      waitForSidecars: readinessProbe
+      sidecars:
+        - name: proxy
+          image: envoyproxy/envoy:v1.30.0
+          readinessProbe:
+            httpGet:
+              path: /ready
+              port: 9901
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestWaitForSidecars(t *testing.T) {
+	err := validate(waitForSidecars)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(waitForSidecars, "waitForSidecars: readinessProbe", "waitForSidecars: started", 1))
+	require.ErrorContains(t, err, "templates.main.waitForSidecars 'started' must be readinessProbe")
+
+	err = validate(strings.Replace(waitForSidecars, "            httpGet:\n              path: /ready\n              port: 9901\n", "            exec:\n              command: [cat, /tmp/ready]\n", 1))
+	require.ErrorContains(t, err, "templates.main.sidecars.proxy.readinessProbe must have an httpGet or tcpSocket handler to be waited for")
+}
+
+var checkpoint = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: checkpoint-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      preemptionPolicy: checkpoint
+      checkpoint:
+        artifacts:
+          - name: state
+            path: /tmp/state
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestCheckpoint(t *testing.T) {
+	err := validate(checkpoint)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(checkpoint, "preemptionPolicy: checkpoint", "preemptionPolicy: restart", 1))
+	require.ErrorContains(t, err, "templates.main.preemptionPolicy 'restart' must be checkpoint")
+
+	err = validate(strings.Replace(checkpoint, "      checkpoint:\n        artifacts:\n          - name: state\n            path: /tmp/state\n", "", 1))
+	require.ErrorContains(t, err, "templates.main.checkpoint.artifacts is required by the checkpoint preemption policy")
+
+	err = validate(strings.Replace(checkpoint, "      container:\n", "      inputs:\n        artifacts:\n          - name: state\n            path: /tmp/in\n            optional: true\n      container:\n", 1))
+	require.ErrorContains(t, err, "templates.main.checkpoint.artifacts.state has the name of another input or checkpoint artifact")
+
+	err = validate(strings.Replace(checkpoint, "        artifacts:\n", "        maxRestarts: -1\n        artifacts:\n", 1))
+	require.ErrorContains(t, err, "templates.main.checkpoint.maxRestarts must not be negative")
+}
+
+var prePull = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pre-pull-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      steps:
+        - - name: train
+            template: train
+    - name: train
+      prePull: true
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestPrePull(t *testing.T) {
+	err := validate(prePull)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(prePull, "    - name: main\n", "    - name: main\n      prePull: true\n", 1))
+	require.ErrorContains(t, err, "templates.main.prePull is only valid for container, script, and container set templates")
+}
+
+var outputLimits = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: output-limits-
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      outputLimits:
+        maxResultBytes: 1024
+        maxParameterBytes: 1024
+        maxLogBytes: 1048576
+        policy: KeepFirst
+      script:
+        image: argoproj/argosay:v2
+        command: [sh]
+        source: echo hello
+`
+
+func TestOutputLimits(t *testing.T) {
+	err := validate(outputLimits)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(outputLimits, "maxParameterBytes: 1024", "maxParameterBytes: 0", 1))
+	require.ErrorContains(t, err, "templates.main.outputLimits.maxParameterBytes must be positive")
+
+	err = validate(strings.Replace(outputLimits, "policy: KeepFirst", "policy: KeepMiddle", 1))
+	require.ErrorContains(t, err, "templates.main.outputLimits.policy 'KeepMiddle' must be KeepLast, KeepFirst, or Fail")
+}
+
+var pendingTimeout = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pending-timeout-
+spec:
+  entrypoint: main
+  pendingTimeout: 10m
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestPendingTimeout(t *testing.T) {
+	err := validate(pendingTimeout)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(pendingTimeout, "pendingTimeout: 10m", "pendingTimeout: 0s", 1))
+	require.ErrorContains(t, err, "spec.pendingTimeout must be greater than zero")
+}
+
+var workflowOwner = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: workflow-owner-
+spec:
+  entrypoint: main
+  owner:
+    team: ml-platform
+    email: ml-platform@example.com
+    slackChannel: "#ml-platform-alerts"
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+        args: [echo, "{{workflow.owner.team}} {{workflow.owner.email}} {{workflow.owner.slackChannel}}"]
+`
+
+func TestWorkflowOwner(t *testing.T) {
+	err := validate(workflowOwner)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(workflowOwner, "email: ml-platform@example.com", "email: ml-platform", 1))
+	require.ErrorContains(t, err, "spec.owner.email 'ml-platform' is not a valid email address")
+}
+
+var resourceBudget = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: resource-budget-
+spec:
+  entrypoint: main
+  resourceBudget:
+    limits:
+      cpu: 3600
+      memory: 36000
+    action: Suspend
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestResourceBudget(t *testing.T) {
+	err := validate(resourceBudget)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(resourceBudget, "cpu: 3600", "cpu: 0", 1))
+	require.ErrorContains(t, err, "spec.resourceBudget.limits.cpu must be greater than zero")
+
+	err = validate(strings.Replace(resourceBudget, "action: Suspend", "action: Stop", 1))
+	require.ErrorContains(t, err, "spec.resourceBudget.action 'Stop' must be Terminate or Suspend")
+}
+
+var schedulingWindow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: scheduling-window-
+spec:
+  entrypoint: main
+  schedulingWindow:
+    schedules: ["0 22 * * *"]
+    duration: 8h
+    timezone: America/Los_Angeles
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestSchedulingWindow(t *testing.T) {
+	err := validate(schedulingWindow)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(schedulingWindow, `schedules: ["0 22 * * *"]`, `schedules: []`, 1))
+	require.ErrorContains(t, err, "spec.schedulingWindow.schedules is required")
+
+	err = validate(strings.Replace(schedulingWindow, `"0 22 * * *"`, `"0 25 * * *"`, 1))
+	require.ErrorContains(t, err, "spec.schedulingWindow.schedules '0 25 * * *' is malformed")
+
+	err = validate(strings.Replace(schedulingWindow, "duration: 8h", "duration: 0s", 1))
+	require.ErrorContains(t, err, "spec.schedulingWindow.duration must be greater than zero")
+
+	err = validate(strings.Replace(schedulingWindow, "America/Los_Angeles", "Mars/Olympus_Mons", 1))
+	require.ErrorContains(t, err, "spec.schedulingWindow.timezone 'Mars/Olympus_Mons' is invalid")
+}
+
+var resultDelivery = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: result-delivery-
+spec:
+  entrypoint: main
+  resultDelivery:
+    http:
+      url: https://example.com/callback
+      retry:
+        limit: 5
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestResultDelivery(t *testing.T) {
+	err := validate(resultDelivery)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(resultDelivery, "url: https://example.com/callback", "url: example.com/callback", 1))
+	require.ErrorContains(t, err, "spec.resultDelivery.http.url 'example.com/callback' must be an http or https URL")
+
+	err = validate(strings.Replace(resultDelivery, "      url: https://example.com/callback\n", "", 1))
+	require.ErrorContains(t, err, "spec.resultDelivery.http.url is required")
+}
+
+var retryBackoff = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: retry-backoff
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      retryStrategy:
+        limit: 10
+        backoff:
+          duration: 10s
+          factor: 2
+          jitter: 20
+          maxAttemptDuration: 5m
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestRetryBackoff(t *testing.T) {
+	err := validate(retryBackoff)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(retryBackoff, "jitter: 20", "jitter: 120", 1))
+	require.ErrorContains(t, err, "retryStrategy.backoff.jitter must be a percentage between 0 and 100")
+
+	err = validate(strings.Replace(retryBackoff, "jitter: 20", "jitter: some", 1))
+	require.ErrorContains(t, err, "retryStrategy.backoff.jitter 'some' must be an integer or a variable")
+
+	err = validate(strings.Replace(retryBackoff, "maxAttemptDuration: 5m", "maxAttemptDuration: later", 1))
+	require.ErrorContains(t, err, "retryStrategy.backoff.maxAttemptDuration unable to parse later as a duration")
+
+	err = validate(strings.Replace(retryBackoff, `      container:
+        image: argoproj/argosay:v2`, `      steps:
+        - - name: a
+            template: a
+    - name: a
+      container:
+        image: argoproj/argosay:v2`, 1))
+	require.ErrorContains(t, err, "templates.main.retryStrategy.backoff.maxAttemptDuration is only supported by templates that run a pod, not by Steps templates")
+}
+
+var artifactKeyExpression = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: artifact-key-expression
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+      outputs:
+        artifacts:
+          - name: out
+            path: /tmp/out
+            s3:
+              key: "{{=sprig.trunc(8, workflow.uid)}}/outputs"
+`
+
+func TestArtifactKeyExpression(t *testing.T) {
+	err := validate(artifactKeyExpression)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(artifactKeyExpression, "workflow.uid)", "workflow.uid", 1))
+	require.ErrorContains(t, err, "templates.main.outputs.artifacts.out key: invalid expression {{=sprig.trunc(8, workflow.uid}}")
+
+	err = validate(strings.Replace(artifactKeyExpression, "outputs:", "inputs:", 1))
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(strings.Replace(artifactKeyExpression, "outputs:", "inputs:", 1), "workflow.uid)", "workflow.uid", 1))
+	require.ErrorContains(t, err, "templates.main.inputs.artifacts.out key: invalid expression")
+}
+
+var taskSelector = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: task-selector-
+spec:
+  entrypoint: main
+  taskSelector: canary=true
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: a
+            template: echo
+            labels:
+              canary: "true"
+    - name: echo
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestTaskSelector(t *testing.T) {
+	err := validate(taskSelector)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(taskSelector, "taskSelector: canary=true", "taskSelector: canary in true", 1))
+	require.ErrorContains(t, err, "spec.taskSelector 'canary in true' is invalid")
+
+	err = validate(strings.Replace(taskSelector, `canary: "true"`, `canary: "not a value"`, 1))
+	require.ErrorContains(t, err, `templates.main.tasks.a.labels.canary value "not a value" is invalid`)
+}
+
+var podNameTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pod-name-template-
+  annotations:
+    workflows.argoproj.io/pod-name-template: "{{workflow.name}}-{{template.name}}-{{node.hash}}"
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestPodNameTemplate(t *testing.T) {
+	err := validate(podNameTemplate)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(podNameTemplate, "-{{node.hash}}", "", 1))
+	require.ErrorContains(t, err, "metadata.annotations.workflows.argoproj.io/pod-name-template is invalid")
+}
+
+var mergeStrategy = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: merge-strategy-
+spec:
+  entrypoint: main
+  mergeStrategy: Merge
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+`
+
+func TestMergeStrategy(t *testing.T) {
+	err := validate(mergeStrategy)
+	require.NoError(t, err)
+
+	err = validate(strings.Replace(mergeStrategy, "mergeStrategy: Merge", "mergeStrategy: Append", 1))
+	require.ErrorContains(t, err, "spec.mergeStrategy 'Append' must be Replace or Merge")
+}